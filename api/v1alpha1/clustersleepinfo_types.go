@@ -0,0 +1,66 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSleepInfoLabel is set on the SleepInfos created by a ClusterSleepInfo, to the name of the
+// ClusterSleepInfo.
+const ClusterSleepInfoLabel = "kube-green.stratio.com/cluster-sleepinfo"
+
+// ClusterSleepInfoSpec defines the desired state of ClusterSleepInfo
+type ClusterSleepInfoSpec struct {
+	// NamespaceSelector selects the namespaces where the sleep policy is applied.
+	// An empty selector selects all the namespaces.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// Template is the spec of the SleepInfo created in every selected namespace,
+	// with the name of the ClusterSleepInfo.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Template SleepInfoSpec `json:"template"`
+}
+
+// ClusterSleepInfoStatus defines the observed state of ClusterSleepInfo
+type ClusterSleepInfoStatus struct {
+	// Namespaces where the SleepInfo of the ClusterSleepInfo is applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Namespaces []string `json:"namespaces,omitempty"`
+	// SkippedNamespaces are selected namespaces with a SleepInfo of the same name not created by
+	// the ClusterSleepInfo, which is left untouched.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SkippedNamespaces []string `json:"skippedNamespaces,omitempty"`
+	// ObservedGeneration is the generation of the ClusterSleepInfo applied to the namespaces.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clustersleepinfos,scope=Cluster
+// +operator-sdk:csv:customresourcedefinitions:displayName="ClusterSleepInfo",resources={{SleepInfo,v1alpha1,sleepinfo}}
+
+// ClusterSleepInfo is the Schema for the clustersleepinfos API. It applies the same SleepInfo to
+// all the namespaces selected by its namespaceSelector.
+type ClusterSleepInfo struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterSleepInfoSpec   `json:"spec,omitempty"`
+	Status ClusterSleepInfoStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSleepInfoList contains a list of ClusterSleepInfo
+type ClusterSleepInfoList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSleepInfo `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSleepInfo{}, &ClusterSleepInfoList{})
+}
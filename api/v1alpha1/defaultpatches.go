@@ -1,11 +1,40 @@
 package v1alpha1
 
+import "fmt"
+
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=postgres.stratio.com,resources=pgbouncer;pgcluster,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=hdfs.stratio.com,resources=hdfscluster,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opensearch.stratio.com,resources=oscluster;osdashboardses,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=kafka.stratio.com,resources=kafkacluster,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkas;kafkanodepools;kafkaconnects,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=kibana.k8s.elastic.co,resources=kibanas,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=flink.apache.org,resources=flinkdeployments,verbs=get;list;watch;update;patch
+
+// SleepPatchAnnotation holds the JSON patch applied on sleep to the annotated resource, instead of
+// the patch of its target. On a CustomResourceDefinition, it makes its kind a patch target.
+const SleepPatchAnnotation = "kube-green.stratio.com/sleep-patch"
+
+// WakePatchAnnotation holds the JSON patch applied on wake up to the annotated resource, instead of
+// restoring it. On a CustomResourceDefinition, it applies to all the resources of its kind.
+const WakePatchAnnotation = "kube-green.stratio.com/wake-patch"
+
+// IgnoreOwnerReferencesAnnotation, when "true", patches the annotated resource, or the resources of
+// the annotated CustomResourceDefinition, even when they are managed by another controller.
+const IgnoreOwnerReferencesAnnotation = "kube-green.stratio.com/ignore-owner-references"
+
+// ExcludeAnnotation, when "true", excludes the annotated resource from sleep, in addition to the
+// excludeRef of the SleepInfo. A resource annotated while sleeping is still woken up.
+const ExcludeAnnotation = "kube-green.stratio.com/exclude"
+
+// SleepAtAnnotation is set, preSleepDelay before the sleep, on the resources to sleep, to the RFC3339
+// time of their sleep. It is kept until the next sleep is announced.
+const SleepAtAnnotation = "kube-green.stratio.com/sleep-at"
 
 var DeploymentTarget = PatchTarget{
 	Group: "apps",
@@ -22,6 +51,26 @@ var CronJobTarget = PatchTarget{
 	Kind:  "CronJob",
 }
 
+var JobTarget = PatchTarget{
+	Group: "batch",
+	Kind:  "Job",
+}
+
+var HorizontalPodAutoscalerTarget = PatchTarget{
+	Group: "autoscaling",
+	Kind:  "HorizontalPodAutoscaler",
+}
+
+var KnativeServiceTarget = PatchTarget{
+	Group: "serving.knative.dev",
+	Kind:  "Service",
+}
+
+var ScaledObjectTarget = PatchTarget{
+	Group: "keda.sh",
+	Kind:  "ScaledObject",
+}
+
 var deploymentPatch = Patch{
 	Target: DeploymentTarget,
 	Patch: `
@@ -38,6 +87,18 @@ var statefulSetPatch = Patch{
   value: 0`,
 }
 
+// replicasPatch scales the resources of target to replicas, used instead of the Deployment and
+// StatefulSet patches by the resources matching SleepReplicas
+func replicasPatch(target PatchTarget, replicas int32) Patch {
+	return Patch{
+		Target: target,
+		Patch: fmt.Sprintf(`
+- op: add
+  path: /spec/replicas
+  value: %d`, replicas),
+	}
+}
+
 var cronjobPatch = Patch{
 	Target: CronJobTarget,
 	Patch: `
@@ -46,6 +107,50 @@ var cronjobPatch = Patch{
   value: true`,
 }
 
+// jobPatch suspends the running Jobs: their pods are deleted and the Job keeps its progress until
+// it is resumed. Completed and failed Jobs are not patched.
+var jobPatch = Patch{
+	Target: JobTarget,
+	Patch: `
+- op: add
+  path: /spec/suspend
+  value: true`,
+}
+
+// hpaPatch marks the HorizontalPodAutoscaler as paused by kube-green and lowers minReplicas to 1,
+// the lowest value accepted without the HPAScaleToZero feature gate. With its target at 0 replicas
+// the HPA stops scaling, and the restore patch brings back the original minReplicas on wake up.
+var hpaPatch = Patch{
+	Target: HorizontalPodAutoscalerTarget,
+	Patch: `
+- op: add
+  path: /metadata/annotations/kube-green.stratio.com~1paused
+  value: "true"
+- op: add
+  path: /spec/minReplicas
+  value: 1`,
+}
+
+// scaledObjectPatch pauses the KEDA autoscaling of the target at 0 replicas. Only the ScaledObjects
+// targeting a workload put to sleep are patched.
+var scaledObjectPatch = Patch{
+	Target: ScaledObjectTarget,
+	Patch: `
+- op: add
+  path: /metadata/annotations/autoscaling.keda.sh~1paused-replicas
+  value: "0"`,
+}
+
+// knativeServicePatch lets the autoscaler scale the revisions of a Knative Service to zero; the
+// Deployment created by Knative is owned by the revision, so it is not patched directly.
+var knativeServicePatch = Patch{
+	Target: KnativeServiceTarget,
+	Patch: `
+- op: add
+  path: /spec/template/metadata/annotations/autoscaling.knative.dev~1min-scale
+  value: "0"`,
+}
+
 // EXTENSIÓN: Patches para CRDs personalizados
 
 var PgBouncerTarget = PatchTarget{
@@ -181,3 +286,124 @@ var KafkaclusterWakePatch = Patch{
   path: /metadata/annotations/kafkacluster.stratio.com~1shutdown
   value: "false"`,
 }
+
+var StrimziKafkaTarget = PatchTarget{
+	Group: "kafka.strimzi.io",
+	Kind:  "Kafka",
+}
+
+var StrimziKafkaNodePoolTarget = PatchTarget{
+	Group: "kafka.strimzi.io",
+	Kind:  "KafkaNodePool",
+}
+
+var StrimziKafkaConnectTarget = PatchTarget{
+	Group: "kafka.strimzi.io",
+	Kind:  "KafkaConnect",
+}
+
+// strimziKafkaPatch scales the brokers and the ZooKeeper nodes of a Kafka cluster without node pools.
+// The brokers of the clusters with node pools are scaled through their KafkaNodePools.
+var strimziKafkaPatch = Patch{
+	Target: StrimziKafkaTarget,
+	Patch: `
+- op: replace
+  path: /spec/kafka/replicas
+  value: 0
+- op: replace
+  path: /spec/zookeeper/replicas
+  value: 0`,
+}
+
+var strimziKafkaNodePoolPatch = Patch{
+	Target: StrimziKafkaNodePoolTarget,
+	Patch: `
+- op: replace
+  path: /spec/replicas
+  value: 0`,
+}
+
+var strimziKafkaConnectPatch = Patch{
+	Target: StrimziKafkaConnectTarget,
+	Patch: `
+- op: replace
+  path: /spec/replicas
+  value: 0`,
+}
+
+// strimziWakeStages wake up the node pools and the Kafka clusters, then the applications once the
+// Kafka clusters are ready.
+var strimziWakeStages = []WakeStage{
+	{
+		Name:    "strimzi-node-pools",
+		Targets: []FilterRef{{APIVersion: "kafka.strimzi.io/v1beta2", Kind: StrimziKafkaNodePoolTarget.Kind}},
+	},
+	{
+		Name:         "strimzi-kafka",
+		Targets:      []FilterRef{{APIVersion: "kafka.strimzi.io/v1beta2", Kind: StrimziKafkaTarget.Kind}},
+		WaitForReady: true,
+	},
+	{
+		Name: "strimzi-clients",
+		Targets: []FilterRef{
+			{APIVersion: "kafka.strimzi.io/v1beta2", Kind: StrimziKafkaConnectTarget.Kind},
+			{APIVersion: "apps/v1", Kind: DeploymentTarget.Kind},
+			{APIVersion: "apps/v1", Kind: StatefulSetTarget.Kind},
+		},
+	},
+}
+
+var ElasticsearchTarget = PatchTarget{
+	Group: "elasticsearch.k8s.elastic.co",
+	Kind:  "Elasticsearch",
+}
+
+var KibanaTarget = PatchTarget{
+	Group: "kibana.k8s.elastic.co",
+	Kind:  "Kibana",
+}
+
+// ElasticsearchPatch scales the first nodeSet of an Elasticsearch cluster, every cluster having at
+// least one. The patch applied to each cluster scales all its nodeSets, see NodeSetsPatch.
+var ElasticsearchPatch = Patch{
+	Target: ElasticsearchTarget,
+	Patch:  NodeSetsPatch(1),
+}
+
+var kibanaPatch = Patch{
+	Target: KibanaTarget,
+	Patch: `
+- op: replace
+  path: /spec/count
+  value: 0`,
+}
+
+// NodeSetsPatch returns the patch setting to 0 the count of the first nodeSets of an Elasticsearch cluster
+func NodeSetsPatch(nodeSets int) string {
+	patch := ""
+	for i := 0; i < nodeSets; i++ {
+		patch += fmt.Sprintf(`
+- op: replace
+  path: /spec/nodeSets/%d/count
+  value: 0`, i)
+	}
+	return patch
+}
+
+var FlinkDeploymentTarget = PatchTarget{
+	Group: "flink.apache.org",
+	Kind:  "FlinkDeployment",
+}
+
+// flinkDeploymentPatch suspends the job taking a savepoint, the Flink operator resumes it from the
+// savepoint when the restore patch sets its state back to running
+var flinkDeploymentPatch = Patch{
+	Target: FlinkDeploymentTarget,
+	Patch: `
+- op: add
+  path: /spec/job/state
+  value: suspended
+- op: add
+  path: /spec/job/upgradeMode
+  value: savepoint`,
+}
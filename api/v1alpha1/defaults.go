@@ -0,0 +1,77 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTimeZone is the time zone of the SleepInfos without timeZone
+const DefaultTimeZone = "UTC"
+
+// numericWeekdays matches the weekdays lists of days and ranges, e.g. "1,2,3" or "1-3,5"
+var numericWeekdays = regexp.MustCompile(`^[0-7](-[0-7])?(,[0-7](-[0-7])?)*$`)
+
+// Default sets the defaults of the spec, as the REST API writes them: the time zone, timeZone when
+// empty, the suspension of the Deployments and StatefulSets, and the weekdays, also those of the
+// schedules, in canonical form.
+func (s *SleepInfoSpec) Default(timeZone string) {
+	if s.TimeZone == "" {
+		s.TimeZone = timeZone
+	}
+	if s.SuspendDeployments == nil {
+		s.SuspendDeployments = boolPtr(true)
+	}
+	if s.SuspendStatefulSets == nil {
+		s.SuspendStatefulSets = boolPtr(true)
+	}
+	s.Weekdays = CanonicalWeekdays(s.Weekdays)
+	for i := range s.Schedules {
+		s.Schedules[i].Weekdays = CanonicalWeekdays(s.Schedules[i].Weekdays)
+	}
+}
+
+// CanonicalWeekdays returns the weekdays as a range when they are consecutive, e.g. "1-5" for
+// "1,2,3,4,5", otherwise as the sorted list of days, e.g. "0,5,6" for "5,6,0". The weekdays with
+// names, steps or wildcards, or invalid, are returned as they are.
+func CanonicalWeekdays(weekdays string) string {
+	value := strings.ReplaceAll(weekdays, " ", "")
+	if !numericWeekdays.MatchString(value) {
+		return weekdays
+	}
+	selected := map[int]bool{}
+	for _, part := range strings.Split(value, ",") {
+		start, end, isRange := strings.Cut(part, "-")
+		first, _ := strconv.Atoi(start)
+		last := first
+		if isRange {
+			last, _ = strconv.Atoi(end)
+		}
+		if first > last {
+			return weekdays
+		}
+		for day := first; day <= last; day++ {
+			// 7 is also Sunday
+			selected[day%7] = true
+		}
+	}
+	days := make([]int, 0, len(selected))
+	for day := range selected {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	if days[len(days)-1]-days[0] == len(days)-1 && len(days) > 1 {
+		return fmt.Sprintf("%d-%d", days[0], days[len(days)-1])
+	}
+	values := make([]string, 0, len(days))
+	for _, day := range days {
+		values = append(values, strconv.Itoa(day))
+	}
+	return strings.Join(values, ",")
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
@@ -0,0 +1,52 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalWeekdays(t *testing.T) {
+	tests := map[string]string{
+		"1,2,3,4,5":     "1-5",
+		"1-3,4-5":       "1-5",
+		"5,6,0":         "0,5,6",
+		"5-6,7":         "0,5,6",
+		"0-6":           "0-6",
+		"3":             "3",
+		"1, 2, 3":       "1-3",
+		"3,3":           "3",
+		"1,3,5":         "1,3,5",
+		"*":             "*",
+		"MON-FRI":       "MON-FRI",
+		"1-5/2":         "1-5/2",
+		"5-1":           "5-1",
+		"":              "",
+		"0,1,2,3,4,5,6": "0-6",
+	}
+	for weekdays, expected := range tests {
+		require.Equal(t, expected, CanonicalWeekdays(weekdays), weekdays)
+	}
+}
+
+func TestSleepInfoSpecDefault(t *testing.T) {
+	spec := SleepInfoSpec{Weekdays: "1,2,3,4,5", SleepTime: "20:00"}
+	spec.Default("Europe/Madrid")
+	require.Equal(t, SleepInfoSpec{
+		Weekdays:            "1-5",
+		SleepTime:           "20:00",
+		TimeZone:            "Europe/Madrid",
+		SuspendDeployments:  getPtr(true),
+		SuspendStatefulSets: getPtr(true),
+	}, spec)
+
+	spec = SleepInfoSpec{Weekdays: "0,6", TimeZone: "America/Bogota", SuspendDeployments: getPtr(false)}
+	spec.Default(DefaultTimeZone)
+	require.Equal(t, "America/Bogota", spec.TimeZone)
+	require.False(t, *spec.SuspendDeployments)
+	require.Equal(t, "0,6", spec.Weekdays)
+
+	spec = SleepInfoSpec{Schedules: []WeeklySchedule{{Weekdays: "5,6,0", SleepTime: "20:00", WakeUpTime: "08:00"}}}
+	spec.Default(DefaultTimeZone)
+	require.Equal(t, "0,5,6", spec.Schedules[0].Weekdays)
+}
@@ -0,0 +1,124 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronFields is the number of fields of a standard cron expression:
+// minute, hour, day of month, month and day of week
+const cronFields = 5
+
+// maxNthWeekdayDays bounds the days checked by a nth weekday schedule looking for its next time
+const maxNthWeekdayDays = 5 * 366
+
+// ScheduleSeparator separates the cron expressions of a schedule running at the earliest of them,
+// e.g. the sleeps of a SleepInfo with several schedules
+const ScheduleSeparator = "; "
+
+const (
+	// schedulesCheckedDays is how far ahead the sleeps and wake ups of several schedules are checked
+	schedulesCheckedDays = 5 * 7
+	// maxCheckedScheduleEvents bounds the times checked for a schedule running every few minutes
+	maxCheckedScheduleEvents = 1000
+)
+
+// IsCronExpression returns whether a sleepAt or wakeUpAt is a standard cron expression instead of
+// Hours:Minutes.
+func IsCronExpression(schedule string) bool {
+	return len(strings.Fields(schedule)) == cronFields
+}
+
+// ParseSchedule parses a standard cron expression, optionally prefixed by CRON_TZ or TZ. Its day of
+// week also accepts the nth weekday of the month as weekday#n, e.g. "0 20 * * 6#1" runs at 20:00
+// on the first Saturday of each month. Day of month must then be *. Several expressions joined by
+// ScheduleSeparator run at the earliest of them.
+func ParseSchedule(spec string) (cron.Schedule, error) {
+	if specs := strings.Split(spec, strings.TrimSpace(ScheduleSeparator)); len(specs) > 1 {
+		schedules := earliestSchedules{}
+		for _, spec := range specs {
+			schedule, err := ParseSchedule(strings.TrimSpace(spec))
+			if err != nil {
+				return nil, err
+			}
+			schedules = append(schedules, schedule)
+		}
+		return schedules, nil
+	}
+
+	fields := strings.Fields(spec)
+	timeZone := ""
+	if len(fields) > 0 && (strings.HasPrefix(fields[0], "CRON_TZ=") || strings.HasPrefix(fields[0], "TZ=")) {
+		timeZone, fields = fields[0]+" ", fields[1:]
+	}
+	if len(fields) != cronFields || !strings.Contains(fields[4], "#") {
+		return cron.ParseStandard(spec)
+	}
+	if fields[2] != "*" {
+		return nil, fmt.Errorf("day of month must be * with a nth weekday, got: %s", fields[2])
+	}
+
+	schedules := earliestSchedules{}
+	for _, entry := range strings.Split(fields[4], ",") {
+		weekday, nthValue, isNth := strings.Cut(entry, "#")
+		nth := 0
+		if isNth {
+			var err error
+			//nolint:mnd
+			if nth, err = strconv.Atoi(nthValue); err != nil || nth < 1 || nth > 5 {
+				return nil, fmt.Errorf("nth weekday %s is invalid: must be between 1 and 5", entry)
+			}
+		}
+		schedule, err := cron.ParseStandard(fmt.Sprintf("%s%s %s", timeZone, strings.Join(fields[:4], " "), weekday))
+		if err != nil {
+			return nil, err
+		}
+		specSchedule, ok := schedule.(*cron.SpecSchedule)
+		if !ok {
+			return nil, fmt.Errorf("nth weekday is not supported in %s", spec)
+		}
+		schedules = append(schedules, nthWeekdaySchedule{schedule: specSchedule, nth: nth})
+	}
+	return schedules, nil
+}
+
+// nthWeekdaySchedule runs at the times of its schedule falling on the nth week of the month, or at
+// all of them when nth is 0
+type nthWeekdaySchedule struct {
+	schedule *cron.SpecSchedule
+	nth      int
+}
+
+func (s nthWeekdaySchedule) Next(t time.Time) time.Time {
+	next := s.schedule.Next(t)
+	for i := 0; s.nth > 0 && !next.IsZero(); i++ {
+		if i > maxNthWeekdayDays {
+			return time.Time{}
+		}
+		local := next.In(s.schedule.Location)
+		if (local.Day()-1)/7+1 == s.nth {
+			return next
+		}
+		// Skip the other times of the same day
+		endOfDay := time.Date(local.Year(), local.Month(), local.Day(), 23, 59, 59, 0, s.schedule.Location)
+		next = s.schedule.Next(endOfDay)
+	}
+	return next
+}
+
+// earliestSchedules runs at the earliest next time of its schedules
+type earliestSchedules []cron.Schedule
+
+func (s earliestSchedules) Next(t time.Time) time.Time {
+	var earliest time.Time
+	for _, schedule := range s {
+		if next := schedule.Next(t); !next.IsZero() && (earliest.IsZero() || next.Before(earliest)) {
+			earliest = next
+		}
+	}
+	return earliest
+}
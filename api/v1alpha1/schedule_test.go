@@ -0,0 +1,184 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+	from := time.Date(2026, 3, 10, 12, 0, 0, 0, rome)
+
+	tests := []struct {
+		name          string
+		schedule      string
+		expectedNext  []time.Time
+		expectedError string
+	}{
+		{
+			name:         "standard cron expression",
+			schedule:     "CRON_TZ=Europe/Rome 0 20 15 * *",
+			expectedNext: []time.Time{time.Date(2026, 3, 15, 20, 0, 0, 0, rome), time.Date(2026, 4, 15, 20, 0, 0, 0, rome)},
+		},
+		{
+			name:         "first Saturday of the month",
+			schedule:     "CRON_TZ=Europe/Rome 0 20 * * 6#1",
+			expectedNext: []time.Time{time.Date(2026, 4, 4, 20, 0, 0, 0, rome), time.Date(2026, 5, 2, 20, 0, 0, 0, rome)},
+		},
+		{
+			name:         "nth weekdays in a list",
+			schedule:     "CRON_TZ=Europe/Rome 0 20 * * 5#2,6#4",
+			expectedNext: []time.Time{time.Date(2026, 3, 13, 20, 0, 0, 0, rome), time.Date(2026, 3, 28, 20, 0, 0, 0, rome), time.Date(2026, 4, 10, 20, 0, 0, 0, rome)},
+		},
+		{
+			name:         "fifth weekday skips the months without it",
+			schedule:     "CRON_TZ=Europe/Rome */30 8 * * 1#5",
+			expectedNext: []time.Time{time.Date(2026, 3, 30, 8, 0, 0, 0, rome), time.Date(2026, 3, 30, 8, 30, 0, 0, rome), time.Date(2026, 6, 29, 8, 0, 0, 0, rome)},
+		},
+		{
+			name:         "several cron expressions",
+			schedule:     "CRON_TZ=Europe/Rome 0 22 * * 1-5; CRON_TZ=Europe/Rome 0 13 * * 1-5",
+			expectedNext: []time.Time{time.Date(2026, 3, 10, 13, 0, 0, 0, rome), time.Date(2026, 3, 10, 22, 0, 0, 0, rome), time.Date(2026, 3, 11, 13, 0, 0, 0, rome)},
+		},
+		{
+			name:          "several cron expressions with an invalid one",
+			schedule:      "0 22 * * 1-5; 0 20 1 * 6#1",
+			expectedError: "day of month must be * with a nth weekday, got: 1",
+		},
+		{
+			name:          "nth weekday with day of month",
+			schedule:      "0 20 1 * 6#1",
+			expectedError: "day of month must be * with a nth weekday, got: 1",
+		},
+		{
+			name:          "invalid nth weekday",
+			schedule:      "0 20 * * 6#6",
+			expectedError: "nth weekday 6#6 is invalid: must be between 1 and 5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(test.schedule)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			next := from
+			for _, expected := range test.expectedNext {
+				next = schedule.Next(next)
+				require.Equal(t, expected, next.In(rome))
+			}
+		})
+	}
+}
+
+func TestCronExpressionSchedule(t *testing.T) {
+	sleepInfo := SleepInfo{Spec: SleepInfoSpec{
+		SleepTime:  "0  20 * * 6#1",
+		WakeUpTime: "0 8 * * 1#1",
+		TimeZone:   "Europe/Rome",
+	}}
+
+	schedule, err := sleepInfo.GetSleepSchedule()
+	require.NoError(t, err)
+	require.Equal(t, "CRON_TZ=Europe/Rome 0 20 * * 6#1", schedule)
+
+	schedule, err = sleepInfo.GetWakeUpSchedule()
+	require.NoError(t, err)
+	require.Equal(t, "CRON_TZ=Europe/Rome 0 8 * * 1#1", schedule)
+
+	require.True(t, IsCronExpression(sleepInfo.Spec.SleepTime))
+	require.False(t, IsCronExpression("20:00"))
+}
+
+func TestSchedules(t *testing.T) {
+	sleepInfo := SleepInfo{Spec: SleepInfoSpec{
+		Schedules: []WeeklySchedule{
+			{Weekdays: "1-5", SleepTime: "13:00", WakeUpTime: "14:00"},
+			{SleepTime: "0 22 * * 1-5", WakeUpTime: "0 6 * * 1-5"},
+		},
+		TimeZone: "Europe/Rome",
+	}}
+
+	schedule, err := sleepInfo.GetSleepSchedule()
+	require.NoError(t, err)
+	require.Equal(t, "CRON_TZ=Europe/Rome 00 13 * * 1-5; CRON_TZ=Europe/Rome 0 22 * * 1-5", schedule)
+
+	schedule, err = sleepInfo.GetWakeUpSchedule()
+	require.NoError(t, err)
+	require.Equal(t, "CRON_TZ=Europe/Rome 00 14 * * 1-5; CRON_TZ=Europe/Rome 0 6 * * 1-5", schedule)
+	require.True(t, sleepInfo.HasWakeUpSchedule())
+
+	sleepInfo.Spec.Schedules[0].Weekdays = ""
+	_, err = sleepInfo.GetSleepSchedule()
+	require.EqualError(t, err, "schedules[0] is invalid: empty weekdays from SleepInfo configuration")
+}
+
+func TestValidateSchedules(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		schedules     []WeeklySchedule
+		expectedError string
+	}{
+		{
+			name: "weeknights and lunch breaks",
+			schedules: []WeeklySchedule{
+				{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+				{Weekdays: "1-5", SleepTime: "13:00", WakeUpTime: "14:00"},
+			},
+		},
+		{
+			name: "weeknights and weekend",
+			schedules: []WeeklySchedule{
+				{SleepTime: "0 22 * * 1-4", WakeUpTime: "0 6 * * 2-5"},
+				{SleepTime: "0 20 * * 5", WakeUpTime: "0 8 * * 1"},
+			},
+		},
+		{
+			name: "sleeps while asleep",
+			schedules: []WeeklySchedule{
+				{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+				{Weekdays: "1-5", SleepTime: "23:00", WakeUpTime: "23:30"},
+			},
+			expectedError: "schedules[1] sleeps at 2026-03-10T23:00:00Z while schedules[0] keeps the resources asleep",
+		},
+		{
+			name: "sleeps when another one wakes up",
+			schedules: []WeeklySchedule{
+				{Weekdays: "1-5", SleepTime: "13:00", WakeUpTime: "14:00"},
+				{Weekdays: "1-5", SleepTime: "14:00", WakeUpTime: "15:00"},
+			},
+			expectedError: "schedules[0] and schedules[1] both sleep or wake up at 2026-03-10T14:00:00Z",
+		},
+		{
+			name: "wakes up while awake",
+			schedules: []WeeklySchedule{
+				{Weekdays: "1-5", SleepTime: "20:00", WakeUpTime: "08:00"},
+				{Weekdays: "1-5", SleepTime: "0 21 * * 6", WakeUpTime: "09:00"},
+			},
+			expectedError: "schedules[1] wakes up at 2026-03-11T09:00:00Z while the resources are awake since the wake up of schedules[0]",
+		},
+		{
+			name:          "without wake up",
+			schedules:     []WeeklySchedule{{Weekdays: "1-5", SleepTime: "20:00"}},
+			expectedError: "schedules[0] is invalid: sleepAt and wakeUpAt are required",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := SleepInfo{Spec: SleepInfoSpec{Schedules: test.schedules}}.validateSchedules(now)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
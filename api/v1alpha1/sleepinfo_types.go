@@ -5,15 +5,22 @@ Copyright 2021.
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/kube-green/kube-green/internal/patcher"
 
-	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -31,29 +38,87 @@ type FilterRef struct {
 	// MatchLabels which identify the kubernetes resource by labels
 	// +optional
 	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// MatchExpressions which identify the kubernetes resource by label requirements.
+	// Supported operators are In, NotIn, Exists and DoesNotExist.
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // SleepInfoSpec defines the desired state of SleepInfo
 type SleepInfoSpec struct {
 	// Weekdays are in cron notation.
 	//
-	// For example, to configure a schedule from monday to friday, set it to "1-5"
+	// For example, to configure a schedule from monday to friday, set it to "1-5".
+	// Required unless Window or Schedules are set or sleepAt is a cron expression.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Weekdays string `json:"weekdays"`
+	Weekdays string `json:"weekdays,omitempty"`
 	// Hours:Minutes
 	//
 	// Accept cron schedule for both hour and minute.
 	// For example, *:*/2 is set to configure a run every even minute.
+	// It also accepts a standard cron expression, which ignores Weekdays, whose day of week can be
+	// the nth weekday of the month: "0 20 * * 6#1" sleeps on the first Saturday of each month.
+	// Required unless Window or Schedules are set.
+	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	SleepTime string `json:"sleepAt"`
+	SleepTime string `json:"sleepAt,omitempty"`
 	// Hours:Minutes
 	//
 	// Accept cron schedule for both hour and minute.
 	// For example, *:*/2 is set to configure a run every even minute.
+	// It also accepts a standard cron expression, like sleepAt.
 	// It is not required.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	WakeUpTime string `json:"wakeUpAt,omitempty"`
+	// Schedules are several weekly schedules, instead of weekdays, sleepAt and wakeUpAt: e.g. the
+	// weeknights and the whole weekend. Their sleeps and wake ups must alternate, a schedule cannot
+	// sleep while another one keeps the resources asleep.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Schedules []WeeklySchedule `json:"schedules,omitempty"`
+	// SleepDuration is how long the resources sleep, as an alternative to WakeUpTime.
+	// The wake up time is computed from each sleep executed, so it is correct across
+	// daylight saving time changes. For example, 10h or 8h30m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SleepDuration *metav1.Duration `json:"sleepDuration,omitempty"`
+	// PreSleepDelay announces the sleep that long before it: the resources to sleep are annotated
+	// with the time of their sleep, in the SleepAtAnnotation, giving long-running requests and batch
+	// jobs time to finish before their replicas are patched. For example, 15m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PreSleepDelay *metav1.Duration `json:"preSleepDelay,omitempty"`
+	// PreSleepHooks are run in order before the sleep patches, e.g. to flush caches or notify an
+	// application to checkpoint. The sleep waits for the Jobs of the hooks, and is aborted by a
+	// failed hook unless its failurePolicy is Continue.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PreSleepHooks []Hook `json:"preSleepHooks,omitempty"`
+	// PostWakeHooks are run in order once the wake up is complete, after its last wake stage, e.g. to
+	// warm caches, register consumers again or run smoke tests. A failed hook skips the next ones
+	// unless its failurePolicy is Continue.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PostWakeHooks []Hook `json:"postWakeHooks,omitempty"`
+	// AlertSilence configures the Alertmanager silence created by each sleep, when the manager has an
+	// Alertmanager configured, and expired once the wake up is complete. By default it silences the
+	// alerts of the namespace of the SleepInfo.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AlertSilence *AlertSilence `json:"alertSilence,omitempty"`
+	// NodeScaleDown releases the nodes of a dedicated node pool left without workloads by the sleep,
+	// when the manager has node scale-down enabled. They are restored before the wake up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeScaleDown *NodeScaleDown `json:"nodeScaleDown,omitempty"`
+	// Karpenter scales the limits of Karpenter NodePools to zero during the sleep, and restores them
+	// shortly before the wake up so that their capacity is provisioned for its wake stages.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Karpenter *Karpenter `json:"karpenter,omitempty"`
 	// Time zone to set the schedule, in IANA time zone identifier.
 	// It is not required, default to UTC.
 	// For example, for the Italy time zone set Europe/Rome.
@@ -119,10 +184,100 @@ type SleepInfoSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SuspendStatefulSetsKafka *bool `json:"suspendStatefulSetsKafka,omitempty"`
+	// If SuspendKnative is set to true, on sleep all Knative Services (serving.knative.dev) in the namespace
+	// will be managed by setting the autoscaling.knative.dev/min-scale annotation of their revision template to 0.
+	// The original value is restored on wake up.
+	// Defaults to false (does not manage Knative Services).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendKnative *bool `json:"suspendKnative,omitempty"`
+	// If SuspendHPA is set to true, on sleep all HorizontalPodAutoscalers in the namespace are annotated
+	// with kube-green.stratio.com/paused and their minReplicas is lowered to 1, so they do not scale
+	// up again the workloads put to sleep. The original values are restored on wake up.
+	// Defaults to false (does not manage HorizontalPodAutoscalers).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendHPA *bool `json:"suspendHPA,omitempty"`
+	// If SuspendKEDA is set to true, on sleep the KEDA ScaledObjects (keda.sh) targeting a workload put
+	// to sleep are annotated with autoscaling.keda.sh/paused-replicas=0, so KEDA does not scale it up
+	// again. The annotation is removed on wake up.
+	// Defaults to false (does not manage ScaledObjects).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendKEDA *bool `json:"suspendKEDA,omitempty"`
+	// If SuspendStrimzi is set to true, on sleep the Strimzi (kafka.strimzi.io) KafkaNodePools and
+	// KafkaConnects are scaled to 0 replicas, as the kafka and zookeeper replicas of the Kafka clusters
+	// without node pools. The original replicas are restored on wake up. Without wakeStages, the
+	// Kafka clusters wake up first and the Deployments, StatefulSets and KafkaConnects wait for them
+	// to be ready.
+	// Defaults to false (does not manage Strimzi resources).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendStrimzi *bool `json:"suspendStrimzi,omitempty"`
+	// If SuspendECK is set to true, on sleep the count of every nodeSet of the Elastic (ECK)
+	// Elasticsearch clusters and the count of the Kibana instances are set to 0, even when they are
+	// owned by another controller. The original counts are restored on wake up.
+	// Defaults to false (does not manage Elasticsearch and Kibana).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendECK *bool `json:"suspendECK,omitempty"`
+	// If SuspendFlink is set to true, on sleep the jobs of the Apache Flink FlinkDeployments are
+	// suspended taking a savepoint, and they are resumed from it on wake up.
+	// Defaults to false (does not manage FlinkDeployments).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SuspendFlink *bool `json:"suspendFlink,omitempty"`
+	// JobPolicy is what happens on sleep to the Jobs of the namespace still running: suspend sets
+	// spec.suspend on them and resumes them on wake up, letFinish (the default) leaves them running.
+	// Jobs created by a CronJob are managed with their CronJob.
+	// +optional
+	// +kubebuilder:validation:Enum=letFinish;suspend
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	JobPolicy JobPolicy `json:"jobPolicy,omitempty"`
+	// SleepReplicas keeps some replicas of the matching Deployments and StatefulSets during sleep
+	// instead of scaling them to zero. The first matching entry is used, and the original replicas
+	// are restored on wake up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SleepReplicas []SleepReplicas `json:"sleepReplicas,omitempty"`
+	// WakeStages wakes up the resources in sequence instead of all at once: the resources matching a
+	// stage are woken up once its delay after the wake up time is over, the others at the wake up time.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WakeStages []WakeStage `json:"wakeStages,omitempty"`
+	// WakeUpOnDeletion adds a finalizer so that deleting the SleepInfo while its resources are asleep
+	// first wakes them up with the stored restore patches. Defaults to the --wake-up-on-deletion flag.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WakeUpOnDeletion *bool `json:"wakeUpOnDeletion,omitempty"`
+	// RestorePolicy is what the wake up does to the resources modified since the sleep: Skip (the
+	// default) leaves them as they are, Merge restores them unless the fields changed by the sleep
+	// were modified, Overwrite always restores those fields.
+	// +optional
+	// +kubebuilder:validation:Enum=Skip;Overwrite;Merge
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestorePolicy RestorePolicy `json:"restorePolicy,omitempty"`
 	// Patches is a list of json 6902 patches to apply to the target resources.
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	Patches []Patch `json:"patches,omitempty"`
+	// Suspend, like the one of CronJobs, skips the scheduled sleeps and wake ups until it is set back
+	// to false, keeping the SleepInfo and its restore data. Manual actions still override it.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Suspend *bool `json:"suspend,omitempty"`
+	// ExecuteOnce runs the next scheduled operations once: the SleepInfo is completed after its first
+	// scheduled wake up, or after its first scheduled sleep when it has no wakeUpAt, and skips the
+	// later ones. Manual actions still work.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ExecuteOnce *bool `json:"executeOnce,omitempty"`
+	// DeleteWhenCompleted deletes an ExecuteOnce SleepInfo once it is completed by a wake up, with
+	// the sleep SleepInfo of its pair. SleepInfos completed by a sleep are kept, as their secret
+	// holds the restore patches of the resources asleep.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DeleteWhenCompleted *bool `json:"deleteWhenCompleted,omitempty"`
 	// SuspendScheduleUntil temporarily suspends the cron schedule until the given time.
 	// While suspended, neither sleep nor wake cron triggers will execute.
 	// Manual actions (via kube-green.stratio.com/manual-action annotation) still override the suspension.
@@ -130,6 +285,372 @@ type SleepInfoSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	SuspendScheduleUntil *metav1.Time `json:"suspendScheduleUntil,omitempty"`
+	// Window configures a one-time sleep between two dates instead of the weekly schedule:
+	// weekdays, sleepAt and wakeUpAt are ignored, the namespace sleeps at window.start, wakes up
+	// at window.end and the SleepInfo is deleted once the window is over.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Window *ScheduleWindow `json:"window,omitempty"`
+	// HolidayCalendar lists the holidays the HolidayPolicy applies to. Defaults to the cluster holiday
+	// calendar of the --holiday-calendar-configmap flag.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HolidayCalendar *HolidayCalendar `json:"holidayCalendar,omitempty"`
+	// HolidayPolicy is what the scheduled operations do on the dates of the HolidayCalendar:
+	// skipSleep keeps the resources awake, forceSleep skips the wake up so the resources stay asleep
+	// until the next working day, ignore (the default) runs the schedule as usual.
+	// +optional
+	// +kubebuilder:validation:Enum=ignore;skipSleep;forceSleep
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HolidayPolicy HolidayPolicy `json:"holidayPolicy,omitempty"`
+	// NamespaceSelector, when set, also applies the SleepInfo to the namespaces it selects: a copy
+	// with the same name and spec, without namespaceSelector, is kept in each of them. Creating it,
+	// or changing the selector, requires permission to create SleepInfos in the selected namespaces.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// DryRun computes the resources each operation would patch, sending the patches to the API
+	// server as dry run, and reports them in status.lastDryRun without changing anything.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DryRun *bool `json:"dryRun,omitempty"`
+}
+
+// WeeklySchedule is one of the schedules of a SleepInfo, in its time zone.
+type WeeklySchedule struct {
+	// Weekdays are in cron notation, like the weekdays of the SleepInfo.
+	// Required unless sleepAt and wakeUpAt are cron expressions.
+	// +optional
+	Weekdays string `json:"weekdays,omitempty"`
+	// SleepTime is Hours:Minutes or a standard cron expression, like the sleepAt of the SleepInfo.
+	SleepTime string `json:"sleepAt"`
+	// WakeUpTime is Hours:Minutes or a standard cron expression, like the wakeUpAt of the SleepInfo.
+	WakeUpTime string `json:"wakeUpAt"`
+}
+
+// SleepReplicas is the number of replicas kept during sleep by the Deployments and StatefulSets
+// matching the filter. The matchLabels and matchExpressions must all match.
+type SleepReplicas struct {
+	FilterRef `json:",inline"`
+	// Replicas kept during sleep.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Replicas int32 `json:"replicas"`
+}
+
+// WakeStage wakes up the resources matching one of its targets some time after the wake up.
+type WakeStage struct {
+	// Name of the stage.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Targets are the resources woken up by the stage. The matchLabels and matchExpressions of a
+	// target must all match, and a resource is woken up by the first stage with a matching target.
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Targets []FilterRef `json:"targets"`
+	// Delay of the stage after the wake up time, as a duration such as 5m. Defaults to 0.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Delay string `json:"delay,omitempty"`
+	// If WaitForReady is set to true, the next stage also waits until the resources woken up by this
+	// stage are ready: replicas ready for workloads, Ready condition or running phase for the CRDs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WaitForReady bool `json:"waitForReady,omitempty"`
+	// ReadyTimeout is the longest wait for the resources of the stage to be ready, as a duration such
+	// as 15m. Once over, the next stage starts anyway. Defaults to 10m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ReadyTimeout string `json:"readyTimeout,omitempty"`
+}
+
+// DefaultWakeStageReadyTimeout is the wait for the resources of a stage to be ready when ReadyTimeout is not set
+const DefaultWakeStageReadyTimeout = 10 * time.Minute
+
+// GetDelay returns the delay of the stage after the wake up time
+func (w WakeStage) GetDelay() time.Duration {
+	delay, err := time.ParseDuration(w.Delay)
+	if err != nil {
+		return 0
+	}
+	return delay
+}
+
+// GetReadyTimeout returns the longest wait for the resources of the stage to be ready
+func (w WakeStage) GetReadyTimeout() time.Duration {
+	timeout, err := time.ParseDuration(w.ReadyTimeout)
+	if err != nil || timeout <= 0 {
+		return DefaultWakeStageReadyTimeout
+	}
+	return timeout
+}
+
+// matches returns whether the filter applies to a resource of target with the given name and labels
+func (r FilterRef) matches(target PatchTarget, name string, objLabels map[string]string) bool {
+	if r.Kind != "" && r.Kind != target.Kind {
+		return false
+	}
+	if r.APIVersion != "" && !strings.HasPrefix(r.APIVersion, target.Group+"/") {
+		return false
+	}
+	if r.Name != "" && r.Name != name {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: r.MatchLabels, MatchExpressions: r.MatchExpressions})
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objLabels))
+}
+
+// Hook is an HTTP call or a Job run around an operation. Exactly one of HTTP and Job must be set.
+type Hook struct {
+	// Name of the hook, unique among the hooks of the operation.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// HTTP calls an URL, successful with a 2xx response.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HTTP *HTTPHook `json:"http,omitempty"`
+	// Job is created from the template in the namespace of the SleepInfo, successful once complete.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Job *batchv1.JobTemplateSpec `json:"job,omitempty"`
+	// FailurePolicy is what the operation does when the hook fails: Abort (the default) skips it,
+	// or the next post-wake hooks, Continue executes it anyway.
+	// +optional
+	// +kubebuilder:validation:Enum=Abort;Continue
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+	// Timeout of the hook, as a duration such as 2m. Defaults to 30s for HTTP calls and 10m for Jobs.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Timeout string `json:"timeout,omitempty"`
+	// If WaitForReady is set to true, the hook waits until the resources woken up are ready, for up
+	// to 10m, before it runs. Only for postWakeHooks.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WaitForReady bool `json:"waitForReady,omitempty"`
+}
+
+// AlertSilence is the Alertmanager silence of the alerts of the resources asleep.
+type AlertSilence struct {
+	// If Disabled is set to true, the alerts are not silenced during the sleep.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Disabled bool `json:"disabled,omitempty"`
+	// Matchers of the alerts silenced, all of them must match. Defaults to the namespace label of the
+	// alerts equal to the namespace of the SleepInfo.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Matchers []AlertMatcher `json:"matchers,omitempty"`
+}
+
+// AlertMatcher matches a label of the alerts.
+type AlertMatcher struct {
+	// Name of the label.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+	// Value of the label, a regular expression with the =~ and !~ operators.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Value string `json:"value"`
+	// Operator comparing the label with the value: = (the default), !=, =~ or !~.
+	// +optional
+	// +kubebuilder:validation:Enum="=";"!=";"=~";"!~"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Operator string `json:"operator,omitempty"`
+}
+
+// Operators of an AlertMatcher
+const (
+	AlertMatchEqual     = "="
+	AlertMatchNotEqual  = "!="
+	AlertMatchRegexp    = "=~"
+	AlertMatchNotRegexp = "!~"
+)
+
+// IsRegexp returns true if the matcher value is a regular expression
+func (m AlertMatcher) IsRegexp() bool {
+	return m.Operator == AlertMatchRegexp || m.Operator == AlertMatchNotRegexp
+}
+
+// IsEqual returns true if the matcher selects the alerts matching the value, false for the negative operators
+func (m AlertMatcher) IsEqual() bool {
+	return m.Operator != AlertMatchNotEqual && m.Operator != AlertMatchNotRegexp
+}
+
+// NodeScaleDownAction is how the nodes left without workloads are released.
+// +kubebuilder:validation:Enum=Cordon;Taint;ScaleDown
+type NodeScaleDownAction string
+
+const (
+	// NodeScaleDownCordon marks the nodes unschedulable.
+	NodeScaleDownCordon NodeScaleDownAction = "Cordon"
+	// NodeScaleDownTaint adds the NodeAsleepTaint NoSchedule taint to the nodes.
+	NodeScaleDownTaint NodeScaleDownAction = "Taint"
+	// NodeScaleDownScaleDown removes the scale-down-disabled annotation of cluster-autoscaler from the
+	// nodes, so it removes them.
+	NodeScaleDownScaleDown NodeScaleDownAction = "ScaleDown"
+)
+
+// NodeAsleepTaint is the key of the taint of the nodes released by the NodeScaleDownTaint action
+const NodeAsleepTaint = "kube-green.com/asleep"
+
+// NodeScaleDown releases the nodes of a dedicated node pool left without workloads by the sleep.
+type NodeScaleDown struct {
+	// NodeSelector selects the nodes of the dedicated node pool by their labels.
+	// +kubebuilder:validation:MinProperties=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Action on the nodes without workloads, other than the DaemonSet and static pods: Cordon (the
+	// default), Taint or ScaleDown.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Action NodeScaleDownAction `json:"action,omitempty"`
+}
+
+// GetAction returns the action on the nodes, Cordon by default
+func (n NodeScaleDown) GetAction() NodeScaleDownAction {
+	if n.Action == "" {
+		return NodeScaleDownCordon
+	}
+	return n.Action
+}
+
+// DefaultKarpenterRestoreBefore is how long before the wake up the NodePool limits are restored by default
+const DefaultKarpenterRestoreBefore = 5 * time.Minute
+
+// Karpenter scales the limits of Karpenter NodePools to zero during the sleep.
+type Karpenter struct {
+	// NodePools are the names of the Karpenter NodePools.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	NodePools []string `json:"nodePools"`
+	// RestoreBefore is how long before the wake up the limits of the NodePools are restored. Defaults
+	// to 5m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RestoreBefore *metav1.Duration `json:"restoreBefore,omitempty"`
+}
+
+// GetRestoreBefore returns how long before the wake up the limits of the NodePools are restored
+func (k Karpenter) GetRestoreBefore() time.Duration {
+	if k.RestoreBefore == nil {
+		return DefaultKarpenterRestoreBefore
+	}
+	return k.RestoreBefore.Duration
+}
+
+// HTTPHook is the HTTP call of a hook.
+type HTTPHook struct {
+	// URL called, http or https.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	URL string `json:"url"`
+	// Method of the request. Defaults to POST.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Method string `json:"method,omitempty"`
+	// Headers of the request.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Headers map[string]string `json:"headers,omitempty"`
+	// Body of the request.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Body string `json:"body,omitempty"`
+}
+
+// HookFailurePolicy is what an operation does when one of its hooks fails.
+type HookFailurePolicy string
+
+const (
+	HookFailurePolicyAbort    HookFailurePolicy = "Abort"
+	HookFailurePolicyContinue HookFailurePolicy = "Continue"
+)
+
+// Default timeouts of the hooks without timeout
+const (
+	DefaultHTTPHookTimeout = 30 * time.Second
+	DefaultJobHookTimeout  = 10 * time.Minute
+)
+
+// GetTimeout returns the timeout of the hook
+func (h Hook) GetTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(h.Timeout); err == nil && timeout > 0 {
+		return timeout
+	}
+	if h.Job != nil {
+		return DefaultJobHookTimeout
+	}
+	return DefaultHTTPHookTimeout
+}
+
+// IsAbortOnFailure returns true if the operation, or the next post-wake hooks, are skipped when the hook fails
+func (h Hook) IsAbortOnFailure() bool {
+	return h.FailurePolicy != HookFailurePolicyContinue
+}
+
+// JobPolicy is what the sleep does to running Jobs.
+type JobPolicy string
+
+const (
+	JobPolicyLetFinish JobPolicy = "letFinish"
+	JobPolicySuspend   JobPolicy = "suspend"
+)
+
+// RestorePolicy is what the wake up does to the resources modified since the sleep.
+type RestorePolicy string
+
+const (
+	RestorePolicySkip      RestorePolicy = "Skip"
+	RestorePolicyOverwrite RestorePolicy = "Overwrite"
+	RestorePolicyMerge     RestorePolicy = "Merge"
+)
+
+// HolidayPolicy is what the scheduled operations do on holidays.
+type HolidayPolicy string
+
+const (
+	HolidayPolicyIgnore     HolidayPolicy = "ignore"
+	HolidayPolicySkipSleep  HolidayPolicy = "skipSleep"
+	HolidayPolicyForceSleep HolidayPolicy = "forceSleep"
+)
+
+// HolidayCalendar is where the holidays are read from: a ConfigMap or an iCalendar URL.
+type HolidayCalendar struct {
+	// ConfigMap holding the holidays in its `holidays` key, one YYYY-MM-DD date per line.
+	// Text after a # is a comment.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ConfigMap string `json:"configMap,omitempty"`
+	// Namespace of the ConfigMap, the namespace of the SleepInfo if not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
+	// URL of an iCalendar (ICS) feed: every day covered by one of its events is a holiday.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	URL string `json:"url,omitempty"`
+	// TimeZone the holiday dates are in, in IANA time zone identifier.
+	// It defaults to the time zone of the SleepInfo.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ScheduleWindow is a one-time sleep, e.g. a holiday shutdown.
+type ScheduleWindow struct {
+	// Start is when the resources are put to sleep.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Start metav1.Time `json:"start"`
+	// End is when the resources are woken up. It must be after Start.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	End metav1.Time `json:"end"`
 }
 
 type Patch struct {
@@ -177,6 +698,169 @@ type SleepInfoStatus struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Suspended Until"
 	SuspendedUntil *metav1.Time `json:"suspendedUntil,omitempty"`
+	// LastManualOperation records the last operation executed on demand
+	// (kube-green.stratio.com/manual-action annotation) instead of by the schedule.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Manual Operation"
+	LastManualOperation *ManualOperationStatus `json:"lastManualOperation,omitempty"`
+	// LastSleepTime is the time of the last sleep executed successfully.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Sleep Time"
+	LastSleepTime *metav1.Time `json:"lastSleepTime,omitempty"`
+	// LastWakeUpTime is the time of the last wake up executed successfully.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Wake Up Time"
+	LastWakeUpTime *metav1.Time `json:"lastWakeUpTime,omitempty"`
+	// CompletedAt is the time an ExecuteOnce SleepInfo executed its scheduled operations.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Completed At"
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	// CurrentState is Sleeping after a sleep, Awake after a wake up and Transitioning while an
+	// operation is in progress, failed, or has wake stages left.
+	// +optional
+	// +kubebuilder:validation:Enum=Sleeping;Awake;Transitioning
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Current State"
+	CurrentState string `json:"currentState,omitempty"`
+	// SuspendedResourceCounts is the number of resources slept by kind, until they wake up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Suspended Resource Counts"
+	SuspendedResourceCounts map[string]int32 `json:"suspendedResourceCounts,omitempty"`
+	// SavedResources are the CPU and memory requested by the pods of the workloads slept, until they
+	// wake up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Saved Resources"
+	SavedResources corev1.ResourceList `json:"savedResources,omitempty"`
+	// FailedResources are the resources the last operation failed to sleep or wake up, or skipped
+	// because they changed since the sleep.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Failed Resources"
+	FailedResources []FailedResource `json:"failedResources,omitempty"`
+	// LastDryRun reports the resources the last operation of a dryRun SleepInfo would have patched.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last Dry Run"
+	LastDryRun *DryRunStatus `json:"lastDryRun,omitempty"`
+	// Hooks are the results of the hooks of the last operations, while they run and once finished.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Hooks"
+	Hooks []HookStatus `json:"hooks,omitempty"`
+	// AlertSilenceID is the Alertmanager silence of the current sleep, expired once the wake up is complete.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Alert Silence ID"
+	AlertSilenceID string `json:"alertSilenceID,omitempty"`
+	// ScaledDownNodes are the nodes released by the current sleep, restored before the wake up.
+	// +optional
+	// +listType=atomic
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Scaled Down Nodes"
+	ScaledDownNodes []string `json:"scaledDownNodes,omitempty"`
+	// ObservedGeneration is the generation of the SleepInfo last handled by the controller: the
+	// status is up to date with the spec when it equals metadata.generation.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Observed Generation"
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are Ready, false when the schedule cannot be handled, LastOperationSucceeded,
+	// the result of the last sleep or wake up, Degraded, true when it failed on some resources, and
+	// ReconcileSkipped, true while the skip-reconcile annotation stops its reconciliation.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// States of a SleepInfo, reported in status.currentState
+const (
+	StateSleeping      = "Sleeping"
+	StateAwake         = "Awake"
+	StateTransitioning = "Transitioning"
+)
+
+// Condition types of a SleepInfo
+const (
+	ConditionReady                  = "Ready"
+	ConditionLastOperationSucceeded = "LastOperationSucceeded"
+	ConditionDegraded               = "Degraded"
+	ConditionReconcileSkipped       = "ReconcileSkipped"
+)
+
+// FailedResource is a resource an operation failed to sleep or wake up.
+type FailedResource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Reason is the error, or why the resource was skipped.
+	Reason string `json:"reason"`
+}
+
+// DryRunStatus reports the resources an operation of a dryRun SleepInfo would patch.
+type DryRunStatus struct {
+	// OperationType simulated, SLEEP or WAKE_UP.
+	OperationType string `json:"operation"`
+	// ExecutedAt is the time the operation was simulated.
+	ExecutedAt metav1.Time `json:"executedAt"`
+	// ResourceCounts is the number of resources that would be patched by kind.
+	// +optional
+	ResourceCounts map[string]int32 `json:"resourceCounts,omitempty"`
+	// Resources that would be patched, the first 100.
+	// +optional
+	// +listType=atomic
+	Resources []DryRunResource `json:"resources,omitempty"`
+	// FailedResources are the resources whose patch was rejected by the API server, or which would be
+	// skipped.
+	// +optional
+	// +listType=atomic
+	FailedResources []FailedResource `json:"failedResources,omitempty"`
+}
+
+// HookPhase is the phase of a hook run.
+type HookPhase string
+
+const (
+	// HookPhasePending is a hook waiting for the resources woken up to be ready
+	HookPhasePending   HookPhase = "Pending"
+	HookPhaseRunning   HookPhase = "Running"
+	HookPhaseSucceeded HookPhase = "Succeeded"
+	HookPhaseFailed    HookPhase = "Failed"
+)
+
+// HookStatus is the result of a hook run.
+type HookStatus struct {
+	// Name of the hook.
+	Name string `json:"name"`
+	// OperationType the hook is run for, SLEEP or WAKE_UP.
+	OperationType string `json:"operation"`
+	// Phase is Pending, Running, Succeeded or Failed.
+	Phase HookPhase `json:"phase"`
+	// Job created by the hook.
+	// +optional
+	Job string `json:"job,omitempty"`
+	// StartedAt is the time the hook was started, or began to wait for the resources to be ready.
+	StartedAt metav1.Time `json:"startedAt"`
+	// FinishedAt is the time the hook succeeded or failed.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+	// Message is the error of a failed hook.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DryRunResource is a resource a dry run operation would patch.
+type DryRunResource struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// ManualOperationStatus describes an operation triggered on demand.
+type ManualOperationStatus struct {
+	// Action requested, sleep or wake.
+	Action string `json:"action"`
+	// OperationType executed by the controller, SLEEP or WAKE_UP.
+	OperationType string `json:"operation"`
+	// RequestedAt is the time the manual action was requested.
+	// +optional
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty"`
+	// ExecutedAt is the time the controller executed the manual action.
+	ExecutedAt metav1.Time `json:"executedAt"`
 }
 
 // +kubebuilder:object:root=true
@@ -195,16 +879,42 @@ type SleepInfo struct {
 }
 
 func (s SleepInfo) GetSleepSchedule() (string, error) {
+	if len(s.Spec.Schedules) > 0 {
+		return s.getSchedulesOf(func(schedule WeeklySchedule) string { return schedule.SleepTime })
+	}
 	return s.getScheduleFromWeekdayAndTime(s.Spec.SleepTime)
 }
 
 func (s SleepInfo) GetWakeUpSchedule() (string, error) {
+	if len(s.Spec.Schedules) > 0 {
+		return s.getSchedulesOf(func(schedule WeeklySchedule) string { return schedule.WakeUpTime })
+	}
 	if s.Spec.WakeUpTime == "" {
 		return "", nil
 	}
 	return s.getScheduleFromWeekdayAndTime(s.Spec.WakeUpTime)
 }
 
+// HasWakeUpSchedule returns whether the SleepInfo wakes up at a scheduled time, set by wakeUpAt or
+// by its schedules.
+func (s SleepInfo) HasWakeUpSchedule() bool {
+	return s.Spec.WakeUpTime != "" || len(s.Spec.Schedules) > 0
+}
+
+// getSchedulesOf returns the cron expressions of the times of the schedules, joined by
+// ScheduleSeparator so that ParseSchedule runs at the earliest of them.
+func (s SleepInfo) getSchedulesOf(timeOf func(WeeklySchedule) string) (string, error) {
+	schedules := make([]string, 0, len(s.Spec.Schedules))
+	for i, schedule := range s.Spec.Schedules {
+		cronSchedule, err := scheduleFromWeekdayAndTime(schedule.Weekdays, timeOf(schedule), s.Spec.TimeZone)
+		if err != nil {
+			return "", fmt.Errorf("schedules[%d] is invalid: %w", i, err)
+		}
+		schedules = append(schedules, cronSchedule)
+	}
+	return strings.Join(schedules, ScheduleSeparator), nil
+}
+
 func (s SleepInfo) GetIncludeRef() []FilterRef {
 	return s.Spec.IncludeRef
 }
@@ -214,7 +924,18 @@ func (s SleepInfo) GetExcludeRef() []FilterRef {
 }
 
 func (s SleepInfo) getScheduleFromWeekdayAndTime(hourAndMinute string) (string, error) {
-	weekday := s.Spec.Weekdays
+	return scheduleFromWeekdayAndTime(s.Spec.Weekdays, hourAndMinute, s.Spec.TimeZone)
+}
+
+func scheduleFromWeekdayAndTime(weekday, hourAndMinute, timeZone string) (string, error) {
+	if IsCronExpression(hourAndMinute) {
+		schedule := strings.Join(strings.Fields(hourAndMinute), " ")
+		if timeZone != "" {
+			schedule = fmt.Sprintf("CRON_TZ=%s %s", timeZone, schedule)
+		}
+		return schedule, nil
+	}
+
 	if weekday == "" {
 		return "", fmt.Errorf("empty weekdays from SleepInfo configuration")
 	}
@@ -225,8 +946,8 @@ func (s SleepInfo) getScheduleFromWeekdayAndTime(hourAndMinute string) (string,
 		return "", fmt.Errorf("time should be of format HH:mm, actual: %s", hourAndMinute)
 	}
 	schedule := fmt.Sprintf("%s %s * * %s", splittedTime[1], splittedTime[0], weekday)
-	if s.Spec.TimeZone != "" {
-		schedule = fmt.Sprintf("CRON_TZ=%s %s", s.Spec.TimeZone, schedule)
+	if timeZone != "" {
+		schedule = fmt.Sprintf("CRON_TZ=%s %s", timeZone, schedule)
 	}
 	return schedule, nil
 }
@@ -291,6 +1012,89 @@ func (s SleepInfo) IsKafkaToSuspend() bool {
 	return *s.Spec.SuspendStatefulSetsKafka
 }
 
+func (s SleepInfo) IsKnativeToSuspend() bool {
+	if s.Spec.SuspendKnative == nil {
+		return false
+	}
+	return *s.Spec.SuspendKnative
+}
+
+func (s SleepInfo) IsHPAToSuspend() bool {
+	if s.Spec.SuspendHPA == nil {
+		return false
+	}
+	return *s.Spec.SuspendHPA
+}
+
+func (s SleepInfo) IsKEDAToSuspend() bool {
+	if s.Spec.SuspendKEDA == nil {
+		return false
+	}
+	return *s.Spec.SuspendKEDA
+}
+
+func (s SleepInfo) IsStrimziToSuspend() bool {
+	if s.Spec.SuspendStrimzi == nil {
+		return false
+	}
+	return *s.Spec.SuspendStrimzi
+}
+
+func (s SleepInfo) IsECKToSuspend() bool {
+	if s.Spec.SuspendECK == nil {
+		return false
+	}
+	return *s.Spec.SuspendECK
+}
+
+func (s SleepInfo) IsFlinkToSuspend() bool {
+	if s.Spec.SuspendFlink == nil {
+		return false
+	}
+	return *s.Spec.SuspendFlink
+}
+
+func (s SleepInfo) IsJobsToSuspend() bool {
+	return s.Spec.JobPolicy == JobPolicySuspend
+}
+
+// GetSleepDuration returns how long the resources sleep, and 0 when the wake up is not set by a duration.
+func (s SleepInfo) GetSleepDuration() time.Duration {
+	if s.Spec.SleepDuration == nil {
+		return 0
+	}
+	return s.Spec.SleepDuration.Duration
+}
+
+// GetPreSleepDelay returns how long before the sleep its resources are annotated, and 0 when the
+// sleep is not announced.
+func (s SleepInfo) GetPreSleepDelay() time.Duration {
+	if s.Spec.PreSleepDelay == nil {
+		return 0
+	}
+	return s.Spec.PreSleepDelay.Duration
+}
+
+// IsExecuteOnce returns true if the SleepInfo runs its scheduled operations only once.
+func (s SleepInfo) IsExecuteOnce() bool {
+	return s.Spec.ExecuteOnce != nil && *s.Spec.ExecuteOnce
+}
+
+// IsDeleteWhenCompleted returns true if the SleepInfo is deleted once it is completed.
+func (s SleepInfo) IsDeleteWhenCompleted() bool {
+	return s.IsExecuteOnce() && s.Spec.DeleteWhenCompleted != nil && *s.Spec.DeleteWhenCompleted
+}
+
+// IsCompleted returns true if an ExecuteOnce SleepInfo has executed its scheduled operations.
+func (s SleepInfo) IsCompleted() bool {
+	return s.IsExecuteOnce() && s.Status.CompletedAt != nil
+}
+
+// IsDryRun returns true if the operations of the SleepInfo are only simulated.
+func (s SleepInfo) IsDryRun() bool {
+	return s.Spec.DryRun != nil && *s.Spec.DryRun
+}
+
 // IsSuspendedUntil returns true if the schedule is temporarily suspended at the given time.
 // A suspension is active when SuspendScheduleUntil is set and its time is in the future relative to now.
 func (s SleepInfo) IsSuspendedUntil(now time.Time) bool {
@@ -300,6 +1104,74 @@ func (s SleepInfo) IsSuspendedUntil(now time.Time) bool {
 	return now.Before(s.Spec.SuspendScheduleUntil.Time)
 }
 
+// WakeUpFinalizer keeps a deleted SleepInfo with wakeUpOnDeletion until its resources still asleep are
+// woken up. Its secret holds their restore patches, so it must not be deleted before it.
+const WakeUpFinalizer = "kube-green.com/wake-up-on-deletion"
+
+// TargetNamespacesFinalizer keeps a deleted SleepInfo with namespaceSelector until its copies in the
+// selected namespaces are deleted.
+const TargetNamespacesFinalizer = "kube-green.com/target-namespaces"
+
+// SourceNamespaceLabel is set on the copies of a SleepInfo with namespaceSelector, to the namespace
+// of the SleepInfo they are copied from.
+const SourceNamespaceLabel = "kube-green.stratio.com/source-namespace"
+
+// NamespaceSelectorRequesterAnnotation records, as JSON, the user who last set the namespaceSelector of
+// a SleepInfo. The mutating webhook writes it, and the controller copies the SleepInfo only into the
+// selected namespaces where this user can create SleepInfos, including the namespaces labelled later.
+const NamespaceSelectorRequesterAnnotation = "kube-green.com/namespace-selector-requester"
+
+// Requester is the user stored in the NamespaceSelectorRequesterAnnotation
+// +kubebuilder:object:generate=false
+type Requester struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// NamespaceSelectorRequester returns the user who last set the namespaceSelector, false when it is
+// not recorded or cannot be decoded
+func (s SleepInfo) NamespaceSelectorRequester() (Requester, bool) {
+	var requester Requester
+	value, ok := s.Annotations[NamespaceSelectorRequesterAnnotation]
+	if !ok || json.Unmarshal([]byte(value), &requester) != nil || requester.Username == "" {
+		return Requester{}, false
+	}
+	return requester, true
+}
+
+// IsSuspended returns true if the schedule is suspended through spec.suspend.
+func (s SleepInfo) IsSuspended() bool {
+	return s.Spec.Suspend != nil && *s.Spec.Suspend
+}
+
+// SnoozeUntilAnnotation delays only the next scheduled sleep until the RFC3339 time it holds.
+// The controller skips the scheduled sleep, runs it at that time and then removes the annotation.
+const SnoozeUntilAnnotation = "kube-green.stratio.com/snooze-until"
+
+// GetSnoozeUntil returns the time the next sleep is delayed to, and false when there is no valid snooze.
+func (s SleepInfo) GetSnoozeUntil() (time.Time, bool) {
+	value := strings.TrimSpace(s.GetAnnotations()[SnoozeUntilAnnotation])
+	if value == "" {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// SkipReconcileAnnotation set to "true" stops the controller from reconciling the SleepInfo, e.g. while
+// a GitOps tool syncs it: its schedule is not executed and its status is not updated until it is removed.
+// A deleted SleepInfo is still finalized.
+const SkipReconcileAnnotation = "kube-green.stratio.com/skip-reconcile"
+
+// IsReconcileSkipped returns true when the SleepInfo has the SkipReconcileAnnotation set to "true".
+func (s SleepInfo) IsReconcileSkipped() bool {
+	return strings.TrimSpace(s.GetAnnotations()[SkipReconcileAnnotation]) == "true"
+}
+
 func (s SleepInfo) GetPatches() []Patch {
 	patches := []Patch{}
 	if s.IsDeploymentsToSuspend() {
@@ -318,17 +1190,181 @@ func (s SleepInfo) GetPatches() []Patch {
 	if s.IsOsDashboardsToSuspend() {
 		patches = append(patches, OsdashboardsPatch)
 	}
+	if s.IsKnativeToSuspend() {
+		patches = append(patches, knativeServicePatch)
+	}
+	if s.IsHPAToSuspend() {
+		patches = append(patches, hpaPatch)
+	}
+	if s.IsKEDAToSuspend() {
+		patches = append(patches, scaledObjectPatch)
+	}
+	if s.IsJobsToSuspend() {
+		patches = append(patches, jobPatch)
+	}
+	if s.IsStrimziToSuspend() {
+		patches = append(patches, strimziKafkaPatch, strimziKafkaNodePoolPatch, strimziKafkaConnectPatch)
+	}
+	if s.IsECKToSuspend() {
+		patches = append(patches, ElasticsearchPatch, kibanaPatch)
+	}
+	if s.IsFlinkToSuspend() {
+		patches = append(patches, flinkDeploymentPatch)
+	}
 	// NOTA: Patches para PgCluster y HDFSCluster se agregan dinámicamente según operación (SLEEP/WAKE)
 	// en el controller, ya que dependen de la anotación (true para sleep, false para wake)
 	return append(patches, s.Spec.Patches...)
 }
 
+// GetResourcePatch returns the patch to apply to a resource of the target of patch. It is a
+// patch scaling to SleepReplicas instead of zero for the Deployments and StatefulSets matching
+// an entry of SleepReplicas, and patch otherwise.
+func (s SleepInfo) GetResourcePatch(patch Patch, name string, objLabels map[string]string) Patch {
+	if patch != deploymentPatch && patch != statefulSetPatch {
+		return patch
+	}
+	if replicas, ok := s.GetSleepReplicas(patch.Target, name, objLabels); ok {
+		return replicasPatch(patch.Target, replicas)
+	}
+	return patch
+}
+
+// GetSleepReplicas returns the replicas kept during sleep by a resource of target, from the first
+// matching entry of SleepReplicas.
+func (s SleepInfo) GetSleepReplicas(target PatchTarget, name string, objLabels map[string]string) (int32, bool) {
+	for _, sleepReplicas := range s.Spec.SleepReplicas {
+		if sleepReplicas.matches(target, name, objLabels) {
+			return sleepReplicas.Replicas, true
+		}
+	}
+	return 0, false
+}
+
+// IsAlertSilenceEnabled returns true if the alerts are silenced during the sleep, when the manager has
+// an Alertmanager configured
+func (s SleepInfo) IsAlertSilenceEnabled() bool {
+	return s.Spec.AlertSilence == nil || !s.Spec.AlertSilence.Disabled
+}
+
+// GetAlertMatchers returns the matchers of the alert silence, empty for the default matcher
+func (s SleepInfo) GetAlertMatchers() []AlertMatcher {
+	if s.Spec.AlertSilence == nil {
+		return nil
+	}
+	return s.Spec.AlertSilence.Matchers
+}
+
+// GetWakeStages returns the wake stages of the SleepInfo. Without WakeStages, a weekly schedule
+// suspending Strimzi wakes up the Kafka clusters before the applications depending on them.
+func (s SleepInfo) GetWakeStages() []WakeStage {
+	if len(s.Spec.WakeStages) > 0 || !s.IsStrimziToSuspend() || s.IsWindow() {
+		return s.Spec.WakeStages
+	}
+	return strimziWakeStages
+}
+
+// GetWakeStage returns the index of the wake stage of a resource of target, from the first stage
+// with a matching target, or -1 when the resource wakes up at the wake up time.
+func (s SleepInfo) GetWakeStage(target PatchTarget, name string, objLabels map[string]string) int {
+	for i, stage := range s.GetWakeStages() {
+		for _, stageTarget := range stage.Targets {
+			if stageTarget.matches(target, name, objLabels) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// IsWindow returns true if the SleepInfo is a one-time window instead of a weekly schedule.
+func (s SleepInfo) IsWindow() bool {
+	return s.Spec.Window != nil
+}
+
+// GetHolidayPolicy returns the holiday policy, ignore when not set.
+func (s SleepInfo) GetHolidayPolicy() HolidayPolicy {
+	if s.Spec.HolidayPolicy == "" {
+		return HolidayPolicyIgnore
+	}
+	return s.Spec.HolidayPolicy
+}
+
+// GetRestorePolicy returns the restore policy, Skip when not set.
+func (s SleepInfo) GetRestorePolicy() RestorePolicy {
+	if s.Spec.RestorePolicy == "" {
+		return RestorePolicySkip
+	}
+	return s.Spec.RestorePolicy
+}
+
 func (s SleepInfo) Validate(cl client.Client) ([]string, error) {
+	switch s.Spec.JobPolicy {
+	case "", JobPolicyLetFinish, JobPolicySuspend:
+	default:
+		return nil, fmt.Errorf("jobPolicy %s is invalid. Must be one of: letFinish, suspend", s.Spec.JobPolicy)
+	}
+	switch s.Spec.RestorePolicy {
+	case "", RestorePolicySkip, RestorePolicyOverwrite, RestorePolicyMerge:
+	default:
+		return nil, fmt.Errorf("restorePolicy %s is invalid. Must be one of: Skip, Overwrite, Merge", s.Spec.RestorePolicy)
+	}
+	if s.Spec.SleepDuration != nil {
+		if s.Spec.WakeUpTime != "" {
+			return nil, fmt.Errorf("sleepDuration and wakeUpAt are mutually exclusive")
+		}
+		if s.Spec.SleepDuration.Duration <= 0 {
+			return nil, fmt.Errorf("sleepDuration %s is invalid: must be positive", s.Spec.SleepDuration.Duration)
+		}
+	}
+	if err := s.validateSchedules(time.Now()); err != nil {
+		return nil, err
+	}
+	if s.Spec.PreSleepDelay != nil && s.Spec.PreSleepDelay.Duration <= 0 {
+		return nil, fmt.Errorf("preSleepDelay %s is invalid: must be positive", s.Spec.PreSleepDelay.Duration)
+	}
+	if err := validateHooks("preSleepHooks", s.Spec.PreSleepHooks); err != nil {
+		return nil, err
+	}
+	for _, hook := range s.Spec.PreSleepHooks {
+		if hook.WaitForReady {
+			return nil, fmt.Errorf("preSleepHooks %s is invalid: waitForReady is only supported by postWakeHooks", hook.Name)
+		}
+	}
+	if err := validateHooks("postWakeHooks", s.Spec.PostWakeHooks); err != nil {
+		return nil, err
+	}
+	if err := s.validateAlertSilence(); err != nil {
+		return nil, err
+	}
+	if err := s.validateNodeScaleDown(); err != nil {
+		return nil, err
+	}
+	if err := s.validateKarpenter(); err != nil {
+		return nil, err
+	}
+	if s.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(s.Spec.NamespaceSelector); err != nil {
+			return nil, fmt.Errorf("namespaceSelector is invalid: %w", err)
+		}
+	}
+	if err := s.validateSleepReplicas(); err != nil {
+		return nil, err
+	}
+	if err := s.validateWakeStages(); err != nil {
+		return nil, err
+	}
+	if s.IsWindow() {
+		if !s.Spec.Window.End.After(s.Spec.Window.Start.Time) {
+			return nil, fmt.Errorf("window end must be after window start")
+		}
+		return s.validateFilters(cl)
+	}
+
 	schedule, err := s.GetSleepSchedule()
 	if err != nil {
 		return nil, err
 	}
-	if _, err = cron.ParseStandard(schedule); err != nil {
+	if _, err = ParseSchedule(schedule); err != nil {
 		return nil, err
 	}
 
@@ -337,28 +1373,300 @@ func (s SleepInfo) Validate(cl client.Client) ([]string, error) {
 		return nil, err
 	}
 	if schedule != "" {
-		if _, err = cron.ParseStandard(schedule); err != nil {
+		if _, err = ParseSchedule(schedule); err != nil {
 			return nil, err
 		}
 	}
+	if err := s.validateHolidays(); err != nil {
+		return nil, err
+	}
+
+	return s.validateFilters(cl)
+}
+
+// validateSchedules checks that the sleeps and wake ups of the schedules alternate in the
+// schedulesCheckedDays after now, so that each sleep is followed by the wake up of its own schedule.
+func (s SleepInfo) validateSchedules(now time.Time) error {
+	if len(s.Spec.Schedules) == 0 {
+		return nil
+	}
+	if s.Spec.Weekdays != "" || s.Spec.SleepTime != "" || s.Spec.WakeUpTime != "" {
+		return fmt.Errorf("schedules and weekdays, sleepAt or wakeUpAt are mutually exclusive")
+	}
+	if s.Spec.SleepDuration != nil || s.IsWindow() {
+		return fmt.Errorf("schedules and sleepDuration or window are mutually exclusive")
+	}
+
+	type scheduleEvent struct {
+		at       time.Time
+		schedule int
+		sleep    bool
+	}
+	events := []scheduleEvent{}
+	until := now.Add(schedulesCheckedDays * 24 * time.Hour)
+	for i, schedule := range s.Spec.Schedules {
+		if schedule.SleepTime == "" || schedule.WakeUpTime == "" {
+			return fmt.Errorf("schedules[%d] is invalid: sleepAt and wakeUpAt are required", i)
+		}
+		for _, event := range []struct {
+			time  string
+			sleep bool
+		}{{schedule.SleepTime, true}, {schedule.WakeUpTime, false}} {
+			spec, err := scheduleFromWeekdayAndTime(schedule.Weekdays, event.time, s.Spec.TimeZone)
+			if err != nil {
+				return fmt.Errorf("schedules[%d] is invalid: %w", i, err)
+			}
+			cronSchedule, err := ParseSchedule(spec)
+			if err != nil {
+				return fmt.Errorf("schedules[%d] is invalid: %w", i, err)
+			}
+			for t, count := cronSchedule.Next(now), 0; !t.IsZero() && t.Before(until) && count < maxCheckedScheduleEvents; t, count = cronSchedule.Next(t), count+1 {
+				events = append(events, scheduleEvent{at: t, schedule: i, sleep: event.sleep})
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+	for i := 1; i < len(events); i++ {
+		previous, event := events[i-1], events[i]
+		switch {
+		case event.at.Equal(previous.at):
+			return fmt.Errorf("schedules[%d] and schedules[%d] both sleep or wake up at %s", previous.schedule, event.schedule, event.at.Format(time.RFC3339))
+		case event.sleep && previous.sleep:
+			return fmt.Errorf("schedules[%d] sleeps at %s while schedules[%d] keeps the resources asleep", event.schedule, event.at.Format(time.RFC3339), previous.schedule)
+		case !event.sleep && !previous.sleep:
+			return fmt.Errorf("schedules[%d] wakes up at %s while the resources are awake since the wake up of schedules[%d]", event.schedule, event.at.Format(time.RFC3339), previous.schedule)
+		}
+	}
+	return nil
+}
+
+func (s SleepInfo) validateSleepReplicas() error {
+	for i, sleepReplicas := range s.Spec.SleepReplicas {
+		if sleepReplicas.Replicas < 0 {
+			return fmt.Errorf("sleepReplicas %d is invalid: replicas must not be negative", i)
+		}
+		if sleepReplicas.Kind == "" && sleepReplicas.Name == "" && len(sleepReplicas.MatchLabels) == 0 && len(sleepReplicas.MatchExpressions) == 0 {
+			return fmt.Errorf("sleepReplicas %d is invalid. Must have set: kind, name, matchLabels or matchExpressions", i)
+		}
+		if sleepReplicas.Kind != "" && sleepReplicas.Kind != DeploymentTarget.Kind && sleepReplicas.Kind != StatefulSetTarget.Kind {
+			return fmt.Errorf("sleepReplicas %d is invalid: kind must be Deployment or StatefulSet", i)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: sleepReplicas.MatchExpressions}); err != nil {
+			return fmt.Errorf("sleepReplicas %d is invalid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (s SleepInfo) validateWakeStages() error {
+	if len(s.Spec.WakeStages) > 0 && s.IsWindow() {
+		return fmt.Errorf("wakeStages cannot be set with a window")
+	}
+	names := map[string]bool{}
+	var previousDelay time.Duration
+	for i, stage := range s.Spec.WakeStages {
+		if stage.Name == "" {
+			return fmt.Errorf("wakeStages %d is invalid: name is required", i)
+		}
+		if names[stage.Name] {
+			return fmt.Errorf("wakeStages %d is invalid: name %s is duplicated", i, stage.Name)
+		}
+		names[stage.Name] = true
+		if len(stage.Targets) == 0 {
+			return fmt.Errorf("wakeStages %s is invalid: targets are required", stage.Name)
+		}
+		for _, target := range stage.Targets {
+			if target.Kind == "" && target.Name == "" && len(target.MatchLabels) == 0 && len(target.MatchExpressions) == 0 {
+				return fmt.Errorf("wakeStages %s is invalid. Targets must have set: kind, name, matchLabels or matchExpressions", stage.Name)
+			}
+			if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: target.MatchExpressions}); err != nil {
+				return fmt.Errorf("wakeStages %s is invalid: %w", stage.Name, err)
+			}
+		}
+		delay := time.Duration(0)
+		if stage.Delay != "" {
+			var err error
+			if delay, err = time.ParseDuration(stage.Delay); err != nil {
+				return fmt.Errorf("wakeStages %s is invalid: delay %s is not a duration", stage.Name, stage.Delay)
+			}
+		}
+		if delay < 0 {
+			return fmt.Errorf("wakeStages %s is invalid: delay must not be negative", stage.Name)
+		}
+		if delay < previousDelay {
+			return fmt.Errorf("wakeStages %s is invalid: delay must not be lower than the delay of the previous stage", stage.Name)
+		}
+		previousDelay = delay
+		if stage.ReadyTimeout != "" {
+			if !stage.WaitForReady {
+				return fmt.Errorf("wakeStages %s is invalid: readyTimeout needs waitForReady", stage.Name)
+			}
+			if timeout, err := time.ParseDuration(stage.ReadyTimeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("wakeStages %s is invalid: readyTimeout %s is not a positive duration", stage.Name, stage.ReadyTimeout)
+			}
+		}
+	}
+	return nil
+}
+
+// validateHooks validates the hooks of the field
+func validateHooks(field string, hooks []Hook) error {
+	names := map[string]bool{}
+	for i, hook := range hooks {
+		if hook.Name == "" {
+			return fmt.Errorf("%s %d is invalid: name is required", field, i)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("%s %d is invalid: name %s is duplicated", field, i, hook.Name)
+		}
+		names[hook.Name] = true
+		if (hook.HTTP == nil) == (hook.Job == nil) {
+			return fmt.Errorf("%s %s is invalid: exactly one of http and job must be set", field, hook.Name)
+		}
+		if hook.HTTP != nil {
+			hookURL, err := url.Parse(hook.HTTP.URL)
+			if err != nil || (hookURL.Scheme != "http" && hookURL.Scheme != "https") || hookURL.Host == "" {
+				return fmt.Errorf("%s %s is invalid: url %s must be an http or https URL", field, hook.Name, hook.HTTP.URL)
+			}
+		}
+		if hook.Job != nil && len(hook.Job.Spec.Template.Spec.Containers) == 0 {
+			return fmt.Errorf("%s %s is invalid: job template has no containers", field, hook.Name)
+		}
+		switch hook.FailurePolicy {
+		case "", HookFailurePolicyAbort, HookFailurePolicyContinue:
+		default:
+			return fmt.Errorf("%s %s is invalid: failurePolicy %s must be one of: Abort, Continue", field, hook.Name, hook.FailurePolicy)
+		}
+		if hook.Timeout != "" {
+			if timeout, err := time.ParseDuration(hook.Timeout); err != nil || timeout <= 0 {
+				return fmt.Errorf("%s %s is invalid: timeout %s is not a positive duration", field, hook.Name, hook.Timeout)
+			}
+		}
+	}
+	return nil
+}
+
+// alertLabelName is the format of the Prometheus label names
+var alertLabelName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateAlertSilence validates the matchers of the alert silence: as Alertmanager, at least one of
+// them must not match the empty label, so that the silence does not match every alert
+func (s SleepInfo) validateAlertSilence() error {
+	if s.Spec.AlertSilence == nil || len(s.Spec.AlertSilence.Matchers) == 0 {
+		return nil
+	}
+	matchesEmpty := true
+	for i, matcher := range s.Spec.AlertSilence.Matchers {
+		if !alertLabelName.MatchString(matcher.Name) {
+			return fmt.Errorf("alertSilence matcher %d is invalid: name %q is not a valid label name", i, matcher.Name)
+		}
+		matches := func(value string) bool { return matcher.Value == value }
+		switch matcher.Operator {
+		case "", AlertMatchEqual, AlertMatchNotEqual:
+		case AlertMatchRegexp, AlertMatchNotRegexp:
+			re, err := regexp.Compile("^(?:" + matcher.Value + ")$")
+			if err != nil {
+				return fmt.Errorf("alertSilence matcher %s is invalid: %w", matcher.Name, err)
+			}
+			matches = re.MatchString
+		default:
+			return fmt.Errorf("alertSilence matcher %s is invalid: operator %s must be one of: =, !=, =~, !~", matcher.Name, matcher.Operator)
+		}
+		if matches("") != matcher.IsEqual() {
+			matchesEmpty = false
+		}
+	}
+	if matchesEmpty {
+		return fmt.Errorf("alertSilence is invalid: at least one matcher must not match the empty value")
+	}
+	return nil
+}
+
+func (s SleepInfo) validateNodeScaleDown() error {
+	if s.Spec.NodeScaleDown == nil {
+		return nil
+	}
+	if len(s.Spec.NodeScaleDown.NodeSelector) == 0 {
+		return fmt.Errorf("nodeScaleDown is invalid: nodeSelector is required")
+	}
+	if _, err := labels.ValidatedSelectorFromSet(s.Spec.NodeScaleDown.NodeSelector); err != nil {
+		return fmt.Errorf("nodeScaleDown nodeSelector is invalid: %w", err)
+	}
+	switch s.Spec.NodeScaleDown.Action {
+	case "", NodeScaleDownCordon, NodeScaleDownTaint, NodeScaleDownScaleDown:
+	default:
+		return fmt.Errorf("nodeScaleDown action %s is invalid. Must be one of: Cordon, Taint, ScaleDown", s.Spec.NodeScaleDown.Action)
+	}
+	return nil
+}
+
+func (s SleepInfo) validateKarpenter() error {
+	if s.Spec.Karpenter == nil {
+		return nil
+	}
+	if len(s.Spec.Karpenter.NodePools) == 0 {
+		return fmt.Errorf("karpenter is invalid: nodePools is required")
+	}
+	for _, name := range s.Spec.Karpenter.NodePools {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("karpenter nodePool %q is invalid: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	if s.Spec.Karpenter.RestoreBefore != nil && s.Spec.Karpenter.RestoreBefore.Duration < 0 {
+		return fmt.Errorf("karpenter restoreBefore %s is invalid: must not be negative", s.Spec.Karpenter.RestoreBefore.Duration)
+	}
+	return nil
+}
+
+func (s SleepInfo) validateHolidays() error {
+	switch s.Spec.HolidayPolicy {
+	case "", HolidayPolicyIgnore, HolidayPolicySkipSleep, HolidayPolicyForceSleep:
+	default:
+		return fmt.Errorf("holidayPolicy %s is invalid. Must be one of: ignore, skipSleep, forceSleep", s.Spec.HolidayPolicy)
+	}
+	// Without calendar the policy applies to the cluster holiday calendar
+	calendar := s.Spec.HolidayCalendar
+	if calendar == nil {
+		return nil
+	}
+	if (calendar.ConfigMap == "") == (calendar.URL == "") {
+		return fmt.Errorf("holidayCalendar is invalid. Must have set one of: configMap or url")
+	}
+	if calendar.TimeZone != "" {
+		if _, err := time.LoadLocation(calendar.TimeZone); err != nil {
+			return fmt.Errorf("holidayCalendar timeZone is invalid: %w", err)
+		}
+	}
+	return nil
+}
 
+func (s SleepInfo) validateFilters(cl client.Client) ([]string, error) {
 	for _, excludeRef := range s.GetExcludeRef() {
 		if err := isExcludeRefValid(excludeRef); err != nil {
 			return nil, err
 		}
 	}
+	for _, includeRef := range s.GetIncludeRef() {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: includeRef.MatchExpressions}); err != nil {
+			return nil, fmt.Errorf("includeRef is invalid: %w", err)
+		}
+	}
 
 	return s.validatePatches(cl)
 }
 
 func isExcludeRefValid(excludeRef FilterRef) error {
-	if excludeRef.Name == "" && excludeRef.APIVersion == "" && excludeRef.Kind == "" && len(excludeRef.MatchLabels) > 0 {
+	hasLabels := len(excludeRef.MatchLabels) > 0 || len(excludeRef.MatchExpressions) > 0
+	if excludeRef.Name == "" && excludeRef.APIVersion == "" && excludeRef.Kind == "" && hasLabels {
+		if _, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: excludeRef.MatchExpressions}); err != nil {
+			return fmt.Errorf("excludeRef is invalid: %w", err)
+		}
 		return nil
 	}
-	if len(excludeRef.MatchLabels) == 0 && excludeRef.Name != "" && excludeRef.APIVersion != "" && excludeRef.Kind != "" {
+	if !hasLabels && excludeRef.Name != "" && excludeRef.APIVersion != "" && excludeRef.Kind != "" {
 		return nil
 	}
-	return fmt.Errorf(`excludeRef is invalid. Must have set: matchLabels or name,apiVersion and kind fields`)
+	return fmt.Errorf(`excludeRef is invalid. Must have set: matchLabels, matchExpressions or name,apiVersion and kind fields`)
 }
 
 func (s *SleepInfo) validatePatches(cl client.Client) ([]string, error) {
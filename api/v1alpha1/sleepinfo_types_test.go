@@ -2,8 +2,13 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
+
+	"github.com/kube-green/kube-green/internal/patcher"
 
 	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -558,6 +563,194 @@ func TestSleepInfo(t *testing.T) {
 		require.Equal(t, patches, sleepInfo.GetPatches())
 	})
 
+	t.Run("with knative services", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendDeployments:  getPtr(false),
+				SuspendStatefulSets: getPtr(false),
+				SuspendKnative:      getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsKnativeToSuspend())
+		require.Equal(t, []Patch{knativeServicePatch}, sleepInfo.GetPatches())
+
+		t.Run("patch sets min-scale to 0", func(t *testing.T) {
+			patcherFn, err := patcher.New([]byte(knativeServicePatch.Patch))
+			require.NoError(t, err)
+
+			modified, err := patcherFn.Exec([]byte(`{"spec":{"template":{"spec":{}}}}`))
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec":{"template":{"metadata":{"annotations":{"autoscaling.knative.dev/min-scale":"0"}},"spec":{}}}}`, string(modified))
+		})
+
+		t.Run("disabled by default", func(t *testing.T) {
+			require.False(t, SleepInfo{}.IsKnativeToSuspend())
+		})
+	})
+
+	t.Run("with horizontal pod autoscalers", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendHPA: getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsHPAToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, hpaPatch}, sleepInfo.GetPatches())
+
+		t.Run("patch pauses the autoscaler", func(t *testing.T) {
+			patcherFn, err := patcher.New([]byte(hpaPatch.Patch))
+			require.NoError(t, err)
+
+			modified, err := patcherFn.Exec([]byte(`{"metadata":{"name":"api"},"spec":{"minReplicas":3,"maxReplicas":10}}`))
+			require.NoError(t, err)
+			require.JSONEq(t, `{"metadata":{"name":"api","annotations":{"kube-green.stratio.com/paused":"true"}},"spec":{"minReplicas":1,"maxReplicas":10}}`, string(modified))
+		})
+
+		t.Run("disabled by default", func(t *testing.T) {
+			require.False(t, SleepInfo{}.IsHPAToSuspend())
+		})
+	})
+
+	t.Run("with keda scaled objects", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendKEDA: getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsKEDAToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, scaledObjectPatch}, sleepInfo.GetPatches())
+		require.False(t, SleepInfo{}.IsKEDAToSuspend())
+	})
+
+	t.Run("with strimzi", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendStrimzi: getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsStrimziToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, strimziKafkaPatch, strimziKafkaNodePoolPatch, strimziKafkaConnectPatch}, sleepInfo.GetPatches())
+		require.False(t, SleepInfo{}.IsStrimziToSuspend())
+
+		t.Run("wakes up the kafka clusters first", func(t *testing.T) {
+			require.Equal(t, strimziWakeStages, sleepInfo.GetWakeStages())
+			require.Equal(t, 0, sleepInfo.GetWakeStage(StrimziKafkaNodePoolTarget, "brokers", nil))
+			require.Equal(t, 1, sleepInfo.GetWakeStage(StrimziKafkaTarget, "kafka", nil))
+			require.Equal(t, 2, sleepInfo.GetWakeStage(StrimziKafkaConnectTarget, "connect", nil))
+			require.Equal(t, 2, sleepInfo.GetWakeStage(DeploymentTarget, "api", nil))
+			require.Equal(t, -1, sleepInfo.GetWakeStage(CronJobTarget, "report", nil))
+			require.Equal(t, -1, sleepInfo.GetWakeStage(PatchTarget{Group: "example.com", Kind: "Kafka"}, "kafka", nil))
+		})
+
+		t.Run("keeps the wake stages set", func(t *testing.T) {
+			stages := []WakeStage{{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}}}
+			withStages := SleepInfo{Spec: SleepInfoSpec{SuspendStrimzi: getPtr(true), WakeStages: stages}}
+			require.Equal(t, stages, withStages.GetWakeStages())
+			require.Empty(t, SleepInfo{}.GetWakeStages())
+		})
+	})
+
+	t.Run("with eck", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendECK: getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsECKToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, ElasticsearchPatch, kibanaPatch}, sleepInfo.GetPatches())
+		require.False(t, SleepInfo{}.IsECKToSuspend())
+
+		t.Run("scales all the nodeSets", func(t *testing.T) {
+			require.Equal(t, `
+- op: replace
+  path: /spec/nodeSets/0/count
+  value: 0
+- op: replace
+  path: /spec/nodeSets/1/count
+  value: 0`, NodeSetsPatch(2))
+			require.Empty(t, NodeSetsPatch(0))
+		})
+	})
+
+	t.Run("with flink", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SuspendFlink: getPtr(true),
+			},
+		}
+
+		require.True(t, sleepInfo.IsFlinkToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, flinkDeploymentPatch}, sleepInfo.GetPatches())
+		require.False(t, SleepInfo{}.IsFlinkToSuspend())
+	})
+
+	t.Run("with job policy", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				JobPolicy: JobPolicySuspend,
+			},
+		}
+
+		require.True(t, sleepInfo.IsJobsToSuspend())
+		require.Equal(t, []Patch{deploymentPatch, statefulSetPatch, jobPatch}, sleepInfo.GetPatches())
+		require.False(t, SleepInfo{Spec: SleepInfoSpec{JobPolicy: JobPolicyLetFinish}}.IsJobsToSuspend())
+		require.False(t, SleepInfo{}.IsJobsToSuspend())
+	})
+
+	t.Run("with sleep replicas", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				SleepReplicas: []SleepReplicas{
+					{FilterRef: FilterRef{Kind: "Deployment", Name: "gateway"}, Replicas: 1},
+					{FilterRef: FilterRef{MatchLabels: map[string]string{"tier": "edge"}}, Replicas: 2},
+				},
+			},
+		}
+
+		require.Equal(t, replicasPatch(DeploymentTarget, 1), sleepInfo.GetResourcePatch(deploymentPatch, "gateway", nil))
+		require.Equal(t, replicasPatch(StatefulSetTarget, 2), sleepInfo.GetResourcePatch(statefulSetPatch, "cache", map[string]string{"tier": "edge"}))
+		require.Equal(t, replicasPatch(DeploymentTarget, 1), sleepInfo.GetResourcePatch(deploymentPatch, "gateway", map[string]string{"tier": "edge"}), "the first matching entry is used")
+		require.Equal(t, statefulSetPatch, sleepInfo.GetResourcePatch(statefulSetPatch, "gateway", nil))
+		require.Equal(t, deploymentPatch, sleepInfo.GetResourcePatch(deploymentPatch, "api", map[string]string{"tier": "backend"}))
+		require.Equal(t, cronjobPatch, sleepInfo.GetResourcePatch(cronjobPatch, "gateway", nil))
+
+		t.Run("patch scales to the replicas", func(t *testing.T) {
+			patcherFn, err := patcher.New([]byte(replicasPatch(DeploymentTarget, 1).Patch))
+			require.NoError(t, err)
+
+			modified, err := patcherFn.Exec([]byte(`{"spec":{"replicas":4}}`))
+			require.NoError(t, err)
+			require.JSONEq(t, `{"spec":{"replicas":1}}`, string(modified))
+		})
+	})
+
+	t.Run("with wake stages", func(t *testing.T) {
+		sleepInfo := SleepInfo{
+			Spec: SleepInfoSpec{
+				WakeStages: []WakeStage{
+					{Name: "databases", Targets: []FilterRef{{APIVersion: "postgres.stratio.com/v1", Kind: "PgCluster"}}},
+					{Name: "poolers", Targets: []FilterRef{{Kind: "PgBouncer"}, {MatchLabels: map[string]string{"tier": "pooler"}}}, Delay: "5m"},
+					{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}, Delay: "7m"},
+				},
+			},
+		}
+
+		require.Equal(t, 0, sleepInfo.GetWakeStage(PgClusterTarget, "postgres", nil))
+		require.Equal(t, 1, sleepInfo.GetWakeStage(PgBouncerTarget, "pooler", nil))
+		require.Equal(t, 1, sleepInfo.GetWakeStage(DeploymentTarget, "pgbouncer", map[string]string{"tier": "pooler"}), "the first matching stage is used")
+		require.Equal(t, 2, sleepInfo.GetWakeStage(DeploymentTarget, "api", nil))
+		require.Equal(t, -1, sleepInfo.GetWakeStage(CronJobTarget, "report", nil))
+		require.Equal(t, 7*time.Minute, sleepInfo.Spec.WakeStages[2].GetDelay())
+		require.Equal(t, time.Duration(0), sleepInfo.Spec.WakeStages[0].GetDelay())
+		require.Equal(t, DefaultWakeStageReadyTimeout, sleepInfo.Spec.WakeStages[0].GetReadyTimeout())
+		require.Equal(t, 15*time.Minute, WakeStage{WaitForReady: true, ReadyTimeout: "15m"}.GetReadyTimeout())
+	})
+
 	t.Run("PatchTarget", func(t *testing.T) {
 		t.Run("String method", func(t *testing.T) {
 			target := PatchTarget{
@@ -578,6 +771,43 @@ func TestSleepInfo(t *testing.T) {
 			}, target.GroupKind())
 		})
 	})
+
+	t.Run("suspend", func(t *testing.T) {
+		sleepInfo := SleepInfo{}
+		require.False(t, sleepInfo.IsSuspended())
+
+		sleepInfo.Spec.Suspend = getPtr(false)
+		require.False(t, sleepInfo.IsSuspended())
+
+		sleepInfo.Spec.Suspend = getPtr(true)
+		require.True(t, sleepInfo.IsSuspended())
+	})
+
+	t.Run("snooze annotation", func(t *testing.T) {
+		sleepInfo := SleepInfo{}
+		_, ok := sleepInfo.GetSnoozeUntil()
+		require.False(t, ok)
+
+		sleepInfo.Annotations = map[string]string{SnoozeUntilAnnotation: "2025-12-22T21:00:00Z"}
+		until, ok := sleepInfo.GetSnoozeUntil()
+		require.True(t, ok)
+		require.Equal(t, time.Date(2025, 12, 22, 21, 0, 0, 0, time.UTC), until)
+
+		sleepInfo.Annotations[SnoozeUntilAnnotation] = "tomorrow"
+		_, ok = sleepInfo.GetSnoozeUntil()
+		require.False(t, ok)
+	})
+
+	t.Run("skip reconcile annotation", func(t *testing.T) {
+		sleepInfo := SleepInfo{}
+		require.False(t, sleepInfo.IsReconcileSkipped())
+
+		sleepInfo.Annotations = map[string]string{SkipReconcileAnnotation: "false"}
+		require.False(t, sleepInfo.IsReconcileSkipped())
+
+		sleepInfo.Annotations[SkipReconcileAnnotation] = "true"
+		require.True(t, sleepInfo.IsReconcileSkipped())
+	})
 }
 
 func TestValidateSleepInfo(t *testing.T) {
@@ -602,6 +832,31 @@ func TestValidateSleepInfo(t *testing.T) {
 			name:          "fails - without weekdays",
 			expectedError: "empty weekdays from SleepInfo configuration",
 		},
+		{
+			name: "ok - schedules",
+			sleepInfoSpec: SleepInfoSpec{
+				Schedules: []WeeklySchedule{
+					{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+					{Weekdays: "1-5", SleepTime: "13:00", WakeUpTime: "14:00"},
+				},
+			},
+		},
+		{
+			name:          "fails - schedules and sleepAt",
+			expectedError: "schedules and weekdays, sleepAt or wakeUpAt are mutually exclusive",
+			sleepInfoSpec: SleepInfoSpec{
+				SleepTime: "20:00",
+				Schedules: []WeeklySchedule{{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"}},
+			},
+		},
+		{
+			name:          "fails - schedules and sleepDuration",
+			expectedError: "schedules and sleepDuration or window are mutually exclusive",
+			sleepInfoSpec: SleepInfoSpec{
+				SleepDuration: &metav1.Duration{Duration: time.Hour},
+				Schedules:     []WeeklySchedule{{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"}},
+			},
+		},
 		{
 			name:          "fails - without sleep",
 			expectedError: "time should be of format HH:mm, actual: ",
@@ -704,7 +959,7 @@ func TestValidateSleepInfo(t *testing.T) {
 		},
 		{
 			name:          "fails - missing Name in ExcludeRef item",
-			expectedError: `excludeRef is invalid. Must have set: matchLabels or name,apiVersion and kind fields`,
+			expectedError: `excludeRef is invalid. Must have set: matchLabels, matchExpressions or name,apiVersion and kind fields`,
 			sleepInfoSpec: SleepInfoSpec{
 				Weekdays:   "1-5",
 				SleepTime:  "13:15",
@@ -719,7 +974,7 @@ func TestValidateSleepInfo(t *testing.T) {
 		},
 		{
 			name:          "fails - Name and MatchLabels both sets in ExcludeRef item",
-			expectedError: `excludeRef is invalid. Must have set: matchLabels or name,apiVersion and kind fields`,
+			expectedError: `excludeRef is invalid. Must have set: matchLabels, matchExpressions or name,apiVersion and kind fields`,
 			sleepInfoSpec: SleepInfoSpec{
 				Weekdays:  "1-5",
 				SleepTime: "13:15",
@@ -747,6 +1002,78 @@ func TestValidateSleepInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ok - excludeRef only matchExpressions",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "13:15",
+				ExcludeRef: []FilterRef{
+					{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"core"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "fails - invalid operator in ExcludeRef matchExpressions",
+			expectedError: `excludeRef is invalid: "Gt" is not a valid label selector operator`,
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "13:15",
+				ExcludeRef: []FilterRef{
+					{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "replicas", Operator: "Gt", Values: []string{"1"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ok - includeRef matchExpressions",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "13:15",
+				IncludeRef: []FilterRef{
+					{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"core"}},
+							{Key: "critical", Operator: metav1.LabelSelectorOpDoesNotExist},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "fails - invalid operator in IncludeRef matchExpressions",
+			expectedError: `includeRef is invalid: "Gt" is not a valid label selector operator`,
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "13:15",
+				IncludeRef: []FilterRef{
+					{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "replicas", Operator: "Gt", Values: []string{"1"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:          "fails - invalid namespaceSelector",
+			expectedError: `namespaceSelector is invalid: "Gt" is not a valid label selector operator`,
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "13:15",
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "env", Operator: "Gt", Values: []string{"1"}},
+					},
+				},
+			},
+		},
 		{
 			name: "ok - excludeRef Name,ApiVersion,Kind",
 			sleepInfoSpec: SleepInfoSpec{
@@ -761,6 +1088,466 @@ func TestValidateSleepInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ok - window without weekdays and sleep time",
+			sleepInfoSpec: SleepInfoSpec{
+				Window: &ScheduleWindow{
+					Start: metav1.NewTime(time.Date(2024, 12, 24, 18, 0, 0, 0, time.UTC)),
+					End:   metav1.NewTime(time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name:          "fails - window end before start",
+			expectedError: "window end must be after window start",
+			sleepInfoSpec: SleepInfoSpec{
+				Window: &ScheduleWindow{
+					Start: metav1.NewTime(time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)),
+					End:   metav1.NewTime(time.Date(2024, 12, 24, 18, 0, 0, 0, time.UTC)),
+				},
+			},
+		},
+		{
+			name: "ok - holiday calendar from ConfigMap",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:        "1-5",
+				SleepTime:       "20:00",
+				HolidayCalendar: &HolidayCalendar{ConfigMap: "holidays", TimeZone: "Europe/Madrid"},
+				HolidayPolicy:   HolidayPolicyForceSleep,
+			},
+		},
+		{
+			name: "ok - suspend jobs",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				JobPolicy: JobPolicySuspend,
+			},
+		},
+		{
+			name:          "fails - invalid job policy",
+			expectedError: "jobPolicy delete is invalid. Must be one of: letFinish, suspend",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				JobPolicy: "delete",
+			},
+		},
+		{
+			name: "ok - overwrite restore policy",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				RestorePolicy: RestorePolicyOverwrite,
+			},
+		},
+		{
+			name:          "fails - invalid restore policy",
+			expectedError: "restorePolicy Replace is invalid. Must be one of: Skip, Overwrite, Merge",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				RestorePolicy: "Replace",
+			},
+		},
+		{
+			name: "ok - cron expressions without weekdays",
+			sleepInfoSpec: SleepInfoSpec{
+				SleepTime:  "0 20 * * 6#1",
+				WakeUpTime: "0 8 1 * *",
+			},
+		},
+		{
+			name:          "fails - invalid cron expression",
+			expectedError: "nth weekday 6#0 is invalid: must be between 1 and 5",
+			sleepInfoSpec: SleepInfoSpec{
+				SleepTime: "0 20 * * 6#0",
+			},
+		},
+		{
+			name: "ok - sleep duration",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				SleepDuration: &metav1.Duration{Duration: 10 * time.Hour},
+			},
+		},
+		{
+			name:          "fails - sleep duration with wake up",
+			expectedError: "sleepDuration and wakeUpAt are mutually exclusive",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				WakeUpTime:    "08:00",
+				SleepDuration: &metav1.Duration{Duration: 10 * time.Hour},
+			},
+		},
+		{
+			name:          "fails - sleep duration not positive",
+			expectedError: "sleepDuration 0s is invalid: must be positive",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				SleepDuration: &metav1.Duration{},
+			},
+		},
+		{
+			name: "ok - pre sleep delay",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepDelay: &metav1.Duration{Duration: 15 * time.Minute},
+			},
+		},
+		{
+			name:          "fails - pre sleep delay not positive",
+			expectedError: "preSleepDelay -5m0s is invalid: must be positive",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepDelay: &metav1.Duration{Duration: -5 * time.Minute},
+			},
+		},
+		{
+			name: "ok - pre sleep hooks",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				PreSleepHooks: []Hook{
+					{Name: "flush", HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}, Timeout: "1m"},
+					{Name: "checkpoint", FailurePolicy: HookFailurePolicyContinue, Job: &batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "checkpoint", Image: "busybox"}},
+						}}},
+					}},
+				},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook without name",
+			expectedError: "preSleepHooks 0 is invalid: name is required",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook name duplicated",
+			expectedError: "preSleepHooks 1 is invalid: name flush is duplicated",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				PreSleepHooks: []Hook{
+					{Name: "flush", HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}},
+					{Name: "flush", HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}},
+				},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook without http or job",
+			expectedError: "preSleepHooks flush is invalid: exactly one of http and job must be set",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "flush"}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook url invalid",
+			expectedError: "preSleepHooks flush is invalid: url cache/flush must be an http or https URL",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "flush", HTTP: &HTTPHook{URL: "cache/flush"}}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook job without containers",
+			expectedError: "preSleepHooks checkpoint is invalid: job template has no containers",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "checkpoint", Job: &batchv1.JobTemplateSpec{}}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook failure policy invalid",
+			expectedError: "preSleepHooks flush is invalid: failurePolicy Retry must be one of: Abort, Continue",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "flush", FailurePolicy: "Retry", HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook timeout invalid",
+			expectedError: "preSleepHooks flush is invalid: timeout 0s is not a positive duration",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "flush", Timeout: "0s", HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}}},
+			},
+		},
+		{
+			name: "ok - post wake hooks",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				WakeUpTime:    "08:00",
+				PostWakeHooks: []Hook{{Name: "warm-up", WaitForReady: true, HTTP: &HTTPHook{URL: "http://api.my-namespace.svc/warm-up"}}},
+			},
+		},
+		{
+			name:          "fails - post wake hook without http or job",
+			expectedError: "postWakeHooks warm-up is invalid: exactly one of http and job must be set",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				WakeUpTime:    "08:00",
+				PostWakeHooks: []Hook{{Name: "warm-up"}},
+			},
+		},
+		{
+			name:          "fails - pre sleep hook waiting for ready",
+			expectedError: "preSleepHooks flush is invalid: waitForReady is only supported by postWakeHooks",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				PreSleepHooks: []Hook{{Name: "flush", WaitForReady: true, HTTP: &HTTPHook{URL: "https://cache.example.com/flush"}}},
+			},
+		},
+		{
+			name: "ok - alert silence matchers",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				AlertSilence: &AlertSilence{Matchers: []AlertMatcher{
+					{Name: "severity", Value: "info", Operator: AlertMatchNotEqual},
+					{Name: "kubernetes_namespace", Value: "bdadevdat-.*", Operator: AlertMatchRegexp},
+				}},
+			},
+		},
+		{
+			name:          "fails - alert silence matcher with invalid name",
+			expectedError: `alertSilence matcher 0 is invalid: name "app.kubernetes.io/name" is not a valid label name`,
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:     "1-5",
+				SleepTime:    "20:00",
+				AlertSilence: &AlertSilence{Matchers: []AlertMatcher{{Name: "app.kubernetes.io/name", Value: "api"}}},
+			},
+		},
+		{
+			name:          "fails - alert silence matcher with invalid regexp",
+			expectedError: "alertSilence matcher namespace is invalid: error parsing regexp: missing closing ): `^(?:(dev)$`",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:     "1-5",
+				SleepTime:    "20:00",
+				AlertSilence: &AlertSilence{Matchers: []AlertMatcher{{Name: "namespace", Value: "(dev", Operator: AlertMatchRegexp}}},
+			},
+		},
+		{
+			name:          "fails - alert silence matching every alert",
+			expectedError: "alertSilence is invalid: at least one matcher must not match the empty value",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				AlertSilence: &AlertSilence{Matchers: []AlertMatcher{
+					{Name: "severity", Value: "critical", Operator: AlertMatchNotEqual},
+					{Name: "namespace", Value: ".*", Operator: AlertMatchRegexp},
+				}},
+			},
+		},
+		{
+			name: "ok - node scale down",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				NodeScaleDown: &NodeScaleDown{NodeSelector: map[string]string{"node-pool": "bdadevdat"}, Action: NodeScaleDownTaint},
+			},
+		},
+		{
+			name:          "fails - node scale down without node selector",
+			expectedError: "nodeScaleDown is invalid: nodeSelector is required",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				NodeScaleDown: &NodeScaleDown{},
+			},
+		},
+		{
+			name:          "fails - node scale down with invalid action",
+			expectedError: "nodeScaleDown action Drain is invalid. Must be one of: Cordon, Taint, ScaleDown",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				NodeScaleDown: &NodeScaleDown{NodeSelector: map[string]string{"node-pool": "bdadevdat"}, Action: "Drain"},
+			},
+		},
+		{
+			name: "ok - karpenter node pools",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:   "1-5",
+				SleepTime:  "20:00",
+				WakeUpTime: "08:00",
+				Karpenter:  &Karpenter{NodePools: []string{"bdadevdat-apps"}, RestoreBefore: &metav1.Duration{Duration: 10 * time.Minute}},
+			},
+		},
+		{
+			name:          "fails - karpenter with invalid node pool",
+			expectedError: `karpenter nodePool "Apps" is invalid: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				Karpenter: &Karpenter{NodePools: []string{"Apps"}},
+			},
+		},
+		{
+			name: "ok - sleep replicas",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				SleepReplicas: []SleepReplicas{
+					{FilterRef: FilterRef{APIVersion: "apps/v1", Kind: "Deployment", Name: "gateway"}, Replicas: 1},
+				},
+			},
+		},
+		{
+			name:          "fails - sleep replicas without filter",
+			expectedError: "sleepReplicas 0 is invalid. Must have set: kind, name, matchLabels or matchExpressions",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				SleepReplicas: []SleepReplicas{{Replicas: 1}},
+			},
+		},
+		{
+			name:          "fails - sleep replicas of unsupported kind",
+			expectedError: "sleepReplicas 0 is invalid: kind must be Deployment or StatefulSet",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				SleepReplicas: []SleepReplicas{{FilterRef: FilterRef{Kind: "CronJob"}, Replicas: 1}},
+			},
+		},
+		{
+			name:          "fails - negative sleep replicas",
+			expectedError: "sleepReplicas 0 is invalid: replicas must not be negative",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				SleepReplicas: []SleepReplicas{{FilterRef: FilterRef{Name: "gateway"}, Replicas: -1}},
+			},
+		},
+		{
+			name: "ok - wake stages",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				WakeStages: []WakeStage{
+					{Name: "databases", Targets: []FilterRef{{Kind: "StatefulSet"}}, WaitForReady: true, ReadyTimeout: "15m"},
+					{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}, Delay: "5m"},
+				},
+			},
+		},
+		{
+			name:          "fails - wake stage without targets",
+			expectedError: "wakeStages apps is invalid: targets are required",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:   "1-5",
+				SleepTime:  "20:00",
+				WakeStages: []WakeStage{{Name: "apps"}},
+			},
+		},
+		{
+			name:          "fails - duplicated wake stage",
+			expectedError: "wakeStages 1 is invalid: name apps is duplicated",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				WakeStages: []WakeStage{
+					{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}},
+					{Name: "apps", Targets: []FilterRef{{Kind: "StatefulSet"}}},
+				},
+			},
+		},
+		{
+			name:          "fails - invalid wake stage delay",
+			expectedError: "wakeStages apps is invalid: delay 5 minutes is not a duration",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:   "1-5",
+				SleepTime:  "20:00",
+				WakeStages: []WakeStage{{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}, Delay: "5 minutes"}},
+			},
+		},
+		{
+			name:          "fails - ready timeout without wait for ready",
+			expectedError: "wakeStages databases is invalid: readyTimeout needs waitForReady",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:   "1-5",
+				SleepTime:  "20:00",
+				WakeStages: []WakeStage{{Name: "databases", Targets: []FilterRef{{Kind: "StatefulSet"}}, ReadyTimeout: "15m"}},
+			},
+		},
+		{
+			name:          "fails - invalid ready timeout",
+			expectedError: "wakeStages databases is invalid: readyTimeout 0s is not a positive duration",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:   "1-5",
+				SleepTime:  "20:00",
+				WakeStages: []WakeStage{{Name: "databases", Targets: []FilterRef{{Kind: "StatefulSet"}}, WaitForReady: true, ReadyTimeout: "0s"}},
+			},
+		},
+		{
+			name:          "fails - wake stages not in order",
+			expectedError: "wakeStages databases is invalid: delay must not be lower than the delay of the previous stage",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:  "1-5",
+				SleepTime: "20:00",
+				WakeStages: []WakeStage{
+					{Name: "apps", Targets: []FilterRef{{Kind: "Deployment"}}, Delay: "5m"},
+					{Name: "databases", Targets: []FilterRef{{Kind: "StatefulSet"}}, Delay: "1m"},
+				},
+			},
+		},
+		{
+			name:          "fails - invalid holiday policy",
+			expectedError: "holidayPolicy always is invalid. Must be one of: ignore, skipSleep, forceSleep",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:        "1-5",
+				SleepTime:       "20:00",
+				HolidayCalendar: &HolidayCalendar{ConfigMap: "holidays"},
+				HolidayPolicy:   "always",
+			},
+		},
+		{
+			name: "holiday policy without calendar uses the cluster calendar",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:      "1-5",
+				SleepTime:     "20:00",
+				HolidayPolicy: HolidayPolicySkipSleep,
+			},
+		},
+		{
+			name:          "fails - holiday calendar with ConfigMap and url",
+			expectedError: "holidayCalendar is invalid. Must have set one of: configMap or url",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:        "1-5",
+				SleepTime:       "20:00",
+				HolidayCalendar: &HolidayCalendar{ConfigMap: "holidays", URL: "https://example.com/holidays.ics"},
+				HolidayPolicy:   HolidayPolicySkipSleep,
+			},
+		},
+		{
+			name:          "fails - holiday calendar with invalid time zone",
+			expectedError: "holidayCalendar timeZone is invalid: unknown time zone Mars/Olympus",
+			sleepInfoSpec: SleepInfoSpec{
+				Weekdays:        "1-5",
+				SleepTime:       "20:00",
+				HolidayCalendar: &HolidayCalendar{URL: "https://example.com/holidays.ics", TimeZone: "Mars/Olympus"},
+				HolidayPolicy:   HolidayPolicySkipSleep,
+			},
+		},
 		{
 			name: "ok - patches with existent resources",
 			sleepInfoSpec: SleepInfoSpec{
@@ -834,6 +1621,7 @@ func TestValidateSleepInfo(t *testing.T) {
 
 	groupVersion := []schema.GroupVersion{
 		{Group: "apps", Version: "v1"},
+		{Group: "batch", Version: "v1"},
 	}
 	restMapper := meta.NewDefaultRESTMapper(groupVersion)
 	restMapper.Add(schema.GroupVersionKind{
@@ -851,6 +1639,11 @@ func TestValidateSleepInfo(t *testing.T) {
 		Version: "v1",
 		Kind:    "CronJob",
 	}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{
+		Group:   "batch",
+		Version: "v1",
+		Kind:    "Job",
+	}, meta.RESTScopeNamespace)
 
 	for _, test := range tests {
 		s := sleepInfo.DeepCopy()
@@ -874,3 +1667,11 @@ func TestValidateSleepInfo(t *testing.T) {
 func getPtr[T any](item T) *T {
 	return &item
 }
+
+func TestHookTimeout(t *testing.T) {
+	require.Equal(t, DefaultHTTPHookTimeout, Hook{HTTP: &HTTPHook{}}.GetTimeout())
+	require.Equal(t, DefaultJobHookTimeout, Hook{Job: &batchv1.JobTemplateSpec{}}.GetTimeout())
+	require.Equal(t, 2*time.Minute, Hook{Job: &batchv1.JobTemplateSpec{}, Timeout: "2m"}.GetTimeout())
+	require.True(t, Hook{}.IsAbortOnFailure())
+	require.False(t, Hook{FailurePolicy: HookFailurePolicyContinue}.IsAbortOnFailure())
+}
@@ -0,0 +1,76 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SleepInfoStateSpec defines the restore data saved by the sleeps of a SleepInfo
+type SleepInfoStateSpec struct {
+	// SleepInfo is the name of the SleepInfo which saved the state, in the same namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SleepInfo string `json:"sleepInfo"`
+	// SavedAt is when the restore data last changed.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SavedAt *metav1.Time `json:"savedAt,omitempty"`
+	// Targets are the restore patches of the slept resources, by kind.
+	// +optional
+	// +listType=map
+	// +listMapKey=target
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Targets []SleepInfoStateTarget `json:"targets,omitempty"`
+}
+
+// SleepInfoStateTarget is the restore data of the slept resources of a kind
+type SleepInfoStateTarget struct {
+	// Target is the kind of the resources, as kind.group (e.g. Deployment.apps).
+	Target string `json:"target"`
+	// SavedAt is when the patches of the kind last changed.
+	// +optional
+	SavedAt *metav1.Time `json:"savedAt,omitempty"`
+	// Resources are the restore patches of the slept resources of the kind.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Resources []SleepInfoStateResource `json:"resources,omitempty"`
+}
+
+// SleepInfoStateResource is the restore data of a slept resource
+type SleepInfoStateResource struct {
+	// Name of the resource.
+	Name string `json:"name"`
+	// Patch restores the resource as it was before the sleep.
+	// +optional
+	Patch string `json:"patch,omitempty"`
+	// Generation of the resource after the sleep, to detect the resources changed while asleep.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=sleepinfostates
+// +kubebuilder:printcolumn:name="SleepInfo",type=string,JSONPath=`.spec.sleepInfo`
+// +kubebuilder:printcolumn:name="Saved At",type=date,JSONPath=`.spec.savedAt`
+// +operator-sdk:csv:customresourcedefinitions:displayName="SleepInfoState",resources={{SleepInfo,v1alpha1,sleepinfo}}
+
+// SleepInfoState is the Schema for the sleepinfostates API. It stores the restore patches of a
+// SleepInfo, with its name, when the controller runs with --restore-state-crd.
+type SleepInfoState struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SleepInfoStateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SleepInfoStateList contains a list of SleepInfoState
+type SleepInfoStateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SleepInfoState `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SleepInfoState{}, &SleepInfoStateList{})
+}
@@ -0,0 +1,180 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantScheduleLabel is set on the SleepInfos created by a TenantSchedule, to the name of the
+// TenantSchedule.
+const TenantScheduleLabel = "kube-green.stratio.com/tenant-schedule"
+
+// TenantScheduleDelays are the delays of the staggered wake up of the namespaces with datastores,
+// after the wake up time.
+type TenantScheduleDelays struct {
+	// PgHdfsDelay is the delay of the wake up of the datastores (PgCluster, HDFSCluster, ...), e.g. "0m".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PgHdfsDelay string `json:"pgHdfsDelay,omitempty"`
+	// PgbouncerDelay is the delay of the wake up of PgBouncer, e.g. "5m".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	PgbouncerDelay string `json:"pgbouncerDelay,omitempty"`
+	// DeploymentsDelay is the delay of the wake up of the applications, e.g. "7m".
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	DeploymentsDelay string `json:"deploymentsDelay,omitempty"`
+}
+
+// NamespaceFilter selects resources of one namespace of the tenant.
+type NamespaceFilter struct {
+	// Namespace is the suffix of the tenant namespace (e.g. datastores) or its full name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+	// Filter selects the resources of the namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Filter FilterRef `json:"filter"`
+}
+
+// NamespaceSleepReplicas are the replicas kept during sleep by resources of one namespace of the tenant.
+type NamespaceSleepReplicas struct {
+	// Namespace is the suffix of the tenant namespace (e.g. apps) or its full name.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespace string `json:"namespace"`
+	// Filter selects the workloads of the namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Filter FilterRef `json:"filter"`
+	// Replicas kept during sleep by the selected workloads.
+	// +kubebuilder:validation:Minimum=0
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Replicas int32 `json:"replicas"`
+}
+
+// TenantScheduleHolidays is the holiday calendar of a TenantSchedule and what it does on holidays.
+type TenantScheduleHolidays struct {
+	HolidayCalendar `json:",inline"`
+	// Policy is what the schedule does on holidays.
+	// +kubebuilder:validation:Enum=ignore;skipSleep;forceSleep
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Policy HolidayPolicy `json:"policy"`
+}
+
+// TenantScheduleSpec defines the desired state of TenantSchedule. It holds the same schedule as a
+// schedule creation request of the REST API.
+type TenantScheduleSpec struct {
+	// Tenant whose namespaces are put to sleep, named {tenant}-{suffix}.
+	// +kubebuilder:validation:MinLength=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Tenant string `json:"tenant"`
+	// Off is the sleep time, HH:MM in the user time zone. Not used with OffCron.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Off string `json:"off,omitempty"`
+	// On is the wake up time, HH:MM in the user time zone. Not used with Duration.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	On string `json:"on,omitempty"`
+	// OffCron is the cron expression of the sleep in the user time zone, instead of Off and Weekdays.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OffCron string `json:"offCron,omitempty"`
+	// OnCron is the cron expression of the wake up in the user time zone, instead of On.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OnCron string `json:"onCron,omitempty"`
+	// Duration the namespaces stay asleep after Off, instead of On (e.g. 8h or 10h30m).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Duration string `json:"duration,omitempty"`
+	// Weekdays of the schedule, in human ("lunes-viernes") or numeric ("1-5") format. All the days
+	// if not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Weekdays string `json:"weekdays,omitempty"`
+	// SleepDays are the days of the sleep, instead of Weekdays.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SleepDays string `json:"sleepDays,omitempty"`
+	// WakeDays are the days of the wake up, instead of Weekdays.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	WakeDays string `json:"wakeDays,omitempty"`
+	// Namespaces are the suffixes of the tenant namespaces put to sleep (e.g. datastores, apps).
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Namespaces []string `json:"namespaces"`
+	// Delays of the staggered wake up of the namespaces with datastores.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Delays *TenantScheduleDelays `json:"delays,omitempty"`
+	// Description of the schedule.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Description string `json:"description,omitempty"`
+	// Exclusions are resources never put to sleep, added to the exclusions detected automatically.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Exclusions []NamespaceFilter `json:"exclusions,omitempty"`
+	// Inclusions limit the resources of a namespace put to sleep to the ones matching them.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Inclusions []NamespaceFilter `json:"inclusions,omitempty"`
+	// SleepReplicas are the replicas kept during sleep by workloads of the namespaces.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`
+	// Holidays is the holiday calendar of the schedule and what it does on holidays.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Holidays *TenantScheduleHolidays `json:"holidays,omitempty"`
+	// KarpenterNodePools are scaled to zero while asleep, and restored before the wake up.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	KarpenterNodePools []string `json:"karpenterNodePools,omitempty"`
+}
+
+// TenantScheduleStatus defines the observed state of TenantSchedule
+type TenantScheduleStatus struct {
+	// Namespaces where the SleepInfo of the TenantSchedule is applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Error of the last apply of the TenantSchedule, empty when it is applied.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the generation of the TenantSchedule applied to the namespaces.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=tenantschedules,scope=Cluster
+// +kubebuilder:printcolumn:name="Tenant",type=string,JSONPath=`.spec.tenant`
+// +kubebuilder:printcolumn:name="Off",type=string,JSONPath=`.spec.off`
+// +kubebuilder:printcolumn:name="On",type=string,JSONPath=`.spec.on`
+// +operator-sdk:csv:customresourcedefinitions:displayName="TenantSchedule",resources={{SleepInfo,v1alpha1,sleepinfo}}
+
+// TenantSchedule is the Schema for the tenantschedules API. It is the schedule of the namespaces of a
+// tenant, applied as a SleepInfo named as the TenantSchedule in each of them.
+type TenantSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantScheduleSpec   `json:"spec,omitempty"`
+	Status TenantScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantScheduleList contains a list of TenantSchedule
+type TenantScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantSchedule{}, &TenantScheduleList{})
+}
@@ -82,6 +82,78 @@ func TestDeepCopy(t *testing.T) {
 		require.Equal(t, sleepInfoList, sleepInfoList.DeepCopyObject())
 	})
 
+	t.Run("cluster sleep info", func(t *testing.T) {
+		clusterSleepInfoList := &ClusterSleepInfoList{
+			Items: []ClusterSleepInfo{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterSleepInfo",
+						APIVersion: "kube-green.com/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "name",
+					},
+					Spec: ClusterSleepInfoSpec{
+						NamespaceSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "dev"},
+						},
+						Template: SleepInfoSpec{
+							Weekdays:  "1-5",
+							SleepTime: "20:00",
+						},
+					},
+					Status: ClusterSleepInfoStatus{
+						Namespaces:        []string{"dev-1", "dev-2"},
+						SkippedNamespaces: []string{"dev-3"},
+					},
+				},
+			},
+		}
+
+		require.Equal(t, clusterSleepInfoList, clusterSleepInfoList.DeepCopy())
+		require.Equal(t, clusterSleepInfoList, clusterSleepInfoList.DeepCopyObject())
+		require.Equal(t, &clusterSleepInfoList.Items[0], clusterSleepInfoList.Items[0].DeepCopyObject())
+		require.Equal(t, &clusterSleepInfoList.Items[0].Spec, clusterSleepInfoList.Items[0].Spec.DeepCopy())
+		require.Equal(t, &clusterSleepInfoList.Items[0].Status, clusterSleepInfoList.Items[0].Status.DeepCopy())
+	})
+
+	t.Run("sleep info state", func(t *testing.T) {
+		savedAt := metav1.Now()
+		sleepInfoStateList := &SleepInfoStateList{
+			Items: []SleepInfoState{
+				{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "SleepInfoState",
+						APIVersion: "kube-green.com/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "name",
+					},
+					Spec: SleepInfoStateSpec{
+						SleepInfo: "name",
+						SavedAt:   &savedAt,
+						Targets: []SleepInfoStateTarget{
+							{
+								Target:  "Deployment.apps",
+								SavedAt: &savedAt,
+								Resources: []SleepInfoStateResource{
+									{Name: "api", Patch: `[{"op":"add","path":"/spec/replicas","value":3}]`, Generation: 2},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		require.Equal(t, sleepInfoStateList, sleepInfoStateList.DeepCopy())
+		require.Equal(t, sleepInfoStateList, sleepInfoStateList.DeepCopyObject())
+		require.Equal(t, &sleepInfoStateList.Items[0], sleepInfoStateList.Items[0].DeepCopyObject())
+		require.Equal(t, &sleepInfoStateList.Items[0].Spec, sleepInfoStateList.Items[0].Spec.DeepCopy())
+		require.Equal(t, &sleepInfoStateList.Items[0].Spec.Targets[0], sleepInfoStateList.Items[0].Spec.Targets[0].DeepCopy())
+		require.Equal(t, &sleepInfoStateList.Items[0].Spec.Targets[0].Resources[0], sleepInfoStateList.Items[0].Spec.Targets[0].Resources[0].DeepCopy())
+	})
+
 	t.Run("nil", func(t *testing.T) {
 		t.Run("exclude ref", func(t *testing.T) {
 			var excludeRef *FilterRef = nil
@@ -9,64 +9,955 @@ Copyright 2024.
 package v1alpha1
 
 import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertMatcher) DeepCopyInto(out *AlertMatcher) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertMatcher.
+func (in *AlertMatcher) DeepCopy() *AlertMatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertMatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilence) DeepCopyInto(out *AlertSilence) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]AlertMatcher, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilence.
+func (in *AlertSilence) DeepCopy() *AlertSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSleepInfo) DeepCopyInto(out *ClusterSleepInfo) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSleepInfo.
+func (in *ClusterSleepInfo) DeepCopy() *ClusterSleepInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSleepInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSleepInfo) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSleepInfoList) DeepCopyInto(out *ClusterSleepInfoList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSleepInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSleepInfoList.
+func (in *ClusterSleepInfoList) DeepCopy() *ClusterSleepInfoList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSleepInfoList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSleepInfoList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSleepInfoSpec) DeepCopyInto(out *ClusterSleepInfoSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSleepInfoSpec.
+func (in *ClusterSleepInfoSpec) DeepCopy() *ClusterSleepInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSleepInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSleepInfoStatus) DeepCopyInto(out *ClusterSleepInfoStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedNamespaces != nil {
+		in, out := &in.SkippedNamespaces, &out.SkippedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSleepInfoStatus.
+func (in *ClusterSleepInfoStatus) DeepCopy() *ClusterSleepInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSleepInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunResource) DeepCopyInto(out *DryRunResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunResource.
+func (in *DryRunResource) DeepCopy() *DryRunResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DryRunStatus) DeepCopyInto(out *DryRunStatus) {
+	*out = *in
+	in.ExecutedAt.DeepCopyInto(&out.ExecutedAt)
+	if in.ResourceCounts != nil {
+		in, out := &in.ResourceCounts, &out.ResourceCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]DryRunResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]FailedResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DryRunStatus.
+func (in *DryRunStatus) DeepCopy() *DryRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DryRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedResource) DeepCopyInto(out *FailedResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedResource.
+func (in *FailedResource) DeepCopy() *FailedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterRef) DeepCopyInto(out *FilterRef) {
 	*out = *in
-	if in.MatchLabels != nil {
-		in, out := &in.MatchLabels, &out.MatchLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRef.
+func (in *FilterRef) DeepCopy() *FilterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHook) DeepCopyInto(out *HTTPHook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHook.
+func (in *HTTPHook) DeepCopy() *HTTPHook {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HolidayCalendar) DeepCopyInto(out *HolidayCalendar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HolidayCalendar.
+func (in *HolidayCalendar) DeepCopy() *HolidayCalendar {
+	if in == nil {
+		return nil
+	}
+	out := new(HolidayCalendar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(batchv1.JobTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Karpenter) DeepCopyInto(out *Karpenter) {
+	*out = *in
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestoreBefore != nil {
+		in, out := &in.RestoreBefore, &out.RestoreBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Karpenter.
+func (in *Karpenter) DeepCopy() *Karpenter {
+	if in == nil {
+		return nil
+	}
+	out := new(Karpenter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualOperationStatus) DeepCopyInto(out *ManualOperationStatus) {
+	*out = *in
+	if in.RequestedAt != nil {
+		in, out := &in.RequestedAt, &out.RequestedAt
+		*out = (*in).DeepCopy()
+	}
+	in.ExecutedAt.DeepCopyInto(&out.ExecutedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualOperationStatus.
+func (in *ManualOperationStatus) DeepCopy() *ManualOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceFilter) DeepCopyInto(out *NamespaceFilter) {
+	*out = *in
+	in.Filter.DeepCopyInto(&out.Filter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceFilter.
+func (in *NamespaceFilter) DeepCopy() *NamespaceFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSleepReplicas) DeepCopyInto(out *NamespaceSleepReplicas) {
+	*out = *in
+	in.Filter.DeepCopyInto(&out.Filter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSleepReplicas.
+func (in *NamespaceSleepReplicas) DeepCopy() *NamespaceSleepReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceSleepReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeScaleDown) DeepCopyInto(out *NodeScaleDown) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeScaleDown.
+func (in *NodeScaleDown) DeepCopy() *NodeScaleDown {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeScaleDown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Patch) DeepCopyInto(out *Patch) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patch.
+func (in *Patch) DeepCopy() *Patch {
+	if in == nil {
+		return nil
+	}
+	out := new(Patch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatchTarget) DeepCopyInto(out *PatchTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget.
+func (in *PatchTarget) DeepCopy() *PatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfo) DeepCopyInto(out *SleepInfo) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfo.
+func (in *SleepInfo) DeepCopy() *SleepInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleepInfo) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfoList) DeepCopyInto(out *SleepInfoList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SleepInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoList.
+func (in *SleepInfoList) DeepCopy() *SleepInfoList {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfoList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleepInfoList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfoSpec) DeepCopyInto(out *SleepInfoSpec) {
+	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]WeeklySchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.SleepDuration != nil {
+		in, out := &in.SleepDuration, &out.SleepDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PreSleepDelay != nil {
+		in, out := &in.PreSleepDelay, &out.PreSleepDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PreSleepHooks != nil {
+		in, out := &in.PreSleepHooks, &out.PreSleepHooks
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostWakeHooks != nil {
+		in, out := &in.PostWakeHooks, &out.PostWakeHooks
+		*out = make([]Hook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AlertSilence != nil {
+		in, out := &in.AlertSilence, &out.AlertSilence
+		*out = new(AlertSilence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeScaleDown != nil {
+		in, out := &in.NodeScaleDown, &out.NodeScaleDown
+		*out = new(NodeScaleDown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Karpenter != nil {
+		in, out := &in.Karpenter, &out.Karpenter
+		*out = new(Karpenter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExcludeRef != nil {
+		in, out := &in.ExcludeRef, &out.ExcludeRef
+		*out = make([]FilterRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IncludeRef != nil {
+		in, out := &in.IncludeRef, &out.IncludeRef
+		*out = make([]FilterRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SuspendDeployments != nil {
+		in, out := &in.SuspendDeployments, &out.SuspendDeployments
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSets != nil {
+		in, out := &in.SuspendStatefulSets, &out.SuspendStatefulSets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendDeploymentsPgbouncer != nil {
+		in, out := &in.SuspendDeploymentsPgbouncer, &out.SuspendDeploymentsPgbouncer
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSetsPostgres != nil {
+		in, out := &in.SuspendStatefulSetsPostgres, &out.SuspendStatefulSetsPostgres
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSetsHdfs != nil {
+		in, out := &in.SuspendStatefulSetsHdfs, &out.SuspendStatefulSetsHdfs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSetsOpenSearch != nil {
+		in, out := &in.SuspendStatefulSetsOpenSearch, &out.SuspendStatefulSetsOpenSearch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSetsOsDashboards != nil {
+		in, out := &in.SuspendStatefulSetsOsDashboards, &out.SuspendStatefulSetsOsDashboards
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStatefulSetsKafka != nil {
+		in, out := &in.SuspendStatefulSetsKafka, &out.SuspendStatefulSetsKafka
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendKnative != nil {
+		in, out := &in.SuspendKnative, &out.SuspendKnative
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendHPA != nil {
+		in, out := &in.SuspendHPA, &out.SuspendHPA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendKEDA != nil {
+		in, out := &in.SuspendKEDA, &out.SuspendKEDA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendStrimzi != nil {
+		in, out := &in.SuspendStrimzi, &out.SuspendStrimzi
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendECK != nil {
+		in, out := &in.SuspendECK, &out.SuspendECK
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendFlink != nil {
+		in, out := &in.SuspendFlink, &out.SuspendFlink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SleepReplicas != nil {
+		in, out := &in.SleepReplicas, &out.SleepReplicas
+		*out = make([]SleepReplicas, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WakeStages != nil {
+		in, out := &in.WakeStages, &out.WakeStages
+		*out = make([]WakeStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WakeUpOnDeletion != nil {
+		in, out := &in.WakeUpOnDeletion, &out.WakeUpOnDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]Patch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExecuteOnce != nil {
+		in, out := &in.ExecuteOnce, &out.ExecuteOnce
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeleteWhenCompleted != nil {
+		in, out := &in.DeleteWhenCompleted, &out.DeleteWhenCompleted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuspendScheduleUntil != nil {
+		in, out := &in.SuspendScheduleUntil, &out.SuspendScheduleUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(ScheduleWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HolidayCalendar != nil {
+		in, out := &in.HolidayCalendar, &out.HolidayCalendar
+		*out = new(HolidayCalendar)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoSpec.
+func (in *SleepInfoSpec) DeepCopy() *SleepInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfoState) DeepCopyInto(out *SleepInfoState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoState.
+func (in *SleepInfoState) DeepCopy() *SleepInfoState {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfoState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleepInfoState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfoStateList) DeepCopyInto(out *SleepInfoStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SleepInfoState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterRef.
-func (in *FilterRef) DeepCopy() *FilterRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStateList.
+func (in *SleepInfoStateList) DeepCopy() *SleepInfoStateList {
 	if in == nil {
 		return nil
 	}
-	out := new(FilterRef)
+	out := new(SleepInfoStateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SleepInfoStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Patch) DeepCopyInto(out *Patch) {
+func (in *SleepInfoStateResource) DeepCopyInto(out *SleepInfoStateResource) {
 	*out = *in
-	out.Target = in.Target
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Patch.
-func (in *Patch) DeepCopy() *Patch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStateResource.
+func (in *SleepInfoStateResource) DeepCopy() *SleepInfoStateResource {
 	if in == nil {
 		return nil
 	}
-	out := new(Patch)
+	out := new(SleepInfoStateResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PatchTarget) DeepCopyInto(out *PatchTarget) {
+func (in *SleepInfoStateSpec) DeepCopyInto(out *SleepInfoStateSpec) {
 	*out = *in
+	if in.SavedAt != nil {
+		in, out := &in.SavedAt, &out.SavedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]SleepInfoStateTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatchTarget.
-func (in *PatchTarget) DeepCopy() *PatchTarget {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStateSpec.
+func (in *SleepInfoStateSpec) DeepCopy() *SleepInfoStateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PatchTarget)
+	out := new(SleepInfoStateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SleepInfo) DeepCopyInto(out *SleepInfo) {
+func (in *SleepInfoStateTarget) DeepCopyInto(out *SleepInfoStateTarget) {
+	*out = *in
+	if in.SavedAt != nil {
+		in, out := &in.SavedAt, &out.SavedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]SleepInfoStateResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStateTarget.
+func (in *SleepInfoStateTarget) DeepCopy() *SleepInfoStateTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfoStateTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepInfoStatus) DeepCopyInto(out *SleepInfoStatus) {
+	*out = *in
+	in.LastScheduleTime.DeepCopyInto(&out.LastScheduleTime)
+	if in.SuspendedUntil != nil {
+		in, out := &in.SuspendedUntil, &out.SuspendedUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.LastManualOperation != nil {
+		in, out := &in.LastManualOperation, &out.LastManualOperation
+		*out = new(ManualOperationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastSleepTime != nil {
+		in, out := &in.LastSleepTime, &out.LastSleepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastWakeUpTime != nil {
+		in, out := &in.LastWakeUpTime, &out.LastWakeUpTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SuspendedResourceCounts != nil {
+		in, out := &in.SuspendedResourceCounts, &out.SuspendedResourceCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SavedResources != nil {
+		in, out := &in.SavedResources, &out.SavedResources
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]FailedResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDryRun != nil {
+		in, out := &in.LastDryRun, &out.LastDryRun
+		*out = new(DryRunStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScaledDownNodes != nil {
+		in, out := &in.ScaledDownNodes, &out.ScaledDownNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStatus.
+func (in *SleepInfoStatus) DeepCopy() *SleepInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SleepReplicas) DeepCopyInto(out *SleepReplicas) {
+	*out = *in
+	in.FilterRef.DeepCopyInto(&out.FilterRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepReplicas.
+func (in *SleepReplicas) DeepCopy() *SleepReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(SleepReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantSchedule) DeepCopyInto(out *TenantSchedule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -74,18 +965,18 @@ func (in *SleepInfo) DeepCopyInto(out *SleepInfo) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfo.
-func (in *SleepInfo) DeepCopy() *SleepInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantSchedule.
+func (in *TenantSchedule) DeepCopy() *TenantSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(SleepInfo)
+	out := new(TenantSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SleepInfo) DeepCopyObject() runtime.Object {
+func (in *TenantSchedule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -93,31 +984,62 @@ func (in *SleepInfo) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SleepInfoList) DeepCopyInto(out *SleepInfoList) {
+func (in *TenantScheduleDelays) DeepCopyInto(out *TenantScheduleDelays) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScheduleDelays.
+func (in *TenantScheduleDelays) DeepCopy() *TenantScheduleDelays {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantScheduleDelays)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantScheduleHolidays) DeepCopyInto(out *TenantScheduleHolidays) {
+	*out = *in
+	out.HolidayCalendar = in.HolidayCalendar
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScheduleHolidays.
+func (in *TenantScheduleHolidays) DeepCopy() *TenantScheduleHolidays {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantScheduleHolidays)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantScheduleList) DeepCopyInto(out *TenantScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SleepInfo, len(*in))
+		*out = make([]TenantSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoList.
-func (in *SleepInfoList) DeepCopy() *SleepInfoList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScheduleList.
+func (in *TenantScheduleList) DeepCopy() *TenantScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(SleepInfoList)
+	out := new(TenantScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SleepInfoList) DeepCopyObject() runtime.Object {
+func (in *TenantScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -125,76 +1047,114 @@ func (in *SleepInfoList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SleepInfoSpec) DeepCopyInto(out *SleepInfoSpec) {
+func (in *TenantScheduleSpec) DeepCopyInto(out *TenantScheduleSpec) {
 	*out = *in
-	if in.ExcludeRef != nil {
-		in, out := &in.ExcludeRef, &out.ExcludeRef
-		*out = make([]FilterRef, len(*in))
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Delays != nil {
+		in, out := &in.Delays, &out.Delays
+		*out = new(TenantScheduleDelays)
+		**out = **in
+	}
+	if in.Exclusions != nil {
+		in, out := &in.Exclusions, &out.Exclusions
+		*out = make([]NamespaceFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.IncludeRef != nil {
-		in, out := &in.IncludeRef, &out.IncludeRef
-		*out = make([]FilterRef, len(*in))
+	if in.Inclusions != nil {
+		in, out := &in.Inclusions, &out.Inclusions
+		*out = make([]NamespaceFilter, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SuspendDeployments != nil {
-		in, out := &in.SuspendDeployments, &out.SuspendDeployments
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SuspendStatefulSets != nil {
-		in, out := &in.SuspendStatefulSets, &out.SuspendStatefulSets
-		*out = new(bool)
-		**out = **in
+	if in.SleepReplicas != nil {
+		in, out := &in.SleepReplicas, &out.SleepReplicas
+		*out = make([]NamespaceSleepReplicas, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.SuspendDeploymentsPgbouncer != nil {
-		in, out := &in.SuspendDeploymentsPgbouncer, &out.SuspendDeploymentsPgbouncer
-		*out = new(bool)
+	if in.Holidays != nil {
+		in, out := &in.Holidays, &out.Holidays
+		*out = new(TenantScheduleHolidays)
 		**out = **in
 	}
-	if in.SuspendStatefulSetsPostgres != nil {
-		in, out := &in.SuspendStatefulSetsPostgres, &out.SuspendStatefulSetsPostgres
-		*out = new(bool)
-		**out = **in
+	if in.KarpenterNodePools != nil {
+		in, out := &in.KarpenterNodePools, &out.KarpenterNodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.SuspendStatefulSetsHdfs != nil {
-		in, out := &in.SuspendStatefulSetsHdfs, &out.SuspendStatefulSetsHdfs
-		*out = new(bool)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScheduleSpec.
+func (in *TenantScheduleSpec) DeepCopy() *TenantScheduleSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Patches != nil {
-		in, out := &in.Patches, &out.Patches
-		*out = make([]Patch, len(*in))
+	out := new(TenantScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantScheduleStatus) DeepCopyInto(out *TenantScheduleStatus) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoSpec.
-func (in *SleepInfoSpec) DeepCopy() *SleepInfoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantScheduleStatus.
+func (in *TenantScheduleStatus) DeepCopy() *TenantScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SleepInfoSpec)
+	out := new(TenantScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SleepInfoStatus) DeepCopyInto(out *SleepInfoStatus) {
+func (in *WakeStage) DeepCopyInto(out *WakeStage) {
 	*out = *in
-	in.LastScheduleTime.DeepCopyInto(&out.LastScheduleTime)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]FilterRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SleepInfoStatus.
-func (in *SleepInfoStatus) DeepCopy() *SleepInfoStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WakeStage.
+func (in *WakeStage) DeepCopy() *WakeStage {
 	if in == nil {
 		return nil
 	}
-	out := new(SleepInfoStatus)
+	out := new(WakeStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeeklySchedule) DeepCopyInto(out *WeeklySchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeeklySchedule.
+func (in *WeeklySchedule) DeepCopy() *WeeklySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(WeeklySchedule)
 	in.DeepCopyInto(out)
 	return out
 }
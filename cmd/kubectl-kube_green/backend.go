@@ -0,0 +1,250 @@
+/*
+Copyright 2025.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type (
+	manualResult  = apiv1.ManualOperationResponse
+	statusResult  = apiv1.NamespaceStatusResponse
+	nextResult    = apiv1.NextOperationResponse
+	previewResult = apiv1.SchedulePreviewResponse
+	importResult  = apiv1.BundleImportReport
+)
+
+// backend runs the commands, on the SleepInfos of the cluster or through the REST API
+type backend interface {
+	sleep(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error)
+	wake(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error)
+	status(ctx context.Context, tenant, suffix string) ([]statusResult, error)
+	next(ctx context.Context, tenant string) (*nextResult, error)
+	preview(ctx context.Context, tenant, suffix string, days int) (*previewResult, error)
+}
+
+func newBackend(opts options) (backend, error) {
+	if opts.apiURL != "" {
+		return &restBackend{baseURL: strings.TrimSuffix(opts.apiURL, "/"), token: opts.token, client: &http.Client{Timeout: opts.timeout}}, nil
+	}
+	service, err := newScheduleService()
+	if err != nil {
+		return nil, err
+	}
+	return crdBackend{service: service}, nil
+}
+
+// newScheduleService returns the schedule service of the API over a client of the current kubeconfig
+func newScheduleService() (*apiv1.ScheduleService, error) {
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := kubegreenv1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Kubernetes client: %w", err)
+	}
+	return apiv1.NewScheduleService(c, logr.Discard()), nil
+}
+
+// crdBackend reads and annotates the SleepInfos directly, with the permissions of the kubeconfig user,
+// as the REST API does
+type crdBackend struct {
+	service *apiv1.ScheduleService
+}
+
+func (b crdBackend) sleep(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error) {
+	return b.service.SleepNow(ctx, tenant, scheduleName, suffix)
+}
+
+func (b crdBackend) wake(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error) {
+	return b.service.WakeNow(ctx, tenant, scheduleName, suffix)
+}
+
+func (b crdBackend) status(ctx context.Context, tenant, suffix string) ([]statusResult, error) {
+	suffixes := []string{suffix}
+	if suffix == "" {
+		tenants, err := b.service.ListTenants(ctx)
+		if err != nil {
+			return nil, err
+		}
+		suffixes = tenantNamespaces(tenants, tenant)
+	}
+	statuses := []statusResult{}
+	for _, suffix := range suffixes {
+		status, err := b.service.GetNamespaceStatus(ctx, tenant, suffix, time.Now())
+		if err != nil {
+			if strings.Contains(err.Error(), "no schedules found") {
+				continue // namespace without SleepInfos
+			}
+			return nil, err
+		}
+		statuses = append(statuses, *status)
+	}
+	return statuses, nil
+}
+
+func (b crdBackend) next(ctx context.Context, tenant string) (*nextResult, error) {
+	return b.service.GetNextOperation(ctx, tenant)
+}
+
+func (b crdBackend) preview(ctx context.Context, tenant, suffix string, days int) (*previewResult, error) {
+	return b.service.PreviewSchedule(ctx, tenant, suffix, days, time.Now())
+}
+
+// restBackend calls the REST API, with the permissions of the user of the token
+type restBackend struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+func (b *restBackend) sleep(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error) {
+	result := &manualResult{}
+	return result, b.do(ctx, http.MethodPost, schedulePath(tenant, "sleep-now"), manualQuery(scheduleName, suffix), result)
+}
+
+func (b *restBackend) wake(ctx context.Context, tenant, scheduleName, suffix string) (*manualResult, error) {
+	result := &manualResult{}
+	return result, b.do(ctx, http.MethodPost, schedulePath(tenant, "wake-now"), manualQuery(scheduleName, suffix), result)
+}
+
+func (b *restBackend) status(ctx context.Context, tenant, suffix string) ([]statusResult, error) {
+	suffixes := []string{suffix}
+	if suffix == "" {
+		tenants := &apiv1.TenantListResponse{}
+		if err := b.do(ctx, http.MethodGet, "/api/v1/tenants", nil, tenants); err != nil {
+			return nil, err
+		}
+		suffixes = tenantNamespaces(tenants, tenant)
+	}
+	statuses := []statusResult{}
+	for _, suffix := range suffixes {
+		status := statusResult{}
+		if err := b.do(ctx, http.MethodGet, schedulePath(tenant, url.PathEscape(suffix), "status"), nil, &status); err != nil {
+			var apiErr *apiError
+			if errors.As(err, &apiErr) && apiErr.code == http.StatusNotFound {
+				continue // namespace without SleepInfos
+			}
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (b *restBackend) next(ctx context.Context, tenant string) (*nextResult, error) {
+	result := &nextResult{}
+	return result, b.do(ctx, http.MethodGet, schedulePath(tenant, "next"), nil, result)
+}
+
+func (b *restBackend) preview(ctx context.Context, tenant, suffix string, days int) (*previewResult, error) {
+	query := url.Values{"days": []string{strconv.Itoa(days)}}
+	if suffix != "" {
+		query.Set("namespace", suffix)
+	}
+	result := &previewResult{}
+	return result, b.do(ctx, http.MethodGet, schedulePath(tenant, "preview"), query, result)
+}
+
+// apiError is an error response of the REST API
+type apiError struct {
+	code    int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.message, e.code)
+}
+
+// do calls the REST API and decodes the data of its response into result
+func (b *restBackend) do(ctx context.Context, method, path string, query url.Values, result interface{}) error {
+	endpoint := b.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if b.token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	response := struct {
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return &apiError{code: resp.StatusCode, message: strings.TrimSpace(string(body))}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return &apiError{code: resp.StatusCode, message: response.Error}
+	}
+	if len(response.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Data, result)
+}
+
+func schedulePath(tenant string, elements ...string) string {
+	return "/api/v1/schedules/" + url.PathEscape(tenant) + "/" + strings.Join(elements, "/")
+}
+
+func manualQuery(scheduleName, suffix string) url.Values {
+	query := url.Values{}
+	if scheduleName != "" {
+		query.Set("scheduleName", scheduleName)
+	}
+	if suffix != "" {
+		query.Set("namespace", suffix)
+	}
+	return query
+}
+
+// tenantNamespaces returns the namespace suffixes of a tenant
+func tenantNamespaces(tenants *apiv1.TenantListResponse, tenant string) []string {
+	for _, t := range tenants.Tenants {
+		if t.Name == tenant {
+			return t.Namespaces
+		}
+	}
+	return nil
+}
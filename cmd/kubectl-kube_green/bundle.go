@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+
+	"sigs.k8s.io/yaml"
+)
+
+// exportBundle writes the bundle of the cluster, or of --tenant, as YAML into --file
+func exportBundle(ctx context.Context, opts options, out io.Writer) error {
+	if opts.apiURL != "" {
+		return errors.New("export reads the cluster with the kubeconfig, --api-url is not supported")
+	}
+	service, err := newScheduleService()
+	if err != nil {
+		return err
+	}
+	bundle, err := service.ExportBundle(ctx, opts.tenant)
+	if err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the bundle: %w", err)
+	}
+	if opts.file == "-" {
+		_, err = out.Write(content)
+		return err
+	}
+	if err := os.WriteFile(opts.file, content, 0o600); err != nil {
+		return err
+	}
+	sleepInfos, tenantSchedules := 0, 0
+	for _, tenant := range bundle.Tenants {
+		sleepInfos += len(tenant.SleepInfos)
+		tenantSchedules += len(tenant.TenantSchedules)
+	}
+	fmt.Fprintf(out, "exported %d tenants, %d SleepInfos and %d TenantSchedules to %s\n", len(bundle.Tenants), sleepInfos, tenantSchedules, opts.file)
+	return nil
+}
+
+// importBundle applies the bundle of --file to the cluster and prints the import report
+func importBundle(ctx context.Context, opts options, out io.Writer) error {
+	if opts.apiURL != "" {
+		return errors.New("import writes the cluster with the kubeconfig, --api-url is not supported")
+	}
+	var content []byte
+	var err error
+	if opts.file == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(opts.file)
+	}
+	if err != nil {
+		return err
+	}
+	bundle := &apiv1.ClusterBundle{}
+	if err := yaml.UnmarshalStrict(content, bundle); err != nil {
+		return fmt.Errorf("invalid bundle %s: %w", opts.file, err)
+	}
+
+	service, err := newScheduleService()
+	if err != nil {
+		return err
+	}
+	report, err := service.ImportBundle(ctx, bundle, apiv1.BundleImportOptions{
+		TenantMapping:    opts.tenantMapping,
+		NamespaceMapping: opts.namespaceMapping,
+		DryRun:           opts.dryRun,
+	})
+	if report != nil {
+		if printErr := printResult(out, opts.output, report); printErr != nil && err == nil {
+			err = printErr
+		}
+	}
+	return err
+}
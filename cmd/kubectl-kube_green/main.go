@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+*/
+
+// kubectl-kube_green is the kubectl plugin of kube-green, run as "kubectl kube-green". It puts the
+// namespaces of a tenant to sleep, wakes them up and shows their status and next operation, either
+// writing the SleepInfos directly or through the REST API. It also exports the schedules of a cluster
+// into a bundle and imports them into another cluster.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+)
+
+const usage = `Usage: kubectl kube-green <command> (--tenant TENANT | -n NAMESPACE) [options]
+       kubectl kube-green export [--tenant TENANT] [-f FILE]
+       kubectl kube-green import -f FILE [--map-tenant OLD=NEW]... [--map-namespace OLD=NEW]... [--dry-run]
+
+Commands:
+  sleep    Put the namespaces to sleep now
+  wake     Wake the namespaces up now
+  status   Show the state of the SleepInfos of the namespaces
+  next     Show the next scheduled operation
+  preview  Show every operation planned in the next --days, wake stages included
+  export   Write the SleepInfos and TenantSchedules of the cluster into a bundle
+  import   Apply a bundle to the cluster, remapping its tenants and namespaces
+
+Options:
+`
+
+// options are the flags shared by every command
+type options struct {
+	tenant       string
+	namespace    string
+	scheduleName string
+	apiURL       string
+	token        string
+	output       string
+	timeout      time.Duration
+	days         int
+
+	file             string
+	tenantMapping    mapping
+	namespaceMapping mapping
+	dryRun           bool
+}
+
+// mapping is a repeatable OLD=NEW flag
+type mapping map[string]string
+
+func (m mapping) String() string {
+	pairs := make([]string, 0, len(m))
+	for from, to := range m {
+		pairs = append(pairs, from+"="+to)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m mapping) Set(value string) error {
+	from, to, ok := strings.Cut(value, "=")
+	if !ok || from == "" || to == "" {
+		return fmt.Errorf("invalid mapping %q (expected OLD=NEW)", value)
+	}
+	m[from] = to
+	return nil
+}
+
+// target returns the tenant and the namespace suffix the command operates on: every namespace of the
+// tenant, or a single {tenant}-{suffix} namespace
+func (o options) target() (string, string, error) {
+	if o.namespace == "" {
+		if o.tenant == "" {
+			return "", "", errors.New("--tenant or --namespace is required")
+		}
+		return o.tenant, "", nil
+	}
+	if o.tenant != "" {
+		suffix, ok := strings.CutPrefix(o.namespace, o.tenant+"-")
+		if !ok {
+			return "", "", fmt.Errorf("namespace %s is not a namespace of tenant %s", o.namespace, o.tenant)
+		}
+		return o.tenant, suffix, nil
+	}
+	tenant, suffix, ok := apiv1.SplitNamespaceName(o.namespace)
+	if !ok || tenant == "" || suffix == "" {
+		return "", "", fmt.Errorf("namespace %s is not a {tenant}-{suffix} namespace, set --tenant", o.namespace)
+	}
+	return tenant, suffix, nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flags := flag.CommandLine
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		flags.Usage()
+		return errors.New("a command is required")
+	}
+	command := args[0]
+
+	opts := options{tenantMapping: mapping{}, namespaceMapping: mapping{}}
+	flags.StringVar(&opts.tenant, "tenant", "", "Tenant whose namespaces are selected")
+	flags.StringVar(&opts.namespace, "namespace", "", "Single namespace selected, {tenant}-{suffix}")
+	flags.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace")
+	flags.StringVar(&opts.scheduleName, "schedule", "", "Only the SleepInfos of this schedule name (sleep and wake)")
+	flags.StringVar(&opts.apiURL, "api-url", os.Getenv("KUBE_GREEN_API_URL"), "URL of the kube-green REST API; the SleepInfos are read and written directly when empty")
+	flags.StringVar(&opts.token, "token", os.Getenv("KUBE_GREEN_API_TOKEN"), "Bearer token of the REST API")
+	flags.StringVar(&opts.output, "output", "table", "Output format: table or json")
+	flags.StringVar(&opts.output, "o", "table", "Shorthand for --output")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the command")
+	flags.IntVar(&opts.days, "days", 7, "Days shown by preview")
+	flags.StringVar(&opts.file, "file", "-", "Bundle file of export and import, - for stdout or stdin")
+	flags.StringVar(&opts.file, "f", "-", "Shorthand for --file")
+	flags.Var(opts.tenantMapping, "map-tenant", "Tenant renamed by import, OLD=NEW (repeatable)")
+	flags.Var(opts.namespaceMapping, "map-namespace", "Namespace renamed by import, OLD=NEW (repeatable)")
+	flags.BoolVar(&opts.dryRun, "dry-run", false, "Report what import would apply without writing")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if opts.output != "table" && opts.output != "json" {
+		return fmt.Errorf("invalid output %s (expected table or json)", opts.output)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+	switch command {
+	case "export":
+		return exportBundle(ctx, opts, out)
+	case "import":
+		return importBundle(ctx, opts, out)
+	}
+
+	tenant, suffix, err := opts.target()
+	if err != nil {
+		return err
+	}
+	b, err := newBackend(opts)
+	if err != nil {
+		return err
+	}
+
+	var result interface{}
+	switch command {
+	case "sleep":
+		result, err = b.sleep(ctx, tenant, opts.scheduleName, suffix)
+	case "wake":
+		result, err = b.wake(ctx, tenant, opts.scheduleName, suffix)
+	case "status":
+		result, err = b.status(ctx, tenant, suffix)
+	case "next":
+		result, err = b.next(ctx, tenant)
+	case "preview":
+		result, err = b.preview(ctx, tenant, suffix, opts.days)
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown command %s", command)
+	}
+	if err != nil {
+		return err
+	}
+	return printResult(out, opts.output, result)
+}
+
+// printResult prints the result of a command in the output format
+func printResult(out io.Writer, output string, result interface{}) error {
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	return printTable(out, result)
+}
+
+// printTable prints the result of a command as a table
+func printTable(out io.Writer, result interface{}) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	switch r := result.(type) {
+	case *manualResult:
+		fmt.Fprintln(w, "NAMESPACE\tSLEEPINFO\tACTION\tAT")
+		for _, si := range r.SleepInfos {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", si.Namespace, si.Name, r.Action, si.ScheduledAt.Local().Format(time.RFC3339))
+		}
+	case []statusResult:
+		fmt.Fprintln(w, "NAMESPACE\tSLEEPINFO\tSTATE\tLAST OPERATION\tLAST SCHEDULE\tHEALTHY")
+		for _, namespace := range r {
+			for _, si := range namespace.SleepInfos {
+				lastSchedule := "-"
+				if si.LastScheduleTime != nil {
+					lastSchedule = si.LastScheduleTime.Local().Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\n", namespace.Namespace, si.Name, valueOr(si.CurrentState, "-"), valueOr(si.LastOperation, "-"), lastSchedule, namespace.Healthy)
+			}
+		}
+	case *previewResult:
+		fmt.Fprintln(w, "TIME (UTC)\tUSER TIME\tTIMEZONE\tNAMESPACE\tSLEEPINFO\tOPERATION\tSTAGE\tRESOURCES")
+		for _, o := range r.Occurrences {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", o.Time.Format("Mon 2006-01-02 15:04"), userTime(o.UserTime), o.UserTimezone, o.Namespace, o.SleepInfo, o.Operation, valueOr(o.WakeStage, "-"), valueOr(strings.Join(o.Resources, ","), "-"))
+		}
+	case *importResult:
+		fmt.Fprintln(w, "OBJECT\tRESULT")
+		applied := "applied"
+		if r.DryRun {
+			applied = "would be applied"
+		}
+		for _, object := range r.Applied {
+			fmt.Fprintf(w, "%s\t%s\n", object, applied)
+		}
+		for _, skipped := range r.Skipped {
+			fmt.Fprintf(w, "%s\tskipped: %s\n", skipped.Object, skipped.Reason)
+		}
+	case *nextResult:
+		fmt.Fprintln(w, "TENANT\tOPERATION\tAT\tIN\tNAMESPACE")
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Tenant, r.Operation, r.Time.Local().Format(time.RFC3339), time.Until(r.Time).Round(time.Minute), valueOr(r.Namespace, "-"))
+	default:
+		return fmt.Errorf("unexpected result %T", result)
+	}
+	return w.Flush()
+}
+
+// userTime formats the RFC3339 user time of an occurrence as the times of the preview table
+func userTime(rfc3339 string) string {
+	t, err := time.Parse(time.RFC3339, rfc3339)
+	if err != nil {
+		return rfc3339
+	}
+	return t.Format("Mon 2006-01-02 15:04")
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -5,16 +5,30 @@ Copyright 2021.
 package main
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	kubegreencomv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/api/grpcapi"
 	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+	clustersleepinfocontroller "github.com/kube-green/kube-green/internal/controller/clustersleepinfo"
 	sleepinfocontroller "github.com/kube-green/kube-green/internal/controller/sleepinfo"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/alertmanager"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/holidays"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/metrics"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/patchtargets"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/sharding"
+	tenantschedulecontroller "github.com/kube-green/kube-green/internal/controller/tenantschedule"
+	"github.com/kube-green/kube-green/internal/gitops"
+	"github.com/kube-green/kube-green/internal/notifications"
+	"github.com/kube-green/kube-green/internal/wakeonrequest"
 	webhookv1alpha1 "github.com/kube-green/kube-green/internal/webhook/v1alpha1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -29,6 +43,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -58,6 +73,7 @@ func main() {
 	var metricsAddr string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var metricsCertPath, metricsCertName, metricsCertKey string
+	var apiCertPath, apiCertName, apiCertKey string
 	var enableLeaderElection bool
 	var probeAddr string
 	var sleepDelta int64
@@ -67,6 +83,41 @@ func main() {
 	var apiPort int
 	var enableAPI bool
 	var enableAPICORS bool
+	var apiCORS apiv1.CORSConfig
+	var apiCORSOrigins, apiCORSHeaders, apiCORSMethods string
+	var apiRateLimit apiv1.RateLimitConfig
+	var apiTrustedProxies string
+	var apiAuditLog string
+	var apiAuditEvents bool
+	var apiImpersonate bool
+	var apiImpersonation apiv1.ImpersonationConfig
+	var apiImpersonationGroups string
+	var apiGitOps gitops.Repository
+	var grpcPort int
+	var savingsPricing apiv1.SavingsPricing
+	var namespaceLabels apiv1.NamespaceLabels
+	var namespacePolicies apiv1.NamespacePolicySource
+	var namespacePoliciesFile string
+	var enableNotifications bool
+	var notificationsConfig notifications.DispatcherConfig
+	var emailNotificationsSecret string
+	var emailDigestDay string
+	var emailDigestHour int
+	var patchTargets patchtargets.Loader
+	var patchTargetPresets string
+	var workloadEvents bool
+	var wakeUpOnDeletion bool
+	var shard sharding.Shard
+	var restoreStateCRD bool
+	var restoreDataKey restoredata.EncryptionKey
+	var holidayCalendar kubegreencomv1alpha1.HolidayCalendar
+	var alertmanagerClient alertmanager.Client
+	var nodeScaleDown bool
+	var httpHooks bool
+	var httpHookAllowedHosts string
+	var defaultTimeZone string
+	var wakeOnRequestAddr string
+	var wakeOnRequestRetryAfter time.Duration
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&webhookHost, "webhook-host", "", "The host where the server binds to. Default means all interfaces.")
 	flag.IntVar(&webhookPort, "webhook-server-port", 9443, "The port where the server will listen.")
@@ -93,7 +144,142 @@ func main() {
 		"Max concurrent schedules that will be processed at the same time.")
 	flag.IntVar(&apiPort, "api-port", 8080, "The port where the REST API server will listen.")
 	flag.BoolVar(&enableAPI, "enable-api", false, "Enable the REST API server.")
+	flag.StringVar(&apiCertPath, "api-cert-path", "",
+		"The directory that contains the REST API server certificate. Empty serves plain HTTP.")
+	flag.StringVar(&apiCertName, "api-cert-name", "tls.crt", "The name of the REST API server certificate file.")
+	flag.StringVar(&apiCertKey, "api-key-name", "tls.key", "The name of the REST API server key file.")
 	flag.BoolVar(&enableAPICORS, "enable-api-cors", false, "Enable CORS for the REST API server.")
+	flag.StringVar(&apiCORSOrigins, "api-cors-allowed-origins", os.Getenv("API_CORS_ALLOWED_ORIGINS"),
+		"Comma separated origins allowed by the REST API CORS policy (wildcards like https://*.example.com allowed). "+
+			"Empty allows any origin.")
+	flag.StringVar(&apiCORSHeaders, "api-cors-allowed-headers", os.Getenv("API_CORS_ALLOWED_HEADERS"),
+		"Comma separated request headers allowed by the REST API CORS policy. Empty uses the default list.")
+	flag.StringVar(&apiCORSMethods, "api-cors-allowed-methods", os.Getenv("API_CORS_ALLOWED_METHODS"),
+		"Comma separated methods allowed by the REST API CORS policy. Empty uses the default list.")
+	flag.BoolVar(&apiCORS.AllowCredentials, "api-cors-allow-credentials", os.Getenv("API_CORS_ALLOW_CREDENTIALS") != "false",
+		"Send Access-Control-Allow-Credentials in the REST API CORS responses.")
+	flag.IntVar(&apiCORS.MaxAge, "api-cors-max-age", 0,
+		"Seconds browsers may cache the REST API CORS preflight responses. 0 omits the header.")
+	flag.Float64Var(&apiRateLimit.GlobalRPS, "api-rate-limit", 0,
+		"Maximum requests per second accepted by the REST API server from all clients. 0 disables the limit.")
+	flag.IntVar(&apiRateLimit.GlobalBurst, "api-rate-limit-burst", 0,
+		"Burst size of the global REST API rate limit. Defaults to the rate.")
+	flag.Float64Var(&apiRateLimit.ClientRPS, "api-client-rate-limit", 0,
+		"Maximum requests per second accepted by the REST API server per client IP. 0 disables the limit.")
+	flag.IntVar(&apiRateLimit.ClientBurst, "api-client-rate-limit-burst", 0,
+		"Burst size of the per-client REST API rate limit. Defaults to the rate.")
+	flag.StringVar(&apiTrustedProxies, "api-trusted-proxies", os.Getenv("API_TRUSTED_PROXIES"),
+		"Comma separated IPs or CIDRs of the proxies allowed to set the client IP of REST API requests "+
+			"(X-Forwarded-For, X-Real-IP), used by the per-client rate limit. Empty trusts none.")
+	flag.IntVar(&grpcPort, "grpc-port", 0,
+		"The port where the gRPC API server will listen (requires --enable-api). 0 disables the gRPC API.")
+	flag.Float64Var(&savingsPricing.PerCoreHour, "savings-price-per-core-hour", 0,
+		"Price of one requested CPU core per hour used by the savings estimation endpoint. 0 omits the cost.")
+	flag.Float64Var(&savingsPricing.PerGBHour, "savings-price-per-gb-hour", 0,
+		"Price of one requested GiB of memory per hour used by the savings estimation endpoint. 0 omits the cost.")
+	flag.StringVar(&savingsPricing.Currency, "savings-currency", "", "Currency code shown by the savings estimation endpoint.")
+	flag.StringVar(&namespaceLabels.TenantLabel, "api-tenant-label", os.Getenv("API_TENANT_LABEL"),
+		"Namespace label holding the tenant name, e.g. stratio.com/tenant. Labelled namespaces are grouped by it "+
+			"instead of the {tenant}-{suffix} name. Empty parses the namespace name.")
+	flag.StringVar(&namespaceLabels.SuffixLabel, "api-namespace-suffix-label", os.Getenv("API_NAMESPACE_SUFFIX_LABEL"),
+		"Namespace label holding the namespace suffix, e.g. stratio.com/role. When missing, the suffix is the "+
+			"namespace name without the {tenant}- prefix.")
+	flag.StringVar(&namespacePoliciesFile, "api-namespace-policies", os.Getenv("API_NAMESPACE_POLICIES"),
+		"YAML file with the namespace suffix catalogue: suffix, suspendStatefulSets, staggeredWake and defaultExclusions "+
+			"of each suffix. Empty uses the built-in suffixes and the resource detection.")
+	flag.StringVar(&namespacePolicies.ConfigMap, "api-namespace-policy-configmap", os.Getenv("API_NAMESPACE_POLICY_CONFIGMAP"),
+		"ConfigMap of the kube-green namespace whose "+apiv1.NamespacePoliciesKey+" key extends the namespace suffix "+
+			"catalogue. It is read on every schedule creation, so new suffixes are onboarded without a restart.")
+	flag.BoolVar(&enableNotifications, "enable-webhook-notifications", false,
+		"POST the schedule lifecycle events (created/updated/deleted, sleep/wake executed) to the callback URLs "+
+			"registered through /api/v1/webhooks.")
+	flag.IntVar(&notificationsConfig.MaxRetries, "webhook-notifications-max-retries", 3,
+		"Retries of a failed webhook notification delivery, with exponential backoff. Negative disables retries.")
+	flag.DurationVar(&notificationsConfig.Timeout, "webhook-notifications-timeout", 10*time.Second,
+		"Timeout of each webhook notification delivery attempt.")
+	flag.StringVar(&emailNotificationsSecret, "email-notifications-secret", "",
+		"Secret of the kube-green namespace with the SMTP settings (host, port, username, password, from and tls). "+
+			"Enables the email subscriptions of /api/v1/webhooks and their weekly digest.")
+	flag.StringVar(&emailDigestDay, "email-digest-day", "Monday",
+		"Day of the week the digest of the executed sleeps and wake ups is emailed, in UTC.")
+	flag.IntVar(&emailDigestHour, "email-digest-hour", 8,
+		"Hour of the day, 0 to 23 in UTC, the weekly digest is emailed.")
+	flag.StringVar(&apiAuditLog, "api-audit-log", "",
+		"File where the REST API writes JSON lines audit records of mutating requests. Use - for stdout.")
+	flag.BoolVar(&apiAuditEvents, "api-audit-events", false,
+		"Record a Kubernetes Event in every namespace modified by a REST API request.")
+	flag.BoolVar(&apiImpersonate, "api-impersonate", false,
+		"Write SleepInfos impersonating the authenticated REST API user (Impersonate-User/Group), so Kubernetes "+
+			"RBAC and audit apply to the real person. Needs authentication and the impersonate permission.")
+	flag.StringVar(&apiImpersonation.UserPrefix, "api-impersonation-user-prefix", "",
+		"Prefix of the impersonated username, e.g. kube-green: impersonates kube-green:alice.")
+	flag.StringVar(&apiImpersonation.GroupPrefix, "api-impersonation-group-prefix", "",
+		"Prefix of the group impersonated from the REST API role, e.g. kube-green: sends kube-green:admin. "+
+			"Empty sends no role group.")
+	flag.StringVar(&apiImpersonationGroups, "api-impersonation-groups", "",
+		"Comma separated groups added to every impersonated user.")
+	flag.StringVar(&apiGitOps.Repository, "api-gitops-repository", os.Getenv("API_GITOPS_REPOSITORY"),
+		"GitHub repository, as owner/name, where the REST API commits the SleepInfos and TenantSchedules it writes, "+
+			"one manifest per object, instead of writing them to the cluster. Empty writes to the cluster.")
+	flag.StringVar(&apiGitOps.Branch, "api-gitops-branch", os.Getenv("API_GITOPS_BRANCH"),
+		"Branch of the GitOps repository receiving the commits. Empty uses the default branch.")
+	flag.StringVar(&apiGitOps.PullRequestBase, "api-gitops-pull-request-base", os.Getenv("API_GITOPS_PULL_REQUEST_BASE"),
+		"Branch the GitOps commits are proposed to: they go to --api-gitops-branch, created from it when missing, "+
+			"and a pull request into it is opened. Empty commits directly.")
+	flag.StringVar(&apiGitOps.Path, "api-gitops-path", os.Getenv("API_GITOPS_PATH"),
+		"Directory of the GitOps repository holding the manifests, in {namespace}/{kind}-{name}.yaml files.")
+	flag.StringVar(&apiGitOps.APIURL, "api-gitops-api-url", cmp.Or(os.Getenv("API_GITOPS_API_URL"), gitops.DefaultAPIURL),
+		"GitHub REST API of the GitOps repository, https://{host}/api/v3 for GitHub Enterprise.")
+	flag.StringVar(&apiGitOps.TokenFile, "api-gitops-token-file", os.Getenv("API_GITOPS_TOKEN_FILE"),
+		"File with the GitHub token of the GitOps commits, read on every request, e.g. mounted from a Secret.")
+	flag.StringVar(&patchTargets.ConfigMap, "patch-targets-configmap", os.Getenv("PATCH_TARGETS_CONFIGMAP"),
+		"ConfigMap of the kube-green namespace whose "+patchtargets.TargetsKey+" key lists the CRDs slept through "+
+			"patches: group, kind, sleepPatch, wakePatch and ignoreOwnerReferences of each target. It is read on "+
+			"every reconcile, so new operators are supported without rebuilding the image.")
+	flag.BoolVar(&patchTargets.DiscoverCRDs, "discover-annotated-crds", os.Getenv("DISCOVER_ANNOTATED_CRDS") == "true",
+		"Sleep the kinds of the CustomResourceDefinitions annotated with "+kubegreencomv1alpha1.SleepPatchAnnotation+
+			" (and optionally "+kubegreencomv1alpha1.WakePatchAnnotation+"), found on every reconcile.")
+	flag.StringVar(&patchTargetPresets, "patch-target-presets", os.Getenv("PATCH_TARGET_PRESETS"),
+		"Comma separated presets of patch targets for common data operators ("+strings.Join(patchtargets.PresetNames(), ", ")+
+			"). The patch targets ConfigMap and the annotated CRDs override their targets.")
+	flag.BoolVar(&workloadEvents, "workload-events", os.Getenv("WORKLOAD_EVENTS") == "true",
+		"Record a Kubernetes Event on every resource slept or woken up, besides the Events of the SleepInfo.")
+	flag.BoolVar(&wakeUpOnDeletion, "wake-up-on-deletion", os.Getenv("WAKE_UP_ON_DELETION") == "true",
+		"Default of spec.wakeUpOnDeletion: a finalizer wakes up the resources of a SleepInfo deleted while they are asleep.")
+	flag.BoolVar(&restoreStateCRD, "restore-state-crd", os.Getenv("RESTORE_STATE_CRD") == "true",
+		"Store the restore patches of the sleeps in SleepInfoStates instead of the sleepinfo-* secrets.")
+	flag.StringVar(&restoreDataKey.Secret, "restore-data-encryption-secret", os.Getenv("RESTORE_DATA_ENCRYPTION_SECRET"),
+		"Secret of the kube-green namespace whose "+restoredata.EncryptionKeySecretKey+" key holds an AES key of 16, 24 or 32 "+
+			"bytes encrypting with AES-GCM the restore patches stored in the sleepinfo-* secrets.")
+	flag.StringVar(&holidayCalendar.ConfigMap, "holiday-calendar-configmap", os.Getenv("HOLIDAY_CALENDAR_CONFIGMAP"),
+		"ConfigMap of the kube-green namespace whose "+holidays.DatesKey+" key lists the holidays of the cluster, one "+
+			"YYYY-MM-DD date per line, used by the SleepInfos with a holidayPolicy and without holidayCalendar.")
+	flag.StringVar(&alertmanagerClient.URL, "alertmanager-url", os.Getenv("ALERTMANAGER_URL"),
+		"URL of Alertmanager, e.g. http://alertmanager-operated.monitoring:9093. Each sleep silences the alerts of its "+
+			"namespace, or of the spec.alertSilence matchers, until the wake up is complete.")
+	flag.StringVar(&alertmanagerClient.NamespaceLabel, "alertmanager-namespace-label", alertmanager.DefaultNamespaceLabel,
+		"Label of the alerts holding their namespace, matched by the default silence of the sleeps.")
+	flag.StringVar(&alertmanagerClient.BearerTokenFile, "alertmanager-bearer-token-file", "",
+		"File with the bearer token of the Alertmanager requests, read on every request, e.g. the service account token.")
+	flag.BoolVar(&nodeScaleDown, "node-scale-down", false,
+		"Enable spec.nodeScaleDown: the nodes of a dedicated node pool left without workloads by a sleep are cordoned, "+
+			"tainted or released to cluster-autoscaler, and restored before the wake up. Requires access to the nodes and the pods.")
+	flag.BoolVar(&httpHooks, "http-hooks", false,
+		"Enable the HTTP hooks of the SleepInfos, called by the controller from inside the cluster. Failed when disabled.")
+	flag.StringVar(&httpHookAllowedHosts, "http-hook-allowed-hosts", "",
+		"Comma-separated hosts the HTTP hooks can call, \"*.example.com\" allows the subdomains. Empty allows every host.")
+	flag.StringVar(&defaultTimeZone, "default-time-zone", cmp.Or(os.Getenv("DEFAULT_TIME_ZONE"), kubegreencomv1alpha1.DefaultTimeZone),
+		"IANA time zone set by the mutating webhook on the SleepInfos without spec.timeZone, and on the SleepInfos of the ClusterSleepInfos.")
+	flag.StringVar(&wakeOnRequestAddr, "wake-on-request-bind-address", os.Getenv("WAKE_ON_REQUEST_BIND_ADDRESS"),
+		"Address of the wake on request handler, e.g. :8083, where the requests failing while a namespace labelled "+
+			wakeonrequest.EnabledLabel+"=true is asleep are routed, e.g. as the ingress-nginx default backend. "+
+			"It wakes the namespace up and serves a waking up page. Empty disables it.")
+	flag.DurationVar(&wakeOnRequestRetryAfter, "wake-on-request-retry-after", wakeonrequest.DefaultRetryAfter,
+		"Retry-After of the waking up page, after which it is reloaded.")
+	flag.IntVar(&shard.Count, "shard-count", 1,
+		"Number of shards splitting the namespaces between controller deployments. Each shard elects its own leader.")
+	flag.IntVar(&shard.Index, "shard-index", 0,
+		"Shard reconciled by this controller, from 0 to shard-count - 1. The shard 0 also reconciles the ClusterSleepInfos.")
 
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
@@ -101,6 +287,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := shard.Validate(); err != nil {
+		setupLog.Error(err, "invalid shard")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancelation and
@@ -190,10 +381,10 @@ func main() {
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "2bd226ed.kube-green.com",
+		LeaderElectionID:       shard.LeaderElectionID("2bd226ed.kube-green.com"),
 		Client: client.Options{
 			Cache: &client.CacheOptions{
-				DisableFor: []client.Object{&v1.Secret{}},
+				DisableFor: []client.Object{&v1.Secret{}, &kubegreencomv1alpha1.SleepInfoState{}, &v1.Node{}, &v1.Pod{}},
 			},
 		},
 	})
@@ -204,7 +395,54 @@ func main() {
 
 	customMetrics := metrics.SetupMetricsOrDie("kube_green").MustRegister(ctrlMetrics.Registry)
 
-	if err = (&sleepinfocontroller.SleepInfoReconciler{
+	// Get namespace from environment or use default
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		namespace = "keos-core" // Default namespace
+	}
+
+	if emailNotificationsSecret != "" {
+		notificationsConfig.Mailer = &notifications.SMTPMailer{
+			Client:    mgr.GetClient(),
+			Namespace: namespace,
+			Secret:    emailNotificationsSecret,
+		}
+	}
+	var notifier *notifications.Dispatcher
+	var subscriptions *notifications.Store
+	if enableNotifications || notificationsConfig.Mailer != nil {
+		subscriptions = notifications.NewStore(mgr.GetClient(), namespace)
+		notifier = notifications.NewDispatcher(subscriptions, ctrl.Log.WithName("notifications"), notificationsConfig)
+		if err := mgr.Add(notifier); err != nil {
+			setupLog.Error(err, "unable to add webhook notifications dispatcher to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Webhook notifications enabled", "secret", notifications.SecretName, "namespace", namespace)
+	}
+	var digest *notifications.Digest
+	if notificationsConfig.Mailer != nil {
+		day, err := notifications.ParseWeekday(emailDigestDay)
+		if err != nil {
+			setupLog.Error(err, "invalid email digest day")
+			os.Exit(1)
+		}
+		if emailDigestHour < 0 || emailDigestHour > 23 {
+			setupLog.Error(nil, "invalid email digest hour, must be 0 to 23", "hour", emailDigestHour)
+			os.Exit(1)
+		}
+		digest = notifications.NewDigest(mgr.GetClient(), namespace, subscriptions, ctrl.Log.WithName("notifications").WithName("digest"), notifications.DigestConfig{
+			Day:    day,
+			Hour:   emailDigestHour,
+			Mailer: notificationsConfig.Mailer,
+		})
+		if err := mgr.Add(digest); err != nil {
+			setupLog.Error(err, "unable to add email digest to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Email notifications enabled", "secret", emailNotificationsSecret, "digestDay", day, "digestHour", emailDigestHour)
+	}
+
+	reconciler := &sleepinfocontroller.SleepInfoReconciler{
 		Client:                  mgr.GetClient(),
 		Log:                     ctrl.Log.WithName("controllers").WithName("SleepInfo"),
 		Scheme:                  mgr.GetScheme(),
@@ -212,11 +450,115 @@ func main() {
 		SleepDelta:              sleepDelta,
 		ManagerName:             managerName,
 		MaxConcurrentReconciles: maxConcurrentReconciles,
-	}).SetupWithManager(mgr); err != nil {
+		Recorder:                mgr.GetEventRecorderFor("kube-green"),
+		WorkloadEvents:          workloadEvents,
+		WakeUpOnDeletion:        wakeUpOnDeletion,
+		Shard:                   shard,
+		RestoreStateCRD:         restoreStateCRD,
+		NodeScaleDown:           nodeScaleDown,
+		HTTPHooks:               httpHooks,
+		HTTPHookAllowedHosts:    apiv1.ParseCSV(httpHookAllowedHosts),
+	}
+	if restoreDataKey.Secret != "" {
+		restoreDataKey.Client = mgr.GetClient()
+		restoreDataKey.Namespace = namespace
+		reconciler.RestoreDataKey = &restoreDataKey
+		setupLog.Info("Restore data encryption enabled", "secret", restoreDataKey.Secret, "namespace", namespace)
+	}
+	if holidayCalendar.ConfigMap != "" {
+		holidayCalendar.ConfigMapNamespace = namespace
+		reconciler.HolidayCalendar = &holidayCalendar
+		setupLog.Info("Cluster holiday calendar enabled", "configmap", holidayCalendar.ConfigMap, "namespace", namespace)
+	}
+	if alertmanagerClient.URL != "" {
+		reconciler.Alertmanager = &alertmanagerClient
+		setupLog.Info("Alertmanager silences enabled", "url", alertmanagerClient.URL, "namespaceLabel", alertmanagerClient.NamespaceLabel)
+	}
+	if nodeScaleDown {
+		setupLog.Info("Node scale-down enabled")
+	}
+	if httpHooks {
+		setupLog.Info("HTTP hooks enabled", "allowedHosts", reconciler.HTTPHookAllowedHosts)
+	}
+	if shard.IsEnabled() {
+		setupLog.Info("Sharding enabled", "shard", shard.Index, "shards", shard.Count)
+	}
+	if digest != nil {
+		reconciler.Notifier = notifications.Notifiers{notifier, digest}
+	} else if notifier != nil {
+		reconciler.Notifier = notifier
+	}
+	patchTargets.Presets = apiv1.ParseCSV(patchTargetPresets)
+	if _, err := patchtargets.PresetTargets(patchTargets.Presets); err != nil {
+		setupLog.Error(err, "invalid patch target presets")
+		os.Exit(1)
+	}
+	if patchTargets.ConfigMap != "" || patchTargets.DiscoverCRDs || len(patchTargets.Presets) > 0 {
+		patchTargets.Client = mgr.GetClient()
+		patchTargets.RESTMapper = mgr.GetRESTMapper()
+		patchTargets.Namespace = namespace
+		reconciler.PatchTargets = &patchTargets
+		setupLog.Info("Patch targets enabled", "configmap", patchTargets.ConfigMap, "namespace", namespace,
+			"discoverCRDs", patchTargets.DiscoverCRDs, "presets", patchTargets.Presets)
+	}
+	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SleepInfo")
 		os.Exit(1)
 	}
-	if err = webhookv1alpha1.SetupWebhookWithManager(mgr); err != nil {
+	ctx := ctrl.SetupSignalHandler()
+	// The REST API and the TenantSchedule controller write the SleepInfos through the ScheduleService,
+	// which needs the field indexes and the namespace policies
+	if enableAPI || shard.IsFirst() {
+		if err := apiv1.SetupIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+			setupLog.Error(err, "unable to set up schedule field indexes")
+			os.Exit(1)
+		}
+		if namespacePoliciesFile != "" {
+			policies, err := apiv1.LoadNamespacePolicies(namespacePoliciesFile)
+			if err != nil {
+				setupLog.Error(err, "unable to load namespace policies", "file", namespacePoliciesFile)
+				os.Exit(1)
+			}
+			namespacePolicies.Policies = policies
+		}
+		namespacePolicies.Namespace = namespace
+	}
+
+	// The cluster-scoped ClusterSleepInfos and TenantSchedules are reconciled by the first shard only
+	if shard.IsFirst() {
+		if err = (&clustersleepinfocontroller.ClusterSleepInfoReconciler{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("ClusterSleepInfo"),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("kube-green"),
+
+			DefaultTimeZone: defaultTimeZone,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterSleepInfo")
+			os.Exit(1)
+		}
+		tenantScheduleLog := ctrl.Log.WithName("controllers").WithName("TenantSchedule")
+		if err = (&tenantschedulecontroller.TenantScheduleReconciler{
+			Client:   mgr.GetClient(),
+			Log:      tenantScheduleLog,
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("kube-green"),
+			Applier: apiv1.NewService(apiv1.ServiceConfig{
+				Client:    mgr.GetClient(),
+				APIReader: mgr.GetAPIReader(),
+				Logger:    tenantScheduleLog,
+				Namespace: namespace,
+
+				NamespaceLabels:   namespaceLabels,
+				NamespacePolicies: namespacePolicies,
+				RestoreDataKey:    reconciler.RestoreDataKey,
+			}),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TenantSchedule")
+			os.Exit(1)
+		}
+	}
+	if err = webhookv1alpha1.SetupWebhookWithManager(mgr, defaultTimeZone); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "SleepInfo")
 		os.Exit(1)
 	}
@@ -248,22 +590,90 @@ func main() {
 	}
 
 	// Start REST API server if enabled
-	ctx := ctrl.SetupSignalHandler()
 	if enableAPI {
-		// Get namespace from environment or use default
-		namespace := os.Getenv("POD_NAMESPACE")
-		if namespace == "" {
-			namespace = "keos-core" // Default namespace
+		apiCORS.AllowedOrigins = apiv1.ParseCSV(apiCORSOrigins)
+		apiCORS.AllowedHeaders = apiv1.ParseCSV(apiCORSHeaders)
+		apiCORS.AllowedMethods = apiv1.ParseCSV(apiCORSMethods)
+
+		apiAudit := apiv1.AuditConfig{LogPath: apiAuditLog}
+		if apiAuditEvents {
+			apiAudit.Recorder = mgr.GetEventRecorderFor("kube-green-api")
+		}
+
+		var apiTLSConfig *tls.Config
+		if len(apiCertPath) > 0 {
+			setupLog.Info("Initializing REST API certificate watcher using provided certificates",
+				"api-cert-path", apiCertPath, "api-cert-name", apiCertName, "api-key-name", apiCertKey)
+			apiCertWatcher, err := certwatcher.New(
+				filepath.Join(apiCertPath, apiCertName),
+				filepath.Join(apiCertPath, apiCertKey),
+			)
+			if err != nil {
+				setupLog.Error(err, "Failed to initialize REST API certificate watcher")
+				os.Exit(1)
+			}
+			if err := mgr.Add(apiCertWatcher); err != nil {
+				setupLog.Error(err, "unable to add REST API certificate watcher to manager")
+				os.Exit(1)
+			}
+			apiTLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			for _, opt := range tlsOpts {
+				opt(apiTLSConfig)
+			}
+			apiTLSConfig.GetCertificate = apiCertWatcher.GetCertificate
 		}
 
-		apiServer := apiv1.NewServer(apiv1.Config{
+		if apiImpersonate {
+			apiImpersonation.RestConfig = mgr.GetConfig()
+			apiImpersonation.Scheme = mgr.GetScheme()
+			apiImpersonation.Mapper = mgr.GetRESTMapper()
+			apiImpersonation.Groups = apiv1.ParseCSV(apiImpersonationGroups)
+		}
+
+		// One ScheduleService for both transports, sharing the audit log, rate limits and impersonation
+		serviceConfig := apiv1.ServiceConfig{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Logger:    ctrl.Log.WithName("api"),
+			Namespace: namespace,
+
+			NamespaceLabels:   namespaceLabels,
+			NamespacePolicies: namespacePolicies,
+			RestoreDataKey:    reconciler.RestoreDataKey,
+			Impersonation:     apiImpersonation,
+			Audit:             apiAudit,
+			RateLimit:         apiRateLimit,
+		}
+		if notifier != nil {
+			serviceConfig.Notifier = notifier
+		}
+		if apiGitOps.Repository != "" {
+			if err := apiGitOps.Validate(); err != nil {
+				setupLog.Error(err, "invalid GitOps configuration")
+				os.Exit(1)
+			}
+			serviceConfig.GitOps = &apiGitOps
+		}
+		apiService := apiv1.NewService(serviceConfig)
+
+		apiConfig := apiv1.Config{
 			Port:       apiPort,
 			Client:     mgr.GetClient(),
-			APIReader:  mgr.GetAPIReader(),
+			Service:    apiService,
 			Logger:     ctrl.Log.WithName("api"),
 			EnableCORS: enableAPICORS,
+			CORS:       apiCORS,
 			Namespace:  namespace,
-		})
+			Informers:  mgr.GetCache(),
+			Pricing:    savingsPricing,
+			TLSConfig:  apiTLSConfig,
+
+			TrustedProxies: apiv1.ParseCSV(apiTrustedProxies),
+		}
+		if notifier != nil {
+			apiConfig.Subscriptions = subscriptions
+		}
+		apiServer := apiv1.NewServer(apiConfig)
 
 		// Add API server as a runnable to the manager
 		if err := mgr.Add(&runnableServer{
@@ -273,7 +683,42 @@ func main() {
 			setupLog.Error(err, "unable to add REST API server to manager")
 			os.Exit(1)
 		}
-		setupLog.Info("REST API server enabled", "port", apiPort)
+		setupLog.Info("REST API server enabled", "port", apiPort, "tls", apiTLSConfig != nil)
+
+		if grpcPort > 0 {
+			grpcConfig := grpcapi.Config{
+				Port:      grpcPort,
+				Client:    mgr.GetClient(),
+				Service:   apiService,
+				Logger:    ctrl.Log.WithName("grpc"),
+				Namespace: namespace,
+			}
+			grpcServer := grpcapi.NewServer(grpcConfig)
+			if err := mgr.Add(manager.RunnableFunc(grpcServer.Start)); err != nil {
+				setupLog.Error(err, "unable to add gRPC API server to manager")
+				os.Exit(1)
+			}
+			setupLog.Info("gRPC API server enabled", "port", grpcPort)
+		}
+	}
+
+	if wakeOnRequestAddr != "" {
+		wakeOnRequestRequests := wakeonrequest.NewRequestsMetric("kube_green")
+		ctrlMetrics.Registry.MustRegister(wakeOnRequestRequests)
+		if err := mgr.Add(&wakeonrequest.Server{
+			Addr: wakeOnRequestAddr,
+			Handler: &wakeonrequest.Handler{
+				Client:     mgr.GetClient(),
+				Recorder:   mgr.GetEventRecorderFor("kube-green"),
+				Log:        ctrl.Log.WithName("wake-on-request"),
+				RetryAfter: wakeOnRequestRetryAfter,
+				Requests:   wakeOnRequestRequests,
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to add wake on request handler to manager")
+			os.Exit(1)
+		}
+		setupLog.Info("Wake on request handler enabled", "address", wakeOnRequestAddr)
 	}
 
 	setupLog.Info("starting manager")
@@ -0,0 +1,2036 @@
+// Code generated by hack/sdkgen from pkg/apiclient/swagger.json. DO NOT EDIT.
+// Kube-Green REST API 1.0
+
+/** Standard API response structure */
+export interface ApiResponse {
+  /** Optional response data */
+  data?: unknown
+  /** Optional error message (if success is false) */
+  error?: string
+  /** Optional success message */
+  message?: string
+  /** Indicates if the operation was successful */
+  success?: boolean
+}
+
+export interface BulkDeleteItemResult {
+  error?: string
+  /** SleepInfo name, selector deletions only */
+  name?: string
+  namespace?: string
+  /** deleted, not_found or failed */
+  status?: string
+  tenant?: string
+}
+
+export interface BulkDeleteResponse {
+  deleted?: number
+  failed?: number
+  notFound?: number
+  results?: BulkDeleteItemResult[]
+}
+
+export interface CloneItemResult {
+  delays?: DelayConfig
+  error?: string
+  /** Custom exclusions, re-resolved for the target */
+  exclusions?: ExclusionFilter[]
+  /** Inclusions, re-resolved for the target */
+  inclusions?: ExclusionFilter[]
+  /** Full target namespace name */
+  namespace?: string
+  /** User timezone */
+  off?: string
+  /** User timezone */
+  on?: string
+  scheduleName?: string
+  /** Full source namespace name */
+  sourceNamespace?: string
+  /** created or failed */
+  status?: string
+  tenant?: string
+}
+
+export interface CloneScheduleRequest {
+  /** Optional source namespace suffix */
+  namespace?: string
+  /** Optional source schedule name */
+  scheduleName?: string
+  targets: CloneTarget[]
+}
+
+export interface CloneScheduleResponse {
+  created?: number
+  failed?: number
+  results?: CloneItemResult[]
+  sourceTenant?: string
+}
+
+export interface CloneTarget {
+  namespaces?: string[]
+  tenant: string
+}
+
+/** Request to create a new sleep/wake schedule for a tenant */
+export interface CreateScheduleRequest {
+  /** Always applies to cluster (field is ignored but kept for compatibility) */
+  apply?: boolean
+  /** Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"}) */
+  delays?: DelayConfig
+  /** Optional: description of the schedule */
+  description?: string
+  /** Optional: hours asleep after off, instead of on (e.g. 8 or 10.5) */
+  durationHours?: number
+  /** Optional: resources of each namespace never put to sleep, added to the automatic exclusions */
+  exclusions?: NamespaceExclusion[]
+  /** Optional: sleep and wake up once at the next scheduled times, then delete the schedule */
+  executeOnce?: boolean
+  /** Optional: holiday calendar and what the schedule does on holidays */
+  holidays?: HolidayConfig
+  /** Optional: only the matching resources of each namespace are put to sleep */
+  inclusions?: NamespaceInclusion[]
+  /** Optional: Karpenter NodePools scaled to zero while asleep, restored 5 minutes before the wake up */
+  karpenterNodePools?: string[]
+  /** Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso) */
+  namespaces?: string[]
+  /** Sleep time in local timezone (HH:MM format, 24-hour) */
+  off?: string
+  /** Optional: cron expression of the sleep in local timezone, instead of off and weekdays (6#1: first Saturday of the month) */
+  offCron?: string
+  /** Wake time in local timezone (HH:MM format, 24-hour) */
+  on?: string
+  /** Optional: cron expression of the wake up in local timezone, instead of on */
+  onCron?: string
+  /** Optional: name to identify this schedule (allows multiple schedules per namespace) */
+  scheduleName?: string
+  /** Optional: specific days for sleep (overrides weekdays) */
+  sleepDays?: string
+  /** Optional: replicas kept during sleep by the matching workloads of each namespace */
+  sleepReplicas?: NamespaceSleepReplicas[]
+  /** Tenant name (e.g., bdadevdat, bdadevprd) */
+  tenant: string
+  /** Optional: specific days for wake (overrides weekdays) */
+  wakeDays?: string
+  /** Days of week (human format: "lunes-viernes", or numeric: "1-5") */
+  weekdays?: string
+  /** Frontend format: specific days for sleep (mapped to SleepDays) */
+  weekdaysSleep?: string
+  /** Frontend format: specific days for wake (mapped to WakeDays) */
+  weekdaysWake?: string
+}
+
+export interface CreateUserRequest {
+  password: string
+  role: string
+  username: string
+}
+
+export interface DelayConfig {
+  /** Delay for Deployments (e.g., "7m") */
+  deploymentsDelay?: string
+  /** Delay for PgCluster + HDFSCluster (e.g., "0m", "5m") */
+  pgHdfsDelay?: string
+  /** Delay for PgBouncer (e.g., "5m") */
+  pgbouncerDelay?: string
+}
+
+export interface DriftField {
+  /** Value of the spec */
+  actual?: string
+  /** Value applied by the API */
+  expected?: string
+  /** sleepTime, wakeUpTime, weekdays or timeZone */
+  field?: string
+}
+
+export interface DriftReport {
+  checkedAt?: string
+  sleepInfos?: DriftedSleepInfo[]
+}
+
+export interface DriftedSleepInfo {
+  drift?: DriftField[]
+  name?: string
+  namespace?: string
+  scheduleName?: string
+  tenant?: string
+}
+
+export interface EffectiveScheduleResponse {
+  /** Sorted by class name */
+  classes?: ResourceClassSchedule[]
+  /** Full namespace name */
+  namespace?: string
+  /** SleepInfos merged into the timeline */
+  sleepInfos?: string[]
+  tenant?: string
+  /** Timezone of the user* fields */
+  userTimezone?: string
+}
+
+/** Error response structure */
+export interface ErrorResponse {
+  /** HTTP status code */
+  code?: number
+  /** Error message */
+  error?: string
+  /** Always false for error responses */
+  success?: boolean
+}
+
+export interface ExclusionFilter {
+  apiVersion?: string
+  kind?: string
+  /** In, NotIn, Exists or DoesNotExist */
+  matchExpressions?: K8sLabelSelectorRequirement[]
+  matchLabels?: Record<string, string>
+  name?: string
+}
+
+export interface FilterRef {
+  apiVersion?: string
+  kind?: string
+  matchExpressions?: K8sLabelSelectorRequirement[]
+  matchLabels?: Record<string, string>
+  name?: string
+}
+
+export interface HolidayConfig {
+  /** ConfigMap with one YYYY-MM-DD date per line in its "holidays" key */
+  configMap?: string
+  /** Namespace of the ConfigMap, the namespace of each SleepInfo if empty */
+  configMapNamespace?: string
+  /** skipSleep keeps the namespaces awake on holidays, forceSleep keeps them asleep */
+  policy?: 'ignore' | 'skipSleep' | 'forceSleep'
+  /** Time zone of the holiday dates, the user timezone if empty */
+  timeZone?: string
+  /** iCalendar (ICS) feed, instead of the ConfigMap */
+  url?: string
+}
+
+export interface LoginRequest {
+  password: string
+  username: string
+}
+
+export interface ManualOperationItem {
+  name?: string
+  namespace?: string
+  /** Later than requestedAt for staged wake steps */
+  scheduledAt?: string
+}
+
+export interface ManualOperationResponse {
+  action?: string
+  requestedAt?: string
+  sleepInfos?: ManualOperationItem[]
+  tenant?: string
+}
+
+export interface ManualScheduleRequest {
+  /** "sleep" or "wake" */
+  action: string
+  /** Optional: target namespace suffix */
+  namespace?: string
+  /** Optional: target specific schedule name */
+  scheduleName?: string
+}
+
+export interface NamespaceExclusion {
+  filter?: ExclusionFilter
+  namespace?: string
+}
+
+export interface NamespaceFailure {
+  error?: string
+  /** Namespace suffix */
+  namespace?: string
+}
+
+export interface NamespaceInclusion {
+  filter?: ExclusionFilter
+  namespace?: string
+}
+
+export interface NamespaceInfo {
+  /** Schedule description if set */
+  description?: string
+  namespace?: string
+  /** Chronologically ordered schedule */
+  schedule?: SleepInfoSummary[]
+  /** Schedule name if set */
+  scheduleName?: string
+  /** Human-readable summary */
+  summary?: ScheduleSummary
+  timezone?: string
+  weekdays?: string
+}
+
+export interface NamespacePolicy {
+  /** Added to every schedule of the suffix */
+  defaultExclusions?: ExclusionFilter[]
+  description?: string
+  /** Overrides the CRD detection */
+  staggeredWake?: boolean
+  suffix?: string
+  /** Overrides the StatefulSets detection */
+  suspendStatefulSets?: boolean
+}
+
+export interface NamespacePolicyListResponse {
+  policies?: NamespacePolicy[]
+}
+
+export interface NamespaceResourceInfo {
+  autoExclusions?: ExclusionFilter[]
+  hasHdfsCluster?: boolean
+  hasKafkaCluster?: boolean
+  hasMongoDB?: boolean
+  hasOsCluster?: boolean
+  hasOsDashboards?: boolean
+  hasPgBouncer?: boolean
+  hasPgCluster?: boolean
+  hasRedisCluster?: boolean
+  hasVirtualizer?: boolean
+  namespace?: string
+  resourceCounts?: ResourceCounts
+}
+
+export interface NamespaceSavings {
+  /** Core-hours saved per week */
+  cpuCoreHours?: number
+  /** Requested CPU cores of the covered workloads */
+  cpuCores?: number
+  estimatedCost?: number
+  /** Requested memory (GiB) of the covered workloads */
+  memoryGB?: number
+  /** GiB-hours saved per week */
+  memoryGBHours?: number
+  namespace?: string
+  /** Hours per week the namespace is asleep according to its schedule */
+  weeklySleepHours?: number
+  /** Deployments and StatefulSets covered by the SleepInfos */
+  workloads?: number
+}
+
+export interface NamespaceServicesResponse {
+  namespace?: string
+  services?: ServiceInfo[]
+}
+
+export interface NamespaceSleepReplicas {
+  filter?: ExclusionFilter
+  namespace?: string
+  /** Replicas kept during sleep */
+  replicas?: number
+}
+
+export interface NamespaceStatusResponse {
+  /** True when no SleepInfo reports errors */
+  healthy?: boolean
+  /** Full namespace name */
+  namespace?: string
+  sleepInfos?: SleepInfoStatus[]
+  tenant?: string
+}
+
+export interface NextOccurrencesResponse {
+  /** Occurrences computed per SleepInfo operation */
+  count?: number
+  occurrences?: ScheduledOccurrence[]
+  tenant?: string
+}
+
+export interface NextOperationResponse {
+  description?: string
+  namespace?: string
+  /** "SLEEP" or "WAKE_UP" */
+  operation?: string
+  tenant?: string
+  time?: string
+}
+
+export interface OneTimeWindow {
+  end?: string
+  start?: string
+}
+
+export interface RefreshRequest {
+  refreshToken: string
+}
+
+export interface ResourceClassSchedule {
+  /** Deployments, StatefulSets, CronJobs, Postgres, ... or the Kind of a custom patch */
+  class?: string
+  /** Sorted by sleep time */
+  windows?: ScheduleWindow[]
+}
+
+export interface ResourceCounts {
+  cronJobs?: number
+  deployments?: number
+  hdfsClusters?: number
+  kafkaClusters?: number
+  mongoDBs?: number
+  osClusters?: number
+  osDashboardses?: number
+  pgBouncers?: number
+  pgClusters?: number
+  redisClusters?: number
+  statefulSets?: number
+}
+
+export interface SavingsResponse {
+  cpuCoreHours?: number
+  currency?: string
+  /** Only when a price is configured */
+  estimatedCost?: number
+  /** Start of the estimated week */
+  from?: string
+  memoryGBHours?: number
+  namespaces?: NamespaceSavings[]
+  pricePerCoreHour?: number
+  pricePerGBHour?: number
+  tenant?: string
+  to?: string
+}
+
+export interface ScheduleExport {
+  /** Only when includeSecrets=true */
+  secrets?: SecretMetadata[]
+  sleepInfos?: V1alpha1SleepInfo[]
+  tenant?: string
+}
+
+export interface SchedulePreviewResponse {
+  days?: number
+  from?: string
+  /** Namespace suffix, when filtered */
+  namespace?: string
+  occurrences?: ScheduledOccurrence[]
+  tenant?: string
+  to?: string
+}
+
+export interface ScheduleResponse {
+  namespaces?: Record<string, NamespaceInfo>
+  tenant?: string
+}
+
+export interface ScheduleSummary {
+  /** Human-readable description */
+  description?: string
+  /** List of operations in order */
+  operations?: string[]
+  /** When resources go to sleep */
+  sleepTime?: string
+  /** When resources wake up */
+  wakeTime?: string
+}
+
+export interface ScheduleValidationResult {
+  errors?: ValidationIssue[]
+  /** True when there are no errors (warnings do not block creation) */
+  valid?: boolean
+  warnings?: ValidationIssue[]
+}
+
+export interface ScheduleWindow {
+  /** Resources of the class left untouched */
+  excludeRef?: FilterRef[]
+  /** When set, only these resources of the class are handled */
+  includeRef?: FilterRef[]
+  paused?: boolean
+  scheduleName?: string
+  /** Empty when the class is only woken up (e.g. by a wake stage without sleep) */
+  sleepAt?: string
+  sleepDays?: number[]
+  /** SleepInfo executing the sleep */
+  sleepInfo?: string
+  suspendedUntil?: string
+  timeZone?: string
+  userSleepAt?: string
+  userSleepDays?: number[]
+  userWakeAt?: string
+  userWakeDays?: number[]
+  /** Empty when the class is never woken up by the schedule */
+  wakeAt?: string
+  wakeDays?: number[]
+  /** SleepInfo executing the wake, different for pairs */
+  wakeSleepInfo?: string
+}
+
+export interface ScheduleWriteErrorResponse {
+  code?: number
+  error?: string
+  report?: ScheduleWriteReport
+  success?: boolean
+}
+
+export interface ScheduleWriteReport {
+  /** Every SleepInfo written is rolled back */
+  consistent?: boolean
+  /** Namespaces whose SleepInfos failed to be written */
+  failed?: NamespaceFailure[]
+  /** SleepInfos left with the new spec, their rollback failed */
+  notRolledBack?: string[]
+  /** SleepInfos (namespace/name) deleted or restored to their previous spec */
+  rolledBack?: string[]
+}
+
+export interface ScheduledOccurrence {
+  description?: string
+  /** Namespace suffix (datastores, apps, ...) */
+  namespace?: string
+  /** "SLEEP" or "WAKE_UP" */
+  operation?: string
+  /** Resources handled at this step (staged wakes have one entry per step) */
+  resources?: string[]
+  scheduleName?: string
+  sleepInfo?: string
+  /** Instant in cluster timezone (UTC) */
+  time?: string
+  /** Same instant in the user timezone (RFC3339) */
+  userTime?: string
+  userTimezone?: string
+  /** Wake stage of the SleepInfo executed at this step, if any */
+  wakeStage?: string
+}
+
+export interface SecretMetadata {
+  annotations?: Record<string, string>
+  /** Data keys present in the Secret (values are never exported) */
+  keys?: string[]
+  labels?: Record<string, string>
+  name?: string
+  namespace?: string
+}
+
+export interface ServiceInfo {
+  annotations?: Record<string, string>
+  kind?: string
+  labels?: Record<string, string>
+  name?: string
+  readyReplicas?: number
+  replicas?: number
+  status?: string
+}
+
+export interface SleepInfoStatus {
+  /** Ready, LastOperationSucceeded and Degraded */
+  conditions?: K8sCondition[]
+  /** Sleeping, Awake or Transitioning, from status */
+  currentState?: string
+  /** Invalid spec, missed operations and warning events */
+  errors?: string[]
+  /** Resources the last operation failed on */
+  failedResources?: V1alpha1FailedResource[]
+  lastManualOperation?: V1alpha1ManualOperationStatus
+  /** SLEEP or WAKE_UP, from status */
+  lastOperation?: string
+  /** status.lastScheduleTime */
+  lastScheduleTime?: string
+  /** status.lastSleepTime */
+  lastSleepTime?: string
+  /** status.lastWakeUpTime */
+  lastWakeUpTime?: string
+  name?: string
+  /** schedule, window, manual-action, snooze or suspension-end */
+  nextRequeueReason?: string
+  /** When the controller is expected to act next */
+  nextRequeueTime?: string
+  /** Paused schedules are never requeued by the cron */
+  paused?: boolean
+  /** sleep or wake, not yet executed */
+  pendingManualAction?: string
+  /** The controller executed at least one operation */
+  processed?: boolean
+  /** The secret or the SleepInfoState holds restore patches for a wake */
+  restoreDataPresent?: boolean
+  /** sleep, wake, window, or sleep/wake for single objects */
+  role?: string
+  /** Operation recorded in the secret */
+  secretOperation?: string
+  /** The sleepinfo-<name> secret exists */
+  secretPresent?: boolean
+  /** Last operation recorded in the secret */
+  secretScheduledAt?: string
+  /** status.suspendedResourceCounts */
+  suspendedResources?: Record<string, number>
+  /** Pending suspension deadline */
+  suspendedUntil?: string
+}
+
+export interface SleepInfoSummary {
+  annotations?: Record<string, string>
+  /** When an executeOnce SleepInfo ran its operations */
+  completedAt?: string
+  /** Schedule description if set */
+  description?: string
+  /** Fields of the spec changed since the API applied it */
+  drift?: DriftField[]
+  /** True when the spec was changed since the API applied it, e.g. by kubectl edit */
+  drifted?: boolean
+  /** Exclusion filters */
+  excludeRef?: FilterRef[]
+  /** True when the schedule runs once and is then deleted */
+  executeOnce?: boolean
+  /** Holiday calendar and policy, if set */
+  holidays?: HolidayConfig
+  /** Inclusion filters, only matching resources sleep */
+  includeRef?: FilterRef[]
+  /** Karpenter NodePools scaled to zero while asleep */
+  karpenterNodePools?: string[]
+  name?: string
+  namespace?: string
+  /** Human-readable description */
+  operation?: string
+  /** True when the schedule is paused until resumed */
+  paused?: boolean
+  /** List of resources managed (Postgres, HDFS, PgBouncer, Deployments, etc.) */
+  resources?: string[]
+  /** "sleep" or "wake" */
+  role?: string
+  /** Schedule name if set */
+  scheduleName?: string
+  /** Replicas kept during sleep instead of zero */
+  sleepReplicas?: SleepReplicasConfig[]
+  /** True when paused through spec.suspend */
+  suspend?: boolean
+  /** Non-nil when schedule is temporarily suspended */
+  suspendScheduleUntil?: string
+  /** Sleep or wake time, in TimeZone */
+  time?: string
+  /** Timezone of Time, WakeTime and Weekdays (the user timezone, "UTC" for older schedules) */
+  timeZone?: string
+  /** User timezone (e.g. "America/Bogota") — authoritative source, no annotation parsing needed */
+  userTimezone?: string
+  /** Wake stage of the SleepInfo woken up at Time, if any */
+  wakeStage?: string
+  wakeTime?: string
+  weekdays?: string
+  /** One-time sleep, deleted once over */
+  window?: OneTimeWindow
+}
+
+export interface SleepReplicasConfig {
+  filter?: ExclusionFilter
+  /** Replicas kept during sleep */
+  replicas?: number
+}
+
+export interface SnoozeItem {
+  name?: string
+  namespace?: string
+  scheduledSleep?: string
+  snoozedUntil?: string
+}
+
+export interface SnoozeRequest {
+  /** Go duration, e.g. 30m, 2h, 1h30m */
+  duration: string
+  /** Optional namespace suffix filter */
+  namespace?: string
+  /** Optional schedule name filter */
+  scheduleName?: string
+}
+
+export interface SnoozeResponse {
+  duration?: string
+  sleepInfos?: SnoozeItem[]
+  tenant?: string
+}
+
+export interface SuspendScheduleRequest {
+  /** Optional: target namespace suffix */
+  namespace?: string
+  /** Optional: target specific schedule name */
+  scheduleName?: string
+  /** Until is the RFC3339 datetime until which the schedule is suspended (e.g. "2026-06-30T00:00:00Z") */
+  until: string
+}
+
+export interface SuspendedServiceInfo {
+  kind?: string
+  name?: string
+  namespace?: string
+  reason?: string
+  /** SleepInfo that put the service to sleep */
+  sleepInfo?: string
+  suspendedAt?: string
+  willWakeAt?: string
+}
+
+export interface SuspendedServicesResponse {
+  suspended?: SuspendedServiceInfo[]
+  tenant?: string
+}
+
+export interface TenantDetails {
+  /** Suffixes whose last operation was a sleep */
+  asleepNamespaces?: string[]
+  nextSleep?: string
+  nextWake?: string
+  /** asleep, awake, partial or unscheduled */
+  powerState?: string
+  /** Namespace suffix -> has SleepInfos */
+  scheduledNamespaces?: Record<string, boolean>
+  sleepInfoCount?: number
+}
+
+export interface TenantInfo {
+  createdAt?: string
+  /** Only with ?details=true */
+  details?: TenantDetails
+  name?: string
+  namespaces?: string[]
+}
+
+export interface TenantListResponse {
+  tenants?: TenantInfo[]
+}
+
+export interface TimezoneInfo {
+  /** Current abbreviation (e.g. CET, -05) */
+  abbreviation?: string
+  /** IANA name (e.g. America/Bogota) */
+  name?: string
+  /** Current UTC offset (e.g. -05:00) */
+  offset?: string
+  /** Current UTC offset in seconds */
+  offsetSeconds?: number
+  /** Top-level area (e.g. America) */
+  region?: string
+}
+
+export interface TimezoneListResponse {
+  count?: number
+  /** Only when grouped by region */
+  regions?: Record<string, TimezoneInfo[]>
+  timezones?: TimezoneInfo[]
+}
+
+export interface UpdatePasswordRequest {
+  password: string
+}
+
+export interface UpdateRoleRequest {
+  role: string
+}
+
+/** Request to update an existing sleep/wake schedule for a tenant (all fields optional) */
+export interface UpdateScheduleRequest {
+  /** Always applies to cluster (field is ignored) */
+  apply?: boolean
+  /** Optional: hours asleep after off, instead of on (e.g. 8 or 10.5) */
+  durationHours?: number
+  /** Optional: replaces the holiday calendar, kept when omitted */
+  holidays?: HolidayConfig
+  /** Optional: replaces the inclusions, kept when omitted */
+  inclusions?: NamespaceInclusion[]
+  /** Optional: limit to specific namespaces */
+  namespaces?: string[]
+  /** Sleep time in local timezone (HH:MM format, 24-hour) */
+  off?: string
+  /** Wake time in local timezone (HH:MM format, 24-hour) */
+  on?: string
+  /** Optional: specific days for sleep (overrides weekdays) */
+  sleepDays?: string
+  /** Optional: replaces the replicas kept during sleep, kept when omitted */
+  sleepReplicas?: NamespaceSleepReplicas[]
+  /** Optional: specific days for wake (overrides weekdays) */
+  wakeDays?: string
+  /** Days of week (human format: "lunes-viernes", or numeric: "1-5") */
+  weekdays?: string
+  /** Frontend format: specific days for sleep (mapped to sleepDays) */
+  weekdaysSleep?: string
+  /** Frontend format: specific days for wake (mapped to wakeDays) */
+  weekdaysWake?: string
+}
+
+/** User information returned by the API */
+export interface UserInfo {
+  /** User role: admin, operacion, or lectura */
+  role?: string
+  /** Username */
+  username?: string
+}
+
+export interface ValidateScheduleRequest {
+  /** Always applies to cluster (field is ignored but kept for compatibility) */
+  apply?: boolean
+  /** Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"}) */
+  delays?: DelayConfig
+  /** Optional: description of the schedule */
+  description?: string
+  /** Optional: hours asleep after off, instead of on (e.g. 8 or 10.5) */
+  durationHours?: number
+  /** Optional: resources of each namespace never put to sleep, added to the automatic exclusions */
+  exclusions?: NamespaceExclusion[]
+  /** Optional: sleep and wake up once at the next scheduled times, then delete the schedule */
+  executeOnce?: boolean
+  /** Optional: holiday calendar and what the schedule does on holidays */
+  holidays?: HolidayConfig
+  /** Optional: only the matching resources of each namespace are put to sleep */
+  inclusions?: NamespaceInclusion[]
+  /** Optional: Karpenter NodePools scaled to zero while asleep, restored 5 minutes before the wake up */
+  karpenterNodePools?: string[]
+  /** Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso) */
+  namespaces?: string[]
+  /** Sleep time in local timezone (HH:MM format, 24-hour) */
+  off?: string
+  /** Optional: cron expression of the sleep in local timezone, instead of off and weekdays (6#1: first Saturday of the month) */
+  offCron?: string
+  /** Wake time in local timezone (HH:MM format, 24-hour) */
+  on?: string
+  /** Optional: cron expression of the wake up in local timezone, instead of on */
+  onCron?: string
+  /** Optional: name to identify this schedule (allows multiple schedules per namespace) */
+  scheduleName?: string
+  /** Optional: specific days for sleep (overrides weekdays) */
+  sleepDays?: string
+  /** Optional: replicas kept during sleep by the matching workloads of each namespace */
+  sleepReplicas?: NamespaceSleepReplicas[]
+  /** Tenant name (e.g., bdadevdat, bdadevprd) */
+  tenant: string
+  /** Optional: specific days for wake (overrides weekdays) */
+  wakeDays?: string
+  /** Days of week (human format: "lunes-viernes", or numeric: "1-5") */
+  weekdays?: string
+  /** Frontend format: specific days for sleep (mapped to SleepDays) */
+  weekdaysSleep?: string
+  /** Frontend format: specific days for wake (mapped to WakeDays) */
+  weekdaysWake?: string
+}
+
+export interface ValidationIssue {
+  /** Machine-readable code (e.g. NAMESPACE_NOT_FOUND) */
+  code?: string
+  /** Request field the issue refers to */
+  field?: string
+  message?: string
+  /** Namespace the issue refers to */
+  namespace?: string
+}
+
+export interface WebhookSubscriptionInfo {
+  createdAt?: string
+  createdBy?: string
+  digest?: boolean
+  emails?: string[]
+  events?: string[]
+  format?: string
+  id?: string
+  /** True when the deliveries are signed */
+  signed?: boolean
+  template?: string
+  tenant?: string
+  url?: string
+}
+
+export interface WebhookSubscriptionRequest {
+  /** Optional: email the weekly digest of the tenant (sleeps, wake ups and estimated savings) instead of the events */
+  digest?: boolean
+  /** Optional: recipients of the events by email instead of the URL, requires the SMTP settings */
+  emails?: string[]
+  /** Optional: event types, all of them when empty */
+  events?: string[]
+  /** Optional: body format, json (the event, default), teams (Adaptive Card for a Teams workflow webhook) or template */
+  format?: string
+  /** Optional: HMAC-SHA256 key, the body signature is sent in X-Kube-Green-Signature */
+  secret?: string
+  /** Optional: Go template of the body with format template, executed with the event. {{ json .Namespace }} quotes a value */
+  template?: string
+  /** Optional: only events of this tenant (global subscriptions require admin role) */
+  tenant?: string
+  /** Callback URL receiving the events as JSON POSTs, required without emails */
+  url?: string
+}
+
+export interface WindowItem {
+  name?: string
+  namespace?: string
+}
+
+export interface WindowScheduleRequest {
+  description?: string
+  /** RFC3339, or YYYY-MM-DDTHH:MM in the user timezone */
+  end: string
+  exclusions?: NamespaceExclusion[]
+  namespaces: string[]
+  scheduleName?: string
+  /** RFC3339, or YYYY-MM-DDTHH:MM in the user timezone */
+  start: string
+}
+
+export interface WindowScheduleResponse {
+  end?: string
+  sleepInfos?: WindowItem[]
+  start?: string
+  tenant?: string
+}
+
+export interface V1alpha1AlertMatcher {
+  /** Name of the label. */
+  name?: string
+  /** Operator comparing the label with the value: = (the default), !=, =~ or !~. */
+  operator?: string
+  /** Value of the label, a regular expression with the =~ and !~ operators. */
+  value?: string
+}
+
+export interface V1alpha1AlertSilence {
+  /** If Disabled is set to true, the alerts are not silenced during the sleep. */
+  disabled?: boolean
+  /**
+   * Matchers of the alerts silenced, all of them must match. Defaults to the namespace label of the
+   * alerts equal to the namespace of the SleepInfo.
+   */
+  matchers?: V1alpha1AlertMatcher[]
+}
+
+export interface V1alpha1DryRunResource {
+  kind?: string
+  name?: string
+}
+
+export interface V1alpha1DryRunStatus {
+  /** ExecutedAt is the time the operation was simulated. */
+  executedAt?: string
+  /**
+   * FailedResources are the resources whose patch was rejected by the API server, or which would be
+   * skipped.
+   */
+  failedResources?: V1alpha1FailedResource[]
+  /** OperationType simulated, SLEEP or WAKE_UP. */
+  operation?: string
+  /** ResourceCounts is the number of resources that would be patched by kind. */
+  resourceCounts?: Record<string, number>
+  /** Resources that would be patched, the first 100. */
+  resources?: V1alpha1DryRunResource[]
+}
+
+export interface V1alpha1FailedResource {
+  kind?: string
+  name?: string
+  /** Reason is the error, or why the resource was skipped. */
+  reason?: string
+}
+
+export interface V1alpha1FilterRef {
+  /** ApiVersion of the kubernetes resources. */
+  apiVersion?: string
+  /** Kind of the kubernetes resources of the specific version. */
+  kind?: string
+  /**
+   * MatchExpressions which identify the kubernetes resource by label requirements.
+   * Supported operators are In, NotIn, Exists and DoesNotExist.
+   */
+  matchExpressions?: K8sLabelSelectorRequirement[]
+  /** MatchLabels which identify the kubernetes resource by labels */
+  matchLabels?: Record<string, string>
+  /** Name which identify the kubernetes resource. */
+  name?: string
+}
+
+export interface V1alpha1HTTPHook {
+  /** Body of the request. */
+  body?: string
+  /** Headers of the request. */
+  headers?: Record<string, string>
+  /** Method of the request. Defaults to POST. */
+  method?: string
+  /** URL called, http or https. */
+  url?: string
+}
+
+export interface V1alpha1HolidayCalendar {
+  /**
+   * ConfigMap holding the holidays in its `holidays` key, one YYYY-MM-DD date per line.
+   * Text after a # is a comment.
+   */
+  configMap?: string
+  /** Namespace of the ConfigMap, the namespace of the SleepInfo if not set. */
+  configMapNamespace?: string
+  /**
+   * TimeZone the holiday dates are in, in IANA time zone identifier.
+   * It defaults to the time zone of the SleepInfo.
+   */
+  timeZone?: string
+  /** URL of an iCalendar (ICS) feed: every day covered by one of its events is a holiday. */
+  url?: string
+}
+
+export type V1alpha1HolidayPolicy = 'ignore' | 'skipSleep' | 'forceSleep'
+
+export interface V1alpha1Hook {
+  /**
+   * FailurePolicy is what the operation does when the hook fails: Abort (the default) skips it,
+   * or the next post-wake hooks, Continue executes it anyway.
+   */
+  failurePolicy?: V1alpha1HookFailurePolicy
+  /** HTTP calls an URL, successful with a 2xx response. */
+  http?: V1alpha1HTTPHook
+  /** Job is created from the template in the namespace of the SleepInfo, successful once complete. */
+  job?: unknown
+  /** Name of the hook, unique among the hooks of the operation. */
+  name?: string
+  /** Timeout of the hook, as a duration such as 2m. Defaults to 30s for HTTP calls and 10m for Jobs. */
+  timeout?: string
+  /**
+   * If WaitForReady is set to true, the hook waits until the resources woken up are ready, for up
+   * to 10m, before it runs. Only for postWakeHooks.
+   */
+  waitForReady?: boolean
+}
+
+export type V1alpha1HookFailurePolicy = 'Abort' | 'Continue'
+
+export type V1alpha1HookPhase = 'Pending' | 'Running' | 'Succeeded' | 'Failed'
+
+export interface V1alpha1HookStatus {
+  /** FinishedAt is the time the hook succeeded or failed. */
+  finishedAt?: string
+  /** Job created by the hook. */
+  job?: string
+  /** Message is the error of a failed hook. */
+  message?: string
+  /** Name of the hook. */
+  name?: string
+  /** OperationType the hook is run for, SLEEP or WAKE_UP. */
+  operation?: string
+  /** Phase is Pending, Running, Succeeded or Failed. */
+  phase?: V1alpha1HookPhase
+  /** StartedAt is the time the hook was started, or began to wait for the resources to be ready. */
+  startedAt?: string
+}
+
+export type V1alpha1JobPolicy = 'letFinish' | 'suspend'
+
+export interface V1alpha1Karpenter {
+  /** NodePools are the names of the Karpenter NodePools. */
+  nodePools?: string[]
+  /**
+   * RestoreBefore is how long before the wake up the limits of the NodePools are restored. Defaults
+   * to 5m.
+   */
+  restoreBefore?: unknown
+}
+
+export interface V1alpha1ManualOperationStatus {
+  /** Action requested, sleep or wake. */
+  action?: string
+  /** ExecutedAt is the time the controller executed the manual action. */
+  executedAt?: string
+  /** OperationType executed by the controller, SLEEP or WAKE_UP. */
+  operation?: string
+  /** RequestedAt is the time the manual action was requested. */
+  requestedAt?: string
+}
+
+export interface V1alpha1NodeScaleDown {
+  /**
+   * Action on the nodes without workloads, other than the DaemonSet and static pods: Cordon (the
+   * default), Taint or ScaleDown.
+   */
+  action?: V1alpha1NodeScaleDownAction
+  /** NodeSelector selects the nodes of the dedicated node pool by their labels. */
+  nodeSelector?: Record<string, string>
+}
+
+export type V1alpha1NodeScaleDownAction = 'Cordon' | 'Taint' | 'ScaleDown'
+
+export interface V1alpha1Patch {
+  /** Patch is the json6902 patch to apply to the target resource. */
+  patch?: string
+  /** Target is the target resource to patch. */
+  target?: V1alpha1PatchTarget
+}
+
+export interface V1alpha1PatchTarget {
+  /** Group of the Kubernetes resources. */
+  group?: string
+  /** Kind of the Kubernetes resources. */
+  kind?: string
+}
+
+export type V1alpha1RestorePolicy = 'Skip' | 'Overwrite' | 'Merge'
+
+export interface V1alpha1ScheduleWindow {
+  /** End is when the resources are woken up. It must be after Start. */
+  end?: string
+  /** Start is when the resources are put to sleep. */
+  start?: string
+}
+
+export interface V1alpha1SleepInfo {
+  /**
+   * APIVersion defines the versioned schema of this representation of an object.
+   * Servers should convert recognized schemas to the latest internal value, and
+   * may reject unrecognized values.
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+   */
+  apiVersion?: string
+  /**
+   * Kind is a string value representing the REST resource this object represents.
+   * Servers may infer this from the endpoint the client submits requests to.
+   * Cannot be updated.
+   * In CamelCase.
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+   */
+  kind?: string
+  metadata?: K8sObjectMeta
+  spec?: V1alpha1SleepInfoSpec
+  status?: V1alpha1SleepInfoStatus
+}
+
+export interface V1alpha1SleepInfoSpec {
+  /**
+   * AlertSilence configures the Alertmanager silence created by each sleep, when the manager has an
+   * Alertmanager configured, and expired once the wake up is complete. By default it silences the
+   * alerts of the namespace of the SleepInfo.
+   */
+  alertSilence?: V1alpha1AlertSilence
+  /**
+   * DeleteWhenCompleted deletes an ExecuteOnce SleepInfo once it is completed by a wake up, with
+   * the sleep SleepInfo of its pair. SleepInfos completed by a sleep are kept, as their secret
+   * holds the restore patches of the resources asleep.
+   */
+  deleteWhenCompleted?: boolean
+  /**
+   * DryRun computes the resources each operation would patch, sending the patches to the API
+   * server as dry run, and reports them in status.lastDryRun without changing anything.
+   */
+  dryRun?: boolean
+  /**
+   * ExcludeRef define the resource to exclude from the sleep.
+   * Exclusion rules are evaluated in AND condition.
+   */
+  excludeRef?: V1alpha1FilterRef[]
+  /**
+   * ExecuteOnce runs the next scheduled operations once: the SleepInfo is completed after its first
+   * scheduled wake up, or after its first scheduled sleep when it has no wakeUpAt, and skips the
+   * later ones. Manual actions still work.
+   */
+  executeOnce?: boolean
+  /**
+   * HolidayCalendar lists the holidays the HolidayPolicy applies to. Defaults to the cluster holiday
+   * calendar of the --holiday-calendar-configmap flag.
+   */
+  holidayCalendar?: V1alpha1HolidayCalendar
+  /**
+   * HolidayPolicy is what the scheduled operations do on the dates of the HolidayCalendar:
+   * skipSleep keeps the resources awake, forceSleep skips the wake up so the resources stay asleep
+   * until the next working day, ignore (the default) runs the schedule as usual.
+   */
+  holidayPolicy?: V1alpha1HolidayPolicy
+  /**
+   * IncludeRef define the resource to include from the sleep.
+   * Inclusion rules are evaluated in AND condition.
+   */
+  includeRef?: V1alpha1FilterRef[]
+  /**
+   * JobPolicy is what happens on sleep to the Jobs of the namespace still running: suspend sets
+   * spec.suspend on them and resumes them on wake up, letFinish (the default) leaves them running.
+   * Jobs created by a CronJob are managed with their CronJob.
+   */
+  jobPolicy?: V1alpha1JobPolicy
+  /**
+   * Karpenter scales the limits of Karpenter NodePools to zero during the sleep, and restores them
+   * shortly before the wake up so that their capacity is provisioned for its wake stages.
+   */
+  karpenter?: V1alpha1Karpenter
+  /**
+   * NamespaceSelector, when set, also applies the SleepInfo to the namespaces it selects: a copy
+   * with the same name and spec, without namespaceSelector, is kept in each of them. Creating it,
+   * or changing the selector, requires permission to create SleepInfos in the selected namespaces.
+   */
+  namespaceSelector?: unknown
+  /**
+   * NodeScaleDown releases the nodes of a dedicated node pool left without workloads by the sleep,
+   * when the manager has node scale-down enabled. They are restored before the wake up.
+   */
+  nodeScaleDown?: V1alpha1NodeScaleDown
+  /** Patches is a list of json 6902 patches to apply to the target resources. */
+  patches?: V1alpha1Patch[]
+  /**
+   * PostWakeHooks are run in order once the wake up is complete, after its last wake stage, e.g. to
+   * warm caches, register consumers again or run smoke tests. A failed hook skips the next ones
+   * unless its failurePolicy is Continue.
+   */
+  postWakeHooks?: V1alpha1Hook[]
+  /**
+   * PreSleepDelay announces the sleep that long before it: the resources to sleep are annotated
+   * with the time of their sleep, in the SleepAtAnnotation, giving long-running requests and batch
+   * jobs time to finish before their replicas are patched. For example, 15m.
+   */
+  preSleepDelay?: unknown
+  /**
+   * PreSleepHooks are run in order before the sleep patches, e.g. to flush caches or notify an
+   * application to checkpoint. The sleep waits for the Jobs of the hooks, and is aborted by a
+   * failed hook unless its failurePolicy is Continue.
+   */
+  preSleepHooks?: V1alpha1Hook[]
+  /**
+   * RestorePolicy is what the wake up does to the resources modified since the sleep: Skip (the
+   * default) leaves them as they are, Merge restores them unless the fields changed by the sleep
+   * were modified, Overwrite always restores those fields.
+   */
+  restorePolicy?: V1alpha1RestorePolicy
+  /**
+   * Schedules are several weekly schedules, instead of weekdays, sleepAt and wakeUpAt: e.g. the
+   * weeknights and the whole weekend. Their sleeps and wake ups must alternate, a schedule cannot
+   * sleep while another one keeps the resources asleep.
+   */
+  schedules?: V1alpha1WeeklySchedule[]
+  /**
+   * Hours:Minutes
+   * 
+   * Accept cron schedule for both hour and minute.
+   * For example, *:*\/2 is set to configure a run every even minute.
+   * It also accepts a standard cron expression, which ignores Weekdays, whose day of week can be
+   * the nth weekday of the month: "0 20 * * 6#1" sleeps on the first Saturday of each month.
+   * Required unless Window or Schedules are set.
+   */
+  sleepAt?: string
+  /**
+   * SleepDuration is how long the resources sleep, as an alternative to WakeUpTime.
+   * The wake up time is computed from each sleep executed, so it is correct across
+   * daylight saving time changes. For example, 10h or 8h30m.
+   */
+  sleepDuration?: unknown
+  /**
+   * SleepReplicas keeps some replicas of the matching Deployments and StatefulSets during sleep
+   * instead of scaling them to zero. The first matching entry is used, and the original replicas
+   * are restored on wake up.
+   */
+  sleepReplicas?: V1alpha1SleepReplicas[]
+  /**
+   * Suspend, like the one of CronJobs, skips the scheduled sleeps and wake ups until it is set back
+   * to false, keeping the SleepInfo and its restore data. Manual actions still override it.
+   */
+  suspend?: boolean
+  /** If SuspendCronjobs is set to true, on sleep the cronjobs of the namespace will be suspended. */
+  suspendCronJobs?: boolean
+  /** If SuspendDeployments is set to false, on sleep the deployment of the namespace will not be suspended. By default Deployment will be suspended. */
+  suspendDeployments?: boolean
+  /**
+   * If SuspendDeploymentsPgbouncer is set to true, on sleep all PgBouncer CRDs in the namespace
+   * will be managed by modifying spec.instances (similar to native deployments with spec.replicas).
+   * NOTE: PgBouncer is a CRD that generates Deployments (not StatefulSets), hence the "Deployments" prefix.
+   * Defaults to false (does not manage PgBouncer).
+   */
+  suspendDeploymentsPgbouncer?: boolean
+  /**
+   * If SuspendECK is set to true, on sleep the count of every nodeSet of the Elastic (ECK)
+   * Elasticsearch clusters and the count of the Kibana instances are set to 0, even when they are
+   * owned by another controller. The original counts are restored on wake up.
+   * Defaults to false (does not manage Elasticsearch and Kibana).
+   */
+  suspendECK?: boolean
+  /**
+   * If SuspendFlink is set to true, on sleep the jobs of the Apache Flink FlinkDeployments are
+   * suspended taking a savepoint, and they are resumed from it on wake up.
+   * Defaults to false (does not manage FlinkDeployments).
+   */
+  suspendFlink?: boolean
+  /**
+   * If SuspendHPA is set to true, on sleep all HorizontalPodAutoscalers in the namespace are annotated
+   * with kube-green.stratio.com/paused and their minReplicas is lowered to 1, so they do not scale
+   * up again the workloads put to sleep. The original values are restored on wake up.
+   * Defaults to false (does not manage HorizontalPodAutoscalers).
+   */
+  suspendHPA?: boolean
+  /**
+   * If SuspendKEDA is set to true, on sleep the KEDA ScaledObjects (keda.sh) targeting a workload put
+   * to sleep are annotated with autoscaling.keda.sh/paused-replicas=0, so KEDA does not scale it up
+   * again. The annotation is removed on wake up.
+   * Defaults to false (does not manage ScaledObjects).
+   */
+  suspendKEDA?: boolean
+  /**
+   * If SuspendKnative is set to true, on sleep all Knative Services (serving.knative.dev) in the namespace
+   * will be managed by setting the autoscaling.knative.dev/min-scale annotation of their revision template to 0.
+   * The original value is restored on wake up.
+   * Defaults to false (does not manage Knative Services).
+   */
+  suspendKnative?: boolean
+  /**
+   * SuspendScheduleUntil temporarily suspends the cron schedule until the given time.
+   * While suspended, neither sleep nor wake cron triggers will execute.
+   * Manual actions (via kube-green.stratio.com/manual-action annotation) still override the suspension.
+   * Set to nil or a past time to resume normal scheduling.
+   */
+  suspendScheduleUntil?: string
+  /** If SuspendStatefulSets is set to false, on sleep the statefulset of the namespace will not be suspended. By default StatefulSet will be suspended. */
+  suspendStatefulSets?: boolean
+  /**
+   * If SuspendStatefulSetsHdfs is set to true, on sleep all HDFSCluster CRDs in the namespace
+   * will be managed by applying the hdfscluster.stratio.com/shutdown annotation.
+   * Defaults to false (does not manage HDFSCluster).
+   */
+  suspendStatefulSetsHdfs?: boolean
+  /**
+   * If SuspendStatefulSetsKafka is set to true, on sleep all KafkaCluster CRDs in the namespace
+   * will be managed by applying the kafkacluster.stratio.com/shutdown annotation.
+   * Defaults to false (does not manage KafkaCluster).
+   */
+  suspendStatefulSetsKafka?: boolean
+  /**
+   * If SuspendStatefulSetsOpenSearch is set to true, on sleep all OsCluster CRDs in the namespace
+   * will be managed by applying the oscluster.stratio.com/shutdown annotation.
+   * Defaults to false (does not manage OsCluster).
+   */
+  suspendStatefulSetsOpenSearch?: boolean
+  /**
+   * If SuspendStatefulSetsOsDashboards is set to true, on sleep all OsDashboards CRDs in the namespace
+   * will be managed by modifying spec.replicas (similar to native deployments with spec.replicas).
+   * Defaults to false (does not manage OsDashboards).
+   */
+  suspendStatefulSetsOsDashboards?: boolean
+  /**
+   * If SuspendStatefulSetsPostgres is set to true, on sleep all PgCluster CRDs in the namespace
+   * will be managed by applying the pgcluster.stratio.com/shutdown annotation.
+   * Defaults to false (does not manage PgCluster).
+   */
+  suspendStatefulSetsPostgres?: boolean
+  /**
+   * If SuspendStrimzi is set to true, on sleep the Strimzi (kafka.strimzi.io) KafkaNodePools and
+   * KafkaConnects are scaled to 0 replicas, as the kafka and zookeeper replicas of the Kafka clusters
+   * without node pools. The original replicas are restored on wake up. Without wakeStages, the
+   * Kafka clusters wake up first and the Deployments, StatefulSets and KafkaConnects wait for them
+   * to be ready.
+   * Defaults to false (does not manage Strimzi resources).
+   */
+  suspendStrimzi?: boolean
+  /**
+   * Time zone to set the schedule, in IANA time zone identifier.
+   * It is not required, default to UTC.
+   * For example, for the Italy time zone set Europe/Rome.
+   */
+  timeZone?: string
+  /**
+   * WakeStages wakes up the resources in sequence instead of all at once: the resources matching a
+   * stage are woken up once its delay after the wake up time is over, the others at the wake up time.
+   */
+  wakeStages?: V1alpha1WakeStage[]
+  /**
+   * Hours:Minutes
+   * 
+   * Accept cron schedule for both hour and minute.
+   * For example, *:*\/2 is set to configure a run every even minute.
+   * It also accepts a standard cron expression, like sleepAt.
+   * It is not required.
+   */
+  wakeUpAt?: string
+  /**
+   * WakeUpOnDeletion adds a finalizer so that deleting the SleepInfo while its resources are asleep
+   * first wakes them up with the stored restore patches. Defaults to the --wake-up-on-deletion flag.
+   */
+  wakeUpOnDeletion?: boolean
+  /**
+   * Weekdays are in cron notation.
+   * 
+   * For example, to configure a schedule from monday to friday, set it to "1-5".
+   * Required unless Window or Schedules are set or sleepAt is a cron expression.
+   */
+  weekdays?: string
+  /**
+   * Window configures a one-time sleep between two dates instead of the weekly schedule:
+   * weekdays, sleepAt and wakeUpAt are ignored, the namespace sleeps at window.start, wakes up
+   * at window.end and the SleepInfo is deleted once the window is over.
+   */
+  window?: V1alpha1ScheduleWindow
+}
+
+export interface V1alpha1SleepInfoStatus {
+  /** AlertSilenceID is the Alertmanager silence of the current sleep, expired once the wake up is complete. */
+  alertSilenceID?: string
+  /** CompletedAt is the time an ExecuteOnce SleepInfo executed its scheduled operations. */
+  completedAt?: string
+  /**
+   * Conditions are Ready, false when the schedule cannot be handled, LastOperationSucceeded,
+   * the result of the last sleep or wake up, Degraded, true when it failed on some resources, and
+   * ReconcileSkipped, true while the skip-reconcile annotation stops its reconciliation.
+   */
+  conditions?: K8sCondition[]
+  /**
+   * CurrentState is Sleeping after a sleep, Awake after a wake up and Transitioning while an
+   * operation is in progress, failed, or has wake stages left.
+   */
+  currentState?: string
+  /**
+   * FailedResources are the resources the last operation failed to sleep or wake up, or skipped
+   * because they changed since the sleep.
+   */
+  failedResources?: V1alpha1FailedResource[]
+  /** Hooks are the results of the hooks of the last operations, while they run and once finished. */
+  hooks?: V1alpha1HookStatus[]
+  /** LastDryRun reports the resources the last operation of a dryRun SleepInfo would have patched. */
+  lastDryRun?: V1alpha1DryRunStatus
+  /**
+   * LastManualOperation records the last operation executed on demand
+   * (kube-green.stratio.com/manual-action annotation) instead of by the schedule.
+   */
+  lastManualOperation?: V1alpha1ManualOperationStatus
+  /** Information when was the last time the run was successfully scheduled. */
+  lastScheduleTime?: string
+  /** LastSleepTime is the time of the last sleep executed successfully. */
+  lastSleepTime?: string
+  /** LastWakeUpTime is the time of the last wake up executed successfully. */
+  lastWakeUpTime?: string
+  /**
+   * ObservedGeneration is the generation of the SleepInfo last handled by the controller: the
+   * status is up to date with the spec when it equals metadata.generation.
+   */
+  observedGeneration?: number
+  /**
+   * The operation type handled in last schedule. SLEEP or WAKE_UP are the
+   * possibilities
+   */
+  operation?: string
+  /**
+   * SavedResources are the CPU and memory requested by the pods of the workloads slept, until they
+   * wake up.
+   */
+  savedResources?: unknown
+  /** ScaledDownNodes are the nodes released by the current sleep, restored before the wake up. */
+  scaledDownNodes?: string[]
+  /** SuspendedResourceCounts is the number of resources slept by kind, until they wake up. */
+  suspendedResourceCounts?: Record<string, number>
+  /**
+   * SuspendedUntil reflects the current suspension deadline, mirrored from spec.suspendScheduleUntil.
+   * Cleared automatically once the deadline has passed.
+   */
+  suspendedUntil?: string
+}
+
+export interface V1alpha1SleepReplicas {
+  /** ApiVersion of the kubernetes resources. */
+  apiVersion?: string
+  /** Kind of the kubernetes resources of the specific version. */
+  kind?: string
+  /**
+   * MatchExpressions which identify the kubernetes resource by label requirements.
+   * Supported operators are In, NotIn, Exists and DoesNotExist.
+   */
+  matchExpressions?: K8sLabelSelectorRequirement[]
+  /** MatchLabels which identify the kubernetes resource by labels */
+  matchLabels?: Record<string, string>
+  /** Name which identify the kubernetes resource. */
+  name?: string
+  /** Replicas kept during sleep. */
+  replicas?: number
+}
+
+export interface V1alpha1WakeStage {
+  /** Delay of the stage after the wake up time, as a duration such as 5m. Defaults to 0. */
+  delay?: string
+  /** Name of the stage. */
+  name?: string
+  /**
+   * ReadyTimeout is the longest wait for the resources of the stage to be ready, as a duration such
+   * as 15m. Once over, the next stage starts anyway. Defaults to 10m.
+   */
+  readyTimeout?: string
+  /**
+   * Targets are the resources woken up by the stage. The matchLabels and matchExpressions of a
+   * target must all match, and a resource is woken up by the first stage with a matching target.
+   */
+  targets?: V1alpha1FilterRef[]
+  /**
+   * If WaitForReady is set to true, the next stage also waits until the resources woken up by this
+   * stage are ready: replicas ready for workloads, Ready condition or running phase for the CRDs.
+   */
+  waitForReady?: boolean
+}
+
+export interface V1alpha1WeeklySchedule {
+  /** SleepTime is Hours:Minutes or a standard cron expression, like the sleepAt of the SleepInfo. */
+  sleepAt?: string
+  /** WakeUpTime is Hours:Minutes or a standard cron expression, like the wakeUpAt of the SleepInfo. */
+  wakeUpAt?: string
+  /**
+   * Weekdays are in cron notation, like the weekdays of the SleepInfo.
+   * Required unless sleepAt and wakeUpAt are cron expressions.
+   */
+  weekdays?: string
+}
+
+export interface K8sCondition {
+  /**
+   * lastTransitionTime is the last time the condition transitioned from one status to another.
+   * This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+   */
+  lastTransitionTime?: string
+  /**
+   * message is a human readable message indicating details about the transition.
+   * This may be an empty string.
+   */
+  message?: string
+  /**
+   * observedGeneration represents the .metadata.generation that the condition was set based upon.
+   * For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date
+   * with respect to the current state of the instance.
+   */
+  observedGeneration?: number
+  /**
+   * reason contains a programmatic identifier indicating the reason for the condition's last transition.
+   * Producers of specific condition types may define expected values and meanings for this field,
+   * and whether the values are considered a guaranteed API.
+   * The value should be a CamelCase string.
+   * This field may not be empty.
+   */
+  reason?: string
+  /** status of the condition, one of True, False, Unknown. */
+  status?: 
+  /**
+   * type of condition in CamelCase or in foo.example.com/CamelCase.
+   * ---
+   * Many .condition.type values are consistent across resources like Available, but because arbitrary conditions can be
+   * useful (see .node.status.conditions), the ability to deconflict is important.
+   * The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+   */
+  type?: string
+}
+
+export interface K8sLabelSelectorRequirement {
+  /** key is the label key that the selector applies to. */
+  key?: string
+  /**
+   * operator represents a key's relationship to a set of values.
+   * Valid operators are In, NotIn, Exists and DoesNotExist.
+   */
+  operator?: 
+  /**
+   * values is an array of string values. If the operator is In or NotIn,
+   * the values array must be non-empty. If the operator is Exists or DoesNotExist,
+   * the values array must be empty. This array is replaced during a strategic
+   * merge patch.
+   */
+  values?: string[]
+}
+
+export interface K8sObjectMeta {
+  /**
+   * Annotations is an unstructured key value map stored with a resource that may be
+   * set by external tools to store and retrieve arbitrary metadata. They are not
+   * queryable and should be preserved when modifying objects.
+   * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/annotations
+   */
+  annotations?: Record<string, string>
+  /**
+   * CreationTimestamp is a timestamp representing the server time when this object was
+   * created. It is not guaranteed to be set in happens-before order across separate operations.
+   * Clients may not set this value. It is represented in RFC3339 form and is in UTC.
+   * 
+   * Populated by the system.
+   * Read-only.
+   * Null for lists.
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+   */
+  creationTimestamp?: string
+  /**
+   * Number of seconds allowed for this object to gracefully terminate before
+   * it will be removed from the system. Only set when deletionTimestamp is also set.
+   * May only be shortened.
+   * Read-only.
+   */
+  deletionGracePeriodSeconds?: number
+  /**
+   * DeletionTimestamp is RFC 3339 date and time at which this resource will be deleted. This
+   * field is set by the server when a graceful deletion is requested by the user, and is not
+   * directly settable by a client. The resource is expected to be deleted (no longer visible
+   * from resource lists, and not reachable by name) after the time in this field, once the
+   * finalizers list is empty. As long as the finalizers list contains items, deletion is blocked.
+   * Once the deletionTimestamp is set, this value may not be unset or be set further into the
+   * future, although it may be shortened or the resource may be deleted prior to this time.
+   * For example, a user may request that a pod is deleted in 30 seconds. The Kubelet will react
+   * by sending a graceful termination signal to the containers in the pod. After that 30 seconds,
+   * the Kubelet will send a hard termination signal (SIGKILL) to the container and after cleanup,
+   * remove the pod from the API. In the presence of network partitions, this object may still
+   * exist after this timestamp, until an administrator or automated process can determine the
+   * resource is fully terminated.
+   * If not set, graceful deletion of the object has not been requested.
+   * 
+   * Populated by the system when a graceful deletion is requested.
+   * Read-only.
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+   */
+  deletionTimestamp?: string
+  /**
+   * Must be empty before the object is deleted from the registry. Each entry
+   * is an identifier for the responsible component that will remove the entry
+   * from the list. If the deletionTimestamp of the object is non-nil, entries
+   * in this list can only be removed.
+   * Finalizers may be processed and removed in any order.  Order is NOT enforced
+   * because it introduces significant risk of stuck finalizers.
+   * finalizers is a shared field, any actor with permission can reorder it.
+   * If the finalizer list is processed in order, then this can lead to a situation
+   * in which the component responsible for the first finalizer in the list is
+   * waiting for a signal (field value, external system, or other) produced by a
+   * component responsible for a finalizer later in the list, resulting in a deadlock.
+   * Without enforced ordering finalizers are free to order amongst themselves and
+   * are not vulnerable to ordering changes in the list.
+   */
+  finalizers?: string[]
+  /**
+   * GenerateName is an optional prefix, used by the server, to generate a unique
+   * name ONLY IF the Name field has not been provided.
+   * If this field is used, the name returned to the client will be different
+   * than the name passed. This value will also be combined with a unique suffix.
+   * The provided value has the same validation rules as the Name field,
+   * and may be truncated by the length of the suffix required to make the value
+   * unique on the server.
+   * 
+   * If this field is specified and the generated name exists, the server will return a 409.
+   * 
+   * Applied only if Name is not specified.
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#idempotency
+   */
+  generateName?: string
+  /**
+   * A sequence number representing a specific generation of the desired state.
+   * Populated by the system. Read-only.
+   */
+  generation?: number
+  /**
+   * Map of string keys and values that can be used to organize and categorize
+   * (scope and select) objects. May match selectors of replication controllers
+   * and services.
+   * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels
+   */
+  labels?: Record<string, string>
+  /**
+   * ManagedFields maps workflow-id and version to the set of fields
+   * that are managed by that workflow. This is mostly for internal
+   * housekeeping, and users typically shouldn't need to set or
+   * understand this field. A workflow can be the user's name, a
+   * controller's name, or the name of a specific apply path like
+   * "ci-cd". The set of fields is always in the version that the
+   * workflow used when modifying the object.
+   */
+  managedFields?: []
+  /**
+   * Name must be unique within a namespace. Is required when creating resources, although
+   * some resources may allow a client to request the generation of an appropriate name
+   * automatically. Name is primarily intended for creation idempotence and configuration
+   * definition.
+   * Cannot be updated.
+   * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#names
+   */
+  name?: string
+  /**
+   * Namespace defines the space within which each name must be unique. An empty namespace is
+   * equivalent to the "default" namespace, but "default" is the canonical representation.
+   * Not all objects are required to be scoped to a namespace - the value of this field for
+   * those objects will be empty.
+   * 
+   * Must be a DNS_LABEL.
+   * Cannot be updated.
+   * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces
+   */
+  namespace?: string
+  /**
+   * List of objects depended by this object. If ALL objects in the list have
+   * been deleted, this object will be garbage collected. If this object is managed by a controller,
+   * then an entry in this list will point to this controller, with the controller field set to true.
+   * There cannot be more than one managing controller.
+   */
+  ownerReferences?: []
+  /**
+   * An opaque value that represents the internal version of this object that can
+   * be used by clients to determine when objects have changed. May be used for optimistic
+   * concurrency, change detection, and the watch operation on a resource or set of resources.
+   * Clients must treat these values as opaque and passed unmodified back to the server.
+   * They may only be valid for a particular resource or set of resources.
+   * 
+   * Populated by the system.
+   * Read-only.
+   * Value must be treated as opaque by clients and .
+   * More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#concurrency-control-and-consistency
+   */
+  resourceVersion?: string
+  /** Deprecated: selfLink is a legacy read-only field that is no longer populated by the system. */
+  selfLink?: string
+  /**
+   * UID is the unique in time and space value for this object. It is typically generated by
+   * the server on successful creation of a resource and is not allowed to change on PUT
+   * operations.
+   * 
+   * Populated by the system.
+   * Read-only.
+   * More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names#uids
+   */
+  uid?: string
+}
+
+export interface ClientOptions {
+  /** Base URL of the API, e.g. http://kube-green:8080, or '' for the same origin */
+  baseUrl: string
+  /** Bearer token of the requests, or a function returning it before each request */
+  token?: string | (() => string | undefined | Promise<string | undefined>)
+  /** fetch implementation, globalThis.fetch by default */
+  fetch?: typeof fetch
+}
+
+/** Error response of the API */
+export class ApiError extends Error {
+  constructor(
+    public readonly status: number,
+    message: string,
+    /** The whole response, e.g. a ScheduleWriteErrorResponse */
+    public readonly body: unknown
+  ) {
+    super(message)
+    this.name = 'ApiError'
+  }
+}
+
+type QueryValue = string | number | boolean | undefined
+
+export class KubeGreenClient {
+  constructor(private readonly options: ClientOptions) {}
+
+  private async request(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown
+  ): Promise<Response> {
+    const search = new URLSearchParams()
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined && value !== '') {
+        search.set(key, String(value))
+      }
+    }
+    const queryString = search.toString()
+    const url = this.options.baseUrl.replace(/\/$/, '') + path + (queryString ? '?' + queryString : '')
+
+    const headers: Record<string, string> = {}
+    const token =
+      typeof this.options.token === 'function' ? await this.options.token() : this.options.token
+    if (token) {
+      headers.Authorization = 'Bearer ' + token
+    }
+    if (body !== undefined) {
+      headers['Content-Type'] = 'application/json'
+    }
+
+    const doFetch = this.options.fetch ?? globalThis.fetch
+    const response = await doFetch(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    })
+    if (!response.ok) {
+      const text = await response.text()
+      let errorBody: unknown = text
+      let message = text || response.statusText
+      try {
+        errorBody = JSON.parse(text)
+        const error = (errorBody as { error?: unknown }).error
+        if (typeof error === 'string' && error) {
+          message = error
+        }
+      } catch {
+        // Not JSON, keep the text
+      }
+      throw new ApiError(response.status, message, errorBody)
+    }
+    return response
+  }
+
+  private async data<T>(response: Response): Promise<T> {
+    const envelope = (await response.json()) as { data?: T }
+    return envelope.data as T
+  }
+
+  /** POST /api/v1/auth/login: Login */
+  async authLogin(request: LoginRequest): Promise<Record<string, unknown>> {
+    const response = await this.request('POST', '/api/v1/auth/login', undefined, request)
+    return (await response.json()) as Record<string, unknown>
+  }
+
+  /** GET /api/v1/auth/me: Get current user info */
+  async authMe(): Promise<Record<string, unknown>> {
+    const response = await this.request('GET', '/api/v1/auth/me')
+    return (await response.json()) as Record<string, unknown>
+  }
+
+  /** POST /api/v1/auth/refresh: Refresh token */
+  async authRefresh(request: RefreshRequest): Promise<Record<string, unknown>> {
+    const response = await this.request('POST', '/api/v1/auth/refresh', undefined, request)
+    return (await response.json()) as Record<string, unknown>
+  }
+
+  /** GET /api/v1/info: API information endpoint */
+  async info(): Promise<ApiResponse> {
+    const response = await this.request('GET', '/api/v1/info')
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/namespace-policies: List namespace policies */
+  async listNamespacePolicies(): Promise<NamespacePolicyListResponse> {
+    const response = await this.request('GET', '/api/v1/namespace-policies')
+    return this.data<NamespacePolicyListResponse>(response)
+  }
+
+  /** GET /api/v1/namespaces/{tenant}/effective-schedule: Get the effective schedule of a namespace */
+  async getEffectiveSchedule(tenant: string, params: { namespace: string }): Promise<EffectiveScheduleResponse> {
+    const response = await this.request('GET', `/api/v1/namespaces/${encodeURIComponent(tenant)}/effective-schedule`, params)
+    return this.data<EffectiveScheduleResponse>(response)
+  }
+
+  /** GET /api/v1/namespaces/{tenant}/resources: Get resources for a namespace */
+  async getNamespaceResources(tenant: string, params: { namespace: string }): Promise<NamespaceResourceInfo> {
+    const response = await this.request('GET', `/api/v1/namespaces/${encodeURIComponent(tenant)}/resources`, params)
+    return this.data<NamespaceResourceInfo>(response)
+  }
+
+  /** GET /api/v1/namespaces/{tenant}/services: Get services for a namespace */
+  async getNamespaceServices(tenant: string, params: { namespace: string }): Promise<NamespaceServicesResponse> {
+    const response = await this.request('GET', `/api/v1/namespaces/${encodeURIComponent(tenant)}/services`, params)
+    return this.data<NamespaceServicesResponse>(response)
+  }
+
+  /** GET /api/v1/schedules: List all schedules */
+  async listSchedules(params: { tenantPrefix?: string; namespace?: string; scheduleName?: string; name?: string; limit?: number; continue?: string } = {}): Promise<ScheduleResponse[]> {
+    const response = await this.request('GET', '/api/v1/schedules', params)
+    return this.data<ScheduleResponse[]>(response)
+  }
+
+  /** POST /api/v1/schedules: Create a new schedule */
+  async createSchedule(request: CreateScheduleRequest): Promise<ApiResponse> {
+    const response = await this.request('POST', '/api/v1/schedules', undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** DELETE /api/v1/schedules: Bulk delete schedules */
+  async bulkDeleteSchedules(params: { tenants?: string; namespace?: string; scheduleName?: string; labelSelector?: string; annotationSelector?: string } = {}): Promise<BulkDeleteResponse> {
+    const response = await this.request('DELETE', '/api/v1/schedules', params)
+    return this.data<BulkDeleteResponse>(response)
+  }
+
+  /** GET /api/v1/schedules/drifted: Get drifted SleepInfos */
+  async getDriftedSleepInfos(params: { tenant?: string } = {}): Promise<DriftReport> {
+    const response = await this.request('GET', '/api/v1/schedules/drifted', params)
+    return this.data<DriftReport>(response)
+  }
+
+  /** GET /api/v1/schedules/next: Get next operation across all tenants */
+  async getAllNextOperations(): Promise<NextOperationResponse> {
+    const response = await this.request('GET', '/api/v1/schedules/next')
+    return this.data<NextOperationResponse>(response)
+  }
+
+  /** GET /api/v1/schedules/suspended: Get all suspended services */
+  async getAllSuspendedServices(): Promise<SuspendedServiceInfo[]> {
+    const response = await this.request('GET', '/api/v1/schedules/suspended')
+    return this.data<SuspendedServiceInfo[]>(response)
+  }
+
+  /** POST /api/v1/schedules/validate: Validate a schedule */
+  async validateSchedule(request: ValidateScheduleRequest): Promise<ScheduleValidationResult> {
+    const response = await this.request('POST', '/api/v1/schedules/validate', undefined, request)
+    return this.data<ScheduleValidationResult>(response)
+  }
+
+  /** GET /api/v1/schedules/{tenant}: Get schedule for tenant */
+  async getSchedule(tenant: string, params: { namespace?: string } = {}): Promise<ScheduleResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}`, params)
+    return this.data<ScheduleResponse>(response)
+  }
+
+  /** PUT /api/v1/schedules/{tenant}: Update a schedule */
+  async updateSchedule(tenant: string, request: UpdateScheduleRequest): Promise<ApiResponse> {
+    const response = await this.request('PUT', `/api/v1/schedules/${encodeURIComponent(tenant)}`, undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** DELETE /api/v1/schedules/{tenant}: Delete a schedule */
+  async deleteSchedule(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ApiResponse> {
+    const response = await this.request('DELETE', `/api/v1/schedules/${encodeURIComponent(tenant)}`, params)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/schedules/{tenant}/calendar.ics: Get the schedule calendar (iCal) */
+  async getScheduleCalendar(tenant: string, params: { namespace?: string; access_token?: string } = {}): Promise<string> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/calendar.ics`, params)
+    return response.text()
+  }
+
+  /** POST /api/v1/schedules/{tenant}/clone: Clone schedules */
+  async cloneSchedule(tenant: string, request: CloneScheduleRequest): Promise<CloneScheduleResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/clone`, undefined, request)
+    return this.data<CloneScheduleResponse>(response)
+  }
+
+  /** GET /api/v1/schedules/{tenant}/export: Export schedule manifests */
+  async exportSchedule(tenant: string, params: { namespace?: string; format?: string; includeSecrets?: boolean } = {}): Promise<ScheduleExport> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/export`, params)
+    return this.data<ScheduleExport>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/manual: Manual sleep/wake action */
+  async manualScheduleAction(tenant: string, request: ManualScheduleRequest): Promise<ApiResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/manual`, undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/schedules/{tenant}/next: Get next scheduled operation for tenant */
+  async getNextOperation(tenant: string, params: { count?: number } = {}): Promise<NextOccurrencesResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/next`, params)
+    return this.data<NextOccurrencesResponse>(response)
+  }
+
+  /** PUT /api/v1/schedules/{tenant}/pause: Pause a schedule */
+  async pauseSchedule(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ApiResponse> {
+    const response = await this.request('PUT', `/api/v1/schedules/${encodeURIComponent(tenant)}/pause`, params)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/schedules/{tenant}/preview: Preview the next days of a tenant schedule */
+  async getSchedulePreview(tenant: string, params: { days?: number; namespace?: string } = {}): Promise<SchedulePreviewResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/preview`, params)
+    return this.data<SchedulePreviewResponse>(response)
+  }
+
+  /** PUT /api/v1/schedules/{tenant}/resume: Resume a paused schedule */
+  async resumeSchedule(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ApiResponse> {
+    const response = await this.request('PUT', `/api/v1/schedules/${encodeURIComponent(tenant)}/resume`, params)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/schedules/{tenant}/savings: Estimate schedule savings */
+  async getSavings(tenant: string, params: { pricePerCoreHour?: number; pricePerGBHour?: number; currency?: string } = {}): Promise<SavingsResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/savings`, params)
+    return this.data<SavingsResponse>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/sleep-now: Force sleep now */
+  async sleepNow(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ManualOperationResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/sleep-now`, params)
+    return this.data<ManualOperationResponse>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/snooze: Snooze the next sleep */
+  async snoozeSchedule(tenant: string, request: SnoozeRequest): Promise<SnoozeResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/snooze`, undefined, request)
+    return this.data<SnoozeResponse>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/suspend: Suspend a schedule temporarily */
+  async suspendSchedule(tenant: string, request: SuspendScheduleRequest): Promise<ApiResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/suspend`, undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** DELETE /api/v1/schedules/{tenant}/suspend: Remove schedule suspension */
+  async unsuspendSchedule(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ApiResponse> {
+    const response = await this.request('DELETE', `/api/v1/schedules/${encodeURIComponent(tenant)}/suspend`, params)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/schedules/{tenant}/suspended: Get suspended services for tenant */
+  async getSuspendedServices(tenant: string): Promise<SuspendedServicesResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/suspended`)
+    return this.data<SuspendedServicesResponse>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/wake-now: Force wake now */
+  async wakeNow(tenant: string, params: { namespace?: string; scheduleName?: string } = {}): Promise<ManualOperationResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/wake-now`, params)
+    return this.data<ManualOperationResponse>(response)
+  }
+
+  /** POST /api/v1/schedules/{tenant}/windows: Create a one-time window */
+  async createWindowSchedule(tenant: string, request: WindowScheduleRequest): Promise<WindowScheduleResponse> {
+    const response = await this.request('POST', `/api/v1/schedules/${encodeURIComponent(tenant)}/windows`, undefined, request)
+    return this.data<WindowScheduleResponse>(response)
+  }
+
+  /** GET /api/v1/schedules/{tenant}/{namespace}/status: Get reconcile status of a namespace */
+  async getNamespaceStatus(tenant: string, namespace: string): Promise<NamespaceStatusResponse> {
+    const response = await this.request('GET', `/api/v1/schedules/${encodeURIComponent(tenant)}/${encodeURIComponent(namespace)}/status`)
+    return this.data<NamespaceStatusResponse>(response)
+  }
+
+  /** GET /api/v1/tenants: List all tenants */
+  async listTenants(params: { details?: boolean } = {}): Promise<TenantListResponse> {
+    const response = await this.request('GET', '/api/v1/tenants', params)
+    return this.data<TenantListResponse>(response)
+  }
+
+  /** GET /api/v1/timezones: List timezones */
+  async listTimezones(params: { groupBy?: string } = {}): Promise<TimezoneListResponse> {
+    const response = await this.request('GET', '/api/v1/timezones', params)
+    return this.data<TimezoneListResponse>(response)
+  }
+
+  /** GET /api/v1/users: List all users */
+  async listUsers(): Promise<UserInfo[]> {
+    const response = await this.request('GET', '/api/v1/users')
+    return this.data<UserInfo[]>(response)
+  }
+
+  /** POST /api/v1/users: Create a new user */
+  async createUser(request: CreateUserRequest): Promise<ApiResponse> {
+    const response = await this.request('POST', '/api/v1/users', undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** DELETE /api/v1/users/{username}: Delete user */
+  async deleteUser(username: string): Promise<ApiResponse> {
+    const response = await this.request('DELETE', `/api/v1/users/${encodeURIComponent(username)}`)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** PUT /api/v1/users/{username}/password: Update user password */
+  async updateUserPassword(username: string, request: UpdatePasswordRequest): Promise<ApiResponse> {
+    const response = await this.request('PUT', `/api/v1/users/${encodeURIComponent(username)}/password`, undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** PUT /api/v1/users/{username}/role: Update user role */
+  async updateUserRole(username: string, request: UpdateRoleRequest): Promise<ApiResponse> {
+    const response = await this.request('PUT', `/api/v1/users/${encodeURIComponent(username)}/role`, undefined, request)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /api/v1/webhooks: List webhook subscriptions */
+  async listWebhooks(): Promise<WebhookSubscriptionInfo[]> {
+    const response = await this.request('GET', '/api/v1/webhooks')
+    return this.data<WebhookSubscriptionInfo[]>(response)
+  }
+
+  /** POST /api/v1/webhooks: Register a webhook subscription */
+  async createWebhook(request: WebhookSubscriptionRequest): Promise<WebhookSubscriptionInfo> {
+    const response = await this.request('POST', '/api/v1/webhooks', undefined, request)
+    return this.data<WebhookSubscriptionInfo>(response)
+  }
+
+  /** DELETE /api/v1/webhooks/{id}: Delete a webhook subscription */
+  async deleteWebhook(id: string): Promise<ApiResponse> {
+    const response = await this.request('DELETE', `/api/v1/webhooks/${encodeURIComponent(id)}`)
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /health: Health check endpoint */
+  async health(): Promise<ApiResponse> {
+    const response = await this.request('GET', '/health')
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /ready: Readiness check endpoint */
+  async ready(): Promise<ApiResponse> {
+    const response = await this.request('GET', '/ready')
+    return (await response.json()) as ApiResponse
+  }
+
+  /** GET /ui-config: Get UI configuration */
+  async getUIConfig(): Promise<Record<string, string>> {
+    const response = await this.request('GET', '/ui-config')
+    return (await response.json()) as Record<string, string>
+  }
+}
@@ -46,6 +46,9 @@ export interface CreateScheduleRequest {
   namespaces: string[]
   delays?: DelayConfig
   exclusions?: Exclusion[]
+  inclusions?: Exclusion[] // Only the matching resources of each namespace sleep
+  sleepReplicas?: NamespaceSleepReplicas[] // Replicas kept during sleep instead of zero
+  holidays?: HolidayConfig
 }
 
 export interface DelayConfig {
@@ -71,6 +74,17 @@ export interface Exclusion {
   }
 }
 
+export interface NamespaceSleepReplicas {
+  namespace: string
+  filter: ExclusionFilter // Deployments and StatefulSets only
+  replicas: number
+}
+
+export interface SleepReplicasConfig {
+  filter: ExclusionFilter
+  replicas: number
+}
+
 // Namespace Resource Detection Types
 export interface NamespaceResourceInfo {
   namespace: string
@@ -91,8 +105,18 @@ export interface ResourceCounts {
   pgBouncers: number
 }
 
+export interface LabelSelectorRequirement {
+  key: string
+  operator: 'In' | 'NotIn' | 'Exists' | 'DoesNotExist'
+  values?: string[]
+}
+
 export interface ExclusionFilter {
-  matchLabels: Record<string, string>
+  apiVersion?: string
+  kind?: string
+  name?: string
+  matchLabels?: Record<string, string>
+  matchExpressions?: LabelSelectorRequirement[]
 }
 
 // Namespace Schedule Types
@@ -107,6 +131,9 @@ export interface NamespaceScheduleRequest {
   weekdaysWake: string
   delays?: WakeDelayConfig
   exclusions?: Exclusion[]
+  inclusions?: Exclusion[] // Only the matching resources of each namespace sleep
+  sleepReplicas?: NamespaceSleepReplicas[] // Replicas kept during sleep instead of zero
+  holidays?: HolidayConfig
 }
 
 export interface SleepInfoDetail {
@@ -124,7 +151,26 @@ export interface SleepInfoDetail {
   suspendStatefulSetsPostgres?: boolean
   suspendStatefulSetsHdfs?: boolean
   excludeRef?: ExclusionFilter[]
+  includeRef?: ExclusionFilter[]
+  sleepReplicas?: SleepReplicasConfig[]
   annotations?: Record<string, string>
+  window?: OneTimeWindow // One-time sleep, deleted once over
+  holidays?: HolidayConfig
+}
+
+export interface OneTimeWindow {
+  start: string // RFC3339, UTC
+  end: string // RFC3339, UTC
+}
+
+export type HolidayPolicy = 'ignore' | 'skipSleep' | 'forceSleep'
+
+export interface HolidayConfig {
+  configMap?: string // ConfigMap with one YYYY-MM-DD date per line in its "holidays" key
+  configMapNamespace?: string
+  url?: string // iCalendar (ICS) feed, instead of the ConfigMap
+  timeZone?: string // Defaults to the user timezone
+  policy: HolidayPolicy // skipSleep keeps the namespaces awake on holidays, forceSleep keeps them asleep
 }
 
 export interface NamespaceScheduleResponse {
@@ -149,6 +195,7 @@ export interface ScheduleSummary {
   role?: string
   operation?: string
   resources?: string[]
+  sleepReplicas?: SleepReplicasConfig[]
   annotations?: Record<string, string>
   // Campos alternativos que pueden venir del API
   Weekdays?: string
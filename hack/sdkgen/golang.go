@@ -0,0 +1,354 @@
+/*
+Copyright 2025.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// goRenderer writes the Go client: the API types, the query parameters of the operations and a method
+// of Client per operation
+type goRenderer struct {
+	m       *apiModel
+	b       bytes.Buffer
+	imports map[string]string // path to alias
+	err     error
+}
+
+func renderGo(m *apiModel) ([]byte, error) {
+	r := &goRenderer{m: m, imports: map[string]string{
+		"context":  "",
+		"net/http": "",
+		"net/url":  "",
+	}}
+	for _, name := range m.definitionsOf(kindAPI) {
+		r.definition(name)
+	}
+	for _, op := range m.operations {
+		r.operation(op)
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	// The imports are known once the types are written
+	var out bytes.Buffer
+	out.WriteString("// Code generated by hack/sdkgen from swagger.json. DO NOT EDIT.\n\n")
+	out.WriteString("package apiclient\n\n")
+	out.WriteString(r.importBlock())
+	out.Write(r.b.Bytes())
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the Go client: %w", err)
+	}
+	return formatted, nil
+}
+
+func (r *goRenderer) importBlock() string {
+	std, others := []string{}, []string{}
+	for path, alias := range r.imports {
+		line := fmt.Sprintf("%q", path)
+		if alias != "" {
+			line = alias + " " + line
+		}
+		if strings.Contains(path, ".") {
+			others = append(others, line)
+		} else {
+			std = append(std, line)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(others)
+	block := "import (\n\t" + strings.Join(std, "\n\t")
+	if len(others) > 0 {
+		block += "\n\n\t" + strings.Join(others, "\n\t")
+	}
+	return block + "\n)\n\n"
+}
+
+func (r *goRenderer) definition(name string) {
+	definition := r.m.definitions[name]
+	typeName := r.m.names[name]
+	fmt.Fprintf(&r.b, "// %s is generated from the %s definition.\n", typeName, name)
+	if definition.Description != "" {
+		r.b.WriteString("//\n")
+		r.comment(definition.Description, "")
+	}
+	if schemaType(definition) != "object" || len(definition.Properties) == 0 {
+		fmt.Fprintf(&r.b, "type %s %s\n\n", typeName, r.goType(definition, true))
+		return
+	}
+	fmt.Fprintf(&r.b, "type %s struct {\n", typeName)
+	for _, property := range sortedKeys(definition.Properties) {
+		schema := definition.Properties[property]
+		required := isRequired(definition, property)
+		if schema.Description != "" {
+			r.comment(schema.Description, "\t")
+		}
+		tag := property
+		if !required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&r.b, "\t%s %s `json:\"%s\"`\n", goName(property), r.goType(schema, required), tag)
+	}
+	r.b.WriteString("}\n\n")
+}
+
+func (r *goRenderer) operation(op operation) {
+	methodName := goName(op.id)
+	paramsType := methodName + "Params"
+	if len(op.queryParams) > 0 {
+		fmt.Fprintf(&r.b, "// %s are the query parameters of %s.\n", paramsType, methodName)
+		fmt.Fprintf(&r.b, "type %s struct {\n", paramsType)
+		for _, p := range op.queryParams {
+			description := p.description
+			if p.required {
+				description = strings.TrimSpace(description + " (required)")
+			}
+			if description != "" {
+				r.comment(description, "\t")
+			}
+			fmt.Fprintf(&r.b, "\t%s %s\n", goName(p.name), goParamType(p.kind))
+		}
+		r.b.WriteString("}\n\n")
+	}
+
+	fmt.Fprintf(&r.b, "// %s calls %s %s: %s.\n", methodName, op.method, op.path, strings.TrimSuffix(op.summary, "."))
+	if op.description != "" {
+		r.b.WriteString("//\n")
+		r.comment(op.description, "")
+	}
+	args := []string{"ctx context.Context"}
+	for _, p := range op.pathParams {
+		args = append(args, goVar(p.name)+" string")
+	}
+	if op.body != nil {
+		args = append(args, "request "+r.goType(*op.body, true))
+	}
+	if len(op.queryParams) > 0 {
+		args = append(args, "params "+paramsType)
+	}
+	resultType, zero := r.resultType(op)
+	fmt.Fprintf(&r.b, "func (c *Client) %s(%s) (%s, error) {\n", methodName, strings.Join(args, ", "), resultType)
+
+	query := "nil"
+	if len(op.queryParams) > 0 {
+		query = "query"
+		r.b.WriteString("\tquery := url.Values{}\n")
+		for _, p := range op.queryParams {
+			r.queryParam(p)
+		}
+	}
+	body := "nil"
+	if op.body != nil {
+		body = "request"
+	}
+	fmt.Fprintf(&r.b, "\tbody, err := c.call(ctx, http.Method%s, %s, %s, %s)\n", methodConst(op.method), r.pathExpression(op), query, body)
+	fmt.Fprintf(&r.b, "\tif err != nil {\n\t\treturn %s, err\n\t}\n", zero)
+
+	switch op.result {
+	case resultText:
+		r.b.WriteString("\treturn string(body), nil\n")
+	case resultEnvelope:
+		r.b.WriteString("\tresult := &APIResponse{}\n")
+		r.b.WriteString("\tif err := json.Unmarshal(body, result); err != nil {\n\t\treturn nil, err\n\t}\n\treturn result, nil\n")
+		r.imports["encoding/json"] = ""
+	default:
+		decode := "decodeData(body, %s)"
+		if op.result == resultJSON {
+			decode = "json.Unmarshal(body, %s)"
+			r.imports["encoding/json"] = ""
+		}
+		if strings.HasPrefix(resultType, "*") {
+			fmt.Fprintf(&r.b, "\tresult := &%s{}\n", resultType[1:])
+			fmt.Fprintf(&r.b, "\tif err := "+decode+"; err != nil {\n\t\treturn nil, err\n\t}\n", "result")
+		} else {
+			fmt.Fprintf(&r.b, "\tvar result %s\n", resultType)
+			fmt.Fprintf(&r.b, "\tif err := "+decode+"; err != nil {\n\t\treturn %s, err\n\t}\n", "&result", zero)
+		}
+		r.b.WriteString("\treturn result, nil\n")
+	}
+	r.b.WriteString("}\n\n")
+}
+
+// resultType returns the Go type returned by an operation and its zero value
+func (r *goRenderer) resultType(op operation) (string, string) {
+	switch op.result {
+	case resultText:
+		return "string", `""`
+	case resultEnvelope:
+		return "*APIResponse", "nil"
+	}
+	// Structs are returned as pointers
+	t := r.goType(*op.resultType, false)
+	if strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "json.RawMessage" {
+		return t, "nil"
+	}
+	return t, goZero(t)
+}
+
+func (r *goRenderer) isStruct(typeName string) bool {
+	for name, n := range r.m.names {
+		if n == typeName && r.m.kinds[name] == kindAPI {
+			definition := r.m.definitions[name]
+			return schemaType(definition) == "object" && len(definition.Properties) > 0
+		}
+	}
+	return false
+}
+
+func (r *goRenderer) queryParam(p param) {
+	field := "params." + goName(p.name)
+	var value, isSet string
+	switch p.kind {
+	case "integer":
+		value, isSet = "strconv.Itoa("+field+")", field+" != 0"
+		r.imports["strconv"] = ""
+	case "number":
+		value, isSet = "strconv.FormatFloat("+field+", 'f', -1, 64)", field+" != 0"
+		r.imports["strconv"] = ""
+	case "boolean":
+		value, isSet = "strconv.FormatBool("+field+")", field
+		r.imports["strconv"] = ""
+	default:
+		value, isSet = field, field+` != ""`
+	}
+	if p.required {
+		fmt.Fprintf(&r.b, "\tquery.Set(%q, %s)\n", p.name, value)
+		return
+	}
+	fmt.Fprintf(&r.b, "\tif %s {\n\t\tquery.Set(%q, %s)\n\t}\n", isSet, p.name, value)
+}
+
+// pathExpression returns the Go expression of the path of an operation, with its escaped parameters
+func (r *goRenderer) pathExpression(op operation) string {
+	parts := []string{}
+	rest := op.path
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(rest, "}")
+		if start > 0 {
+			parts = append(parts, fmt.Sprintf("%q", rest[:start]))
+		}
+		parts = append(parts, "url.PathEscape("+goVar(rest[start+1:end])+")")
+		rest = rest[end+1:]
+	}
+	if rest != "" {
+		parts = append(parts, fmt.Sprintf("%q", rest))
+	}
+	return strings.Join(parts, "+")
+}
+
+// goType returns the Go type of a schema. Optional structs are pointers, so they are omitted when empty.
+func (r *goRenderer) goType(schema spec.Schema, required bool) string {
+	if name := refName(schema); name != "" {
+		switch r.m.kinds[name] {
+		case kindCRD:
+			r.imports["github.com/kube-green/kube-green/api/v1alpha1"] = "kubegreenv1alpha1"
+			return r.pointer("kubegreenv1alpha1."+r.m.names[name], required)
+		case kindKubernetes:
+			goType, ok := kubernetesTypes[name]
+			if !ok {
+				r.err = fmt.Errorf("no Go type for the Kubernetes definition %s, add it to kubernetesTypes", name)
+			}
+			r.imports["k8s.io/apimachinery/pkg/apis/meta/v1"] = "metav1"
+			return r.pointer(goType, required)
+		}
+		typeName := r.m.names[name]
+		if r.isStruct(typeName) {
+			return r.pointer(typeName, required)
+		}
+		return typeName
+	}
+	switch schemaType(schema) {
+	case "string":
+		return "string"
+	case "integer":
+		switch schema.Format {
+		case "int64":
+			return "int64"
+		case "int32":
+			return "int32"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			r.imports["encoding/json"] = ""
+			return "[]json.RawMessage"
+		}
+		return "[]" + r.goType(*schema.Items.Schema, true)
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + r.goType(*schema.AdditionalProperties.Schema, true)
+		}
+		return "map[string]interface{}"
+	}
+	r.imports["encoding/json"] = ""
+	return "json.RawMessage"
+}
+
+func (r *goRenderer) pointer(goType string, required bool) string {
+	if required {
+		return goType
+	}
+	return "*" + goType
+}
+
+func (r *goRenderer) comment(text, indent string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fmt.Fprintf(&r.b, "%s// %s\n", indent, strings.TrimRight(line, " "))
+	}
+}
+
+func goParamType(kind string) string {
+	switch kind {
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+func goZero(goType string) string {
+	switch goType {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	case "int", "int32", "int64", "float64":
+		return "0"
+	}
+	return goType + "{}"
+}
+
+// goVar returns the unexported Go name of a path parameter
+func goVar(name string) string {
+	n := goName(name)
+	if initialisms[n] {
+		return strings.ToLower(n)
+	}
+	return strings.ToLower(n[:1]) + n[1:]
+}
+
+func methodConst(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
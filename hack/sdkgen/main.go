@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+*/
+
+// sdkgen generates the OpenAPI definition of the REST API from the swag annotations of internal/api/v1,
+// and the Go and TypeScript clients of the API from it. Run it from the root of the module with make sdk.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+func main() {
+	specIn := flag.String("spec", "", "OpenAPI definition to read instead of parsing the annotations of the API")
+	specOut := flag.String("spec-out", "pkg/apiclient/swagger.json", "Where the OpenAPI definition parsed from the annotations is written")
+	goOut := flag.String("go-out", "pkg/apiclient/zz_generated.client.go", "Where the Go client is written")
+	tsOut := flag.String("ts-out", "frontend-app/src/sdk/kubeGreenApi.ts", "Where the TypeScript client is written")
+	flag.Parse()
+
+	if err := run(*specIn, *specOut, *goOut, *tsOut); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specIn, specOut, goOut, tsOut string) error {
+	swagger, err := loadSpec(specIn)
+	if err != nil {
+		return err
+	}
+	if specIn == "" {
+		content, err := json.MarshalIndent(swagger, "", "    ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the OpenAPI definition: %w", err)
+		}
+		if err := os.WriteFile(specOut, append(content, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+
+	model, err := newAPIModel(swagger)
+	if err != nil {
+		return err
+	}
+	goClient, err := renderGo(model)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(goOut, goClient, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(tsOut, renderTypeScript(model), 0o644)
+}
+
+// loadSpec reads the OpenAPI definition of path, or parses it from the annotations of the API as
+// make swagger does
+func loadSpec(path string) (*spec.Swagger, error) {
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		swagger := &spec.Swagger{}
+		if err := json.Unmarshal(content, swagger); err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI definition %s: %w", path, err)
+		}
+		return swagger, nil
+	}
+
+	parser := swag.New(swag.SetParseDependency(int(swag.ParseModels)), swag.SetDebugger(quietDebugger{}))
+	if err := parser.ParseAPI("./internal/api/v1", "doc.go", 100); err != nil {
+		return nil, fmt.Errorf("failed to parse the API annotations: %w", err)
+	}
+	swagger := parser.GetSwagger()
+	for name, definition := range swagger.Definitions {
+		dedupeEnums(&definition)
+		swagger.Definitions[name] = definition
+	}
+	return swagger, nil
+}
+
+// dedupeEnums removes the repeated values of the enums of schema and its properties, and of their names.
+// swag adds the constants of a type once per package parsed that uses it, e.g. those of time.Duration,
+// a number of times that changes between runs.
+func dedupeEnums(schema *spec.Schema) {
+	if len(schema.Enum) > 0 {
+		schema.Enum = dedupe(schema.Enum)
+		switch names := schema.Extensions["x-enum-varnames"].(type) {
+		case []interface{}:
+			schema.Extensions["x-enum-varnames"] = dedupe(names)
+		case []string:
+			values := make([]interface{}, len(names))
+			for i, name := range names {
+				values[i] = name
+			}
+			schema.Extensions["x-enum-varnames"] = dedupe(values)
+		}
+	}
+	for name, property := range schema.Properties {
+		dedupeEnums(&property)
+		schema.Properties[name] = property
+	}
+	if schema.Items != nil && schema.Items.Schema != nil {
+		dedupeEnums(schema.Items.Schema)
+	}
+}
+
+func dedupe(values []interface{}) []interface{} {
+	seen := map[string]bool{}
+	result := []interface{}{}
+	for _, value := range values {
+		if key := fmt.Sprint(value); !seen[key] {
+			seen[key] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// quietDebugger drops the progress logs of the swag parser
+type quietDebugger struct{}
+
+func (quietDebugger) Printf(string, ...interface{}) {}
@@ -0,0 +1,368 @@
+/*
+Copyright 2025.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// kubernetesTypes are the Kubernetes definitions referenced by the types of the API, with their Go type.
+// A Kubernetes definition reached from the API types and missing here is generated as an API type.
+var kubernetesTypes = map[string]string{
+	"v1.Condition":                "metav1.Condition",
+	"v1.LabelSelectorRequirement": "metav1.LabelSelectorRequirement",
+	"v1.ObjectMeta":               "metav1.ObjectMeta",
+}
+
+// crdPrefix is the prefix of the definitions of the kube-green CRDs, the api/v1alpha1 package
+const crdPrefix = "v1alpha1."
+
+// Kinds of the definitions
+const (
+	kindAPI        = "api"        // type of the API, generated in both clients
+	kindCRD        = "crd"        // api/v1alpha1 type: imported by the Go client, generated in the TypeScript one
+	kindKubernetes = "kubernetes" // Kubernetes type: imported by the Go client, typed by the TypeScript one when in kubernetesTypes, unknown otherwise
+)
+
+// Result kinds of the operations
+const (
+	resultEnvelope = "envelope" // the APIResponse, with untyped data
+	resultData     = "data"     // the data of the APIResponse
+	resultJSON     = "json"     // a JSON body not wrapped in an APIResponse
+	resultText     = "text"     // a text body, e.g. an iCalendar feed
+)
+
+// apiModel is the API as generated in the clients
+type apiModel struct {
+	title       string
+	version     string
+	definitions spec.Definitions
+	kinds       map[string]string // kind of each definition used by the operations
+	names       map[string]string // type name of each definition, without package
+	operations  []operation
+}
+
+type operation struct {
+	id          string
+	method      string
+	path        string
+	summary     string
+	description string
+	pathParams  []param
+	queryParams []param
+	body        *spec.Schema
+	result      string
+	resultType  *spec.Schema // type of the data or JSON body
+}
+
+type param struct {
+	name        string
+	kind        string // string, integer, number or boolean
+	required    bool
+	description string
+}
+
+func newAPIModel(swagger *spec.Swagger) (*apiModel, error) {
+	m := &apiModel{
+		definitions: swagger.Definitions,
+		kinds:       map[string]string{},
+		names:       map[string]string{},
+	}
+	if swagger.Info != nil {
+		m.title = swagger.Info.Title
+		m.version = swagger.Info.Version
+	}
+	if swagger.Paths == nil {
+		return nil, fmt.Errorf("the OpenAPI definition has no paths")
+	}
+
+	paths := make([]string, 0, len(swagger.Paths.Paths))
+	for path := range swagger.Paths.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	ids := map[string]bool{}
+	for _, path := range paths {
+		item := swagger.Paths.Paths[path]
+		for _, entry := range []struct {
+			method string
+			op     *spec.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodPatch, item.Patch},
+			{http.MethodDelete, item.Delete},
+		} {
+			if entry.op == nil || produces(entry.op, "text/event-stream") {
+				continue // Event streams are read with an EventSource, not a request
+			}
+			op, err := m.newOperation(entry.method, path, entry.op)
+			if err != nil {
+				return nil, err
+			}
+			if ids[op.id] {
+				return nil, fmt.Errorf("duplicated operation ID %s", op.id)
+			}
+			ids[op.id] = true
+			m.operations = append(m.operations, op)
+		}
+	}
+
+	// The CRD and Kubernetes types are imported by the Go client and prefixed by the TypeScript one
+	apiNames := map[string]string{}
+	for name, kind := range m.kinds {
+		typeName := definitionTypeName(name)
+		m.names[name] = typeName
+		if kind != kindAPI {
+			continue
+		}
+		if other, found := apiNames[typeName]; found {
+			return nil, fmt.Errorf("definitions %s and %s have the same type name %s", name, other, typeName)
+		}
+		apiNames[typeName] = name
+	}
+	return m, nil
+}
+
+func (m *apiModel) newOperation(method, path string, o *spec.Operation) (operation, error) {
+	if o.ID == "" {
+		return operation{}, fmt.Errorf("%s %s has no @ID", method, path)
+	}
+	op := operation{
+		id:          o.ID,
+		method:      method,
+		path:        path,
+		summary:     o.Summary,
+		description: o.Description,
+	}
+	for _, p := range o.Parameters {
+		switch p.In {
+		case "path":
+			op.pathParams = append(op.pathParams, param{name: p.Name, kind: p.Type, required: true, description: p.Description})
+		case "query":
+			op.queryParams = append(op.queryParams, param{name: p.Name, kind: p.Type, required: p.Required, description: p.Description})
+		case "body":
+			op.body = p.Schema
+			m.walk(p.Schema, kindAPI)
+		}
+	}
+	// Path parameters in the order of the path
+	sort.SliceStable(op.pathParams, func(i, j int) bool {
+		return strings.Index(path, "{"+op.pathParams[i].name+"}") < strings.Index(path, "{"+op.pathParams[j].name+"}")
+	})
+
+	if o.Responses == nil {
+		return operation{}, fmt.Errorf("%s %s has no responses", method, path)
+	}
+	codes := make([]int, 0, len(o.Responses.StatusCodeResponses))
+	for code, response := range o.Responses.StatusCodeResponses {
+		codes = append(codes, code)
+		m.walk(response.Schema, kindAPI)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		if code < 200 || code > 299 {
+			continue
+		}
+		op.result, op.resultType = m.classifyResult(o, o.Responses.StatusCodeResponses[code].Schema)
+		break
+	}
+	if op.result == "" {
+		return operation{}, fmt.Errorf("%s %s has no success response", method, path)
+	}
+	return op, nil
+}
+
+// classifyResult returns how the success response of an operation is decoded
+func (m *apiModel) classifyResult(o *spec.Operation, schema *spec.Schema) (string, *spec.Schema) {
+	if !produces(o, "application/json") && len(o.Produces) > 0 {
+		return resultText, nil
+	}
+	if schema == nil {
+		return resultEnvelope, nil
+	}
+	if len(schema.AllOf) == 2 && isEnvelope(schema.AllOf[0]) {
+		if data, ok := schema.AllOf[1].Properties["data"]; ok {
+			return resultData, &data
+		}
+	}
+	if isEnvelope(*schema) {
+		return resultEnvelope, nil
+	}
+	return resultJSON, schema
+}
+
+// walk records the kind of the definitions referenced by schema, as used from a definition of kind from
+func (m *apiModel) walk(schema *spec.Schema, from string) {
+	if schema == nil {
+		return
+	}
+	if name := refName(*schema); name != "" {
+		kind := kindAPI
+		switch {
+		case strings.HasPrefix(name, crdPrefix):
+			kind = kindCRD
+		case kubernetesTypes[name] != "" || from != kindAPI:
+			kind = kindKubernetes
+		}
+		if seen, ok := m.kinds[name]; ok && (seen != kindKubernetes || kind != kindAPI) {
+			return
+		}
+		m.kinds[name] = kind
+		if kind == kindKubernetes {
+			return // Imported in Go, typed from its own properties in TypeScript
+		}
+		definition := m.definitions[name]
+		m.walk(&definition, kind)
+		return
+	}
+	for _, s := range schema.AllOf {
+		m.walk(&s, from)
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		m.walk(&property, from)
+	}
+	if schema.Items != nil {
+		m.walk(schema.Items.Schema, from)
+	}
+	if schema.AdditionalProperties != nil {
+		m.walk(schema.AdditionalProperties.Schema, from)
+	}
+}
+
+// definitionsOf returns the names of the definitions of a kind, sorted by type name
+func (m *apiModel) definitionsOf(kind string) []string {
+	names := []string{}
+	for name, k := range m.kinds {
+		if k == kind {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return m.names[names[i]] < m.names[names[j]]
+	})
+	return names
+}
+
+// isEnvelope reports whether schema is the APIResponse
+func isEnvelope(schema spec.Schema) bool {
+	return definitionTypeName(refName(schema)) == "APIResponse"
+}
+
+// refName returns the definition referenced by schema, directly or as the only element of an allOf
+func refName(schema spec.Schema) string {
+	if ref := schema.Ref.String(); ref != "" {
+		return strings.TrimPrefix(ref, "#/definitions/")
+	}
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return refName(schema.AllOf[0])
+	}
+	return ""
+}
+
+// definitionTypeName returns the type name of a definition: v1.ScheduleResponse is ScheduleResponse
+func definitionTypeName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+func schemaType(schema spec.Schema) string {
+	if len(schema.Type) == 0 {
+		return ""
+	}
+	return schema.Type[0]
+}
+
+func produces(o *spec.Operation, mediaType string) bool {
+	for _, p := range o.Produces {
+		if p == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(properties spec.SchemaProperties) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isRequired(schema spec.Schema, property string) bool {
+	for _, r := range schema.Required {
+		if r == property {
+			return true
+		}
+	}
+	return false
+}
+
+// words splits a JSON name, an operation ID or a parameter name in its words
+func words(name string) []string {
+	result := []string{}
+	current := []rune{}
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, string(current))
+			current = current[:0]
+		}
+	}
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.' || r == ' ':
+			flush()
+			continue
+		case r >= 'A' && r <= 'Z' && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') || (prev >= 'A' && prev <= 'Z' && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return result
+}
+
+// initialisms are the words written in upper case in the Go names
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "HTTP": true, "ID": true, "IP": true, "JSON": true, "TTL": true,
+	"UID": true, "URL": true, "UTC": true, "TZ": true,
+}
+
+// goName returns the exported Go name of a JSON name, an operation ID or a parameter name
+func goName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if upper := strings.ToUpper(w); initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// tsName returns the TypeScript name of a parameter
+func tsName(name string) string {
+	n := goName(name)
+	for _, w := range words(name)[:1] {
+		if initialisms[strings.ToUpper(w)] {
+			return strings.ToLower(w) + n[len(w):]
+		}
+	}
+	return strings.ToLower(n[:1]) + n[1:]
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// tsPrefixes are the prefixes of the TypeScript names of the types that are not of the API, so a CRD
+// type cannot clash with an API type of the same name
+var tsPrefixes = map[string]string{
+	kindAPI:        "",
+	kindCRD:        "V1alpha1",
+	kindKubernetes: "K8s",
+}
+
+// tsRuntime is the part of the TypeScript client that does not depend on the API
+const tsRuntime = `export interface ClientOptions {
+  /** Base URL of the API, e.g. http://kube-green:8080, or '' for the same origin */
+  baseUrl: string
+  /** Bearer token of the requests, or a function returning it before each request */
+  token?: string | (() => string | undefined | Promise<string | undefined>)
+  /** fetch implementation, globalThis.fetch by default */
+  fetch?: typeof fetch
+}
+
+/** Error response of the API */
+export class ApiError extends Error {
+  constructor(
+    public readonly status: number,
+    message: string,
+    /** The whole response, e.g. a ScheduleWriteErrorResponse */
+    public readonly body: unknown
+  ) {
+    super(message)
+    this.name = 'ApiError'
+  }
+}
+
+type QueryValue = string | number | boolean | undefined
+
+export class KubeGreenClient {
+  constructor(private readonly options: ClientOptions) {}
+
+  private async request(
+    method: string,
+    path: string,
+    query?: Record<string, QueryValue>,
+    body?: unknown
+  ): Promise<Response> {
+    const search = new URLSearchParams()
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value !== undefined && value !== '') {
+        search.set(key, String(value))
+      }
+    }
+    const queryString = search.toString()
+    const url = this.options.baseUrl.replace(/\/$/, '') + path + (queryString ? '?' + queryString : '')
+
+    const headers: Record<string, string> = {}
+    const token =
+      typeof this.options.token === 'function' ? await this.options.token() : this.options.token
+    if (token) {
+      headers.Authorization = 'Bearer ' + token
+    }
+    if (body !== undefined) {
+      headers['Content-Type'] = 'application/json'
+    }
+
+    const doFetch = this.options.fetch ?? globalThis.fetch
+    const response = await doFetch(url, {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    })
+    if (!response.ok) {
+      const text = await response.text()
+      let errorBody: unknown = text
+      let message = text || response.statusText
+      try {
+        errorBody = JSON.parse(text)
+        const error = (errorBody as { error?: unknown }).error
+        if (typeof error === 'string' && error) {
+          message = error
+        }
+      } catch {
+        // Not JSON, keep the text
+      }
+      throw new ApiError(response.status, message, errorBody)
+    }
+    return response
+  }
+
+  private async data<T>(response: Response): Promise<T> {
+    const envelope = (await response.json()) as { data?: T }
+    return envelope.data as T
+  }
+`
+
+func renderTypeScript(m *apiModel) []byte {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by hack/sdkgen from pkg/apiclient/swagger.json. DO NOT EDIT.\n")
+	fmt.Fprintf(&b, "// %s %s\n\n", m.title, m.version)
+
+	for _, kind := range []string{kindAPI, kindCRD, kindKubernetes} {
+		for _, name := range m.definitionsOf(kind) {
+			if kind == kindKubernetes && kubernetesTypes[name] == "" {
+				continue // Typed as unknown
+			}
+			tsDefinition(&b, m, name)
+		}
+	}
+
+	b.WriteString(tsRuntime)
+	for _, op := range m.operations {
+		tsOperation(&b, m, op)
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func tsDefinition(b *bytes.Buffer, m *apiModel, name string) {
+	definition := m.definitions[name]
+	typeName := tsTypeName(m, name)
+	tsComment(b, definition.Description, "")
+	if schemaType(definition) != "object" || len(definition.Properties) == 0 {
+		fmt.Fprintf(b, "export type %s = %s\n\n", typeName, tsType(m, definition))
+		return
+	}
+	fmt.Fprintf(b, "export interface %s {\n", typeName)
+	for _, property := range sortedKeys(definition.Properties) {
+		schema := definition.Properties[property]
+		tsComment(b, schema.Description, "  ")
+		optional := "?"
+		if isRequired(definition, property) {
+			optional = ""
+		}
+		fmt.Fprintf(b, "  %s%s: %s\n", tsProperty(property), optional, tsType(m, schema))
+	}
+	b.WriteString("}\n\n")
+}
+
+func tsOperation(b *bytes.Buffer, m *apiModel, op operation) {
+	summary := strings.TrimSuffix(op.summary, ".")
+	b.WriteString("\n")
+	tsComment(b, fmt.Sprintf("%s %s: %s", op.method, op.path, summary), "  ")
+
+	args := []string{}
+	for _, p := range op.pathParams {
+		args = append(args, tsName(p.name)+": string")
+	}
+	if op.body != nil {
+		args = append(args, "request: "+tsType(m, *op.body))
+	}
+	if len(op.queryParams) > 0 {
+		fields := []string{}
+		optional := true
+		for _, p := range op.queryParams {
+			mark := "?"
+			if p.required {
+				mark, optional = "", false
+			}
+			fields = append(fields, fmt.Sprintf("%s%s: %s", tsProperty(p.name), mark, tsParamType(p.kind)))
+		}
+		arg := "params: { " + strings.Join(fields, "; ") + " }"
+		if optional {
+			arg += " = {}"
+		}
+		args = append(args, arg)
+	}
+
+	var result string
+	switch op.result {
+	case resultText:
+		result = "string"
+	case resultEnvelope:
+		result = "ApiResponse"
+	default:
+		result = tsType(m, *op.resultType)
+	}
+	fmt.Fprintf(b, "  async %s(%s): Promise<%s> {\n", tsName(op.id), strings.Join(args, ", "), result)
+
+	requestArgs := []string{"'" + op.method + "'", tsPath(op)}
+	switch {
+	case op.body != nil && len(op.queryParams) > 0:
+		requestArgs = append(requestArgs, "params", "request")
+	case op.body != nil:
+		requestArgs = append(requestArgs, "undefined", "request")
+	case len(op.queryParams) > 0:
+		requestArgs = append(requestArgs, "params")
+	}
+	fmt.Fprintf(b, "    const response = await this.request(%s)\n", strings.Join(requestArgs, ", "))
+	switch op.result {
+	case resultText:
+		b.WriteString("    return response.text()\n")
+	case resultData:
+		fmt.Fprintf(b, "    return this.data<%s>(response)\n", result)
+	default:
+		fmt.Fprintf(b, "    return (await response.json()) as %s\n", result)
+	}
+	b.WriteString("  }\n")
+}
+
+// tsTypeName returns the TypeScript name of a definition
+func tsTypeName(m *apiModel, name string) string {
+	typeName := m.names[name]
+	if typeName == "APIResponse" {
+		return "ApiResponse"
+	}
+	return tsPrefixes[m.kinds[name]] + typeName
+}
+
+func tsType(m *apiModel, schema spec.Schema) string {
+	if name := refName(schema); name != "" {
+		if m.kinds[name] == kindKubernetes && kubernetesTypes[name] == "" {
+			return "unknown"
+		}
+		return tsTypeName(m, name)
+	}
+	if len(schema.Enum) > 0 && schemaType(schema) == "string" {
+		values := []string{}
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprintf("'%v'", value))
+		}
+		return strings.Join(values, " | ")
+	}
+	switch schemaType(schema) {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "unknown[]"
+		}
+		item := tsType(m, *schema.Items.Schema)
+		if strings.Contains(item, " | ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "Record<string, " + tsType(m, *schema.AdditionalProperties.Schema) + ">"
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+func tsParamType(kind string) string {
+	switch kind {
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	}
+	return "string"
+}
+
+// tsPath returns the TypeScript expression of the path of an operation, with its encoded parameters
+func tsPath(op operation) string {
+	if !strings.Contains(op.path, "{") {
+		return "'" + op.path + "'"
+	}
+	path := op.path
+	for _, p := range op.pathParams {
+		path = strings.ReplaceAll(path, "{"+p.name+"}", "${encodeURIComponent("+tsName(p.name)+")}")
+	}
+	return "`" + path + "`"
+}
+
+// tsProperty quotes the property names that are not identifiers
+func tsProperty(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9')) {
+			return "'" + name + "'"
+		}
+	}
+	return name
+}
+
+// tsComment writes a JSDoc comment, without the kubebuilder markers of the CRD types
+func tsComment(b *bytes.Buffer, text, indent string) {
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "*/", "*\\/"), "\n") {
+		if line = strings.TrimRight(line, " "); !strings.HasPrefix(strings.TrimSpace(line), "+") {
+			lines = append(lines, line)
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s * %s\n", indent, strings.TrimSpace(line))
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+*/
+
+package grpcapi
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/kube-green/kube-green/internal/api/grpcapi/schedulepb"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+)
+
+func createRequestFromProto(req *schedulepb.CreateScheduleRequest) apiv1.CreateScheduleRequest {
+	createReq := apiv1.CreateScheduleRequest{
+		Tenant:       req.GetTenant(),
+		Off:          req.GetOff(),
+		On:           req.GetOn(),
+		Weekdays:     req.GetWeekdays(),
+		SleepDays:    req.GetSleepDays(),
+		WakeDays:     req.GetWakeDays(),
+		Namespaces:   req.GetNamespaces(),
+		ScheduleName: req.GetScheduleName(),
+		Description:  req.GetDescription(),
+	}
+	if d := req.GetDelays(); d != nil {
+		createReq.Delays = &apiv1.DelayConfig{
+			PgHdfsDelay:      d.GetPgHdfsDelay(),
+			PgbouncerDelay:   d.GetPgbouncerDelay(),
+			DeploymentsDelay: d.GetDeploymentsDelay(),
+		}
+	}
+	return createReq
+}
+
+func scheduleToProto(schedule *apiv1.ScheduleResponse) *schedulepb.Schedule {
+	out := &schedulepb.Schedule{
+		Tenant:     schedule.Tenant,
+		Namespaces: make(map[string]*schedulepb.NamespaceSchedule, len(schedule.Namespaces)),
+	}
+	for suffix, ns := range schedule.Namespaces {
+		nsOut := &schedulepb.NamespaceSchedule{
+			Namespace: ns.Namespace,
+			Weekdays:  ns.Weekdays,
+			Timezone:  ns.Timezone,
+			Summary: &schedulepb.ScheduleSummary{
+				SleepTime:   ns.Summary.SleepTime,
+				WakeTime:    ns.Summary.WakeTime,
+				Operations:  ns.Summary.Operations,
+				Description: ns.Summary.Description,
+			},
+			ScheduleName: ns.ScheduleName,
+			Description:  ns.Description,
+		}
+		for _, si := range ns.Schedule {
+			nsOut.Schedule = append(nsOut.Schedule, sleepInfoSummaryToProto(si))
+		}
+		out.Namespaces[suffix] = nsOut
+	}
+	return out
+}
+
+func sleepInfoSummaryToProto(si apiv1.SleepInfoSummary) *schedulepb.SleepInfoSummary {
+	out := &schedulepb.SleepInfoSummary{
+		Name:         si.Name,
+		Namespace:    si.Namespace,
+		Role:         si.Role,
+		Operation:    si.Operation,
+		Time:         si.Time,
+		Weekdays:     si.Weekdays,
+		TimeZone:     si.TimeZone,
+		UserTimezone: si.UserTimezone,
+		Resources:    si.Resources,
+		WakeTime:     si.WakeTime,
+		ScheduleName: si.ScheduleName,
+		Description:  si.Description,
+		Annotations:  si.Annotations,
+		Paused:       si.Paused,
+	}
+	for _, ref := range si.ExcludeRef {
+		out.ExcludeRef = append(out.ExcludeRef, &schedulepb.FilterRef{MatchLabels: ref.MatchLabels})
+	}
+	if si.SuspendScheduleUntil != nil {
+		out.SuspendScheduleUntil = timestamppb.New(*si.SuspendScheduleUntil)
+	}
+	return out
+}
+
+func namespaceResourcesToProto(info *apiv1.NamespaceResourceInfo) *schedulepb.NamespaceResources {
+	out := &schedulepb.NamespaceResources{
+		Namespace:       info.Namespace,
+		HasPgCluster:    info.HasPgCluster,
+		HasHdfsCluster:  info.HasHdfsCluster,
+		HasOsCluster:    info.HasOsCluster,
+		HasOsDashboards: info.HasOsDashboards,
+		HasKafkaCluster: info.HasKafkaCluster,
+		HasPgBouncer:    info.HasPgBouncer,
+		HasVirtualizer:  info.HasVirtualizer,
+		ResourceCounts: &schedulepb.ResourceCounts{
+			Deployments:    int32(info.ResourceCounts.Deployments),
+			StatefulSets:   int32(info.ResourceCounts.StatefulSets),
+			CronJobs:       int32(info.ResourceCounts.CronJobs),
+			PgClusters:     int32(info.ResourceCounts.PgClusters),
+			HdfsClusters:   int32(info.ResourceCounts.HdfsClusters),
+			OsClusters:     int32(info.ResourceCounts.OsClusters),
+			OsDashboardses: int32(info.ResourceCounts.OsDashboardses),
+			KafkaClusters:  int32(info.ResourceCounts.KafkaClusters),
+			PgBouncers:     int32(info.ResourceCounts.PgBouncers),
+		},
+	}
+	for _, ex := range info.AutoExclusions {
+		out.AutoExclusions = append(out.AutoExclusions, &schedulepb.ExclusionFilter{MatchLabels: ex.MatchLabels})
+	}
+	return out
+}
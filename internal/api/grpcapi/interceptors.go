@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/kube-green/kube-green/internal/api/grpcapi/schedulepb"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+)
+
+// mutatingMethods are the calls recorded in the audit log, like the POST, PUT and DELETE REST requests
+var mutatingMethods = map[string]bool{
+	schedulepb.ScheduleService_CreateSchedule_FullMethodName: true,
+	schedulepb.ScheduleService_UpdateSchedule_FullMethodName: true,
+	schedulepb.ScheduleService_DeleteSchedule_FullMethodName: true,
+}
+
+// clientIP returns the address of the peer of the call
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// checkRateLimit applies the rate limits shared with the REST API. Calls are limited before
+// authentication, so the client bucket is keyed on the peer address and never on the token.
+func (s *Server) checkRateLimit(ctx context.Context) error {
+	var limited *apiv1.RateLimitError
+	if !errors.As(s.scheduleService.CheckRateLimit("ip:"+clientIP(ctx)), &limited) {
+		return nil
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(limited.RetryAfter.Seconds()))))
+	return status.Error(codes.ResourceExhausted, limited.Error())
+}
+
+func (s *Server) unaryRateLimitInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.checkRateLimit(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamRateLimitInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.checkRateLimit(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// unaryAuditInterceptor records every mutating call in the audit log shared with the REST API,
+// including the ones rejected by authentication or permission checks, so it runs before them
+func (s *Server) unaryAuditInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !s.scheduleService.Audited() || !mutatingMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	ctx, touched := apiv1.BeginAudit(ctx)
+	user := &callUser{}
+	resp, err := handler(context.WithValue(ctx, callUserKey, user), req)
+
+	code := status.Code(err)
+	rec := apiv1.AuditRecord{
+		Time:       start.UTC(),
+		Subject:    user.username,
+		Role:       user.role,
+		ClientIP:   clientIP(ctx),
+		Method:     "GRPC",
+		Path:       info.FullMethod,
+		Operation:  "GRPC " + info.FullMethod,
+		Tenant:     requestTenant(req),
+		Namespaces: touched(),
+		Status:     httpStatusFromCode(code),
+		LatencyMs:  time.Since(start).Milliseconds(),
+	}
+	incomingID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(apiv1.RequestIDHeader); len(ids) > 0 {
+			incomingID = ids[0]
+		}
+	}
+	rec.RequestID = apiv1.RequestID(incomingID)
+	rec.Result = "success"
+	if err != nil {
+		rec.Result = "failure"
+		rec.Error = status.Convert(err).Message()
+	}
+	s.scheduleService.WriteAudit(rec)
+	return resp, err
+}
+
+type callUserKeyType struct{}
+
+// callUserKey holds the *callUser the authentication fills in for the audit record
+var callUserKey = callUserKeyType{}
+
+// callUser is the authenticated user of a call, empty when the call is anonymous or rejected
+type callUser struct {
+	username string
+	role     string
+}
+
+// requestTenant returns the tenant of a mutating request
+func requestTenant(req any) string {
+	switch r := req.(type) {
+	case *schedulepb.CreateScheduleRequest:
+		return r.GetTenant()
+	case *schedulepb.UpdateScheduleRequest:
+		return r.GetSchedule().GetTenant()
+	case *schedulepb.DeleteScheduleRequest:
+		return r.GetTenant()
+	}
+	return ""
+}
+
+// httpStatusFromCode maps a gRPC code to the HTTP status of the same REST API error, see toStatus
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+*/
+
+package grpcapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kube-green/kube-green/internal/api/grpcapi/schedulepb"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+	"github.com/kube-green/kube-green/internal/api/v1/auth"
+)
+
+func TestUnaryAuditInterceptor(t *testing.T) {
+	secret := []byte("test-secret")
+	pair, err := auth.GenerateTokenPair("alice", auth.RoleOperacion, secret, time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	s := &Server{
+		authEnabled: true,
+		jwtSecret:   secret,
+		scheduleService: apiv1.NewService(apiv1.ServiceConfig{
+			Client: fake.NewClientBuilder().Build(),
+			Logger: logr.Discard(),
+			Audit:  apiv1.AuditConfig{LogPath: logPath},
+		}),
+	}
+	// call runs the audit and authentication interceptors in the order of the server chain
+	call := func(ctx context.Context, method string, req any) error {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := s.unaryAuditInterceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return s.unaryAuthInterceptor(ctx, req, info, func(context.Context, any) (any, error) {
+				return &schedulepb.CreateScheduleResponse{}, nil
+			})
+		})
+		return err
+	}
+	records := func() []apiv1.AuditRecord {
+		f, err := os.Open(logPath)
+		require.NoError(t, err)
+		defer f.Close()
+		var recs []apiv1.AuditRecord
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var rec apiv1.AuditRecord
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &rec))
+			recs = append(recs, rec)
+		}
+		require.NoError(t, scanner.Err())
+		return recs
+	}
+	withRequestID := func(ctx context.Context, id string) context.Context {
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+		md.Set(apiv1.RequestIDHeader, id)
+		return metadata.NewIncomingContext(ctx, md)
+	}
+	create := &schedulepb.CreateScheduleRequest{Tenant: "bdadevdat"}
+
+	require.NoError(t, call(withRequestID(withBearer(pair.AccessToken), "req-42"), schedulepb.ScheduleService_CreateSchedule_FullMethodName, create))
+	err = call(withRequestID(context.Background(), "forged\nid"), schedulepb.ScheduleService_CreateSchedule_FullMethodName, create)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.NoError(t, call(withBearer(pair.AccessToken), schedulepb.ScheduleService_GetSchedule_FullMethodName, &schedulepb.GetScheduleRequest{Tenant: "bdadevdat"}))
+
+	recs := records()
+	require.Len(t, recs, 2, "read-only calls are not audited")
+
+	t.Run("authenticated call records the user and the incoming request ID", func(t *testing.T) {
+		rec := recs[0]
+		require.Equal(t, "req-42", rec.RequestID)
+		require.Equal(t, "alice", rec.Subject)
+		require.Equal(t, auth.RoleOperacion, rec.Role)
+		require.Equal(t, "bdadevdat", rec.Tenant)
+		require.Equal(t, "GRPC "+schedulepb.ScheduleService_CreateSchedule_FullMethodName, rec.Operation)
+		require.Equal(t, http.StatusOK, rec.Status)
+		require.Equal(t, "success", rec.Result)
+	})
+
+	t.Run("rejected call is recorded with a sanitized request ID", func(t *testing.T) {
+		rec := recs[1]
+		require.NotEqual(t, "forged\nid", rec.RequestID)
+		require.Equal(t, apiv1.RequestID(rec.RequestID), rec.RequestID)
+		require.Equal(t, "anonymous", rec.Subject)
+		require.Equal(t, http.StatusUnauthorized, rec.Status)
+		require.Equal(t, "failure", rec.Result)
+		require.NotEmpty(t, rec.Error)
+	})
+}
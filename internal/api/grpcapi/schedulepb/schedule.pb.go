@@ -0,0 +1,1731 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: schedule.proto
+
+package schedulepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// DelayConfig configures the staged wake-up of a datastores namespace.
+type DelayConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Delay for PgCluster and HDFSCluster (e.g. "0m").
+	PgHdfsDelay string `protobuf:"bytes,1,opt,name=pg_hdfs_delay,json=pgHdfsDelay,proto3" json:"pg_hdfs_delay,omitempty"`
+	// Delay for PgBouncer (e.g. "5m").
+	PgbouncerDelay string `protobuf:"bytes,2,opt,name=pgbouncer_delay,json=pgbouncerDelay,proto3" json:"pgbouncer_delay,omitempty"`
+	// Delay for Deployments (e.g. "7m").
+	DeploymentsDelay string `protobuf:"bytes,3,opt,name=deployments_delay,json=deploymentsDelay,proto3" json:"deployments_delay,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DelayConfig) Reset() {
+	*x = DelayConfig{}
+	mi := &file_schedule_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DelayConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DelayConfig) ProtoMessage() {}
+
+func (x *DelayConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DelayConfig.ProtoReflect.Descriptor instead.
+func (*DelayConfig) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DelayConfig) GetPgHdfsDelay() string {
+	if x != nil {
+		return x.PgHdfsDelay
+	}
+	return ""
+}
+
+func (x *DelayConfig) GetPgbouncerDelay() string {
+	if x != nil {
+		return x.PgbouncerDelay
+	}
+	return ""
+}
+
+func (x *DelayConfig) GetDeploymentsDelay() string {
+	if x != nil {
+		return x.DeploymentsDelay
+	}
+	return ""
+}
+
+// CreateScheduleRequest describes a tenant schedule. Times are in the user timezone.
+type CreateScheduleRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant name (e.g. bdadevdat).
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Sleep time (HH:MM, 24-hour).
+	Off string `protobuf:"bytes,2,opt,name=off,proto3" json:"off,omitempty"`
+	// Wake time (HH:MM, 24-hour).
+	On string `protobuf:"bytes,3,opt,name=on,proto3" json:"on,omitempty"`
+	// Days of week ("lunes-viernes" or "1-5").
+	Weekdays string `protobuf:"bytes,4,opt,name=weekdays,proto3" json:"weekdays,omitempty"`
+	// Specific days for sleep, overrides weekdays.
+	SleepDays string `protobuf:"bytes,5,opt,name=sleep_days,json=sleepDays,proto3" json:"sleep_days,omitempty"`
+	// Specific days for wake, overrides weekdays.
+	WakeDays string `protobuf:"bytes,6,opt,name=wake_days,json=wakeDays,proto3" json:"wake_days,omitempty"`
+	// Namespace suffixes to schedule, all of them when empty.
+	Namespaces []string `protobuf:"bytes,7,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// Staged wake-up delays.
+	Delays *DelayConfig `protobuf:"bytes,8,opt,name=delays,proto3" json:"delays,omitempty"`
+	// Name identifying the schedule, allows several schedules per namespace.
+	ScheduleName string `protobuf:"bytes,9,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	// Description of the schedule.
+	Description   string `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleRequest) Reset() {
+	*x = CreateScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleRequest) ProtoMessage() {}
+
+func (x *CreateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateScheduleRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetOff() string {
+	if x != nil {
+		return x.Off
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetOn() string {
+	if x != nil {
+		return x.On
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetWeekdays() string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetSleepDays() string {
+	if x != nil {
+		return x.SleepDays
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetWakeDays() string {
+	if x != nil {
+		return x.WakeDays
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetDelays() *DelayConfig {
+	if x != nil {
+		return x.Delays
+	}
+	return nil
+}
+
+func (x *CreateScheduleRequest) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+func (x *CreateScheduleRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CreateScheduleResponse is returned when the schedule is created.
+type CreateScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateScheduleResponse) Reset() {
+	*x = CreateScheduleResponse{}
+	mi := &file_schedule_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduleResponse) ProtoMessage() {}
+
+func (x *CreateScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CreateScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// GetScheduleRequest selects the schedule of a tenant.
+type GetScheduleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Optional namespace suffix filter.
+	Namespace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScheduleRequest) Reset() {
+	*x = GetScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScheduleRequest) ProtoMessage() {}
+
+func (x *GetScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetScheduleRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetScheduleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// UpdateScheduleRequest replaces the schedule of schedule.tenant.
+type UpdateScheduleRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Schedule *CreateScheduleRequest `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// Optional namespace suffix filter.
+	Namespace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleRequest) Reset() {
+	*x = UpdateScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleRequest) ProtoMessage() {}
+
+func (x *UpdateScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateScheduleRequest) GetSchedule() *CreateScheduleRequest {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *UpdateScheduleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// UpdateScheduleResponse is returned when the schedule is updated.
+type UpdateScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduleResponse) Reset() {
+	*x = UpdateScheduleResponse{}
+	mi := &file_schedule_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduleResponse) ProtoMessage() {}
+
+func (x *UpdateScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduleResponse.ProtoReflect.Descriptor instead.
+func (*UpdateScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// DeleteScheduleRequest selects the SleepInfos to delete.
+type DeleteScheduleRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Optional namespace suffix filter.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Optional schedule name filter.
+	ScheduleName  string `protobuf:"bytes,3,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleRequest) Reset() {
+	*x = DeleteScheduleRequest{}
+	mi := &file_schedule_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteScheduleRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeleteScheduleRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteScheduleRequest) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+// DeleteScheduleResponse is returned when the schedule is deleted.
+type DeleteScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteScheduleResponse) Reset() {
+	*x = DeleteScheduleResponse{}
+	mi := &file_schedule_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteScheduleResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// ListSchedulesRequest filters the streamed schedules.
+type ListSchedulesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only tenants whose name starts with this prefix.
+	TenantPrefix string `protobuf:"bytes,1,opt,name=tenant_prefix,json=tenantPrefix,proto3" json:"tenant_prefix,omitempty"`
+	// Only SleepInfos in namespaces with this suffix.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Only SleepInfos belonging to this schedule name.
+	ScheduleName  string `protobuf:"bytes,3,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchedulesRequest) Reset() {
+	*x = ListSchedulesRequest{}
+	mi := &file_schedule_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchedulesRequest) ProtoMessage() {}
+
+func (x *ListSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSchedulesRequest) GetTenantPrefix() string {
+	if x != nil {
+		return x.TenantPrefix
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListSchedulesRequest) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+// Schedule is the schedule of a tenant grouped by namespace suffix.
+type Schedule struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Tenant        string                        `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Namespaces    map[string]*NamespaceSchedule `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	mi := &file_schedule_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Schedule) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *Schedule) GetNamespaces() map[string]*NamespaceSchedule {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+// NamespaceSchedule is the schedule of a single namespace.
+type NamespaceSchedule struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Weekdays  string                 `protobuf:"bytes,2,opt,name=weekdays,proto3" json:"weekdays,omitempty"`
+	Timezone  string                 `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// SleepInfos in chronological order.
+	Schedule      []*SleepInfoSummary `protobuf:"bytes,4,rep,name=schedule,proto3" json:"schedule,omitempty"`
+	Summary       *ScheduleSummary    `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	ScheduleName  string              `protobuf:"bytes,6,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	Description   string              `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceSchedule) Reset() {
+	*x = NamespaceSchedule{}
+	mi := &file_schedule_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceSchedule) ProtoMessage() {}
+
+func (x *NamespaceSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceSchedule.ProtoReflect.Descriptor instead.
+func (*NamespaceSchedule) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NamespaceSchedule) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceSchedule) GetWeekdays() string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return ""
+}
+
+func (x *NamespaceSchedule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *NamespaceSchedule) GetSchedule() []*SleepInfoSummary {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *NamespaceSchedule) GetSummary() *ScheduleSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *NamespaceSchedule) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+func (x *NamespaceSchedule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ScheduleSummary is a human-readable summary of a namespace schedule.
+type ScheduleSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SleepTime     string                 `protobuf:"bytes,1,opt,name=sleep_time,json=sleepTime,proto3" json:"sleep_time,omitempty"`
+	WakeTime      string                 `protobuf:"bytes,2,opt,name=wake_time,json=wakeTime,proto3" json:"wake_time,omitempty"`
+	Operations    []string               `protobuf:"bytes,3,rep,name=operations,proto3" json:"operations,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleSummary) Reset() {
+	*x = ScheduleSummary{}
+	mi := &file_schedule_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleSummary) ProtoMessage() {}
+
+func (x *ScheduleSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleSummary.ProtoReflect.Descriptor instead.
+func (*ScheduleSummary) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ScheduleSummary) GetSleepTime() string {
+	if x != nil {
+		return x.SleepTime
+	}
+	return ""
+}
+
+func (x *ScheduleSummary) GetWakeTime() string {
+	if x != nil {
+		return x.WakeTime
+	}
+	return ""
+}
+
+func (x *ScheduleSummary) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *ScheduleSummary) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// FilterRef is a resource exclusion filter.
+type FilterRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchLabels   map[string]string      `protobuf:"bytes,1,rep,name=match_labels,json=matchLabels,proto3" json:"match_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterRef) Reset() {
+	*x = FilterRef{}
+	mi := &file_schedule_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterRef) ProtoMessage() {}
+
+func (x *FilterRef) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterRef.ProtoReflect.Descriptor instead.
+func (*FilterRef) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FilterRef) GetMatchLabels() map[string]string {
+	if x != nil {
+		return x.MatchLabels
+	}
+	return nil
+}
+
+// SleepInfoSummary describes a SleepInfo of a schedule.
+type SleepInfoSummary struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// "sleep" or "wake".
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// Human-readable description of the operation.
+	Operation string `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Sleep or wake time (UTC).
+	Time         string            `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	Weekdays     string            `protobuf:"bytes,6,opt,name=weekdays,proto3" json:"weekdays,omitempty"`
+	TimeZone     string            `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	UserTimezone string            `protobuf:"bytes,8,opt,name=user_timezone,json=userTimezone,proto3" json:"user_timezone,omitempty"`
+	Resources    []string          `protobuf:"bytes,9,rep,name=resources,proto3" json:"resources,omitempty"`
+	WakeTime     string            `protobuf:"bytes,10,opt,name=wake_time,json=wakeTime,proto3" json:"wake_time,omitempty"`
+	ScheduleName string            `protobuf:"bytes,11,opt,name=schedule_name,json=scheduleName,proto3" json:"schedule_name,omitempty"`
+	Description  string            `protobuf:"bytes,12,opt,name=description,proto3" json:"description,omitempty"`
+	Annotations  map[string]string `protobuf:"bytes,13,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ExcludeRef   []*FilterRef      `protobuf:"bytes,14,rep,name=exclude_ref,json=excludeRef,proto3" json:"exclude_ref,omitempty"`
+	// Set when the schedule is temporarily suspended.
+	SuspendScheduleUntil *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=suspend_schedule_until,json=suspendScheduleUntil,proto3" json:"suspend_schedule_until,omitempty"`
+	// True when the schedule is paused until resumed.
+	Paused        bool `protobuf:"varint,16,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SleepInfoSummary) Reset() {
+	*x = SleepInfoSummary{}
+	mi := &file_schedule_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SleepInfoSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SleepInfoSummary) ProtoMessage() {}
+
+func (x *SleepInfoSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SleepInfoSummary.ProtoReflect.Descriptor instead.
+func (*SleepInfoSummary) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SleepInfoSummary) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetWeekdays() string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetUserTimezone() string {
+	if x != nil {
+		return x.UserTimezone
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *SleepInfoSummary) GetWakeTime() string {
+	if x != nil {
+		return x.WakeTime
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetScheduleName() string {
+	if x != nil {
+		return x.ScheduleName
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SleepInfoSummary) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *SleepInfoSummary) GetExcludeRef() []*FilterRef {
+	if x != nil {
+		return x.ExcludeRef
+	}
+	return nil
+}
+
+func (x *SleepInfoSummary) GetSuspendScheduleUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendScheduleUntil
+	}
+	return nil
+}
+
+func (x *SleepInfoSummary) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// ListTenantsRequest has no parameters.
+type ListTenantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsRequest) Reset() {
+	*x = ListTenantsRequest{}
+	mi := &file_schedule_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsRequest) ProtoMessage() {}
+
+func (x *ListTenantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantsRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{14}
+}
+
+// Tenant is a tenant discovered from the {tenant}-{suffix} namespaces.
+type Tenant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespaces    []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tenant) Reset() {
+	*x = Tenant{}
+	mi := &file_schedule_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tenant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tenant) ProtoMessage() {}
+
+func (x *Tenant) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tenant.ProtoReflect.Descriptor instead.
+func (*Tenant) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Tenant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tenant) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *Tenant) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// ListTenantsResponse lists the discovered tenants.
+type ListTenantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tenants       []*Tenant              `protobuf:"bytes,1,rep,name=tenants,proto3" json:"tenants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantsResponse) Reset() {
+	*x = ListTenantsResponse{}
+	mi := &file_schedule_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantsResponse) ProtoMessage() {}
+
+func (x *ListTenantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantsResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantsResponse) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTenantsResponse) GetTenants() []*Tenant {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+// GetNamespaceResourcesRequest selects a tenant namespace.
+type GetNamespaceResourcesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Tenant string                 `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// Namespace suffix (datastores, apps, ...).
+	Namespace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNamespaceResourcesRequest) Reset() {
+	*x = GetNamespaceResourcesRequest{}
+	mi := &file_schedule_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNamespaceResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNamespaceResourcesRequest) ProtoMessage() {}
+
+func (x *GetNamespaceResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNamespaceResourcesRequest.ProtoReflect.Descriptor instead.
+func (*GetNamespaceResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetNamespaceResourcesRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetNamespaceResourcesRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ResourceCounts counts the resources of a namespace by kind.
+type ResourceCounts struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Deployments    int32                  `protobuf:"varint,1,opt,name=deployments,proto3" json:"deployments,omitempty"`
+	StatefulSets   int32                  `protobuf:"varint,2,opt,name=stateful_sets,json=statefulSets,proto3" json:"stateful_sets,omitempty"`
+	CronJobs       int32                  `protobuf:"varint,3,opt,name=cron_jobs,json=cronJobs,proto3" json:"cron_jobs,omitempty"`
+	PgClusters     int32                  `protobuf:"varint,4,opt,name=pg_clusters,json=pgClusters,proto3" json:"pg_clusters,omitempty"`
+	HdfsClusters   int32                  `protobuf:"varint,5,opt,name=hdfs_clusters,json=hdfsClusters,proto3" json:"hdfs_clusters,omitempty"`
+	OsClusters     int32                  `protobuf:"varint,6,opt,name=os_clusters,json=osClusters,proto3" json:"os_clusters,omitempty"`
+	OsDashboardses int32                  `protobuf:"varint,7,opt,name=os_dashboardses,json=osDashboardses,proto3" json:"os_dashboardses,omitempty"`
+	KafkaClusters  int32                  `protobuf:"varint,8,opt,name=kafka_clusters,json=kafkaClusters,proto3" json:"kafka_clusters,omitempty"`
+	PgBouncers     int32                  `protobuf:"varint,9,opt,name=pg_bouncers,json=pgBouncers,proto3" json:"pg_bouncers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResourceCounts) Reset() {
+	*x = ResourceCounts{}
+	mi := &file_schedule_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceCounts) ProtoMessage() {}
+
+func (x *ResourceCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceCounts.ProtoReflect.Descriptor instead.
+func (*ResourceCounts) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResourceCounts) GetDeployments() int32 {
+	if x != nil {
+		return x.Deployments
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetStatefulSets() int32 {
+	if x != nil {
+		return x.StatefulSets
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetCronJobs() int32 {
+	if x != nil {
+		return x.CronJobs
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetPgClusters() int32 {
+	if x != nil {
+		return x.PgClusters
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetHdfsClusters() int32 {
+	if x != nil {
+		return x.HdfsClusters
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetOsClusters() int32 {
+	if x != nil {
+		return x.OsClusters
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetOsDashboardses() int32 {
+	if x != nil {
+		return x.OsDashboardses
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetKafkaClusters() int32 {
+	if x != nil {
+		return x.KafkaClusters
+	}
+	return 0
+}
+
+func (x *ResourceCounts) GetPgBouncers() int32 {
+	if x != nil {
+		return x.PgBouncers
+	}
+	return 0
+}
+
+// ExclusionFilter is an automatically detected exclusion.
+type ExclusionFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchLabels   map[string]string      `protobuf:"bytes,1,rep,name=match_labels,json=matchLabels,proto3" json:"match_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExclusionFilter) Reset() {
+	*x = ExclusionFilter{}
+	mi := &file_schedule_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExclusionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExclusionFilter) ProtoMessage() {}
+
+func (x *ExclusionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExclusionFilter.ProtoReflect.Descriptor instead.
+func (*ExclusionFilter) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExclusionFilter) GetMatchLabels() map[string]string {
+	if x != nil {
+		return x.MatchLabels
+	}
+	return nil
+}
+
+// NamespaceResources describes the resources detected in a namespace.
+type NamespaceResources struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Namespace       string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	HasPgCluster    bool                   `protobuf:"varint,2,opt,name=has_pg_cluster,json=hasPgCluster,proto3" json:"has_pg_cluster,omitempty"`
+	HasHdfsCluster  bool                   `protobuf:"varint,3,opt,name=has_hdfs_cluster,json=hasHdfsCluster,proto3" json:"has_hdfs_cluster,omitempty"`
+	HasOsCluster    bool                   `protobuf:"varint,4,opt,name=has_os_cluster,json=hasOsCluster,proto3" json:"has_os_cluster,omitempty"`
+	HasOsDashboards bool                   `protobuf:"varint,5,opt,name=has_os_dashboards,json=hasOsDashboards,proto3" json:"has_os_dashboards,omitempty"`
+	HasKafkaCluster bool                   `protobuf:"varint,6,opt,name=has_kafka_cluster,json=hasKafkaCluster,proto3" json:"has_kafka_cluster,omitempty"`
+	HasPgBouncer    bool                   `protobuf:"varint,7,opt,name=has_pg_bouncer,json=hasPgBouncer,proto3" json:"has_pg_bouncer,omitempty"`
+	HasVirtualizer  bool                   `protobuf:"varint,8,opt,name=has_virtualizer,json=hasVirtualizer,proto3" json:"has_virtualizer,omitempty"`
+	ResourceCounts  *ResourceCounts        `protobuf:"bytes,9,opt,name=resource_counts,json=resourceCounts,proto3" json:"resource_counts,omitempty"`
+	AutoExclusions  []*ExclusionFilter     `protobuf:"bytes,10,rep,name=auto_exclusions,json=autoExclusions,proto3" json:"auto_exclusions,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NamespaceResources) Reset() {
+	*x = NamespaceResources{}
+	mi := &file_schedule_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceResources) ProtoMessage() {}
+
+func (x *NamespaceResources) ProtoReflect() protoreflect.Message {
+	mi := &file_schedule_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceResources.ProtoReflect.Descriptor instead.
+func (*NamespaceResources) Descriptor() ([]byte, []int) {
+	return file_schedule_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NamespaceResources) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceResources) GetHasPgCluster() bool {
+	if x != nil {
+		return x.HasPgCluster
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasHdfsCluster() bool {
+	if x != nil {
+		return x.HasHdfsCluster
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasOsCluster() bool {
+	if x != nil {
+		return x.HasOsCluster
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasOsDashboards() bool {
+	if x != nil {
+		return x.HasOsDashboards
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasKafkaCluster() bool {
+	if x != nil {
+		return x.HasKafkaCluster
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasPgBouncer() bool {
+	if x != nil {
+		return x.HasPgBouncer
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetHasVirtualizer() bool {
+	if x != nil {
+		return x.HasVirtualizer
+	}
+	return false
+}
+
+func (x *NamespaceResources) GetResourceCounts() *ResourceCounts {
+	if x != nil {
+		return x.ResourceCounts
+	}
+	return nil
+}
+
+func (x *NamespaceResources) GetAutoExclusions() []*ExclusionFilter {
+	if x != nil {
+		return x.AutoExclusions
+	}
+	return nil
+}
+
+var File_schedule_proto protoreflect.FileDescriptor
+
+const file_schedule_proto_rawDesc = "" +
+	"\n" +
+	"\x0eschedule.proto\x12\x15kubegreen.schedule.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\x01\n" +
+	"\vDelayConfig\x12\"\n" +
+	"\rpg_hdfs_delay\x18\x01 \x01(\tR\vpgHdfsDelay\x12'\n" +
+	"\x0fpgbouncer_delay\x18\x02 \x01(\tR\x0epgbouncerDelay\x12+\n" +
+	"\x11deployments_delay\x18\x03 \x01(\tR\x10deploymentsDelay\"\xcc\x02\n" +
+	"\x15CreateScheduleRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x10\n" +
+	"\x03off\x18\x02 \x01(\tR\x03off\x12\x0e\n" +
+	"\x02on\x18\x03 \x01(\tR\x02on\x12\x1a\n" +
+	"\bweekdays\x18\x04 \x01(\tR\bweekdays\x12\x1d\n" +
+	"\n" +
+	"sleep_days\x18\x05 \x01(\tR\tsleepDays\x12\x1b\n" +
+	"\twake_days\x18\x06 \x01(\tR\bwakeDays\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\a \x03(\tR\n" +
+	"namespaces\x12:\n" +
+	"\x06delays\x18\b \x01(\v2\".kubegreen.schedule.v1.DelayConfigR\x06delays\x12#\n" +
+	"\rschedule_name\x18\t \x01(\tR\fscheduleName\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\"2\n" +
+	"\x16CreateScheduleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"J\n" +
+	"\x12GetScheduleRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\x7f\n" +
+	"\x15UpdateScheduleRequest\x12H\n" +
+	"\bschedule\x18\x01 \x01(\v2,.kubegreen.schedule.v1.CreateScheduleRequestR\bschedule\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"2\n" +
+	"\x16UpdateScheduleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"r\n" +
+	"\x15DeleteScheduleRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12#\n" +
+	"\rschedule_name\x18\x03 \x01(\tR\fscheduleName\"2\n" +
+	"\x16DeleteScheduleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"~\n" +
+	"\x14ListSchedulesRequest\x12#\n" +
+	"\rtenant_prefix\x18\x01 \x01(\tR\ftenantPrefix\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12#\n" +
+	"\rschedule_name\x18\x03 \x01(\tR\fscheduleName\"\xdc\x01\n" +
+	"\bSchedule\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12O\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\v2/.kubegreen.schedule.v1.Schedule.NamespacesEntryR\n" +
+	"namespaces\x1ag\n" +
+	"\x0fNamespacesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12>\n" +
+	"\x05value\x18\x02 \x01(\v2(.kubegreen.schedule.v1.NamespaceScheduleR\x05value:\x028\x01\"\xb7\x02\n" +
+	"\x11NamespaceSchedule\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\bweekdays\x18\x02 \x01(\tR\bweekdays\x12\x1a\n" +
+	"\btimezone\x18\x03 \x01(\tR\btimezone\x12C\n" +
+	"\bschedule\x18\x04 \x03(\v2'.kubegreen.schedule.v1.SleepInfoSummaryR\bschedule\x12@\n" +
+	"\asummary\x18\x05 \x01(\v2&.kubegreen.schedule.v1.ScheduleSummaryR\asummary\x12#\n" +
+	"\rschedule_name\x18\x06 \x01(\tR\fscheduleName\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\x8f\x01\n" +
+	"\x0fScheduleSummary\x12\x1d\n" +
+	"\n" +
+	"sleep_time\x18\x01 \x01(\tR\tsleepTime\x12\x1b\n" +
+	"\twake_time\x18\x02 \x01(\tR\bwakeTime\x12\x1e\n" +
+	"\n" +
+	"operations\x18\x03 \x03(\tR\n" +
+	"operations\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xa1\x01\n" +
+	"\tFilterRef\x12T\n" +
+	"\fmatch_labels\x18\x01 \x03(\v21.kubegreen.schedule.v1.FilterRef.MatchLabelsEntryR\vmatchLabels\x1a>\n" +
+	"\x10MatchLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x05\n" +
+	"\x10SleepInfoSummary\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x1c\n" +
+	"\toperation\x18\x04 \x01(\tR\toperation\x12\x12\n" +
+	"\x04time\x18\x05 \x01(\tR\x04time\x12\x1a\n" +
+	"\bweekdays\x18\x06 \x01(\tR\bweekdays\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\x12#\n" +
+	"\ruser_timezone\x18\b \x01(\tR\fuserTimezone\x12\x1c\n" +
+	"\tresources\x18\t \x03(\tR\tresources\x12\x1b\n" +
+	"\twake_time\x18\n" +
+	" \x01(\tR\bwakeTime\x12#\n" +
+	"\rschedule_name\x18\v \x01(\tR\fscheduleName\x12 \n" +
+	"\vdescription\x18\f \x01(\tR\vdescription\x12Z\n" +
+	"\vannotations\x18\r \x03(\v28.kubegreen.schedule.v1.SleepInfoSummary.AnnotationsEntryR\vannotations\x12A\n" +
+	"\vexclude_ref\x18\x0e \x03(\v2 .kubegreen.schedule.v1.FilterRefR\n" +
+	"excludeRef\x12P\n" +
+	"\x16suspend_schedule_until\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x14suspendScheduleUntil\x12\x16\n" +
+	"\x06paused\x18\x10 \x01(\bR\x06paused\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x14\n" +
+	"\x12ListTenantsRequest\"[\n" +
+	"\x06Tenant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\tR\n" +
+	"namespaces\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"N\n" +
+	"\x13ListTenantsResponse\x127\n" +
+	"\atenants\x18\x01 \x03(\v2\x1d.kubegreen.schedule.v1.TenantR\atenants\"T\n" +
+	"\x1cGetNamespaceResourcesRequest\x12\x16\n" +
+	"\x06tenant\x18\x01 \x01(\tR\x06tenant\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"\xcc\x02\n" +
+	"\x0eResourceCounts\x12 \n" +
+	"\vdeployments\x18\x01 \x01(\x05R\vdeployments\x12#\n" +
+	"\rstateful_sets\x18\x02 \x01(\x05R\fstatefulSets\x12\x1b\n" +
+	"\tcron_jobs\x18\x03 \x01(\x05R\bcronJobs\x12\x1f\n" +
+	"\vpg_clusters\x18\x04 \x01(\x05R\n" +
+	"pgClusters\x12#\n" +
+	"\rhdfs_clusters\x18\x05 \x01(\x05R\fhdfsClusters\x12\x1f\n" +
+	"\vos_clusters\x18\x06 \x01(\x05R\n" +
+	"osClusters\x12'\n" +
+	"\x0fos_dashboardses\x18\a \x01(\x05R\x0eosDashboardses\x12%\n" +
+	"\x0ekafka_clusters\x18\b \x01(\x05R\rkafkaClusters\x12\x1f\n" +
+	"\vpg_bouncers\x18\t \x01(\x05R\n" +
+	"pgBouncers\"\xad\x01\n" +
+	"\x0fExclusionFilter\x12Z\n" +
+	"\fmatch_labels\x18\x01 \x03(\v27.kubegreen.schedule.v1.ExclusionFilter.MatchLabelsEntryR\vmatchLabels\x1a>\n" +
+	"\x10MatchLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf0\x03\n" +
+	"\x12NamespaceResources\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12$\n" +
+	"\x0ehas_pg_cluster\x18\x02 \x01(\bR\fhasPgCluster\x12(\n" +
+	"\x10has_hdfs_cluster\x18\x03 \x01(\bR\x0ehasHdfsCluster\x12$\n" +
+	"\x0ehas_os_cluster\x18\x04 \x01(\bR\fhasOsCluster\x12*\n" +
+	"\x11has_os_dashboards\x18\x05 \x01(\bR\x0fhasOsDashboards\x12*\n" +
+	"\x11has_kafka_cluster\x18\x06 \x01(\bR\x0fhasKafkaCluster\x12$\n" +
+	"\x0ehas_pg_bouncer\x18\a \x01(\bR\fhasPgBouncer\x12'\n" +
+	"\x0fhas_virtualizer\x18\b \x01(\bR\x0ehasVirtualizer\x12N\n" +
+	"\x0fresource_counts\x18\t \x01(\v2%.kubegreen.schedule.v1.ResourceCountsR\x0eresourceCounts\x12O\n" +
+	"\x0fauto_exclusions\x18\n" +
+	" \x03(\v2&.kubegreen.schedule.v1.ExclusionFilterR\x0eautoExclusions2\xf9\x05\n" +
+	"\x0fScheduleService\x12m\n" +
+	"\x0eCreateSchedule\x12,.kubegreen.schedule.v1.CreateScheduleRequest\x1a-.kubegreen.schedule.v1.CreateScheduleResponse\x12Y\n" +
+	"\vGetSchedule\x12).kubegreen.schedule.v1.GetScheduleRequest\x1a\x1f.kubegreen.schedule.v1.Schedule\x12m\n" +
+	"\x0eUpdateSchedule\x12,.kubegreen.schedule.v1.UpdateScheduleRequest\x1a-.kubegreen.schedule.v1.UpdateScheduleResponse\x12m\n" +
+	"\x0eDeleteSchedule\x12,.kubegreen.schedule.v1.DeleteScheduleRequest\x1a-.kubegreen.schedule.v1.DeleteScheduleResponse\x12_\n" +
+	"\rListSchedules\x12+.kubegreen.schedule.v1.ListSchedulesRequest\x1a\x1f.kubegreen.schedule.v1.Schedule0\x01\x12d\n" +
+	"\vListTenants\x12).kubegreen.schedule.v1.ListTenantsRequest\x1a*.kubegreen.schedule.v1.ListTenantsResponse\x12w\n" +
+	"\x15GetNamespaceResources\x123.kubegreen.schedule.v1.GetNamespaceResourcesRequest\x1a).kubegreen.schedule.v1.NamespaceResourcesBBZ@github.com/kube-green/kube-green/internal/api/grpcapi/schedulepbb\x06proto3"
+
+var (
+	file_schedule_proto_rawDescOnce sync.Once
+	file_schedule_proto_rawDescData []byte
+)
+
+func file_schedule_proto_rawDescGZIP() []byte {
+	file_schedule_proto_rawDescOnce.Do(func() {
+		file_schedule_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schedule_proto_rawDesc), len(file_schedule_proto_rawDesc)))
+	})
+	return file_schedule_proto_rawDescData
+}
+
+var file_schedule_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_schedule_proto_goTypes = []any{
+	(*DelayConfig)(nil),                  // 0: kubegreen.schedule.v1.DelayConfig
+	(*CreateScheduleRequest)(nil),        // 1: kubegreen.schedule.v1.CreateScheduleRequest
+	(*CreateScheduleResponse)(nil),       // 2: kubegreen.schedule.v1.CreateScheduleResponse
+	(*GetScheduleRequest)(nil),           // 3: kubegreen.schedule.v1.GetScheduleRequest
+	(*UpdateScheduleRequest)(nil),        // 4: kubegreen.schedule.v1.UpdateScheduleRequest
+	(*UpdateScheduleResponse)(nil),       // 5: kubegreen.schedule.v1.UpdateScheduleResponse
+	(*DeleteScheduleRequest)(nil),        // 6: kubegreen.schedule.v1.DeleteScheduleRequest
+	(*DeleteScheduleResponse)(nil),       // 7: kubegreen.schedule.v1.DeleteScheduleResponse
+	(*ListSchedulesRequest)(nil),         // 8: kubegreen.schedule.v1.ListSchedulesRequest
+	(*Schedule)(nil),                     // 9: kubegreen.schedule.v1.Schedule
+	(*NamespaceSchedule)(nil),            // 10: kubegreen.schedule.v1.NamespaceSchedule
+	(*ScheduleSummary)(nil),              // 11: kubegreen.schedule.v1.ScheduleSummary
+	(*FilterRef)(nil),                    // 12: kubegreen.schedule.v1.FilterRef
+	(*SleepInfoSummary)(nil),             // 13: kubegreen.schedule.v1.SleepInfoSummary
+	(*ListTenantsRequest)(nil),           // 14: kubegreen.schedule.v1.ListTenantsRequest
+	(*Tenant)(nil),                       // 15: kubegreen.schedule.v1.Tenant
+	(*ListTenantsResponse)(nil),          // 16: kubegreen.schedule.v1.ListTenantsResponse
+	(*GetNamespaceResourcesRequest)(nil), // 17: kubegreen.schedule.v1.GetNamespaceResourcesRequest
+	(*ResourceCounts)(nil),               // 18: kubegreen.schedule.v1.ResourceCounts
+	(*ExclusionFilter)(nil),              // 19: kubegreen.schedule.v1.ExclusionFilter
+	(*NamespaceResources)(nil),           // 20: kubegreen.schedule.v1.NamespaceResources
+	nil,                                  // 21: kubegreen.schedule.v1.Schedule.NamespacesEntry
+	nil,                                  // 22: kubegreen.schedule.v1.FilterRef.MatchLabelsEntry
+	nil,                                  // 23: kubegreen.schedule.v1.SleepInfoSummary.AnnotationsEntry
+	nil,                                  // 24: kubegreen.schedule.v1.ExclusionFilter.MatchLabelsEntry
+	(*timestamppb.Timestamp)(nil),        // 25: google.protobuf.Timestamp
+}
+var file_schedule_proto_depIdxs = []int32{
+	0,  // 0: kubegreen.schedule.v1.CreateScheduleRequest.delays:type_name -> kubegreen.schedule.v1.DelayConfig
+	1,  // 1: kubegreen.schedule.v1.UpdateScheduleRequest.schedule:type_name -> kubegreen.schedule.v1.CreateScheduleRequest
+	21, // 2: kubegreen.schedule.v1.Schedule.namespaces:type_name -> kubegreen.schedule.v1.Schedule.NamespacesEntry
+	13, // 3: kubegreen.schedule.v1.NamespaceSchedule.schedule:type_name -> kubegreen.schedule.v1.SleepInfoSummary
+	11, // 4: kubegreen.schedule.v1.NamespaceSchedule.summary:type_name -> kubegreen.schedule.v1.ScheduleSummary
+	22, // 5: kubegreen.schedule.v1.FilterRef.match_labels:type_name -> kubegreen.schedule.v1.FilterRef.MatchLabelsEntry
+	23, // 6: kubegreen.schedule.v1.SleepInfoSummary.annotations:type_name -> kubegreen.schedule.v1.SleepInfoSummary.AnnotationsEntry
+	12, // 7: kubegreen.schedule.v1.SleepInfoSummary.exclude_ref:type_name -> kubegreen.schedule.v1.FilterRef
+	25, // 8: kubegreen.schedule.v1.SleepInfoSummary.suspend_schedule_until:type_name -> google.protobuf.Timestamp
+	15, // 9: kubegreen.schedule.v1.ListTenantsResponse.tenants:type_name -> kubegreen.schedule.v1.Tenant
+	24, // 10: kubegreen.schedule.v1.ExclusionFilter.match_labels:type_name -> kubegreen.schedule.v1.ExclusionFilter.MatchLabelsEntry
+	18, // 11: kubegreen.schedule.v1.NamespaceResources.resource_counts:type_name -> kubegreen.schedule.v1.ResourceCounts
+	19, // 12: kubegreen.schedule.v1.NamespaceResources.auto_exclusions:type_name -> kubegreen.schedule.v1.ExclusionFilter
+	10, // 13: kubegreen.schedule.v1.Schedule.NamespacesEntry.value:type_name -> kubegreen.schedule.v1.NamespaceSchedule
+	1,  // 14: kubegreen.schedule.v1.ScheduleService.CreateSchedule:input_type -> kubegreen.schedule.v1.CreateScheduleRequest
+	3,  // 15: kubegreen.schedule.v1.ScheduleService.GetSchedule:input_type -> kubegreen.schedule.v1.GetScheduleRequest
+	4,  // 16: kubegreen.schedule.v1.ScheduleService.UpdateSchedule:input_type -> kubegreen.schedule.v1.UpdateScheduleRequest
+	6,  // 17: kubegreen.schedule.v1.ScheduleService.DeleteSchedule:input_type -> kubegreen.schedule.v1.DeleteScheduleRequest
+	8,  // 18: kubegreen.schedule.v1.ScheduleService.ListSchedules:input_type -> kubegreen.schedule.v1.ListSchedulesRequest
+	14, // 19: kubegreen.schedule.v1.ScheduleService.ListTenants:input_type -> kubegreen.schedule.v1.ListTenantsRequest
+	17, // 20: kubegreen.schedule.v1.ScheduleService.GetNamespaceResources:input_type -> kubegreen.schedule.v1.GetNamespaceResourcesRequest
+	2,  // 21: kubegreen.schedule.v1.ScheduleService.CreateSchedule:output_type -> kubegreen.schedule.v1.CreateScheduleResponse
+	9,  // 22: kubegreen.schedule.v1.ScheduleService.GetSchedule:output_type -> kubegreen.schedule.v1.Schedule
+	5,  // 23: kubegreen.schedule.v1.ScheduleService.UpdateSchedule:output_type -> kubegreen.schedule.v1.UpdateScheduleResponse
+	7,  // 24: kubegreen.schedule.v1.ScheduleService.DeleteSchedule:output_type -> kubegreen.schedule.v1.DeleteScheduleResponse
+	9,  // 25: kubegreen.schedule.v1.ScheduleService.ListSchedules:output_type -> kubegreen.schedule.v1.Schedule
+	16, // 26: kubegreen.schedule.v1.ScheduleService.ListTenants:output_type -> kubegreen.schedule.v1.ListTenantsResponse
+	20, // 27: kubegreen.schedule.v1.ScheduleService.GetNamespaceResources:output_type -> kubegreen.schedule.v1.NamespaceResources
+	21, // [21:28] is the sub-list for method output_type
+	14, // [14:21] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_schedule_proto_init() }
+func file_schedule_proto_init() {
+	if File_schedule_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schedule_proto_rawDesc), len(file_schedule_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schedule_proto_goTypes,
+		DependencyIndexes: file_schedule_proto_depIdxs,
+		MessageInfos:      file_schedule_proto_msgTypes,
+	}.Build()
+	File_schedule_proto = out.File
+	file_schedule_proto_goTypes = nil
+	file_schedule_proto_depIdxs = nil
+}
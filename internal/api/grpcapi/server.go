@@ -0,0 +1,334 @@
+/*
+Copyright 2025.
+*/
+
+// Package grpcapi exposes the ScheduleService operations of the REST API over gRPC.
+// The protobuf definitions live in the schedulepb package (make grpc-generate).
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-green/kube-green/internal/api/grpcapi/schedulepb"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+	"github.com/kube-green/kube-green/internal/api/v1/auth"
+)
+
+const (
+	// listSchedulesPageSize is the number of tenants fetched per page while streaming ListSchedules
+	listSchedulesPageSize = 20
+
+	roleKey contextKey = "role"
+)
+
+type contextKey string
+
+// Server is the gRPC API server
+type Server struct {
+	schedulepb.UnimplementedScheduleServiceServer
+
+	logger          logr.Logger
+	port            int
+	scheduleService *apiv1.ScheduleService
+	grpcServer      *grpc.Server
+	authEnabled     bool
+	jwtSecret       []byte // empty when the secret could not be loaded, every call is then rejected
+}
+
+// Config holds the configuration for the gRPC API server
+type Config struct {
+	Port      int
+	Client    client.Client
+	Service   *apiv1.ScheduleService // shared with the REST server, see apiv1.NewService
+	Logger    logr.Logger
+	Namespace string // Kubernetes namespace for loading the JWT secret
+}
+
+// NewServer creates a new gRPC API server instance. Authentication follows the REST API:
+// when AUTH_ENABLED is set every call must carry "authorization: Bearer <token>" metadata.
+// Rate limits and the audit log are the ones of the shared ScheduleService.
+func NewServer(config Config) *Server {
+	server := &Server{
+		logger:          config.Logger,
+		port:            config.Port,
+		scheduleService: config.Service,
+	}
+
+	if auth.IsAuthEnabled() {
+		server.authEnabled = true
+		secret, err := auth.LoadJWTSecret(config.Client, config.Namespace)
+		if err != nil {
+			// Fail closed: a server that cannot validate tokens rejects every call
+			config.Logger.Error(err, "Failed to load JWT secret, gRPC calls will be rejected")
+		}
+		server.jwtSecret = secret
+	}
+
+	server.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(server.unaryRateLimitInterceptor, server.unaryAuditInterceptor, server.unaryAuthInterceptor),
+		grpc.ChainStreamInterceptor(server.streamRateLimitInterceptor, server.streamAuthInterceptor),
+	)
+	schedulepb.RegisterScheduleServiceServer(server.grpcServer, server)
+	return server
+}
+
+// Start starts the gRPC server and stops it gracefully when the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	s.logger.Info("Starting gRPC API server", "port", s.port)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			errChan <- err
+		}
+	}()
+
+	select {
+	case err := <-errChan:
+		return fmt.Errorf("grpc server error: %w", err)
+	case <-ctx.Done():
+		s.logger.Info("Shutting down gRPC API server")
+		s.grpcServer.GracefulStop()
+		return nil
+	}
+}
+
+// authenticate validates the bearer token of the call and stores the role in the context
+func (s *Server) authenticate(ctx context.Context) (context.Context, error) {
+	if !s.authEnabled {
+		return ctx, nil
+	}
+	if len(s.jwtSecret) == 0 {
+		return nil, status.Error(codes.Unavailable, "authentication is not configured: JWT secret not available")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format. Expected: Bearer <token>")
+	}
+	claims, err := auth.ValidateToken(token, s.jwtSecret)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	if user, ok := ctx.Value(callUserKey).(*callUser); ok {
+		user.username, user.role = claims.Username, claims.Role
+	}
+	// Writes are impersonated as the caller when the shared service impersonates, like the REST API
+	ctx = apiv1.WithUser(ctx, claims.Username, claims.Role)
+	return context.WithValue(ctx, roleKey, claims.Role), nil
+}
+
+func (s *Server) unaryAuthInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamAuthInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the authenticated context to stream handlers
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (a *authenticatedStream) Context() context.Context {
+	return a.ctx
+}
+
+// requireRole checks the role of the caller when authentication is enabled
+func (s *Server) requireRole(ctx context.Context, allowed func(string) bool, action string) error {
+	if !s.authEnabled {
+		return nil
+	}
+	role, _ := ctx.Value(roleKey).(string)
+	if !allowed(role) {
+		return status.Errorf(codes.PermissionDenied, "Insufficient permissions. Only admin and operacion roles can %s schedules", action)
+	}
+	return nil
+}
+
+// toStatus maps service errors to gRPC status codes, mirroring the HTTP status codes of the REST API
+func toStatus(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "no schedules found"), k8serrors.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, apiv1.ErrScheduleOverlap), errors.Is(err, apiv1.ErrNamespaceAsleep):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case k8serrors.IsConflict(err):
+		return status.Error(codes.Aborted, err.Error())
+	case k8serrors.IsForbidden(err):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// CreateSchedule creates the SleepInfos of a tenant schedule
+func (s *Server) CreateSchedule(ctx context.Context, req *schedulepb.CreateScheduleRequest) (*schedulepb.CreateScheduleResponse, error) {
+	if err := s.requireRole(ctx, auth.CanCreateSchedule, "create"); err != nil {
+		return nil, err
+	}
+	createReq := createRequestFromProto(req)
+	if err := apiv1.ValidateCreateSchedule(createReq); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.scheduleService.CreateSchedule(ctx, createReq); err != nil {
+		s.logger.Error(err, "failed to create schedule", "tenant", req.GetTenant())
+		return nil, toStatus(err)
+	}
+	return &schedulepb.CreateScheduleResponse{
+		Message: fmt.Sprintf("Schedule created successfully for tenant %s", req.GetTenant()),
+	}, nil
+}
+
+// GetSchedule returns the schedule of a tenant
+func (s *Server) GetSchedule(ctx context.Context, req *schedulepb.GetScheduleRequest) (*schedulepb.Schedule, error) {
+	if req.GetTenant() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant is required")
+	}
+	var filter []string
+	if req.GetNamespace() != "" {
+		filter = append(filter, req.GetNamespace())
+	}
+	schedule, err := s.scheduleService.GetSchedule(ctx, req.GetTenant(), filter...)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return scheduleToProto(schedule), nil
+}
+
+// UpdateSchedule replaces the schedule of a tenant
+func (s *Server) UpdateSchedule(ctx context.Context, req *schedulepb.UpdateScheduleRequest) (*schedulepb.UpdateScheduleResponse, error) {
+	if err := s.requireRole(ctx, auth.CanCreateSchedule, "update"); err != nil {
+		return nil, err
+	}
+	tenant := req.GetSchedule().GetTenant()
+	if tenant == "" {
+		return nil, status.Error(codes.InvalidArgument, "schedule.tenant is required")
+	}
+	updateReq := createRequestFromProto(req.GetSchedule())
+	if updateReq.Off == "" && updateReq.On == "" {
+		return nil, status.Error(codes.InvalidArgument, "at least 'off' or 'on' time must be provided for update")
+	}
+	var filter []string
+	if req.GetNamespace() != "" {
+		filter = append(filter, req.GetNamespace())
+	}
+	if _, err := s.scheduleService.GetSchedule(ctx, tenant, filter...); err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.scheduleService.UpdateSchedule(ctx, tenant, updateReq, filter...); err != nil {
+		s.logger.Error(err, "failed to update schedule", "tenant", tenant)
+		return nil, toStatus(err)
+	}
+	return &schedulepb.UpdateScheduleResponse{
+		Message: fmt.Sprintf("Schedule updated successfully for tenant %s", tenant),
+	}, nil
+}
+
+// DeleteSchedule deletes the schedule of a tenant
+func (s *Server) DeleteSchedule(ctx context.Context, req *schedulepb.DeleteScheduleRequest) (*schedulepb.DeleteScheduleResponse, error) {
+	if err := s.requireRole(ctx, auth.CanDeleteSchedule, "delete"); err != nil {
+		return nil, err
+	}
+	if req.GetTenant() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant is required")
+	}
+	var err error
+	if req.GetScheduleName() != "" {
+		err = s.scheduleService.DeleteScheduleByName(ctx, req.GetTenant(), req.GetScheduleName(), req.GetNamespace())
+	} else {
+		err = s.scheduleService.DeleteSchedule(ctx, req.GetTenant(), req.GetNamespace())
+	}
+	if err != nil {
+		s.logger.Error(err, "failed to delete schedule", "tenant", req.GetTenant())
+		return nil, toStatus(err)
+	}
+	return &schedulepb.DeleteScheduleResponse{
+		Message: fmt.Sprintf("Schedule deleted successfully for tenant %s", req.GetTenant()),
+	}, nil
+}
+
+// ListSchedules streams the schedules of every tenant, fetching them page by page
+func (s *Server) ListSchedules(req *schedulepb.ListSchedulesRequest, stream grpc.ServerStreamingServer[schedulepb.Schedule]) error {
+	opts := apiv1.ListSchedulesOptions{
+		TenantPrefix:    req.GetTenantPrefix(),
+		NamespaceSuffix: req.GetNamespace(),
+		ScheduleName:    req.GetScheduleName(),
+		Limit:           listSchedulesPageSize,
+	}
+	for {
+		page, err := s.scheduleService.ListSchedules(stream.Context(), opts)
+		if err != nil {
+			return toStatus(err)
+		}
+		for i := range page.Items {
+			if err := stream.Send(scheduleToProto(&page.Items[i])); err != nil {
+				return err
+			}
+		}
+		if page.Continue == "" {
+			return nil
+		}
+		opts.Continue = page.Continue
+	}
+}
+
+// ListTenants returns the tenants discovered from the namespaces
+func (s *Server) ListTenants(ctx context.Context, _ *schedulepb.ListTenantsRequest) (*schedulepb.ListTenantsResponse, error) {
+	tenants, err := s.scheduleService.ListTenants(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &schedulepb.ListTenantsResponse{Tenants: make([]*schedulepb.Tenant, 0, len(tenants.Tenants))}
+	for _, t := range tenants.Tenants {
+		resp.Tenants = append(resp.Tenants, &schedulepb.Tenant{
+			Name:       t.Name,
+			Namespaces: t.Namespaces,
+			CreatedAt:  t.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+// GetNamespaceResources returns the resources detected in a tenant namespace
+func (s *Server) GetNamespaceResources(ctx context.Context, req *schedulepb.GetNamespaceResourcesRequest) (*schedulepb.NamespaceResources, error) {
+	if req.GetTenant() == "" || req.GetNamespace() == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant and namespace are required")
+	}
+	resources, err := s.scheduleService.GetNamespaceResources(ctx, req.GetTenant(), req.GetNamespace())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return namespaceResourcesToProto(resources), nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+*/
+
+package grpcapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kube-green/kube-green/internal/api/v1/auth"
+)
+
+func withBearer(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthenticate(t *testing.T) {
+	secret := []byte("test-secret")
+	pair, err := auth.GenerateTokenPair("alice", auth.RoleOperacion, secret, time.Minute, time.Hour)
+	require.NoError(t, err)
+
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.Claims{
+		Username: "mallory",
+		Role:     auth.RoleAdmin,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}).SignedString([]byte{})
+	require.NoError(t, err)
+
+	t.Run("authentication disabled lets every call through", func(t *testing.T) {
+		s := &Server{}
+		ctx, err := s.authenticate(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, s.requireRole(ctx, auth.CanCreateSchedule, "create"))
+	})
+
+	t.Run("valid token stores the role", func(t *testing.T) {
+		s := &Server{authEnabled: true, jwtSecret: secret}
+		ctx, err := s.authenticate(withBearer(pair.AccessToken))
+		require.NoError(t, err)
+		require.Equal(t, auth.RoleOperacion, ctx.Value(roleKey))
+	})
+
+	t.Run("missing metadata is unauthenticated", func(t *testing.T) {
+		s := &Server{authEnabled: true, jwtSecret: secret}
+		_, err := s.authenticate(context.Background())
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("token signed with an empty key is rejected", func(t *testing.T) {
+		s := &Server{authEnabled: true, jwtSecret: secret}
+		_, err := s.authenticate(withBearer(forged))
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing secret rejects every call", func(t *testing.T) {
+		for _, s := range []*Server{{authEnabled: true}, {authEnabled: true, jwtSecret: []byte{}}} {
+			_, err := s.authenticate(withBearer(forged))
+			require.Equal(t, codes.Unavailable, status.Code(err))
+			_, err = s.authenticate(withBearer(pair.AccessToken))
+			require.Equal(t, codes.Unavailable, status.Code(err))
+		}
+	})
+
+	t.Run("role check applies when authentication is enabled", func(t *testing.T) {
+		s := &Server{authEnabled: true, jwtSecret: secret}
+		err := s.requireRole(context.WithValue(context.Background(), roleKey, auth.RoleLectura), auth.CanCreateSchedule, "create")
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
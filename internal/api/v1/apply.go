@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SleepInfoFieldManager is the field manager of the SleepInfos written by the API with server-side apply
+const SleepInfoFieldManager = "kube-green-api"
+
+// legacyFieldManager is the field manager of the Update requests of the previous versions of the API,
+// which the API server takes from the user agent of the kube-green binary
+const legacyFieldManager = "kube-green"
+
+// applySleepInfo creates or updates the SleepInfo with server-side apply. The applied fields are owned by
+// SleepInfoFieldManager: the fields applied before and missing now are removed, the ones set by other
+// writers are kept. On success sleepInfo is the object stored by the API server.
+func (s *ScheduleService) applySleepInfo(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	return s.applyObject(ctx, sleepInfo, "SleepInfo")
+}
+
+// applyObject creates or updates a kube-green object of the given kind with server-side apply, as
+// applySleepInfo. On success obj is the object stored by the API server.
+func (s *ScheduleService) applyObject(ctx context.Context, obj client.Object, kind string) error {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", kind, err)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if err := s.applyUnstructured(ctx, u, kind); err != nil {
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj)
+}
+
+// applyUnstructured applies the fields of u, a kube-green object of the given kind, as
+// SleepInfoFieldManager. On success u is the object stored by the API server.
+func (s *ScheduleService) applyUnstructured(ctx context.Context, u *unstructured.Unstructured, kind string) error {
+	u.SetAPIVersion(kubegreenv1alpha1.GroupVersion.String())
+	u.SetKind(kind)
+	u.SetResourceVersion("")
+	u.SetManagedFields(nil)
+	unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(u.Object, "status")
+	return s.client.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), client.FieldOwner(SleepInfoFieldManager), client.ForceOwnership)
+}
+
+// adoptLegacySpec moves the ownership of the spec fields written by the Update requests of the previous
+// versions of the API to SleepInfoFieldManager. Otherwise the fields no longer in the schedule would
+// still be owned by the Update manager and kept by the next apply. The fields of other writers, such as
+// kubectl edit, stay theirs. It is done once per SleepInfo, and never in GitOps mode, where the whole
+// manifest is committed.
+func (s *ScheduleService) adoptLegacySpec(ctx context.Context, existing *kubegreenv1alpha1.SleepInfo) error {
+	if s.gitOps != nil {
+		return nil
+	}
+	for _, entry := range existing.ManagedFields {
+		if entry.Manager == SleepInfoFieldManager && entry.Operation == metav1.ManagedFieldsOperationApply {
+			return nil
+		}
+	}
+
+	var spec map[string]interface{}
+	managedFields := make([]metav1.ManagedFieldsEntry, 0, len(existing.ManagedFields)+1)
+	for _, entry := range existing.ManagedFields {
+		if entry.Manager != legacyFieldManager || entry.Operation != metav1.ManagedFieldsOperationUpdate ||
+			entry.Subresource != "" || entry.FieldsV1 == nil {
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return fmt.Errorf("failed to read the managed fields of %s: %w", entry.Manager, err)
+		}
+		owned, ok := fields["f:spec"].(map[string]interface{})
+		if !ok {
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		spec = mergeFieldSets(spec, owned)
+		delete(fields, "f:spec")
+		if len(fields) == 0 {
+			continue
+		}
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		entry.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+		managedFields = append(managedFields, entry)
+	}
+	if spec == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{"f:spec": spec})
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	managedFields = append(managedFields, metav1.ManagedFieldsEntry{
+		Manager:    SleepInfoFieldManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+		Time:       &now,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: raw},
+	})
+
+	patch := client.MergeFromWithOptions(existing.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	existing.ManagedFields = managedFields
+	if err := s.client.Patch(ctx, existing, patch); err != nil {
+		return fmt.Errorf("failed to adopt the spec of SleepInfo %s in namespace %s: %w", existing.Name, existing.Namespace, err)
+	}
+	s.logger.Info("SleepInfo spec adopted by the apply field manager", "name", existing.Name, "namespace", existing.Namespace)
+	return nil
+}
+
+// mergeFieldSets returns the union of two FieldsV1 sets
+func mergeFieldSets(a, b map[string]interface{}) map[string]interface{} {
+	if a == nil {
+		a = map[string]interface{}{}
+	}
+	for key, value := range b {
+		nested, ok := value.(map[string]interface{})
+		current, exists := a[key].(map[string]interface{})
+		if ok && exists {
+			a[key] = mergeFieldSets(current, nested)
+			continue
+		}
+		if _, exists := a[key]; !exists {
+			a[key] = value
+		}
+	}
+	return a
+}
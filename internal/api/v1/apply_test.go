@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// specFieldsOf returns the top-level spec fields owned by each manager and operation
+func specFieldsOf(t *testing.T, si *kubegreenv1alpha1.SleepInfo) map[string][]string {
+	t.Helper()
+	owners := map[string][]string{}
+	for _, entry := range si.ManagedFields {
+		fields := map[string]map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(entry.FieldsV1.Raw, &fields))
+		for field := range fields["f:spec"] {
+			key := entry.Manager + "/" + string(entry.Operation)
+			owners[key] = append(owners[key], field)
+		}
+	}
+	return owners
+}
+
+func TestAdoptLegacySpec(t *testing.T) {
+	ctx := context.Background()
+	service, c := newTestService(t)
+
+	// Written by a previous version of the API, then edited with kubectl
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "bda-apps", Namespace: "bda-apps"},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			Weekdays:   "1-5",
+			SleepTime:  "22:00",
+			WakeUpTime: "06:00",
+		},
+	}
+	require.NoError(t, c.Create(ctx, sleepInfo, client.FieldOwner(legacyFieldManager)))
+	sleepInfo.Spec.ExcludeRef = []kubegreenv1alpha1.FilterRef{{Kind: "Deployment", Name: "keep"}}
+	require.NoError(t, c.Update(ctx, sleepInfo, client.FieldOwner("kubectl-edit")))
+
+	existing := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(sleepInfo), existing))
+	require.ElementsMatch(t, []string{"f:weekdays", "f:sleepAt", "f:wakeUpAt"}, specFieldsOf(t, existing)[legacyFieldManager+"/Update"])
+
+	require.NoError(t, service.adoptLegacySpec(ctx, existing))
+
+	adopted := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(sleepInfo), adopted))
+	owners := specFieldsOf(t, adopted)
+	require.ElementsMatch(t, []string{"f:weekdays", "f:sleepAt", "f:wakeUpAt"}, owners[SleepInfoFieldManager+"/Apply"])
+	require.Empty(t, owners[legacyFieldManager+"/Update"])
+	// The field set by another manager stays its own, so the next apply keeps it
+	require.Equal(t, []string{"f:excludeRef"}, owners["kubectl-edit/Update"])
+
+	// Adopting again is a no-op
+	require.NoError(t, service.adoptLegacySpec(ctx, adopted))
+	again := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(sleepInfo), again))
+	require.Equal(t, adopted.ResourceVersion, again.ResourceVersion)
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// RequestIDHeader carries the request ID. A valid incoming value (at most 128 letters, digits, '.',
+	// '_' or '-') is kept, otherwise one is generated.
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey       = "requestID"
+	auditReasonSuccess = "APIAuditSuccess"
+	auditReasonFailure = "APIAuditFailure"
+	maxRequestIDLength = 128
+)
+
+// AuditConfig configures where audit records of mutating API requests are written.
+// Both sinks are optional and can be enabled at the same time.
+type AuditConfig struct {
+	LogPath  string               // JSON lines file, "-" writes to stdout
+	Recorder record.EventRecorder // Kubernetes Events recorded on every touched namespace
+}
+
+// AuditRecord describes a mutating API request
+type AuditRecord struct {
+	Time       time.Time `json:"time"`
+	RequestID  string    `json:"requestId"`
+	Subject    string    `json:"subject"` // Authenticated username, "anonymous" when auth is disabled or failed
+	Role       string    `json:"role,omitempty"`
+	ClientIP   string    `json:"clientIp"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Operation  string    `json:"operation"` // Route template, e.g. "PUT /api/v1/schedules/:tenant"
+	Tenant     string    `json:"tenant,omitempty"`
+	Namespaces []string  `json:"namespaces,omitempty"` // Namespaces where objects were created, updated or deleted
+	Status     int       `json:"status"`
+	Result     string    `json:"result"` // success or failure
+	Error      string    `json:"error,omitempty"`
+	LatencyMs  int64     `json:"latencyMs"`
+}
+
+// auditLogger writes audit records to the configured sinks
+type auditLogger struct {
+	mu       sync.Mutex
+	out      io.Writer
+	recorder record.EventRecorder
+	logger   logr.Logger
+}
+
+func newAuditLogger(config AuditConfig, logger logr.Logger) (*auditLogger, error) {
+	a := &auditLogger{recorder: config.Recorder, logger: logger}
+	switch config.LogPath {
+	case "":
+	case "-":
+		a.out = os.Stdout
+	default:
+		f, err := os.OpenFile(config.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log %s: %w", config.LogPath, err)
+		}
+		a.out = f
+	}
+	return a, nil
+}
+
+func (a *auditLogger) write(rec AuditRecord) {
+	if a.out != nil {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			a.logger.Error(err, "failed to marshal audit record", "requestId", rec.RequestID)
+		} else {
+			a.mu.Lock()
+			_, err = a.out.Write(append(line, '\n'))
+			a.mu.Unlock()
+			if err != nil {
+				a.logger.Error(err, "failed to write audit record", "requestId", rec.RequestID)
+			}
+		}
+	}
+
+	if a.recorder != nil {
+		eventType, reason := v1.EventTypeNormal, auditReasonSuccess
+		if rec.Result != "success" {
+			eventType, reason = v1.EventTypeWarning, auditReasonFailure
+		}
+		for _, ns := range rec.Namespaces {
+			ref := &v1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: ns, Namespace: ns}
+			a.recorder.Eventf(ref, eventType, reason, "%s by %s (status %d, request %s)",
+				rec.Operation, rec.Subject, rec.Status, rec.RequestID)
+		}
+	}
+}
+
+// requestIDMiddleware assigns a request ID to every request and returns it in the X-Request-ID header
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := RequestID(c.GetHeader(RequestIDHeader))
+		c.Set(requestIDKey, requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// RequestID returns the incoming request ID when it is valid, or a new one. The ID is written to the
+// logs, the audit log and the Events, so anything but a short token of safe characters is replaced.
+func RequestID(incoming string) string {
+	if incoming == "" || len(incoming) > maxRequestIDLength {
+		return newRequestID()
+	}
+	for _, r := range incoming {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			return newRequestID()
+		}
+	}
+	return incoming
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// auditMiddleware emits an audit record for every mutating request (POST, PUT, PATCH, DELETE),
+// including the ones rejected by authentication or permission checks.
+func auditMiddleware(service *ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+		// Login and refresh carry credentials and are not schedule mutations
+		if strings.HasPrefix(c.Request.URL.Path, "/api/v1/auth/") {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		ctx, touched := BeginAudit(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		rec := AuditRecord{
+			Time:       start.UTC(),
+			RequestID:  c.GetString(requestIDKey),
+			Subject:    c.GetString("username"),
+			Role:       c.GetString("role"),
+			ClientIP:   c.ClientIP(),
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			Operation:  c.Request.Method + " " + c.FullPath(),
+			Tenant:     c.Param("tenant"),
+			Namespaces: touched(),
+			Status:     c.Writer.Status(),
+			LatencyMs:  time.Since(start).Milliseconds(),
+		}
+		if rec.Tenant == "" && len(rec.Namespaces) > 0 {
+			rec.Tenant = service.namespaceTenant(c.Request.Context(), rec.Namespaces[0])
+		}
+		rec.Result = "success"
+		if rec.Status >= http.StatusBadRequest {
+			rec.Result = "failure"
+			rec.Error = c.Errors.String()
+		}
+		service.WriteAudit(rec)
+	}
+}
+
+type touchedNamespacesKey struct{}
+
+// touchedNamespaces collects the namespaces written during a request
+type touchedNamespaces struct {
+	mu         sync.Mutex
+	namespaces map[string]struct{}
+}
+
+func (t *touchedNamespaces) add(namespace string) {
+	if namespace == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.namespaces == nil {
+		t.namespaces = make(map[string]struct{})
+	}
+	t.namespaces[namespace] = struct{}{}
+}
+
+func (t *touchedNamespaces) list() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	namespaces := make([]string, 0, len(t.namespaces))
+	for ns := range t.namespaces {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// auditedClient records the namespaces of every successful write into the request audit record
+type auditedClient struct {
+	client.Client
+}
+
+func recordTouchedNamespace(ctx context.Context, obj client.Object) {
+	if touched, ok := ctx.Value(touchedNamespacesKey{}).(*touchedNamespaces); ok {
+		touched.add(obj.GetNamespace())
+	}
+}
+
+func (c auditedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	if err == nil {
+		recordTouchedNamespace(ctx, obj)
+	}
+	return err
+}
+
+func (c auditedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	if err == nil {
+		recordTouchedNamespace(ctx, obj)
+	}
+	return err
+}
+
+func (c auditedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	if err == nil {
+		recordTouchedNamespace(ctx, obj)
+	}
+	return err
+}
+
+func (c auditedClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	err := c.Client.Apply(ctx, obj, opts...)
+	if err == nil {
+		if namespaced, ok := obj.(interface{ GetNamespace() string }); ok {
+			if touched, ok := ctx.Value(touchedNamespacesKey{}).(*touchedNamespaces); ok {
+				touched.add(namespaced.GetNamespace())
+			}
+		}
+	}
+	return err
+}
+
+func (c auditedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	if err == nil {
+		recordTouchedNamespace(ctx, obj)
+	}
+	return err
+}
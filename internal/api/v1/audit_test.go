@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	for _, valid := range []string{"abc", "7f2c9e1a-4b3d.req_01", strings.Repeat("a", maxRequestIDLength)} {
+		require.Equal(t, valid, RequestID(valid))
+	}
+
+	for _, invalid := range []string{
+		"",
+		strings.Repeat("a", maxRequestIDLength+1),
+		"id with spaces",
+		"id\nfake=\"log line\"",
+		"<script>",
+		"ñandú",
+	} {
+		generated := RequestID(invalid)
+		require.NotEqual(t, invalid, generated)
+		require.Len(t, generated, 32)
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestIDMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(requestIDKey))
+	})
+	get := func(requestID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if requestID != "" {
+			req.Header.Set(RequestIDHeader, requestID)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("client-id_1.2")
+	require.Equal(t, "client-id_1.2", rec.Header().Get(RequestIDHeader))
+	require.Equal(t, "client-id_1.2", rec.Body.String())
+
+	rec = get("forged\" role=admin")
+	require.NotContains(t, rec.Header().Get(RequestIDHeader), "forged")
+	require.Equal(t, rec.Header().Get(RequestIDHeader), rec.Body.String())
+
+	rec = get("")
+	require.NotEmpty(t, rec.Header().Get(RequestIDHeader))
+}
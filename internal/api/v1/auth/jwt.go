@@ -5,6 +5,7 @@ Copyright 2025.
 package auth
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -17,6 +18,10 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// ErrEmptySecret is returned when a token is signed or validated without a secret.
+// An empty HMAC key is accepted by the jwt library, so it would let anyone forge tokens.
+var ErrEmptySecret = errors.New("JWT secret is empty")
+
 // TokenPair represents a pair of access and refresh tokens
 type TokenPair struct {
 	AccessToken  string `json:"accessToken"`
@@ -26,6 +31,9 @@ type TokenPair struct {
 
 // GenerateTokenPair generates both access and refresh tokens
 func GenerateTokenPair(username, role string, secret []byte, accessExpiration, refreshExpiration time.Duration) (*TokenPair, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
 	now := time.Now()
 
 	// Generate access token
@@ -75,6 +83,9 @@ func GenerateTokenPair(username, role string, secret []byte, accessExpiration, r
 
 // ValidateToken validates a JWT token and returns the claims
 func ValidateToken(tokenString string, secret []byte) (*Claims, error) {
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
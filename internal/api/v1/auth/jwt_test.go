@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+*/
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateToken(t *testing.T) {
+	secret := []byte("test-secret")
+
+	t.Run("accepts a token signed with the secret", func(t *testing.T) {
+		pair, err := GenerateTokenPair("alice", RoleAdmin, secret, time.Minute, time.Hour)
+		require.NoError(t, err)
+
+		claims, err := ValidateToken(pair.AccessToken, secret)
+		require.NoError(t, err)
+		require.Equal(t, "alice", claims.Username)
+		require.Equal(t, RoleAdmin, claims.Role)
+	})
+
+	t.Run("rejects a token signed with another secret", func(t *testing.T) {
+		pair, err := GenerateTokenPair("alice", RoleAdmin, []byte("other"), time.Minute, time.Hour)
+		require.NoError(t, err)
+
+		_, err = ValidateToken(pair.AccessToken, secret)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects a token signed with an empty key", func(t *testing.T) {
+		forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{
+			Username: "mallory",
+			Role:     RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}).SignedString([]byte{})
+		require.NoError(t, err)
+
+		_, err = ValidateToken(forged, []byte{})
+		require.ErrorIs(t, err, ErrEmptySecret)
+		_, err = ValidateToken(forged, nil)
+		require.ErrorIs(t, err, ErrEmptySecret)
+	})
+
+	t.Run("does not sign tokens with an empty secret", func(t *testing.T) {
+		_, err := GenerateTokenPair("alice", RoleAdmin, nil, time.Minute, time.Hour)
+		require.ErrorIs(t, err, ErrEmptySecret)
+	})
+}
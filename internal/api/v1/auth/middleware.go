@@ -92,6 +92,10 @@ func JWTAuthMiddleware(secret []byte, enabled bool) gin.HandlerFunc {
 
 		// For protected paths, require Authorization header
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && (path == "/api/v1/events" || isCalendarPath(path)) && c.Query("access_token") != "" {
+			// EventSource and calendar subscriptions cannot send headers, they accept the token as query parameter
+			authHeader = "Bearer " + c.Query("access_token")
+		}
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -132,3 +136,7 @@ func JWTAuthMiddleware(secret []byte, enabled bool) gin.HandlerFunc {
 	}
 }
 
+// isCalendarPath reports whether path is an iCal feed (/api/v1/schedules/{tenant}/calendar.ics)
+func isCalendarPath(path string) bool {
+	return strings.HasPrefix(path, "/api/v1/schedules/") && strings.HasSuffix(path, "/calendar.ics")
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/notifications"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// bulkDeleteConcurrency is the number of deletions executed at the same time
+	bulkDeleteConcurrency = 8
+
+	BulkItemDeleted  = "deleted"
+	BulkItemNotFound = "not_found"
+	BulkItemFailed   = "failed"
+)
+
+// BulkDeleteRequest selects the schedules to delete. Either Tenants or a selector is required.
+type BulkDeleteRequest struct {
+	Tenants            []string // Tenants whose schedules are deleted
+	Namespace          string   // Optional namespace suffix filter
+	ScheduleName       string   // Optional schedule name filter
+	LabelSelector      string   // Selects SleepInfos by label, e.g. "team=data"
+	AnnotationSelector string   // Selects SleepInfos by annotation, e.g. "kube-green.stratio.com/schedule-name=night"
+}
+
+// BulkDeleteItemResult is the result of one deletion. With tenants only, an item is a tenant;
+// with selectors, an item is a SleepInfo.
+type BulkDeleteItemResult struct {
+	Tenant    string `json:"tenant"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"` // SleepInfo name, selector deletions only
+	Status    string `json:"status"`         // deleted, not_found or failed
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkDeleteResponse is the result of a bulk deletion
+type BulkDeleteResponse struct {
+	Deleted  int                    `json:"deleted"`
+	NotFound int                    `json:"notFound"`
+	Failed   int                    `json:"failed"`
+	Results  []BulkDeleteItemResult `json:"results"`
+}
+
+func (r *BulkDeleteResponse) add(item BulkDeleteItemResult) {
+	switch item.Status {
+	case BulkItemDeleted:
+		r.Deleted++
+	case BulkItemNotFound:
+		r.NotFound++
+	default:
+		r.Failed++
+	}
+	r.Results = append(r.Results, item)
+}
+
+// BulkDeleteSchedules deletes the schedules of several tenants, or the SleepInfos matching the selectors,
+// concurrently, and reports the result of every item.
+func (s *ScheduleService) BulkDeleteSchedules(ctx context.Context, req BulkDeleteRequest) (*BulkDeleteResponse, error) {
+	if len(req.Tenants) == 0 && req.LabelSelector == "" && req.AnnotationSelector == "" {
+		return nil, fmt.Errorf("at least one tenant or selector is required")
+	}
+	if req.LabelSelector == "" && req.AnnotationSelector == "" {
+		return s.bulkDeleteTenants(ctx, req), nil
+	}
+	return s.bulkDeleteSelected(ctx, req)
+}
+
+func (s *ScheduleService) bulkDeleteTenants(ctx context.Context, req BulkDeleteRequest) *BulkDeleteResponse {
+	results := make([]BulkDeleteItemResult, len(req.Tenants))
+	runConcurrently(len(req.Tenants), bulkDeleteConcurrency, func(i int) {
+		tenant := req.Tenants[i]
+		var err error
+		if req.ScheduleName != "" {
+			err = s.DeleteScheduleByName(ctx, tenant, req.ScheduleName, req.Namespace)
+		} else {
+			err = s.DeleteSchedule(ctx, tenant, req.Namespace)
+		}
+		results[i] = BulkDeleteItemResult{Tenant: tenant, Status: BulkItemDeleted}
+		if req.Namespace != "" {
+			results[i].Namespace = fmt.Sprintf("%s-%s", tenant, req.Namespace)
+		}
+		switch {
+		case err == nil:
+		case strings.Contains(err.Error(), "no schedules found"):
+			results[i].Status = BulkItemNotFound
+			results[i].Error = err.Error()
+		default:
+			results[i].Status = BulkItemFailed
+			results[i].Error = err.Error()
+		}
+	})
+
+	response := &BulkDeleteResponse{Results: []BulkDeleteItemResult{}}
+	for _, item := range results {
+		response.add(item)
+	}
+	return response
+}
+
+func (s *ScheduleService) bulkDeleteSelected(ctx context.Context, req BulkDeleteRequest) (*BulkDeleteResponse, error) {
+	listOpts := []client.ListOption{}
+	if req.LabelSelector != "" {
+		selector, err := labels.Parse(req.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: selector})
+	}
+	annotationSelector := labels.Everything()
+	if req.AnnotationSelector != "" {
+		selector, err := labels.Parse(req.AnnotationSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid annotationSelector: %w", err)
+		}
+		annotationSelector = selector
+	}
+
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.reader.List(ctx, sleepInfoList, listOpts...); err != nil {
+		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+	}
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenants := make(map[string]bool, len(req.Tenants))
+	for _, tenant := range req.Tenants {
+		tenants[tenant] = true
+	}
+
+	var selected []kubegreenv1alpha1.SleepInfo
+	for _, si := range sleepInfoList.Items {
+		tenant, suffix, ok := resolver.split(si.Namespace)
+		if !ok || (len(tenants) > 0 && !tenants[tenant]) {
+			continue
+		}
+		if req.Namespace != "" && suffix != req.Namespace {
+			continue
+		}
+		if !matchesScheduleName(si, req.ScheduleName) || !annotationSelector.Matches(labels.Set(si.Annotations)) {
+			continue
+		}
+		selected = append(selected, si)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Namespace != selected[j].Namespace {
+			return selected[i].Namespace < selected[j].Namespace
+		}
+		return selected[i].Name < selected[j].Name
+	})
+
+	results := make([]BulkDeleteItemResult, len(selected))
+	runConcurrently(len(selected), bulkDeleteConcurrency, func(i int) {
+		si := selected[i]
+		tenant, _, _ := resolver.split(si.Namespace)
+		results[i] = BulkDeleteItemResult{Tenant: tenant, Namespace: si.Namespace, Name: si.Name, Status: BulkItemDeleted}
+
+		if err := s.client.Delete(ctx, &si); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				results[i].Status = BulkItemNotFound
+			} else {
+				results[i].Status = BulkItemFailed
+			}
+			results[i].Error = err.Error()
+			return
+		}
+		s.logger.Info("SleepInfo deleted", "name", si.Name, "namespace", si.Namespace)
+	})
+
+	response := &BulkDeleteResponse{Results: []BulkDeleteItemResult{}}
+	notified := make(map[string]bool)
+	for _, item := range results {
+		response.add(item)
+		if item.Status == BulkItemDeleted && !notified[item.Namespace] {
+			notified[item.Namespace] = true
+			suffix := resolver.suffix(item.Namespace)
+			s.notifyScheduleEvent(notifications.EventScheduleDeleted, item.Tenant, suffix, req.ScheduleName, nil)
+		}
+	}
+	return response, nil
+}
+
+// runConcurrently calls fn for every index in [0, n) with at most concurrency calls at a time
+func runConcurrently(n, concurrency int, fn func(i int)) {
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BundleAPIVersion is the version of the format of the cluster bundles
+const BundleAPIVersion = "kube-green.stratio.com/bundle/v1"
+
+// ClusterBundle is the portable copy of the schedules of a cluster, used to restore them in another
+// cluster for disaster recovery or migrations
+type ClusterBundle struct {
+	APIVersion string         `json:"apiVersion"`
+	ExportedAt time.Time      `json:"exportedAt"`
+	Tenants    []TenantBundle `json:"tenants"`
+}
+
+// TenantBundle is the schedule of a tenant in a ClusterBundle. The SleepInfos generated by a
+// TenantSchedule or a ClusterSleepInfo are not exported: the TenantSchedules are, and generate them again.
+type TenantBundle struct {
+	Tenant          string                             `json:"tenant"`
+	Namespaces      []string                           `json:"namespaces"` // Namespaces of the tenant when exported
+	SleepInfos      []kubegreenv1alpha1.SleepInfo      `json:"sleepInfos"`
+	TenantSchedules []kubegreenv1alpha1.TenantSchedule `json:"tenantSchedules,omitempty"`
+}
+
+// BundleImportOptions select how a ClusterBundle is imported
+type BundleImportOptions struct {
+	// TenantMapping renames tenants, old to new: their TenantSchedules target the new tenant and the
+	// {old}- prefix of their namespaces becomes {new}-
+	TenantMapping map[string]string
+	// NamespaceMapping renames namespaces, old to new, before TenantMapping
+	NamespaceMapping map[string]string
+	// DryRun reports what would be imported without writing
+	DryRun bool
+}
+
+// BundleImportSkip is an object of the bundle not imported
+type BundleImportSkip struct {
+	Object string `json:"object"` // kind namespace/name
+	Reason string `json:"reason"`
+}
+
+// BundleImportReport is the result of a bundle import
+type BundleImportReport struct {
+	DryRun  bool               `json:"dryRun"`
+	Applied []string           `json:"applied"` // kind namespace/name, after remapping
+	Skipped []BundleImportSkip `json:"skipped,omitempty"`
+}
+
+// ExportBundle returns the SleepInfos and TenantSchedules of every tenant, or of tenant when set, grouped
+// by tenant. Secrets are not exported: the controller recreates them on the first sleep.
+func (s *ScheduleService) ExportBundle(ctx context.Context, tenant string) (*ClusterBundle, error) {
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaceList := &v1.NamespaceList{}
+	if err := s.client.List(ctx, namespaceList); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.client.List(ctx, sleepInfoList); err != nil {
+		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+	}
+	tenantScheduleList := &kubegreenv1alpha1.TenantScheduleList{}
+	if err := s.client.List(ctx, tenantScheduleList); err != nil {
+		return nil, fmt.Errorf("failed to list TenantSchedules: %w", err)
+	}
+
+	tenants := map[string]*TenantBundle{}
+	bundleOf := func(name string) *TenantBundle {
+		if tenants[name] == nil {
+			tenants[name] = &TenantBundle{Tenant: name, Namespaces: []string{}, SleepInfos: []kubegreenv1alpha1.SleepInfo{}}
+		}
+		return tenants[name]
+	}
+	for _, ns := range namespaceList.Items {
+		nsTenant, _, ok := resolver.split(ns.Name)
+		if !ok || (tenant != "" && nsTenant != tenant) {
+			continue
+		}
+		bundle := bundleOf(nsTenant)
+		bundle.Namespaces = append(bundle.Namespaces, ns.Name)
+	}
+	for _, si := range sleepInfoList.Items {
+		if si.Labels[kubegreenv1alpha1.TenantScheduleLabel] != "" || si.Labels[kubegreenv1alpha1.ClusterSleepInfoLabel] != "" {
+			continue
+		}
+		siTenant, _, ok := resolver.split(si.Namespace)
+		if !ok || (tenant != "" && siTenant != tenant) {
+			continue
+		}
+		bundle := bundleOf(siTenant)
+		bundle.SleepInfos = append(bundle.SleepInfos, cleanSleepInfoForExport(si))
+	}
+	for _, ts := range tenantScheduleList.Items {
+		if tenant != "" && ts.Spec.Tenant != tenant {
+			continue
+		}
+		bundle := bundleOf(ts.Spec.Tenant)
+		bundle.TenantSchedules = append(bundle.TenantSchedules, kubegreenv1alpha1.TenantSchedule{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+				Kind:       "TenantSchedule",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        ts.Name,
+				Labels:      ts.Labels,
+				Annotations: withoutLastApplied(ts.Annotations),
+			},
+			Spec: *ts.Spec.DeepCopy(),
+		})
+	}
+
+	export := &ClusterBundle{APIVersion: BundleAPIVersion, ExportedAt: time.Now().UTC(), Tenants: []TenantBundle{}}
+	for _, bundle := range tenants {
+		sort.Strings(bundle.Namespaces)
+		sort.Slice(bundle.SleepInfos, func(i, j int) bool {
+			a, b := bundle.SleepInfos[i], bundle.SleepInfos[j]
+			return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+		})
+		sort.Slice(bundle.TenantSchedules, func(i, j int) bool {
+			return bundle.TenantSchedules[i].Name < bundle.TenantSchedules[j].Name
+		})
+		export.Tenants = append(export.Tenants, *bundle)
+	}
+	sort.Slice(export.Tenants, func(i, j int) bool {
+		return export.Tenants[i].Tenant < export.Tenants[j].Tenant
+	})
+	return export, nil
+}
+
+// ImportBundle applies the SleepInfos and TenantSchedules of a bundle with server-side apply, remapping
+// their tenants and namespaces. The SleepInfos of namespaces missing in the cluster are skipped, so a
+// bundle can be imported before every namespace is created and imported again later.
+func (s *ScheduleService) ImportBundle(ctx context.Context, bundle *ClusterBundle, opts BundleImportOptions) (*BundleImportReport, error) {
+	if bundle.APIVersion != BundleAPIVersion {
+		return nil, fmt.Errorf("unsupported bundle apiVersion %q (expected %s)", bundle.APIVersion, BundleAPIVersion)
+	}
+
+	report := &BundleImportReport{DryRun: opts.DryRun, Applied: []string{}}
+	namespaceExists := map[string]bool{}
+	for _, tenantBundle := range bundle.Tenants {
+		tenant := tenantBundle.Tenant
+		if mapped, ok := opts.TenantMapping[tenant]; ok {
+			tenant = mapped
+		}
+
+		for _, exported := range tenantBundle.SleepInfos {
+			si := exported.DeepCopy()
+			si.Namespace = opts.remapNamespace(tenantBundle.Tenant, exported.Namespace)
+			object := fmt.Sprintf("SleepInfo %s/%s", si.Namespace, si.Name)
+
+			exists, checked := namespaceExists[si.Namespace]
+			if !checked {
+				err := s.reader.Get(ctx, client.ObjectKey{Name: si.Namespace}, &v1.Namespace{})
+				if err != nil && !apierrors.IsNotFound(err) {
+					return report, fmt.Errorf("failed to get namespace %s: %w", si.Namespace, err)
+				}
+				exists = err == nil
+				namespaceExists[si.Namespace] = exists
+			}
+			if !exists {
+				report.Skipped = append(report.Skipped, BundleImportSkip{Object: object, Reason: "namespace not found"})
+				continue
+			}
+			if !opts.DryRun {
+				if err := s.applySleepInfo(ctx, si); err != nil {
+					return report, fmt.Errorf("failed to import %s: %w", object, err)
+				}
+			}
+			report.Applied = append(report.Applied, object)
+		}
+
+		for _, exported := range tenantBundle.TenantSchedules {
+			ts := exported.DeepCopy()
+			ts.Spec.Tenant = tenant
+			object := fmt.Sprintf("TenantSchedule %s", ts.Name)
+			if !opts.DryRun {
+				if err := s.applyObject(ctx, ts, "TenantSchedule"); err != nil {
+					return report, fmt.Errorf("failed to import %s: %w", object, err)
+				}
+			}
+			report.Applied = append(report.Applied, object)
+		}
+	}
+	s.logger.Info("bundle imported", "applied", len(report.Applied), "skipped", len(report.Skipped), "dryRun", opts.DryRun)
+	return report, nil
+}
+
+// remapNamespace returns the namespace of the target cluster of a namespace of tenant
+func (opts BundleImportOptions) remapNamespace(tenant, namespace string) string {
+	if mapped, ok := opts.NamespaceMapping[namespace]; ok {
+		return mapped
+	}
+	if mapped, ok := opts.TenantMapping[tenant]; ok {
+		if suffix, found := strings.CutPrefix(namespace, tenant+"-"); found {
+			return mapped + "-" + suffix
+		}
+	}
+	return namespace
+}
+
+// withoutLastApplied returns the annotations without the kubectl last applied configuration
+func withoutLastApplied(annotations map[string]string) map[string]string {
+	if _, ok := annotations["kubectl.kubernetes.io/last-applied-configuration"]; !ok {
+		return annotations
+	}
+	filtered := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if k != "kubectl.kubernetes.io/last-applied-configuration" {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
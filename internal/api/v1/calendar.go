@@ -0,0 +1,374 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	icalDateTime = "20060102T150405"
+	icalProdID   = "-//kube-green//Schedules//EN"
+)
+
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// calendarEvent is a weekly recurring VEVENT: the resources are off from Start for Duration
+// on every day of Weekdays (user timezone)
+type calendarEvent struct {
+	uid         string
+	summary     string
+	description string
+	start       time.Time
+	duration    time.Duration
+	weekdays    []time.Weekday
+}
+
+// GetScheduleCalendar renders the sleep windows of a tenant as an iCalendar (RFC 5545) feed in the user
+// timezone: one weekly recurring event per namespace, window and duration, spanning from the sleep to
+// the wake of the resources. Paused windows are left out.
+func (s *ScheduleService) GetScheduleCalendar(ctx context.Context, tenant, namespaceSuffix string, now time.Time) ([]byte, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", namespaceSuffix)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := map[string][]kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfos {
+		suffix := resolver.suffix(si.Namespace)
+		groups[suffix] = append(groups[suffix], si)
+	}
+	suffixes := make([]string, 0, len(groups))
+	for suffix := range groups {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+
+	userTZ := TZLocal
+	events := []calendarEvent{}
+	for _, suffix := range suffixes {
+		schedule := buildEffectiveSchedule(tenant, suffix, groups[suffix])
+		userTZ = schedule.UserTimezone
+		events = append(events, calendarEvents(schedule, now)...)
+	}
+	userLoc, err := time.LoadLocation(userTZ)
+	if err != nil {
+		userLoc = time.UTC
+		userTZ = TZUTC
+	}
+	return renderCalendar(tenant, userTZ, userLoc, events, now), nil
+}
+
+// calendarEvents turns the windows of a namespace into events. Windows of different classes sharing
+// the same sleep and wake become a single event listing every class.
+func calendarEvents(schedule *EffectiveScheduleResponse, now time.Time) []calendarEvent {
+	type windowKey struct{ sleepInfo, sleepAt, wakeSleepInfo, wakeAt string }
+	keys := []windowKey{}
+	windows := map[windowKey]ScheduleWindow{}
+	classes := map[windowKey][]string{}
+	for _, class := range schedule.Classes {
+		for _, window := range class.Windows {
+			if window.Paused || window.SleepAt == "" {
+				continue
+			}
+			key := windowKey{window.SleepInfo, window.SleepAt, window.WakeSleepInfo, window.WakeAt}
+			if _, ok := windows[key]; !ok {
+				keys = append(keys, key)
+				windows[key] = window
+			}
+			classes[key] = append(classes[key], class.Class)
+		}
+	}
+
+	userLoc, err := time.LoadLocation(schedule.UserTimezone)
+	if err != nil {
+		userLoc = time.UTC
+	}
+	events := []calendarEvent{}
+	for _, key := range keys {
+		window := windows[key]
+		summary := fmt.Sprintf("%s asleep", schedule.Namespace)
+		if window.ScheduleName != "" {
+			summary = fmt.Sprintf("%s asleep (%s)", schedule.Namespace, window.ScheduleName)
+		}
+		description := fmt.Sprintf("Resources: %s\nSleepInfo: %s", strings.Join(classes[key], ", "), window.SleepInfo)
+		if window.WakeSleepInfo != "" && window.WakeSleepInfo != window.SleepInfo {
+			description += fmt.Sprintf("\nWake SleepInfo: %s", window.WakeSleepInfo)
+		}
+		if window.WakeAt == "" {
+			description += "\nNo scheduled wake: resources stay asleep until woken manually"
+		}
+		if window.SuspendedUntil != nil && window.SuspendedUntil.After(now) {
+			description += fmt.Sprintf("\nSuspended until %s", window.SuspendedUntil.In(userLoc).Format(time.RFC3339))
+		}
+
+		for _, occurrence := range windowOccurrences(window, now, userLoc) {
+			occurrence.uid = calendarUID(schedule.Namespace, key.sleepInfo, key.sleepAt, key.wakeSleepInfo, key.wakeAt, occurrence.duration.String())
+			occurrence.summary = summary
+			occurrence.description = description
+			events = append(events, occurrence)
+		}
+	}
+	return events
+}
+
+// windowOccurrences computes the next sleep of every weekday of the window and groups the weekdays
+// whose sleep lasts the same (e.g. Friday night until Monday vs. a single night).
+func windowOccurrences(window ScheduleWindow, now time.Time, userLoc *time.Location) []calendarEvent {
+	sleepSched, err := windowCron(window.SleepAt, window.SleepDays, window.TimeZone)
+	if err != nil {
+		return nil
+	}
+	var wakeSched cron.Schedule
+	if window.WakeAt != "" {
+		if wakeSched, err = windowCron(window.WakeAt, window.WakeDays, window.TimeZone); err != nil {
+			return nil
+		}
+	}
+
+	type occurrenceKey struct {
+		duration time.Duration
+		hhmm     string
+	}
+	keys := []occurrenceKey{}
+	grouped := map[occurrenceKey]*calendarEvent{}
+	next := now
+	for i := 0; i < len(window.SleepDays); i++ {
+		next = sleepSched.Next(next)
+		if next.IsZero() {
+			break
+		}
+		var duration time.Duration
+		if wakeSched != nil {
+			duration = wakeSched.Next(next).Sub(next)
+		}
+		start := next.In(userLoc)
+		key := occurrenceKey{duration: duration, hhmm: start.Format("15:04")}
+		if event, ok := grouped[key]; ok {
+			event.weekdays = append(event.weekdays, start.Weekday())
+			continue
+		}
+		keys = append(keys, key)
+		grouped[key] = &calendarEvent{start: start, duration: duration, weekdays: []time.Weekday{start.Weekday()}}
+	}
+
+	events := make([]calendarEvent, 0, len(keys))
+	for _, key := range keys {
+		event := grouped[key]
+		sort.Slice(event.weekdays, func(i, j int) bool { return event.weekdays[i] < event.weekdays[j] })
+		events = append(events, *event)
+	}
+	return events
+}
+
+func windowCron(hhmm string, days []int, timeZone string) (cron.Schedule, error) {
+	parts := strings.Split(hhmm, ":")
+	if len(parts) != 2 || len(days) == 0 {
+		return nil, fmt.Errorf("invalid time %q", hhmm)
+	}
+	dayList := make([]string, 0, len(days))
+	for _, day := range days {
+		dayList = append(dayList, fmt.Sprintf("%d", day))
+	}
+	if timeZone == "" {
+		timeZone = TZUTC
+	}
+	return cron.ParseStandard(fmt.Sprintf("CRON_TZ=%s %s %s * * %s", timeZone, parts[1], parts[0], strings.Join(dayList, ",")))
+}
+
+// calendarUID is stable across requests so calendar clients update events instead of duplicating them
+func calendarUID(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(sum[:12]) + "@kube-green"
+}
+
+func renderCalendar(tenant, userTZ string, userLoc *time.Location, events []calendarEvent, now time.Time) []byte {
+	var b strings.Builder
+	line := func(content string) {
+		b.WriteString(foldICalLine(content))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + icalProdID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escapeICalText(fmt.Sprintf("kube-green %s", tenant)))
+	line("X-WR-TIMEZONE:" + userTZ)
+	for _, tzLine := range vtimezone(userTZ, userLoc, now) {
+		line(tzLine)
+	}
+
+	stamp := now.UTC().Format(icalDateTime) + "Z"
+	for _, event := range events {
+		days := make([]string, 0, len(event.weekdays))
+		for _, day := range event.weekdays {
+			days = append(days, icalWeekdays[day])
+		}
+		line("BEGIN:VEVENT")
+		line("UID:" + event.uid)
+		line("DTSTAMP:" + stamp)
+		line(fmt.Sprintf("DTSTART;TZID=%s:%s", userTZ, event.start.Format(icalDateTime)))
+		line("DURATION:" + icalDuration(event.duration))
+		line("RRULE:FREQ=WEEKLY;BYDAY=" + strings.Join(days, ","))
+		line("SUMMARY:" + escapeICalText(event.summary))
+		line("DESCRIPTION:" + escapeICalText(event.description))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String())
+}
+
+// vtimezone describes the UTC offsets of loc during the year of now. Zones with daylight saving time get
+// STANDARD and DAYLIGHT rules recurring on the same weekday of the month as this year's transitions.
+func vtimezone(tzid string, loc *time.Location, now time.Time) []string {
+	year := now.In(loc).Year()
+	_, janOffset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	_, julOffset := time.Date(year, time.July, 1, 0, 0, 0, 0, loc).Zone()
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + tzid}
+	if janOffset == julOffset {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		lines = append(lines,
+			"BEGIN:STANDARD",
+			"DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+icalOffset(offset),
+			"TZOFFSETTO:"+icalOffset(offset),
+			"TZNAME:"+name,
+			"END:STANDARD",
+		)
+		return append(lines, "END:VTIMEZONE")
+	}
+
+	for _, transition := range zoneTransitions(loc, year) {
+		component := "STANDARD"
+		if transition.to > transition.from {
+			component = "DAYLIGHT"
+		}
+		local := transition.at.Add(time.Duration(transition.from) * time.Second).UTC()
+		lines = append(lines,
+			"BEGIN:"+component,
+			"DTSTART:"+local.Format(icalDateTime),
+			fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%s", int(local.Month()), weekdayOrdinal(local)),
+			"TZOFFSETFROM:"+icalOffset(transition.from),
+			"TZOFFSETTO:"+icalOffset(transition.to),
+			"TZNAME:"+transition.name,
+			"END:"+component,
+		)
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+type zoneTransition struct {
+	at       time.Time
+	from, to int
+	name     string
+}
+
+// zoneTransitions finds the offset changes of loc during year, with minute precision
+func zoneTransitions(loc *time.Location, year int) []zoneTransition {
+	transitions := []zoneTransition{}
+	current := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	_, offset := current.Zone()
+	for day := current; day.Before(end); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		if _, nextOffset := next.Zone(); nextOffset == offset {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Minute {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, midOffset := mid.Zone(); midOffset == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		at := hi.Truncate(time.Minute)
+		name, newOffset := at.Zone()
+		transitions = append(transitions, zoneTransition{at: at, from: offset, to: newOffset, name: name})
+		offset = newOffset
+	}
+	return transitions
+}
+
+// weekdayOrdinal returns the BYDAY value of t within its month: 2SU for the second Sunday, -1SU for the last one
+func weekdayOrdinal(t time.Time) string {
+	day := icalWeekdays[t.Weekday()]
+	if t.AddDate(0, 0, 7).Month() != t.Month() {
+		return "-1" + day
+	}
+	return fmt.Sprintf("%d%s", (t.Day()-1)/7+1, day)
+}
+
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func icalDuration(d time.Duration) string {
+	if d <= 0 {
+		return "PT0M"
+	}
+	minutes := int(d.Round(time.Minute).Minutes())
+	days, hours, mins := minutes/(24*60), (minutes%(24*60))/60, minutes%60
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if hours > 0 || mins > 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if mins > 0 {
+			fmt.Fprintf(&b, "%dM", mins)
+		}
+	}
+	return b.String()
+}
+
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits lines longer than 75 octets, continuation lines start with a space
+func foldICalLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
@@ -0,0 +1,395 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/notifications"
+)
+
+const (
+	CloneItemCreated = "created"
+	CloneItemFailed  = "failed"
+)
+
+// CloneScheduleRequest copies the schedules of a tenant to other tenants
+type CloneScheduleRequest struct {
+	Namespace    string        `json:"namespace,omitempty" example:"apps"`               // Optional source namespace suffix
+	ScheduleName string        `json:"scheduleName,omitempty" example:"horario-laboral"` // Optional source schedule name
+	Targets      []CloneTarget `json:"targets" binding:"required,min=1,dive"`
+}
+
+// CloneTarget is a tenant receiving the cloned schedules. Without Namespaces, every source namespace
+// is cloned into the namespace with the same suffix. With a single source namespace, Namespaces lists
+// the suffixes receiving it; otherwise it selects which source namespaces are cloned.
+type CloneTarget struct {
+	Tenant     string   `json:"tenant" binding:"required" example:"bdadevprd"`
+	Namespaces []string `json:"namespaces,omitempty" example:"apps,rocket"`
+}
+
+// CloneItemResult is the result of cloning one schedule into one namespace
+type CloneItemResult struct {
+	Tenant          string            `json:"tenant"`
+	Namespace       string            `json:"namespace"`       // Full target namespace name
+	SourceNamespace string            `json:"sourceNamespace"` // Full source namespace name
+	ScheduleName    string            `json:"scheduleName,omitempty"`
+	Off             string            `json:"off,omitempty"` // User timezone
+	On              string            `json:"on,omitempty"`  // User timezone
+	Delays          *DelayConfig      `json:"delays,omitempty"`
+	Exclusions      []ExclusionFilter `json:"exclusions,omitempty"` // Custom exclusions, re-resolved for the target
+	Inclusions      []ExclusionFilter `json:"inclusions,omitempty"` // Inclusions, re-resolved for the target
+	Status          string            `json:"status"`               // created or failed
+	Error           string            `json:"error,omitempty"`
+}
+
+// CloneScheduleResponse is the result of a clone
+type CloneScheduleResponse struct {
+	SourceTenant string            `json:"sourceTenant"`
+	Created      int               `json:"created"`
+	Failed       int               `json:"failed"`
+	Results      []CloneItemResult `json:"results"`
+}
+
+// clonedSchedule is a schedule of the source tenant rebuilt as a namespace schedule request
+type clonedSchedule struct {
+	suffix        string
+	request       NamespaceScheduleRequest
+	exclusions    []ExclusionFilter
+	inclusions    []ExclusionFilter
+	sleepReplicas []SleepReplicasConfig
+}
+
+// CloneSchedule copies the schedules of sourceTenant (times, weekdays, staggered wake delays, holiday calendar,
+// replicas kept during sleep, inclusions and custom exclusions) to the target tenants and namespaces. Exclusions detected automatically
+// in the source namespace are dropped, as the target detects its own; label values naming the source
+// namespace or tenant are rewritten for the target. Every target is created independently and reported.
+func (s *ScheduleService) CloneSchedule(ctx context.Context, sourceTenant string, req CloneScheduleRequest) (*CloneScheduleResponse, error) {
+	if len(req.Targets) == 0 {
+		return nil, fmt.Errorf("at least one target is required")
+	}
+	sleepInfos, err := s.listTenantSleepInfos(ctx, sourceTenant, req.ScheduleName, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.cloneSources(ctx, sourceTenant, sleepInfos)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CloneScheduleResponse{SourceTenant: sourceTenant, Results: []CloneItemResult{}}
+	for _, target := range req.Targets {
+		for _, item := range s.cloneToTarget(ctx, sourceTenant, target, schedules) {
+			if item.Status == CloneItemCreated {
+				response.Created++
+			} else {
+				response.Failed++
+			}
+			response.Results = append(response.Results, item)
+		}
+	}
+	return response, nil
+}
+
+// cloneSources groups the SleepInfos by namespace and schedule name and rebuilds the request of each group
+func (s *ScheduleService) cloneSources(ctx context.Context, tenant string, sleepInfos []kubegreenv1alpha1.SleepInfo) ([]clonedSchedule, error) {
+	type groupKey struct{ suffix, scheduleName string }
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups := map[groupKey][]kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfos {
+		key := groupKey{resolver.suffix(si.Namespace), si.Annotations["kube-green.stratio.com/schedule-name"]}
+		groups[key] = append(groups[key], si)
+	}
+
+	schedules := make([]clonedSchedule, 0, len(groups))
+	for key, group := range groups {
+		resources, err := s.GetNamespaceResources(ctx, tenant, key.suffix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect resources of %s-%s: %w", tenant, key.suffix, err)
+		}
+		// Exclusions of the source namespace policy are added again by the policy of the target suffix
+		automatic := append(resources.AutoExclusions, s.namespacePolicy(ctx, key.suffix).DefaultExclusions...)
+		schedule, err := cloneSource(tenant, key.suffix, key.scheduleName, group, automatic)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].suffix != schedules[j].suffix {
+			return schedules[i].suffix < schedules[j].suffix
+		}
+		return schedules[i].request.ScheduleName < schedules[j].request.ScheduleName
+	})
+	return schedules, nil
+}
+
+// cloneSource rebuilds the user-facing request of the SleepInfos of one schedule of a namespace
+func cloneSource(tenant, suffix, scheduleName string, group []kubegreenv1alpha1.SleepInfo, autoExclusions []ExclusionFilter) (clonedSchedule, error) {
+	effective := buildEffectiveSchedule(tenant, suffix, group)
+
+	// Sleep side: every class sleeps at the same time, the wake may be staggered per class
+	var sleepWindow *ScheduleWindow
+	wakeAt := map[string]ScheduleWindow{}
+	for _, class := range effective.Classes {
+		for i := range class.Windows {
+			window := class.Windows[i]
+			if sleepWindow == nil && window.UserSleepAt != "" {
+				sleepWindow = &window
+			}
+			if _, ok := wakeAt[class.Class]; !ok && window.UserWakeAt != "" {
+				wakeAt[class.Class] = window
+			}
+		}
+	}
+	if sleepWindow == nil || len(wakeAt) == 0 {
+		return clonedSchedule{}, fmt.Errorf("schedule %q of %s-%s has no sleep and wake times to clone", scheduleName, tenant, suffix)
+	}
+
+	// The earliest wake is the base time of the delays
+	base := ScheduleWindow{}
+	for _, window := range wakeAt {
+		if base.UserWakeAt == "" || window.UserWakeAt < base.UserWakeAt {
+			base = window
+		}
+	}
+	delayOf := func(classes ...string) string {
+		for _, class := range classes {
+			if window, ok := wakeAt[class]; ok {
+				return formatMinutesToDelay(calculateTimeDifferenceMinutes(base.UserWakeAt, window.UserWakeAt))
+			}
+		}
+		return "0m"
+	}
+	// Explicit delays keep the source wake times: without them, creation applies the default stagger
+	delays := &DelayConfig{
+		PgHdfsDelay:      delayOf(ClassPostgres, ClassHDFS),
+		PgbouncerDelay:   delayOf(ClassPgBouncer),
+		DeploymentsDelay: delayOf(ClassDeployments, ClassStatefulSets),
+	}
+
+	description := ""
+	var holidays *HolidayConfig
+	var sleepReplicas []SleepReplicasConfig
+	for _, si := range group {
+		if d := si.Annotations["kube-green.stratio.com/schedule-description"]; d != "" && description == "" {
+			description = d
+		}
+		if holidays == nil {
+			holidays = holidaysOf(si)
+		}
+		if sleepReplicas == nil {
+			sleepReplicas = sleepReplicasOf(si)
+		}
+	}
+
+	return clonedSchedule{
+		suffix: suffix,
+		request: NamespaceScheduleRequest{
+			Off:           sleepWindow.UserSleepAt,
+			On:            base.UserWakeAt,
+			WeekdaysSleep: joinWeekdays(sleepWindow.UserSleepDays),
+			WeekdaysWake:  joinWeekdays(base.UserWakeDays),
+			ScheduleName:  scheduleName,
+			Description:   description,
+			Delays:        delays,
+			Holidays:      holidays,
+		},
+		exclusions:    customExclusions(group, autoExclusions),
+		inclusions:    inclusions(group),
+		sleepReplicas: sleepReplicas,
+	}, nil
+}
+
+// customExclusions returns the exclusions of the SleepInfos that were not added automatically
+func customExclusions(group []kubegreenv1alpha1.SleepInfo, autoExclusions []ExclusionFilter) []ExclusionFilter {
+	automatic := map[string]bool{}
+	for _, excl := range autoExclusions {
+		automatic[excl.key()] = true
+	}
+	for _, ref := range getExcludeRefsForOperators() {
+		automatic[exclusionFromFilterRef(ref).key()] = true
+	}
+
+	seen := map[string]bool{}
+	exclusions := []ExclusionFilter{}
+	for _, si := range group {
+		for _, ref := range si.Spec.ExcludeRef {
+			filter := exclusionFromFilterRef(ref)
+			key := filter.key()
+			if filter.isEmpty() || automatic[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			exclusions = append(exclusions, filter)
+		}
+	}
+	return exclusions
+}
+
+// cloneToTarget creates the cloned schedules in the namespaces of target
+func (s *ScheduleService) cloneToTarget(ctx context.Context, sourceTenant string, target CloneTarget, schedules []clonedSchedule) []CloneItemResult {
+	sourceSuffixes := map[string]bool{}
+	for _, schedule := range schedules {
+		sourceSuffixes[schedule.suffix] = true
+	}
+	targetSuffixes := normalizeNamespaces(target.Namespaces)
+
+	results := []CloneItemResult{}
+	for _, schedule := range schedules {
+		destinations := []string{schedule.suffix}
+		switch {
+		case len(targetSuffixes) == 0:
+		case len(sourceSuffixes) == 1:
+			destinations = sortedKeys(targetSuffixes)
+		case !targetSuffixes[schedule.suffix]:
+			continue
+		}
+
+		for _, suffix := range destinations {
+			sourceNamespace := fmt.Sprintf("%s-%s", sourceTenant, schedule.suffix)
+			targetNamespace := fmt.Sprintf("%s-%s", target.Tenant, suffix)
+			req := schedule.request
+			req.Tenant = target.Tenant
+			req.Namespace = suffix
+			req.Exclusions = []NamespaceExclusion{}
+			exclusions := make([]ExclusionFilter, 0, len(schedule.exclusions))
+			for _, excl := range schedule.exclusions {
+				filter := retargetExclusion(excl, sourceTenant, sourceNamespace, target.Tenant, targetNamespace)
+				exclusions = append(exclusions, filter)
+				req.Exclusions = append(req.Exclusions, NamespaceExclusion{Namespace: suffix, Filter: filter})
+			}
+			req.Inclusions = []NamespaceInclusion{}
+			inclusions := make([]ExclusionFilter, 0, len(schedule.inclusions))
+			for _, incl := range schedule.inclusions {
+				filter := retargetExclusion(incl, sourceTenant, sourceNamespace, target.Tenant, targetNamespace)
+				inclusions = append(inclusions, filter)
+				req.Inclusions = append(req.Inclusions, NamespaceInclusion{Namespace: suffix, Filter: filter})
+			}
+			req.SleepReplicas = []NamespaceSleepReplicas{}
+			for _, entry := range schedule.sleepReplicas {
+				filter := retargetExclusion(entry.Filter, sourceTenant, sourceNamespace, target.Tenant, targetNamespace)
+				req.SleepReplicas = append(req.SleepReplicas, NamespaceSleepReplicas{Namespace: suffix, Filter: filter, Replicas: entry.Replicas})
+			}
+
+			delays := *req.Delays
+			item := CloneItemResult{
+				Tenant:          target.Tenant,
+				Namespace:       targetNamespace,
+				SourceNamespace: sourceNamespace,
+				ScheduleName:    req.ScheduleName,
+				Off:             req.Off,
+				On:              req.On,
+				Delays:          &delays,
+				Exclusions:      exclusions,
+				Inclusions:      inclusions,
+				Status:          CloneItemCreated,
+			}
+			if err := s.CreateNamespaceSchedule(ctx, req); err != nil {
+				item.Status = CloneItemFailed
+				item.Error = err.Error()
+			} else {
+				s.logger.Info("Schedule cloned", "source", sourceNamespace, "target", targetNamespace, "scheduleName", req.ScheduleName)
+				s.notifyScheduleEvent(notifications.EventScheduleCreated, target.Tenant, suffix, req.ScheduleName, map[string]string{
+					"off":    req.Off,
+					"on":     req.On,
+					"source": sourceNamespace,
+				})
+			}
+			results = append(results, item)
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, CloneItemResult{
+			Tenant: target.Tenant,
+			Status: CloneItemFailed,
+			Error:  fmt.Sprintf("none of the namespaces %s has a schedule in tenant %s", strings.Join(target.Namespaces, ","), sourceTenant),
+		})
+	}
+	return results
+}
+
+// inclusions returns the includeRef of the SleepInfos
+func inclusions(group []kubegreenv1alpha1.SleepInfo) []ExclusionFilter {
+	seen := map[string]bool{}
+	filters := []ExclusionFilter{}
+	for _, si := range group {
+		for _, ref := range si.Spec.IncludeRef {
+			filter := exclusionFromFilterRef(ref)
+			if key := filter.key(); !filter.isEmpty() && !seen[key] {
+				seen[key] = true
+				filters = append(filters, filter)
+			}
+		}
+	}
+	return filters
+}
+
+// retargetExclusion rewrites label values naming the source namespace or tenant for the target
+func retargetExclusion(excl ExclusionFilter, sourceTenant, sourceNamespace, targetTenant, targetNamespace string) ExclusionFilter {
+	retarget := func(value string) string {
+		switch {
+		case strings.Contains(value, sourceNamespace):
+			return strings.ReplaceAll(value, sourceNamespace, targetNamespace)
+		case value == sourceTenant:
+			return targetTenant
+		case strings.HasPrefix(value, sourceTenant+"-"):
+			return targetTenant + strings.TrimPrefix(value, sourceTenant)
+		}
+		return value
+	}
+
+	retargeted := ExclusionFilter{APIVersion: excl.APIVersion, Kind: excl.Kind, Name: excl.Name}
+	if excl.MatchLabels != nil {
+		retargeted.MatchLabels = make(map[string]string, len(excl.MatchLabels))
+		for key, value := range excl.MatchLabels {
+			retargeted.MatchLabels[key] = retarget(value)
+		}
+	}
+	for _, expression := range excl.MatchExpressions {
+		expression = *expression.DeepCopy()
+		for i := range expression.Values {
+			expression.Values[i] = retarget(expression.Values[i])
+		}
+		retargeted.MatchExpressions = append(retargeted.MatchExpressions, expression)
+	}
+	return retargeted
+}
+
+// labelsKey returns a stable representation of a label map
+func labelsKey(matchLabels map[string]string) string {
+	pairs := make([]string, 0, len(matchLabels))
+	for key, value := range matchLabels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// joinWeekdays formats days as a numeric weekdays expression, e.g. "1,2,3,4,5"
+func joinWeekdays(days []int) string {
+	values := make([]string, 0, len(days))
+	for _, day := range days {
+		values = append(values, strconv.Itoa(day))
+	}
+	return strings.Join(values, ",")
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	defaultCORSHeaders = []string{
+		"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization",
+		"accept", "origin", "Cache-Control", "X-Requested-With", RequestIDHeader,
+	}
+	defaultCORSMethods = []string{"POST", "OPTIONS", "GET", "PUT", "DELETE"}
+	corsExposedHeaders = []string{"X-Total-Count", "X-Continue-Token", RequestIDHeader}
+)
+
+// CORSConfig configures the CORS headers of the API server.
+// Empty lists keep the previous behaviour: any origin and the default headers and methods.
+type CORSConfig struct {
+	AllowedOrigins   []string // Exact origins (https://app.example.com), "*.example.com" wildcards or "*"
+	AllowedHeaders   []string
+	AllowedMethods   []string
+	AllowCredentials bool
+	MaxAge           int // Seconds browsers may cache a preflight response, 0 omits the header
+}
+
+// ParseCSV splits a comma separated flag value, dropping empty items
+func ParseCSV(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// allowsAnyOrigin reports whether the allow-list is empty or contains "*"
+func (c CORSConfig) allowsAnyOrigin() bool {
+	if len(c.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// originAllowed matches origin against the allow-list. A "*." entry matches any subdomain,
+// keeping the scheme when given (https://*.example.com).
+func (c CORSConfig) originAllowed(origin string) bool {
+	for _, allowed := range c.AllowedOrigins {
+		if strings.EqualFold(allowed, origin) {
+			return true
+		}
+		if i := strings.Index(allowed, "*."); i >= 0 {
+			prefix, suffix := strings.ToLower(allowed[:i]), strings.ToLower(allowed[i+1:])
+			lower := strings.ToLower(origin)
+			if strings.HasPrefix(lower, prefix) && strings.HasSuffix(lower, suffix) &&
+				len(lower) > len(prefix)+len(suffix) && !strings.Contains(lower[len(prefix):len(lower)-len(suffix)], "/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// corsMiddleware adds CORS headers
+func corsMiddleware(config CORSConfig) gin.HandlerFunc {
+	headers := config.AllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	methods := config.AllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	allowHeaders := strings.Join(headers, ", ")
+	allowMethods := strings.Join(methods, ", ")
+	exposeHeaders := strings.Join(corsExposedHeaders, ", ")
+	anyOrigin := config.allowsAnyOrigin()
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		allowed := true
+		if anyOrigin {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			// The response depends on the Origin header, caches must not share it between origins
+			c.Writer.Header().Add("Vary", "Origin")
+			allowed = origin != "" && config.originAllowed(origin)
+			if allowed {
+				c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		}
+
+		if allowed {
+			if config.AllowCredentials {
+				c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			c.Writer.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+			c.Writer.Header().Set("Access-Control-Allow-Methods", allowMethods)
+			c.Writer.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+			if config.MaxAge > 0 {
+				c.Writer.Header().Set("Access-Control-Max-Age", strconv.Itoa(config.MaxAge))
+			}
+		}
+
+		if c.Request.Method == "OPTIONS" {
+			if !allowed && origin != "" {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// validateCronSchedule validates a schedule set by cron expressions instead of off and on times
+func validateCronSchedule(req CreateScheduleRequest) error {
+	if req.OffCron == "" {
+		if req.OnCron != "" {
+			return fmt.Errorf("onCron requires offCron")
+		}
+		return nil
+	}
+	if req.Off != "" || req.On != "" || req.DurationHours != 0 {
+		return fmt.Errorf("offCron and onCron are mutually exclusive with off, on and durationHours")
+	}
+	if req.Weekdays != "" || req.SleepDays != "" || req.WakeDays != "" {
+		return fmt.Errorf("offCron and onCron are mutually exclusive with weekdays, sleepDays and wakeDays")
+	}
+	for field, expr := range map[string]string{"offCron": req.OffCron, "onCron": req.OnCron} {
+		if expr == "" {
+			continue
+		}
+		if !kubegreenv1alpha1.IsCronExpression(expr) {
+			return fmt.Errorf("%s must be a cron expression with 5 fields (minute hour day-of-month month day-of-week), got: %s", field, expr)
+		}
+		if _, err := kubegreenv1alpha1.ParseSchedule(expr); err != nil {
+			return fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// createCronSchedule creates a schedule set by cron expressions: one SleepInfo per namespace, sleeping
+// at offCron and waking up at onCron in the user timezone. The wake up is not staggered.
+func (s *ScheduleService) createCronSchedule(ctx context.Context, req CreateScheduleRequest, skipScheduleNameValidation bool) error {
+	if err := validateCronSchedule(req); err != nil {
+		return err
+	}
+	if err := validateExclusions(req.Exclusions); err != nil {
+		return err
+	}
+	if err := validateInclusions(req.Tenant, req.Inclusions); err != nil {
+		return err
+	}
+	if err := req.Holidays.validate(); err != nil {
+		return err
+	}
+	if err := validateSleepReplicas(req.SleepReplicas); err != nil {
+		return err
+	}
+
+	selectedNamespaces := normalizeNamespaces(req.Namespaces)
+	if req.ScheduleName != "" && !skipScheduleNameValidation {
+		for suffix := range selectedNamespaces {
+			if err := s.validateScheduleNameUniqueness(ctx, fmt.Sprintf("%s-%s", req.Tenant, suffix), req.ScheduleName); err != nil {
+				return err
+			}
+		}
+	}
+
+	userTZ := TZLocal
+	err := forEachNamespace(selectedNamespaces, func(suffix string) error {
+		namespace := fmt.Sprintf("%s-%s", req.Tenant, suffix)
+		resources, err := s.GetNamespaceResources(ctx, req.Tenant, suffix)
+		if err != nil {
+			s.logger.Error(err, "failed to detect resources in namespace", "namespace", namespace)
+			resources = &NamespaceResourceInfo{Namespace: namespace}
+		}
+		excludeRefs := append(getExcludeRefsForOperators(), exclusionRefsFor(req.Tenant, suffix, req.Exclusions)...)
+		for _, autoExcl := range resources.AutoExclusions {
+			excludeRefs = append(excludeRefs, autoExcl.toFilterRef())
+		}
+		policy := s.namespacePolicy(ctx, suffix)
+		excludeRefs = append(excludeRefs, policy.excludeRefs()...)
+
+		name := fmt.Sprintf("%s-%s", req.Tenant, suffix)
+		annotations := map[string]string{"kube-green.stratio.com/user-timezone": userTZ}
+		if req.ScheduleName != "" {
+			name = req.ScheduleName
+			annotations["kube-green.stratio.com/schedule-name"] = req.ScheduleName
+		}
+		if req.Description != "" {
+			annotations["kube-green.stratio.com/schedule-description"] = req.Description
+		}
+		suspendDeployments := true
+		suspendStatefulSets := policy.suspendStatefulSets(resources.ResourceCounts.StatefulSets > 0 || resources.HasPgCluster)
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Annotations: annotations,
+			},
+			Spec: kubegreenv1alpha1.SleepInfoSpec{
+				SleepTime:                       req.OffCron,
+				WakeUpTime:                      req.OnCron,
+				TimeZone:                        userTZ,
+				SuspendDeployments:              &suspendDeployments,
+				SuspendStatefulSets:             &suspendStatefulSets,
+				SuspendCronjobs:                 true,
+				SuspendDeploymentsPgbouncer:     datastoreSuspension(resources.HasPgBouncer),
+				SuspendStatefulSetsPostgres:     datastoreSuspension(resources.HasPgCluster),
+				SuspendStatefulSetsHdfs:         datastoreSuspension(resources.HasHdfsCluster),
+				SuspendStatefulSetsOpenSearch:   datastoreSuspension(resources.HasOsCluster),
+				SuspendStatefulSetsOsDashboards: datastoreSuspension(resources.HasOsDashboards),
+				SuspendStatefulSetsKafka:        datastoreSuspension(resources.HasKafkaCluster),
+				ExcludeRef:                      excludeRefs,
+				IncludeRef:                      inclusionRefsFor(req.Tenant, suffix, req.Inclusions),
+				SleepReplicas:                   sleepReplicasFor(req.Tenant, suffix, req.SleepReplicas),
+			},
+		}
+		req.Holidays.apply(&sleepInfo.Spec, userTZ)
+
+		s.logger.Info("createCronSchedule: creating SleepInfo", "name", name, "namespace", namespace, "offCron", req.OffCron, "onCron", req.OnCron)
+		if err := s.createOrUpdateSleepInfo(ctx, sleepInfo, userTZ); err != nil {
+			return fmt.Errorf("failed to create sleepinfo for %s: %w", namespace, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.ExecuteOnce {
+		if err := s.setExecuteOnce(ctx, req.Tenant, selectedNamespaces, req.ScheduleName); err != nil {
+			return err
+		}
+	}
+	if len(req.KarpenterNodePools) > 0 {
+		if err := s.setKarpenterNodePools(ctx, req.Tenant, selectedNamespaces, req.ScheduleName, req.KarpenterNodePools); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// datastoreSuspension returns the suspension of a datastore kind, unset when it is not in the namespace
+func datastoreSuspension(detected bool) *bool {
+	if !detected {
+		return nil
+	}
+	return &detected
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// Annotations recording the schedule the API applied to a SleepInfo, compared with its spec to detect
+// manual edits
+const (
+	appliedSleepTimeAnnotation  = "kube-green.stratio.com/applied-sleep-time"
+	appliedWakeUpTimeAnnotation = "kube-green.stratio.com/applied-wake-up-time"
+	appliedWeekdaysAnnotation   = "kube-green.stratio.com/applied-weekdays"
+)
+
+// driftCheckInterval is how often the SleepInfos are checked for drift in the background
+const driftCheckInterval = 5 * time.Minute
+
+// DriftField is a field of the SleepInfo spec changed since the API applied it
+type DriftField struct {
+	Field    string `json:"field" example:"sleepTime"` // sleepTime, wakeUpTime, weekdays or timeZone
+	Expected string `json:"expected" example:"22:00"`  // Value applied by the API
+	Actual   string `json:"actual" example:"23:30"`    // Value of the spec
+}
+
+// DriftedSleepInfo is a SleepInfo whose spec no longer matches the schedule applied by the API
+type DriftedSleepInfo struct {
+	Tenant       string       `json:"tenant"`
+	Namespace    string       `json:"namespace"`
+	Name         string       `json:"name"`
+	ScheduleName string       `json:"scheduleName,omitempty"`
+	Drift        []DriftField `json:"drift"`
+}
+
+// DriftReport lists the drifted SleepInfos found by the last check
+type DriftReport struct {
+	CheckedAt  time.Time          `json:"checkedAt"`
+	SleepInfos []DriftedSleepInfo `json:"sleepInfos"`
+}
+
+// recordAppliedSchedule sets the annotations recording the times and weekdays of the spec, as applied by
+// the API
+func recordAppliedSchedule(si *kubegreenv1alpha1.SleepInfo) {
+	if si.Annotations == nil {
+		si.Annotations = map[string]string{}
+	}
+	si.Annotations[appliedSleepTimeAnnotation] = si.Spec.SleepTime
+	si.Annotations[appliedWakeUpTimeAnnotation] = si.Spec.WakeUpTime
+	si.Annotations[appliedWeekdaysAnnotation] = si.Spec.Weekdays
+}
+
+// sleepInfoDrift returns the fields of the spec changed since the API applied the SleepInfo: the times and
+// weekdays of its applied annotations and the timezone of its user timezone. SleepInfos not applied by the
+// API, or by a version not recording the annotations, never drift.
+func sleepInfoDrift(si kubegreenv1alpha1.SleepInfo) []DriftField {
+	sleepTime, ok := si.Annotations[appliedSleepTimeAnnotation]
+	if !ok {
+		return nil
+	}
+	drift := []DriftField{}
+	if !sameClock(sleepTime, si.Spec.SleepTime) {
+		drift = append(drift, DriftField{Field: "sleepTime", Expected: sleepTime, Actual: si.Spec.SleepTime})
+	}
+	if wakeUpTime := si.Annotations[appliedWakeUpTimeAnnotation]; !sameClock(wakeUpTime, si.Spec.WakeUpTime) {
+		drift = append(drift, DriftField{Field: "wakeUpTime", Expected: wakeUpTime, Actual: si.Spec.WakeUpTime})
+	}
+	if weekdays := si.Annotations[appliedWeekdaysAnnotation]; weekdays != si.Spec.Weekdays && !sameWeekdays(weekdays, si.Spec.Weekdays) {
+		drift = append(drift, DriftField{Field: "weekdays", Expected: weekdays, Actual: si.Spec.Weekdays})
+	}
+	if userTimezone := si.Annotations["kube-green.stratio.com/user-timezone"]; userTimezone != "" && si.Spec.TimeZone != userTimezone {
+		drift = append(drift, DriftField{Field: "timeZone", Expected: userTimezone, Actual: si.Spec.TimeZone})
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+	return drift
+}
+
+// sameClock reports whether two sleep or wake up times are the same, comparing HH:MM times by value
+func sameClock(a, b string) bool {
+	if a == b {
+		return true
+	}
+	aClock, aErr := parseClock(a)
+	bClock, bErr := parseClock(b)
+	return aErr == nil && bErr == nil && aClock == bClock
+}
+
+// CheckDrift returns the SleepInfos of every tenant, or of tenant when set, whose spec was changed since
+// the API applied it, e.g. by kubectl edit
+func (s *ScheduleService) CheckDrift(ctx context.Context, tenant string) (*DriftReport, error) {
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.client.List(ctx, sleepInfoList); err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{CheckedAt: time.Now().UTC(), SleepInfos: []DriftedSleepInfo{}}
+	for _, si := range sleepInfoList.Items {
+		sleepInfoTenant, _, ok := resolver.split(si.Namespace)
+		if !ok || (tenant != "" && sleepInfoTenant != tenant) {
+			continue
+		}
+		drift := sleepInfoDrift(si)
+		if drift == nil {
+			continue
+		}
+		report.SleepInfos = append(report.SleepInfos, DriftedSleepInfo{
+			Tenant:       sleepInfoTenant,
+			Namespace:    si.Namespace,
+			Name:         si.Name,
+			ScheduleName: si.Annotations["kube-green.stratio.com/schedule-name"],
+			Drift:        drift,
+		})
+	}
+	sort.Slice(report.SleepInfos, func(i, j int) bool {
+		a, b := report.SleepInfos[i], report.SleepInfos[j]
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name) < 0
+	})
+	return report, nil
+}
+
+// DriftedSleepInfos returns the drifted SleepInfos of the last background check, filtered by tenant when
+// set, or checks them now when the background check is not running
+func (s *ScheduleService) DriftedSleepInfos(ctx context.Context, tenant string) (*DriftReport, error) {
+	last := s.drift.Load()
+	if last == nil {
+		return s.CheckDrift(ctx, tenant)
+	}
+	if tenant == "" {
+		return last, nil
+	}
+	report := &DriftReport{CheckedAt: last.CheckedAt, SleepInfos: []DriftedSleepInfo{}}
+	for _, drifted := range last.SleepInfos {
+		if drifted.Tenant == tenant {
+			report.SleepInfos = append(report.SleepInfos, drifted)
+		}
+	}
+	return report, nil
+}
+
+// WatchDrift checks the SleepInfos for drift every driftCheckInterval until ctx is done, logging the
+// SleepInfos that drifted since the previous check
+func (s *ScheduleService) WatchDrift(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(driftCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			report, err := s.CheckDrift(ctx, "")
+			if err != nil {
+				s.logger.Error(err, "failed to check the SleepInfos for drift")
+				continue
+			}
+			previous := map[string]bool{}
+			if last := s.drift.Load(); last != nil {
+				for _, drifted := range last.SleepInfos {
+					previous[drifted.Namespace+"/"+drifted.Name] = true
+				}
+			}
+			for _, drifted := range report.SleepInfos {
+				if !previous[drifted.Namespace+"/"+drifted.Name] {
+					s.logger.Info("SleepInfo spec drifted from the applied schedule", "name", drifted.Name, "namespace", drifted.Namespace, "tenant", drifted.Tenant)
+				}
+			}
+			s.drift.Store(report)
+		}
+	}()
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxDurationHours is the longest sleep accepted as durationHours: a whole week
+const maxDurationHours = 7 * 24
+
+// validateDurationHours validates a sleep set by durationHours instead of an on time
+func validateDurationHours(on string, durationHours float64) error {
+	if durationHours == 0 {
+		return nil
+	}
+	if on != "" {
+		return fmt.Errorf("on and durationHours are mutually exclusive")
+	}
+	if durationHours < 0 || durationHours > maxDurationHours {
+		return fmt.Errorf("durationHours must be greater than 0 and at most %d, got: %v", maxDurationHours, durationHours)
+	}
+	if math.Round(durationHours*60) == 0 {
+		return fmt.Errorf("durationHours must be at least one minute, got: %v", durationHours)
+	}
+	return nil
+}
+
+// wakeAfterDuration returns the wake time in HH:MM format durationHours after the off time, and the
+// number of days the wake up falls after the sleep
+func wakeAfterDuration(off string, durationHours float64) (string, int, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(off, "%d:%d", &hour, &minute); err != nil {
+		return "", 0, fmt.Errorf("invalid time format: %s", off)
+	}
+	minutes := int(math.Round(durationHours * 60))
+	on, err := AddMinutes(off, minutes)
+	if err != nil {
+		return "", 0, err
+	}
+	return on, (hour*60 + minute + minutes) / (24 * 60), nil
+}
+
+// applyDurationHours sets the on time of a request with durationHours from its off time. When the
+// wake up falls on a later day and no wake days are set, the wake days follow the sleep days.
+func (req *CreateScheduleRequest) applyDurationHours() error {
+	if req.DurationHours == 0 {
+		return nil
+	}
+	on, dayShift, err := wakeAfterDuration(req.Off, req.DurationHours)
+	if err != nil {
+		return fmt.Errorf("invalid off time: %w", err)
+	}
+	req.On = on
+
+	sleepDays := req.SleepDays
+	if sleepDays == "" {
+		sleepDays = req.Weekdays
+	}
+	if req.WakeDays != "" || sleepDays == "" || dayShift == 0 {
+		return nil
+	}
+	wdSleep, err := HumanWeekdaysToKube(sleepDays)
+	if err != nil {
+		return fmt.Errorf("invalid sleepDays: %w", err)
+	}
+	if req.WakeDays, err = ShiftWeekdaysStr(wdSleep, dayShift); err != nil {
+		return fmt.Errorf("failed to shift wake weekdays: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// Resource classes of the effective schedule. Custom patches use the Kind of their target.
+const (
+	ClassDeployments          = "Deployments"
+	ClassStatefulSets         = "StatefulSets"
+	ClassCronJobs             = "CronJobs"
+	ClassPgBouncer            = "PgBouncer"
+	ClassPostgres             = "Postgres"
+	ClassHDFS                 = "HDFS"
+	ClassOpenSearch           = "OpenSearch"
+	ClassOpenSearchDashboards = "OpenSearchDashboards"
+	ClassKafka                = "Kafka"
+	ClassKnative              = "Knative"
+	ClassHPA                  = "HorizontalPodAutoscalers"
+	ClassKEDA                 = "KEDA"
+	ClassJobs                 = "Jobs"
+)
+
+// EffectiveScheduleResponse is the merged timeline of every SleepInfo of a namespace
+type EffectiveScheduleResponse struct {
+	Tenant       string                  `json:"tenant"`
+	Namespace    string                  `json:"namespace"`    // Full namespace name
+	UserTimezone string                  `json:"userTimezone"` // Timezone of the user* fields
+	SleepInfos   []string                `json:"sleepInfos"`   // SleepInfos merged into the timeline
+	Classes      []ResourceClassSchedule `json:"classes"`      // Sorted by class name
+}
+
+// ResourceClassSchedule is when a class of resources sleeps and wakes
+type ResourceClassSchedule struct {
+	Class   string           `json:"class"`   // Deployments, StatefulSets, CronJobs, Postgres, ... or the Kind of a custom patch
+	Windows []ScheduleWindow `json:"windows"` // Sorted by sleep time
+}
+
+// ScheduleWindow is a sleep followed by the wake that restores the same resources.
+// Times are HH:MM in the SleepInfo timezone, weekdays are expanded and sorted (0 = Sunday).
+type ScheduleWindow struct {
+	SleepAt        string      `json:"sleepAt,omitempty"` // Empty when the class is only woken up (e.g. by a wake stage without sleep)
+	SleepDays      []int       `json:"sleepDays,omitempty"`
+	WakeAt         string      `json:"wakeAt,omitempty"` // Empty when the class is never woken up by the schedule
+	WakeDays       []int       `json:"wakeDays,omitempty"`
+	TimeZone       string      `json:"timeZone"`
+	UserSleepAt    string      `json:"userSleepAt,omitempty"`
+	UserSleepDays  []int       `json:"userSleepDays,omitempty"`
+	UserWakeAt     string      `json:"userWakeAt,omitempty"`
+	UserWakeDays   []int       `json:"userWakeDays,omitempty"`
+	SleepInfo      string      `json:"sleepInfo,omitempty"`     // SleepInfo executing the sleep
+	WakeSleepInfo  string      `json:"wakeSleepInfo,omitempty"` // SleepInfo executing the wake, different for pairs
+	ScheduleName   string      `json:"scheduleName,omitempty"`
+	ExcludeRef     []FilterRef `json:"excludeRef,omitempty"` // Resources of the class left untouched
+	IncludeRef     []FilterRef `json:"includeRef,omitempty"` // When set, only these resources of the class are handled
+	Paused         bool        `json:"paused,omitempty"`
+	SuspendedUntil *time.Time  `json:"suspendedUntil,omitempty"`
+}
+
+// resourceClasses returns the classes a SleepInfo acts on, following the defaults of the controller:
+// Deployments and StatefulSets unless disabled, the rest only when enabled.
+func resourceClasses(si kubegreenv1alpha1.SleepInfo) []string {
+	classes := []string{}
+	if si.IsDeploymentsToSuspend() {
+		classes = append(classes, ClassDeployments)
+	}
+	if si.IsStatefulSetsToSuspend() {
+		classes = append(classes, ClassStatefulSets)
+	}
+	if si.IsCronjobsToSuspend() {
+		classes = append(classes, ClassCronJobs)
+	}
+	if si.IsPgbouncerToSuspend() {
+		classes = append(classes, ClassPgBouncer)
+	}
+	if si.IsPostgresToSuspend() {
+		classes = append(classes, ClassPostgres)
+	}
+	if si.IsHdfsToSuspend() {
+		classes = append(classes, ClassHDFS)
+	}
+	if si.IsOpenSearchToSuspend() {
+		classes = append(classes, ClassOpenSearch)
+	}
+	if si.IsOsDashboardsToSuspend() {
+		classes = append(classes, ClassOpenSearchDashboards)
+	}
+	if si.IsKafkaToSuspend() {
+		classes = append(classes, ClassKafka)
+	}
+	if si.IsKnativeToSuspend() {
+		classes = append(classes, ClassKnative)
+	}
+	if si.IsHPAToSuspend() {
+		classes = append(classes, ClassHPA)
+	}
+	if si.IsKEDAToSuspend() {
+		classes = append(classes, ClassKEDA)
+	}
+	if si.IsJobsToSuspend() {
+		classes = append(classes, ClassJobs)
+	}
+	for _, patch := range si.Spec.Patches {
+		if patch.Target.Kind != "" && !containsString(classes, patch.Target.Kind) {
+			classes = append(classes, patch.Target.Kind)
+		}
+	}
+	return classes
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEffectiveSchedule merges the SleepInfos of a namespace (single objects with their wake stages,
+// sleep/wake pairs and staggered wake SleepInfos) into one timeline per resource class. The result only depends on the
+// SleepInfo specs, so it is stable across calls.
+func (s *ScheduleService) GetEffectiveSchedule(ctx context.Context, tenant, namespaceSuffix string) (*EffectiveScheduleResponse, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", namespaceSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("no schedules found for tenant: %s in namespace: %s", tenant, namespaceSuffix)
+	}
+	return buildEffectiveSchedule(tenant, namespaceSuffix, sleepInfos), nil
+}
+
+// buildEffectiveSchedule merges the SleepInfos of the namespace {tenant}-{namespaceSuffix}
+func buildEffectiveSchedule(tenant, namespaceSuffix string, sleepInfos []kubegreenv1alpha1.SleepInfo) *EffectiveScheduleResponse {
+	sort.Slice(sleepInfos, func(i, j int) bool { return sleepInfos[i].Name < sleepInfos[j].Name })
+
+	response := &EffectiveScheduleResponse{
+		Tenant:       tenant,
+		Namespace:    fmt.Sprintf("%s-%s", tenant, namespaceSuffix),
+		UserTimezone: TZLocal,
+		SleepInfos:   make([]string, 0, len(sleepInfos)),
+		Classes:      []ResourceClassSchedule{},
+	}
+	for _, si := range sleepInfos {
+		response.SleepInfos = append(response.SleepInfos, si.Name)
+		if tz := si.Annotations["kube-green.stratio.com/user-timezone"]; tz != "" {
+			response.UserTimezone = tz
+		}
+	}
+
+	windows := map[string][]ScheduleWindow{}
+	for _, si := range sleepInfos {
+		role := si.Annotations["kube-green.stratio.com/pair-role"]
+		if role == "wake" {
+			continue
+		}
+		for _, class := range resourceClasses(si) {
+			window := newScheduleWindow(si, response.UserTimezone)
+			window.SleepAt = si.Spec.SleepTime
+			window.SleepInfo = si.Name
+			if role != "sleep" && si.Spec.WakeUpTime != "" {
+				window.WakeAt = si.Spec.WakeUpTime
+				window.WakeSleepInfo = si.Name
+				// A wake stage wakes up its classes after the wake up time
+				if delay := classWakeDelay(si, class); delay > 0 {
+					window.WakeAt = stagedTime(si.Spec.WakeUpTime, delay)
+					window.UserWakeAt, window.UserWakeDays = userTime(window.WakeAt, si.Spec.Weekdays, si.Spec.TimeZone, response.UserTimezone)
+				}
+			}
+			windows[class] = append(windows[class], window)
+		}
+	}
+
+	// Wake objects of a pair complete the window of the sleep object for the classes they wake.
+	// Each staggered stage wakes its own classes, so they get different wake times.
+	for _, wake := range sleepInfos {
+		if wake.Annotations["kube-green.stratio.com/pair-role"] != "wake" {
+			continue
+		}
+		wakeAt := wake.Spec.WakeUpTime
+		if wakeAt == "" {
+			wakeAt = wake.Spec.SleepTime
+		}
+		pairID := wake.Annotations["kube-green.stratio.com/pair-id"]
+		for _, class := range resourceClasses(wake) {
+			matched := false
+			for i := range windows[class] {
+				window := &windows[class][i]
+				if window.WakeSleepInfo != "" || !sleepInfoInPair(sleepInfos, window.SleepInfo, pairID) {
+					continue
+				}
+				window.WakeAt = wakeAt
+				window.WakeSleepInfo = wake.Name
+				window.WakeDays = expandWeekdays(wake.Spec.Weekdays)
+				window.UserWakeAt, window.UserWakeDays = userTime(wakeAt, wake.Spec.Weekdays, wake.Spec.TimeZone, response.UserTimezone)
+				matched = true
+				break
+			}
+			if !matched {
+				window := newScheduleWindow(wake, response.UserTimezone)
+				window.WakeAt = wakeAt
+				window.WakeSleepInfo = wake.Name
+				window.SleepDays = nil
+				window.WakeDays = expandWeekdays(wake.Spec.Weekdays)
+				window.UserWakeAt, window.UserWakeDays = userTime(wakeAt, wake.Spec.Weekdays, wake.Spec.TimeZone, response.UserTimezone)
+				windows[class] = append(windows[class], window)
+			}
+		}
+	}
+
+	classes := make([]string, 0, len(windows))
+	for class := range windows {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		classWindows := windows[class]
+		sort.SliceStable(classWindows, func(i, j int) bool {
+			if classWindows[i].SleepAt != classWindows[j].SleepAt {
+				return classWindows[i].SleepAt < classWindows[j].SleepAt
+			}
+			if classWindows[i].WakeAt != classWindows[j].WakeAt {
+				return classWindows[i].WakeAt < classWindows[j].WakeAt
+			}
+			return classWindows[i].SleepInfo < classWindows[j].SleepInfo
+		})
+		response.Classes = append(response.Classes, ResourceClassSchedule{Class: class, Windows: classWindows})
+	}
+	return response
+}
+
+// newScheduleWindow fills the fields shared by the sleep and wake sides of a window from si
+func newScheduleWindow(si kubegreenv1alpha1.SleepInfo, userTZ string) ScheduleWindow {
+	window := ScheduleWindow{
+		SleepDays:    expandWeekdays(si.Spec.Weekdays),
+		TimeZone:     si.Spec.TimeZone,
+		ScheduleName: si.Annotations["kube-green.stratio.com/schedule-name"],
+		ExcludeRef:   toFilterRefs(si.Spec.ExcludeRef),
+		IncludeRef:   toFilterRefs(si.Spec.IncludeRef),
+		Paused:       si.IsSuspended(),
+	}
+	if window.TimeZone == "" {
+		window.TimeZone = TZUTC
+	}
+	if si.Spec.SuspendScheduleUntil != nil {
+		until := si.Spec.SuspendScheduleUntil.Time
+		window.SuspendedUntil = &until
+	}
+	if si.Annotations["kube-green.stratio.com/pair-role"] != "wake" {
+		window.UserSleepAt, window.UserSleepDays = userTime(si.Spec.SleepTime, si.Spec.Weekdays, si.Spec.TimeZone, userTZ)
+		if si.Annotations["kube-green.stratio.com/pair-role"] != "sleep" && si.Spec.WakeUpTime != "" {
+			window.WakeDays = window.SleepDays
+			window.UserWakeAt, window.UserWakeDays = userTime(si.Spec.WakeUpTime, si.Spec.Weekdays, si.Spec.TimeZone, userTZ)
+		}
+	}
+	return window
+}
+
+// sleepInfoInPair reports whether the SleepInfo called name belongs to the pair pairID
+func sleepInfoInPair(sleepInfos []kubegreenv1alpha1.SleepInfo, name, pairID string) bool {
+	if pairID == "" {
+		return false
+	}
+	for _, si := range sleepInfos {
+		if si.Name == name {
+			return si.Annotations["kube-green.stratio.com/pair-id"] == pairID
+		}
+	}
+	return false
+}
+
+// expandWeekdays returns the sorted days of a weekdays expression, nil when it is invalid
+func expandWeekdays(weekdays string) []int {
+	days, err := ExpandWeekdaysStr(weekdays)
+	if err != nil {
+		return nil
+	}
+	sort.Ints(days)
+	return days
+}
+
+// userTime converts a HH:MM and its weekdays from the SleepInfo timezone to the user timezone
+func userTime(hhmm, weekdays, clusterTZ, userTZ string) (string, []int) {
+	if hhmm == "" {
+		return "", nil
+	}
+	conversion, err := FromClusterToUserTimezone(hhmm, clusterTZ, userTZ)
+	if err != nil {
+		return "", nil
+	}
+	shifted, err := ShiftWeekdaysStr(weekdays, conversion.DayShift)
+	if err != nil {
+		return conversion.TimeUTC, nil
+	}
+	return conversion.TimeUTC, expandWeekdays(shifted)
+}
+
+func toFilterRefs(refs []kubegreenv1alpha1.FilterRef) []FilterRef {
+	result := []FilterRef{}
+	for _, ref := range refs {
+		if filter := exclusionFromFilterRef(ref); !filter.isEmpty() {
+			result = append(result, filter.toAPIFilterRef())
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+const (
+	// ScheduleEventCreated is sent when a SleepInfo is created
+	ScheduleEventCreated = "created"
+	// ScheduleEventUpdated is sent when the spec or metadata of a SleepInfo changes
+	ScheduleEventUpdated = "updated"
+	// ScheduleEventDeleted is sent when a SleepInfo is deleted
+	ScheduleEventDeleted = "deleted"
+	// ScheduleEventExecuted is sent when the controller executes a sleep or wake operation
+	ScheduleEventExecuted = "executed"
+
+	eventSubscriberBuffer = 64
+	eventHeartbeat        = 15 * time.Second
+)
+
+// ScheduleEvent is a real-time notification about a SleepInfo
+type ScheduleEvent struct {
+	Type      string    `json:"type"` // created, updated, deleted or executed
+	Tenant    string    `json:"tenant"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Operation string    `json:"operation,omitempty"` // SLEEP or WAKE_UP for executed events
+	Time      time.Time `json:"time"`
+}
+
+// EventHub fans out schedule events to the connected subscribers
+type EventHub struct {
+	mu          sync.RWMutex
+	subscribers map[chan ScheduleEvent]struct{}
+}
+
+// NewEventHub creates an empty event hub
+func NewEventHub() *EventHub {
+	return &EventHub{
+		subscribers: make(map[chan ScheduleEvent]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. The returned function must be called to unsubscribe.
+func (h *EventHub) Subscribe() (<-chan ScheduleEvent, func()) {
+	ch := make(chan ScheduleEvent, eventSubscriberBuffer)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// Publish sends the event to every subscriber. Slow subscribers with a full buffer miss the event
+// instead of blocking the informer.
+func (h *EventHub) Publish(event ScheduleEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// watchSleepInfos publishes SleepInfo changes from the shared informer into the hub, with the tenant
+// of their namespace resolved by tenantOf
+func (h *EventHub) watchSleepInfos(ctx context.Context, informers cache.Informers, tenantOf func(string) string) error {
+	informer, err := informers.GetInformer(ctx, &kubegreenv1alpha1.SleepInfo{})
+	if err != nil {
+		return fmt.Errorf("failed to get SleepInfo informer: %w", err)
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if si, ok := obj.(*kubegreenv1alpha1.SleepInfo); ok {
+				h.Publish(newScheduleEvent(ScheduleEventCreated, si, tenantOf))
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSI, ok1 := oldObj.(*kubegreenv1alpha1.SleepInfo)
+			newSI, ok2 := newObj.(*kubegreenv1alpha1.SleepInfo)
+			if !ok1 || !ok2 || oldSI.ResourceVersion == newSI.ResourceVersion {
+				return
+			}
+			if !newSI.Status.LastScheduleTime.Equal(&oldSI.Status.LastScheduleTime) {
+				event := newScheduleEvent(ScheduleEventExecuted, newSI, tenantOf)
+				event.Operation = newSI.Status.OperationType
+				event.Time = newSI.Status.LastScheduleTime.Time
+				h.Publish(event)
+				return
+			}
+			h.Publish(newScheduleEvent(ScheduleEventUpdated, newSI, tenantOf))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if si, ok := obj.(*kubegreenv1alpha1.SleepInfo); ok {
+				h.Publish(newScheduleEvent(ScheduleEventDeleted, si, tenantOf))
+			}
+		},
+	})
+	return err
+}
+
+func newScheduleEvent(eventType string, si *kubegreenv1alpha1.SleepInfo, tenantOf func(string) string) ScheduleEvent {
+	return ScheduleEvent{
+		Type:      eventType,
+		Tenant:    tenantOf(si.Namespace),
+		Namespace: si.Namespace,
+		Name:      si.Name,
+		Time:      time.Now(),
+	}
+}
+
+// handleEvents streams schedule events using Server-Sent Events
+// @Summary Stream schedule events
+// @ID events
+// @Description Server-Sent Events stream notifying when a SleepInfo is created, updated or deleted, and when the controller executes a sleep or wake operation (event types created, updated, deleted, executed). Browsers using EventSource, which cannot send headers, may pass the JWT in the access_token query parameter. A comment heartbeat is sent every 15 seconds.
+// @Tags Schedules
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param tenant query string false "Only events of this tenant" example:"bdadevdat"
+// @Success 200 {object} ScheduleEvent "Stream of events (one JSON ScheduleEvent per data line)"
+// @Failure 503 {object} ErrorResponse "Event stream not available"
+// @Router /api/v1/events [get]
+func (s *Server) handleEvents(c *gin.Context) {
+	if s.eventHub == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Success: false,
+			Error:   "event stream not available",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return
+	}
+	tenant := c.Query("tenant")
+
+	events, unsubscribe := s.eventHub.Subscribe()
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	// The server WriteTimeout would close the stream: extend the deadline before every write.
+	rc := http.NewResponseController(c.Writer)
+	write := func(payload string) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(eventHeartbeat * 2))
+		if _, err := c.Writer.WriteString(payload); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+
+	if !write(": connected\n\n") {
+		return
+	}
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if !write(": heartbeat\n\n") {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if tenant != "" && event.Tenant != tenant {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				s.logger.Error(err, "failed to marshal schedule event")
+				continue
+			}
+			if !write(fmt.Sprintf("event: %s\ndata: %s\n\n", event.Type, data)) {
+				return
+			}
+		}
+	}
+}
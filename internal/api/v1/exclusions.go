@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// supportedExclusionOperators are the matchExpressions operators accepted in exclusions
+var supportedExclusionOperators = map[metav1.LabelSelectorOperator]selection.Operator{
+	metav1.LabelSelectorOpIn:           selection.In,
+	metav1.LabelSelectorOpNotIn:        selection.NotIn,
+	metav1.LabelSelectorOpExists:       selection.Exists,
+	metav1.LabelSelectorOpDoesNotExist: selection.DoesNotExist,
+}
+
+// exclusionFromFilterRef converts a SleepInfo excludeRef to its API representation
+func exclusionFromFilterRef(ref kubegreenv1alpha1.FilterRef) ExclusionFilter {
+	return ExclusionFilter{
+		APIVersion:       ref.APIVersion,
+		Kind:             ref.Kind,
+		Name:             ref.Name,
+		MatchLabels:      ref.MatchLabels,
+		MatchExpressions: ref.MatchExpressions,
+	}
+}
+
+// toFilterRef converts the exclusion to a SleepInfo excludeRef
+func (f ExclusionFilter) toFilterRef() kubegreenv1alpha1.FilterRef {
+	return kubegreenv1alpha1.FilterRef{
+		APIVersion:       f.APIVersion,
+		Kind:             f.Kind,
+		Name:             f.Name,
+		MatchLabels:      f.MatchLabels,
+		MatchExpressions: f.MatchExpressions,
+	}
+}
+
+// toAPIFilterRef converts the exclusion to the FilterRef of the schedule summaries
+func (f ExclusionFilter) toAPIFilterRef() FilterRef {
+	return FilterRef{
+		APIVersion:       f.APIVersion,
+		Kind:             f.Kind,
+		Name:             f.Name,
+		MatchLabels:      f.MatchLabels,
+		MatchExpressions: f.MatchExpressions,
+	}
+}
+
+// isEmpty returns whether the exclusion selects nothing
+func (f ExclusionFilter) isEmpty() bool {
+	return f.Name == "" && len(f.MatchLabels) == 0 && len(f.MatchExpressions) == 0
+}
+
+// validate checks the filter can be applied by the controller: a name needs the apiVersion
+// (with its group, e.g. apps/v1) and the kind of the resource and excludes labels, and expressions
+// use In, NotIn, Exists or DoesNotExist with values only for In and NotIn.
+func (f ExclusionFilter) validate() error {
+	if f.isEmpty() {
+		return fmt.Errorf("filter must have a name, matchLabels or matchExpressions")
+	}
+	if f.Name != "" {
+		if f.Kind == "" || !strings.Contains(f.APIVersion, "/") {
+			return fmt.Errorf("filter on %s needs the kind and the apiVersion with its group, e.g. apps/v1", f.Name)
+		}
+		if len(f.MatchLabels) > 0 || len(f.MatchExpressions) > 0 {
+			return fmt.Errorf("filter on %s cannot set matchLabels or matchExpressions together with the name", f.Name)
+		}
+	}
+	for _, expression := range f.MatchExpressions {
+		operator, ok := supportedExclusionOperators[expression.Operator]
+		if !ok {
+			return fmt.Errorf("invalid operator %q for key %s: supported operators are In, NotIn, Exists and DoesNotExist", expression.Operator, expression.Key)
+		}
+		if _, err := labels.NewRequirement(expression.Key, operator, expression.Values); err != nil {
+			return fmt.Errorf("invalid expression for key %s: %w", expression.Key, err)
+		}
+	}
+	return nil
+}
+
+// matches returns whether the exclusion applies to a resource of a kind with the given name and labels.
+// Like the controller, every label and expression excludes on its own.
+func (f ExclusionFilter) matches(kind, name string, objLabels map[string]string) bool {
+	if f.Name != "" && f.Kind == kind && f.Name == name {
+		return true
+	}
+	for key, value := range f.MatchLabels {
+		if objLabels[key] == value {
+			return true
+		}
+	}
+	for _, expression := range f.MatchExpressions {
+		requirement, err := labels.NewRequirement(expression.Key, supportedExclusionOperators[expression.Operator], expression.Values)
+		if err == nil && requirement.Matches(labels.Set(objLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// key returns a stable representation of the exclusion
+func (f ExclusionFilter) key() string {
+	expressions := make([]string, 0, len(f.MatchExpressions))
+	for _, expression := range f.MatchExpressions {
+		values := append([]string{}, expression.Values...)
+		sort.Strings(values)
+		expressions = append(expressions, fmt.Sprintf("%s %s (%s)", expression.Key, expression.Operator, strings.Join(values, ",")))
+	}
+	sort.Strings(expressions)
+	return strings.Join([]string{f.APIVersion, f.Kind, f.Name, labelsKey(f.MatchLabels), strings.Join(expressions, ";")}, "|")
+}
+
+// validateInclusions checks the inclusions of a schedule can be applied by the controller, which
+// combines every inclusion of a namespace: its labels must all match and a name only restricts the
+// resources of its kind, so a namespace can include a single name per kind.
+func validateInclusions(tenant string, inclusions []NamespaceInclusion) error {
+	names := map[string]string{}
+	for i, inclusion := range inclusions {
+		if strings.TrimSpace(inclusion.Namespace) == "" {
+			return fmt.Errorf("invalid inclusion %d: namespace is required", i)
+		}
+		if err := inclusion.Filter.validate(); err != nil {
+			return fmt.Errorf("invalid inclusion %d: %w", i, err)
+		}
+		if len(inclusion.Filter.MatchExpressions) > 0 {
+			return fmt.Errorf("invalid inclusion %d: matchExpressions are not supported in inclusions", i)
+		}
+		if inclusion.Filter.Name == "" {
+			continue
+		}
+		key := strings.TrimPrefix(inclusion.Namespace, tenant+"-") + "/" + inclusion.Filter.Kind
+		if other, ok := names[key]; ok && other != inclusion.Filter.Name {
+			return fmt.Errorf("invalid inclusion %d: %s already includes the %s %s, only one name per kind can be included", i, inclusion.Namespace, inclusion.Filter.Kind, other)
+		}
+		names[key] = inclusion.Filter.Name
+	}
+	return nil
+}
+
+// inclusionRefsFor returns the includeRef of the namespace {tenant}-{suffix}
+func inclusionRefsFor(tenant, suffix string, inclusions []NamespaceInclusion) []kubegreenv1alpha1.FilterRef {
+	var refs []kubegreenv1alpha1.FilterRef
+	for _, inclusion := range inclusions {
+		if inclusion.Namespace == suffix || inclusion.Namespace == fmt.Sprintf("%s-%s", tenant, suffix) {
+			refs = append(refs, inclusion.Filter.toFilterRef())
+		}
+	}
+	return refs
+}
+
+// validateExclusions checks the custom exclusions of a schedule name their namespace and select resources
+func validateExclusions(exclusions []NamespaceExclusion) error {
+	for i, exclusion := range exclusions {
+		if strings.TrimSpace(exclusion.Namespace) == "" {
+			return fmt.Errorf("invalid exclusion %d: namespace is required", i)
+		}
+		if err := exclusion.Filter.validate(); err != nil {
+			return fmt.Errorf("invalid exclusion %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// exclusionRefsFor returns the custom excludeRef of the namespace {tenant}-{suffix}
+func exclusionRefsFor(tenant, suffix string, exclusions []NamespaceExclusion) []kubegreenv1alpha1.FilterRef {
+	var refs []kubegreenv1alpha1.FilterRef
+	for _, exclusion := range exclusions {
+		if exclusion.Namespace == suffix || exclusion.Namespace == fmt.Sprintf("%s-%s", tenant, suffix) {
+			refs = append(refs, exclusion.Filter.toFilterRef())
+		}
+	}
+	return refs
+}
+
+// existingInclusions returns the includeRef of the SleepInfos of a schedule, so an update keeps them
+func existingInclusions(schedule *ScheduleResponse) []NamespaceInclusion {
+	inclusions := []NamespaceInclusion{}
+	for suffix, nsInfo := range schedule.Namespaces {
+		seen := map[string]bool{}
+		for _, sched := range nsInfo.Schedule {
+			for _, ref := range sched.IncludeRef {
+				filter := ExclusionFilter{
+					APIVersion:       ref.APIVersion,
+					Kind:             ref.Kind,
+					Name:             ref.Name,
+					MatchLabels:      ref.MatchLabels,
+					MatchExpressions: ref.MatchExpressions,
+				}
+				if key := filter.key(); !seen[key] {
+					seen[key] = true
+					inclusions = append(inclusions, NamespaceInclusion{Namespace: suffix, Filter: filter})
+				}
+			}
+		}
+	}
+	return inclusions
+}
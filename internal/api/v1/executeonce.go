@@ -0,0 +1,37 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+)
+
+// setExecuteOnce makes the SleepInfos of a schedule run once: they sleep and wake up at their next
+// scheduled times and are then deleted by the controller, for ad-hoc requests such as turning an
+// environment off tonight only.
+func (s *ScheduleService) setExecuteOnce(ctx context.Context, tenant string, namespaceSuffixes map[string]bool, scheduleName string) error {
+	for suffix := range namespaceSuffixes {
+		namespace := fmt.Sprintf("%s-%s", tenant, suffix)
+		sleepInfos, err := s.listWrittenSleepInfos(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to list SleepInfos in %s: %w", namespace, err)
+		}
+		for i := range sleepInfos {
+			si := &sleepInfos[i]
+			if !matchesScheduleName(*si, scheduleName) || si.IsDeleteWhenCompleted() {
+				continue
+			}
+			executeOnce := true
+			si.Spec.ExecuteOnce = &executeOnce
+			si.Spec.DeleteWhenCompleted = &executeOnce
+			if err := s.client.Update(ctx, si); err != nil {
+				return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
+			}
+			s.logger.Info("SleepInfo set to execute once", "name", si.Name, "namespace", si.Namespace)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ScheduleExport contains the portable manifests of a tenant schedule
+type ScheduleExport struct {
+	Tenant     string                        `json:"tenant"`
+	SleepInfos []kubegreenv1alpha1.SleepInfo `json:"sleepInfos"`
+	Secrets    []SecretMetadata              `json:"secrets,omitempty"` // Only when includeSecrets=true
+}
+
+// SecretMetadata describes a sleepinfo-* Secret without exposing its data
+type SecretMetadata struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Keys        []string          `json:"keys"` // Data keys present in the Secret (values are never exported)
+}
+
+// ExportSchedule returns the SleepInfos of a tenant as clean manifests (no status, uid, resourceVersion,
+// managedFields...) ready to be committed to Git or applied on another cluster.
+func (s *ScheduleService) ExportSchedule(ctx context.Context, tenant, namespaceSuffix string, includeSecrets bool) (*ScheduleExport, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", namespaceSuffix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sleepInfos, func(i, j int) bool {
+		if sleepInfos[i].Namespace != sleepInfos[j].Namespace {
+			return sleepInfos[i].Namespace < sleepInfos[j].Namespace
+		}
+		return sleepInfos[i].Name < sleepInfos[j].Name
+	})
+
+	export := &ScheduleExport{
+		Tenant:     tenant,
+		SleepInfos: make([]kubegreenv1alpha1.SleepInfo, 0, len(sleepInfos)),
+	}
+	for _, si := range sleepInfos {
+		export.SleepInfos = append(export.SleepInfos, cleanSleepInfoForExport(si))
+
+		if !includeSecrets {
+			continue
+		}
+		secret := &v1.Secret{}
+		key := client.ObjectKey{Name: fmt.Sprintf("sleepinfo-%s", si.Name), Namespace: si.Namespace}
+		if err := s.reader.Get(ctx, key, secret); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to get secret %s: %w", key.Name, err)
+			}
+			continue
+		}
+		keys := make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		export.Secrets = append(export.Secrets, SecretMetadata{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+			Keys:        keys,
+		})
+	}
+	return export, nil
+}
+
+// cleanSleepInfoForExport strips the server-populated fields of a SleepInfo
+func cleanSleepInfoForExport(si kubegreenv1alpha1.SleepInfo) kubegreenv1alpha1.SleepInfo {
+	annotations := make(map[string]string, len(si.Annotations))
+	for k, v := range si.Annotations {
+		// Transient annotations must not be replayed on another cluster
+		if k == "kube-green.stratio.com/manual-action" || k == "kube-green.stratio.com/manual-at" ||
+			k == "kubectl.kubernetes.io/last-applied-configuration" {
+			continue
+		}
+		annotations[k] = v
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	return kubegreenv1alpha1.SleepInfo{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+			Kind:       "SleepInfo",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        si.Name,
+			Namespace:   si.Namespace,
+			Labels:      si.Labels,
+			Annotations: annotations,
+		},
+		Spec: *si.Spec.DeepCopy(),
+	}
+}
+
+// ToYAML renders the SleepInfos as a multi-document YAML stream
+func (e *ScheduleExport) ToYAML() ([]byte, error) {
+	var buf bytes.Buffer
+	for i := range e.SleepInfos {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&e.SleepInfos[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert SleepInfo %s: %w", e.SleepInfos[i].Name, err)
+		}
+		// Zero values emitted by the typed structs, never part of a portable manifest
+		delete(obj, "status")
+		unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal SleepInfo %s: %w", e.SleepInfos[i].Name, err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(out)
+	}
+	if len(e.Secrets) > 0 {
+		out, err := yaml.Marshal(map[string]interface{}{"secrets": e.Secrets})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal secrets metadata: %w", err)
+		}
+		buf.WriteString("# Secrets metadata (values are not exported, the controller recreates them)\n")
+		for _, line := range bytes.SplitAfter(out, []byte("\n")) {
+			if len(line) > 0 {
+				buf.WriteString("# ")
+				buf.Write(line)
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/gitops"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+// clusterScopedDirectory is the directory of the GitOps repository holding the cluster-scoped objects
+const clusterScopedDirectory = "_cluster"
+
+// runtimeAnnotations are never committed to the GitOps repository: they are written by the controller
+// or for a single execution, and ignored by the Argo CD diff of config/argocd
+var runtimeAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"kube-green.stratio.com/manual-action",
+	"kube-green.stratio.com/manual-at",
+	kubegreenv1alpha1.SnoozeUntilAnnotation,
+	"kube-green.stratio.com/restore-incomplete",
+	"kube-green.stratio.com/restore-incomplete-at",
+}
+
+type clusterWriteKey struct{}
+
+// withClusterWrite makes the writes of ctx go to the cluster in GitOps mode. It is used for the
+// runtime annotations, e.g. manual actions and snoozes, which are not desired state.
+func withClusterWrite(ctx context.Context) context.Context {
+	return context.WithValue(ctx, clusterWriteKey{}, true)
+}
+
+// gitOpsClient commits the kube-green objects written by the API to a Git repository, one manifest per
+// object, instead of writing them to the cluster, where Flux or Argo CD applies them. Reads, status
+// writes and the other objects use the cluster.
+type gitOpsClient struct {
+	client.Client
+	repository *gitops.Repository
+}
+
+// committed returns the kind of obj when its writes are committed to the repository
+func (c *gitOpsClient) committed(ctx context.Context, obj runtime.Object) (schema.GroupVersionKind, bool) {
+	if clusterWrite, _ := ctx.Value(clusterWriteKey{}).(bool); clusterWrite {
+		return schema.GroupVersionKind{}, false
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	return gvk, err == nil && gvk.Group == kubegreenv1alpha1.GroupVersion.Group
+}
+
+func (c *gitOpsClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	gvk, ok := c.committed(ctx, obj)
+	if !ok {
+		return c.Client.Create(ctx, obj, opts...)
+	}
+	manifest, err := gitOpsManifest(obj, gvk)
+	if err != nil {
+		return err
+	}
+	err = c.repository.Create(ctx, gitOpsFile(obj, gvk.Kind), manifest, gitOpsMessage(ctx, "create", gvk.Kind, obj))
+	if errors.Is(err, gitops.ErrExists) {
+		return apierrors.NewAlreadyExists(gitOpsResource(gvk), obj.GetName())
+	}
+	return err
+}
+
+func (c *gitOpsClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	gvk, ok := c.committed(ctx, obj)
+	if !ok {
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	return c.write(ctx, obj, gvk, "update")
+}
+
+func (c *gitOpsClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	content, ok := obj.(interface{ UnstructuredContent() map[string]interface{} })
+	if !ok {
+		return c.Client.Apply(ctx, obj, opts...)
+	}
+	u := &unstructured.Unstructured{Object: content.UnstructuredContent()}
+	gvk, ok := c.committed(ctx, u)
+	if !ok {
+		return c.Client.Apply(ctx, obj, opts...)
+	}
+	return c.write(ctx, u, gvk, "apply")
+}
+
+func (c *gitOpsClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if gvk, ok := c.committed(ctx, obj); ok {
+		return fmt.Errorf("%s %s cannot be patched in GitOps mode", gvk.Kind, client.ObjectKeyFromObject(obj))
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *gitOpsClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	gvk, ok := c.committed(ctx, obj)
+	if !ok {
+		return c.Client.Delete(ctx, obj, opts...)
+	}
+	err := c.repository.Delete(ctx, gitOpsFile(obj, gvk.Kind), gitOpsMessage(ctx, "delete", gvk.Kind, obj))
+	if errors.Is(err, gitops.ErrNotFound) {
+		// Deleting it from the cluster only would be reverted by the next sync
+		return fmt.Errorf("%s %s is not managed in the GitOps repository, delete it from its source", gvk.Kind, client.ObjectKeyFromObject(obj))
+	}
+	return err
+}
+
+func (c *gitOpsClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if gvk, ok := c.committed(ctx, obj); ok {
+		return fmt.Errorf("%s objects cannot be deleted in bulk in GitOps mode", gvk.Kind)
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *gitOpsClient) write(ctx context.Context, obj client.Object, gvk schema.GroupVersionKind, verb string) error {
+	manifest, err := gitOpsManifest(obj, gvk)
+	if err != nil {
+		return err
+	}
+	return c.repository.Write(ctx, gitOpsFile(obj, gvk.Kind), manifest, gitOpsMessage(ctx, verb, gvk.Kind, obj))
+}
+
+// listSleepInfos returns the SleepInfos of a namespace committed to the repository
+func (c *gitOpsClient) listSleepInfos(ctx context.Context, namespace string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	names, err := c.repository.List(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	sleepInfos := []kubegreenv1alpha1.SleepInfo{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, "sleepinfo-") || !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+		content, err := c.repository.Read(ctx, namespace+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+		sleepInfo := kubegreenv1alpha1.SleepInfo{}
+		if err := yaml.Unmarshal(content, &sleepInfo); err != nil {
+			return nil, fmt.Errorf("invalid SleepInfo manifest %s/%s: %w", namespace, name, err)
+		}
+		sleepInfos = append(sleepInfos, sleepInfo)
+	}
+	return sleepInfos, nil
+}
+
+// gitOpsFile returns the file of an object in the repository: {namespace}/{kind}-{name}.yaml
+func gitOpsFile(obj client.Object, kind string) string {
+	directory := obj.GetNamespace()
+	if directory == "" {
+		directory = clusterScopedDirectory
+	}
+	return directory + "/" + strings.ToLower(kind) + "-" + obj.GetName() + ".yaml"
+}
+
+// gitOpsResource returns the resource of a kube-green kind, e.g. sleepinfos
+func gitOpsResource(gvk schema.GroupVersionKind) schema.GroupResource {
+	return schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind) + "s"}
+}
+
+// gitOpsManifest returns the manifest of an object as committed: its name, namespace, labels,
+// annotations without the runtime ones, and spec. The cluster fields, e.g. the status, the owner
+// references and the resource version, are left out.
+func gitOpsManifest(obj client.Object, gvk schema.GroupVersionKind) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", gvk.Kind, err)
+	}
+	metadata := map[string]interface{}{"name": obj.GetName()}
+	if obj.GetNamespace() != "" {
+		metadata["namespace"] = obj.GetNamespace()
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	annotations := map[string]string{}
+	for key, value := range obj.GetAnnotations() {
+		annotations[key] = value
+	}
+	for _, key := range runtimeAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+	manifest := map[string]interface{}{
+		"apiVersion": gvk.GroupVersion().String(),
+		"kind":       gvk.Kind,
+		"metadata":   metadata,
+	}
+	if spec, ok := content["spec"]; ok {
+		manifest["spec"] = spec
+	}
+	return yaml.Marshal(manifest)
+}
+
+// gitOpsMessage returns the commit message of a write, naming the API user of ctx
+func gitOpsMessage(ctx context.Context, verb, kind string, obj client.Object) string {
+	message := fmt.Sprintf("kube-green: %s %s %s", verb, kind, strings.TrimPrefix(client.ObjectKeyFromObject(obj).String(), "/"))
+	if user, ok := ctx.Value(apiUserKey{}).(apiUser); ok {
+		message += "\n\nRequested by " + user.username + " through the kube-green API."
+	}
+	return message
+}
+
+// listWrittenSleepInfos lists the SleepInfos of a namespace as last written by the API: from the GitOps
+// repository in GitOps mode, as they reach the cluster only after the next sync
+func (s *ScheduleService) listWrittenSleepInfos(ctx context.Context, namespace string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	if s.gitOps != nil {
+		return s.gitOps.listSleepInfos(ctx, namespace)
+	}
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.reader.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	return sleepInfoList.Items, nil
+}
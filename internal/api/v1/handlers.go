@@ -5,16 +5,19 @@ Copyright 2025.
 package v1
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kube-green/kube-green/internal/api/v1/auth"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // APIResponse represents a standard API response
@@ -36,6 +39,7 @@ type ErrorResponse struct {
 
 // handleHealth returns health status
 // @Summary Health check endpoint
+// @ID health
 // @Description Returns the health status of the API server
 // @Tags Health
 // @Accept json
@@ -51,6 +55,7 @@ func (s *Server) handleHealth(c *gin.Context) {
 
 // handleReady returns readiness status
 // @Summary Readiness check endpoint
+// @ID ready
 // @Description Returns the readiness status of the API server
 // @Tags Health
 // @Accept json
@@ -67,6 +72,7 @@ func (s *Server) handleReady(c *gin.Context) {
 
 // handleInfo returns API information
 // @Summary API information endpoint
+// @ID info
 // @Description Returns information about the API
 // @Tags Info
 // @Accept json
@@ -95,16 +101,39 @@ func (s *Server) handleInfo(c *gin.Context) {
 
 // handleListTenants lists all discovered tenants
 // @Summary List all tenants
-// @Description Discovers all tenants by scanning namespaces that match the pattern {tenant}-{suffix}
+// @ID listTenants
+// @Description Discovers all tenants by scanning namespaces that match the pattern {tenant}-{suffix}. With details=true every tenant also includes its schedule aggregates: number of SleepInfos, which namespaces have a schedule, next sleep/wake and current power state.
 // @Tags Tenants
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param details query bool false "Include the schedule aggregates of every tenant" example:"true"
 // @Success 200 {object} APIResponse{data=TenantListResponse}
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/tenants [get]
 func (s *Server) handleListTenants(c *gin.Context) {
-	tenants, err := s.scheduleService.ListTenants(c.Request.Context())
+	details := false
+	if detailsStr := c.Query("details"); detailsStr != "" {
+		parsed, err := strconv.ParseBool(detailsStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "details must be a boolean",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		details = parsed
+	}
+
+	var tenants *TenantListResponse
+	var err error
+	if details {
+		tenants, err = s.scheduleService.ListTenantsWithDetails(c.Request.Context(), time.Now())
+	} else {
+		tenants, err = s.scheduleService.ListTenants(c.Request.Context())
+	}
 	if err != nil {
 		s.logger.Error(err, "failed to list tenants")
 		handleKubernetesError(c, err)
@@ -117,8 +146,61 @@ func (s *Server) handleListTenants(c *gin.Context) {
 	})
 }
 
+// handleListNamespacePolicies returns the namespace suffix catalogue
+// @Summary List namespace policies
+// @ID listNamespacePolicies
+// @Description Returns the known namespace suffixes and how schedules are created in them: whether StatefulSets are suspended, whether the wake is staggered and the default exclusions. Unset behaviors rely on the resource detection of the namespace. The catalogue is loaded from the built-in suffixes, the --api-namespace-policies file and the --api-namespace-policy-configmap ConfigMap.
+// @Tags Namespaces
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=NamespacePolicyListResponse}
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/namespace-policies [get]
+func (s *Server) handleListNamespacePolicies(c *gin.Context) {
+	policies, err := s.scheduleService.NamespacePolicies(c.Request.Context())
+	if err != nil {
+		s.logger.Error(err, "failed to load namespace policies")
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    NamespacePolicyListResponse{Policies: policies},
+	})
+}
+
+// handleListTimezones lists the IANA timezones available in the container
+// @Summary List timezones
+// @ID listTimezones
+// @Description Returns the IANA timezone names available in the container with their current UTC offset, so the frontend can populate its timezone picker. Use groupBy=region to group them by top-level area (America, Europe, ...)
+// @Tags Timezones
+// @Produce json
+// @Security BearerAuth
+// @Param groupBy query string false "Group the timezones by region" Enums(region)
+// @Success 200 {object} APIResponse{data=TimezoneListResponse}
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Router /api/v1/timezones [get]
+func (s *Server) handleListTimezones(c *gin.Context) {
+	groupBy := c.Query("groupBy")
+	if groupBy != "" && groupBy != "region" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid groupBy %q, only \"region\" is supported", groupBy),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    ListTimezones(time.Now(), groupBy == "region"),
+	})
+}
+
 // handleGetNamespaceServices lists services in a tenant namespace
 // @Summary Get services for a namespace
+// @ID getNamespaceServices
 // @Description Lists deployments, statefulsets and cronjobs for a tenant namespace
 // @Tags Namespaces
 // @Accept json
@@ -155,8 +237,57 @@ func (s *Server) handleGetNamespaceServices(c *gin.Context) {
 	})
 }
 
+// handleGetEffectiveSchedule returns the merged schedule of a tenant namespace
+// @Summary Get the effective schedule of a namespace
+// @ID getEffectiveSchedule
+// @Description Merges every SleepInfo of the namespace (single objects, sleep/wake pairs, staggered wake stages and exclusions) into one normalized timeline per resource class (Deployments, StatefulSets, CronJobs, Postgres, HDFS, PgBouncer, ... or the Kind of a custom patch), telling when each class sleeps and wakes in the cluster and user timezones
+// @Tags Namespaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdaqa"
+// @Param namespace query string true "Namespace suffix" example:"datastores"
+// @Success 200 {object} APIResponse{data=EffectiveScheduleResponse}
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/namespaces/{tenant}/effective-schedule [get]
+func (s *Server) handleGetEffectiveSchedule(c *gin.Context) {
+	tenant := c.Param("tenant")
+	namespace := c.Query("namespace")
+	if tenant == "" || namespace == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "tenant and namespace parameters are required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	schedule, err := s.scheduleService.GetEffectiveSchedule(c.Request.Context(), tenant, namespace)
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to get effective schedule", "tenant", tenant, "namespace", namespace)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    schedule,
+	})
+}
+
 // handleGetNamespaceResources detects resources in a tenant namespace
 // @Summary Get resources for a namespace
+// @ID getNamespaceResources
 // @Description Detects CRDs and resource counts for a tenant namespace
 // @Tags Namespaces
 // @Accept json
@@ -195,30 +326,75 @@ func (s *Server) handleGetNamespaceResources(c *gin.Context) {
 
 // handleListSchedules lists all schedules
 // @Summary List all schedules
-// @Description Lists all SleepInfo schedules across all namespaces
+// @ID listSchedules
+// @Description Lists SleepInfo schedules across all namespaces grouped by tenant, sorted by tenant name. Supports optional filters and limit/continue pagination (limit counts tenants). The pagination state is returned in the X-Total-Count and X-Continue-Token response headers so the response body stays a plain list.
 // @Tags Schedules
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} APIResponse
+// @Param tenantPrefix query string false "Only tenants whose name starts with this prefix" example:"bdadev"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"datastores"
+// @Param scheduleName query string false "Only SleepInfos belonging to this schedule name" example:"horario-laboral"
+// @Param name query string false "Alias of scheduleName: returns the SleepInfos of the named schedule across all namespaces, resolved through the schedule-name index" example:"horario-laboral"
+// @Param limit query int false "Maximum number of tenants to return (0 = all)" example:"20"
+// @Param continue query string false "Continue token returned by the previous page (X-Continue-Token header)"
+// @Success 200 {object} APIResponse{data=[]ScheduleResponse}
+// @Header 200 {int} X-Total-Count "Total number of tenants matching the filters"
+// @Header 200 {string} X-Continue-Token "Token for the next page, absent on the last page"
+// @Failure 400 {object} ErrorResponse "Invalid pagination parameters"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/schedules [get]
 func (s *Server) handleListSchedules(c *gin.Context) {
-	schedules, err := s.scheduleService.ListSchedules(c.Request.Context())
+	opts := ListSchedulesOptions{
+		TenantPrefix:    c.Query("tenantPrefix"),
+		NamespaceSuffix: c.Query("namespace"),
+		ScheduleName:    c.Query("scheduleName"),
+		Continue:        c.Query("continue"),
+	}
+	if opts.ScheduleName == "" {
+		opts.ScheduleName = c.Query("name")
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   "limit must be a non-negative integer",
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		opts.Limit = limit
+	}
+	if _, err := decodeContinueToken(opts.Continue); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	page, err := s.scheduleService.ListSchedules(c.Request.Context(), opts)
 	if err != nil {
 		s.logger.Error(err, "failed to list schedules")
 		handleKubernetesError(c, err)
 		return
 	}
 
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	if page.Continue != "" {
+		c.Header("X-Continue-Token", page.Continue)
+	}
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
-		Data:    schedules,
+		Data:    page.Items,
 	})
 }
 
 // handleGetSchedule gets schedule for a specific tenant
 // @Summary Get schedule for tenant
+// @ID getSchedule
 // @Description Returns all SleepInfo configurations for a specific tenant, grouped by namespace. If namespace parameter is not provided, returns all namespaces. If namespace is provided (datastores, apps, rocket, intelligence, airflowsso), returns only that namespace.
 // @Tags Schedules
 // @Accept json
@@ -269,26 +445,225 @@ func (s *Server) handleGetSchedule(c *gin.Context) {
 	})
 }
 
+// handleGetScheduleCalendar exports the sleep windows of a tenant as an iCal feed
+// @Summary Get the schedule calendar (iCal)
+// @ID getScheduleCalendar
+// @Description Returns an iCalendar (RFC 5545) feed with weekly recurring events spanning from each sleep to the matching wake, in the user timezone, so teams can subscribe from Outlook or Google Calendar and know when their environments are off. Calendar clients that cannot send headers can pass the token as access_token query parameter.
+// @Tags Schedules
+// @Produce text/calendar
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace query string false "Namespace suffix filter" example:"datastores"
+// @Param access_token query string false "Bearer token for calendar subscriptions"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/calendar.ics [get]
+func (s *Server) handleGetScheduleCalendar(c *gin.Context) {
+	tenant := c.Param("tenant")
+	namespaceFilter := c.Query("namespace")
+
+	calendar, err := s.scheduleService.GetScheduleCalendar(c.Request.Context(), tenant, namespaceFilter, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to build schedule calendar", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s.ics", tenant))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// handleGetNamespaceStatus returns the controller view of the SleepInfos of a tenant namespace
+// @Summary Get reconcile status of a namespace
+// @ID getNamespaceStatus
+// @Description Returns, for every SleepInfo of the namespace, what the controller did with it: lastScheduleTime and lastOperation from its status, the last manual operation, whether the sleepinfo-<name> secret and its restore data exist, when the controller is expected to act next, and errors (invalid schedule, operations not recorded on time, warning events). Use it to tell whether the created SleepInfos are actually being processed.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace path string true "Namespace suffix" example:"datastores"
+// @Success 200 {object} APIResponse{data=NamespaceStatusResponse}
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/{namespace}/status [get]
+func (s *Server) handleGetNamespaceStatus(c *gin.Context) {
+	tenant := c.Param("tenant")
+	namespace := c.Param("namespace")
+
+	status, err := s.scheduleService.GetNamespaceStatus(c.Request.Context(), tenant, namespace, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to get namespace status", "tenant", tenant, "namespace", namespace)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    status,
+	})
+}
+
+// handleExportSchedule exports the SleepInfos of a tenant as Kubernetes manifests
+// @Summary Export schedule manifests
+// @ID exportSchedule
+// @Description Returns the SleepInfo manifests of a tenant without server-populated fields (status, uid, resourceVersion, managedFields), so they can be committed to Git or applied on another cluster. format=yaml (default) returns a multi-document YAML file; format=json returns the manifests inside the standard APIResponse. With includeSecrets=true the metadata of the sleepinfo-* Secrets is added (never their values).
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Produce application/yaml
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"datastores"
+// @Param format query string false "Output format: yaml or json" Enums(yaml, json) default(yaml)
+// @Param includeSecrets query bool false "Include the metadata of the associated secrets" default(false)
+// @Success 200 {object} APIResponse{data=ScheduleExport} "Manifests (format=json)"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/export [get]
+func (s *Server) handleExportSchedule(c *gin.Context) {
+	tenant := c.Param("tenant")
+	format := strings.ToLower(c.DefaultQuery("format", "yaml"))
+	if format != "yaml" && format != "json" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "format must be yaml or json",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	includeSecrets := c.Query("includeSecrets") == "true"
+	namespaceFilter := c.Query("namespace")
+
+	export, err := s.scheduleService.ExportSchedule(c.Request.Context(), tenant, namespaceFilter, includeSecrets)
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to export schedule", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, APIResponse{
+			Success: true,
+			Data:    export,
+		})
+		return
+	}
+
+	manifests, err := export.ToYAML()
+	if err != nil {
+		s.logger.Error(err, "failed to render schedule export", "tenant", tenant)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s-sleepinfos.yaml", tenant))
+	c.Data(http.StatusOK, "application/yaml", manifests)
+}
+
 // CreateScheduleRequest represents a request to create a schedule
 // @Description Request to create a new sleep/wake schedule for a tenant
 type CreateScheduleRequest struct {
-	Tenant        string       `json:"tenant" binding:"required" example:"bdadevdat"`                      // Tenant name (e.g., bdadevdat, bdadevprd)
-	Off           string       `json:"off" binding:"required" example:"22:00"`                             // Sleep time in local timezone (HH:MM format, 24-hour)
-	On            string       `json:"on" binding:"required" example:"06:00"`                              // Wake time in local timezone (HH:MM format, 24-hour)
-	Weekdays      string       `json:"weekdays,omitempty" example:"lunes-viernes"`                         // Days of week (human format: "lunes-viernes", or numeric: "1-5")
-	SleepDays     string       `json:"sleepDays,omitempty" example:"viernes"`                              // Optional: specific days for sleep (overrides weekdays)
-	WakeDays      string       `json:"wakeDays,omitempty" example:"lunes"`                                 // Optional: specific days for wake (overrides weekdays)
-	WeekdaysSleep string       `json:"weekdaysSleep,omitempty" example:"viernes"`                          // Frontend format: specific days for sleep (mapped to SleepDays)
-	WeekdaysWake  string       `json:"weekdaysWake,omitempty" example:"lunes"`                             // Frontend format: specific days for wake (mapped to WakeDays)
-	Namespaces    []string     `json:"namespaces,omitempty" example:"datastores,apps"`                     // Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso)
-	Delays        *DelayConfig `json:"delays,omitempty"`                                                   // Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"})
-	ScheduleName  string       `json:"scheduleName,omitempty" example:"horario-laboral"`                   // Optional: name to identify this schedule (allows multiple schedules per namespace)
-	Description   string       `json:"description,omitempty" example:"Horario laboral de lunes a viernes"` // Optional: description of the schedule
-	Apply         bool         `json:"apply,omitempty"`                                                    // Always applies to cluster (field is ignored but kept for compatibility)
+	Tenant             string                   `json:"tenant" binding:"required" example:"bdadevdat"`                           // Tenant name (e.g., bdadevdat, bdadevprd)
+	Off                string                   `json:"off" binding:"required_without=OffCron" example:"22:00"`                  // Sleep time in local timezone (HH:MM format, 24-hour)
+	On                 string                   `json:"on" binding:"required_without_all=DurationHours OffCron" example:"06:00"` // Wake time in local timezone (HH:MM format, 24-hour)
+	OffCron            string                   `json:"offCron,omitempty" example:"0 20 * * 6#1"`                                // Optional: cron expression of the sleep in local timezone, instead of off and weekdays (6#1: first Saturday of the month)
+	OnCron             string                   `json:"onCron,omitempty" example:"0 8 * * 1#1"`                                  // Optional: cron expression of the wake up in local timezone, instead of on
+	DurationHours      float64                  `json:"durationHours,omitempty" example:"8"`                                     // Optional: hours asleep after off, instead of on (e.g. 8 or 10.5)
+	Weekdays           string                   `json:"weekdays,omitempty" example:"lunes-viernes"`                              // Days of week (human format: "lunes-viernes", or numeric: "1-5")
+	SleepDays          string                   `json:"sleepDays,omitempty" example:"viernes"`                                   // Optional: specific days for sleep (overrides weekdays)
+	WakeDays           string                   `json:"wakeDays,omitempty" example:"lunes"`                                      // Optional: specific days for wake (overrides weekdays)
+	WeekdaysSleep      string                   `json:"weekdaysSleep,omitempty" example:"viernes"`                               // Frontend format: specific days for sleep (mapped to SleepDays)
+	WeekdaysWake       string                   `json:"weekdaysWake,omitempty" example:"lunes"`                                  // Frontend format: specific days for wake (mapped to WakeDays)
+	Namespaces         []string                 `json:"namespaces,omitempty" example:"datastores,apps"`                          // Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso)
+	Delays             *DelayConfig             `json:"delays,omitempty"`                                                        // Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"})
+	ScheduleName       string                   `json:"scheduleName,omitempty" example:"horario-laboral"`                        // Optional: name to identify this schedule (allows multiple schedules per namespace)
+	Description        string                   `json:"description,omitempty" example:"Horario laboral de lunes a viernes"`      // Optional: description of the schedule
+	Apply              bool                     `json:"apply,omitempty"`                                                         // Always applies to cluster (field is ignored but kept for compatibility)
+	Exclusions         []NamespaceExclusion     `json:"exclusions,omitempty"`                                                    // Optional: resources of each namespace never put to sleep, added to the automatic exclusions
+	Inclusions         []NamespaceInclusion     `json:"inclusions,omitempty"`                                                    // Optional: only the matching resources of each namespace are put to sleep
+	Holidays           *HolidayConfig           `json:"holidays,omitempty"`                                                      // Optional: holiday calendar and what the schedule does on holidays
+	SleepReplicas      []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`                                                 // Optional: replicas kept during sleep by the matching workloads of each namespace
+	ExecuteOnce        bool                     `json:"executeOnce,omitempty"`                                                   // Optional: sleep and wake up once at the next scheduled times, then delete the schedule
+	KarpenterNodePools []string                 `json:"karpenterNodePools,omitempty" example:"bdadevdat-apps"`                   // Optional: Karpenter NodePools scaled to zero while asleep, restored 5 minutes before the wake up
+}
+
+// handleValidateSchedule validates a schedule without creating it
+// @Summary Validate a schedule
+// @ID validateSchedule
+// @Description Runs the same validations as the schedule creation plus cluster-aware checks (namespaces exist, scheduleName is unique, no overlap with existing schedules, exclusions match at least one resource) and returns the errors and warnings found. Nothing is created.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ValidateScheduleRequest true "Schedule configuration"
+// @Success 200 {object} APIResponse{data=ScheduleValidationResult} "Validation result"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/validate [post]
+func (s *Server) handleValidateSchedule(c *gin.Context) {
+	// Decode without binding validation: missing fields are reported in the result
+	var req ValidateScheduleRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("invalid request body: %v", err),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if req.SleepDays == "" {
+		req.SleepDays = req.WeekdaysSleep
+	}
+	if req.WakeDays == "" {
+		req.WakeDays = req.WeekdaysWake
+	}
+
+	result, err := s.scheduleService.ValidateSchedule(c.Request.Context(), req)
+	if err != nil {
+		s.logger.Error(err, "failed to validate schedule", "tenant", req.Tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+	})
 }
 
 // handleCreateSchedule creates a new schedule
 // @Summary Create a new schedule
+// @ID createSchedule
 // @Description Creates SleepInfo configurations for a tenant. Automatically converts local time (America/Bogota) to UTC and handles timezone day shifts. Creates schedules for all namespaces (datastores, apps, rocket, intelligence, airflowsso) unless filtered.
 // @Tags Schedules
 // @Accept json
@@ -297,7 +672,7 @@ type CreateScheduleRequest struct {
 // @Param request body CreateScheduleRequest true "Schedule configuration"
 // @Success 201 {object} APIResponse "Schedule created successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request parameters"
-// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 500 {object} ScheduleWriteErrorResponse "Internal server error, with the rollback report when some SleepInfos were written"
 // @Router /api/v1/schedules [post]
 func (s *Server) handleCreateSchedule(c *gin.Context) {
 	// Check permissions
@@ -354,16 +729,24 @@ func (s *Server) handleCreateSchedule(c *gin.Context) {
 
 	// Create schedule using service
 	serviceReq := CreateScheduleRequest{
-		Tenant:       req.Tenant,
-		Off:          req.Off,
-		On:           req.On,
-		Weekdays:     req.Weekdays,
-		SleepDays:    sleepDays,
-		WakeDays:     wakeDays,
-		Namespaces:   req.Namespaces,
-		Delays:       req.Delays,
-		ScheduleName: req.ScheduleName,
-		Description:  req.Description,
+		Tenant:             req.Tenant,
+		Off:                req.Off,
+		On:                 req.On,
+		DurationHours:      req.DurationHours,
+		OffCron:            req.OffCron,
+		OnCron:             req.OnCron,
+		Weekdays:           req.Weekdays,
+		SleepDays:          sleepDays,
+		WakeDays:           wakeDays,
+		Namespaces:         req.Namespaces,
+		Delays:             req.Delays,
+		ScheduleName:       req.ScheduleName,
+		Description:        req.Description,
+		Inclusions:         req.Inclusions,
+		Holidays:           req.Holidays,
+		SleepReplicas:      req.SleepReplicas,
+		ExecuteOnce:        req.ExecuteOnce,
+		KarpenterNodePools: req.KarpenterNodePools,
 	}
 
 	if err := s.scheduleService.CreateSchedule(c.Request.Context(), serviceReq); err != nil {
@@ -376,6 +759,13 @@ func (s *Server) handleCreateSchedule(c *gin.Context) {
 			})
 			return
 		}
+		if k8serrors.IsForbidden(err) {
+			handleKubernetesError(c, err)
+			return
+		}
+		if respondScheduleWriteError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Success: false,
 			Error:   fmt.Sprintf("Failed to create schedule: %v", err),
@@ -393,15 +783,19 @@ func (s *Server) handleCreateSchedule(c *gin.Context) {
 // UpdateScheduleRequest represents a request to update a schedule
 // @Description Request to update an existing sleep/wake schedule for a tenant (all fields optional)
 type UpdateScheduleRequest struct {
-	Off           string   `json:"off,omitempty" example:"23:00"`             // Sleep time in local timezone (HH:MM format, 24-hour)
-	On            string   `json:"on,omitempty" example:"07:00"`              // Wake time in local timezone (HH:MM format, 24-hour)
-	Weekdays      string   `json:"weekdays,omitempty" example:"1-5"`          // Days of week (human format: "lunes-viernes", or numeric: "1-5")
-	SleepDays     string   `json:"sleepDays,omitempty" example:"viernes"`     // Optional: specific days for sleep (overrides weekdays)
-	WakeDays      string   `json:"wakeDays,omitempty" example:"lunes"`        // Optional: specific days for wake (overrides weekdays)
-	WeekdaysSleep string   `json:"weekdaysSleep,omitempty" example:"viernes"` // Frontend format: specific days for sleep (mapped to sleepDays)
-	WeekdaysWake  string   `json:"weekdaysWake,omitempty" example:"lunes"`    // Frontend format: specific days for wake (mapped to wakeDays)
-	Namespaces    []string `json:"namespaces,omitempty" example:"apps"`       // Optional: limit to specific namespaces
-	Apply         bool     `json:"apply,omitempty"`                           // Always applies to cluster (field is ignored)
+	Off           string                   `json:"off,omitempty" example:"23:00"`             // Sleep time in local timezone (HH:MM format, 24-hour)
+	On            string                   `json:"on,omitempty" example:"07:00"`              // Wake time in local timezone (HH:MM format, 24-hour)
+	DurationHours float64                  `json:"durationHours,omitempty" example:"8"`       // Optional: hours asleep after off, instead of on (e.g. 8 or 10.5)
+	Weekdays      string                   `json:"weekdays,omitempty" example:"1-5"`          // Days of week (human format: "lunes-viernes", or numeric: "1-5")
+	SleepDays     string                   `json:"sleepDays,omitempty" example:"viernes"`     // Optional: specific days for sleep (overrides weekdays)
+	WakeDays      string                   `json:"wakeDays,omitempty" example:"lunes"`        // Optional: specific days for wake (overrides weekdays)
+	WeekdaysSleep string                   `json:"weekdaysSleep,omitempty" example:"viernes"` // Frontend format: specific days for sleep (mapped to sleepDays)
+	WeekdaysWake  string                   `json:"weekdaysWake,omitempty" example:"lunes"`    // Frontend format: specific days for wake (mapped to wakeDays)
+	Namespaces    []string                 `json:"namespaces,omitempty" example:"apps"`       // Optional: limit to specific namespaces
+	Apply         bool                     `json:"apply,omitempty"`                           // Always applies to cluster (field is ignored)
+	Inclusions    []NamespaceInclusion     `json:"inclusions,omitempty"`                      // Optional: replaces the inclusions, kept when omitted
+	Holidays      *HolidayConfig           `json:"holidays,omitempty"`                        // Optional: replaces the holiday calendar, kept when omitted
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`                   // Optional: replaces the replicas kept during sleep, kept when omitted
 }
 
 // ManualScheduleRequest represents a manual sleep/wake action for a schedule
@@ -421,6 +815,7 @@ type SuspendScheduleRequest struct {
 
 // handleUpdateSchedule updates an existing schedule
 // @Summary Update a schedule
+// @ID updateSchedule
 // @Description Updates SleepInfo configurations for a tenant. Missing fields are extracted from existing schedule. At least 'off' or 'on' time must be provided.
 // @Tags Schedules
 // @Accept json
@@ -431,7 +826,7 @@ type SuspendScheduleRequest struct {
 // @Success 200 {object} APIResponse "Schedule updated successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request parameters"
 // @Failure 404 {object} ErrorResponse "Schedule not found"
-// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 500 {object} ScheduleWriteErrorResponse "Internal server error, with the rollback report when some SleepInfos were written"
 // @Router /api/v1/schedules/{tenant} [put]
 func (s *Server) handleUpdateSchedule(c *gin.Context) {
 	// Check permissions
@@ -490,13 +885,17 @@ func (s *Server) handleUpdateSchedule(c *gin.Context) {
 
 	// Convert UpdateScheduleRequest to CreateScheduleRequest
 	createReq := CreateScheduleRequest{
-		Tenant:     tenant,
-		Off:        req.Off,
-		On:         req.On,
-		Weekdays:   req.Weekdays,
-		SleepDays:  sleepDays,
-		WakeDays:   wakeDays,
-		Namespaces: req.Namespaces,
+		Tenant:        tenant,
+		Off:           req.Off,
+		On:            req.On,
+		DurationHours: req.DurationHours,
+		Weekdays:      req.Weekdays,
+		SleepDays:     sleepDays,
+		WakeDays:      wakeDays,
+		Namespaces:    req.Namespaces,
+		Inclusions:    req.Inclusions,
+		Holidays:      req.Holidays,
+		SleepReplicas: req.SleepReplicas,
 	}
 
 	// Verify schedule exists before updating
@@ -532,36 +931,378 @@ func (s *Server) handleUpdateSchedule(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
 				Success: false,
 				Error:   err.Error(),
-				Code:    http.StatusBadRequest,
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if !k8serrors.IsForbidden(err) && respondScheduleWriteError(c, err) {
+			return
+		}
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Schedule updated successfully for tenant %s", tenant),
+	})
+}
+
+// handleManualScheduleAction triggers a manual sleep/wake for a schedule
+// @Summary Manual sleep/wake action
+// @ID manualScheduleAction
+// @Description Triggers a manual sleep or wake operation without changing the schedule
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param request body ManualScheduleRequest true "Manual action payload"
+// @Success 200 {object} APIResponse "Manual action triggered successfully"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/manual [post]
+func (s *Server) handleManualScheduleAction(c *gin.Context) {
+	// Check permissions
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can trigger manual actions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tenant := c.Param("tenant")
+	if tenant == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "tenant parameter is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	var req ManualScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if req.Action == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "action is required (sleep|wake)",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := s.scheduleService.TriggerManualAction(c.Request.Context(), tenant, req.Action, req.ScheduleName, req.Namespace); err != nil {
+		s.logger.Error(err, "failed to trigger manual action", "tenant", tenant, "action", req.Action)
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to trigger manual action: %v", err),
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Manual action '%s' triggered successfully for tenant %s", req.Action, tenant),
+	})
+}
+
+// handleSleepNow puts a tenant to sleep immediately
+// @Summary Force sleep now
+// @ID sleepNow
+// @Description Immediately triggers the sleep operation for a tenant (or a single namespace) through the controller, using the same patches the scheduled sleep applies. Wake objects of staged pairs are not touched, so the next scheduled wake restores the services normally. The operation is recorded in status.lastManualOperation of each SleepInfo.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"apps"
+// @Param scheduleName query string false "Only SleepInfos belonging to this schedule name" example:"horario-laboral"
+// @Success 200 {object} APIResponse{data=ManualOperationResponse} "Sleep requested"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/sleep-now [post]
+func (s *Server) handleSleepNow(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can trigger manual actions",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tenant := c.Param("tenant")
+	if tenant == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "tenant parameter is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	namespaceFilter := c.Query("namespace")
+
+	result, err := s.scheduleService.SleepNow(c.Request.Context(), tenant, c.Query("scheduleName"), namespaceFilter)
+	if err != nil {
+		s.logger.Error(err, "failed to trigger sleep", "tenant", tenant, "namespace", namespaceFilter)
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Sleep triggered for tenant %s", tenant),
+		Data:    result,
+	})
+}
+
+// handleSnoozeSchedule delays the next sleep of a tenant
+// @Summary Snooze the next sleep
+// @ID snoozeSchedule
+// @Description Delays only the next scheduled sleep of a tenant (or a single namespace/schedule) by a duration such as "2h", without changing the recurring schedule. The controller skips the scheduled sleep, runs it once the snooze ends and clears the snooze. Snoozing again extends a pending snooze. The delayed sleep must happen before the next wake.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param request body SnoozeRequest true "Snooze duration and filters"
+// @Success 200 {object} APIResponse{data=SnoozeResponse} "Sleep snoozed"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/snooze [post]
+func (s *Server) handleSnoozeSchedule(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can snooze schedules",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tenant := c.Param("tenant")
+	var req SnoozeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	result, err := s.scheduleService.SnoozeSchedule(c.Request.Context(), tenant, req, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid duration") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to snooze schedule", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Next sleep of tenant %s snoozed by %s", tenant, result.Duration),
+		Data:    result,
+	})
+}
+
+// handleCreateWindowSchedule creates a one-time sleep between two dates
+// @Summary Create a one-time window
+// @ID createWindowSchedule
+// @Description Puts the selected namespaces of a tenant to sleep once between two dates, e.g. from 2024-12-24T18:00 to 2025-01-02T08:00, next to the recurring schedules. Dates are RFC3339 or YYYY-MM-DDTHH:MM in the user timezone. Resources are detected like for recurring schedules but all wake up at the end of the window, without staggering. The controller deletes the window SleepInfos once they have woken up.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param request body WindowScheduleRequest true "Window dates and namespaces"
+// @Success 201 {object} APIResponse{data=WindowScheduleResponse} "Window created"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 409 {object} ErrorResponse "Schedule name already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/windows [post]
+func (s *Server) handleCreateWindowSchedule(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can create schedules",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tenant := c.Param("tenant")
+	var req WindowScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	result, err := s.scheduleService.CreateWindowSchedule(c.Request.Context(), tenant, req, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		if strings.Contains(err.Error(), "already exists") {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusConflict,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to create window schedule", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Window created for tenant %s from %s to %s", tenant, result.Start.Format(time.RFC3339), result.End.Format(time.RFC3339)),
+		Data:    result,
+	})
+}
+
+// handleCloneSchedule copies the schedules of a tenant to other tenants and namespaces
+// @Summary Clone schedules
+// @ID cloneSchedule
+// @Description Copies the schedules of a tenant (or a single namespace/schedule) to a set of target tenants and namespaces: sleep and wake times, weekdays, staggered wake delays and custom exclusions. Exclusions detected automatically in the source namespace are re-detected in each target, and label values naming the source namespace or tenant are rewritten. Each target is created independently and reported in the results.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Source tenant name" example:"bdadevdat"
+// @Param request body CloneScheduleRequest true "Source filters and targets"
+// @Success 200 {object} APIResponse{data=CloneScheduleResponse} "Clone results per target namespace"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/clone [post]
+func (s *Server) handleCloneSchedule(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can clone schedules",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	tenant := c.Param("tenant")
+	var req CloneScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	result, err := s.scheduleService.CloneSchedule(c.Request.Context(), tenant, req)
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
 			})
 			return
 		}
+		s.logger.Error(err, "failed to clone schedule", "tenant", tenant)
 		handleKubernetesError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, APIResponse{
-		Success: true,
-		Message: fmt.Sprintf("Schedule updated successfully for tenant %s", tenant),
+		Success: result.Failed == 0,
+		Message: fmt.Sprintf("Schedules of tenant %s cloned: %d created, %d failed", tenant, result.Created, result.Failed),
+		Data:    result,
 	})
 }
 
-// handleManualScheduleAction triggers a manual sleep/wake for a schedule
-// @Summary Manual sleep/wake action
-// @Description Triggers a manual sleep or wake operation without changing the schedule
+// handleWakeNow wakes a tenant immediately
+// @Summary Force wake now
+// @ID wakeNow
+// @Description Immediately wakes all suspended resources of a tenant (or a single namespace), e.g. for an emergency debugging session at night. The controller restores the resources from the saved restore patches; staged datastores wakes keep their relative delays (Postgres/HDFS first, then PgBouncer, then Deployments). The last operation is recorded as WAKE_UP, so the next scheduled sleep still works.
 // @Tags Schedules
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param tenant path string true "Tenant name" example:"bdadevdat"
-// @Param request body ManualScheduleRequest true "Manual action payload"
-// @Success 200 {object} APIResponse "Manual action triggered successfully"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"datastores"
+// @Param scheduleName query string false "Only SleepInfos belonging to this schedule name" example:"horario-laboral"
+// @Success 200 {object} APIResponse{data=ManualOperationResponse} "Wake requested, with the time each stage will run"
 // @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
 // @Failure 404 {object} ErrorResponse "Schedule not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /api/v1/schedules/{tenant}/manual [post]
-func (s *Server) handleManualScheduleAction(c *gin.Context) {
-	// Check permissions
+// @Router /api/v1/schedules/{tenant}/wake-now [post]
+func (s *Server) handleWakeNow(c *gin.Context) {
 	role, exists := c.Get("role")
 	if !exists || !auth.CanCreateSchedule(role.(string)) {
 		c.JSON(http.StatusForbidden, ErrorResponse{
@@ -581,28 +1322,98 @@ func (s *Server) handleManualScheduleAction(c *gin.Context) {
 		})
 		return
 	}
+	namespaceFilter := c.Query("namespace")
 
-	var req ManualScheduleRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Success: false,
-			Error:   err.Error(),
-			Code:    http.StatusBadRequest,
-		})
+	result, err := s.scheduleService.WakeNow(c.Request.Context(), tenant, c.Query("scheduleName"), namespaceFilter)
+	if err != nil {
+		s.logger.Error(err, "failed to trigger wake", "tenant", tenant, "namespace", namespaceFilter)
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		handleKubernetesError(c, err)
 		return
 	}
 
-	if req.Action == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: fmt.Sprintf("Wake triggered for tenant %s", tenant),
+		Data:    result,
+	})
+}
+
+// handlePauseSchedule pauses a schedule without deleting it
+// @Summary Pause a schedule
+// @ID pauseSchedule
+// @Description Pauses sleep/wake for a tenant (optionally a single namespace or schedule) until it is resumed, e.g. during an incident, by setting spec.suspend on its SleepInfos. SleepInfos, delays, exclusions, annotations and restore data are kept intact. Manual actions still work while paused.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"apps"
+// @Param scheduleName query string false "Only SleepInfos belonging to this schedule name" example:"horario-laboral"
+// @Success 200 {object} APIResponse "Schedule paused successfully"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/pause [put]
+func (s *Server) handlePauseSchedule(c *gin.Context) {
+	s.setSchedulePaused(c, true)
+}
+
+// handleResumeSchedule resumes a paused schedule
+// @Summary Resume a paused schedule
+// @ID resumeSchedule
+// @Description Resumes sleep/wake for a tenant previously paused with PUT /api/v1/schedules/{tenant}/pause, clearing spec.suspend.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param namespace query string false "Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)" example:"apps"
+// @Param scheduleName query string false "Only SleepInfos belonging to this schedule name" example:"horario-laboral"
+// @Success 200 {object} APIResponse "Schedule resumed successfully"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Schedule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/resume [put]
+func (s *Server) handleResumeSchedule(c *gin.Context) {
+	s.setSchedulePaused(c, false)
+}
+
+func (s *Server) setSchedulePaused(c *gin.Context, paused bool) {
+	action := "resume"
+	if paused {
+		action = "pause"
+	}
+
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
 			Success: false,
-			Error:   "action is required (sleep|wake)",
-			Code:    http.StatusBadRequest,
+			Error:   fmt.Sprintf("Insufficient permissions. Only admin and operacion roles can %s schedules", action),
+			Code:    http.StatusForbidden,
 		})
 		return
 	}
 
-	if err := s.scheduleService.TriggerManualAction(c.Request.Context(), tenant, req.Action, req.ScheduleName, req.Namespace); err != nil {
-		s.logger.Error(err, "failed to trigger manual action", "tenant", tenant, "action", req.Action)
+	tenant := c.Param("tenant")
+	namespaceFilter := c.Query("namespace")
+	scheduleName := c.Query("scheduleName")
+
+	var err error
+	if paused {
+		err = s.scheduleService.PauseSchedule(c.Request.Context(), tenant, scheduleName, namespaceFilter)
+	} else {
+		err = s.scheduleService.ResumeSchedule(c.Request.Context(), tenant, scheduleName, namespaceFilter)
+	}
+	if err != nil {
+		s.logger.Error(err, "failed to "+action+" schedule", "tenant", tenant, "namespace", namespaceFilter)
 		if strings.Contains(err.Error(), "no schedules found") {
 			c.JSON(http.StatusNotFound, ErrorResponse{
 				Success: false,
@@ -611,22 +1422,23 @@ func (s *Server) handleManualScheduleAction(c *gin.Context) {
 			})
 			return
 		}
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Success: false,
-			Error:   fmt.Sprintf("Failed to trigger manual action: %v", err),
-			Code:    http.StatusInternalServerError,
-		})
+		handleKubernetesError(c, err)
 		return
 	}
 
+	message := fmt.Sprintf("Schedule resumed for tenant %s", tenant)
+	if paused {
+		message = fmt.Sprintf("Schedule paused for tenant %s", tenant)
+	}
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
-		Message: fmt.Sprintf("Manual action '%s' triggered successfully for tenant %s", req.Action, tenant),
+		Message: message,
 	})
 }
 
 // handleSuspendSchedule temporarily suspends a cron schedule until a specified date/time.
 // @Summary Suspend a schedule temporarily
+// @ID suspendSchedule
 // @Description Sets spec.suspendScheduleUntil on matching SleepInfos. Cron sleep/wake triggers are skipped until the deadline. Manual actions still work.
 // @Tags Schedules
 // @Accept json
@@ -716,6 +1528,7 @@ func (s *Server) handleSuspendSchedule(c *gin.Context) {
 
 // handleUnsuspendSchedule removes a temporary suspension from a schedule.
 // @Summary Remove schedule suspension
+// @ID unsuspendSchedule
 // @Description Clears spec.suspendScheduleUntil on matching SleepInfos, resuming normal cron execution immediately.
 // @Tags Schedules
 // @Accept json
@@ -780,6 +1593,7 @@ func (s *Server) handleUnsuspendSchedule(c *gin.Context) {
 
 // handleDeleteSchedule deletes a schedule
 // @Summary Delete a schedule
+// @ID deleteSchedule
 // @Description Deletes SleepInfo configurations and associated secrets for a tenant. Optional filters: namespace, scheduleName.
 // @Tags Schedules
 // @Accept json
@@ -866,9 +1680,76 @@ func (s *Server) handleDeleteSchedule(c *gin.Context) {
 	})
 }
 
+// handleBulkDeleteSchedules deletes the schedules of several tenants or the SleepInfos matching selectors
+// @Summary Bulk delete schedules
+// @ID bulkDeleteSchedules
+// @Description Deletes the schedules of several tenants (tenants=a,b) or the SleepInfos matching a label and/or annotation selector, concurrently. Optional filters: namespace, scheduleName, and tenants when a selector is set. At least one of tenants, labelSelector or annotationSelector is required. Returns the result of every tenant (tenants only) or SleepInfo (selectors).
+// @Tags Schedules
+// @Produce json
+// @Security BearerAuth
+// @Param tenants query string false "Comma separated tenants" example:"bdadevdat,bdadevprs"
+// @Param namespace query string false "Namespace suffix (optional)" example:"apps"
+// @Param scheduleName query string false "Schedule name (optional)" example:"apagado-tenant-bdaqa"
+// @Param labelSelector query string false "SleepInfo label selector" example:"team=data"
+// @Param annotationSelector query string false "SleepInfo annotation selector" example:"kube-green.stratio.com/schedule-name=night"
+// @Success 200 {object} APIResponse{data=BulkDeleteResponse} "Per item results"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules [delete]
+func (s *Server) handleBulkDeleteSchedules(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanDeleteSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can delete schedules",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	req := BulkDeleteRequest{
+		Tenants:            ParseCSV(c.Query("tenants")),
+		Namespace:          c.Query("namespace"),
+		ScheduleName:       c.Query("scheduleName"),
+		LabelSelector:      c.Query("labelSelector"),
+		AnnotationSelector: c.Query("annotationSelector"),
+	}
+	if len(req.Tenants) == 0 && req.LabelSelector == "" && req.AnnotationSelector == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "tenants, labelSelector or annotationSelector query parameter is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	result, err := s.scheduleService.BulkDeleteSchedules(c.Request.Context(), req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid labelSelector") || strings.Contains(err.Error(), "invalid annotationSelector") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to bulk delete schedules", "tenants", req.Tenants)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: result.Failed == 0,
+		Message: fmt.Sprintf("Bulk delete finished: %d deleted, %d not found, %d failed", result.Deleted, result.NotFound, result.Failed),
+		Data:    result,
+	})
+}
+
 // handleGetSuspendedServices gets currently suspended services for a tenant
 // @Summary Get suspended services for tenant
-// @Description Returns all currently suspended services (Deployments, StatefulSets, CronJobs) for a specific tenant
+// @ID getSuspendedServices
+// @Description Returns the services (Deployments, StatefulSets, CronJobs and managed CRDs) of a tenant that are actually asleep: resources with restore data in the SleepInfo secrets whose live state still differs from it. Each entry reports since when it is asleep, the reason (scheduled or manual sleep) and when it will wake according to the paired wake SleepInfo
 // @Tags Schedules
 // @Accept json
 // @Produce json
@@ -913,13 +1794,16 @@ func (s *Server) handleGetSuspendedServices(c *gin.Context) {
 
 // handleGetNextOperation gets the next scheduled operation for a tenant
 // @Summary Get next scheduled operation for tenant
-// @Description Returns the next scheduled sleep or wake operation for a specific tenant
+// @ID getNextOperation
+// @Description Returns the next scheduled sleep or wake operation for a specific tenant. When count is set, returns instead the next count concrete executions of every sleep and wake operation (including each staggered wake step of datastores) in chronological order, in both cluster (UTC) and user timezone, so a UI can render a calendar.
 // @Tags Schedules
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param count query int false "Number of occurrences per operation (1-50). Switches the response to NextOccurrencesResponse" example:"5"
 // @Success 200 {object} APIResponse{data=NextOperationResponse} "Next operation information"
+// @Success 200 {object} APIResponse{data=NextOccurrencesResponse} "Next occurrences (when count is set)"
 // @Failure 400 {object} ErrorResponse "Invalid request parameters"
 // @Failure 404 {object} ErrorResponse "Tenant not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -935,6 +1819,37 @@ func (s *Server) handleGetNextOperation(c *gin.Context) {
 		return
 	}
 
+	if countStr := c.Query("count"); countStr != "" {
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count < 1 || count > MaxOccurrencesCount {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   fmt.Sprintf("count must be an integer between 1 and %d", MaxOccurrencesCount),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		occurrences, err := s.scheduleService.GetNextOccurrences(c.Request.Context(), tenant, count, time.Now())
+		if err != nil {
+			if strings.Contains(err.Error(), "no schedules found") {
+				c.JSON(http.StatusNotFound, ErrorResponse{
+					Success: false,
+					Error:   err.Error(),
+					Code:    http.StatusNotFound,
+				})
+				return
+			}
+			s.logger.Error(err, "failed to get next occurrences", "tenant", tenant)
+			handleKubernetesError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, APIResponse{
+			Success: true,
+			Data:    occurrences,
+		})
+		return
+	}
+
 	nextOp, err := s.scheduleService.GetNextOperation(c.Request.Context(), tenant)
 	if err != nil {
 		if strings.Contains(err.Error(), "no schedules found") {
@@ -956,8 +1871,131 @@ func (s *Server) handleGetNextOperation(c *gin.Context) {
 	})
 }
 
+// handleGetSchedulePreview previews the operations planned for a tenant in the next days
+// @Summary Preview the next days of a tenant schedule
+// @ID getSchedulePreview
+// @Description Returns every sleep and wake operation planned for the tenant, or one of its namespaces, in the next days in chronological order, including each wake stage and each staggered wake step, in both cluster (UTC) and user timezone, so complex schedules can be checked before they fire. Paused SleepInfos are skipped, suspended ones start after the suspension deadline and holiday policies are not applied.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param days query int false "Number of days previewed (1-31, default 7)" example:"7"
+// @Param namespace query string false "Namespace suffix to preview" example:"datastores"
+// @Success 200 {object} APIResponse{data=SchedulePreviewResponse} "Planned operations"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} ErrorResponse "Tenant not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/preview [get]
+func (s *Server) handleGetSchedulePreview(c *gin.Context) {
+	tenant := c.Param("tenant")
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > MaxPreviewDays {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   fmt.Sprintf("days must be an integer between 1 and %d", MaxPreviewDays),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	preview, err := s.scheduleService.PreviewSchedule(c.Request.Context(), tenant, c.Query("namespace"), days, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to preview schedule", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    preview,
+	})
+}
+
+// handleGetSavings estimates the resources saved by a tenant schedule
+// @Summary Estimate schedule savings
+// @ID getSavings
+// @Description Estimates the CPU core-hours and memory GiB-hours saved during the next week: requested CPU/memory of the Deployments and StatefulSets covered by each namespace SleepInfos (exclusions honored, workloads asleep counted with their replicas before sleep) multiplied by the weekly sleep hours of the schedule. Paused SleepInfos are ignored. The cost is returned when a price is configured on the server or given in the query.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant path string true "Tenant name" example:"bdadevdat"
+// @Param pricePerCoreHour query number false "Price of one CPU core per hour (overrides the server default)" example:"0.04"
+// @Param pricePerGBHour query number false "Price of one GiB of memory per hour (overrides the server default)" example:"0.005"
+// @Param currency query string false "Currency code shown in the response" example:"USD"
+// @Success 200 {object} APIResponse{data=SavingsResponse} "Savings estimation"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 404 {object} ErrorResponse "Tenant not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/{tenant}/savings [get]
+func (s *Server) handleGetSavings(c *gin.Context) {
+	tenant := c.Param("tenant")
+	if tenant == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   "tenant parameter is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	pricing := s.savingsPricing
+	for param, target := range map[string]*float64{
+		"pricePerCoreHour": &pricing.PerCoreHour,
+		"pricePerGBHour":   &pricing.PerGBHour,
+	} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil || price < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Success: false,
+				Error:   fmt.Sprintf("%s must be a non-negative number", param),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+		*target = price
+	}
+	if currency := c.Query("currency"); currency != "" {
+		pricing.Currency = currency
+	}
+
+	savings, err := s.scheduleService.GetSavings(c.Request.Context(), tenant, pricing, time.Now())
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to estimate savings", "tenant", tenant)
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    savings,
+	})
+}
+
 // handleGetAllSuspendedServices gets suspended services for all tenants
 // @Summary Get all suspended services
+// @ID getAllSuspendedServices
 // @Description Returns all currently suspended services across all tenants
 // @Tags Schedules
 // @Accept json
@@ -982,6 +2020,7 @@ func (s *Server) handleGetAllSuspendedServices(c *gin.Context) {
 
 // handleGetAllNextOperations gets the next operation across all tenants
 // @Summary Get next operation across all tenants
+// @ID getAllNextOperations
 // @Description Returns the earliest next scheduled operation across all tenants
 // @Tags Schedules
 // @Accept json
@@ -1013,8 +2052,35 @@ func (s *Server) handleGetAllNextOperations(c *gin.Context) {
 	})
 }
 
+// handleGetDriftedSleepInfos lists the SleepInfos changed since the API applied them
+// @Summary Get drifted SleepInfos
+// @ID getDriftedSleepInfos
+// @Description Returns the SleepInfos whose times, weekdays or timezone were changed since the API applied them, e.g. by kubectl edit, from the last background check (every 5 minutes). Re-sync them with PUT /api/v1/schedules/{tenant}, or adopt the manual change by sending its values.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenant query string false "Tenant filter" example:"bdadevdat"
+// @Success 200 {object} APIResponse{data=DriftReport} "Drifted SleepInfos"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedules/drifted [get]
+func (s *Server) handleGetDriftedSleepInfos(c *gin.Context) {
+	report, err := s.scheduleService.DriftedSleepInfos(c.Request.Context(), c.Query("tenant"))
+	if err != nil {
+		s.logger.Error(err, "failed to get drifted SleepInfos")
+		handleKubernetesError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
 // handleListUsers lists all users (admin only)
 // @Summary List all users
+// @ID listUsers
 // @Description Lists all users in the system. Requires admin role.
 // @Tags Users
 // @Accept json
@@ -1079,6 +2145,7 @@ type CreateUserRequest struct {
 
 // handleCreateUser creates a new user (admin only)
 // @Summary Create a new user
+// @ID createUser
 // @Description Creates a new user with a specified username, password, and role. Requires admin role.
 // @Tags Users
 // @Accept json
@@ -1157,6 +2224,7 @@ type UpdatePasswordRequest struct {
 
 // handleUpdateUserPassword updates a user's password (admin only)
 // @Summary Update user password
+// @ID updateUserPassword
 // @Description Updates a user's password. Requires admin role.
 // @Tags Users
 // @Accept json
@@ -1245,6 +2313,7 @@ type UpdateRoleRequest struct {
 
 // handleUpdateUserRole updates a user's role (admin only)
 // @Summary Update user role
+// @ID updateUserRole
 // @Description Updates a user's role. Requires admin role.
 // @Tags Users
 // @Accept json
@@ -1328,6 +2397,7 @@ func (s *Server) handleUpdateUserRole(c *gin.Context) {
 
 // handleDeleteUser deletes a user (admin only)
 // @Summary Delete user
+// @ID deleteUser
 // @Description Deletes a user from the system. Requires admin role.
 // @Tags Users
 // @Accept json
@@ -1409,6 +2479,22 @@ func (s *Server) handleDeleteUser(c *gin.Context) {
 	})
 }
 
+// respondScheduleWriteError responds a schedule write rolled back after a partial failure with its report.
+// It returns false when err is not a *ScheduleWriteError.
+func respondScheduleWriteError(c *gin.Context, err error) bool {
+	var writeErr *ScheduleWriteError
+	if !errors.As(err, &writeErr) {
+		return false
+	}
+	c.JSON(http.StatusInternalServerError, ScheduleWriteErrorResponse{
+		Success: false,
+		Error:   err.Error(),
+		Code:    http.StatusInternalServerError,
+		Report:  writeErr.Report,
+	})
+	return true
+}
+
 // handleKubernetesError converts Kubernetes API errors to HTTP responses
 func handleKubernetesError(c *gin.Context, err error) {
 	if k8serrors.IsNotFound(err) {
@@ -1429,6 +2515,16 @@ func handleKubernetesError(c *gin.Context, err error) {
 		return
 	}
 
+	// Denied by the Kubernetes RBAC of the impersonated user
+	if k8serrors.IsForbidden(err) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
 	// Generic error
 	c.JSON(http.StatusInternalServerError, ErrorResponse{
 		Success: false,
@@ -1437,9 +2533,14 @@ func handleKubernetesError(c *gin.Context, err error) {
 	})
 }
 
-// ExclusionFilter represents a filter for excluding resources
+// ExclusionFilter represents a filter for excluding resources, by name (apiVersion, kind and name),
+// by labels or by label expressions. Every label and expression excludes the resources it matches.
 type ExclusionFilter struct {
-	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	APIVersion       string                            `json:"apiVersion,omitempty" example:"apps/v1"`
+	Kind             string                            `json:"kind,omitempty" example:"Deployment"`
+	Name             string                            `json:"name,omitempty" example:"api-gateway"`
+	MatchLabels      map[string]string                 `json:"matchLabels,omitempty"`
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"` // In, NotIn, Exists or DoesNotExist
 }
 
 // DelayConfig represents delay configuration for staged wake-up
@@ -1451,17 +2552,21 @@ type DelayConfig struct {
 
 // NamespaceScheduleRequest represents a request to create/update a schedule for a specific namespace
 type NamespaceScheduleRequest struct {
-	Tenant        string               `json:"tenant" binding:"required"`
-	Namespace     string               `json:"namespace" binding:"required"`
-	Off           string               `json:"off" binding:"required"`
-	On            string               `json:"on" binding:"required"`
-	Weekdays      string               `json:"weekdays,omitempty"`
-	WeekdaysSleep string               `json:"weekdaysSleep,omitempty"`
-	WeekdaysWake  string               `json:"weekdaysWake,omitempty"`
-	ScheduleName  string               `json:"scheduleName,omitempty"`
-	Description   string               `json:"description,omitempty"`
-	Delays        *DelayConfig         `json:"delays,omitempty"`
-	Exclusions    []NamespaceExclusion `json:"exclusions,omitempty"`
+	Tenant        string                   `json:"tenant" binding:"required"`
+	Namespace     string                   `json:"namespace" binding:"required"`
+	Off           string                   `json:"off" binding:"required"`
+	On            string                   `json:"on" binding:"required"`
+	Weekdays      string                   `json:"weekdays,omitempty"`
+	WeekdaysSleep string                   `json:"weekdaysSleep,omitempty"`
+	WeekdaysWake  string                   `json:"weekdaysWake,omitempty"`
+	ScheduleName  string                   `json:"scheduleName,omitempty"`
+	Description   string                   `json:"description,omitempty"`
+	Delays        *DelayConfig             `json:"delays,omitempty"`
+	Exclusions    []NamespaceExclusion     `json:"exclusions,omitempty"`
+	Inclusions    []NamespaceInclusion     `json:"inclusions,omitempty"`
+	Holidays      *HolidayConfig           `json:"holidays,omitempty"`
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`
+	SuspendFlink  bool                     `json:"suspendFlink,omitempty"` // Suspends the Flink jobs with a savepoint during sleep
 }
 
 // NamespaceExclusion represents an exclusion for a specific namespace
@@ -1470,8 +2575,16 @@ type NamespaceExclusion struct {
 	Filter    ExclusionFilter `json:"filter"`
 }
 
+// NamespaceInclusion limits the resources of a specific namespace put to sleep to the ones matching the filter.
+// The inclusions of a namespace are combined: resources must match all of them.
+type NamespaceInclusion struct {
+	Namespace string          `json:"namespace"`
+	Filter    ExclusionFilter `json:"filter"`
+}
+
 // handleGetUIConfig returns UI configuration for the frontend (env name, color, etc.)
 // @Summary Get UI configuration
+// @ID getUIConfig
 // @Description Returns environment-specific UI configuration (colors, labels) read from env vars ENV_NAME, ENV_COLOR, ENV_LABEL
 // @Tags config
 // @Produce json
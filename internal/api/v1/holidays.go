@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// HolidayConfig attaches a holiday calendar to the SleepInfos of a schedule
+type HolidayConfig struct {
+	ConfigMap          string `json:"configMap,omitempty" example:"festivos"`                            // ConfigMap with one YYYY-MM-DD date per line in its "holidays" key
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty" example:"kube-green"`                 // Namespace of the ConfigMap, the namespace of each SleepInfo if empty
+	URL                string `json:"url,omitempty" example:"https://calendar.example.com/festivos.ics"` // iCalendar (ICS) feed, instead of the ConfigMap
+	TimeZone           string `json:"timeZone,omitempty" example:"America/Bogota"`                       // Time zone of the holiday dates, the user timezone if empty
+	Policy             string `json:"policy" example:"forceSleep" enums:"ignore,skipSleep,forceSleep"`   // skipSleep keeps the namespaces awake on holidays, forceSleep keeps them asleep
+}
+
+// validate checks the calendar has exactly one source and a known policy
+func (h *HolidayConfig) validate() error {
+	if h == nil {
+		return nil
+	}
+	switch kubegreenv1alpha1.HolidayPolicy(h.Policy) {
+	case kubegreenv1alpha1.HolidayPolicyIgnore, kubegreenv1alpha1.HolidayPolicySkipSleep, kubegreenv1alpha1.HolidayPolicyForceSleep:
+	default:
+		return fmt.Errorf("invalid holidays policy %q: must be one of ignore, skipSleep, forceSleep", h.Policy)
+	}
+	if (h.ConfigMap == "") == (h.URL == "") {
+		return fmt.Errorf("invalid holidays: set one of configMap or url")
+	}
+	if h.TimeZone != "" {
+		if _, err := time.LoadLocation(h.TimeZone); err != nil {
+			return fmt.Errorf("invalid holidays timezone %s: %w", h.TimeZone, err)
+		}
+	}
+	return nil
+}
+
+// apply sets the holiday calendar and policy of a SleepInfo; the dates are in userTimezone unless
+// the calendar sets its own
+func (h *HolidayConfig) apply(spec *kubegreenv1alpha1.SleepInfoSpec, userTimezone string) {
+	if h == nil {
+		return
+	}
+	timeZone := h.TimeZone
+	if timeZone == "" {
+		timeZone = userTimezone
+	}
+	spec.HolidayCalendar = &kubegreenv1alpha1.HolidayCalendar{
+		ConfigMap:          h.ConfigMap,
+		ConfigMapNamespace: h.ConfigMapNamespace,
+		URL:                h.URL,
+		TimeZone:           timeZone,
+	}
+	spec.HolidayPolicy = kubegreenv1alpha1.HolidayPolicy(h.Policy)
+}
+
+// holidaysOf returns the holiday calendar of si, nil when it has none
+func holidaysOf(si kubegreenv1alpha1.SleepInfo) *HolidayConfig {
+	calendar := si.Spec.HolidayCalendar
+	if calendar == nil {
+		return nil
+	}
+	return &HolidayConfig{
+		ConfigMap:          calendar.ConfigMap,
+		ConfigMapNamespace: calendar.ConfigMapNamespace,
+		URL:                calendar.URL,
+		TimeZone:           calendar.TimeZone,
+		Policy:             string(si.GetHolidayPolicy()),
+	}
+}
+
+// existingHolidays returns the holiday calendar of the SleepInfos of a schedule, so an update keeps it
+func existingHolidays(schedule *ScheduleResponse) *HolidayConfig {
+	for _, nsInfo := range schedule.Namespaces {
+		for _, sched := range nsInfo.Schedule {
+			if sched.Holidays != nil {
+				return sched.Holidays
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale is the language of the human-readable texts returned by the API
+type Locale string
+
+const (
+	// LocaleES is Spanish, the default locale
+	LocaleES Locale = "es"
+	// LocaleEN is English
+	LocaleEN Locale = "en"
+
+	// DefaultLocale is used when the client does not ask for a supported language
+	DefaultLocale = LocaleES
+)
+
+type localeContextKey struct{}
+
+// messages holds the translations of every human-readable text, indexed by locale and key
+var messages = map[Locale]map[string]string{
+	LocaleES: {
+		"action.sleep": "Apagar",
+		"action.wake":  "Encender",
+		"services":     "servicios",
+		"allServices":  "Todos los servicios",
+		"at":           "a las",
+		"and":          "y",
+		"noSchedule":   "Sin programación configurada",
+	},
+	LocaleEN: {
+		"action.sleep": "Stop",
+		"action.wake":  "Start",
+		"services":     "services",
+		"allServices":  "All services",
+		"at":           "at",
+		"and":          "and",
+		"noSchedule":   "No schedule configured",
+	},
+}
+
+// T returns the text of key in the given locale, falling back to the default locale
+func T(locale Locale, key string) string {
+	if msg, ok := messages[locale][key]; ok {
+		return msg
+	}
+	return messages[DefaultLocale][key]
+}
+
+// ParseLocale resolves the locale from an explicit lang value or an Accept-Language header,
+// e.g. "en-US,en;q=0.9,es;q=0.8". The first supported language wins.
+func ParseLocale(lang, acceptLanguage string) Locale {
+	candidates := []string{lang}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		candidates = append(candidates, strings.SplitN(part, ";", 2)[0])
+	}
+	for _, candidate := range candidates {
+		base := strings.ToLower(strings.TrimSpace(candidate))
+		if idx := strings.IndexAny(base, "-_"); idx > 0 {
+			base = base[:idx]
+		}
+		if _, ok := messages[Locale(base)]; ok {
+			return Locale(base)
+		}
+	}
+	return DefaultLocale
+}
+
+// WithLocale returns a copy of ctx carrying the locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored in ctx, or the default locale
+func LocaleFromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeContextKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// localeMiddleware stores the locale requested with ?lang= or Accept-Language in the request context
+func localeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := ParseLocale(c.Query("lang"), c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", string(locale))
+		c.Next()
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImpersonationConfig makes the REST API write SleepInfos and their secrets as the authenticated user
+// instead of its ServiceAccount, so Kubernetes RBAC authorizes every change and the Kubernetes audit
+// log names the real person. It needs authentication enabled, and the ServiceAccount needs the
+// impersonate verb on users and groups.
+type ImpersonationConfig struct {
+	RestConfig  *rest.Config    // config of the ServiceAccount, impersonation is disabled when nil
+	Scheme      *runtime.Scheme // scheme of the impersonated clients
+	Mapper      meta.RESTMapper // optional shared RESTMapper, avoids a discovery per impersonated user
+	UserPrefix  string          // prepended to the username, e.g. "kube-green:" impersonates kube-green:alice
+	GroupPrefix string          // the role is impersonated as the group {GroupPrefix}{role}; empty sends no role group
+	Groups      []string        // groups added to every impersonated user
+}
+
+// Enabled returns whether writes are impersonated
+func (c ImpersonationConfig) Enabled() bool {
+	return c.RestConfig != nil
+}
+
+// identity returns the Kubernetes user and groups impersonated for an API user
+func (c ImpersonationConfig) identity(user apiUser) rest.ImpersonationConfig {
+	groups := append([]string{}, c.Groups...)
+	if c.GroupPrefix != "" && user.role != "" {
+		groups = append(groups, c.GroupPrefix+user.role)
+	}
+	return rest.ImpersonationConfig{UserName: c.UserPrefix + user.username, Groups: groups}
+}
+
+type apiUserKey struct{}
+
+// apiUser is the authenticated user of a request
+type apiUser struct {
+	username string
+	role     string
+}
+
+// WithUser stores the authenticated user of a call in ctx, where the impersonating client reads it
+func WithUser(ctx context.Context, username, role string) context.Context {
+	if username == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, apiUserKey{}, apiUser{username: username, role: role})
+}
+
+// impersonationMiddleware stores the authenticated user in the request context, where the
+// impersonating client reads it. It must run after the JWT middleware.
+func impersonationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithUser(c.Request.Context(), c.GetString("username"), c.GetString("role")))
+		c.Next()
+	}
+}
+
+// impersonatingClient sends the writes of a request as its authenticated user, status and other
+// subresource writes included. Reads, and writes without an authenticated user, use the
+// ServiceAccount client.
+type impersonatingClient struct {
+	client.Client
+	config ImpersonationConfig
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+func newImpersonatingClient(c client.Client, config ImpersonationConfig) *impersonatingClient {
+	return &impersonatingClient{Client: c, config: config, clients: map[string]client.Client{}}
+}
+
+// writer returns the client impersonating the user of ctx, created once per user and role
+func (c *impersonatingClient) writer(ctx context.Context) (client.Client, error) {
+	user, ok := ctx.Value(apiUserKey{}).(apiUser)
+	if !ok {
+		return c.Client, nil
+	}
+	identity := c.config.identity(user)
+	key := identity.UserName + "|" + strings.Join(identity.Groups, ",")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cl, ok := c.clients[key]; ok {
+		return cl, nil
+	}
+	restConfig := rest.CopyConfig(c.config.RestConfig)
+	restConfig.Impersonate = identity
+	cl, err := client.New(restConfig, client.Options{Scheme: c.config.Scheme, Mapper: c.config.Mapper})
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate %s: %w", identity.UserName, err)
+	}
+	c.clients[key] = cl
+	return cl, nil
+}
+
+func (c *impersonatingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.Create(ctx, obj, opts...)
+}
+
+func (c *impersonatingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.Update(ctx, obj, opts...)
+}
+
+func (c *impersonatingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *impersonatingClient) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.Apply(ctx, obj, opts...)
+}
+
+func (c *impersonatingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.Delete(ctx, obj, opts...)
+}
+
+func (c *impersonatingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	cl, err := c.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status returns a writer sending the status updates of a request as its authenticated user
+func (c *impersonatingClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource returns a client reading a subresource with the ServiceAccount and writing it as the
+// authenticated user of the request
+func (c *impersonatingClient) SubResource(subResource string) client.SubResourceClient {
+	return &impersonatingSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		client:            c,
+		subResource:       subResource,
+	}
+}
+
+type impersonatingSubResourceClient struct {
+	client.SubResourceClient
+	client      *impersonatingClient
+	subResource string
+}
+
+func (w *impersonatingSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	cl, err := w.client.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.SubResource(w.subResource).Create(ctx, obj, subResource, opts...)
+}
+
+func (w *impersonatingSubResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	cl, err := w.client.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.SubResource(w.subResource).Update(ctx, obj, opts...)
+}
+
+func (w *impersonatingSubResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	cl, err := w.client.writer(ctx)
+	if err != nil {
+		return err
+	}
+	return cl.SubResource(w.subResource).Patch(ctx, obj, patch, opts...)
+}
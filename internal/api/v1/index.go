@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ScheduleNameIndex is the field index of the schedule names a SleepInfo belongs to
+const ScheduleNameIndex = "kube-green.stratio.com/schedule-name"
+
+// TenantIndex is the field index of the tenant of the {tenant}-{suffix} namespace of a SleepInfo
+const TenantIndex = "kube-green.stratio.com/tenant"
+
+// SetupIndexes registers the field indexes used by the API on the manager cache.
+// It must be called before the manager is started.
+func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &kubegreenv1alpha1.SleepInfo{}, ScheduleNameIndex, scheduleNameIndexKeys); err != nil {
+		return fmt.Errorf("failed to index SleepInfos by schedule name: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &kubegreenv1alpha1.SleepInfo{}, TenantIndex, tenantIndexKeys); err != nil {
+		return fmt.Errorf("failed to index SleepInfos by tenant: %w", err)
+	}
+	return nil
+}
+
+// scheduleNameIndexKeys returns the ScheduleNameIndex keys of a SleepInfo
+func scheduleNameIndexKeys(obj client.Object) []string {
+	si, ok := obj.(*kubegreenv1alpha1.SleepInfo)
+	if !ok {
+		return nil
+	}
+	return scheduleNameKeys(*si)
+}
+
+// tenantIndexKeys returns the TenantIndex key of a SleepInfo, none when its namespace is not named {tenant}-{suffix}
+func tenantIndexKeys(obj client.Object) []string {
+	tenant, _, ok := SplitNamespaceName(obj.GetNamespace())
+	if !ok {
+		return nil
+	}
+	return []string{tenant}
+}
+
+// scheduleNameKeys returns every schedule name matchesScheduleName accepts for si
+func scheduleNameKeys(si kubegreenv1alpha1.SleepInfo) []string {
+	keys := []string{si.Name}
+	add := func(name string) {
+		if name != "" && !containsString(keys, name) {
+			keys = append(keys, name)
+		}
+	}
+	add(si.Annotations["kube-green.stratio.com/schedule-name"])
+	add(si.Annotations["kube-green.com/schedule-name"])
+	add(strings.TrimPrefix(strings.TrimPrefix(si.Name, "sleep-"), "wake-"))
+	return keys
+}
+
+// listSleepInfosByScheduleName returns the SleepInfos of every namespace belonging to scheduleName.
+// It uses the ScheduleNameIndex of the cache and falls back to a full list when the index is not registered.
+func (s *ScheduleService) listSleepInfosByScheduleName(ctx context.Context, scheduleName string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	err := s.client.List(ctx, sleepInfoList, client.MatchingFields{ScheduleNameIndex: scheduleName})
+	if err == nil {
+		return sleepInfoList.Items, nil
+	}
+	s.logger.Info("schedule name index not available, listing all SleepInfos", "reason", err.Error())
+
+	if err := s.reader.List(ctx, sleepInfoList); err != nil {
+		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+	}
+	sleepInfos := []kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfoList.Items {
+		if matchesScheduleName(si, scheduleName) {
+			sleepInfos = append(sleepInfos, si)
+		}
+	}
+	return sleepInfos, nil
+}
+
+// listCachedSleepInfos returns the SleepInfos of tenant, or of every namespace when tenant is empty, from the
+// manager cache. The SleepInfos of the {tenant}-{suffix} namespaces are read through the TenantIndex, the ones of
+// namespaces labelled with the tenant one namespace at a time. Callers still filter them with the resolver.
+func (s *ScheduleService) listCachedSleepInfos(ctx context.Context, resolver *namespaceResolver, tenant string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if tenant == "" {
+		if err := s.client.List(ctx, sleepInfoList); err != nil {
+			return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+		}
+		return sleepInfoList.Items, nil
+	}
+
+	if err := s.client.List(ctx, sleepInfoList, client.MatchingFields{TenantIndex: tenant}); err != nil {
+		s.logger.Info("tenant index not available, listing all SleepInfos", "reason", err.Error())
+		if err := s.client.List(ctx, sleepInfoList); err != nil {
+			return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+		}
+		return sleepInfoList.Items, nil
+	}
+	sleepInfos := sleepInfoList.Items
+	for _, namespace := range resolver.tenantNamespaces(tenant) {
+		if name, _, ok := SplitNamespaceName(namespace); ok && name == tenant {
+			continue // already listed through the index
+		}
+		namespaceList := &kubegreenv1alpha1.SleepInfoList{}
+		if err := s.client.List(ctx, namespaceList, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list SleepInfos in namespace %s: %w", namespace, err)
+		}
+		sleepInfos = append(sleepInfos, namespaceList.Items...)
+	}
+	return sleepInfos, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+func TestListCachedSleepInfos(t *testing.T) {
+	ctx := context.Background()
+	sleepInfo := func(namespace, name string) client.Object {
+		return &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	objects := []client.Object{
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "datastores", Labels: map[string]string{"stratio.com/tenant": "bda"}}},
+		sleepInfo("bda-apps", "laboral"),
+		sleepInfo("bda-rocket", "laboral"),
+		sleepInfo("bda-dev-apps", "laboral"),
+		sleepInfo("bdadev-apps", "laboral"),
+		sleepInfo("datastores", "laboral"),
+	}
+	names := func(sleepInfos []kubegreenv1alpha1.SleepInfo) []string {
+		keys := []string{}
+		for _, si := range sleepInfos {
+			keys = append(keys, si.Namespace+"/"+si.Name)
+		}
+		sort.Strings(keys)
+		return keys
+	}
+	newService := func(indexed bool) *ScheduleService {
+		builder := newTestClientBuilder(t).WithObjects(objects...)
+		if indexed {
+			builder = builder.WithIndex(&kubegreenv1alpha1.SleepInfo{}, TenantIndex, tenantIndexKeys)
+		}
+		service := NewScheduleService(builder.Build(), logr.Discard())
+		service.SetNamespaceLabels(NamespaceLabels{TenantLabel: "stratio.com/tenant"})
+		return service
+	}
+
+	t.Run("tenant SleepInfos are read through the index and the labelled namespaces", func(t *testing.T) {
+		service := newService(true)
+		resolver, err := service.newNamespaceResolver(ctx)
+		require.NoError(t, err)
+
+		sleepInfos, err := service.listCachedSleepInfos(ctx, resolver, "bda")
+		require.NoError(t, err)
+		require.Equal(t, []string{"bda-apps/laboral", "bda-rocket/laboral", "datastores/laboral"}, names(sleepInfos))
+
+		sleepInfos, err = service.listCachedSleepInfos(ctx, resolver, "")
+		require.NoError(t, err)
+		require.Len(t, sleepInfos, 5)
+	})
+
+	t.Run("every SleepInfo is listed without the index", func(t *testing.T) {
+		service := newService(false)
+		resolver, err := service.newNamespaceResolver(ctx)
+		require.NoError(t, err)
+
+		sleepInfos, err := service.listCachedSleepInfos(ctx, resolver, "bda")
+		require.NoError(t, err)
+		require.Len(t, sleepInfos, 5)
+
+		// Callers filter them by tenant with the resolver
+		tenantSleepInfos, err := service.listTenantSleepInfos(ctx, "bda", "", "")
+		require.NoError(t, err)
+		require.Equal(t, []string{"bda-apps/laboral", "bda-rocket/laboral", "datastores/laboral"}, names(tenantSleepInfos))
+	})
+}
+
+func TestScheduleNameIndexKeys(t *testing.T) {
+	si := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{
+		Name:        "sleep-laboral",
+		Annotations: map[string]string{"kube-green.stratio.com/schedule-name": "oficina"},
+	}}
+	require.Equal(t, []string{"sleep-laboral", "oficina", "laboral"}, scheduleNameIndexKeys(si))
+	require.Nil(t, scheduleNameIndexKeys(&v1.Namespace{}))
+	require.Nil(t, tenantIndexKeys(&kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Namespace: "standalone"}}))
+}
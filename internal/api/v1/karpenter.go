@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateKarpenterNodePools validates the names of the Karpenter NodePools of a schedule
+func validateKarpenterNodePools(nodePools []string) error {
+	for _, name := range nodePools {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid karpenterNodePools %q: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// setKarpenterNodePools scales the Karpenter NodePools to zero while the SleepInfos of a schedule
+// sleep. Every SleepInfo of the schedule restores them shortly before its wake up, so that the
+// capacity is provisioned for the first of its staggered wake ups.
+func (s *ScheduleService) setKarpenterNodePools(ctx context.Context, tenant string, namespaceSuffixes map[string]bool, scheduleName string, nodePools []string) error {
+	for suffix := range namespaceSuffixes {
+		namespace := fmt.Sprintf("%s-%s", tenant, suffix)
+		sleepInfos, err := s.listWrittenSleepInfos(ctx, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to list SleepInfos in %s: %w", namespace, err)
+		}
+		for i := range sleepInfos {
+			si := &sleepInfos[i]
+			if !matchesScheduleName(*si, scheduleName) {
+				continue
+			}
+			si.Spec.Karpenter = &kubegreenv1alpha1.Karpenter{NodePools: nodePools}
+			if err := s.client.Update(ctx, si); err != nil {
+				return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
+			}
+			s.logger.Info("SleepInfo set to scale Karpenter NodePools", "name", si.Name, "namespace", si.Namespace, "nodePools", nodePools)
+		}
+	}
+	return nil
+}
+
+// karpenterNodePoolsOf returns the Karpenter NodePools of a SleepInfo
+func karpenterNodePoolsOf(si kubegreenv1alpha1.SleepInfo) []string {
+	if si.Spec.Karpenter == nil {
+		return nil
+	}
+	return si.Spec.Karpenter.NodePools
+}
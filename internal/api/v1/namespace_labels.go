@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceLabels is the label convention identifying the tenant and suffix of a namespace.
+// Labelled namespaces are grouped by their labels, so tenants with hyphens in their names are
+// grouped correctly; namespaces without the tenant label fall back to the {tenant}-{suffix} name.
+type NamespaceLabels struct {
+	TenantLabel string // e.g. stratio.com/tenant, empty disables the labels
+	SuffixLabel string // e.g. stratio.com/role; when missing, the suffix is the name without the "{tenant}-" prefix
+}
+
+// Enabled reports whether namespaces are resolved through their labels
+func (l NamespaceLabels) Enabled() bool {
+	return l.TenantLabel != ""
+}
+
+// SetNamespaceLabels sets the label convention of the tenant namespaces
+func (s *ScheduleService) SetNamespaceLabels(labels NamespaceLabels) {
+	s.namespaceLabels = labels
+}
+
+// namespaceResolver maps namespace names to their tenant and suffix
+type namespaceResolver struct {
+	labelled map[string][2]string // namespace -> tenant, suffix, only namespaces with the tenant label
+}
+
+// newNamespaceResolver loads the labelled namespaces. Without a label convention every namespace
+// is resolved from its name.
+func (s *ScheduleService) newNamespaceResolver(ctx context.Context) (*namespaceResolver, error) {
+	resolver := &namespaceResolver{labelled: map[string][2]string{}}
+	if !s.namespaceLabels.Enabled() {
+		return resolver, nil
+	}
+	if tenantMap := s.tenants.Load(); tenantMap != nil {
+		if labelled, ok := tenantMap.labelled(); ok {
+			resolver.labelled = labelled
+			return resolver, nil
+		}
+	}
+	namespaceList := &v1.NamespaceList{}
+	if err := s.client.List(ctx, namespaceList, client.HasLabels{s.namespaceLabels.TenantLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, ns := range namespaceList.Items {
+		if tenant, suffix, ok := s.namespaceLabels.split(ns); ok {
+			resolver.labelled[ns.Name] = [2]string{tenant, suffix}
+		}
+	}
+	return resolver, nil
+}
+
+// split returns the tenant and suffix of a namespace carrying the tenant label
+func (l NamespaceLabels) split(ns v1.Namespace) (string, string, bool) {
+	tenant := ns.Labels[l.TenantLabel]
+	if tenant == "" {
+		return "", "", false
+	}
+	suffix := ""
+	if l.SuffixLabel != "" {
+		suffix = ns.Labels[l.SuffixLabel]
+	}
+	if suffix == "" {
+		suffix = strings.TrimPrefix(ns.Name, tenant+"-")
+	}
+	return tenant, suffix, true
+}
+
+// split returns the tenant and suffix of a namespace: its labels when labelled, otherwise
+// everything before the last hyphen of the name is the tenant
+func (r *namespaceResolver) split(namespace string) (string, string, bool) {
+	if entry, ok := r.labelled[namespace]; ok {
+		return entry[0], entry[1], true
+	}
+	return SplitNamespaceName(namespace)
+}
+
+// SplitNamespaceName returns the tenant and suffix of a namespace named {tenant}-{suffix}: everything
+// before the last hyphen is the tenant. Namespaces following a label convention are split by
+// the namespaceResolver instead.
+func SplitNamespaceName(namespace string) (string, string, bool) {
+	nsParts := strings.Split(namespace, "-")
+	if len(nsParts) < 2 {
+		return "", "", false
+	}
+	return strings.Join(nsParts[:len(nsParts)-1], "-"), nsParts[len(nsParts)-1], true
+}
+
+// tenantNamespaces returns the labelled namespaces of a tenant
+func (r *namespaceResolver) tenantNamespaces(tenant string) []string {
+	namespaces := []string{}
+	for namespace, entry := range r.labelled {
+		if entry[0] == tenant {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// namespaceTenant returns the tenant of a namespace following the label convention of the service,
+// empty when the namespace is not a tenant namespace
+func (s *ScheduleService) namespaceTenant(ctx context.Context, namespace string) string {
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		s.logger.Error(err, "Failed to resolve the tenant of a namespace from its labels", "namespace", namespace)
+		resolver = &namespaceResolver{}
+	}
+	tenant, _, _ := resolver.split(namespace)
+	return tenant
+}
+
+// suffix returns the suffix of a namespace, the whole name when it cannot be split
+func (r *namespaceResolver) suffix(namespace string) string {
+	if _, suffix, ok := r.split(namespace); ok {
+		return suffix
+	}
+	return namespace
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+func TestNamespaceTenant(t *testing.T) {
+	labelled := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "bda-dev-datastores",
+		Labels: map[string]string{"stratio.com/tenant": "bda-dev", "stratio.com/role": "datastores"},
+	}}
+	service, _ := newTestService(t, labelled)
+
+	t.Run("names split on the last hyphen without a label convention", func(t *testing.T) {
+		require.Equal(t, "bda-dev", service.namespaceTenant(context.Background(), "bda-dev-datastores"))
+		require.Equal(t, "", service.namespaceTenant(context.Background(), "standalone"))
+	})
+
+	service.SetNamespaceLabels(NamespaceLabels{TenantLabel: "stratio.com/tenant", SuffixLabel: "stratio.com/role"})
+
+	t.Run("labelled namespaces use their tenant label", func(t *testing.T) {
+		require.Equal(t, "bda-dev", service.namespaceTenant(context.Background(), "bda-dev-datastores"))
+	})
+
+	t.Run("schedule events carry the tenant of the label convention", func(t *testing.T) {
+		labelled.Labels["stratio.com/tenant"] = "bda"
+		service, _ := newTestService(t, labelled)
+		service.SetNamespaceLabels(NamespaceLabels{TenantLabel: "stratio.com/tenant"})
+		tenantOf := func(namespace string) string {
+			return service.namespaceTenant(context.Background(), namespace)
+		}
+
+		si := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "bda-dev-datastores"}}
+		require.Equal(t, "bda", newScheduleEvent(ScheduleEventCreated, si, tenantOf).Tenant)
+		si.Namespace = "other-apps"
+		require.Equal(t, "other", newScheduleEvent(ScheduleEventCreated, si, tenantOf).Tenant)
+	})
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// NamespacePoliciesKey is the ConfigMap key holding the namespace policies
+const NamespacePoliciesKey = "policies.yaml"
+
+// NamespacePolicy describes how schedules are created in the namespaces with a suffix.
+// Unset behaviors keep the resource detection: StatefulSets are suspended when the namespace has
+// StatefulSets, and the wake is staggered when operator CRDs (Postgres, HDFS, PgBouncer, ...) are found.
+type NamespacePolicy struct {
+	Suffix              string            `json:"suffix" example:"datastores"`
+	Description         string            `json:"description,omitempty"`
+	SuspendStatefulSets *bool             `json:"suspendStatefulSets,omitempty"` // Overrides the StatefulSets detection
+	StaggeredWake       *bool             `json:"staggeredWake,omitempty"`       // Overrides the CRD detection
+	DefaultExclusions   []ExclusionFilter `json:"defaultExclusions,omitempty"`   // Added to every schedule of the suffix
+}
+
+// NamespacePolicySource is where the namespace suffix catalogue is loaded from. Policies of the
+// ConfigMap override the static ones, which override the built-in suffixes.
+type NamespacePolicySource struct {
+	Policies  []NamespacePolicy // Static policies, e.g. from the --api-namespace-policies file
+	ConfigMap string            // Optional ConfigMap with a policies.yaml key, read on every use
+	Namespace string            // Namespace of the ConfigMap
+}
+
+// NamespacePolicyListResponse is the namespace suffix catalogue
+type NamespacePolicyListResponse struct {
+	Policies []NamespacePolicy `json:"policies"`
+}
+
+// defaultNamespacePolicies are the built-in suffixes, all relying on the resource detection
+func defaultNamespacePolicies() []NamespacePolicy {
+	policies := []NamespacePolicy{}
+	for _, suffix := range strings.Split(ValidNamespaceSuffixes, ",") {
+		policies = append(policies, NamespacePolicy{Suffix: suffix})
+	}
+	return policies
+}
+
+// ParseNamespacePolicies parses a YAML or JSON list of namespace policies
+func ParseNamespacePolicies(data []byte) ([]NamespacePolicy, error) {
+	policies := []NamespacePolicy{}
+	if err := yaml.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("invalid namespace policies: %w", err)
+	}
+	seen := map[string]bool{}
+	for i := range policies {
+		suffix := strings.ToLower(strings.TrimSpace(policies[i].Suffix))
+		if suffix == "" {
+			return nil, fmt.Errorf("invalid namespace policies: policy %d has no suffix", i)
+		}
+		if seen[suffix] {
+			return nil, fmt.Errorf("invalid namespace policies: duplicated suffix %s", suffix)
+		}
+		seen[suffix] = true
+		policies[i].Suffix = suffix
+		for _, excl := range policies[i].DefaultExclusions {
+			if err := excl.validate(); err != nil {
+				return nil, fmt.Errorf("invalid namespace policies: suffix %s: %w", suffix, err)
+			}
+		}
+	}
+	return policies, nil
+}
+
+// LoadNamespacePolicies reads the namespace policies of a YAML or JSON file
+func LoadNamespacePolicies(path string) ([]NamespacePolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read namespace policies: %w", err)
+	}
+	return ParseNamespacePolicies(data)
+}
+
+// SetNamespacePolicies sets where the namespace suffix catalogue is loaded from
+func (s *ScheduleService) SetNamespacePolicies(source NamespacePolicySource) {
+	s.namespacePolicies = source
+}
+
+// NamespacePolicies returns the namespace suffix catalogue sorted by suffix
+func (s *ScheduleService) NamespacePolicies(ctx context.Context) ([]NamespacePolicy, error) {
+	bySuffix := map[string]NamespacePolicy{}
+	for _, policy := range defaultNamespacePolicies() {
+		bySuffix[policy.Suffix] = policy
+	}
+	for _, policy := range s.namespacePolicies.Policies {
+		bySuffix[policy.Suffix] = policy
+	}
+
+	if s.namespacePolicies.ConfigMap != "" {
+		configMap := &v1.ConfigMap{}
+		key := client.ObjectKey{Name: s.namespacePolicies.ConfigMap, Namespace: s.namespacePolicies.Namespace}
+		err := s.reader.Get(ctx, key, configMap)
+		switch {
+		case err == nil:
+			policies, err := ParseNamespacePolicies([]byte(configMap.Data[NamespacePoliciesKey]))
+			if err != nil {
+				return nil, fmt.Errorf("ConfigMap %s: %w", s.namespacePolicies.ConfigMap, err)
+			}
+			for _, policy := range policies {
+				bySuffix[policy.Suffix] = policy
+			}
+		case apierrors.IsNotFound(err):
+		default:
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", s.namespacePolicies.ConfigMap, err)
+		}
+	}
+
+	policies := make([]NamespacePolicy, 0, len(bySuffix))
+	for _, policy := range bySuffix {
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Suffix < policies[j].Suffix })
+	return policies, nil
+}
+
+// namespacePolicy returns the policy of a suffix, an empty policy (resource detection only) when the
+// suffix is not in the catalogue or the catalogue cannot be loaded
+func (s *ScheduleService) namespacePolicy(ctx context.Context, suffix string) NamespacePolicy {
+	policies, err := s.NamespacePolicies(ctx)
+	if err != nil {
+		s.logger.Error(err, "failed to load namespace policies, using resource detection", "suffix", suffix)
+		return NamespacePolicy{Suffix: suffix}
+	}
+	for _, policy := range policies {
+		if policy.Suffix == suffix {
+			return policy
+		}
+	}
+	return NamespacePolicy{Suffix: suffix}
+}
+
+// staggeredWake returns whether the wake is staggered, detected when the policy does not set it
+func (p NamespacePolicy) staggeredWake(detected bool) bool {
+	if p.StaggeredWake != nil {
+		return *p.StaggeredWake
+	}
+	return detected
+}
+
+// suspendStatefulSets returns whether StatefulSets are suspended, detected when the policy does not set it
+func (p NamespacePolicy) suspendStatefulSets(detected bool) bool {
+	if p.SuspendStatefulSets != nil {
+		return *p.SuspendStatefulSets
+	}
+	return detected
+}
+
+// excludeRefs returns the default exclusions of the policy
+func (p NamespacePolicy) excludeRefs() []kubegreenv1alpha1.FilterRef {
+	refs := make([]kubegreenv1alpha1.FilterRef, 0, len(p.DefaultExclusions))
+	for _, excl := range p.DefaultExclusions {
+		refs = append(refs, excl.toFilterRef())
+	}
+	return refs
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+const (
+	// MaxOccurrencesCount is the maximum number of occurrences returned per SleepInfo operation
+	MaxOccurrencesCount = 50
+	// MaxPreviewDays is the maximum number of days of a schedule preview
+	MaxPreviewDays = 31
+)
+
+// ScheduledOccurrence is a concrete sleep or wake execution computed from a SleepInfo schedule
+type ScheduledOccurrence struct {
+	Operation    string    `json:"operation"` // "SLEEP" or "WAKE_UP"
+	Namespace    string    `json:"namespace"` // Namespace suffix (datastores, apps, ...)
+	SleepInfo    string    `json:"sleepInfo"`
+	Resources    []string  `json:"resources"`           // Resources handled at this step (staged wakes have one entry per step)
+	WakeStage    string    `json:"wakeStage,omitempty"` // Wake stage of the SleepInfo executed at this step, if any
+	Time         time.Time `json:"time"`                // Instant in cluster timezone (UTC)
+	UserTime     string    `json:"userTime"`            // Same instant in the user timezone (RFC3339)
+	UserTimezone string    `json:"userTimezone"`
+	ScheduleName string    `json:"scheduleName,omitempty"`
+	Description  string    `json:"description,omitempty"`
+}
+
+// NextOccurrencesResponse lists the next concrete sleep/wake executions of a tenant in chronological order
+type NextOccurrencesResponse struct {
+	Tenant      string                `json:"tenant"`
+	Count       int                   `json:"count"` // Occurrences computed per SleepInfo operation
+	Occurrences []ScheduledOccurrence `json:"occurrences"`
+}
+
+// SchedulePreviewResponse lists every operation planned for a tenant in the next days, in chronological order
+type SchedulePreviewResponse struct {
+	Tenant      string                `json:"tenant"`
+	Namespace   string                `json:"namespace,omitempty"` // Namespace suffix, when filtered
+	Days        int                   `json:"days"`
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	Occurrences []ScheduledOccurrence `json:"occurrences"`
+}
+
+// GetNextOccurrences computes the next count executions of every sleep and wake operation of the tenant,
+// including each wake stage and each staggered wake step of the datastores pairs. Paused SleepInfos are skipped and
+// suspended ones only produce occurrences after the suspension deadline.
+func (s *ScheduleService) GetNextOccurrences(ctx context.Context, tenant string, count int, now time.Time) (*NextOccurrencesResponse, error) {
+	if count < 1 || count > MaxOccurrencesCount {
+		return nil, fmt.Errorf("invalid count: %d (expected 1-%d)", count, MaxOccurrencesCount)
+	}
+	occurrences, err := s.scheduledOccurrences(ctx, tenant, "", now, count, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return &NextOccurrencesResponse{
+		Tenant:      tenant,
+		Count:       count,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// PreviewSchedule computes every execution of the sleep and wake operations of the tenant, or of one of its
+// namespaces, in the days after now, as GetNextOccurrences, so a schedule can be checked before it fires.
+// Holiday policies are not applied: the holiday calendars are read by the controller.
+func (s *ScheduleService) PreviewSchedule(ctx context.Context, tenant, namespaceSuffix string, days int, now time.Time) (*SchedulePreviewResponse, error) {
+	if days < 1 || days > MaxPreviewDays {
+		return nil, fmt.Errorf("invalid days: %d (expected 1-%d)", days, MaxPreviewDays)
+	}
+	until := now.AddDate(0, 0, days)
+	occurrences, err := s.scheduledOccurrences(ctx, tenant, namespaceSuffix, now, 0, until)
+	if err != nil {
+		return nil, err
+	}
+	return &SchedulePreviewResponse{
+		Tenant:      tenant,
+		Namespace:   namespaceSuffix,
+		Days:        days,
+		From:        now.UTC(),
+		To:          until.UTC(),
+		Occurrences: occurrences,
+	}, nil
+}
+
+// scheduledOccurrences computes the executions of every sleep and wake operation of the SleepInfos of the
+// tenant after now, in chronological order: the next count ones of each operation, or all of them until
+// until when count is 0.
+func (s *ScheduleService) scheduledOccurrences(ctx context.Context, tenant, namespaceSuffix string, now time.Time, count int, until time.Time) ([]ScheduledOccurrence, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", namespaceSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []ScheduledOccurrence{}
+	for _, si := range sleepInfos {
+		if si.IsSuspended() {
+			continue
+		}
+		summary := s.buildSleepInfoSummary(ctx, si)
+		userTZ := summary.UserTimezone
+		if userTZ == "" {
+			userTZ = TZLocal
+		}
+		userLoc, err := time.LoadLocation(userTZ)
+		if err != nil {
+			userLoc = time.UTC
+		}
+		from := now
+		if si.IsSuspendedUntil(now) {
+			from = si.Spec.SuspendScheduleUntil.Time
+		}
+
+		for _, trigger := range sleepInfoTriggers(si) {
+			sched, err := kubegreenv1alpha1.ParseSchedule(trigger.cron)
+			if err != nil {
+				s.logger.Error(err, "failed to parse cron", "sleepinfo", si.Name, "schedule", trigger.cron)
+				continue
+			}
+			// The wake up of a SleepInfo with wake stages has one step per stage
+			steps := []wakeStep{{resources: summary.Resources}}
+			if trigger.operation == "WAKE_UP" && len(si.Spec.WakeStages) > 0 {
+				steps = wakeSteps(LocaleFromContext(ctx), si)
+			}
+			next := from
+			for i := 0; count == 0 || i < count; i++ {
+				next = sched.Next(next)
+				if next.IsZero() || (!until.IsZero() && next.After(until)) {
+					break
+				}
+				for _, step := range steps {
+					at := next.Add(step.delay)
+					if !until.IsZero() && at.After(until) {
+						break
+					}
+					occurrences = append(occurrences, ScheduledOccurrence{
+						Operation:    trigger.operation,
+						Namespace:    resolver.suffix(si.Namespace),
+						SleepInfo:    si.Name,
+						Resources:    step.resources,
+						WakeStage:    step.stage,
+						Time:         at.UTC(),
+						UserTime:     at.In(userLoc).Format(time.RFC3339),
+						UserTimezone: userTZ,
+						ScheduleName: summary.ScheduleName,
+						Description:  summary.Description,
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].Time.Before(occurrences[j].Time)
+	})
+	return occurrences, nil
+}
+
+type sleepInfoTrigger struct {
+	operation string
+	cron      string
+}
+
+// sleepInfoTriggers returns the cron expressions a SleepInfo fires on. Separate wake objects of a
+// pair (pair-role=wake) store their wake time in sleepAt. The expressions of a SleepInfo with
+// several schedules run at the earliest of them.
+func sleepInfoTriggers(si kubegreenv1alpha1.SleepInfo) []sleepInfoTrigger {
+	triggers := []sleepInfoTrigger{}
+	if len(si.Spec.Schedules) > 0 {
+		sleepSchedule, sleepErr := si.GetSleepSchedule()
+		wakeUpSchedule, wakeUpErr := si.GetWakeUpSchedule()
+		if sleepErr != nil || wakeUpErr != nil {
+			return triggers
+		}
+		return append(triggers,
+			sleepInfoTrigger{operation: "SLEEP", cron: sleepSchedule},
+			sleepInfoTrigger{operation: "WAKE_UP", cron: wakeUpSchedule},
+		)
+	}
+	add := func(operation, hhmm string) {
+		if hhmm == "" || (si.Spec.Weekdays == "" && !kubegreenv1alpha1.IsCronExpression(hhmm)) {
+			return
+		}
+		expr, err := parseTimeToCron(hhmm, si.Spec.Weekdays, si.Spec.TimeZone)
+		if err != nil {
+			return
+		}
+		if si.Spec.TimeZone != "" {
+			expr = fmt.Sprintf("CRON_TZ=%s %s", si.Spec.TimeZone, expr)
+		}
+		triggers = append(triggers, sleepInfoTrigger{operation: operation, cron: expr})
+	}
+
+	switch si.Annotations["kube-green.stratio.com/pair-role"] {
+	case "wake":
+		if si.Spec.WakeUpTime != "" {
+			add("WAKE_UP", si.Spec.WakeUpTime)
+		} else {
+			add("WAKE_UP", si.Spec.SleepTime)
+		}
+	case "sleep":
+		add("SLEEP", si.Spec.SleepTime)
+	default:
+		add("SLEEP", si.Spec.SleepTime)
+		add("WAKE_UP", si.Spec.WakeUpTime)
+	}
+	return triggers
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"container/list"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// clientLimiterTTL is the idle time after which a per-client limiter is dropped
+	clientLimiterTTL = 10 * time.Minute
+	// maxClientLimiters bounds the per-client limiters; the least recently seen is dropped when full
+	maxClientLimiters = 10000
+)
+
+// RateLimitConfig configures the token-bucket rate limiting of the API server.
+// A zero (or negative) rate disables the corresponding limiter.
+type RateLimitConfig struct {
+	GlobalRPS   float64 // Requests per second shared by all clients
+	GlobalBurst int
+	ClientRPS   float64 // Requests per second per client IP
+	ClientBurst int
+}
+
+// Enabled reports whether any limiter is configured
+func (c RateLimitConfig) Enabled() bool {
+	return c.GlobalRPS > 0 || c.ClientRPS > 0
+}
+
+type clientLimiter struct {
+	key      string
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter holds the global bucket and one bucket per client. The client buckets are kept in
+// least recently seen order, so expiring and evicting them never walks the whole set.
+type rateLimiter struct {
+	config     RateLimitConfig
+	global     *rate.Limiter
+	maxClients int
+	mu         sync.Mutex
+	clients    map[string]*list.Element
+	recent     *list.List // of *clientLimiter, most recently seen first
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	rl := &rateLimiter{
+		config:     config,
+		maxClients: maxClientLimiters,
+		clients:    make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+	if config.GlobalRPS > 0 {
+		rl.global = rate.NewLimiter(rate.Limit(config.GlobalRPS), burstOrDefault(config.GlobalBurst, config.GlobalRPS))
+	}
+	return rl
+}
+
+// burstOrDefault allows at least one second worth of requests when no burst is configured
+func burstOrDefault(burst int, rps float64) int {
+	if burst > 0 {
+		return burst
+	}
+	return int(math.Max(1, math.Ceil(rps)))
+}
+
+// clientLimiterFor returns the limiter of a client, creating it if needed, and evicts idle clients
+func (rl *rateLimiter) clientLimiterFor(key string, now time.Time) *rate.Limiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	for e := rl.recent.Back(); e != nil && now.Sub(e.Value.(*clientLimiter).lastSeen) > clientLimiterTTL; e = rl.recent.Back() {
+		rl.remove(e)
+	}
+
+	if e, ok := rl.clients[key]; ok {
+		cl := e.Value.(*clientLimiter)
+		cl.lastSeen = now
+		rl.recent.MoveToFront(e)
+		return cl.limiter
+	}
+
+	if rl.recent.Len() >= rl.maxClients {
+		rl.remove(rl.recent.Back())
+	}
+	cl := &clientLimiter{
+		key:      key,
+		limiter:  rate.NewLimiter(rate.Limit(rl.config.ClientRPS), burstOrDefault(rl.config.ClientBurst, rl.config.ClientRPS)),
+		lastSeen: now,
+	}
+	rl.clients[key] = rl.recent.PushFront(cl)
+	return cl.limiter
+}
+
+// remove drops the limiter of a client
+func (rl *rateLimiter) remove(e *list.Element) {
+	delete(rl.clients, rl.recent.Remove(e).(*clientLimiter).key)
+}
+
+// allow takes a token from the bucket of the client and from the global bucket
+func (rl *rateLimiter) allow(key string, now time.Time) error {
+	if rl.config.ClientRPS > 0 && !rl.clientLimiterFor(key, now).AllowN(now, 1) {
+		return &RateLimitError{Scope: "client", RetryAfter: retryAfter(rl.config.ClientRPS)}
+	}
+	if rl.global != nil && !rl.global.AllowN(now, 1) {
+		return &RateLimitError{Scope: "global", RetryAfter: retryAfter(rl.config.GlobalRPS)}
+	}
+	return nil
+}
+
+// retryAfter is the time until a bucket refilled at rps has a token again, at least one second
+func retryAfter(rps float64) time.Duration {
+	return time.Duration(math.Max(1, math.Ceil(1/rps))) * time.Second
+}
+
+// rateLimitClientKey identifies the caller by its IP. The limiter runs before the JWT is verified, so
+// keying on the bearer token would let a client get a fresh bucket with every made-up token.
+// ClientIP only reads X-Forwarded-For and X-Real-IP from the trusted proxies of the router (none by
+// default), otherwise a client could pick a new IP, and bucket, for every request.
+func rateLimitClientKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// rateLimitMiddleware rejects requests exceeding the configured rates with 429 Too Many Requests.
+// Health probes are never limited so that a flood of API calls cannot make the pod look unhealthy.
+// The buckets are shared with the gRPC server through the ScheduleService.
+func rateLimitMiddleware(service *ScheduleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if path == "/health" || path == "/ready" || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		var limited *RateLimitError
+		if errors.As(service.CheckRateLimit(rateLimitClientKey(c)), &limited) {
+			c.Header("Retry-After", strconv.Itoa(int(limited.RetryAfter.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{
+				Success: false,
+				Error:   limited.Error(),
+				Code:    http.StatusTooManyRequests,
+			})
+			return
+		}
+		c.Next()
+	}
+}
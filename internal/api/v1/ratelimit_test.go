@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterEvictsLeastRecentlySeen(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{ClientRPS: 1, ClientBurst: 1})
+	rl.maxClients = 2
+	now := time.Now()
+
+	require.NoError(t, rl.allow("a", now))
+	require.NoError(t, rl.allow("b", now.Add(time.Millisecond)))
+	// a is seen again, so b becomes the least recently seen client
+	require.Error(t, rl.allow("a", now.Add(2*time.Millisecond)))
+
+	require.NoError(t, rl.allow("c", now.Add(3*time.Millisecond)))
+	require.Len(t, rl.clients, 2)
+	require.Contains(t, rl.clients, "a")
+	require.Contains(t, rl.clients, "c")
+	require.NotContains(t, rl.clients, "b")
+
+	// a kept its empty bucket, b gets a new one
+	require.Error(t, rl.allow("a", now.Add(4*time.Millisecond)))
+	require.NoError(t, rl.allow("b", now.Add(5*time.Millisecond)))
+	require.Equal(t, 2, rl.recent.Len())
+}
+
+func TestRateLimiterExpiresIdleClients(t *testing.T) {
+	rl := newRateLimiter(RateLimitConfig{ClientRPS: 1})
+	now := time.Now()
+
+	require.NoError(t, rl.allow("a", now))
+	require.NoError(t, rl.allow("b", now.Add(clientLimiterTTL)))
+	require.NoError(t, rl.allow("c", now.Add(clientLimiterTTL+time.Second)))
+
+	require.NotContains(t, rl.clients, "a")
+	require.Contains(t, rl.clients, "b")
+	require.Contains(t, rl.clients, "c")
+	require.Equal(t, 2, rl.recent.Len())
+}
+
+func TestRateLimitClientIP(t *testing.T) {
+	newRouter := func(trustedProxies []string) http.Handler {
+		logger := logr.Discard()
+		service := NewService(ServiceConfig{
+			Client:    newTestClientBuilder(t).Build(),
+			Logger:    logger,
+			RateLimit: RateLimitConfig{ClientRPS: 1, ClientBurst: 1},
+		})
+		return NewServer(Config{Service: service, Logger: logger, TrustedProxies: trustedProxies}).router
+	}
+	get := func(router http.Handler, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/not-found", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.Header.Set("X-Real-IP", forwardedFor)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("spoofed headers share the bucket of the connection", func(t *testing.T) {
+		router := newRouter(nil)
+		require.NotEqual(t, http.StatusTooManyRequests, get(router, "192.0.2.10:4000", "198.51.100.1"))
+		require.Equal(t, http.StatusTooManyRequests, get(router, "192.0.2.10:4001", "198.51.100.2"))
+		require.NotEqual(t, http.StatusTooManyRequests, get(router, "192.0.2.11:4000", "198.51.100.2"))
+	})
+
+	t.Run("trusted proxies forward the client IP", func(t *testing.T) {
+		router := newRouter([]string{"10.0.0.0/8"})
+		require.NotEqual(t, http.StatusTooManyRequests, get(router, "10.0.0.1:4000", "198.51.100.1"))
+		require.NotEqual(t, http.StatusTooManyRequests, get(router, "10.0.0.1:4001", "198.51.100.2"))
+		require.Equal(t, http.StatusTooManyRequests, get(router, "10.0.0.2:4000", "198.51.100.2"))
+		// headers of untrusted peers are still ignored
+		require.NotEqual(t, http.StatusTooManyRequests, get(router, "192.0.2.10:4000", "198.51.100.3"))
+		require.Equal(t, http.StatusTooManyRequests, get(router, "192.0.2.10:4001", "198.51.100.4"))
+	})
+}
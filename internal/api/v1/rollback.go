@@ -0,0 +1,237 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v6/typed"
+)
+
+// NamespaceError is the error of one namespace of a schedule written to several namespaces
+type NamespaceError struct {
+	Namespace string // namespace suffix
+	Err       error
+}
+
+func (e *NamespaceError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *NamespaceError) Unwrap() error {
+	return e.Err
+}
+
+// NamespaceFailure is a namespace whose SleepInfos failed to be written
+type NamespaceFailure struct {
+	Namespace string `json:"namespace" example:"apps"` // Namespace suffix
+	Error     string `json:"error"`
+}
+
+// ScheduleWriteReport is the machine-readable report of a schedule write that failed after changing
+// some SleepInfos
+type ScheduleWriteReport struct {
+	Failed        []NamespaceFailure `json:"failed"`                  // Namespaces whose SleepInfos failed to be written
+	RolledBack    []string           `json:"rolledBack"`              // SleepInfos (namespace/name) deleted, created again or restored to their previous spec
+	NotRolledBack []string           `json:"notRolledBack,omitempty"` // SleepInfos left with the new spec, their rollback failed
+	Consistent    bool               `json:"consistent"`              // Every SleepInfo written is rolled back
+}
+
+// ScheduleWriteError is returned when a schedule fails after some of its SleepInfos are written. The
+// written SleepInfos are rolled back: the created ones are deleted, the deleted ones are created again and
+// the updated ones get their previous spec back, so on failure the tenant keeps the schedule it had
+// before the call.
+type ScheduleWriteError struct {
+	Err    error
+	Report ScheduleWriteReport
+}
+
+func (e *ScheduleWriteError) Error() string {
+	if e.Report.Consistent {
+		return fmt.Sprintf("%v (changes rolled back)", e.Err)
+	}
+	return fmt.Sprintf("%v (rollback failed for %d SleepInfos)", e.Err, len(e.Report.NotRolledBack))
+}
+
+func (e *ScheduleWriteError) Unwrap() error {
+	return e.Err
+}
+
+// ScheduleWriteErrorResponse is the response of a schedule write rolled back after a partial failure
+type ScheduleWriteErrorResponse struct {
+	Success bool                `json:"success" example:"false"`
+	Error   string              `json:"error"`
+	Code    int                 `json:"code" example:"500"`
+	Report  ScheduleWriteReport `json:"report"`
+}
+
+type writeJournalKey struct{}
+
+// journalEntry is the state of a SleepInfo before a schedule write
+type journalEntry struct {
+	previous *kubegreenv1alpha1.SleepInfo // nil when created by the write
+	deleted  bool                         // deleted by the write, as no longer in the schedule
+}
+
+// writeJournal records the SleepInfos written or deleted during a schedule write, with their state before it
+type writeJournal struct {
+	mu      sync.Mutex
+	written map[client.ObjectKey]journalEntry
+}
+
+// withWriteJournal returns a context recording the SleepInfos written with it into the returned journal
+func withWriteJournal(ctx context.Context) (context.Context, *writeJournal) {
+	journal := &writeJournal{written: map[client.ObjectKey]journalEntry{}}
+	return context.WithValue(ctx, writeJournalKey{}, journal), journal
+}
+
+// recordSleepInfoWrite records that the SleepInfo was written, with its state before the write or nil
+// when it was created. Only the state before the first write is kept.
+func recordSleepInfoWrite(ctx context.Context, key client.ObjectKey, previous *kubegreenv1alpha1.SleepInfo) {
+	recordJournalEntry(ctx, key, journalEntry{previous: previous})
+}
+
+// recordSleepInfoDeletion records that the SleepInfo was deleted
+func recordSleepInfoDeletion(ctx context.Context, deleted *kubegreenv1alpha1.SleepInfo) {
+	recordJournalEntry(ctx, client.ObjectKeyFromObject(deleted), journalEntry{previous: deleted.DeepCopy(), deleted: true})
+}
+
+func recordJournalEntry(ctx context.Context, key client.ObjectKey, entry journalEntry) {
+	journal, ok := ctx.Value(writeJournalKey{}).(*writeJournal)
+	if !ok {
+		return
+	}
+	journal.mu.Lock()
+	defer journal.mu.Unlock()
+	if _, found := journal.written[key]; found {
+		return
+	}
+	journal.written[key] = entry
+}
+
+// keys returns the recorded SleepInfos, sorted
+func (j *writeJournal) keys() []client.ObjectKey {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	keys := make([]client.ObjectKey, 0, len(j.written))
+	for key := range j.written {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, k int) bool {
+		return keys[i].String() < keys[k].String()
+	})
+	return keys
+}
+
+// rollbackSchedule undoes the SleepInfo writes of the journal after the schedule write failed with err.
+// err is returned as is when nothing was written.
+func (s *ScheduleService) rollbackSchedule(ctx context.Context, journal *writeJournal, err error) error {
+	keys := journal.keys()
+	if len(keys) == 0 {
+		return err
+	}
+	// The rollback runs even when the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	report := ScheduleWriteReport{Failed: namespaceFailures(err), RolledBack: []string{}}
+	for _, key := range keys {
+		if rollbackErr := s.rollbackSleepInfo(ctx, key, journal.written[key]); rollbackErr != nil {
+			s.logger.Error(rollbackErr, "failed to roll back SleepInfo", "name", key.Name, "namespace", key.Namespace)
+			report.NotRolledBack = append(report.NotRolledBack, key.String())
+			continue
+		}
+		report.RolledBack = append(report.RolledBack, key.String())
+	}
+	report.Consistent = len(report.NotRolledBack) == 0
+	s.logger.Info("schedule write rolled back", "rolledBack", len(report.RolledBack), "notRolledBack", len(report.NotRolledBack))
+	return &ScheduleWriteError{Err: err, Report: report}
+}
+
+// rollbackSleepInfo deletes the SleepInfo when it was created, creates it again when it was deleted, or
+// applies again the fields SleepInfoFieldManager had applied before the write. The fields of other
+// writers, changed since by them or not, are left alone.
+func (s *ScheduleService) rollbackSleepInfo(ctx context.Context, key client.ObjectKey, entry journalEntry) error {
+	if entry.previous == nil {
+		sleepInfo := kubegreenv1alpha1.SleepInfo{}
+		if err := s.reader.Get(ctx, key, &sleepInfo); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		return s.deleteSleepInfo(ctx, sleepInfo)
+	}
+
+	if entry.deleted {
+		current := kubegreenv1alpha1.SleepInfo{}
+		if err := s.reader.Get(ctx, key, &current); err == nil && !current.DeletionTimestamp.IsZero() {
+			if err := s.waitForSleepInfoDeletion(ctx, key); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+		restored := entry.previous.DeepCopy()
+		restored.UID = ""
+		restored.Finalizers = nil
+		restored.DeletionTimestamp = nil
+		restored.DeletionGracePeriodSeconds = nil
+		return s.applySleepInfo(ctx, restored)
+	}
+
+	applied, err := appliedFields(entry.previous)
+	if err != nil {
+		return err
+	}
+	if applied == nil {
+		// Never applied by the API, as the manifests of the GitOps mode: the whole previous SleepInfo
+		restored := entry.previous.DeepCopy()
+		restored.UID = ""
+		if err := s.applySleepInfo(ctx, restored); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	applied.SetName(key.Name)
+	applied.SetNamespace(key.Namespace)
+	if err := s.applyUnstructured(ctx, applied, "SleepInfo"); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// appliedFields returns the fields of the SleepInfo owned by the apply of SleepInfoFieldManager, nil when
+// it owns none
+func appliedFields(sleepInfo *kubegreenv1alpha1.SleepInfo) (*unstructured.Unstructured, error) {
+	content := map[string]interface{}{}
+	if err := managedfields.ExtractInto(sleepInfo, typed.DeducedParseableType, SleepInfoFieldManager, &content, ""); err != nil {
+		return nil, fmt.Errorf("failed to read the fields applied to SleepInfo %s in namespace %s: %w", sleepInfo.Name, sleepInfo.Namespace, err)
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	return &unstructured.Unstructured{Object: content}, nil
+}
+
+// namespaceFailures returns the namespaces of the NamespaceErrors joined in err, in their order
+func namespaceFailures(err error) []NamespaceFailure {
+	failures := []NamespaceFailure{}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		var nsErr *NamespaceError
+		if errors.As(e, &nsErr) {
+			failures = append(failures, NamespaceFailure{Namespace: nsErr.Namespace, Error: nsErr.Err.Error()})
+		}
+	}
+	return failures
+}
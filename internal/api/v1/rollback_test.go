@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+func TestRollbackSleepInfo(t *testing.T) {
+	ctx := context.Background()
+	service, c := newTestService(t)
+	key := client.ObjectKey{Name: "laboral", Namespace: "bda-apps"}
+	get := func() *kubegreenv1alpha1.SleepInfo {
+		si := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(ctx, key, si))
+		return si
+	}
+	editExcludeRef := func(name string) {
+		si := get()
+		si.Spec.ExcludeRef = []kubegreenv1alpha1.FilterRef{{Kind: "Deployment", Name: name}}
+		require.NoError(t, c.Update(ctx, si, client.FieldOwner("kubectl-edit")))
+	}
+
+	require.NoError(t, service.applySleepInfo(ctx, &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Annotations: map[string]string{"kube-green.stratio.com/schedule-description": "before"}},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+	}))
+	editExcludeRef("before")
+	previous := get()
+
+	// The failed write changes the schedule, another writer changes its own field meanwhile
+	require.NoError(t, service.applySleepInfo(ctx, &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Annotations: map[string]string{"kube-green.stratio.com/schedule-description": "after"}},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "0-6", SleepTime: "23:00", WakeUpTime: "07:00"},
+	}))
+	editExcludeRef("after")
+
+	require.NoError(t, service.rollbackSleepInfo(ctx, key, journalEntry{previous: previous}))
+
+	restored := get()
+	require.Equal(t, "1-5", restored.Spec.Weekdays)
+	require.Equal(t, "22:00", restored.Spec.SleepTime)
+	require.Equal(t, "06:00", restored.Spec.WakeUpTime)
+	require.Equal(t, "before", restored.Annotations["kube-green.stratio.com/schedule-description"])
+	// Only the fields of the API are applied again, the change of the other writer stays
+	require.Equal(t, []kubegreenv1alpha1.FilterRef{{Kind: "Deployment", Name: "after"}}, restored.Spec.ExcludeRef)
+}
+
+func TestRollbackSchedule(t *testing.T) {
+	ctx := context.Background()
+	service, c := newTestService(t)
+
+	created := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "bda-apps"},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "1-5", SleepTime: "22:00"},
+	}
+	deleted := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "bda-rocket", Labels: map[string]string{"team": "data"}},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "1-5", SleepTime: "21:00"},
+	}
+	require.NoError(t, service.applySleepInfo(ctx, deleted))
+
+	journalCtx, journal := withWriteJournal(ctx)
+	require.NoError(t, service.applySleepInfo(journalCtx, created))
+	recordSleepInfoWrite(journalCtx, client.ObjectKeyFromObject(created), nil)
+	require.NoError(t, service.deleteSleepInfo(journalCtx, *deleted))
+	recordSleepInfoDeletion(journalCtx, deleted)
+
+	writeErr := errors.Join(&NamespaceError{Namespace: "datastores", Err: errors.New("forbidden")})
+	err := service.rollbackSchedule(journalCtx, journal, writeErr)
+
+	var scheduleErr *ScheduleWriteError
+	require.ErrorAs(t, err, &scheduleErr)
+	require.True(t, scheduleErr.Report.Consistent)
+	require.Equal(t, []string{"bda-apps/created", "bda-rocket/deleted"}, scheduleErr.Report.RolledBack)
+	require.Equal(t, []NamespaceFailure{{Namespace: "datastores", Error: "forbidden"}}, scheduleErr.Report.Failed)
+
+	require.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(created), &kubegreenv1alpha1.SleepInfo{})))
+	recreated := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(deleted), recreated))
+	require.Equal(t, "21:00", recreated.Spec.SleepTime)
+	require.Equal(t, "data", recreated.Labels["team"])
+
+	// Nothing written, the error is returned as is
+	_, empty := withWriteJournal(ctx)
+	require.Equal(t, writeErr, service.rollbackSchedule(ctx, empty, writeErr))
+}
+
+func TestUpdateScheduleRollsBackWhenStaleDeletionFails(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClientBuilder(t).
+		WithObjects(
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-apps"}},
+			&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-rocket"}},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetNamespace() == "bda-rocket" {
+					return errors.New("delete refused")
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	service := NewScheduleService(c, logr.Discard())
+
+	require.NoError(t, service.CreateSchedule(ctx, CreateScheduleRequest{
+		Tenant: "bda", ScheduleName: "laboral", Off: "22:00", On: "06:00", Weekdays: "1-5", Namespaces: []string{"apps", "rocket"},
+	}))
+	before := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "laboral", Namespace: "bda-apps"}, before))
+
+	// rocket leaves the schedule, its deletion fails after apps is written
+	err := service.UpdateSchedule(ctx, "bda", CreateScheduleRequest{
+		ScheduleName: "laboral", Off: "23:00", On: "07:00", Weekdays: "1-5", Namespaces: []string{"apps"},
+	})
+	var scheduleErr *ScheduleWriteError
+	require.ErrorAs(t, err, &scheduleErr)
+	require.Contains(t, scheduleErr.Report.RolledBack, "bda-apps/laboral")
+
+	after := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "laboral", Namespace: "bda-apps"}, after))
+	require.Equal(t, before.Spec.SleepTime, after.Spec.SleepTime)
+	require.Equal(t, before.Spec.WakeUpTime, after.Spec.WakeUpTime)
+}
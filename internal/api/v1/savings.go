@@ -0,0 +1,286 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	savingsWindow = 7 * 24 * time.Hour
+	bytesPerGB    = 1 << 30
+)
+
+// SavingsPricing is the optional price used to turn resource-hours into a cost
+type SavingsPricing struct {
+	PerCoreHour float64 // Price of one requested CPU core during one hour
+	PerGBHour   float64 // Price of one requested GiB of memory during one hour
+	Currency    string  // Informative currency code (e.g. USD, EUR)
+}
+
+// Enabled reports whether a price is configured
+func (p SavingsPricing) Enabled() bool {
+	return p.PerCoreHour > 0 || p.PerGBHour > 0
+}
+
+// NamespaceSavings is the weekly saving estimation of a namespace
+type NamespaceSavings struct {
+	Namespace        string   `json:"namespace"`
+	WeeklySleepHours float64  `json:"weeklySleepHours"` // Hours per week the namespace is asleep according to its schedule
+	Workloads        int      `json:"workloads"`        // Deployments and StatefulSets covered by the SleepInfos
+	CPUCores         float64  `json:"cpuCores"`         // Requested CPU cores of the covered workloads
+	MemoryGB         float64  `json:"memoryGB"`         // Requested memory (GiB) of the covered workloads
+	CPUCoreHours     float64  `json:"cpuCoreHours"`     // Core-hours saved per week
+	MemoryGBHours    float64  `json:"memoryGBHours"`    // GiB-hours saved per week
+	EstimatedCost    *float64 `json:"estimatedCost,omitempty"`
+}
+
+// SavingsResponse is the weekly saving estimation of a tenant
+type SavingsResponse struct {
+	Tenant        string             `json:"tenant"`
+	From          time.Time          `json:"from"` // Start of the estimated week
+	To            time.Time          `json:"to"`
+	Namespaces    []NamespaceSavings `json:"namespaces"`
+	CPUCoreHours  float64            `json:"cpuCoreHours"`
+	MemoryGBHours float64            `json:"memoryGBHours"`
+	EstimatedCost *float64           `json:"estimatedCost,omitempty"` // Only when a price is configured
+	Currency      string             `json:"currency,omitempty"`
+	PerCoreHour   float64            `json:"pricePerCoreHour,omitempty"`
+	PerGBHour     float64            `json:"pricePerGBHour,omitempty"`
+}
+
+// GetSavings estimates the resources saved by the tenant schedule during the next week: the CPU and
+// memory requested by the workloads covered by each namespace SleepInfos, multiplied by the hours the
+// namespace is asleep. Workloads asleep right now are counted with their replicas before sleep.
+func (s *ScheduleService) GetSavings(ctx context.Context, tenant string, pricing SavingsPricing, now time.Time) (*SavingsResponse, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	byNamespace := make(map[string][]kubegreenv1alpha1.SleepInfo)
+	for _, si := range sleepInfos {
+		if si.IsSuspended() {
+			continue
+		}
+		byNamespace[si.Namespace] = append(byNamespace[si.Namespace], si)
+	}
+
+	response := &SavingsResponse{
+		Tenant:     tenant,
+		From:       now.UTC(),
+		To:         now.Add(savingsWindow).UTC(),
+		Namespaces: []NamespaceSavings{},
+	}
+	if pricing.Enabled() {
+		response.Currency = pricing.Currency
+		response.PerCoreHour = pricing.PerCoreHour
+		response.PerGBHour = pricing.PerGBHour
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		nsSleepInfos := byNamespace[ns]
+		savings := NamespaceSavings{
+			Namespace:        ns,
+			WeeklySleepHours: round2(weeklySleepHours(nsSleepInfos, now)),
+		}
+		cpu, memory, workloads, err := s.coveredRequests(ctx, ns, nsSleepInfos)
+		if err != nil {
+			return nil, err
+		}
+		savings.Workloads = workloads
+		savings.CPUCores = round2(cpu)
+		savings.MemoryGB = round2(memory)
+		savings.CPUCoreHours = round2(cpu * savings.WeeklySleepHours)
+		savings.MemoryGBHours = round2(memory * savings.WeeklySleepHours)
+		if pricing.Enabled() {
+			cost := round2(savings.CPUCoreHours*pricing.PerCoreHour + savings.MemoryGBHours*pricing.PerGBHour)
+			savings.EstimatedCost = &cost
+		}
+
+		response.CPUCoreHours += savings.CPUCoreHours
+		response.MemoryGBHours += savings.MemoryGBHours
+		response.Namespaces = append(response.Namespaces, savings)
+	}
+	response.CPUCoreHours = round2(response.CPUCoreHours)
+	response.MemoryGBHours = round2(response.MemoryGBHours)
+	if pricing.Enabled() {
+		cost := round2(response.CPUCoreHours*pricing.PerCoreHour + response.MemoryGBHours*pricing.PerGBHour)
+		response.EstimatedCost = &cost
+	}
+	return response, nil
+}
+
+// weeklySleepHours returns how long the namespace is asleep in [now, now+7d]. A sleep lasts until the
+// first wake-up that follows it (staged wakes count from their first step).
+func weeklySleepHours(sleepInfos []kubegreenv1alpha1.SleepInfo, now time.Time) float64 {
+	type transition struct {
+		at    time.Time
+		sleep bool
+	}
+	// Start one week earlier to know whether the namespace is already asleep at now
+	from, to := now.Add(-savingsWindow), now.Add(savingsWindow)
+	var transitions []transition
+	for _, si := range sleepInfos {
+		for _, trigger := range sleepInfoTriggers(si) {
+			sched, err := kubegreenv1alpha1.ParseSchedule(trigger.cron)
+			if err != nil {
+				continue
+			}
+			for next := sched.Next(from); !next.IsZero() && next.Before(to); next = sched.Next(next) {
+				transitions = append(transitions, transition{at: next, sleep: trigger.operation == "SLEEP"})
+			}
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].at.Before(transitions[j].at)
+	})
+
+	var asleep time.Duration
+	var sleepingSince *time.Time
+	addInterval := func(start, end time.Time) {
+		if start.Before(now) {
+			start = now
+		}
+		if end.After(start) {
+			asleep += end.Sub(start)
+		}
+	}
+	for i := range transitions {
+		t := transitions[i]
+		switch {
+		case t.sleep && sleepingSince == nil:
+			sleepingSince = &transitions[i].at
+		case !t.sleep && sleepingSince != nil:
+			addInterval(*sleepingSince, t.at)
+			sleepingSince = nil
+		}
+	}
+	if sleepingSince != nil {
+		addInterval(*sleepingSince, to)
+	}
+	return asleep.Hours()
+}
+
+// coveredRequests sums the requested CPU (cores) and memory (GiB) of the Deployments and StatefulSets
+// the SleepInfos of a namespace put to sleep, honoring their exclusions.
+func (s *ScheduleService) coveredRequests(ctx context.Context, namespace string, sleepInfos []kubegreenv1alpha1.SleepInfo) (float64, float64, int, error) {
+	suspendDeployments, suspendStatefulSets := false, false
+	var excludes []ExclusionFilter
+	for _, si := range sleepInfos {
+		suspendDeployments = suspendDeployments || si.IsDeploymentsToSuspend()
+		suspendStatefulSets = suspendStatefulSets || si.IsStatefulSetsToSuspend() || si.IsPostgresToSuspend() ||
+			si.IsHdfsToSuspend() || si.IsOpenSearchToSuspend() || si.IsKafkaToSuspend()
+		for _, ref := range si.GetExcludeRef() {
+			excludes = append(excludes, exclusionFromFilterRef(ref))
+		}
+	}
+	excluded := func(kind, name string, objLabels map[string]string) bool {
+		for _, filter := range excludes {
+			if filter.matches(kind, name, objLabels) {
+				return true
+			}
+		}
+		return false
+	}
+	// replicas kept during sleep are not saved
+	keptReplicas := func(target kubegreenv1alpha1.PatchTarget, name string, objLabels map[string]string) int32 {
+		for _, si := range sleepInfos {
+			if replicas, ok := si.GetSleepReplicas(target, name, objLabels); ok {
+				return replicas
+			}
+		}
+		return 0
+	}
+	replicasBeforeSleep := s.replicasBeforeSleep(ctx, namespace, sleepInfos)
+
+	var cpu, memory float64
+	workloads := 0
+	add := func(target kubegreenv1alpha1.PatchTarget, name string, objLabels map[string]string, specReplicas *int32, podSpec v1.PodSpec) {
+		replicas := int32(1)
+		if specReplicas != nil {
+			replicas = *specReplicas
+		}
+		if replicas == 0 {
+			replicas = replicasBeforeSleep[name]
+		}
+		replicas -= keptReplicas(target, name, objLabels)
+		if replicas <= 0 {
+			return
+		}
+		workloads++
+		for _, c := range podSpec.Containers {
+			cpu += float64(replicas) * c.Resources.Requests.Cpu().AsApproximateFloat64()
+			memory += float64(replicas) * c.Resources.Requests.Memory().AsApproximateFloat64() / bytesPerGB
+		}
+	}
+
+	if suspendDeployments {
+		deployments := &appsv1.DeploymentList{}
+		if err := s.reader.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to list deployments in %s: %w", namespace, err)
+		}
+		for _, d := range deployments.Items {
+			if !excluded("Deployment", d.Name, d.Labels) {
+				add(kubegreenv1alpha1.DeploymentTarget, d.Name, d.Labels, d.Spec.Replicas, d.Spec.Template.Spec)
+			}
+		}
+	}
+	if suspendStatefulSets {
+		statefulSets := &appsv1.StatefulSetList{}
+		if err := s.reader.List(ctx, statefulSets, client.InNamespace(namespace)); err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to list statefulsets in %s: %w", namespace, err)
+		}
+		for _, sts := range statefulSets.Items {
+			if !excluded("StatefulSet", sts.Name, sts.Labels) {
+				add(kubegreenv1alpha1.StatefulSetTarget, sts.Name, sts.Labels, sts.Spec.Replicas, sts.Spec.Template.Spec)
+			}
+		}
+	}
+	return cpu, memory, workloads, nil
+}
+
+// replicasBeforeSleep reads the replicas saved by the controller in the sleepinfo-* secrets
+func (s *ScheduleService) replicasBeforeSleep(ctx context.Context, namespace string, sleepInfos []kubegreenv1alpha1.SleepInfo) map[string]int32 {
+	replicas := make(map[string]int32)
+	for _, si := range sleepInfos {
+		secret := &v1.Secret{}
+		key := client.ObjectKey{Name: fmt.Sprintf("sleepinfo-%s", si.Name), Namespace: namespace}
+		if err := s.reader.Get(ctx, key, secret); err != nil {
+			continue
+		}
+		var saved []struct {
+			Name     string `json:"name"`
+			Replicas int32  `json:"replicas"`
+		}
+		if err := json.Unmarshal(secret.Data["deployment-replicas"], &saved); err != nil {
+			continue
+		}
+		for _, r := range saved {
+			replicas[r.Name] = r.Replicas
+		}
+	}
+	return replicas
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -6,38 +6,58 @@ package v1
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
-	"github.com/robfig/cron/v3"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
+	"github.com/kube-green/kube-green/internal/notifications"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
-	// ValidNamespaceSuffixes are the supported namespace suffixes
+	// ValidNamespaceSuffixes are the built-in namespace suffixes, extended by the namespace policies
 	ValidNamespaceSuffixes = "datastores,apps,rocket,intelligence,airflowsso"
-)
 
-var (
-	validSuffixes = []string{"datastores", "apps", "rocket", "intelligence", "airflowsso"}
+	// namespaceConcurrency is the number of namespaces of a schedule created at the same time
+	namespaceConcurrency = 4
 )
 
 // ScheduleService handles schedule operations
 type ScheduleService struct {
-	client client.Client
-	reader client.Reader // direct API reader, bypasses informer cache
-	logger logger
+	client   client.Client
+	reader   client.Reader // direct API reader, bypasses informer cache
+	logger   logger
+	notifier notifications.Notifier // optional, receives the schedule lifecycle events
+	// optional label convention of the tenant namespaces, the {tenant}-{suffix} name is used otherwise
+	namespaceLabels NamespaceLabels
+	// optional namespace suffix catalogue, the built-in suffixes with resource detection otherwise
+	namespacePolicies NamespacePolicySource
+	// optional key decrypting the restore patches
+	restoreDataKey *restoredata.EncryptionKey
+	// shared by the API transports, see NewService
+	impersonate bool          // writes are impersonated as the user of the call context
+	audit       *auditLogger  // nil when the audit log is disabled
+	limiter     *rateLimiter  // nil when rate limiting is disabled
+	gitOps      *gitOpsClient // nil unless the writes are committed to a GitOps repository
+	// tenant map served by tenant discovery, see WatchNamespaces
+	tenants atomic.Pointer[tenantMap]
+	// drifted SleepInfos of the last background check, see WatchDrift
+	drift atomic.Pointer[DriftReport]
 }
 
 var (
@@ -65,14 +85,80 @@ func NewScheduleService(c client.Client, l logger, readers ...client.Reader) *Sc
 	}
 }
 
+// SetNotifier sets the notifier of the schedule lifecycle events
+func (s *ScheduleService) SetNotifier(n notifications.Notifier) {
+	s.notifier = n
+}
+
+// notifyScheduleEvent sends a schedule lifecycle event when a notifier is configured
+func (s *ScheduleService) notifyScheduleEvent(eventType, tenant, namespaceSuffix, scheduleName string, data map[string]string) {
+	if s.notifier == nil {
+		return
+	}
+	event := notifications.Event{
+		Type:         eventType,
+		Source:       "api",
+		Tenant:       tenant,
+		ScheduleName: scheduleName,
+		Data:         data,
+	}
+	if namespaceSuffix != "" {
+		event.Namespace = fmt.Sprintf("%s-%s", tenant, namespaceSuffix)
+	}
+	s.notifier.Notify(event)
+}
+
+func scheduleEventData(req CreateScheduleRequest) map[string]string {
+	data := map[string]string{"off": req.Off, "on": req.On}
+	for key, value := range map[string]string{
+		"weekdays":   req.Weekdays,
+		"sleepDays":  req.SleepDays,
+		"wakeDays":   req.WakeDays,
+		"namespaces": strings.Join(req.Namespaces, ","),
+		"offCron":    req.OffCron,
+		"onCron":     req.OnCron,
+	} {
+		if value != "" {
+			data[key] = value
+		}
+	}
+	if req.DurationHours != 0 {
+		data["durationHours"] = strconv.FormatFloat(req.DurationHours, 'f', -1, 64)
+	}
+	return data
+}
+
 // CreateSchedule creates SleepInfo objects for the tenant
 func (s *ScheduleService) CreateSchedule(ctx context.Context, req CreateScheduleRequest) error {
-	return s.createSchedule(ctx, req, false)
+	if err := s.createSchedule(ctx, req, false); err != nil {
+		return err
+	}
+	s.notifyScheduleEvent(notifications.EventScheduleCreated, req.Tenant, "", req.ScheduleName, scheduleEventData(req))
+	return nil
 }
 
+// createSchedule writes the SleepInfos of the schedule. When it fails after writing some of them they are
+// rolled back, and a *ScheduleWriteError reports the failed namespaces and the rollback.
 func (s *ScheduleService) createSchedule(ctx context.Context, req CreateScheduleRequest, skipScheduleNameValidation bool) error {
+	ctx, journal := withWriteJournal(ctx)
+	if err := s.writeSchedule(ctx, req, skipScheduleNameValidation); err != nil {
+		return s.rollbackSchedule(ctx, journal, err)
+	}
+	return nil
+}
+
+func (s *ScheduleService) writeSchedule(ctx context.Context, req CreateScheduleRequest, skipScheduleNameValidation bool) error {
 	s.logger.Info("CreateSchedule CALLED", "tenant", req.Tenant, "off", req.Off, "on", req.On, "weekdays", req.Weekdays, "sleepDays", req.SleepDays, "wakeDays", req.WakeDays, "namespaces", fmt.Sprintf("%v", req.Namespaces))
 
+	if req.OffCron != "" {
+		return s.createCronSchedule(ctx, req, skipScheduleNameValidation)
+	}
+
+	// 0. A sleep set by durationHours wakes up that many hours after the off time
+	if err := req.applyDurationHours(); err != nil {
+		return err
+	}
+
 	// 1. Normalize weekdays
 	wdDefault := "0-6"
 	wdSleep := wdDefault
@@ -104,65 +190,60 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 		wdWake = wdSleep
 	}
 
-	// 2. Convert times from local timezone (America/Bogota) to UTC
-	userTZ := TZLocal  // Default to America/Bogota
-	clusterTZ := TZUTC // Default to UTC
+	// 2. Times and weekdays are kept in the user timezone, set as the timezone of the SleepInfos
+	userTZ := TZLocal // Default to America/Bogota
 
-	offConv, err := ToUTCHHMM(req.Off, userTZ)
+	offTime, err := parseClock(req.Off)
 	if err != nil {
-		s.logger.Error(err, "failed to convert off time", "off", req.Off, "userTZ", userTZ)
 		return fmt.Errorf("invalid off time: %w", err)
 	}
-	s.logger.Info("Time conversion: off", "userTime", req.Off, "clusterTime", offConv.TimeUTC, "dayShift", offConv.DayShift, "userTZ", userTZ, "clusterTZ", clusterTZ)
-
-	onConv, err := ToUTCHHMM(req.On, userTZ)
+	onTime, err := parseClock(req.On)
 	if err != nil {
-		s.logger.Error(err, "failed to convert on time", "on", req.On, "userTZ", userTZ)
 		return fmt.Errorf("invalid on time: %w", err)
 	}
-	s.logger.Info("Time conversion: on", "userTime", req.On, "clusterTime", onConv.TimeUTC, "dayShift", onConv.DayShift, "userTZ", userTZ, "clusterTZ", clusterTZ)
-
-	// 3. Adjust weekdays for timezone shift
-	wdSleepUTC, err := ShiftWeekdaysStr(wdSleep, offConv.DayShift)
-	if err != nil {
-		return fmt.Errorf("failed to shift sleep weekdays: %w", err)
-	}
-
-	wdWakeUTC, err := ShiftWeekdaysStr(wdWake, onConv.DayShift)
-	if err != nil {
-		return fmt.Errorf("failed to shift wake weekdays: %w", err)
-	}
 
-	// 4. Calculate staggered wake times based on delays
+	// 3. Calculate staggered wake times based on delays
 	// IMPORTANTE: Los delays por defecto SOLO se aplican para datastores (que tiene CRDs)
 	// Para namespaces simples (apps, rocket, intelligence, airflowsso), usar el tiempo convertido directamente
-	onPgHDFS := onConv.TimeUTC
-	onPgBouncer := onConv.TimeUTC
-	onDeployments := onConv.TimeUTC
+	onPgHDFS := onTime
+	onPgBouncer := onTime
+	onDeployments := onTime
 
 	// Solo aplicar delays si se especifican explícitamente en req.Delays
-	// Los delays por defecto (5m, 7m) SOLO se aplicarán en createDatastoresSleepInfos cuando sea necesario
+	// Los delays por defecto (5m, 7m) SOLO se aplicarán en createDatastoresSleepInfo cuando sea necesario
 	if req.Delays != nil {
 		// Parse delays and apply them
 		if req.Delays.PgHdfsDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.PgHdfsDelay)
-			onPgHDFS, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onPgHDFS, _ = AddMinutes(onTime, delayMinutes)
 		}
 		if req.Delays.PgbouncerDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.PgbouncerDelay)
-			onPgBouncer, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onPgBouncer, _ = AddMinutes(onTime, delayMinutes)
 		}
 		if req.Delays.DeploymentsDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.DeploymentsDelay)
-			onDeployments, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onDeployments, _ = AddMinutes(onTime, delayMinutes)
 		}
 	}
-	// NO aplicar delays por defecto aquí - se aplicarán solo en createDatastoresSleepInfos si es necesario
+	// NO aplicar delays por defecto aquí - se aplicarán solo en createDatastoresSleepInfo si es necesario
 
 	// 5. Determine which namespaces to process
 	selectedNamespaces := normalizeNamespaces(req.Namespaces)
 
-	// 6. Build excludeRef from exclusions (no exclusions in CreateScheduleRequest, use defaults)
+	// 6. Validate the custom exclusions and the inclusions of each namespace
+	if err := validateExclusions(req.Exclusions); err != nil {
+		return err
+	}
+	if err := validateInclusions(req.Tenant, req.Inclusions); err != nil {
+		return err
+	}
+	if err := req.Holidays.validate(); err != nil {
+		return err
+	}
+	if err := validateSleepReplicas(req.SleepReplicas); err != nil {
+		return err
+	}
 
 	// 7. Validate scheduleName uniqueness if provided
 	if req.ScheduleName != "" && !skipScheduleNameValidation {
@@ -174,19 +255,21 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 		}
 	}
 
-	if err := s.validateScheduleOverlap(ctx, req.Tenant, selectedNamespaces, wdSleepUTC, offConv.TimeUTC, onConv.TimeUTC, req.ScheduleName); err != nil {
+	if err := s.validateScheduleOverlap(ctx, req.Tenant, selectedNamespaces, wdSleep, offTime, onTime, userTZ, req.ScheduleName); err != nil {
 		return err
 	}
 
 	// 8. Create SleepInfo objects for each namespace
 	// NO iterar sobre validSuffixes hardcodeados - usar los namespaces seleccionados dinámicamente
 	s.logger.Info("CreateSchedule: processing namespaces", "count", len(selectedNamespaces), "namespaces", fmt.Sprintf("%v", selectedNamespaces))
-	for suffix := range selectedNamespaces {
+	err = forEachNamespace(selectedNamespaces, func(suffix string) error {
 		namespace := fmt.Sprintf("%s-%s", req.Tenant, suffix)
 		s.logger.Info("CreateSchedule: processing namespace", "suffix", suffix, "namespace", namespace)
 
-		// Build excludeRef from exclusions (no custom exclusions in CreateScheduleRequest)
-		excludeRefs := getExcludeRefsForOperators()
+		// Build excludeRef from the operator exclusions and the custom exclusions of the namespace
+		excludeRefs := append(getExcludeRefsForOperators(), exclusionRefsFor(req.Tenant, suffix, req.Exclusions)...)
+		includeRefs := inclusionRefsFor(req.Tenant, suffix, req.Inclusions)
+		sleepReplicas := sleepReplicasFor(req.Tenant, suffix, req.SleepReplicas)
 
 		// DYNAMIC LOGIC: Detect resources in namespace to determine what type of SleepInfos to create
 		// This replaces hardcoded switch statements and works with ANY namespace
@@ -213,11 +296,14 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 
 		// Add auto-exclusions from resource detection
 		for _, autoExcl := range resources.AutoExclusions {
-			excludeRefs = append(excludeRefs, kubegreenv1alpha1.FilterRef{
-				MatchLabels: autoExcl.MatchLabels,
-			})
+			excludeRefs = append(excludeRefs, autoExcl.toFilterRef())
 		}
 
+		// Namespace policy of the suffix: default exclusions and overrides of the detected behaviors
+		policy := s.namespacePolicy(ctx, suffix)
+		excludeRefs = append(excludeRefs, policy.excludeRefs()...)
+		hasCRDs := policy.staggeredWake(resources.hasDatastores())
+
 		// Calculate wake times - apply delays if provided, otherwise use defaults for CRDs
 		onPgHDFSFinal := onPgHDFS
 		onPgBouncerFinal := onPgBouncer
@@ -225,17 +311,16 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 
 		// If no custom delays provided, apply default staggered wake for namespaces with CRDs
 		if req.Delays == nil {
-			hasCRDs := resources.HasPgCluster || resources.HasHdfsCluster || resources.HasOsCluster || resources.HasOsDashboards || resources.HasKafkaCluster || resources.HasPgBouncer
 			if hasCRDs {
 				// Default staggered wake: PgHDFS at t0, PgBouncer at t0+5m, Deployments at t0+7m
-				onPgHDFSFinal = onConv.TimeUTC
-				onPgBouncerFinal, _ = AddMinutes(onConv.TimeUTC, 5)
-				onDeploymentsFinal, _ = AddMinutes(onConv.TimeUTC, 7)
+				onPgHDFSFinal = onTime
+				onPgBouncerFinal, _ = AddMinutes(onTime, 5)
+				onDeploymentsFinal, _ = AddMinutes(onTime, 7)
 			} else {
 				// Simple namespaces: all wake at the same time
-				onPgHDFSFinal = onConv.TimeUTC
-				onPgBouncerFinal = onConv.TimeUTC
-				onDeploymentsFinal = onConv.TimeUTC
+				onPgHDFSFinal = onTime
+				onPgBouncerFinal = onTime
+				onDeploymentsFinal = onTime
 			}
 		} else {
 			// Use custom delays from request
@@ -245,16 +330,14 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 		}
 
 		// Generate SleepInfos based on detected resources (DYNAMIC LOGIC - no hardcoded names)
-		hasCRDs := resources.HasPgCluster || resources.HasHdfsCluster || resources.HasOsCluster || resources.HasOsDashboards || resources.HasKafkaCluster || resources.HasPgBouncer
-
 		if hasCRDs {
-			// Namespace has CRDs: use staggered wake logic
-			s.logger.Info("CreateSchedule: creating staggered SleepInfos (CRDs detected)", "namespace", namespace, "hasPgCluster", resources.HasPgCluster, "hasHdfsCluster", resources.HasHdfsCluster, "hasOsCluster", resources.HasOsCluster, "hasOsDashboards", resources.HasOsDashboards, "hasKafkaCluster", resources.HasKafkaCluster, "hasPgBouncer", resources.HasPgBouncer)
-			if err := s.createDatastoresSleepInfosWithExclusions(ctx, req.Tenant, namespace, offConv.TimeUTC, onDeploymentsFinal, onPgHDFSFinal, onPgBouncerFinal, wdSleepUTC, wdWakeUTC, excludeRefs, req.ScheduleName, req.Description, userTZ); err != nil {
-				s.logger.Error(err, "failed to create staggered sleepinfos", "namespace", namespace)
-				return fmt.Errorf("failed to create staggered sleepinfos for %s: %w", namespace, err)
+			// Namespace has CRDs: the wake stages of its SleepInfo wake up the datastores first
+			s.logger.Info("CreateSchedule: creating SleepInfo with wake stages (CRDs detected)", "namespace", namespace, "hasPgCluster", resources.HasPgCluster, "hasHdfsCluster", resources.HasHdfsCluster, "hasOsCluster", resources.HasOsCluster, "hasOsDashboards", resources.HasOsDashboards, "hasKafkaCluster", resources.HasKafkaCluster, "hasPgBouncer", resources.HasPgBouncer)
+			if err := s.createDatastoresSleepInfoWithExclusions(ctx, req.Tenant, namespace, suffix, offTime, onDeploymentsFinal, onPgHDFSFinal, onPgBouncerFinal, wdSleep, wdWake, false, excludeRefs, includeRefs, sleepReplicas, req.Holidays, req.ScheduleName, req.Description, userTZ); err != nil {
+				s.logger.Error(err, "failed to create sleepinfo with wake stages", "namespace", namespace)
+				return fmt.Errorf("failed to create sleepinfo for %s: %w", namespace, err)
 			}
-			s.logger.Info("CreateSchedule: staggered SleepInfos created successfully", "namespace", namespace)
+			s.logger.Info("CreateSchedule: SleepInfo with wake stages created successfully", "namespace", namespace)
 		} else {
 			// Simple namespace without CRDs
 			// Activate suspendStatefulSets if there are StatefulSets in the namespace
@@ -266,14 +349,30 @@ func (s *ScheduleService) createSchedule(ctx context.Context, req CreateSchedule
 			if resources.HasPgCluster {
 				suspendStatefulSets = true
 			}
+			suspendStatefulSets = policy.suspendStatefulSets(suspendStatefulSets)
 
 			s.logger.Info("CreateSchedule: creating simple namespace SleepInfos", "namespace", namespace, "suspendStatefulSets", suspendStatefulSets, "statefulSetsCount", resources.ResourceCounts.StatefulSets)
-			if err := s.createNamespaceSleepInfoWithExclusions(ctx, req.Tenant, namespace, suffix, offConv.TimeUTC, onDeploymentsFinal, wdSleepUTC, wdWakeUTC, suspendStatefulSets, excludeRefs, req.ScheduleName, req.Description, userTZ); err != nil {
+			if err := s.createNamespaceSleepInfoWithExclusions(ctx, req.Tenant, namespace, suffix, offTime, onDeploymentsFinal, wdSleep, wdWake, suspendStatefulSets, false, excludeRefs, includeRefs, sleepReplicas, req.Holidays, req.ScheduleName, req.Description, userTZ); err != nil {
 				s.logger.Error(err, "failed to create namespace sleepinfo", "namespace", namespace)
 				return fmt.Errorf("failed to create sleepinfo for %s: %w", namespace, err)
 			}
 			s.logger.Info("CreateSchedule: namespace SleepInfos created successfully", "namespace", namespace)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.ExecuteOnce {
+		if err := s.setExecuteOnce(ctx, req.Tenant, selectedNamespaces, req.ScheduleName); err != nil {
+			return err
+		}
+	}
+	if len(req.KarpenterNodePools) > 0 {
+		if err := s.setKarpenterNodePools(ctx, req.Tenant, selectedNamespaces, req.ScheduleName, req.KarpenterNodePools); err != nil {
+			return err
+		}
 	}
 
 	s.logger.Info("CreateSchedule COMPLETED", "tenant", req.Tenant, "namespaces_processed", len(selectedNamespaces))
@@ -338,588 +437,134 @@ func isNamespaceSelected(selected map[string]bool, suffix string) bool {
 	return selected[suffix]
 }
 
-// createNamespaceSleepInfoWithExclusions creates a simple SleepInfo for a namespace with custom exclusions
-func (s *ScheduleService) createNamespaceSleepInfoWithExclusions(ctx context.Context, tenant, namespace, suffix, offUTC, onUTC, wdSleep, wdWake string, suspendStatefulSets bool, excludeRefs []kubegreenv1alpha1.FilterRef, scheduleName, description, userTimezone string) error {
-	// Check if weekdays are the same
-	sleepDays, _ := ExpandWeekdaysStr(wdSleep)
-	wakeDays, _ := ExpandWeekdaysStr(wdWake)
-
-	daysEqual := len(sleepDays) == len(wakeDays)
-	if daysEqual {
-		for i, d := range sleepDays {
-			if i >= len(wakeDays) || d != wakeDays[i] {
-				daysEqual = false
-				break
-			}
-		}
-	}
-
-	var sleepInfo *kubegreenv1alpha1.SleepInfo
-
-	if daysEqual {
-		// Single SleepInfo with sleepAt and wakeUpAt
-		suspendDeployments := true
-		suspendCronJobs := true
-
-		// Generate name based on scheduleName or default pattern
-		name := fmt.Sprintf("%s-%s", tenant, suffix)
-		if scheduleName != "" {
-			name = scheduleName
-		}
-
-		// Initialize annotations with schedule name and description
-		// Build annotations including userTimezone
-		annotations := make(map[string]string)
-		if scheduleName != "" {
-			annotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			annotations["kube-green.stratio.com/schedule-description"] = description
-		}
-		// Store userTimezone in annotations for easy access
-		if userTimezone != "" {
-			annotations["kube-green.stratio.com/user-timezone"] = userTimezone
-			s.logger.Info("createNamespaceSleepInfoWithExclusions: ADDED user-timezone to single SleepInfo annotations", "name", name, "namespace", namespace, "userTimezone", userTimezone)
-		} else {
-			s.logger.Error(nil, "createNamespaceSleepInfoWithExclusions: userTimezone is EMPTY for single SleepInfo", "name", name, "namespace", namespace)
-		}
-
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: creating single SleepInfo", "name", name, "namespace", namespace, "userTimezone", userTimezone, "hasUserTimezoneAnnotation", annotations["kube-green.stratio.com/user-timezone"] != "")
-		sleepInfo = &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        name,
-				Namespace:   namespace,
-				Annotations: annotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:           wdSleep,
-				SleepTime:          offUTC,
-				WakeUpTime:         onUTC,
-				TimeZone:           "UTC",
-				SuspendDeployments: &suspendDeployments,
-				SuspendStatefulSets: func() *bool {
-					b := suspendStatefulSets
-					return &b
-				}(),
-				SuspendCronjobs: suspendCronJobs,
-			},
-		}
-
-		// Virtualizer exclusion removed - can be configured manually from frontend if needed
-		// No automatic exclusion is applied
-
-		if len(excludeRefs) > 0 {
-			sleepInfo.Spec.ExcludeRef = excludeRefs
+// forEachNamespace calls fn for every selected namespace suffix, with at most namespaceConcurrency
+// namespaces at a time. It returns the errors of every failed namespace, as *NamespaceError in the
+// order of the suffixes.
+func forEachNamespace(selected map[string]bool, fn func(suffix string) error) error {
+	suffixes := sortedKeys(selected)
+	errs := make([]error, len(suffixes))
+	runConcurrently(len(suffixes), namespaceConcurrency, func(i int) {
+		if err := fn(suffixes[i]); err != nil {
+			errs[i] = &NamespaceError{Namespace: suffixes[i], Err: err}
 		}
-	} else {
-		// Separate SleepInfos for sleep and wake
-		sharedID := fmt.Sprintf("%s-%s", tenant, suffix)
-		if scheduleName != "" {
-			sharedID = scheduleName
-		}
-
-		suspendDeployments := true
-		suspendCronJobs := true
-
-		// Generate names based on scheduleName or default pattern
-		sleepName := fmt.Sprintf("sleep-%s-%s", tenant, suffix)
-		wakeName := fmt.Sprintf("wake-%s-%s", tenant, suffix)
-		if scheduleName != "" {
-			sleepName = fmt.Sprintf("sleep-%s", scheduleName)
-			wakeName = fmt.Sprintf("wake-%s", scheduleName)
-		}
-
-		// Initialize annotations with schedule name, description, and userTimezone
-		sleepAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "sleep",
-		}
-		wakeAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-			wakeAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-description"] = description
-			wakeAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-		// Store userTimezone in annotations for easy access
-		if userTimezone != "" {
-			sleepAnnotations["kube-green.stratio.com/user-timezone"] = userTimezone
-			wakeAnnotations["kube-green.stratio.com/user-timezone"] = userTimezone
-			s.logger.Info("createNamespaceSleepInfoWithExclusions: ADDED user-timezone to annotations", "name", sleepName, "namespace", namespace, "userTimezone", userTimezone, "sleepAnnotations", fmt.Sprintf("%+v", sleepAnnotations))
-		} else {
-			s.logger.Error(nil, "createNamespaceSleepInfoWithExclusions: userTimezone is EMPTY, not adding to annotations", "name", sleepName, "namespace", namespace)
-		}
-
-		// Sleep SleepInfo
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: creating sleep SleepInfo", "name", sleepName, "namespace", namespace, "userTimezone", userTimezone, "hasUserTimezoneAnnotation", sleepAnnotations["kube-green.stratio.com/user-timezone"] != "")
-		sleepSleepInfo := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        sleepName,
-				Namespace:   namespace,
-				Annotations: sleepAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:           wdSleep,
-				SleepTime:          offUTC,
-				TimeZone:           "UTC",
-				SuspendDeployments: &suspendDeployments,
-				SuspendStatefulSets: func() *bool {
-					b := suspendStatefulSets
-					return &b
-				}(),
-				SuspendCronjobs: suspendCronJobs,
-			},
-		}
-
-		// Wake SleepInfo
-		wakeSleepInfo := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakeName,
-				Namespace:   namespace,
-				Annotations: wakeAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:           wdWake,
-				SleepTime:          onUTC,
-				TimeZone:           "UTC",
-				SuspendDeployments: &suspendDeployments,
-				SuspendStatefulSets: func() *bool {
-					b := suspendStatefulSets
-					return &b
-				}(),
-				SuspendCronjobs: suspendCronJobs,
-			},
-		}
-
-		// Virtualizer exclusion removed - can be configured manually from frontend if needed
-		// No automatic exclusion is applied
+	})
+	return errors.Join(errs...)
+}
 
-		if len(excludeRefs) > 0 {
-			sleepSleepInfo.Spec.ExcludeRef = excludeRefs
-			wakeSleepInfo.Spec.ExcludeRef = excludeRefs
-		}
+// createNamespaceSleepInfoWithExclusions creates the SleepInfo of a namespace with custom exclusions
+func (s *ScheduleService) createNamespaceSleepInfoWithExclusions(ctx context.Context, tenant, namespace, suffix, offTime, onTime, wdSleep, wdWake string, suspendStatefulSets, suspendFlink bool, excludeRefs, includeRefs []kubegreenv1alpha1.FilterRef, sleepReplicas []kubegreenv1alpha1.SleepReplicas, holidays *HolidayConfig, scheduleName, description, userTimezone string) error {
+	suspendDeployments := true
 
-		// Create or update both SleepInfos
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: creating/updating sleep SleepInfo", "name", sleepSleepInfo.Name, "namespace", sleepSleepInfo.Namespace, "sleepTime", sleepSleepInfo.Spec.SleepTime, "weekdays", sleepSleepInfo.Spec.Weekdays)
-		if err := s.createOrUpdateSleepInfo(ctx, sleepSleepInfo, userTimezone); err != nil {
-			s.logger.Error(err, "failed to create/update sleep SleepInfo", "name", sleepSleepInfo.Name, "namespace", sleepSleepInfo.Namespace)
-			return err
-		}
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: sleep SleepInfo created/updated successfully", "name", sleepSleepInfo.Name, "namespace", sleepSleepInfo.Namespace)
+	// Generate name based on scheduleName or default pattern
+	name := fmt.Sprintf("%s-%s", tenant, suffix)
+	if scheduleName != "" {
+		name = scheduleName
+	}
 
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: creating/updating wake SleepInfo", "name", wakeSleepInfo.Name, "namespace", wakeSleepInfo.Namespace, "sleepTime", wakeSleepInfo.Spec.SleepTime, "weekdays", wakeSleepInfo.Spec.Weekdays)
-		if err := s.createOrUpdateSleepInfo(ctx, wakeSleepInfo, userTimezone); err != nil {
-			s.logger.Error(err, "failed to create/update wake SleepInfo", "name", wakeSleepInfo.Name, "namespace", wakeSleepInfo.Namespace)
-			return err
-		}
-		s.logger.Info("createNamespaceSleepInfoWithExclusions: wake SleepInfo created/updated successfully", "name", wakeSleepInfo.Name, "namespace", wakeSleepInfo.Namespace)
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: scheduleAnnotations(scheduleName, description, userTimezone),
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			TimeZone:            userTimezone,
+			SuspendDeployments:  &suspendDeployments,
+			SuspendStatefulSets: &suspendStatefulSets,
+			SuspendCronjobs:     true,
+			SuspendFlink:        flinkSuspension(suspendFlink),
+			SleepReplicas:       sleepReplicas,
+		},
+	}
+	if err := setWeeklySchedule(&sleepInfo.Spec, offTime, onTime, wdSleep, wdWake); err != nil {
+		return err
+	}
 
-		return nil
+	// Virtualizer exclusion removed - can be configured manually from frontend if needed
+	// No automatic exclusion is applied
+	if len(excludeRefs) > 0 {
+		sleepInfo.Spec.ExcludeRef = excludeRefs
+	}
+	if len(includeRefs) > 0 {
+		sleepInfo.Spec.IncludeRef = includeRefs
 	}
+	holidays.apply(&sleepInfo.Spec, userTimezone)
 
-	// Create or update the SleepInfo (single SleepInfo case)
-	s.logger.Info("createNamespaceSleepInfoWithExclusions: creating/updating single SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays)
+	s.logger.Info("createNamespaceSleepInfoWithExclusions: creating/updating SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays)
 	if err := s.createOrUpdateSleepInfo(ctx, sleepInfo, userTimezone); err != nil {
 		s.logger.Error(err, "failed to create/update SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
 		return err
 	}
 	s.logger.Info("createNamespaceSleepInfoWithExclusions: SleepInfo created/updated successfully", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
 
-	return nil
+	// The sleep/wake pair of the schedule created by previous versions is replaced by the SleepInfo
+	return s.deleteLegacyPairedSleepInfos(ctx, namespace, name)
 }
 
-// createDatastoresSleepInfosWithExclusions creates the complex SleepInfos for datastores namespace with custom exclusions
-func (s *ScheduleService) createDatastoresSleepInfosWithExclusions(ctx context.Context, tenant, namespace, offUTC, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake string, excludeRefs []kubegreenv1alpha1.FilterRef, scheduleName, description, userTimezone string) error {
-	suspendDeployments := true
-	suspendStatefulSets := true
-	suspendCronJobs := true
-	suspendPgbouncer := true
-	suspendPostgres := true
-	suspendHdfs := true
-	suspendOpenSearch := true
-	suspendOsDashboards := true
-	suspendKafka := true
-
-	// Check if weekdays are the same
-	sleepDays, _ := ExpandWeekdaysStr(wdSleep)
-	wakeDays, _ := ExpandWeekdaysStr(wdWake)
-
-	daysEqual := len(sleepDays) == len(wakeDays)
-	if daysEqual {
-		for i, d := range sleepDays {
-			if i >= len(wakeDays) || d != wakeDays[i] {
-				daysEqual = false
-				break
-			}
-		}
-	}
+// createDatastoresSleepInfoWithExclusions creates the SleepInfo of a namespace with datastores and custom
+// exclusions. Its wake stages wake up the datastores at onPgHDFS, PgBouncer at onPgBouncer and the
+// applications at onDeployments.
+func (s *ScheduleService) createDatastoresSleepInfoWithExclusions(ctx context.Context, tenant, namespace, suffix, offTime, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake string, suspendFlink bool, excludeRefs, includeRefs []kubegreenv1alpha1.FilterRef, sleepReplicas []kubegreenv1alpha1.SleepReplicas, holidays *HolidayConfig, scheduleName, description, userTimezone string) error {
+	suspend := true
 
-	sharedID := fmt.Sprintf("%s-datastores", tenant)
+	// Generate name based on scheduleName or default pattern
+	name := fmt.Sprintf("%s-%s", tenant, suffix)
 	if scheduleName != "" {
-		sharedID = scheduleName
+		name = scheduleName
 	}
 
-	if daysEqual {
-		// Single sleep SleepInfo with all resources
-		// Generate name based on scheduleName or default pattern
-		sleepName := fmt.Sprintf("sleep-ds-deploys-%s", tenant)
-		if scheduleName != "" {
-			sleepName = fmt.Sprintf("sleep-%s", scheduleName)
-		}
-
-		// Initialize annotations with schedule name, description, and userTimezone
-		sleepAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "sleep",
-		}
-		if scheduleName != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-		// Store userTimezone in annotations for easy access
-		if userTimezone != "" {
-			sleepAnnotations["kube-green.stratio.com/user-timezone"] = userTimezone
-		}
-
-		sleepInfo := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        sleepName,
-				Namespace:   namespace,
-				Annotations: sleepAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                        wdSleep,
-				SleepTime:                       offUTC,
-				TimeZone:                        "UTC",
-				SuspendDeployments:              &suspendDeployments,
-				SuspendStatefulSets:             &suspendStatefulSets,
-				SuspendCronjobs:                 suspendCronJobs,
-				SuspendDeploymentsPgbouncer:     &suspendPgbouncer,
-				SuspendStatefulSetsPostgres:     &suspendPostgres,
-				SuspendStatefulSetsHdfs:         &suspendHdfs,
-				SuspendStatefulSetsOpenSearch:   &suspendOpenSearch,
-				SuspendStatefulSetsOsDashboards: &suspendOsDashboards,
-				SuspendStatefulSetsKafka:        &suspendKafka,
-				ExcludeRef:                      excludeRefs,
-			},
-		}
-
-		// Create wake SleepInfos (staged)
-		// 1. Postgres and HDFS first
-		wakePgHdfsName := fmt.Sprintf("wake-ds-deploys-%s-pg-hdfs", tenant)
-		if scheduleName != "" {
-			wakePgHdfsName = fmt.Sprintf("wake-%s-pg-hdfs", scheduleName)
-		}
-
-		wakePgHdfsAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakePgHdfsAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakePgHdfsAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		// Wake PgHDFS: debe tener suspendDeployments=False, suspendStatefulSets=False, suspendCronJobs=False, suspendDeploymentsPgbouncer=False
-		// pero suspendStatefulSetsPostgres=True, suspendStatefulSetsHdfs=True, suspendStatefulSetsOpenSearch=True, suspendStatefulSetsOsDashboards=True y suspendStatefulSetsKafka=True
-		// (para restaurar solo Postgres, HDFS, OpenSearch, OsDashboards y Kafka)
-		suspendDeploymentsFalse := false
-		suspendStatefulSetsFalse := false
-		suspendCronJobsFalse := false
-		suspendPgbouncerFalse := false
-
-		wakePgHdfs := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakePgHdfsName,
-				Namespace:   namespace,
-				Annotations: wakePgHdfsAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                        wdWake,
-				SleepTime:                       onPgHDFS,
-				TimeZone:                        "UTC",
-				SuspendDeployments:              &suspendDeploymentsFalse,
-				SuspendStatefulSets:             &suspendStatefulSetsFalse,
-				SuspendCronjobs:                 suspendCronJobsFalse,
-				SuspendDeploymentsPgbouncer:     &suspendPgbouncerFalse,
-				SuspendStatefulSetsPostgres:     &suspendPostgres,
-				SuspendStatefulSetsHdfs:         &suspendHdfs,
-				SuspendStatefulSetsOpenSearch:   &suspendOpenSearch,
-				SuspendStatefulSetsOsDashboards: &suspendOsDashboards,
-				SuspendStatefulSetsKafka:        &suspendKafka,
-				ExcludeRef:                      excludeRefs,
-			},
-		}
-
-		// 2. PgBouncer second
-		wakePgbouncerName := fmt.Sprintf("wake-ds-deploys-%s-pgbouncer", tenant)
-		if scheduleName != "" {
-			wakePgbouncerName = fmt.Sprintf("wake-%s-pgbouncer", scheduleName)
-		}
-
-		wakePgbouncerAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakePgbouncerAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakePgbouncerAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		// Wake PgBouncer: debe tener suspendDeployments=False, suspendStatefulSets=False, suspendCronJobs=False
-		// pero suspendDeploymentsPgbouncer=True (para restaurar solo PgBouncer)
-		wakePgbouncer := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakePgbouncerName,
-				Namespace:   namespace,
-				Annotations: wakePgbouncerAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                    wdWake,
-				SleepTime:                   onPgBouncer,
-				TimeZone:                    "UTC",
-				SuspendDeployments:          &suspendDeploymentsFalse,
-				SuspendStatefulSets:         &suspendStatefulSetsFalse,
-				SuspendCronjobs:             suspendCronJobsFalse,
-				SuspendDeploymentsPgbouncer: &suspendPgbouncer,
-				SuspendStatefulSetsPostgres: &suspendStatefulSetsFalse,
-				SuspendStatefulSetsHdfs:     &suspendStatefulSetsFalse,
-				ExcludeRef:                  excludeRefs,
-			},
-		}
-
-		// 3. Native deployments last
-		wakeDeploymentsName := fmt.Sprintf("wake-ds-deploys-%s", tenant)
-		if scheduleName != "" {
-			wakeDeploymentsName = fmt.Sprintf("wake-%s", scheduleName)
-		}
-
-		wakeDeploymentsAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakeDeploymentsAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakeDeploymentsAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		wakeDeployments := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakeDeploymentsName,
-				Namespace:   namespace,
-				Annotations: wakeDeploymentsAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                    wdWake,
-				SleepTime:                   onDeployments,
-				TimeZone:                    "UTC",
-				SuspendDeployments:          &suspendDeployments,
-				SuspendStatefulSets:         &suspendStatefulSets,
-				SuspendCronjobs:             suspendCronJobs,
-				SuspendDeploymentsPgbouncer: &suspendPgbouncer,
-				ExcludeRef:                  excludeRefs,
-			},
-		}
-
-		sleepInfos := []*kubegreenv1alpha1.SleepInfo{sleepInfo, wakePgHdfs, wakePgbouncer, wakeDeployments}
-		for _, si := range sleepInfos {
-			if err := s.createOrUpdateSleepInfo(ctx, si, userTimezone); err != nil {
-				return err
-			}
-		}
-	} else {
-		// Different weekdays: create separate sleep/wake SleepInfos with staggered sleepAt
-		// Sleep: suspend ALL resources with wdSleep
-		sleepName := fmt.Sprintf("sleep-ds-deploys-%s", tenant)
-		if scheduleName != "" {
-			sleepName = fmt.Sprintf("sleep-%s", scheduleName)
-		}
-
-		sleepAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "sleep",
-		}
-		if scheduleName != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			sleepAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		sleepInfo := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        sleepName,
-				Namespace:   namespace,
-				Annotations: sleepAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                        wdSleep,
-				SleepTime:                       offUTC,
-				TimeZone:                        "UTC",
-				SuspendDeployments:              &suspendDeployments,
-				SuspendStatefulSets:             &suspendStatefulSets,
-				SuspendCronjobs:                 suspendCronJobs,
-				SuspendDeploymentsPgbouncer:     &suspendPgbouncer,
-				SuspendStatefulSetsPostgres:     &suspendPostgres,
-				SuspendStatefulSetsHdfs:         &suspendHdfs,
-				SuspendStatefulSetsOpenSearch:   &suspendOpenSearch,
-				SuspendStatefulSetsOsDashboards: &suspendOsDashboards,
-				SuspendStatefulSetsKafka:        &suspendKafka,
-				ExcludeRef:                      excludeRefs,
-			},
-		}
-
-		// Wake staggered: create separate SleepInfos by type with wdWake
-		// 1. Wake PgCluster + HDFSCluster first (onPgHDFS)
-		wakePgHdfsName := fmt.Sprintf("wake-ds-deploys-%s-pg-hdfs", tenant)
-		if scheduleName != "" {
-			wakePgHdfsName = fmt.Sprintf("wake-%s-pg-hdfs", scheduleName)
-		}
-
-		wakePgHdfsAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakePgHdfsAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakePgHdfsAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		// Wake PgHDFS: debe tener suspendDeployments=False, suspendStatefulSets=False, suspendCronJobs=False, suspendDeploymentsPgbouncer=False
-		// pero suspendStatefulSetsPostgres=True, suspendStatefulSetsHdfs=True, suspendStatefulSetsOpenSearch=True, suspendStatefulSetsOsDashboards=True y suspendStatefulSetsKafka=True
-		// (para restaurar solo Postgres, HDFS, OpenSearch, OsDashboards y Kafka)
-		suspendDeploymentsFalse := false
-		suspendStatefulSetsFalse := false
-		suspendCronJobsFalse := false
-		suspendPgbouncerFalse := false
-
-		wakePgHdfs := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakePgHdfsName,
-				Namespace:   namespace,
-				Annotations: wakePgHdfsAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                        wdWake,
-				SleepTime:                       onPgHDFS,
-				TimeZone:                        "UTC",
-				SuspendDeployments:              &suspendDeploymentsFalse,
-				SuspendStatefulSets:             &suspendStatefulSetsFalse,
-				SuspendCronjobs:                 suspendCronJobsFalse,
-				SuspendDeploymentsPgbouncer:     &suspendPgbouncerFalse,
-				SuspendStatefulSetsPostgres:     &suspendPostgres,
-				SuspendStatefulSetsHdfs:         &suspendHdfs,
-				SuspendStatefulSetsOpenSearch:   &suspendOpenSearch,
-				SuspendStatefulSetsOsDashboards: &suspendOsDashboards,
-				SuspendStatefulSetsKafka:        &suspendKafka,
-				ExcludeRef:                      excludeRefs,
-			},
-		}
-
-		// 2. Wake PgBouncer second (onPgBouncer)
-		wakePgbouncerName := fmt.Sprintf("wake-ds-deploys-%s-pgbouncer", tenant)
-		if scheduleName != "" {
-			wakePgbouncerName = fmt.Sprintf("wake-%s-pgbouncer", scheduleName)
-		}
-
-		wakePgbouncerAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakePgbouncerAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakePgbouncerAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
-
-		// Wake PgBouncer: debe tener suspendDeployments=False, suspendStatefulSets=False, suspendCronJobs=False
-		// pero suspendDeploymentsPgbouncer=True (para restaurar solo PgBouncer)
-		wakePgbouncer := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakePgbouncerName,
-				Namespace:   namespace,
-				Annotations: wakePgbouncerAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                    wdWake,
-				SleepTime:                   onPgBouncer,
-				TimeZone:                    "UTC",
-				SuspendDeployments:          &suspendDeploymentsFalse,
-				SuspendStatefulSets:         &suspendStatefulSetsFalse,
-				SuspendCronjobs:             suspendCronJobsFalse,
-				SuspendDeploymentsPgbouncer: &suspendPgbouncer,
-				SuspendStatefulSetsPostgres: &suspendStatefulSetsFalse,
-				SuspendStatefulSetsHdfs:     &suspendStatefulSetsFalse,
-				ExcludeRef:                  excludeRefs,
-			},
-		}
-
-		// 3. Wake native deployments last (onDeployments)
-		wakeDeploymentsName := fmt.Sprintf("wake-ds-deploys-%s", tenant)
-		if scheduleName != "" {
-			wakeDeploymentsName = fmt.Sprintf("wake-%s", scheduleName)
-		}
+	onTime, wakeStages := datastoresWakeStages(onPgHDFS, onPgBouncer, onDeployments)
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: scheduleAnnotations(scheduleName, description, userTimezone),
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			TimeZone:                        userTimezone,
+			SuspendDeployments:              &suspend,
+			SuspendStatefulSets:             &suspend,
+			SuspendCronjobs:                 true,
+			SuspendDeploymentsPgbouncer:     &suspend,
+			SuspendStatefulSetsPostgres:     &suspend,
+			SuspendStatefulSetsHdfs:         &suspend,
+			SuspendStatefulSetsOpenSearch:   &suspend,
+			SuspendStatefulSetsOsDashboards: &suspend,
+			SuspendStatefulSetsKafka:        &suspend,
+			SuspendFlink:                    flinkSuspension(suspendFlink),
+			ExcludeRef:                      excludeRefs,
+			IncludeRef:                      includeRefs,
+			SleepReplicas:                   sleepReplicas,
+			WakeStages:                      wakeStages,
+		},
+	}
+	if err := setWeeklySchedule(&sleepInfo.Spec, offTime, onTime, wdSleep, wdWake); err != nil {
+		return err
+	}
+	holidays.apply(&sleepInfo.Spec, userTimezone)
 
-		wakeDeploymentsAnnotations := map[string]string{
-			"kube-green.stratio.com/pair-id":   sharedID,
-			"kube-green.stratio.com/pair-role": "wake",
-		}
-		if scheduleName != "" {
-			wakeDeploymentsAnnotations["kube-green.stratio.com/schedule-name"] = scheduleName
-		}
-		if description != "" {
-			wakeDeploymentsAnnotations["kube-green.stratio.com/schedule-description"] = description
-		}
+	s.logger.Info("createDatastoresSleepInfoWithExclusions: creating/updating SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays, "wakeStages", len(wakeStages))
+	if err := s.createOrUpdateSleepInfo(ctx, sleepInfo, userTimezone); err != nil {
+		return err
+	}
 
-		wakeDeployments := &kubegreenv1alpha1.SleepInfo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        wakeDeploymentsName,
-				Namespace:   namespace,
-				Annotations: wakeDeploymentsAnnotations,
-			},
-			Spec: kubegreenv1alpha1.SleepInfoSpec{
-				Weekdays:                    wdWake,
-				SleepTime:                   onDeployments,
-				TimeZone:                    "UTC",
-				SuspendDeployments:          &suspendDeployments,
-				SuspendStatefulSets:         &suspendStatefulSets,
-				SuspendCronjobs:             suspendCronJobs,
-				SuspendDeploymentsPgbouncer: &suspendPgbouncer, // TRUE to restore PgBouncer during WAKE
-				ExcludeRef:                  excludeRefs,
-			},
-		}
+	// Previous versions created a sleep SleepInfo and one wake SleepInfo per stage, paired by pair-id
+	return s.deleteLegacyPairedSleepInfos(ctx, namespace, name, fmt.Sprintf("%s-datastores", tenant))
+}
 
-		sleepInfos := []*kubegreenv1alpha1.SleepInfo{sleepInfo, wakePgHdfs, wakePgbouncer, wakeDeployments}
-		for _, si := range sleepInfos {
-			if err := s.createOrUpdateSleepInfo(ctx, si, userTimezone); err != nil {
-				return err
-			}
-		}
+// flinkSuspension returns the suspendFlink of the SleepInfos of a schedule, unset when the schedule
+// does not suspend the FlinkDeployments
+func flinkSuspension(suspendFlink bool) *bool {
+	if !suspendFlink {
+		return nil
 	}
-
-	return nil
+	return &suspendFlink
 }
 
-// createDatastoresSleepInfos creates the complex SleepInfos for datastores namespace (wrapper for backward compatibility)
+// createDatastoresSleepInfo creates the SleepInfo of a namespace with datastores (wrapper for backward compatibility)
 // IMPORTANTE: Si los tiempos no tienen delays aplicados (onDeployments == onPgHDFS == onPgBouncer),
 // aplicar delays por defecto (5m para PgBouncer, 7m para Deployments) como en tenant_power.py
-func (s *ScheduleService) createDatastoresSleepInfos(ctx context.Context, tenant, namespace, offUTC, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake string, scheduleName, description, userTimezone string) error {
+func (s *ScheduleService) createDatastoresSleepInfo(ctx context.Context, tenant, namespace, suffix, offTime, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake string, scheduleName, description, userTimezone string) error {
 	excludeRefs := getExcludeRefsForOperators()
 
 	// Si todos los tiempos son iguales, significa que no se aplicaron delays
@@ -928,16 +573,16 @@ func (s *ScheduleService) createDatastoresSleepInfos(ctx context.Context, tenant
 		// Aplicar delays por defecto: PgHDFS a t0, PgBouncer a t0+5m, Deployments a t0+7m
 		onPgBouncer, _ = AddMinutes(onPgHDFS, 5)
 		onDeployments, _ = AddMinutes(onPgHDFS, 7)
-		s.logger.Info("createDatastoresSleepInfos: applying default delays", "onPgHDFS", onPgHDFS, "onPgBouncer", onPgBouncer, "onDeployments", onDeployments)
+		s.logger.Info("createDatastoresSleepInfo: applying default delays", "onPgHDFS", onPgHDFS, "onPgBouncer", onPgBouncer, "onDeployments", onDeployments)
 	}
 
-	return s.createDatastoresSleepInfosWithExclusions(ctx, tenant, namespace, offUTC, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake, excludeRefs, scheduleName, description, userTimezone)
+	return s.createDatastoresSleepInfoWithExclusions(ctx, tenant, namespace, suffix, offTime, onDeployments, onPgHDFS, onPgBouncer, wdSleep, wdWake, false, excludeRefs, nil, nil, nil, scheduleName, description, userTimezone)
 }
 
 // createNamespaceSleepInfo creates a simple SleepInfo for a namespace (wrapper for backward compatibility)
-func (s *ScheduleService) createNamespaceSleepInfo(ctx context.Context, tenant, namespace, suffix, offUTC, onUTC, wdSleep, wdWake string, suspendStatefulSets bool, scheduleName, description, userTimezone string) error {
+func (s *ScheduleService) createNamespaceSleepInfo(ctx context.Context, tenant, namespace, suffix, offTime, onTime, wdSleep, wdWake string, suspendStatefulSets bool, scheduleName, description, userTimezone string) error {
 	excludeRefs := getExcludeRefsForOperators()
-	return s.createNamespaceSleepInfoWithExclusions(ctx, tenant, namespace, suffix, offUTC, onUTC, wdSleep, wdWake, suspendStatefulSets, excludeRefs, scheduleName, description, userTimezone)
+	return s.createNamespaceSleepInfoWithExclusions(ctx, tenant, namespace, suffix, offTime, onTime, wdSleep, wdWake, suspendStatefulSets, false, excludeRefs, nil, nil, nil, scheduleName, description, userTimezone)
 }
 
 // getExcludeRefsForOperators returns exclude refs for operator-managed resources
@@ -958,11 +603,14 @@ func (s *ScheduleService) validateScheduleNameUniqueness(ctx context.Context, na
 		return nil
 	}
 
-	// List all SleepInfo objects in the namespace
+	// List the SleepInfos of the namespace matching the schedule name through the ScheduleNameIndex,
+	// or all of them when the index is not registered
 	var sleepInfoList kubegreenv1alpha1.SleepInfoList
-	if err := s.reader.List(ctx, &sleepInfoList, client.InNamespace(namespace)); err != nil {
-		// If namespace doesn't exist or error, skip validation (will fail later during creation)
-		return nil
+	if err := s.client.List(ctx, &sleepInfoList, client.InNamespace(namespace), client.MatchingFields{ScheduleNameIndex: scheduleName}); err != nil {
+		if err := s.reader.List(ctx, &sleepInfoList, client.InNamespace(namespace)); err != nil {
+			// If namespace doesn't exist or error, skip validation (will fail later during creation)
+			return nil
+		}
 	}
 
 	// Check if any SleepInfo has the same schedule name in annotations
@@ -975,262 +623,94 @@ func (s *ScheduleService) validateScheduleNameUniqueness(ctx context.Context, na
 	return nil
 }
 
-// createOrUpdateSleepInfo creates or updates a SleepInfo and its associated secret
+// createOrUpdateSleepInfo creates or updates a SleepInfo with server-side apply, and its associated secret
 func (s *ScheduleService) createOrUpdateSleepInfo(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, userTimezone string) error {
+	if err := s.setTenantScheduleOwner(ctx, sleepInfo); err != nil {
+		return err
+	}
 	var existing kubegreenv1alpha1.SleepInfo
 	err := s.reader.Get(ctx, client.ObjectKeyFromObject(sleepInfo), &existing)
-	if err != nil {
-		if client.IgnoreNotFound(err) == nil {
-			// Not found, create
-			// IMPORTANTE: Asegurar que user-timezone esté en las anotaciones si se proporciona
-			if userTimezone != "" {
-				if sleepInfo.Annotations == nil {
-					sleepInfo.Annotations = make(map[string]string)
-				}
-				// Solo agregar si no está presente (para no sobrescribir si ya está)
-				if _, exists := sleepInfo.Annotations["kube-green.stratio.com/user-timezone"]; !exists {
-					sleepInfo.Annotations["kube-green.stratio.com/user-timezone"] = userTimezone
-					s.logger.Info("createOrUpdateSleepInfo: ADDED user-timezone to annotations before creating", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "userTimezone", userTimezone)
-				}
-			}
-			userTZInAnnotations := ""
-			if sleepInfo.Annotations != nil {
-				userTZInAnnotations = sleepInfo.Annotations["kube-green.stratio.com/user-timezone"]
-			}
-			s.logger.Info("createOrUpdateSleepInfo: creating new SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays, "userTimezoneParam", userTimezone, "userTimezoneInAnnotations", userTZInAnnotations, "annotationsCount", len(sleepInfo.Annotations))
-			if err := s.client.Create(ctx, sleepInfo); err != nil {
-				s.logger.Error(err, "failed to create SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-				return err
-			}
-			s.logger.Info("createOrUpdateSleepInfo: SleepInfo created successfully", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-			// After Create(), the sleepInfo object should have the UID populated by the Kubernetes API
-			// However, if it's not available, try to get it with a retry
-			if sleepInfo.UID == "" {
-				// Retry getting the created SleepInfo to obtain its UID
-				var created kubegreenv1alpha1.SleepInfo
-				maxRetries := 3
-				for i := 0; i < maxRetries; i++ {
-					if err := s.client.Get(ctx, client.ObjectKeyFromObject(sleepInfo), &created); err == nil {
-						sleepInfo.UID = created.UID
-						break
-					}
-					if i < maxRetries-1 {
-						// Wait a bit before retrying (exponential backoff: 10ms, 20ms, 40ms)
-						time.Sleep(time.Duration(10*(1<<uint(i))) * time.Millisecond)
-					}
-				}
-				if sleepInfo.UID == "" {
-					s.logger.Error(fmt.Errorf("failed to get UID after %d retries", maxRetries), "failed to get created SleepInfo for UID", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-					// Continue without creating secret - controller will create it
-					return nil
-				}
-			}
-			// Create associated secret after SleepInfo is created (now we have the UID)
-			// CRITICAL: Always create the secret, retry if needed
-			secretCreated := false
-			maxSecretRetries := 5
-			for i := 0; i < maxSecretRetries; i++ {
-				if err := s.createOrUpdateSecretForSleepInfo(ctx, sleepInfo, userTimezone); err != nil {
-					s.logger.Error(err, "failed to create secret for SleepInfo (retry)", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "attempt", i+1, "maxRetries", maxSecretRetries)
-					if i < maxSecretRetries-1 {
-						// Wait before retrying (exponential backoff: 50ms, 100ms, 200ms, 400ms, 800ms)
-						time.Sleep(time.Duration(50*(1<<uint(i))) * time.Millisecond)
-						// Try to get the SleepInfo again to ensure we have the latest UID
-						var updated kubegreenv1alpha1.SleepInfo
-						if err := s.client.Get(ctx, client.ObjectKeyFromObject(sleepInfo), &updated); err == nil {
-							sleepInfo.UID = updated.UID
-						}
-					}
-				} else {
-					secretCreated = true
-					s.logger.Info("Secret created successfully for SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "attempt", i+1)
-					break
-				}
-			}
-			if !secretCreated {
-				s.logger.Error(fmt.Errorf("failed to create secret after %d retries", maxSecretRetries), "CRITICAL: Secret not created for SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-				// Don't fail the entire operation - controller will create it, but log as error
-			}
-			return nil
-		}
+	if err == nil && !existing.DeletionTimestamp.IsZero() {
+		// A terminating SleepInfo, e.g. waking up its resources before the finalizer is removed, is
+		// never updated: the new spec would be lost with it. It is created again once deleted.
+		err = s.waitForSleepInfoDeletion(ctx, client.ObjectKeyFromObject(sleepInfo))
+	}
+	if client.IgnoreNotFound(err) != nil {
 		return err
 	}
+	found := err == nil
 
-	// Exists, update
-	s.logger.Info("createOrUpdateSleepInfo: updating existing SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays)
-
-	// IMPORTANTE: Merge inteligente de anotaciones
-	// 1. Inicializar annotations si es nil
+	// The annotations set by other writers are kept by the apply; the user timezone of the request
+	// replaces the existing one, which is kept when the request has none
 	if sleepInfo.Annotations == nil {
 		sleepInfo.Annotations = make(map[string]string)
 	}
-
-	// 2. Guardar las anotaciones nuevas que vienen en sleepInfo (schedule-name, schedule-description, etc.)
-	newAnnotations := make(map[string]string)
-	for k, v := range sleepInfo.Annotations {
-		newAnnotations[k] = v
-	}
-
-	// 3. Copiar TODAS las anotaciones existentes primero para preservarlas
-	for k, v := range existing.Annotations {
-		sleepInfo.Annotations[k] = v
-	}
-
-	// 4. SOBRESCRIBIR con las anotaciones nuevas (schedule-name, schedule-description, pair-id, pair-role, etc.)
-	// Esto asegura que las anotaciones del request tengan prioridad
-	for k, v := range newAnnotations {
-		sleepInfo.Annotations[k] = v
-	}
-
-	// 5. Actualizar userTimezone en las anotaciones si se proporciona
-	// Si no se proporciona, preservar el existente de las anotaciones
 	timezoneToUse := userTimezone
-	if timezoneToUse == "" {
-		// Leer userTimezone de las anotaciones existentes
-		if existingTZ, ok := sleepInfo.Annotations["kube-green.stratio.com/user-timezone"]; ok {
-			timezoneToUse = existingTZ
-			s.logger.Info("createOrUpdateSleepInfo: using timezone from existing annotations", "timezone", timezoneToUse, "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-		}
-	} else {
-		// Agregar/actualizar user-timezone
+	if timezoneToUse != "" {
 		sleepInfo.Annotations["kube-green.stratio.com/user-timezone"] = timezoneToUse
-		s.logger.Info("createOrUpdateSleepInfo: updating timezone in annotations", "timezone", timezoneToUse, "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
+	} else if found {
+		timezoneToUse = existing.Annotations["kube-green.stratio.com/user-timezone"]
 	}
 
-	// Log para debug
-	s.logger.Info("createOrUpdateSleepInfo: merged annotations", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace,
-		"scheduleName", sleepInfo.Annotations["kube-green.stratio.com/schedule-name"],
-		"description", sleepInfo.Annotations["kube-green.stratio.com/schedule-description"],
-		"userTimezone", sleepInfo.Annotations["kube-green.stratio.com/user-timezone"],
-		"totalAnnotations", len(sleepInfo.Annotations))
-
-	sleepInfo.ResourceVersion = existing.ResourceVersion
-	if err := s.client.Update(ctx, sleepInfo); err != nil {
-		s.logger.Error(err, "failed to update SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-		return err
-	}
-	s.logger.Info("createOrUpdateSleepInfo: SleepInfo updated successfully", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-
-	// Update associated secret - CRITICAL: Always update/create the secret
-	// Use the timezone from request if available, otherwise use the one from existing annotations
-	if err := s.createOrUpdateSecretForSleepInfo(ctx, sleepInfo, timezoneToUse); err != nil {
-		s.logger.Error(err, "CRITICAL: failed to update secret for SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-		// Retry once
-		time.Sleep(100 * time.Millisecond)
-		if err := s.createOrUpdateSecretForSleepInfo(ctx, sleepInfo, timezoneToUse); err != nil {
-			s.logger.Error(err, "CRITICAL: failed to update secret after retry", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
-			// Don't fail the entire operation - controller will update it, but log as error
-		} else {
-			s.logger.Info("Secret updated successfully after retry", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
+	if found {
+		s.logger.Info("createOrUpdateSleepInfo: updating existing SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays)
+		// Keep the schedule paused through spec.suspend and run once
+		if sleepInfo.Spec.Suspend == nil {
+			sleepInfo.Spec.Suspend = existing.Spec.Suspend
+		}
+		if sleepInfo.Spec.ExecuteOnce == nil {
+			sleepInfo.Spec.ExecuteOnce = existing.Spec.ExecuteOnce
+			sleepInfo.Spec.DeleteWhenCompleted = existing.Spec.DeleteWhenCompleted
+		}
+		if sleepInfo.Spec.Karpenter == nil {
+			sleepInfo.Spec.Karpenter = existing.Spec.Karpenter
+		}
+		if err := s.adoptLegacySpec(ctx, &existing); err != nil {
+			return err
 		}
 	} else {
-		s.logger.Info("Secret updated successfully for SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
+		s.logger.Info("createOrUpdateSleepInfo: creating new SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "sleepTime", sleepInfo.Spec.SleepTime, "wakeTime", sleepInfo.Spec.WakeUpTime, "weekdays", sleepInfo.Spec.Weekdays, "userTimezone", timezoneToUse)
 	}
-	return nil
-}
-
-// createOrUpdateSecretForSleepInfo creates or updates the secret associated with a SleepInfo
-func (s *ScheduleService) createOrUpdateSecretForSleepInfo(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, userTimezone string) error {
-	s.logger.Info("createOrUpdateSecretForSleepInfo CALLED", "sleepInfo", sleepInfo.Name, "namespace", sleepInfo.Namespace, "userTimezone", userTimezone, "userTimezoneEmpty", userTimezone == "")
-	secretName := fmt.Sprintf("sleepinfo-%s", sleepInfo.Name)
 
-	// Determine operation type based on SleepInfo annotations or spec
-	operationType := "sleep"
-	if role, ok := sleepInfo.Annotations["kube-green.stratio.com/pair-role"]; ok && role == "wake" {
-		operationType = "wake"
-	} else if sleepInfo.Spec.WakeUpTime != "" && sleepInfo.Spec.SleepTime == "" {
-		// If only WakeUpTime is set, it's a wake operation
-		operationType = "wake"
+	// Taken after the adoption, so a rollback applies again the fields the legacy spec had
+	var previous *kubegreenv1alpha1.SleepInfo
+	if found {
+		previous = existing.DeepCopy()
 	}
-
-	// Get current time for scheduled-at (use time.Now() for RFC3339 format)
-	now := time.Now()
-
-	// Check if secret already exists
-	var existingSecret v1.Secret
-	secretKey := client.ObjectKey{
-		Name:      secretName,
-		Namespace: sleepInfo.Namespace,
-	}
-	err := s.client.Get(ctx, secretKey, &existingSecret)
-	secretExists := err == nil
-
-	// Build secret
-	secret := &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: sleepInfo.Namespace,
-			Labels: map[string]string{
-				"app.kubernetes.io/managed-by": "kube-green",
-			},
-		},
-		StringData: map[string]string{
-			"scheduled-at":   now.Format(time.RFC3339), // RFC3339 format
-			"operation-type": operationType,
-		},
-		Data: make(map[string][]byte),
+	recordAppliedSchedule(sleepInfo)
+	if err := s.applySleepInfo(ctx, sleepInfo); err != nil {
+		s.logger.Error(err, "failed to apply SleepInfo", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace)
+		return err
 	}
+	recordSleepInfoWrite(ctx, client.ObjectKeyFromObject(sleepInfo), previous)
+	s.logger.Info("createOrUpdateSleepInfo: SleepInfo applied successfully", "name", sleepInfo.Name, "namespace", sleepInfo.Namespace, "created", !found)
 
-	// Add userTimezone to secret if provided
-	if userTimezone != "" {
-		secret.StringData["user-timezone"] = userTimezone
-		s.logger.Info("createOrUpdateSecretForSleepInfo: adding user-timezone to secret", "userTimezone", userTimezone, "secret", secretName, "namespace", sleepInfo.Namespace)
-	} else {
-		s.logger.Error(nil, "createOrUpdateSecretForSleepInfo: userTimezone is empty, not adding to secret", "secret", secretName, "namespace", sleepInfo.Namespace)
-	}
+	// The sleepinfo-<name> secret is written by the controller only, which copies the user timezone and
+	// the schedule name from the annotations
+	return nil
+}
 
-	// Add OwnerReference only if UID is available
-	if sleepInfo.UID != "" {
-		secret.OwnerReferences = []metav1.OwnerReference{
-			{
-				APIVersion: kubegreenv1alpha1.GroupVersion.String(),
-				Kind:       "SleepInfo",
-				Name:       sleepInfo.Name,
-				UID:        sleepInfo.UID,
-			},
-		}
-	}
+// sleepInfoDeletionTimeout is how long an upsert waits for a terminating SleepInfo of the same name
+const sleepInfoDeletionTimeout = 30 * time.Second
 
-	// If secret exists, preserve existing original-resource-info if present
-	if secretExists {
-		secret.ResourceVersion = existingSecret.ResourceVersion
-		// CRITICAL: Preserve existing original-resource-info - this contains deployment/statefulset state
-		// that the controller saved during sleep operation. We should NEVER overwrite this.
-		if originalData, ok := existingSecret.Data["original-resource-info"]; ok {
-			secret.Data["original-resource-info"] = originalData
+// waitForSleepInfoDeletion waits until the terminating SleepInfo is deleted, returning its not found
+// error, or fails when it is still terminating after sleepInfoDeletionTimeout
+func (s *ScheduleService) waitForSleepInfoDeletion(ctx context.Context, key client.ObjectKey) error {
+	s.logger.Info("waiting for the deletion of the SleepInfo", "name", key.Name, "namespace", key.Namespace)
+	var notFound error
+	err := wait.PollUntilContextTimeout(ctx, 500*time.Millisecond, sleepInfoDeletionTimeout, true, func(ctx context.Context) (bool, error) {
+		var terminating kubegreenv1alpha1.SleepInfo
+		err := s.reader.Get(ctx, key, &terminating)
+		if apierrors.IsNotFound(err) {
+			notFound = err
+			return true, nil
 		}
-		// Also preserve any other existing data fields (except user-timezone which we update)
-		for key, value := range existingSecret.Data {
-			if key != "original-resource-info" && key != "scheduled-at" && key != "operation-type" && key != "user-timezone" {
-				secret.Data[key] = value
-			}
-		}
-		// Update user-timezone if provided
-		if userTimezone != "" {
-			secret.StringData["user-timezone"] = userTimezone
-		}
-		// Update the secret
-		if err := s.client.Update(ctx, secret); err != nil {
-			s.logger.Error(err, "CRITICAL: failed to update secret", "secret", secretName, "namespace", sleepInfo.Namespace, "operationType", operationType)
-			return fmt.Errorf("failed to update secret %s in namespace %s: %w", secretName, sleepInfo.Namespace, err)
-		}
-		s.logger.Info("Secret updated successfully", "secret", secretName, "namespace", sleepInfo.Namespace, "operationType", operationType, "scheduledAt", now.Format(time.RFC3339))
-		return nil
-	}
-
-	// Create new secret
-	// NOTE: original-resource-info will be added by the controller when it executes sleep operation
-	stringDataKeys := make([]string, 0, len(secret.StringData))
-	for k := range secret.StringData {
-		stringDataKeys = append(stringDataKeys, k)
-	}
-	s.logger.Info("createOrUpdateSecretForSleepInfo: creating new secret", "secret", secretName, "namespace", sleepInfo.Namespace, "userTimezone", userTimezone, "stringDataKeys", strings.Join(stringDataKeys, ","))
-	if err := s.client.Create(ctx, secret); err != nil {
-		s.logger.Error(err, "CRITICAL: failed to create secret", "secret", secretName, "namespace", sleepInfo.Namespace, "operationType", operationType)
-		return fmt.Errorf("failed to create secret %s in namespace %s: %w", secretName, sleepInfo.Namespace, err)
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("SleepInfo %s in namespace %s is being deleted, retry once its resources are woken up: %w", key.Name, key.Namespace, err)
 	}
-	s.logger.Info("Secret created successfully", "secret", secretName, "namespace", sleepInfo.Namespace, "operationType", operationType, "scheduledAt", now.Format(time.RFC3339), "userTimezone", userTimezone)
-	return nil
+	return notFound
 }
 
 // ScheduleResponse represents a schedule for a tenant
@@ -1260,50 +740,127 @@ type ScheduleSummary struct {
 
 // FilterRef represents a filter for excluding resources
 type FilterRef struct {
-	MatchLabels map[string]string `json:"matchLabels"`
+	APIVersion       string                            `json:"apiVersion,omitempty"`
+	Kind             string                            `json:"kind,omitempty"`
+	Name             string                            `json:"name,omitempty"`
+	MatchLabels      map[string]string                 `json:"matchLabels"`
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // SleepInfoSummary represents a summary of a SleepInfo
 type SleepInfoSummary struct {
-	Name                 string            `json:"name"`
-	Namespace            string            `json:"namespace"`
-	Role                 string            `json:"role"`      // "sleep" or "wake"
-	Operation            string            `json:"operation"` // Human-readable description
-	Time                 string            `json:"time"`      // Sleep or wake time (UTC)
-	Weekdays             string            `json:"weekdays"`
-	TimeZone             string            `json:"timeZone"`     // Cluster timezone (always "UTC")
-	UserTimezone         string            `json:"userTimezone"` // User timezone (e.g. "America/Bogota") — authoritative source, no annotation parsing needed
-	Resources            []string          `json:"resources"` // List of resources managed (Postgres, HDFS, PgBouncer, Deployments, etc.)
-	WakeTime             string            `json:"wakeTime,omitempty"`
-	ScheduleName         string            `json:"scheduleName,omitempty"` // Schedule name if set
-	Description          string            `json:"description,omitempty"`  // Schedule description if set
-	Annotations          map[string]string `json:"annotations,omitempty"`
-	ExcludeRef           []FilterRef       `json:"excludeRef,omitempty"`           // Exclusion filters
-	SuspendScheduleUntil *time.Time        `json:"suspendScheduleUntil,omitempty"` // Non-nil when schedule is temporarily suspended
-}
-
-// ListSchedules lists all schedules grouped by tenant
-func (s *ScheduleService) ListSchedules(ctx context.Context) ([]ScheduleResponse, error) {
-	// List all SleepInfos across all namespaces
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+	Name                 string                `json:"name"`
+	Namespace            string                `json:"namespace"`
+	Role                 string                `json:"role"`      // "sleep" or "wake"
+	Operation            string                `json:"operation"` // Human-readable description
+	Time                 string                `json:"time"`      // Sleep or wake time, in TimeZone
+	Weekdays             string                `json:"weekdays"`
+	TimeZone             string                `json:"timeZone"`     // Timezone of Time, WakeTime and Weekdays (the user timezone, "UTC" for older schedules)
+	UserTimezone         string                `json:"userTimezone"` // User timezone (e.g. "America/Bogota") — authoritative source, no annotation parsing needed
+	Resources            []string              `json:"resources"`    // List of resources managed (Postgres, HDFS, PgBouncer, Deployments, etc.)
+	WakeTime             string                `json:"wakeTime,omitempty"`
+	WakeStage            string                `json:"wakeStage,omitempty"`    // Wake stage of the SleepInfo woken up at Time, if any
+	ScheduleName         string                `json:"scheduleName,omitempty"` // Schedule name if set
+	Description          string                `json:"description,omitempty"`  // Schedule description if set
+	Annotations          map[string]string     `json:"annotations,omitempty"`
+	ExcludeRef           []FilterRef           `json:"excludeRef,omitempty"`           // Exclusion filters
+	IncludeRef           []FilterRef           `json:"includeRef,omitempty"`           // Inclusion filters, only matching resources sleep
+	SleepReplicas        []SleepReplicasConfig `json:"sleepReplicas,omitempty"`        // Replicas kept during sleep instead of zero
+	SuspendScheduleUntil *time.Time            `json:"suspendScheduleUntil,omitempty"` // Non-nil when schedule is temporarily suspended
+	Paused               bool                  `json:"paused,omitempty"`               // True when the schedule is paused until resumed
+	Suspend              bool                  `json:"suspend,omitempty"`              // True when paused through spec.suspend
+	ExecuteOnce          bool                  `json:"executeOnce,omitempty"`          // True when the schedule runs once and is then deleted
+	CompletedAt          *time.Time            `json:"completedAt,omitempty"`          // When an executeOnce SleepInfo ran its operations
+	Window               *OneTimeWindow        `json:"window,omitempty"`               // One-time sleep, deleted once over
+	Holidays             *HolidayConfig        `json:"holidays,omitempty"`             // Holiday calendar and policy, if set
+	KarpenterNodePools   []string              `json:"karpenterNodePools,omitempty"`   // Karpenter NodePools scaled to zero while asleep
+	Drifted              bool                  `json:"drifted,omitempty"`              // True when the spec was changed since the API applied it, e.g. by kubectl edit
+	Drift                []DriftField          `json:"drift,omitempty"`                // Fields of the spec changed since the API applied it
+}
+
+// ListSchedulesOptions holds the optional filters and pagination parameters for ListSchedules.
+type ListSchedulesOptions struct {
+	TenantPrefix    string // Only tenants whose name starts with this prefix
+	NamespaceSuffix string // Only SleepInfos in namespaces with this suffix (datastores, apps, ...)
+	ScheduleName    string // Only SleepInfos belonging to this schedule name
+	Limit           int    // Maximum number of tenants per page (0 = no limit)
+	Continue        string // Opaque token returned by the previous page
+}
+
+// ScheduleListResponse is a page of schedules grouped by tenant
+type ScheduleListResponse struct {
+	Items    []ScheduleResponse `json:"items"`
+	Continue string             `json:"continue,omitempty"` // Token to request the next page, empty on the last page
+	Total    int                `json:"total"`              // Total number of tenants matching the filters
+}
+
+// encodeContinueToken builds the opaque continue token from the last tenant of a page
+func encodeContinueToken(tenant string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(tenant))
+}
+
+// decodeContinueToken returns the last tenant of the previous page
+func decodeContinueToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid continue token: %w", err)
+	}
+	return string(decoded), nil
+}
+
+// ListSchedules lists schedules grouped by tenant, sorted by tenant name.
+// Filters are applied before pagination; Limit counts tenants, not SleepInfos,
+// so a tenant is never split across pages.
+func (s *ScheduleService) ListSchedules(ctx context.Context, opts ListSchedulesOptions) (*ScheduleListResponse, error) {
+	if opts.Limit < 0 {
+		return nil, fmt.Errorf("invalid limit: %d", opts.Limit)
+	}
+	after, err := decodeContinueToken(opts.Continue)
+	if err != nil {
+		return nil, err
 	}
 
-	// Group by tenant (extract from namespace: tenant-suffix)
+	// List all SleepInfos across all namespaces, or only the ones of the schedule name through its index
+	var sleepInfos []kubegreenv1alpha1.SleepInfo
+	if opts.ScheduleName != "" {
+		sleepInfos, err = s.listSleepInfosByScheduleName(ctx, opts.ScheduleName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ScheduleName == "" {
+		sleepInfos, err = s.listCachedSleepInfos(ctx, resolver, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Group by tenant, from the namespace labels or the {tenant}-{suffix} name
 	tenantMap := make(map[string]map[string][]kubegreenv1alpha1.SleepInfo)
 
-	for _, si := range sleepInfoList.Items {
-		// Extract tenant from namespace (e.g., "bdadevdat-datastores" -> "bdadevdat")
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
+	for _, si := range sleepInfos {
+		tenant, suffix, ok := resolver.split(si.Namespace)
+		if !ok {
 			continue // Skip namespaces that don't match tenant-suffix pattern
 		}
 
-		// Reconstruct tenant (handle cases like "bdadevdat-datastores")
-		// Take all parts except the last one as tenant
-		tenant := strings.Join(nsParts[:len(nsParts)-1], "-")
-		suffix := nsParts[len(nsParts)-1]
+		if opts.TenantPrefix != "" && !strings.HasPrefix(tenant, opts.TenantPrefix) {
+			continue
+		}
+		if opts.NamespaceSuffix != "" && suffix != opts.NamespaceSuffix {
+			continue
+		}
+		if opts.ScheduleName != "" && !matchesScheduleName(si, opts.ScheduleName) {
+			continue
+		}
 
 		if tenantMap[tenant] == nil {
 			tenantMap[tenant] = make(map[string][]kubegreenv1alpha1.SleepInfo)
@@ -1312,52 +869,70 @@ func (s *ScheduleService) ListSchedules(ctx context.Context) ([]ScheduleResponse
 		tenantMap[tenant][suffix] = append(tenantMap[tenant][suffix], si)
 	}
 
+	tenants := make([]string, 0, len(tenantMap))
+	for tenant := range tenantMap {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+
+	// Skip tenants already returned in previous pages
+	start := sort.SearchStrings(tenants, after)
+	if after != "" && start < len(tenants) && tenants[start] == after {
+		start++
+	}
+	end := len(tenants)
+	if opts.Limit > 0 && start+opts.Limit < end {
+		end = start + opts.Limit
+	}
+
 	// Convert to response format
-	result := make([]ScheduleResponse, 0, len(tenantMap))
-	for tenant, namespaceGroups := range tenantMap {
+	response := &ScheduleListResponse{
+		Items: make([]ScheduleResponse, 0, end-start),
+		Total: len(tenants),
+	}
+	for _, tenant := range tenants[start:end] {
 		namespaces := make(map[string]NamespaceInfo)
-		for suffix, sleepInfos := range namespaceGroups {
+		for suffix, sleepInfos := range tenantMap[tenant] {
 			namespaces[suffix] = s.buildNamespaceInfo(ctx, sleepInfos)
 		}
-		result = append(result, ScheduleResponse{
+		response.Items = append(response.Items, ScheduleResponse{
 			Tenant:     tenant,
 			Namespaces: namespaces,
 		})
 	}
+	if end < len(tenants) {
+		response.Continue = encodeContinueToken(tenants[end-1])
+	}
 
-	return result, nil
+	return response, nil
 }
 
 // GetSchedule gets all SleepInfos for a specific tenant
 func (s *ScheduleService) GetSchedule(ctx context.Context, tenant string, namespaceSuffix ...string) (*ScheduleResponse, error) {
-	// List all SleepInfos
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
-	}
-
 	namespaces := make(map[string]NamespaceInfo)
 	var filterNamespace string
 	if len(namespaceSuffix) > 0 && namespaceSuffix[0] != "" {
 		filterNamespace = namespaceSuffix[0]
 	}
 
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sleepInfos, err := s.listCachedSleepInfos(ctx, resolver, tenant)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter by tenant and group by namespace suffix
 	namespaceGroups := make(map[string][]kubegreenv1alpha1.SleepInfo)
-	for _, si := range sleepInfoList.Items {
+	for _, si := range sleepInfos {
 		// Extract tenant from namespace
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
-			continue
-		}
-
-		tenantFromNS := strings.Join(nsParts[:len(nsParts)-1], "-")
-		if tenantFromNS != tenant {
+		tenantFromNS, suffix, ok := resolver.split(si.Namespace)
+		if !ok || tenantFromNS != tenant {
 			continue
 		}
 
-		suffix := nsParts[len(nsParts)-1]
-
 		// Filter by namespace suffix if provided
 		if filterNamespace != "" && suffix != filterNamespace {
 			continue
@@ -1431,7 +1006,7 @@ func (s *ScheduleService) buildNamespaceInfo(ctx context.Context, sleepInfos []k
 	}
 
 	// IMPORTANTE: NO convertir weekdays aquí - el frontend hace la conversión
-	// Los weekdays se devuelven en UTC tal como están almacenados en el cluster
+	// Los weekdays se devuelven tal como están almacenados en el cluster, en la timezone de spec.timeZone
 	// El frontend tiene la lógica para convertir de UTC a user timezone usando el tiempo y dayShift
 	weekdaysUser := first.Spec.Weekdays
 
@@ -1443,26 +1018,29 @@ func (s *ScheduleService) buildNamespaceInfo(ctx context.Context, sleepInfos []k
 		Description:  description,
 	}
 
+	locale := LocaleFromContext(ctx)
+
 	// Build summaries for each SleepInfo
 	summaries := make([]SleepInfoSummary, 0, len(sleepInfos))
 	var sleepTime, wakeTime string
 	var operations []string
 
 	for _, si := range sleepInfos {
-		summary := s.buildSleepInfoSummary(ctx, si)
-		summaries = append(summaries, summary)
+		for _, summary := range s.sleepInfoSummaries(ctx, si) {
+			summaries = append(summaries, summary)
 
-		// Track times for summary
-		if summary.Role == "sleep" && sleepTime == "" {
-			sleepTime = summary.Time
-		}
-		if summary.Role == "wake" {
-			if wakeTime == "" || summary.Time > wakeTime {
-				wakeTime = summary.Time
+			// Track times for summary
+			if summary.Role == "sleep" && sleepTime == "" {
+				sleepTime = summary.Time
+			}
+			if summary.Role == "wake" {
+				if wakeTime == "" || summary.Time > wakeTime {
+					wakeTime = summary.Time
+				}
+				operations = append(operations, fmt.Sprintf("%s %s %s (%s)", summary.Operation, T(locale, "at"), summary.Time, strings.Join(summary.Resources, ", ")))
+			} else if summary.Role == "sleep" {
+				operations = append(operations, fmt.Sprintf("%s %s %s", summary.Operation, T(locale, "at"), summary.Time))
 			}
-			operations = append(operations, fmt.Sprintf("%s a las %s (%s)", summary.Operation, summary.Time, strings.Join(summary.Resources, ", ")))
-		} else if summary.Role == "sleep" {
-			operations = append(operations, fmt.Sprintf("%s a las %s", summary.Operation, summary.Time))
 		}
 	}
 
@@ -1475,7 +1053,7 @@ func (s *ScheduleService) buildNamespaceInfo(ctx context.Context, sleepInfos []k
 		SleepTime:   sleepTime,
 		WakeTime:    wakeTime,
 		Operations:  operations,
-		Description: buildScheduleDescription(summaries),
+		Description: buildScheduleDescription(locale, summaries),
 	}
 
 	return nsInfo
@@ -1484,14 +1062,14 @@ func (s *ScheduleService) buildNamespaceInfo(ctx context.Context, sleepInfos []k
 // buildSleepInfoSummary creates a SleepInfoSummary from a SleepInfo
 // It reads the associated Secret to extract userTimezone and adds it to annotations in the response
 func (s *ScheduleService) buildSleepInfoSummary(ctx context.Context, si kubegreenv1alpha1.SleepInfo) SleepInfoSummary {
+	locale := LocaleFromContext(ctx)
+
 	// Determine role from annotations or name
 	role := "wake"
-	operation := "Encender servicios"
 	if pairRole, ok := si.Annotations["kube-green.stratio.com/pair-role"]; ok {
 		role = pairRole
 	} else if strings.HasPrefix(si.Name, "sleep-") {
 		role = "sleep"
-		operation = "Apagar servicios"
 	}
 
 	// Determine time based on role
@@ -1511,21 +1089,19 @@ func (s *ScheduleService) buildSleepInfoSummary(ctx context.Context, si kubegree
 	}
 
 	// Determine resources managed
-	resources := determineManagedResources(si, role)
+	resources := determineManagedResources(locale, si, role)
 
 	// Build operation description
-	operation = buildOperationDescription(role, resources)
+	operation := buildOperationDescription(locale, role, resources)
 
 	// Convert ExcludeRef to FilterRef format for API response
 	excludeRefs := make([]FilterRef, 0)
 	// IMPORTANTE: Verificar si ExcludeRef está presente y copiar correctamente
 	if si.Spec.ExcludeRef != nil && len(si.Spec.ExcludeRef) > 0 {
 		for _, excl := range si.Spec.ExcludeRef {
-			// Asegurarse de que MatchLabels no es nil
-			if excl.MatchLabels != nil && len(excl.MatchLabels) > 0 {
-				excludeRefs = append(excludeRefs, FilterRef{
-					MatchLabels: excl.MatchLabels,
-				})
+			// Asegurarse de que el filtro selecciona algún recurso
+			if filter := exclusionFromFilterRef(excl); !filter.isEmpty() {
+				excludeRefs = append(excludeRefs, filter.toAPIFilterRef())
 			}
 		}
 	}
@@ -1571,37 +1147,53 @@ func (s *ScheduleService) buildSleepInfoSummary(ctx context.Context, si kubegree
 	}
 
 	// IMPORTANTE: NO convertir weekdays aquí - el frontend hace la conversión
-	// Los weekdays se devuelven en UTC tal como están almacenados en el cluster
+	// Los weekdays se devuelven tal como están almacenados en el cluster, en la timezone de spec.timeZone
 	// El frontend tiene la lógica para convertir de UTC a user timezone usando el tiempo y dayShift
 	weekdaysUser := si.Spec.Weekdays
 
 	summary := SleepInfoSummary{
-		Name:         si.Name,
-		Namespace:    si.Namespace,
-		Role:         role,
-		Operation:    operation,
-		Time:         time,
-		Weekdays:     weekdaysUser,
-		TimeZone:     si.Spec.TimeZone,
-		UserTimezone: userTimezone, // top-level, no annotation parsing needed by frontend
-		WakeTime:     si.Spec.WakeUpTime,
-		Resources:    resources,
-		ScheduleName: scheduleName,
-		Description:  description,
-		Annotations:  annotations,
-		ExcludeRef:   excludeRefs,
+		Name:          si.Name,
+		Namespace:     si.Namespace,
+		Role:          role,
+		Operation:     operation,
+		Time:          time,
+		Weekdays:      weekdaysUser,
+		TimeZone:      si.Spec.TimeZone,
+		UserTimezone:  userTimezone, // top-level, no annotation parsing needed by frontend
+		WakeTime:      si.Spec.WakeUpTime,
+		Resources:     resources,
+		ScheduleName:  scheduleName,
+		Description:   description,
+		Annotations:   annotations,
+		ExcludeRef:    excludeRefs,
+		IncludeRef:    toFilterRefs(si.Spec.IncludeRef),
+		SleepReplicas: sleepReplicasOf(si),
+		Holidays:      holidaysOf(si),
 	}
 
 	if si.Spec.SuspendScheduleUntil != nil {
 		t := si.Spec.SuspendScheduleUntil.Time
 		summary.SuspendScheduleUntil = &t
 	}
+	summary.Paused = si.IsSuspended()
+	summary.Suspend = si.IsSuspended()
+	summary.ExecuteOnce = si.IsExecuteOnce()
+	if si.IsCompleted() {
+		t := si.Status.CompletedAt.Time
+		summary.CompletedAt = &t
+	}
+	if summary.Window = windowOf(si); summary.Window != nil {
+		summary.Role = "window"
+	}
+	summary.KarpenterNodePools = karpenterNodePoolsOf(si)
+	summary.Drift = sleepInfoDrift(si)
+	summary.Drifted = summary.Drift != nil
 
 	return summary
 }
 
 // determineManagedResources determines which resources are managed by a SleepInfo
-func determineManagedResources(si kubegreenv1alpha1.SleepInfo, role string) []string {
+func determineManagedResources(locale Locale, si kubegreenv1alpha1.SleepInfo, role string) []string {
 	var resources []string
 
 	// Check CRD-specific flags
@@ -1626,51 +1218,47 @@ func determineManagedResources(si kubegreenv1alpha1.SleepInfo, role string) []st
 		resources = append(resources, "CronJobs")
 	}
 
-	// If no specific resources, check role
+	// If no specific resources, the SleepInfo handles every service of the namespace
 	if len(resources) == 0 {
-		if role == "sleep" {
-			resources = []string{"Todos los servicios"}
-		} else {
-			resources = []string{"Todos los servicios"}
-		}
+		resources = []string{T(locale, "allServices")}
 	}
 
 	return resources
 }
 
 // buildOperationDescription creates a human-readable operation description
-func buildOperationDescription(role string, resources []string) string {
-	action := "Encender"
+func buildOperationDescription(locale Locale, role string, resources []string) string {
+	action := T(locale, "action.wake")
 	if role == "sleep" {
-		action = "Apagar"
+		action = T(locale, "action.sleep")
 	}
 
 	if len(resources) == 0 {
-		return fmt.Sprintf("%s servicios", action)
+		return fmt.Sprintf("%s %s", action, T(locale, "services"))
 	}
 
 	if len(resources) == 1 {
 		return fmt.Sprintf("%s %s", action, resources[0])
 	}
 
-	// Join all except last with comma, last with "y"
+	// Join all except last with comma, last with "y" / "and"
 	if len(resources) == 2 {
-		return fmt.Sprintf("%s %s y %s", action, resources[0], resources[1])
+		return fmt.Sprintf("%s %s %s %s", action, resources[0], T(locale, "and"), resources[1])
 	}
 
 	allButLast := strings.Join(resources[:len(resources)-1], ", ")
-	return fmt.Sprintf("%s %s y %s", action, allButLast, resources[len(resources)-1])
+	return fmt.Sprintf("%s %s %s %s", action, allButLast, T(locale, "and"), resources[len(resources)-1])
 }
 
 // buildScheduleDescription creates a human-readable description of the schedule
-func buildScheduleDescription(summaries []SleepInfoSummary) string {
+func buildScheduleDescription(locale Locale, summaries []SleepInfoSummary) string {
 	if len(summaries) == 0 {
-		return "Sin programación configurada"
+		return T(locale, "noSchedule")
 	}
 
 	var parts []string
 	for _, s := range summaries {
-		parts = append(parts, fmt.Sprintf("%s a las %s", s.Operation, s.Time))
+		parts = append(parts, fmt.Sprintf("%s %s %s", s.Operation, T(locale, "at"), s.Time))
 	}
 	return strings.Join(parts, " → ")
 }
@@ -1698,6 +1286,18 @@ func sortSummariesByTime(summaries []SleepInfoSummary) {
 // UpdateSchedule updates schedules for a tenant
 // If fields are empty, they will be extracted from existing schedule
 func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req CreateScheduleRequest, namespaceSuffix ...string) error {
+	if err := s.updateSchedule(ctx, tenant, req, namespaceSuffix...); err != nil {
+		return err
+	}
+	filterNamespace := ""
+	if len(namespaceSuffix) > 0 {
+		filterNamespace = namespaceSuffix[0]
+	}
+	s.notifyScheduleEvent(notifications.EventScheduleUpdated, tenant, filterNamespace, req.ScheduleName, scheduleEventData(req))
+	return nil
+}
+
+func (s *ScheduleService) updateSchedule(ctx context.Context, tenant string, req CreateScheduleRequest, namespaceSuffix ...string) error {
 	// LOG CRÍTICO: Confirmar que la función se está ejecutando
 	s.logger.Info("UpdateSchedule CALLED", "tenant", tenant, "req.Off", req.Off, "req.On", req.On, "req.Namespaces", fmt.Sprintf("%v", req.Namespaces))
 
@@ -1716,6 +1316,7 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 		s.logger.Info("UpdateSchedule: extracting times from existing schedule", "off_empty", req.Off == "", "on_empty", req.On == "")
 		existing, err := s.GetSchedule(ctx, tenant, filterNamespace)
 		if err == nil && existing != nil {
+			withWeeklyClocks(existing)
 			// Get timezones for conversion (default to America/Bogota -> UTC)
 			userTZ := TZLocal
 			clusterTZ := TZUTC
@@ -1845,6 +1446,10 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 	} else {
 		s.logger.Info("UpdateSchedule: using times from request", "off", req.Off, "on", req.On)
 	}
+	// The on time of a sleep set by durationHours follows the off time, sent or extracted
+	if err := req.applyDurationHours(); err != nil {
+		return err
+	}
 
 	// IMPORTANTE: Si req.Namespaces está vacío, obtener todos los namespaces del schedule existente
 	// Esto asegura que se actualicen todos los namespaces que tienen schedules, no solo los que el frontend envía
@@ -1853,6 +1458,7 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 		s.logger.Info("UpdateSchedule: req.Namespaces is empty, extracting from existing schedule", "tenant", tenant, "namespace", filterNamespace)
 		existing, err := s.GetSchedule(ctx, tenant, filterNamespace)
 		if err == nil && existing != nil {
+			withWeeklyClocks(existing)
 			existingSchedule = existing
 			// Extraer todos los namespaces que tienen schedules
 			namespacesList := make([]string, 0, len(existing.Namespaces))
@@ -1869,6 +1475,7 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 		// Obtener schedule existente (no hay Delays en CreateScheduleRequest)
 		existing, err := s.GetSchedule(ctx, tenant, filterNamespace)
 		if err == nil && existing != nil {
+			withWeeklyClocks(existing)
 			existingSchedule = existing
 		}
 	}
@@ -2020,18 +1627,27 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 		}
 	}
 
-	// IMPORTANTE: Eliminar SleepInfos antiguos ANTES de crear los nuevos
-	// Esto asegura que los cambios se reflejen correctamente, especialmente cuando cambian los weekdays
-	// o cuando se cambia de un schedule único a múltiples SleepInfos (o viceversa)
-	// EXCEPCIÓN: Si el edit solo ajusta horarios (mismo scheduleName y namespaces),
-	// se preservan SleepInfos para no perder restore patches.
+	// Preservar los includeRef existentes si el request no define inclusiones
+	if req.Inclusions == nil && existingSchedule != nil {
+		req.Inclusions = existingInclusions(existingSchedule)
+	}
+	// Preservar las réplicas durante el sleep si el request no las define
+	if req.SleepReplicas == nil && existingSchedule != nil {
+		req.SleepReplicas = existingSleepReplicas(existingSchedule)
+	}
+	// Preservar el calendario de festivos existente si el request no lo define
+	if req.Holidays == nil && existingSchedule != nil {
+		req.Holidays = existingHolidays(existingSchedule)
+	}
+
+	// The SleepInfos of the schedule are updated in place, keeping the restore patches of the namespaces
+	// asleep. Only the ones no longer in the schedule, after a change of its name or namespaces, are
+	// deleted, once the new ones are written.
 	existingScheduleName := ""
-	existingNamespaces := map[string]bool{}
 	if existingSchedule != nil {
-		for nsSuffix, nsInfo := range existingSchedule.Namespaces {
-			existingNamespaces[nsSuffix] = true
+		for _, nsInfo := range existingSchedule.Namespaces {
 			if existingScheduleName != "" {
-				continue
+				break
 			}
 			for _, sched := range nsInfo.Schedule {
 				if sched.ScheduleName != "" {
@@ -2047,79 +1663,51 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 			}
 		}
 	}
-
-	requestedNamespaces := map[string]bool{}
-	for _, ns := range req.Namespaces {
-		if ns != "" {
-			requestedNamespaces[ns] = true
-		}
-	}
-
-	sameNamespaces := len(existingNamespaces) == len(requestedNamespaces)
-	if sameNamespaces {
-		for ns := range existingNamespaces {
-			if !requestedNamespaces[ns] {
-				sameNamespaces = false
-				break
-			}
-		}
-	}
-
-	scheduleNameMatches := req.ScheduleName == existingScheduleName
-	if existingScheduleName == "" && req.ScheduleName == "" {
-		scheduleNameMatches = true
-	}
-
-	preserveExisting := existingSchedule != nil && sameNamespaces && scheduleNameMatches
-	if preserveExisting {
-		s.logger.Info("UpdateSchedule: preserving existing SleepInfos to keep restore patches", "tenant", tenant, "scheduleName", req.ScheduleName, "namespaces", strings.Join(req.Namespaces, ","))
+	current, err := s.scheduleSleepInfos(ctx, tenant, existingScheduleName, filterNamespace)
+	if err != nil {
+		return err
 	}
 
 	if req.Off != "" && req.On != "" {
-		wdDefault := "0-6"
 		userTZ := TZLocal
-		offConv, err := ToUTCHHMM(req.Off, userTZ)
+		offTime, err := parseClock(req.Off)
 		if err != nil {
 			return fmt.Errorf("invalid off time: %w", err)
 		}
 
-		onConv, err := ToUTCHHMM(req.On, userTZ)
+		onTime, err := parseClock(req.On)
 		if err != nil {
 			return fmt.Errorf("invalid on time: %w", err)
 		}
 
-		wdSleep := wdDefault
+		wdSleep := "0-6"
 		if req.SleepDays != "" {
-			var err error
 			wdSleep, err = HumanWeekdaysToKube(req.SleepDays)
 			if err != nil {
 				return fmt.Errorf("invalid sleepDays: %w", err)
 			}
 		} else if req.Weekdays != "" {
-			var err error
 			wdSleep, err = HumanWeekdaysToKube(req.Weekdays)
 			if err != nil {
 				return fmt.Errorf("invalid weekdays: %w", err)
 			}
 		}
 
-		wdSleepUTC, err := ShiftWeekdaysStr(wdSleep, offConv.DayShift)
-		if err != nil {
-			return fmt.Errorf("failed to shift sleep weekdays: %w", err)
-		}
-
 		selectedNamespaces := normalizeNamespaces(req.Namespaces)
-		if err := s.validateScheduleOverlap(ctx, tenant, selectedNamespaces, wdSleepUTC, offConv.TimeUTC, onConv.TimeUTC, req.ScheduleName); err != nil {
+		if err := s.validateScheduleOverlap(ctx, tenant, selectedNamespaces, wdSleep, offTime, onTime, userTZ, req.ScheduleName); err != nil {
 			return err
 		}
 	}
 
-	if !preserveExisting {
-		if err := s.DeleteSchedule(ctx, tenant, filterNamespace); err != nil {
-			// Si no se encuentran schedules, está bien - crearemos nuevos
-			if !strings.Contains(err.Error(), "not found") && !strings.Contains(err.Error(), "no schedules found") {
-				s.logger.Info("Failed to delete existing schedules before update (will continue)", "error", err, "tenant", tenant, "namespace", filterNamespace)
-				// Continuar de todas formas - CreateSchedule usará createOrUpdateSleepInfo que actualizará si existen
+	// The schedule name is already taken by the schedule itself in the namespaces where it exists
+	if req.ScheduleName != "" {
+		for suffix := range normalizeNamespaces(req.Namespaces) {
+			namespace := fmt.Sprintf("%s-%s", tenant, suffix)
+			if req.ScheduleName == existingScheduleName && containsSleepInfoIn(current, namespace) {
+				continue
+			}
+			if err := s.validateScheduleNameUniqueness(ctx, namespace, req.ScheduleName); err != nil {
+				return err
 			}
 		}
 	}
@@ -2137,7 +1725,75 @@ func (s *ScheduleService) UpdateSchedule(ctx context.Context, tenant string, req
 
 	req.Tenant = tenant
 	s.logger.Info("UpdateSchedule: calling CreateSchedule", "tenant", tenant, "namespaces", strings.Join(req.Namespaces, ","), "off", req.Off, "on", req.On, "weekdays", req.Weekdays, "sleepDays", req.SleepDays, "wakeDays", req.WakeDays, "scheduleName", req.ScheduleName, "description", req.Description)
-	return s.createSchedule(ctx, req, preserveExisting)
+	// The stale SleepInfos are deleted in the same journal, so a failed deletion rolls back the write too
+	ctx, journal := withWriteJournal(ctx)
+	if err := s.writeSchedule(ctx, req, true); err != nil {
+		return s.rollbackSchedule(ctx, journal, err)
+	}
+	if err := s.deleteStaleSleepInfos(ctx, current, scheduleTargets(tenant, req)); err != nil {
+		return s.rollbackSchedule(ctx, journal, err)
+	}
+	return nil
+}
+
+// scheduleSleepInfos returns the SleepInfos of the tenant schedule named scheduleName, or of its unnamed
+// schedule when scheduleName is empty, optionally filtered by namespace suffix
+func (s *ScheduleService) scheduleSleepInfos(ctx context.Context, tenant, scheduleName, namespaceSuffix string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		if strings.Contains(err.Error(), "no schedules found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if scheduleName != "" {
+		return sleepInfos, nil
+	}
+	unnamed := []kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfos {
+		if si.Annotations["kube-green.stratio.com/schedule-name"] == "" && si.Annotations["kube-green.com/schedule-name"] == "" {
+			unnamed = append(unnamed, si)
+		}
+	}
+	return unnamed, nil
+}
+
+// scheduleTargets returns the namespace/name keys of the SleepInfos written for the schedule request
+func scheduleTargets(tenant string, req CreateScheduleRequest) map[client.ObjectKey]bool {
+	targets := map[client.ObjectKey]bool{}
+	for suffix := range normalizeNamespaces(req.Namespaces) {
+		namespace := fmt.Sprintf("%s-%s", tenant, suffix)
+		name := namespace
+		if req.ScheduleName != "" {
+			name = req.ScheduleName
+		}
+		targets[client.ObjectKey{Namespace: namespace, Name: name}] = true
+	}
+	return targets
+}
+
+// deleteStaleSleepInfos deletes the SleepInfos of a schedule that are not targets of its update
+func (s *ScheduleService) deleteStaleSleepInfos(ctx context.Context, current []kubegreenv1alpha1.SleepInfo, targets map[client.ObjectKey]bool) error {
+	for _, si := range current {
+		if targets[client.ObjectKeyFromObject(&si)] {
+			continue
+		}
+		if err := s.deleteSleepInfo(ctx, si); err != nil {
+			return fmt.Errorf("failed to delete SleepInfo %s in namespace %s: %w", si.Name, si.Namespace, err)
+		}
+		recordSleepInfoDeletion(ctx, &si)
+		s.logger.Info("UpdateSchedule: SleepInfo no longer in the schedule deleted", "name", si.Name, "namespace", si.Namespace)
+	}
+	return nil
+}
+
+func containsSleepInfoIn(sleepInfos []kubegreenv1alpha1.SleepInfo, namespace string) bool {
+	for _, si := range sleepInfos {
+		if si.Namespace == namespace {
+			return true
+		}
+	}
+	return false
 }
 
 // TriggerManualAction sets a manual sleep/wake action on matching SleepInfos.
@@ -2147,140 +1803,279 @@ func (s *ScheduleService) TriggerManualAction(ctx context.Context, tenant, actio
 		return fmt.Errorf("invalid action: %s (expected sleep or wake)", action)
 	}
 
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return fmt.Errorf("failed to list SleepInfos: %w", err)
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for i := range sleepInfos {
+		if err := s.setManualAction(ctx, &sleepInfos[i], action, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ManualOperationResponse lists the SleepInfos an on-demand operation was requested on
+type ManualOperationResponse struct {
+	Tenant      string                `json:"tenant"`
+	Action      string                `json:"action"`
+	RequestedAt time.Time             `json:"requestedAt"`
+	SleepInfos  []ManualOperationItem `json:"sleepInfos"`
+}
+
+// ManualOperationItem is a SleepInfo annotated with a manual action and when the controller will run it
+type ManualOperationItem struct {
+	Namespace   string    `json:"namespace"`
+	Name        string    `json:"name"`
+	ScheduledAt time.Time `json:"scheduledAt"` // Later than requestedAt for staged wake steps
+}
+
+// SleepNow immediately puts the tenant (or a single namespace) to sleep.
+// Only SleepInfos that perform the sleep operation are annotated: wake objects of a
+// pair (pair-role=wake) are skipped, so the controller applies exactly the sleep
+// patches and restore bookkeeping the schedule would have applied.
+func (s *ScheduleService) SleepNow(ctx context.Context, tenant, scheduleName, namespaceSuffix string) (*ManualOperationResponse, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return nil, err
 	}
 
-	updated := 0
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
+	now := time.Now()
+	response := &ManualOperationResponse{
+		Tenant:      tenant,
+		Action:      "sleep",
+		RequestedAt: now,
+		SleepInfos:  []ManualOperationItem{},
+	}
+	for i := range sleepInfos {
+		si := &sleepInfos[i]
+		if si.Annotations["kube-green.stratio.com/pair-role"] == "wake" {
 			continue
 		}
-		tenantFromNS := strings.Join(nsParts[:len(nsParts)-1], "-")
-		if tenantFromNS != tenant {
-			continue
+		if err := s.setManualAction(ctx, si, "sleep", now); err != nil {
+			return nil, err
 		}
+		response.SleepInfos = append(response.SleepInfos, ManualOperationItem{Namespace: si.Namespace, Name: si.Name, ScheduledAt: now})
+	}
+	if len(response.SleepInfos) == 0 {
+		return nil, fmt.Errorf("no schedules found for tenant: %s", tenant)
+	}
+	return response, nil
+}
 
-		suffix := nsParts[len(nsParts)-1]
-		if namespaceSuffix != "" && suffix != namespaceSuffix {
+// WakeNow immediately wakes the tenant (or a single namespace).
+// Only SleepInfos that perform the wake operation are annotated (pair-role=sleep objects are
+// skipped). The controller runs the wake stages of a SleepInfo on a manual wake too, and staged
+// wake objects of the same pair keep their relative delays (e.g. Postgres/HDFS, then PgBouncer 5m
+// later, then Deployments), so dependencies come up in the same order as the scheduled wake. The controller restores from the saved restore patches and records WAKE_UP
+// as the last operation, so the next scheduled sleep runs normally.
+func (s *ScheduleService) WakeNow(ctx context.Context, tenant, scheduleName, namespaceSuffix string) (*ManualOperationResponse, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	wakeInfos := make([]kubegreenv1alpha1.SleepInfo, 0, len(sleepInfos))
+	for _, si := range sleepInfos {
+		if si.Annotations["kube-green.stratio.com/pair-role"] == "sleep" {
 			continue
 		}
+		wakeInfos = append(wakeInfos, si)
+	}
+	if len(wakeInfos) == 0 {
+		return nil, fmt.Errorf("no schedules found for tenant: %s", tenant)
+	}
 
-		if scheduleName != "" && !matchesScheduleName(*si, scheduleName) {
+	now := time.Now()
+	delays := wakeStageDelays(wakeInfos)
+	response := &ManualOperationResponse{
+		Tenant:      tenant,
+		Action:      "wake",
+		RequestedAt: now,
+		SleepInfos:  make([]ManualOperationItem, 0, len(wakeInfos)),
+	}
+	for i := range wakeInfos {
+		si := &wakeInfos[i]
+		at := now.Add(delays[si.Namespace+"/"+si.Name])
+		if err := s.setManualAction(ctx, si, "wake", at); err != nil {
+			return nil, err
+		}
+		response.SleepInfos = append(response.SleepInfos, ManualOperationItem{Namespace: si.Namespace, Name: si.Name, ScheduledAt: at})
+	}
+	sort.Slice(response.SleepInfos, func(i, j int) bool {
+		return response.SleepInfos[i].ScheduledAt.Before(response.SleepInfos[j].ScheduledAt)
+	})
+	return response, nil
+}
+
+// wakeStageDelays returns, for each wake SleepInfo (keyed by namespace/name), the delay relative to
+// the first wake stage of its pair. SleepInfos without a pair-id wake immediately.
+func wakeStageDelays(wakeInfos []kubegreenv1alpha1.SleepInfo) map[string]time.Duration {
+	groups := make(map[string][]kubegreenv1alpha1.SleepInfo)
+	delays := make(map[string]time.Duration, len(wakeInfos))
+	for _, si := range wakeInfos {
+		key := si.Namespace + "/" + si.Name
+		delays[key] = 0
+		pairID := si.Annotations["kube-green.stratio.com/pair-id"]
+		if pairID == "" || si.Annotations["kube-green.stratio.com/pair-role"] != "wake" {
 			continue
 		}
+		groupKey := si.Namespace + "/" + pairID
+		groups[groupKey] = append(groups[groupKey], si)
+	}
 
-		if si.Annotations == nil {
-			si.Annotations = make(map[string]string)
+	for _, group := range groups {
+		// Offsets relative to the first element, normalized to (-12h, 12h] so stages
+		// crossing midnight (23:58 -> 00:05) keep their order.
+		base := timeToMinutes(group[0].Spec.SleepTime)
+		offsets := make([]int, len(group))
+		minOffset := 0
+		for i, si := range group {
+			offset := timeToMinutes(si.Spec.SleepTime) - base
+			if offset > 720 {
+				offset -= 1440
+			} else if offset <= -720 {
+				offset += 1440
+			}
+			offsets[i] = offset
+			if offset < minOffset {
+				minOffset = offset
+			}
 		}
-		si.Annotations["kube-green.stratio.com/manual-action"] = action
-		si.Annotations["kube-green.stratio.com/manual-at"] = time.Now().Format(time.RFC3339)
-
-		if err := s.client.Update(ctx, si); err != nil {
-			return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
+		for i, si := range group {
+			delays[si.Namespace+"/"+si.Name] = time.Duration(offsets[i]-minOffset) * time.Minute
 		}
-		updated++
-	}
-
-	if updated == 0 {
-		return fmt.Errorf("no schedules found for tenant: %s", tenant)
 	}
-
-	return nil
+	return delays
 }
 
-// SuspendSchedule sets spec.suspendScheduleUntil on all matching SleepInfos for the tenant.
-// While suspended the cron schedule is skipped; manual actions can still override it.
-func (s *ScheduleService) SuspendSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string, until time.Time) error {
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return fmt.Errorf("failed to list SleepInfos: %w", err)
+// listTenantSleepInfos returns the SleepInfos of a tenant, optionally filtered by
+// schedule name and namespace suffix.
+func (s *ScheduleService) listTenantSleepInfos(ctx context.Context, tenant, scheduleName, namespaceSuffix string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sleepInfos, err := s.listCachedSleepInfos(ctx, resolver, tenant)
+	if err != nil {
+		return nil, err
 	}
 
-	updated := 0
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
-			continue
-		}
-		tenantFromNS := strings.Join(nsParts[:len(nsParts)-1], "-")
-		if tenantFromNS != tenant {
+	result := []kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfos {
+		tenantFromNS, suffix, ok := resolver.split(si.Namespace)
+		if !ok || tenantFromNS != tenant {
 			continue
 		}
-
-		suffix := nsParts[len(nsParts)-1]
 		if namespaceSuffix != "" && suffix != namespaceSuffix {
 			continue
 		}
 
-		if scheduleName != "" && !matchesScheduleName(*si, scheduleName) {
+		if scheduleName != "" && !matchesScheduleName(si, scheduleName) {
 			continue
 		}
+		result = append(result, si)
+	}
 
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no schedules found for tenant: %s", tenant)
+	}
+	return result, nil
+}
+
+// setManualAction annotates a SleepInfo so the controller executes action at the given time.
+// A time in the future keeps the action pending until then (used for staged wakes). It is a runtime
+// annotation, written to the cluster in GitOps mode too.
+func (s *ScheduleService) setManualAction(ctx context.Context, si *kubegreenv1alpha1.SleepInfo, action string, at time.Time) error {
+	if si.Annotations == nil {
+		si.Annotations = make(map[string]string)
+	}
+	si.Annotations["kube-green.stratio.com/manual-action"] = action
+	si.Annotations["kube-green.stratio.com/manual-at"] = at.Format(time.RFC3339)
+
+	if err := s.client.Update(withClusterWrite(ctx), si); err != nil {
+		return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
+	}
+	return nil
+}
+
+// SuspendSchedule sets spec.suspendScheduleUntil on all matching SleepInfos for the tenant.
+// While suspended the cron schedule is skipped; manual actions can still override it.
+func (s *ScheduleService) SuspendSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string, until time.Time) error {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return err
+	}
+
+	for i := range sleepInfos {
+		si := &sleepInfos[i]
 		t := metav1.NewTime(until)
 		si.Spec.SuspendScheduleUntil = &t
 		if err := s.client.Update(ctx, si); err != nil {
 			return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
 		}
-		updated++
-	}
-
-	if updated == 0 {
-		return fmt.Errorf("no schedules found for tenant: %s", tenant)
 	}
 	return nil
 }
 
-// UnsuspendSchedule removes spec.suspendScheduleUntil from all matching SleepInfos, resuming normal cron execution.
-func (s *ScheduleService) UnsuspendSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string) error {
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return fmt.Errorf("failed to list SleepInfos: %w", err)
+// PauseSchedule pauses the matching SleepInfos of the tenant until ResumeSchedule is called.
+// Unlike DeleteSchedule, the SleepInfos, their delays, exclusions, annotations and restore
+// secrets are kept, so resuming continues exactly where the schedule was left.
+func (s *ScheduleService) PauseSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string) error {
+	return s.setPaused(ctx, tenant, scheduleName, namespaceSuffix, true)
+}
+
+// ResumeSchedule removes the pause from the matching SleepInfos of the tenant.
+func (s *ScheduleService) ResumeSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string) error {
+	return s.setPaused(ctx, tenant, scheduleName, namespaceSuffix, false)
+}
+
+func (s *ScheduleService) setPaused(ctx context.Context, tenant, scheduleName, namespaceSuffix string, paused bool) error {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return err
 	}
 
-	updated := 0
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
+	for i := range sleepInfos {
+		si := &sleepInfos[i]
+		if si.IsSuspended() == paused {
 			continue
 		}
-		tenantFromNS := strings.Join(nsParts[:len(nsParts)-1], "-")
-		if tenantFromNS != tenant {
-			continue
+		if paused {
+			si.Spec.Suspend = &paused
+		} else {
+			si.Spec.Suspend = nil
 		}
-
-		suffix := nsParts[len(nsParts)-1]
-		if namespaceSuffix != "" && suffix != namespaceSuffix {
-			continue
+		if err := s.client.Update(ctx, si); err != nil {
+			return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
 		}
+	}
+	return nil
+}
 
-		if scheduleName != "" && !matchesScheduleName(*si, scheduleName) {
-			continue
-		}
+// UnsuspendSchedule removes spec.suspendScheduleUntil from all matching SleepInfos, resuming normal cron execution.
+func (s *ScheduleService) UnsuspendSchedule(ctx context.Context, tenant, scheduleName, namespaceSuffix string) error {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, scheduleName, namespaceSuffix)
+	if err != nil {
+		return err
+	}
 
+	for i := range sleepInfos {
+		si := &sleepInfos[i]
 		if si.Spec.SuspendScheduleUntil == nil {
-			updated++ // already unsuspended, count as success
-			continue
+			continue // already unsuspended
 		}
 		si.Spec.SuspendScheduleUntil = nil
 		if err := s.client.Update(ctx, si); err != nil {
 			return fmt.Errorf("failed to update SleepInfo %s: %w", si.Name, err)
 		}
-		updated++
-	}
-
-	if updated == 0 {
-		return fmt.Errorf("no schedules found for tenant: %s", tenant)
 	}
 	return nil
 }
 
-func matchesScheduleName(si kubegreenv1alpha1.SleepInfo, scheduleName string) bool {	if scheduleName == "" {
+func matchesScheduleName(si kubegreenv1alpha1.SleepInfo, scheduleName string) bool {
+	if scheduleName == "" {
 		return true
 	}
 	if si.Annotations != nil {
@@ -2299,28 +2094,24 @@ func matchesScheduleName(si kubegreenv1alpha1.SleepInfo, scheduleName string) bo
 }
 
 func (s *ScheduleService) deleteSchedules(ctx context.Context, tenant, filterNamespace, scheduleName string) error {
-	// List all SleepInfos
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := s.reader.List(ctx, sleepInfoList); err != nil {
-		return fmt.Errorf("failed to list SleepInfos: %w", err)
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return err
+	}
+	sleepInfos, err := s.listCachedSleepInfos(ctx, resolver, tenant)
+	if err != nil {
+		return err
 	}
 
 	// Find and delete all SleepInfos for the tenant
 	deletedCount := 0
-	for _, si := range sleepInfoList.Items {
+	for _, si := range sleepInfos {
 		// Extract tenant from namespace
-		nsParts := strings.Split(si.Namespace, "-")
-		if len(nsParts) < 2 {
-			continue
-		}
-
-		tenantFromNS := strings.Join(nsParts[:len(nsParts)-1], "-")
-		if tenantFromNS != tenant {
+		tenantFromNS, suffix, ok := resolver.split(si.Namespace)
+		if !ok || tenantFromNS != tenant {
 			continue
 		}
 
-		suffix := nsParts[len(nsParts)-1]
-
 		// Filter by namespace suffix if provided
 		if filterNamespace != "" && suffix != filterNamespace {
 			continue
@@ -2330,27 +2121,7 @@ func (s *ScheduleService) deleteSchedules(ctx context.Context, tenant, filterNam
 			continue
 		}
 
-		// Delete associated secret first (if it exists)
-		secretName := fmt.Sprintf("sleepinfo-%s", si.Name)
-		secret := &v1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      secretName,
-				Namespace: si.Namespace,
-			},
-		}
-		if err := s.client.Delete(ctx, secret); err != nil {
-			// Ignore not found errors (secret might not exist)
-			if client.IgnoreNotFound(err) == nil {
-				s.logger.Info("Secret not found or already deleted", "secret", secretName, "namespace", si.Namespace)
-			} else {
-				s.logger.Error(err, "failed to delete secret", "secret", secretName, "namespace", si.Namespace)
-			}
-		} else {
-			s.logger.Info("Associated secret deleted", "secret", secretName, "namespace", si.Namespace)
-		}
-
-		// Delete the SleepInfo
-		if err := s.client.Delete(ctx, &si); err != nil {
+		if err := s.deleteSleepInfo(ctx, si); err != nil {
 			s.logger.Error(err, "failed to delete SleepInfo", "name", si.Name, "namespace", si.Namespace)
 			continue
 		}
@@ -2386,13 +2157,23 @@ func (s *ScheduleService) deleteSchedules(ctx context.Context, tenant, filterNam
 	return nil
 }
 
+// deleteSleepInfo deletes a SleepInfo. Its secrets, written by the controller only, are garbage collected
+// with it, or kept by the controller to wake up the resources on deletion.
+func (s *ScheduleService) deleteSleepInfo(ctx context.Context, si kubegreenv1alpha1.SleepInfo) error {
+	return client.IgnoreNotFound(s.client.Delete(ctx, &si))
+}
+
 // DeleteSchedule deletes all SleepInfos for a tenant
 func (s *ScheduleService) DeleteSchedule(ctx context.Context, tenant string, namespaceSuffix ...string) error {
 	var filterNamespace string
 	if len(namespaceSuffix) > 0 && namespaceSuffix[0] != "" {
 		filterNamespace = namespaceSuffix[0]
 	}
-	return s.deleteSchedules(ctx, tenant, filterNamespace, "")
+	if err := s.deleteSchedules(ctx, tenant, filterNamespace, ""); err != nil {
+		return err
+	}
+	s.notifyScheduleEvent(notifications.EventScheduleDeleted, tenant, filterNamespace, "", nil)
+	return nil
 }
 
 // DeleteScheduleByName deletes SleepInfos for a tenant matching a schedule name
@@ -2401,14 +2182,19 @@ func (s *ScheduleService) DeleteScheduleByName(ctx context.Context, tenant, sche
 	if len(namespaceSuffix) > 0 && namespaceSuffix[0] != "" {
 		filterNamespace = namespaceSuffix[0]
 	}
-	return s.deleteSchedules(ctx, tenant, filterNamespace, scheduleName)
+	if err := s.deleteSchedules(ctx, tenant, filterNamespace, scheduleName); err != nil {
+		return err
+	}
+	s.notifyScheduleEvent(notifications.EventScheduleDeleted, tenant, filterNamespace, scheduleName, nil)
+	return nil
 }
 
 // TenantInfo represents a discovered tenant
 type TenantInfo struct {
-	Name       string   `json:"name"`
-	Namespaces []string `json:"namespaces"`
-	CreatedAt  string   `json:"createdAt,omitempty"`
+	Name       string         `json:"name"`
+	Namespaces []string       `json:"namespaces"`
+	CreatedAt  string         `json:"createdAt,omitempty"`
+	Details    *TenantDetails `json:"details,omitempty"` // Only with ?details=true
 }
 
 // TenantListResponse represents the response for listing tenants
@@ -2418,6 +2204,13 @@ type TenantListResponse struct {
 
 // ListTenants discovers all tenants by scanning namespaces
 func (s *ScheduleService) ListTenants(ctx context.Context) (*TenantListResponse, error) {
+	// Served from the tenant map once the namespace informer is synced
+	if tenantMap := s.tenants.Load(); tenantMap != nil {
+		if tenants, ok := tenantMap.list(); ok {
+			return &TenantListResponse{Tenants: tenants}, nil
+		}
+	}
+
 	// List all namespaces
 	namespaceList := &v1.NamespaceList{}
 	if err := s.client.List(ctx, namespaceList); err != nil {
@@ -2426,22 +2219,22 @@ func (s *ScheduleService) ListTenants(ctx context.Context) (*TenantListResponse,
 
 	s.logger.Info("ListTenants", "total_namespaces_found", len(namespaceList.Items))
 
+	resolver, err := s.newNamespaceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Map to track tenants and their namespaces (dinámico - sin filtrar por validSuffixes)
 	tenantMap := make(map[string]map[string]bool)
 
 	for _, ns := range namespaceList.Items {
 		nsName := ns.Name
 
-		// Check if namespace matches tenant-suffix pattern
-		nsParts := strings.Split(nsName, "-")
-		if len(nsParts) < 2 {
+		tenant, suffix, ok := resolver.split(nsName)
+		if !ok {
 			continue // Skip namespaces that don't match pattern
 		}
 
-		// Extract tenant (all parts except last)
-		tenant := strings.Join(nsParts[:len(nsParts)-1], "-")
-		suffix := nsParts[len(nsParts)-1]
-
 		// NO FILTRAR por validSuffixes - aceptar TODOS los namespaces que coincidan con el patrón
 		// Esto permite descubrimiento dinámico de cualquier namespace que siga el patrón {tenant}-{prefix}
 
@@ -2619,6 +2412,7 @@ type SuspendedServiceInfo struct {
 	SuspendedAt string `json:"suspendedAt"`
 	Reason      string `json:"reason"`
 	WillWakeAt  string `json:"willWakeAt,omitempty"`
+	SleepInfo   string `json:"sleepInfo,omitempty"` // SleepInfo that put the service to sleep
 }
 
 // SuspendedServicesResponse represents suspended services for a tenant
@@ -2627,86 +2421,34 @@ type SuspendedServicesResponse struct {
 	Suspended []SuspendedServiceInfo `json:"suspended"`
 }
 
-// GetSuspendedServices lists currently suspended services for a tenant
+// GetSuspendedServices lists the services of a tenant that kube-green has actually put to sleep.
+// A resource is reported when the restore data of a SleepInfo secret still contains a patch that
+// would change its current state (e.g. Deployment at 0 replicas, suspended CronJob, stopped CRD).
 func (s *ScheduleService) GetSuspendedServices(ctx context.Context, tenant string) (*SuspendedServicesResponse, error) {
-	// Get all namespaces for the tenant
-	schedule, err := s.GetSchedule(ctx, tenant)
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
 
+	byNamespace := make(map[string][]kubegreenv1alpha1.SleepInfo)
+	for _, si := range sleepInfos {
+		byNamespace[si.Namespace] = append(byNamespace[si.Namespace], si)
+	}
+	namespaces := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
 	suspended := make([]SuspendedServiceInfo, 0)
 	now := time.Now()
-
-	// Iterate through all namespaces for this tenant
-	for namespaceSuffix := range schedule.Namespaces {
-		namespace := fmt.Sprintf("%s-%s", tenant, namespaceSuffix)
-
-		// Get services in this namespace
-		services, err := s.GetNamespaceServices(ctx, tenant, namespaceSuffix)
+	for _, namespace := range namespaces {
+		services, err := s.suspendedServicesInNamespace(ctx, namespace, byNamespace[namespace], now)
 		if err != nil {
-			s.logger.Error(err, "failed to get services for namespace", "namespace", namespace)
+			s.logger.Error(err, "failed to get suspended services for namespace", "namespace", namespace)
 			continue
 		}
-
-		// Get SleepInfos for this namespace to determine wake times
-		sleepInfos, err := s.getSleepInfosForNamespace(ctx, namespace)
-		if err != nil {
-			s.logger.Error(err, "failed to get SleepInfos for namespace", "namespace", namespace)
-		}
-
-		// Find wake schedule for this namespace
-		var wakeSchedule string
-		var wakeTime time.Time
-		for _, si := range sleepInfos {
-			role := si.Annotations["kube-green.stratio.com/pair-role"]
-			if role == "wake" {
-				if si.Spec.WakeUpTime != "" {
-					wakeSchedule = si.Spec.WakeUpTime
-				} else if si.Spec.SleepTime != "" {
-					// For separate wake SleepInfos, SleepTime contains wake time
-					wakeSchedule = si.Spec.SleepTime
-				}
-				if wakeSchedule != "" {
-					// Parse wake schedule to get next wake time
-					cronSchedule, err := parseTimeToCron(wakeSchedule, si.Spec.Weekdays, si.Spec.TimeZone)
-					if err == nil {
-						if sched, err := cron.ParseStandard(cronSchedule); err == nil {
-							wakeTime = sched.Next(now)
-						}
-					}
-				}
-				break
-			}
-		}
-
-		// Check each service
-		for _, service := range services.Services {
-			if service.Status == "Suspended" {
-				// Get last schedule time from SleepInfo status if available
-				suspendedAt := now.Format(time.RFC3339)
-				for _, si := range sleepInfos {
-					if !si.Status.LastScheduleTime.IsZero() && si.Status.OperationType == "SLEEP" {
-						suspendedAt = si.Status.LastScheduleTime.Format(time.RFC3339)
-						break
-					}
-				}
-
-				willWakeAt := ""
-				if !wakeTime.IsZero() {
-					willWakeAt = wakeTime.Format(time.RFC3339)
-				}
-
-				suspended = append(suspended, SuspendedServiceInfo{
-					Name:        service.Name,
-					Namespace:   namespace,
-					Kind:        service.Kind,
-					SuspendedAt: suspendedAt,
-					Reason:      "Scheduled sleep",
-					WillWakeAt:  willWakeAt,
-				})
-			}
-		}
+		suspended = append(suspended, services...)
 	}
 
 	return &SuspendedServicesResponse{
@@ -2774,6 +2516,11 @@ func (s *ScheduleService) getSleepInfosForNamespace(ctx context.Context, namespa
 
 // parseTimeToCron converts a time string (HH:MM) and weekdays to a cron expression
 func parseTimeToCron(timeStr, weekdays, timezone string) (string, error) {
+	// A cron expression ignores the weekdays
+	if kubegreenv1alpha1.IsCronExpression(timeStr) {
+		return strings.Join(strings.Fields(timeStr), " "), nil
+	}
+
 	// Parse time (HH:MM format)
 	parts := strings.Split(timeStr, ":")
 	if len(parts) != 2 {
@@ -2848,8 +2595,8 @@ func (s *ScheduleService) GetNextOperation(ctx context.Context, tenant string) (
 					wakeCron, err2 := parseTimeToCron(si.Spec.WakeUpTime, si.Spec.Weekdays, si.Spec.TimeZone)
 
 					if err1 == nil && err2 == nil {
-						sleepSched, _ := cron.ParseStandard(sleepCron)
-						wakeSched, _ := cron.ParseStandard(wakeCron)
+						sleepSched, _ := kubegreenv1alpha1.ParseSchedule(sleepCron)
+						wakeSched, _ := kubegreenv1alpha1.ParseSchedule(wakeCron)
 
 						nextSleep := sleepSched.Next(now)
 						nextWake := wakeSched.Next(now)
@@ -2867,14 +2614,14 @@ func (s *ScheduleService) GetNextOperation(ctx context.Context, tenant string) (
 				}
 			}
 
-			if scheduleTime != "" && weekdays != "" {
+			if scheduleTime != "" && (weekdays != "" || kubegreenv1alpha1.IsCronExpression(scheduleTime)) {
 				cronSchedule, err := parseTimeToCron(scheduleTime, weekdays, si.Spec.TimeZone)
 				if err != nil {
 					s.logger.Error(err, "failed to parse schedule", "time", scheduleTime, "weekdays", weekdays)
 					continue
 				}
 
-				sched, err := cron.ParseStandard(cronSchedule)
+				sched, err := kubegreenv1alpha1.ParseSchedule(cronSchedule)
 				if err != nil {
 					s.logger.Error(err, "failed to parse cron", "schedule", cronSchedule)
 					continue
@@ -2915,6 +2662,8 @@ type NamespaceResourceInfo struct {
 	HasOsDashboards bool              `json:"hasOsDashboards"`
 	HasKafkaCluster bool              `json:"hasKafkaCluster"`
 	HasPgBouncer    bool              `json:"hasPgBouncer"`
+	HasRedisCluster bool              `json:"hasRedisCluster"`
+	HasMongoDB      bool              `json:"hasMongoDB"`
 	HasVirtualizer  bool              `json:"hasVirtualizer"`
 	ResourceCounts  ResourceCounts    `json:"resourceCounts"`
 	AutoExclusions  []ExclusionFilter `json:"autoExclusions"`
@@ -2931,6 +2680,14 @@ type ResourceCounts struct {
 	OsDashboardses int `json:"osDashboardses"`
 	KafkaClusters  int `json:"kafkaClusters"`
 	PgBouncers     int `json:"pgBouncers"`
+	RedisClusters  int `json:"redisClusters"`
+	MongoDBs       int `json:"mongoDBs"`
+}
+
+// hasDatastores returns true if the namespace has datastores woken up before its Deployments
+func (info *NamespaceResourceInfo) hasDatastores() bool {
+	return info.HasPgCluster || info.HasHdfsCluster || info.HasOsCluster || info.HasOsDashboards || info.HasKafkaCluster || info.HasPgBouncer ||
+		info.HasRedisCluster || info.HasMongoDB
 }
 
 // GetNamespaceResources detects CRDs and other resources in a namespace
@@ -3103,6 +2860,28 @@ func (s *ScheduleService) GetNamespaceResources(ctx context.Context, tenant, nam
 		info.HasKafkaCluster = len(kafkaClusterList.Items) > 0
 	}
 
+	// Detect the datastores of the redis and mongodb patch target presets, woken up with the other datastores
+	redisClusterList := &unstructured.UnstructuredList{}
+	redisClusterList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "redis.redis.opstreelabs.in",
+		Version: "v1beta2",
+		Kind:    "RedisClusterList",
+	})
+	if err := s.client.List(ctx, redisClusterList, client.InNamespace(namespace)); err == nil {
+		info.ResourceCounts.RedisClusters = len(redisClusterList.Items)
+		info.HasRedisCluster = len(redisClusterList.Items) > 0
+	}
+	mongoDBList := &unstructured.UnstructuredList{}
+	mongoDBList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "mongodbcommunity.mongodb.com",
+		Version: "v1",
+		Kind:    "MongoDBCommunityList",
+	})
+	if err := s.client.List(ctx, mongoDBList, client.InNamespace(namespace)); err == nil {
+		info.ResourceCounts.MongoDBs = len(mongoDBList.Items)
+		info.HasMongoDB = len(mongoDBList.Items) > 0
+	}
+
 	// Build auto-exclusions based on detected resources
 	if info.HasPgCluster || info.HasPgBouncer {
 		info.AutoExclusions = append(info.AutoExclusions, ExclusionFilter{
@@ -3188,21 +2967,27 @@ type NamespaceScheduleResponse struct {
 
 // SleepInfoDetail represents detailed information about a SleepInfo
 type SleepInfoDetail struct {
-	Name                        string            `json:"name"`
-	Namespace                   string            `json:"namespace"`
-	Weekdays                    string            `json:"weekdays"`
-	SleepAt                     string            `json:"sleepAt,omitempty"`
-	WakeUpAt                    string            `json:"wakeUpAt,omitempty"`
-	TimeZone                    string            `json:"timeZone"`
-	Role                        string            `json:"role,omitempty"` // "sleep" or "wake" from annotations
-	SuspendDeployments          bool              `json:"suspendDeployments"`
-	SuspendStatefulSets         bool              `json:"suspendStatefulSets"`
-	SuspendCronJobs             bool              `json:"suspendCronJobs"`
-	SuspendDeploymentsPgbouncer bool              `json:"suspendDeploymentsPgbouncer,omitempty"`
-	SuspendStatefulSetsPostgres bool              `json:"suspendStatefulSetsPostgres,omitempty"`
-	SuspendStatefulSetsHdfs     bool              `json:"suspendStatefulSetsHdfs,omitempty"`
-	ExcludeRef                  []ExclusionFilter `json:"excludeRef,omitempty"`
-	Annotations                 map[string]string `json:"annotations,omitempty"`
+	Name                        string                `json:"name"`
+	Namespace                   string                `json:"namespace"`
+	Weekdays                    string                `json:"weekdays"`
+	SleepAt                     string                `json:"sleepAt,omitempty"`
+	WakeUpAt                    string                `json:"wakeUpAt,omitempty"`
+	TimeZone                    string                `json:"timeZone"`
+	Role                        string                `json:"role,omitempty"` // "sleep" or "wake" from annotations
+	SuspendDeployments          bool                  `json:"suspendDeployments"`
+	SuspendStatefulSets         bool                  `json:"suspendStatefulSets"`
+	SuspendCronJobs             bool                  `json:"suspendCronJobs"`
+	SuspendDeploymentsPgbouncer bool                  `json:"suspendDeploymentsPgbouncer,omitempty"`
+	SuspendStatefulSetsPostgres bool                  `json:"suspendStatefulSetsPostgres,omitempty"`
+	SuspendStatefulSetsHdfs     bool                  `json:"suspendStatefulSetsHdfs,omitempty"`
+	SuspendFlink                bool                  `json:"suspendFlink,omitempty"`
+	ExcludeRef                  []ExclusionFilter     `json:"excludeRef,omitempty"`
+	IncludeRef                  []ExclusionFilter     `json:"includeRef,omitempty"`
+	SleepReplicas               []SleepReplicasConfig `json:"sleepReplicas,omitempty"`
+	Annotations                 map[string]string     `json:"annotations,omitempty"`
+	Window                      *OneTimeWindow        `json:"window,omitempty"`
+	Holidays                    *HolidayConfig        `json:"holidays,omitempty"`
+	Schedules                   []WeeklySchedule      `json:"schedules,omitempty"` // Schedules of a SleepInfo with several of them, instead of weekdays, sleepAt and wakeUpAt
 }
 
 // GetNamespaceSchedule gets SleepInfos for a specific namespace
@@ -3235,7 +3020,12 @@ func (s *ScheduleService) GetNamespaceSchedule(ctx context.Context, tenant, name
 			SuspendDeploymentsPgbouncer: si.Spec.SuspendDeploymentsPgbouncer != nil && *si.Spec.SuspendDeploymentsPgbouncer,
 			SuspendStatefulSetsPostgres: si.Spec.SuspendStatefulSetsPostgres != nil && *si.Spec.SuspendStatefulSetsPostgres,
 			SuspendStatefulSetsHdfs:     si.Spec.SuspendStatefulSetsHdfs != nil && *si.Spec.SuspendStatefulSetsHdfs,
+			SuspendFlink:                si.IsFlinkToSuspend(),
 			Annotations:                 si.Annotations,
+			Window:                      windowOf(si),
+			SleepReplicas:               sleepReplicasOf(si),
+			Holidays:                    holidaysOf(si),
+			Schedules:                   schedulesOf(si),
 		}
 
 		// Extract role from annotations
@@ -3247,11 +3037,12 @@ func (s *ScheduleService) GetNamespaceSchedule(ctx context.Context, tenant, name
 		if len(si.Spec.ExcludeRef) > 0 {
 			detail.ExcludeRef = make([]ExclusionFilter, 0, len(si.Spec.ExcludeRef))
 			for _, ref := range si.Spec.ExcludeRef {
-				detail.ExcludeRef = append(detail.ExcludeRef, ExclusionFilter{
-					MatchLabels: ref.MatchLabels,
-				})
+				detail.ExcludeRef = append(detail.ExcludeRef, exclusionFromFilterRef(ref))
 			}
 		}
+		for _, ref := range si.Spec.IncludeRef {
+			detail.IncludeRef = append(detail.IncludeRef, exclusionFromFilterRef(ref))
+		}
 
 		sleepInfos = append(sleepInfos, detail)
 	}
@@ -3290,68 +3081,59 @@ func (s *ScheduleService) CreateNamespaceSchedule(ctx context.Context, req Names
 		return fmt.Errorf("invalid wake weekdays: %w", err)
 	}
 
-	// 3. Convert times to UTC (default to America/Bogota -> UTC)
+	// 3. Times and weekdays are kept in the user timezone (default to America/Bogota)
 	userTZ := TZLocal
 
-	offConv, err := ToUTCHHMM(req.Off, userTZ)
+	offTime, err := parseClock(req.Off)
 	if err != nil {
 		return fmt.Errorf("invalid off time: %w", err)
 	}
 
-	onConv, err := ToUTCHHMM(req.On, userTZ)
+	onTime, err := parseClock(req.On)
 	if err != nil {
 		return fmt.Errorf("invalid on time: %w", err)
 	}
 
-	// 4. Adjust weekdays for timezone shift
-	wdSleepUTC, err := ShiftWeekdaysStr(wdSleepKube, offConv.DayShift)
-	if err != nil {
-		return fmt.Errorf("failed to shift sleep weekdays: %w", err)
-	}
-
-	wdWakeUTC, err := ShiftWeekdaysStr(wdWakeKube, onConv.DayShift)
-	if err != nil {
-		return fmt.Errorf("failed to shift wake weekdays: %w", err)
-	}
-
-	if err := s.validateScheduleOverlap(ctx, req.Tenant, map[string]bool{req.Namespace: true}, wdSleepUTC, offConv.TimeUTC, onConv.TimeUTC, req.ScheduleName); err != nil {
+	if err := s.validateScheduleOverlap(ctx, req.Tenant, map[string]bool{req.Namespace: true}, wdSleepKube, offTime, onTime, userTZ, req.ScheduleName); err != nil {
 		return err
 	}
 
-	// 5. Calculate staggered wake times based on delays
-	onPgHDFS := onConv.TimeUTC
-	onPgBouncer := onConv.TimeUTC
-	onDeployments := onConv.TimeUTC
+	// 4. Calculate staggered wake times based on delays
+	onPgHDFS := onTime
+	onPgBouncer := onTime
+	onDeployments := onTime
 
 	if req.Delays != nil {
 		if req.Delays.PgHdfsDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.PgHdfsDelay)
-			onPgHDFS, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onPgHDFS, _ = AddMinutes(onTime, delayMinutes)
 		}
 		if req.Delays.PgbouncerDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.PgbouncerDelay)
-			onPgBouncer, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onPgBouncer, _ = AddMinutes(onTime, delayMinutes)
 		}
 		if req.Delays.DeploymentsDelay != "" {
 			delayMinutes, _ := parseDelayToMinutes(req.Delays.DeploymentsDelay)
-			onDeployments, _ = AddMinutes(onConv.TimeUTC, delayMinutes)
+			onDeployments, _ = AddMinutes(onTime, delayMinutes)
 		}
 	} else {
 		// Default delays (like Python script)
-		onPgHDFS = onConv.TimeUTC // t0
+		onPgHDFS = onTime // t0
 		// Aplicar delays por defecto SOLO para datastores (staggered wake)
-		onPgBouncer, _ = AddMinutes(onConv.TimeUTC, 5)   // t0+5m para PgBouncer
-		onDeployments, _ = AddMinutes(onConv.TimeUTC, 7) // t0+7m para Deployments
+		onPgBouncer, _ = AddMinutes(onTime, 5)   // t0+5m para PgBouncer
+		onDeployments, _ = AddMinutes(onTime, 7) // t0+7m para Deployments
 	}
 
 	// 6. Build excludeRefs
-	excludeRefs := resources.AutoExclusions
+	policy := s.namespacePolicy(ctx, req.Namespace)
+	excludeRefs := append(resources.AutoExclusions, policy.DefaultExclusions...)
 	if len(req.Exclusions) > 0 {
 		for _, excl := range req.Exclusions {
 			if excl.Namespace == req.Namespace || excl.Namespace == fmt.Sprintf("%s-%s", req.Tenant, req.Namespace) {
-				excludeRefs = append(excludeRefs, ExclusionFilter{
-					MatchLabels: excl.Filter.MatchLabels,
-				})
+				if err := excl.Filter.validate(); err != nil {
+					return fmt.Errorf("invalid exclusion: %w", err)
+				}
+				excludeRefs = append(excludeRefs, excl.Filter)
 			}
 		}
 	}
@@ -3371,20 +3153,29 @@ func (s *ScheduleService) CreateNamespaceSchedule(ctx context.Context, req Names
 	// Convert to kubegreen FilterRef
 	kubeExcludeRefs := make([]kubegreenv1alpha1.FilterRef, 0, len(excludeRefs))
 	for _, excl := range excludeRefs {
-		kubeExcludeRefs = append(kubeExcludeRefs, kubegreenv1alpha1.FilterRef{
-			MatchLabels: excl.MatchLabels,
-		})
+		kubeExcludeRefs = append(kubeExcludeRefs, excl.toFilterRef())
+	}
+	if err := validateInclusions(req.Tenant, req.Inclusions); err != nil {
+		return err
+	}
+	if err := req.Holidays.validate(); err != nil {
+		return err
+	}
+	if err := validateSleepReplicas(req.SleepReplicas); err != nil {
+		return err
 	}
+	kubeIncludeRefs := inclusionRefsFor(req.Tenant, req.Namespace, req.Inclusions)
+	kubeSleepReplicas := sleepReplicasFor(req.Tenant, req.Namespace, req.SleepReplicas)
 
 	namespace := fmt.Sprintf("%s-%s", req.Tenant, req.Namespace)
 
 	// 7. Generate SleepInfos based on detected resources (DYNAMIC LOGIC)
-	hasCRDs := resources.HasPgCluster || resources.HasHdfsCluster || resources.HasPgBouncer
+	hasCRDs := policy.staggeredWake(resources.hasDatastores())
 
 	if hasCRDs {
-		// Apply staggered wake logic when CRDs are detected
-		if err := s.createDatastoresSleepInfosWithExclusions(ctx, req.Tenant, namespace, offConv.TimeUTC, onDeployments, onPgHDFS, onPgBouncer, wdSleepUTC, wdWakeUTC, kubeExcludeRefs, req.ScheduleName, req.Description, userTZ); err != nil {
-			return fmt.Errorf("failed to create staggered sleepinfos: %w", err)
+		// Wake up the datastores in stages when CRDs are detected
+		if err := s.createDatastoresSleepInfoWithExclusions(ctx, req.Tenant, namespace, req.Namespace, offTime, onDeployments, onPgHDFS, onPgBouncer, wdSleepKube, wdWakeKube, req.SuspendFlink, kubeExcludeRefs, kubeIncludeRefs, kubeSleepReplicas, req.Holidays, req.ScheduleName, req.Description, userTZ); err != nil {
+			return fmt.Errorf("failed to create namespace sleepinfo: %w", err)
 		}
 	} else {
 		// Simple namespace without CRDs
@@ -3397,8 +3188,9 @@ func (s *ScheduleService) CreateNamespaceSchedule(ctx context.Context, req Names
 		if resources.HasPgCluster {
 			suspendStatefulSets = true
 		}
+		suspendStatefulSets = policy.suspendStatefulSets(suspendStatefulSets)
 
-		if err := s.createNamespaceSleepInfoWithExclusions(ctx, req.Tenant, namespace, req.Namespace, offConv.TimeUTC, onDeployments, wdSleepUTC, wdWakeUTC, suspendStatefulSets, kubeExcludeRefs, req.ScheduleName, req.Description, userTZ); err != nil {
+		if err := s.createNamespaceSleepInfoWithExclusions(ctx, req.Tenant, namespace, req.Namespace, offTime, onDeployments, wdSleepKube, wdWakeKube, suspendStatefulSets, req.SuspendFlink, kubeExcludeRefs, kubeIncludeRefs, kubeSleepReplicas, req.Holidays, req.ScheduleName, req.Description, userTZ); err != nil {
 			return fmt.Errorf("failed to create namespace sleepinfo: %w", err)
 		}
 	}
@@ -3409,12 +3201,11 @@ func (s *ScheduleService) CreateNamespaceSchedule(ctx context.Context, req Names
 // UpdateNamespaceSchedule updates SleepInfos for a specific namespace
 func (s *ScheduleService) UpdateNamespaceSchedule(ctx context.Context, req NamespaceScheduleRequest) error {
 	if req.Off != "" && req.On != "" {
-		userTZ := TZLocal
-		offConv, err := ToUTCHHMM(req.Off, userTZ)
+		offTime, err := parseClock(req.Off)
 		if err != nil {
 			return fmt.Errorf("invalid off time: %w", err)
 		}
-		onConv, err := ToUTCHHMM(req.On, userTZ)
+		onTime, err := parseClock(req.On)
 		if err != nil {
 			return fmt.Errorf("invalid on time: %w", err)
 		}
@@ -3427,12 +3218,8 @@ func (s *ScheduleService) UpdateNamespaceSchedule(ctx context.Context, req Names
 		if err != nil {
 			return fmt.Errorf("invalid sleep weekdays: %w", err)
 		}
-		wdSleepUTC, err := ShiftWeekdaysStr(wdSleepKube, offConv.DayShift)
-		if err != nil {
-			return fmt.Errorf("failed to shift sleep weekdays: %w", err)
-		}
 
-		if err := s.validateScheduleOverlap(ctx, req.Tenant, map[string]bool{req.Namespace: true}, wdSleepUTC, offConv.TimeUTC, onConv.TimeUTC, req.ScheduleName); err != nil {
+		if err := s.validateScheduleOverlap(ctx, req.Tenant, map[string]bool{req.Namespace: true}, wdSleepKube, offTime, onTime, TZLocal, req.ScheduleName); err != nil {
 			return err
 		}
 	}
@@ -3450,7 +3237,7 @@ func (s *ScheduleService) UpdateNamespaceSchedule(ctx context.Context, req Names
 }
 
 // extractDelaysFromSchedule extrae los delays configurados de un schedule existente
-// analizando los tiempos de los SleepInfos wake en namespaces datastores
+// analizando los tiempos de los wake (etapas de wake o SleepInfos wake) en namespaces datastores
 func (s *ScheduleService) extractDelaysFromSchedule(existing *ScheduleResponse, targetNamespaces []string) *DelayConfig {
 	// Buscar namespace datastores para extraer delays (solo datastores tiene staggered wake)
 	var datastoresNS *NamespaceInfo
@@ -3720,12 +3507,13 @@ func (s *ScheduleService) validateScheduleOverlap(
 	ctx context.Context,
 	tenant string,
 	namespaces map[string]bool,
-	sleepWeekdaysUTC string,
-	offUTC string,
-	onUTC string,
+	sleepWeekdays string,
+	off string,
+	on string,
+	timeZone string,
 	scheduleName string,
 ) error {
-	if len(namespaces) == 0 || sleepWeekdaysUTC == "" || offUTC == "" || onUTC == "" {
+	if len(namespaces) == 0 || sleepWeekdays == "" || off == "" || on == "" {
 		return nil
 	}
 
@@ -3737,13 +3525,18 @@ func (s *ScheduleService) validateScheduleOverlap(
 		return err
 	}
 
-	candidateDays := parseWeekdaysToArray(sleepWeekdaysUTC)
+	candidateDays := parseWeekdaysToArray(sleepWeekdays)
 	if len(candidateDays) == 0 {
 		return nil
 	}
 
-	candidateIntervals := buildIntervals(candidateDays, offUTC, onUTC)
-	now := time.Now().UTC()
+	// The schedules are compared in the timezone of the candidate, the one of its SleepInfos
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %s: %w", timeZone, err)
+	}
+	candidateIntervals := buildIntervals(candidateDays, off, on)
+	now := time.Now().In(location)
 	nowDay := int(now.Weekday())
 	nowMinutes := now.Hour()*60 + now.Minute()
 
@@ -3787,17 +3580,22 @@ func (s *ScheduleService) validateScheduleOverlap(
 				continue
 			}
 
-			sleepTime := group.sleep.Time
-			if sleepTime == "" {
+			if group.sleep.Time == "" {
+				continue
+			}
+			sleepTime, sleepWeekdays, err := toScheduleTimezone(group.sleep.Time, group.sleep.Weekdays, group.sleep.TimeZone, timeZone)
+			if err != nil {
 				continue
 			}
 
 			wakeTime := sleepTime
 			if group.wake != nil && group.wake.Time != "" {
-				wakeTime = group.wake.Time
+				if wakeTime, _, err = toScheduleTimezone(group.wake.Time, group.wake.Weekdays, group.wake.TimeZone, timeZone); err != nil {
+					continue
+				}
 			}
 
-			sleepDays := parseWeekdaysToArray(group.sleep.Weekdays)
+			sleepDays := parseWeekdaysToArray(sleepWeekdays)
 			if len(sleepDays) == 0 {
 				continue
 			}
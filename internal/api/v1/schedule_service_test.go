@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+func TestForEachNamespace(t *testing.T) {
+	selected := map[string]bool{"apps": true, "airflowsso": true, "datastores": true, "intelligence": true, "rocket": true, "extra": true}
+
+	t.Run("every namespace is processed with bounded concurrency", func(t *testing.T) {
+		var mu sync.Mutex
+		processed := map[string]bool{}
+		var running, maxRunning atomic.Int32
+		err := forEachNamespace(selected, func(suffix string) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				current := maxRunning.Load()
+				if n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			processed[suffix] = true
+			mu.Unlock()
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, selected, processed)
+		require.LessOrEqual(t, maxRunning.Load(), int32(namespaceConcurrency))
+		require.Greater(t, maxRunning.Load(), int32(1))
+	})
+
+	t.Run("errors of every failed namespace are returned in suffix order", func(t *testing.T) {
+		err := forEachNamespace(selected, func(suffix string) error {
+			if suffix == "rocket" || suffix == "apps" {
+				return errors.New(suffix + " failed")
+			}
+			return nil
+		})
+		require.EqualError(t, err, "apps failed\nrocket failed")
+	})
+}
+
+func TestUpdateScheduleInPlace(t *testing.T) {
+	ctx := context.Background()
+	service, c := newTestService(t,
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-apps"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-rocket"}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-datastores"}},
+	)
+	get := func(namespace, name string) (*kubegreenv1alpha1.SleepInfo, error) {
+		si := &kubegreenv1alpha1.SleepInfo{}
+		return si, c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, si)
+	}
+
+	require.NoError(t, service.CreateSchedule(ctx, CreateScheduleRequest{
+		Tenant: "bda", ScheduleName: "laboral", Off: "22:00", On: "06:00", Weekdays: "1-5", Namespaces: []string{"apps", "rocket"},
+	}))
+	require.NoError(t, service.CreateSchedule(ctx, CreateScheduleRequest{
+		Tenant: "bda", ScheduleName: "finde", Off: "20:00", On: "08:00", Weekdays: "6", Namespaces: []string{"rocket"},
+	}))
+	// A label set by another writer is only kept when the SleepInfo is not recreated
+	before, err := get("bda-apps", "laboral")
+	require.NoError(t, err)
+	before.Labels = map[string]string{"team": "data"}
+	require.NoError(t, c.Update(ctx, before, client.FieldOwner("kubectl-edit")))
+
+	// rocket leaves the schedule and datastores joins it
+	require.NoError(t, service.UpdateSchedule(ctx, "bda", CreateScheduleRequest{
+		ScheduleName: "laboral", Off: "23:00", On: "07:00", Weekdays: "1-5", Namespaces: []string{"apps", "datastores"},
+	}))
+
+	t.Run("SleepInfos still in the schedule are updated in place", func(t *testing.T) {
+		after, err := get("bda-apps", "laboral")
+		require.NoError(t, err)
+		require.Equal(t, "data", after.Labels["team"])
+		require.NotEqual(t, before.Spec.SleepTime, after.Spec.SleepTime)
+	})
+
+	t.Run("new namespaces get their SleepInfo", func(t *testing.T) {
+		_, err := get("bda-datastores", "laboral")
+		require.NoError(t, err)
+	})
+
+	t.Run("only the SleepInfos no longer in the schedule are deleted", func(t *testing.T) {
+		_, err := get("bda-rocket", "laboral")
+		require.True(t, apierrors.IsNotFound(err))
+		_, err = get("bda-rocket", "finde")
+		require.NoError(t, err)
+	})
+}
@@ -6,6 +6,7 @@ package v1
 
 import (
 	"context"
+	"crypto/tls"
 	_ "embed"
 	"fmt"
 	"net/http"
@@ -16,10 +17,12 @@ import (
 	"github.com/go-logr/logr"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kube-green/kube-green/internal/api/v1/auth"
 	_ "github.com/kube-green/kube-green/internal/api/v1/docs" // Swagger docs
+	"github.com/kube-green/kube-green/internal/notifications"
 )
 
 //go:embed static/API_DOCUMENTATION.html
@@ -35,16 +38,32 @@ type Server struct {
 	scheduleService *ScheduleService
 	authHandler     *auth.AuthHandler
 	userStore       *auth.UserStore
+	informers       cache.Informers
+	eventHub        *EventHub
+	savingsPricing  SavingsPricing
+	tls             bool
+	notifier        notifications.Notifier
+	subscriptions   *notifications.Store
 }
 
 // Config holds the configuration for the REST API server
 type Config struct {
 	Port       int
 	Client     client.Client
-	APIReader  client.Reader // optional direct API reader, bypasses informer cache
+	Service    *ScheduleService // shared with the gRPC server, see NewService
 	Logger     logr.Logger
 	EnableCORS bool
-	Namespace  string // Kubernetes namespace for loading secrets
+	CORS       CORSConfig      // allow-list of the CORS headers when EnableCORS is set
+	Namespace  string          // Kubernetes namespace for loading secrets
+	Informers  cache.Informers // optional shared informers, enables the /api/v1/events stream
+	Pricing    SavingsPricing  // optional default prices of the savings estimation
+	TLSConfig  *tls.Config     // optional, serves HTTPS when set (e.g. with a certwatcher GetCertificate)
+	// IPs or CIDRs of the proxies whose X-Forwarded-For and X-Real-IP headers identify the client of
+	// the rate limits and the logs. None by default: the client is the peer of the connection.
+	TrustedProxies []string
+	// optional subscriptions of the webhook notifications, enables /api/v1/webhooks with the
+	// notifier of the Service
+	Subscriptions *notifications.Store
 }
 
 // NewServer creates a new REST API server instance
@@ -53,14 +72,35 @@ func NewServer(config Config) *Server {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		config.Logger.Error(err, "Invalid trusted proxies, client IPs are taken from the connection", "proxies", config.TrustedProxies)
+		_ = router.SetTrustedProxies(nil)
+	}
 	router.Use(gin.Recovery())
+	router.Use(requestIDMiddleware())
+	router.Use(localeMiddleware())
 
 	// Add logging middleware
 	router.Use(ginLogger(config.Logger))
 
 	// Enable CORS if requested
 	if config.EnableCORS {
-		router.Use(corsMiddleware())
+		router.Use(corsMiddleware(config.CORS))
+		if len(config.CORS.AllowedOrigins) > 0 {
+			config.Logger.Info("CORS allow-list enabled", "origins", config.CORS.AllowedOrigins,
+				"allowCredentials", config.CORS.AllowCredentials)
+		}
+	}
+
+	// Rate limiting protects the Kubernetes API from clients flooding the cluster-wide LIST endpoints
+	service := config.Service
+	if service.limiter != nil {
+		router.Use(rateLimitMiddleware(service))
+	}
+
+	// Audit mutating requests. Must run before the JWT middleware to also record rejected requests.
+	if service.Audited() {
+		router.Use(auditMiddleware(service))
 	}
 
 	server := &Server{
@@ -68,7 +108,14 @@ func NewServer(config Config) *Server {
 		logger:          config.Logger,
 		router:          router,
 		port:            config.Port,
-		scheduleService: NewScheduleService(config.Client, config.Logger, config.APIReader),
+		scheduleService: service,
+		informers:       config.Informers,
+		savingsPricing:  config.Pricing,
+		notifier:        service.notifier,
+		subscriptions:   config.Subscriptions,
+	}
+	if config.Informers != nil {
+		server.eventHub = NewEventHub()
 	}
 
 	// Initialize authentication if enabled
@@ -100,6 +147,9 @@ func NewServer(config Config) *Server {
 	// Add JWT middleware if auth is enabled
 	if authEnabled && jwtSecret != nil {
 		router.Use(auth.JWTAuthMiddleware(jwtSecret, true))
+		if service.impersonate {
+			router.Use(impersonationMiddleware())
+		}
 	}
 
 	// Setup routes
@@ -112,7 +162,9 @@ func NewServer(config Config) *Server {
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		TLSConfig:    config.TLSConfig,
 	}
+	server.tls = config.TLSConfig != nil
 
 	return server
 }
@@ -137,6 +189,20 @@ func (s *Server) setupRoutes() {
 	// Tenant discovery endpoints
 	s.router.GET("/api/v1/tenants", s.handleListTenants)
 
+	// Timezones available for schedules
+	s.router.GET("/api/v1/timezones", s.handleListTimezones)
+
+	// Webhook notifications of the schedule lifecycle
+	webhooks := s.router.Group("/api/v1/webhooks")
+	{
+		webhooks.GET("", s.handleListWebhooks)
+		webhooks.POST("", s.handleCreateWebhook)
+		webhooks.DELETE("/:id", s.handleDeleteWebhook)
+	}
+
+	// Real-time schedule events (Server-Sent Events)
+	s.router.GET("/api/v1/events", s.handleEvents)
+
 	// User management endpoints (admin only)
 	userMgmt := s.router.Group("/api/v1/users")
 	{
@@ -150,6 +216,8 @@ func (s *Server) setupRoutes() {
 	// Namespace services endpoints
 	s.router.GET("/api/v1/namespaces/:tenant/services", s.handleGetNamespaceServices)
 	s.router.GET("/api/v1/namespaces/:tenant/resources", s.handleGetNamespaceResources)
+	s.router.GET("/api/v1/namespaces/:tenant/effective-schedule", s.handleGetEffectiveSchedule)
+	s.router.GET("/api/v1/namespace-policies", s.handleListNamespacePolicies)
 
 	// Schedule management endpoints
 	v1 := s.router.Group("/api/v1/schedules")
@@ -157,14 +225,29 @@ func (s *Server) setupRoutes() {
 		v1.GET("", s.handleListSchedules)
 		v1.GET("/suspended", s.handleGetAllSuspendedServices) // Aggregate endpoint for all tenants
 		v1.GET("/next", s.handleGetAllNextOperations)         // Aggregate endpoint for all tenants
+		v1.GET("/drifted", s.handleGetDriftedSleepInfos)      // Aggregate endpoint for all tenants
 		v1.GET("/:tenant", s.handleGetSchedule)
 		v1.GET("/:tenant/suspended", s.handleGetSuspendedServices)
 		v1.GET("/:tenant/next", s.handleGetNextOperation)
+		v1.GET("/:tenant/preview", s.handleGetSchedulePreview)
+		v1.GET("/:tenant/export", s.handleExportSchedule)
+		v1.GET("/:tenant/calendar.ics", s.handleGetScheduleCalendar)
+		v1.GET("/:tenant/savings", s.handleGetSavings)
+		v1.GET("/:tenant/:namespace/status", s.handleGetNamespaceStatus)
 		v1.POST("", s.handleCreateSchedule)
+		v1.POST("/validate", s.handleValidateSchedule)
 		v1.POST("/:tenant/manual", s.handleManualScheduleAction)
+		v1.POST("/:tenant/sleep-now", s.handleSleepNow)
+		v1.POST("/:tenant/wake-now", s.handleWakeNow)
+		v1.POST("/:tenant/snooze", s.handleSnoozeSchedule)
+		v1.POST("/:tenant/windows", s.handleCreateWindowSchedule)
+		v1.POST("/:tenant/clone", s.handleCloneSchedule)
 		v1.POST("/:tenant/suspend", s.handleSuspendSchedule)
 		v1.DELETE("/:tenant/suspend", s.handleUnsuspendSchedule)
+		v1.PUT("/:tenant/pause", s.handlePauseSchedule)
+		v1.PUT("/:tenant/resume", s.handleResumeSchedule)
 		v1.PUT("/:tenant", s.handleUpdateSchedule)
+		v1.DELETE("", s.handleBulkDeleteSchedules)
 		v1.DELETE("/:tenant", s.handleDeleteSchedule)
 
 		// Namespace-specific schedule endpoints
@@ -194,12 +277,35 @@ func (s *Server) setupRoutes() {
 
 // Start starts the HTTP server
 func (s *Server) Start(ctx context.Context) error {
-	s.logger.Info("Starting REST API server", "port", s.port)
+	s.logger.Info("Starting REST API server", "port", s.port, "tls", s.tls)
+
+	if s.informers != nil {
+		if err := s.scheduleService.WatchNamespaces(ctx, s.informers); err != nil {
+			s.logger.Error(err, "failed to watch namespaces, tenants are listed on every request")
+		}
+		s.scheduleService.WatchDrift(ctx)
+	}
+	if s.eventHub != nil {
+		tenantOf := func(namespace string) string {
+			return s.scheduleService.namespaceTenant(ctx, namespace)
+		}
+		if err := s.eventHub.watchSleepInfos(ctx, s.informers, tenantOf); err != nil {
+			s.logger.Error(err, "failed to watch SleepInfos, event stream disabled")
+			s.eventHub = nil
+		}
+	}
 
 	// Start server in a goroutine
 	errChan := make(chan error, 1)
 	go func() {
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.tls {
+			// Certificates come from TLSConfig (GetCertificate or Certificates)
+			err = s.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			errChan <- err
 		}
 	}()
@@ -243,29 +349,14 @@ func ginLogger(logger logr.Logger) gin.HandlerFunc {
 			"status", status,
 			"latency", latency,
 			"client_ip", c.ClientIP(),
+			"request_id", c.GetString(requestIDKey),
 		)
 	}
 }
 
-// corsMiddleware adds CORS headers
-func corsMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
-	}
-}
-
 // handleAuthLogin wraps the auth handler login with lazy initialization
 // @Summary Login
+// @ID authLogin
 // @Description Authenticates a user and returns JWT access and refresh tokens
 // @Tags Auth
 // @Accept json
@@ -295,6 +386,7 @@ func (s *Server) handleAuthLogin(c *gin.Context) {
 
 // handleAuthRefresh wraps the auth handler refresh with lazy initialization
 // @Summary Refresh token
+// @ID authRefresh
 // @Description Refreshes an access token using a refresh token
 // @Tags Auth
 // @Accept json
@@ -321,6 +413,7 @@ func (s *Server) handleAuthRefresh(c *gin.Context) {
 
 // handleAuthMe wraps the auth handler me with lazy initialization
 // @Summary Get current user info
+// @ID authMe
 // @Description Returns information about the currently authenticated user
 // @Tags Auth
 // @Accept json
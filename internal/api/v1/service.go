@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kube-green/kube-green/internal/api/v1/auth"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
+	"github.com/kube-green/kube-green/internal/gitops"
+	"github.com/kube-green/kube-green/internal/notifications"
+)
+
+// ServiceConfig configures the ScheduleService shared by the REST and gRPC API servers
+type ServiceConfig struct {
+	Client    client.Client
+	APIReader client.Reader // optional direct API reader, bypasses informer cache
+	Logger    logr.Logger
+	Namespace string                 // Kubernetes namespace of kube-green, default namespace of the policies ConfigMap
+	Notifier  notifications.Notifier // optional, receives the schedule lifecycle events
+	// optional label convention of the tenant namespaces, the {tenant}-{suffix} name is used otherwise
+	NamespaceLabels NamespaceLabels
+	// optional namespace suffix catalogue; the ConfigMap is looked up in Namespace when its namespace is empty
+	NamespacePolicies NamespacePolicySource
+	// optional key decrypting the restore patches encrypted by the controller
+	RestoreDataKey *restoredata.EncryptionKey
+	// optional impersonation of the authenticated user on writes, needs authentication enabled
+	Impersonation ImpersonationConfig
+	Audit         AuditConfig     // optional audit log of mutating requests
+	RateLimit     RateLimitConfig // optional token-bucket rate limiting, disabled when zero
+	// optional repository where the SleepInfos and TenantSchedules written are committed instead of
+	// the cluster, which Flux or Argo CD syncs
+	GitOps *gitops.Repository
+}
+
+// NewService builds the ScheduleService once for every API transport, so REST and gRPC calls
+// write through the same impersonating and audited client and share the audit log and rate limits.
+func NewService(config ServiceConfig) *ScheduleService {
+	// Writes are impersonated as the authenticated user, so Kubernetes RBAC and audit see the real person
+	serviceClient := config.Client
+	impersonate := config.Impersonation.Enabled() && auth.IsAuthEnabled()
+	if impersonate {
+		serviceClient = newImpersonatingClient(config.Client, config.Impersonation)
+		config.Logger.Info("User impersonation enabled", "userPrefix", config.Impersonation.UserPrefix,
+			"groupPrefix", config.Impersonation.GroupPrefix, "groups", config.Impersonation.Groups)
+	} else if config.Impersonation.Enabled() {
+		config.Logger.Info("User impersonation needs authentication, writes use the ServiceAccount")
+	}
+
+	// The GitOps client wraps the impersonating one, whose writes would otherwise bypass it
+	var gitOps *gitOpsClient
+	if config.GitOps != nil {
+		gitOps = &gitOpsClient{Client: serviceClient, repository: config.GitOps}
+		serviceClient = gitOps
+		config.Logger.Info("GitOps mode enabled, schedule changes are committed to the repository",
+			"repository", config.GitOps.String(), "pullRequestBase", config.GitOps.PullRequestBase)
+	}
+
+	var auditLog *auditLogger
+	if config.Audit.LogPath != "" || config.Audit.Recorder != nil {
+		var err error
+		auditLog, err = newAuditLogger(config.Audit, config.Logger.WithName("audit"))
+		if err != nil {
+			config.Logger.Error(err, "Failed to initialize audit log, audit disabled")
+		} else {
+			serviceClient = auditedClient{Client: serviceClient}
+			config.Logger.Info("Audit log enabled", "path", config.Audit.LogPath, "events", config.Audit.Recorder != nil)
+		}
+	}
+
+	service := NewScheduleService(serviceClient, config.Logger, config.APIReader)
+	service.impersonate = impersonate
+	service.audit = auditLog
+	service.gitOps = gitOps
+	if config.RateLimit.Enabled() {
+		service.limiter = newRateLimiter(config.RateLimit)
+		config.Logger.Info("Rate limiting enabled",
+			"globalRPS", config.RateLimit.GlobalRPS, "globalBurst", config.RateLimit.GlobalBurst,
+			"clientRPS", config.RateLimit.ClientRPS, "clientBurst", config.RateLimit.ClientBurst)
+	}
+
+	service.SetNamespaceLabels(config.NamespaceLabels)
+	if config.NamespacePolicies.ConfigMap != "" && config.NamespacePolicies.Namespace == "" {
+		config.NamespacePolicies.Namespace = config.Namespace
+	}
+	service.SetNamespacePolicies(config.NamespacePolicies)
+	service.SetRestoreDataKey(config.RestoreDataKey)
+	if config.Notifier != nil {
+		service.SetNotifier(config.Notifier)
+	}
+	return service
+}
+
+// Audited reports whether mutating calls are written to the audit log
+func (s *ScheduleService) Audited() bool {
+	return s.audit != nil
+}
+
+// BeginAudit prepares ctx to collect the namespaces written during a call; the returned function
+// lists them once the call is done
+func BeginAudit(ctx context.Context) (context.Context, func() []string) {
+	touched := &touchedNamespaces{}
+	return context.WithValue(ctx, touchedNamespacesKey{}, touched), touched.list
+}
+
+// WriteAudit writes an audit record when the audit log is enabled
+func (s *ScheduleService) WriteAudit(rec AuditRecord) {
+	if s.audit == nil {
+		return
+	}
+	if rec.Subject == "" {
+		rec.Subject = "anonymous"
+	}
+	s.audit.write(rec)
+}
+
+// RateLimitError is returned by CheckRateLimit when a call exceeds the configured rates
+type RateLimitError struct {
+	Scope      string        // client or global
+	RetryAfter time.Duration // time until the next request is allowed
+}
+
+func (e *RateLimitError) Error() string {
+	return "Rate limit exceeded (" + e.Scope + "), retry later"
+}
+
+// CheckRateLimit takes a token from the bucket of the client and from the global bucket, returning
+// a *RateLimitError when either is empty. It always allows calls when rate limiting is disabled.
+func (s *ScheduleService) CheckRateLimit(clientKey string) error {
+	if s.limiter == nil {
+		return nil
+	}
+	return s.limiter.allow(clientKey, time.Now())
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// newTestClientBuilder returns a fake client builder with the kube-green types, whose client returns
+// the managed fields of the objects
+func newTestClientBuilder(t *testing.T) *fake.ClientBuilder {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithReturnManagedFields()
+}
+
+// newTestService returns a ScheduleService over a fake client with the objects
+func newTestService(t *testing.T, objects ...client.Object) (*ScheduleService, client.Client) {
+	t.Helper()
+	c := newTestClientBuilder(t).WithObjects(objects...).Build()
+	return NewScheduleService(c, logr.Discard()), c
+}
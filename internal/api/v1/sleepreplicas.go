@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// SleepReplicasConfig keeps some replicas of the Deployments and StatefulSets matching the filter
+// during sleep instead of scaling them to zero. Its matchLabels and matchExpressions must all match.
+type SleepReplicasConfig struct {
+	Filter   ExclusionFilter `json:"filter"`
+	Replicas int32           `json:"replicas" example:"1"` // Replicas kept during sleep
+}
+
+// NamespaceSleepReplicas keeps some replicas of the matching workloads of a specific namespace during sleep
+type NamespaceSleepReplicas struct {
+	Namespace string          `json:"namespace"`
+	Filter    ExclusionFilter `json:"filter"`
+	Replicas  int32           `json:"replicas" example:"1"` // Replicas kept during sleep
+}
+
+// validateSleepReplicas checks the replicas kept during sleep can be applied by the controller
+func validateSleepReplicas(sleepReplicas []NamespaceSleepReplicas) error {
+	for i, entry := range sleepReplicas {
+		if strings.TrimSpace(entry.Namespace) == "" {
+			return fmt.Errorf("invalid sleepReplicas %d: namespace is required", i)
+		}
+		if err := entry.Filter.validate(); err != nil {
+			return fmt.Errorf("invalid sleepReplicas %d: %w", i, err)
+		}
+		if entry.Filter.Kind != "" && entry.Filter.Kind != "Deployment" && entry.Filter.Kind != "StatefulSet" {
+			return fmt.Errorf("invalid sleepReplicas %d: kind must be Deployment or StatefulSet", i)
+		}
+		if entry.Replicas < 0 {
+			return fmt.Errorf("invalid sleepReplicas %d: replicas must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// sleepReplicasFor returns the sleepReplicas of the namespace {tenant}-{suffix}
+func sleepReplicasFor(tenant, suffix string, sleepReplicas []NamespaceSleepReplicas) []kubegreenv1alpha1.SleepReplicas {
+	var result []kubegreenv1alpha1.SleepReplicas
+	for _, entry := range sleepReplicas {
+		if entry.Namespace == suffix || entry.Namespace == fmt.Sprintf("%s-%s", tenant, suffix) {
+			result = append(result, kubegreenv1alpha1.SleepReplicas{FilterRef: entry.Filter.toFilterRef(), Replicas: entry.Replicas})
+		}
+	}
+	return result
+}
+
+// sleepReplicasOf returns the replicas kept during sleep by a SleepInfo
+func sleepReplicasOf(si kubegreenv1alpha1.SleepInfo) []SleepReplicasConfig {
+	var result []SleepReplicasConfig
+	for _, entry := range si.Spec.SleepReplicas {
+		result = append(result, SleepReplicasConfig{Filter: exclusionFromFilterRef(entry.FilterRef), Replicas: entry.Replicas})
+	}
+	return result
+}
+
+// existingSleepReplicas returns the sleepReplicas of the SleepInfos of a schedule, so an update keeps them
+func existingSleepReplicas(schedule *ScheduleResponse) []NamespaceSleepReplicas {
+	sleepReplicas := []NamespaceSleepReplicas{}
+	for suffix, nsInfo := range schedule.Namespaces {
+		seen := map[string]bool{}
+		for _, sched := range nsInfo.Schedule {
+			for _, entry := range sched.SleepReplicas {
+				if key := fmt.Sprintf("%s|%d", entry.Filter.key(), entry.Replicas); !seen[key] {
+					seen[key] = true
+					sleepReplicas = append(sleepReplicas, NamespaceSleepReplicas{Namespace: suffix, Filter: entry.Filter, Replicas: entry.Replicas})
+				}
+			}
+		}
+	}
+	return sleepReplicas
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// MaxSnoozeDuration is the longest delay accepted for the next sleep
+const MaxSnoozeDuration = 24 * time.Hour
+
+// SnoozeRequest delays the next sleep of a tenant
+type SnoozeRequest struct {
+	Duration     string `json:"duration" binding:"required" example:"2h"`         // Go duration, e.g. 30m, 2h, 1h30m
+	Namespace    string `json:"namespace,omitempty" example:"apps"`               // Optional namespace suffix filter
+	ScheduleName string `json:"scheduleName,omitempty" example:"horario-laboral"` // Optional schedule name filter
+}
+
+// SnoozeResponse lists the SleepInfos whose next sleep was delayed
+type SnoozeResponse struct {
+	Tenant     string       `json:"tenant"`
+	Duration   string       `json:"duration"`
+	SleepInfos []SnoozeItem `json:"sleepInfos"`
+}
+
+// SnoozeItem is a SleepInfo whose next sleep now runs at SnoozedUntil instead of ScheduledSleep,
+// which is the pending snooze deadline when the sleep was already snoozed
+type SnoozeItem struct {
+	Namespace      string    `json:"namespace"`
+	Name           string    `json:"name"`
+	ScheduledSleep time.Time `json:"scheduledSleep"`
+	SnoozedUntil   time.Time `json:"snoozedUntil"`
+}
+
+// SnoozeSchedule delays only the next sleep of the matching SleepInfos by duration, without touching the
+// recurring spec: the controller skips the scheduled sleep, runs it at snooze-until and clears the
+// annotation. Snoozing again while a snooze is pending extends it. The delayed sleep must still happen
+// before the next wake, otherwise the request is rejected.
+func (s *ScheduleService) SnoozeSchedule(ctx context.Context, tenant string, req SnoozeRequest, now time.Time) (*SnoozeResponse, error) {
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 || duration > MaxSnoozeDuration {
+		return nil, fmt.Errorf("invalid duration %q: expected a positive duration up to %s", req.Duration, MaxSnoozeDuration)
+	}
+
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, req.ScheduleName, req.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SnoozeResponse{Tenant: tenant, Duration: duration.String(), SleepInfos: []SnoozeItem{}}
+	updates := []kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfos {
+		if si.Annotations["kube-green.stratio.com/pair-role"] == "wake" || si.IsSuspended() {
+			continue
+		}
+		from := now
+		if si.IsSuspendedUntil(now) {
+			from = si.Spec.SuspendScheduleUntil.Time
+		}
+		scheduledSleep := nextTrigger(si, "SLEEP", from)
+		if until, ok := si.GetSnoozeUntil(); ok && until.After(now) {
+			scheduledSleep = until
+		}
+		if scheduledSleep.IsZero() {
+			continue
+		}
+		snoozedUntil := scheduledSleep.Add(duration)
+
+		wake := nextTrigger(si, "WAKE_UP", scheduledSleep)
+		for _, other := range sleepInfos {
+			pairID := si.Annotations["kube-green.stratio.com/pair-id"]
+			if pairID == "" || other.Annotations["kube-green.stratio.com/pair-id"] != pairID || other.Annotations["kube-green.stratio.com/pair-role"] != "wake" {
+				continue
+			}
+			if t := nextTrigger(other, "WAKE_UP", scheduledSleep); !t.IsZero() && (wake.IsZero() || t.Before(wake)) {
+				wake = t
+			}
+		}
+		if !wake.IsZero() && !snoozedUntil.Before(wake) {
+			return nil, fmt.Errorf("invalid duration %q: the sleep of %s/%s would be delayed to %s, after the next wake at %s",
+				req.Duration, si.Namespace, si.Name, snoozedUntil.UTC().Format(time.RFC3339), wake.UTC().Format(time.RFC3339))
+		}
+
+		if si.Annotations == nil {
+			si.Annotations = make(map[string]string)
+		}
+		si.Annotations[kubegreenv1alpha1.SnoozeUntilAnnotation] = snoozedUntil.UTC().Format(time.RFC3339)
+		updates = append(updates, si)
+		response.SleepInfos = append(response.SleepInfos, SnoozeItem{
+			Namespace:      si.Namespace,
+			Name:           si.Name,
+			ScheduledSleep: scheduledSleep.UTC(),
+			SnoozedUntil:   snoozedUntil.UTC(),
+		})
+	}
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("no schedules found for tenant: %s", tenant)
+	}
+
+	// Every SleepInfo is validated before updating any of them. The snooze is a runtime annotation,
+	// written to the cluster in GitOps mode too.
+	for i := range updates {
+		if err := s.client.Update(withClusterWrite(ctx), &updates[i]); err != nil {
+			return nil, fmt.Errorf("failed to update SleepInfo %s: %w", updates[i].Name, err)
+		}
+		s.logger.Info("Sleep snoozed", "sleepinfo", updates[i].Name, "namespace", updates[i].Namespace,
+			"snoozeUntil", updates[i].Annotations[kubegreenv1alpha1.SnoozeUntilAnnotation])
+	}
+	return response, nil
+}
+
+// nextTrigger returns the first execution of operation (SLEEP or WAKE_UP) of si after from, zero if none
+func nextTrigger(si kubegreenv1alpha1.SleepInfo, operation string, from time.Time) time.Time {
+	var earliest time.Time
+	for _, trigger := range sleepInfoTriggers(si) {
+		if trigger.operation != operation {
+			continue
+		}
+		sched, err := kubegreenv1alpha1.ParseSchedule(trigger.cron)
+		if err != nil {
+			continue
+		}
+		if next := sched.Next(from); !next.IsZero() && (earliest.IsZero() || next.Before(earliest)) {
+			earliest = next
+		}
+	}
+	return earliest
+}
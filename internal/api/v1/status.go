@@ -0,0 +1,313 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxStatusEvents is the number of warning events returned per SleepInfo
+	maxStatusEvents = 10
+	// missedOperationTolerance is how late the controller may record an operation before it is reported as missed
+	missedOperationTolerance = 2 * time.Minute
+)
+
+// NamespaceStatusResponse is the controller view of the SleepInfos of a tenant namespace
+type NamespaceStatusResponse struct {
+	Tenant     string            `json:"tenant"`
+	Namespace  string            `json:"namespace"` // Full namespace name
+	Healthy    bool              `json:"healthy"`   // True when no SleepInfo reports errors
+	SleepInfos []SleepInfoStatus `json:"sleepInfos"`
+}
+
+// SleepInfoStatus tells whether the controller is processing a SleepInfo
+type SleepInfoStatus struct {
+	Name                string                                   `json:"name"`
+	Role                string                                   `json:"role"`                       // sleep, wake, window, or sleep/wake for single objects
+	Processed           bool                                     `json:"processed"`                  // The controller executed at least one operation
+	LastScheduleTime    *time.Time                               `json:"lastScheduleTime,omitempty"` // status.lastScheduleTime
+	LastOperation       string                                   `json:"lastOperation,omitempty"`    // SLEEP or WAKE_UP, from status
+	LastManualOperation *kubegreenv1alpha1.ManualOperationStatus `json:"lastManualOperation,omitempty"`
+	CurrentState        string                                   `json:"currentState,omitempty"`        // Sleeping, Awake or Transitioning, from status
+	LastSleepTime       *time.Time                               `json:"lastSleepTime,omitempty"`       // status.lastSleepTime
+	LastWakeUpTime      *time.Time                               `json:"lastWakeUpTime,omitempty"`      // status.lastWakeUpTime
+	SuspendedResources  map[string]int32                         `json:"suspendedResources,omitempty"`  // status.suspendedResourceCounts
+	FailedResources     []kubegreenv1alpha1.FailedResource       `json:"failedResources,omitempty"`     // Resources the last operation failed on
+	Conditions          []metav1.Condition                       `json:"conditions,omitempty"`          // Ready, LastOperationSucceeded and Degraded
+	SecretPresent       bool                                     `json:"secretPresent"`                 // The sleepinfo-<name> secret exists
+	SecretScheduledAt   *time.Time                               `json:"secretScheduledAt,omitempty"`   // Last operation recorded in the secret
+	SecretOperation     string                                   `json:"secretOperation,omitempty"`     // Operation recorded in the secret
+	RestoreDataPresent  bool                                     `json:"restoreDataPresent"`            // The secret or the SleepInfoState holds restore patches for a wake
+	NextRequeueTime     *time.Time                               `json:"nextRequeueTime,omitempty"`     // When the controller is expected to act next
+	NextRequeueReason   string                                   `json:"nextRequeueReason,omitempty"`   // schedule, window, manual-action, snooze or suspension-end
+	Paused              bool                                     `json:"paused,omitempty"`              // Paused schedules are never requeued by the cron
+	SuspendedUntil      *time.Time                               `json:"suspendedUntil,omitempty"`      // Pending suspension deadline
+	PendingManualAction string                                   `json:"pendingManualAction,omitempty"` // sleep or wake, not yet executed
+	Errors              []string                                 `json:"errors"`                        // Invalid spec, missed operations and warning events
+}
+
+// GetNamespaceStatus returns, for every SleepInfo of the namespace {tenant}-{namespaceSuffix}, what the
+// controller did with it: the status it wrote, the bookkeeping in its secret, the warning events
+// recorded for it and when it is expected to act next. Errors flags SleepInfos the controller rejects
+// or did not process on time.
+func (s *ScheduleService) GetNamespaceStatus(ctx context.Context, tenant, namespaceSuffix string, now time.Time) (*NamespaceStatusResponse, error) {
+	sleepInfos, err := s.listTenantSleepInfos(ctx, tenant, "", namespaceSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("no schedules found for tenant: %s in namespace: %s", tenant, namespaceSuffix)
+	}
+	sort.Slice(sleepInfos, func(i, j int) bool { return sleepInfos[i].Name < sleepInfos[j].Name })
+
+	response := &NamespaceStatusResponse{
+		Tenant:     tenant,
+		Namespace:  fmt.Sprintf("%s-%s", tenant, namespaceSuffix),
+		Healthy:    true,
+		SleepInfos: make([]SleepInfoStatus, 0, len(sleepInfos)),
+	}
+	for _, si := range sleepInfos {
+		status, err := s.sleepInfoStatus(ctx, si, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(status.Errors) > 0 {
+			response.Healthy = false
+		}
+		response.SleepInfos = append(response.SleepInfos, status)
+	}
+	return response, nil
+}
+
+func (s *ScheduleService) sleepInfoStatus(ctx context.Context, si kubegreenv1alpha1.SleepInfo, now time.Time) (SleepInfoStatus, error) {
+	status := SleepInfoStatus{
+		Name:                si.Name,
+		Role:                si.Annotations["kube-green.stratio.com/pair-role"],
+		LastOperation:       si.Status.OperationType,
+		LastManualOperation: si.Status.LastManualOperation,
+		CurrentState:        si.Status.CurrentState,
+		SuspendedResources:  si.Status.SuspendedResourceCounts,
+		FailedResources:     si.Status.FailedResources,
+		Conditions:          si.Status.Conditions,
+		Paused:              si.IsSuspended(),
+		Errors:              []string{},
+	}
+	if status.Role == "" {
+		status.Role = "sleep/wake"
+	}
+	if !si.Status.LastScheduleTime.IsZero() {
+		last := si.Status.LastScheduleTime.Time
+		status.LastScheduleTime = &last
+	}
+	if si.Status.LastSleepTime != nil {
+		last := si.Status.LastSleepTime.Time
+		status.LastSleepTime = &last
+	}
+	if si.Status.LastWakeUpTime != nil {
+		last := si.Status.LastWakeUpTime.Time
+		status.LastWakeUpTime = &last
+	}
+	if si.IsSuspendedUntil(now) {
+		until := si.Spec.SuspendScheduleUntil.Time
+		status.SuspendedUntil = &until
+	}
+
+	// Same checks the controller does before scheduling the SleepInfo, windows have no cron
+	if si.IsWindow() {
+		status.Role = "window"
+	} else {
+		status.Errors = append(status.Errors, cronScheduleErrors(si)...)
+	}
+
+	secret := &v1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKey{Name: fmt.Sprintf("sleepinfo-%s", si.Name), Namespace: si.Namespace}, secret)
+	switch {
+	case err == nil:
+		status.SecretPresent = true
+		if at, err := time.Parse(time.RFC3339, string(secret.Data["scheduled-at"])); err == nil {
+			status.SecretScheduledAt = &at
+		}
+		status.SecretOperation = string(secret.Data["operation-type"])
+		status.RestoreDataPresent = len(secret.Data["original-resource-info"]) > 0 || len(secret.Data[secretRestoreChunksKey]) > 0
+	case apierrors.IsNotFound(err):
+	default:
+		return SleepInfoStatus{}, fmt.Errorf("failed to get secret of SleepInfo %s: %w", si.Name, err)
+	}
+	if statePatches, err := s.restorePatchesFromState(ctx, si.Namespace, si.Name); err != nil {
+		return SleepInfoStatus{}, err
+	} else if statePatches != nil {
+		status.RestoreDataPresent = len(statePatches) > 0
+	}
+	status.Processed = status.LastScheduleTime != nil || status.SecretScheduledAt != nil
+
+	if action := si.Annotations["kube-green.stratio.com/manual-action"]; action != "" {
+		status.PendingManualAction = action
+	}
+	status.NextRequeueTime, status.NextRequeueReason = expectedRequeue(si, now)
+
+	if missed := missedOperation(si, status.LastScheduleTime, now); missed != "" {
+		status.Errors = append(status.Errors, missed)
+	}
+	if condition := meta.FindStatusCondition(si.Status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded); condition != nil && condition.Status == metav1.ConditionFalse {
+		status.Errors = append(status.Errors, fmt.Sprintf("last operation failed: %s", condition.Message))
+	}
+	if condition := meta.FindStatusCondition(si.Status.Conditions, kubegreenv1alpha1.ConditionDegraded); condition != nil && condition.Status == metav1.ConditionTrue {
+		status.Errors = append(status.Errors, condition.Message)
+	}
+
+	events, err := s.sleepInfoWarningEvents(ctx, si)
+	if err != nil {
+		s.logger.Error(err, "failed to list SleepInfo events", "sleepinfo", si.Name, "namespace", si.Namespace)
+	}
+	status.Errors = append(status.Errors, events...)
+	return status, nil
+}
+
+// cronScheduleErrors returns why the controller cannot parse the sleep and wake up schedules of si
+func cronScheduleErrors(si kubegreenv1alpha1.SleepInfo) []string {
+	errors := []string{}
+	if schedule, err := si.GetSleepSchedule(); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid sleep schedule: %s", err))
+	} else if _, err := kubegreenv1alpha1.ParseSchedule(schedule); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid sleep schedule: %s", err))
+	}
+	if schedule, err := si.GetWakeUpSchedule(); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid wake up schedule: %s", err))
+	} else if schedule != "" {
+		if _, err := kubegreenv1alpha1.ParseSchedule(schedule); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid wake up schedule: %s", err))
+		}
+	}
+	return errors
+}
+
+// expectedRequeue returns the earliest time the controller has a reason to act on si
+func expectedRequeue(si kubegreenv1alpha1.SleepInfo, now time.Time) (*time.Time, string) {
+	var next time.Time
+	reason := ""
+	consider := func(t time.Time, why string) {
+		if !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next, reason = t, why
+		}
+	}
+
+	if si.Annotations["kube-green.stratio.com/manual-action"] != "" {
+		at, err := time.Parse(time.RFC3339, si.Annotations["kube-green.stratio.com/manual-at"])
+		if err != nil || at.Before(now) {
+			at = now
+		}
+		consider(at, "manual-action")
+	}
+	if until, ok := si.GetSnoozeUntil(); ok && until.After(now) {
+		consider(until, "snooze")
+	}
+	if !si.IsSuspended() {
+		from := now
+		if si.IsSuspendedUntil(now) {
+			from = si.Spec.SuspendScheduleUntil.Time
+			consider(from, "suspension-end")
+		}
+		for _, operation := range []string{"SLEEP", "WAKE_UP"} {
+			consider(nextTrigger(si, operation, from), "schedule")
+		}
+		if duration := si.GetSleepDuration(); duration > 0 && si.Status.CurrentState == kubegreenv1alpha1.StateSleeping && si.Status.LastSleepTime != nil {
+			consider(si.Status.LastSleepTime.Add(duration), "sleep-duration")
+		}
+		if si.IsWindow() {
+			for _, t := range []time.Time{si.Spec.Window.Start.Time, si.Spec.Window.End.Time} {
+				if t.After(from) {
+					consider(t, "window")
+				}
+			}
+		}
+	}
+	if next.IsZero() {
+		return nil, ""
+	}
+	return &next, reason
+}
+
+// missedOperation reports the last scheduled operation when the controller did not record it.
+// Paused, suspended and snoozed SleepInfos skip operations on purpose and are not checked.
+func missedOperation(si kubegreenv1alpha1.SleepInfo, lastSchedule *time.Time, now time.Time) string {
+	if si.IsSuspended() || si.IsSuspendedUntil(now) {
+		return ""
+	}
+	if _, snoozed := si.GetSnoozeUntil(); snoozed {
+		return ""
+	}
+	created := si.CreationTimestamp.Time
+	var latest time.Time
+	latestOperation := ""
+	for _, trigger := range sleepInfoTriggers(si) {
+		sched, err := kubegreenv1alpha1.ParseSchedule(trigger.cron)
+		if err != nil {
+			continue
+		}
+		// Last execution before now: the one before the first execution after now - 8 days
+		for t := sched.Next(now.Add(-8 * 24 * time.Hour)); !t.IsZero() && t.Before(now); t = sched.Next(t) {
+			if t.After(latest) {
+				latest, latestOperation = t, trigger.operation
+			}
+		}
+	}
+	if latest.IsZero() || latest.Before(created) || now.Sub(latest) < missedOperationTolerance {
+		return ""
+	}
+	if lastSchedule != nil && !lastSchedule.Before(latest.Add(-missedOperationTolerance)) {
+		return ""
+	}
+	recorded := "never"
+	if lastSchedule != nil {
+		recorded = lastSchedule.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("missed operation: %s expected at %s, last recorded schedule %s",
+		latestOperation, latest.UTC().Format(time.RFC3339), recorded)
+}
+
+// sleepInfoWarningEvents returns the latest warning events recorded for si
+func (s *ScheduleService) sleepInfoWarningEvents(ctx context.Context, si kubegreenv1alpha1.SleepInfo) ([]string, error) {
+	events := &v1.EventList{}
+	if err := s.reader.List(ctx, events,
+		client.InNamespace(si.Namespace),
+		client.MatchingFields{"involvedObject.kind": "SleepInfo", "involvedObject.name": si.Name},
+	); err != nil {
+		return nil, err
+	}
+	warnings := []v1.Event{}
+	for _, event := range events.Items {
+		if event.Type == v1.EventTypeWarning {
+			warnings = append(warnings, event)
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool { return eventTime(warnings[i]).After(eventTime(warnings[j])) })
+	if len(warnings) > maxStatusEvents {
+		warnings = warnings[:maxStatusEvents]
+	}
+	messages := make([]string, 0, len(warnings))
+	for _, event := range warnings {
+		messages = append(messages, fmt.Sprintf("%s %s: %s", eventTime(event).UTC().Format(time.RFC3339), event.Reason, event.Message))
+	}
+	return messages, nil
+}
+
+func eventTime(event v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Keys of the sleepinfo-* secrets written by the controller
+	secretLastScheduleKey    = "scheduled-at"
+	secretLastOperationKey   = "operation-type"
+	secretRestorePatchesKey  = "original-resource-info"
+	secretRestoreChunksKey   = "original-resource-info-chunks"
+	secretLegacyReplicasKey  = "deployment-replicas"
+	suspendedReasonScheduled = "Scheduled sleep"
+	suspendedReasonManual    = "Manual sleep"
+)
+
+// suspendedServicesInNamespace cross-references the restore data of the namespace SleepInfos with
+// the current state of each resource and returns the ones still asleep.
+func (s *ScheduleService) suspendedServicesInNamespace(ctx context.Context, namespace string, sleepInfos []kubegreenv1alpha1.SleepInfo, now time.Time) ([]SuspendedServiceInfo, error) {
+	suspended := []SuspendedServiceInfo{}
+	seen := make(map[string]bool)
+
+	for _, si := range sleepInfos {
+		if si.Annotations["kube-green.stratio.com/pair-role"] == "wake" {
+			// Wake objects of a pair only restore what their sleep object saved
+			continue
+		}
+		secret := &v1.Secret{}
+		key := client.ObjectKey{Name: fmt.Sprintf("sleepinfo-%s", si.Name), Namespace: namespace}
+		if err := s.reader.Get(ctx, key, secret); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to get secret %s: %w", key.Name, err)
+			}
+			continue
+		}
+
+		restorePatches, err := restorePatchesFromSecret(ctx, s.reader, s.restoreDataKey, secret)
+		if err != nil {
+			s.logger.Error(err, "invalid restore data", "secret", key.Name, "namespace", namespace)
+			continue
+		}
+		if statePatches, err := s.restorePatchesFromState(ctx, namespace, si.Name); err != nil {
+			return nil, err
+		} else if statePatches != nil {
+			restorePatches = statePatches
+		}
+		if len(restorePatches) == 0 {
+			continue
+		}
+
+		suspendedAt := si.Status.LastScheduleTime.Time
+		if at, err := time.Parse(time.RFC3339, string(secret.Data[secretLastScheduleKey])); err == nil &&
+			string(secret.Data[secretLastOperationKey]) == "SLEEP" {
+			suspendedAt = at
+		}
+		reason := suspendedReasonScheduled
+		if op := si.Status.LastManualOperation; op != nil && op.Action == "sleep" && !op.ExecutedAt.Time.Before(suspendedAt.Add(-time.Minute)) {
+			reason = suspendedReasonManual
+		}
+		willWakeAt := ""
+		if wake := nextWakeTime(si, sleepInfos, now); !wake.IsZero() {
+			willWakeAt = wake.UTC().Format(time.RFC3339)
+		}
+
+		targets := make([]string, 0, len(restorePatches))
+		for target := range restorePatches {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			gk := schema.ParseGroupKind(target)
+			names := make([]string, 0, len(restorePatches[target]))
+			for name := range restorePatches[target] {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				id := gk.String() + "/" + name
+				if seen[id] {
+					continue
+				}
+				asleep, err := s.isResourceAsleep(ctx, gk, namespace, name, restorePatches[target][name])
+				if err != nil {
+					s.logger.Error(err, "failed to check resource state", "kind", gk.Kind, "name", name, "namespace", namespace)
+					continue
+				}
+				if !asleep {
+					continue
+				}
+				seen[id] = true
+				suspended = append(suspended, SuspendedServiceInfo{
+					Name:        name,
+					Namespace:   namespace,
+					Kind:        gk.Kind,
+					SuspendedAt: suspendedAt.UTC().Format(time.RFC3339),
+					Reason:      reason,
+					WillWakeAt:  willWakeAt,
+					SleepInfo:   si.Name,
+				})
+			}
+		}
+	}
+	return suspended, nil
+}
+
+// SetRestoreDataKey sets the key decrypting the restore patches encrypted by the controller
+func (s *ScheduleService) SetRestoreDataKey(key *restoredata.EncryptionKey) {
+	s.restoreDataKey = key
+}
+
+// restorePatchesFromSecret returns the restore merge patches by target ("Kind.group") and resource name,
+// converting the legacy deployment replicas format. Patches split in chunks are read from their secrets.
+func restorePatchesFromSecret(
+	ctx context.Context,
+	c client.Reader,
+	key *restoredata.EncryptionKey,
+	secret *v1.Secret,
+) (map[string]map[string]string, error) {
+	patches := map[string]map[string]string{}
+	data := secret.Data[secretRestorePatchesKey]
+	if index := secret.Data[secretRestoreChunksKey]; len(index) > 0 {
+		var err error
+		if data, err = restoredata.Join(ctx, c, secret.Namespace, index, secretRestorePatchesKey); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) > 0 {
+		var encryptionKey []byte
+		if restoredata.IsEncrypted(data) {
+			var err error
+			if encryptionKey, err = key.Get(ctx); err != nil {
+				return nil, err
+			}
+		}
+		data, err := restoredata.DecodeWithKey(data, encryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &patches); err != nil {
+			return nil, err
+		}
+	}
+	if data := secret.Data[secretLegacyReplicasKey]; len(data) > 0 {
+		var replicas []struct {
+			Name     string `json:"name"`
+			Replicas int32  `json:"replicas"`
+		}
+		if err := json.Unmarshal(data, &replicas); err != nil {
+			return nil, err
+		}
+		target := kubegreenv1alpha1.DeploymentTarget.String()
+		if patches[target] == nil {
+			patches[target] = map[string]string{}
+		}
+		for _, r := range replicas {
+			if _, ok := patches[target][r.Name]; !ok {
+				patches[target][r.Name] = fmt.Sprintf(`{"spec":{"replicas":%d}}`, r.Replicas)
+			}
+		}
+	}
+	return patches, nil
+}
+
+// restorePatchesFromState returns the restore patches of the SleepInfoState of a SleepInfo, which
+// replace the ones of its secret, nil if it has no SleepInfoState
+func (s *ScheduleService) restorePatchesFromState(ctx context.Context, namespace, name string) (map[string]map[string]string, error) {
+	state := &kubegreenv1alpha1.SleepInfoState{}
+	if err := s.reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, state); err != nil {
+		if client.IgnoreNotFound(err) == nil || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SleepInfoState %s: %w", name, err)
+	}
+	patches := map[string]map[string]string{}
+	for _, target := range state.Spec.Targets {
+		for _, res := range target.Resources {
+			if res.Patch == "" {
+				continue
+			}
+			if patches[target.Target] == nil {
+				patches[target.Target] = map[string]string{}
+			}
+			patches[target.Target][res.Name] = res.Patch
+		}
+	}
+	return patches, nil
+}
+
+// isResourceAsleep reports whether applying the restore patch would still change the resource,
+// i.e. it has not been woken up yet. Missing resources are not reported.
+func (s *ScheduleService) isResourceAsleep(ctx context.Context, gk schema.GroupKind, namespace, name, restorePatch string) (bool, error) {
+	if strings.TrimSpace(restorePatch) == "" || strings.TrimSpace(restorePatch) == "{}" {
+		return false, nil
+	}
+	mapping, err := s.client.RESTMapper().RESTMapping(gk)
+	if err != nil {
+		return false, err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(mapping.GroupVersionKind)
+	if err := s.reader.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	current, err := json.Marshal(obj.Object)
+	if err != nil {
+		return false, err
+	}
+	restored, err := jsonpatch.MergePatch(current, []byte(restorePatch))
+	if err != nil {
+		return false, err
+	}
+	var currentMap, restoredMap map[string]interface{}
+	if err := json.Unmarshal(current, &currentMap); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(restored, &restoredMap); err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(currentMap, restoredMap), nil
+}
+
+// nextWakeTime returns when the resources slept by si will be woken up: the earliest of a pending
+// manual wake and the next wake trigger of si itself or of the wake objects of its pair.
+// Paused schedules never wake on their own and return a zero time.
+func nextWakeTime(si kubegreenv1alpha1.SleepInfo, namespaceSleepInfos []kubegreenv1alpha1.SleepInfo, now time.Time) time.Time {
+	candidates := []kubegreenv1alpha1.SleepInfo{si}
+	if pairID := si.Annotations["kube-green.stratio.com/pair-id"]; pairID != "" {
+		for _, other := range namespaceSleepInfos {
+			if other.Name != si.Name && other.Annotations["kube-green.stratio.com/pair-id"] == pairID &&
+				other.Annotations["kube-green.stratio.com/pair-role"] == "wake" {
+				candidates = append(candidates, other)
+			}
+		}
+	}
+
+	var earliest time.Time
+	consider := func(t time.Time) {
+		if !t.IsZero() && (earliest.IsZero() || t.Before(earliest)) {
+			earliest = t
+		}
+	}
+	for _, candidate := range candidates {
+		if candidate.Annotations["kube-green.stratio.com/manual-action"] == "wake" {
+			if at, err := time.Parse(time.RFC3339, candidate.Annotations["kube-green.stratio.com/manual-at"]); err == nil && at.After(now) {
+				consider(at)
+			} else {
+				consider(now)
+			}
+		}
+		if candidate.IsSuspended() {
+			continue
+		}
+		from := now
+		if candidate.IsSuspendedUntil(now) {
+			from = candidate.Spec.SuspendScheduleUntil.Time
+		}
+		for _, trigger := range sleepInfoTriggers(candidate) {
+			if trigger.operation != "WAKE_UP" {
+				continue
+			}
+			if sched, err := kubegreenv1alpha1.ParseSchedule(trigger.cron); err == nil {
+				consider(sched.Next(from))
+			}
+		}
+	}
+	return earliest
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+// Power states of a tenant, derived from the last operation executed in each scheduled namespace
+const (
+	PowerStateAsleep      = "asleep"      // Every scheduled namespace is asleep
+	PowerStateAwake       = "awake"       // No scheduled namespace is asleep
+	PowerStatePartial     = "partial"     // Some scheduled namespaces are asleep
+	PowerStateUnscheduled = "unscheduled" // The tenant has no SleepInfos
+)
+
+// TenantDetails are the schedule aggregates of a tenant
+type TenantDetails struct {
+	SleepInfoCount      int             `json:"sleepInfoCount"`
+	ScheduledNamespaces map[string]bool `json:"scheduledNamespaces"` // Namespace suffix -> has SleepInfos
+	NextSleep           *time.Time      `json:"nextSleep,omitempty"`
+	NextWake            *time.Time      `json:"nextWake,omitempty"`
+	PowerState          string          `json:"powerState"`       // asleep, awake, partial or unscheduled
+	AsleepNamespaces    []string        `json:"asleepNamespaces"` // Suffixes whose last operation was a sleep
+}
+
+// ListTenantsWithDetails lists the tenants like ListTenants and adds the schedule aggregates of each one,
+// computed from a single list of every SleepInfo.
+func (s *ScheduleService) ListTenantsWithDetails(ctx context.Context, now time.Time) (*TenantListResponse, error) {
+	response, err := s.ListTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.client.List(ctx, sleepInfoList); err != nil {
+		return nil, fmt.Errorf("failed to list SleepInfos: %w", err)
+	}
+	byNamespace := make(map[string][]kubegreenv1alpha1.SleepInfo)
+	for _, si := range sleepInfoList.Items {
+		byNamespace[si.Namespace] = append(byNamespace[si.Namespace], si)
+	}
+
+	for i := range response.Tenants {
+		tenant := &response.Tenants[i]
+		details := &TenantDetails{
+			ScheduledNamespaces: make(map[string]bool, len(tenant.Namespaces)),
+			AsleepNamespaces:    []string{},
+		}
+		scheduled := 0
+		for _, suffix := range tenant.Namespaces {
+			sleepInfos := byNamespace[fmt.Sprintf("%s-%s", tenant.Name, suffix)]
+			details.ScheduledNamespaces[suffix] = len(sleepInfos) > 0
+			if len(sleepInfos) == 0 {
+				continue
+			}
+			scheduled++
+			details.SleepInfoCount += len(sleepInfos)
+			if lastOperation(sleepInfos) == "SLEEP" {
+				details.AsleepNamespaces = append(details.AsleepNamespaces, suffix)
+			}
+			for _, si := range sleepInfos {
+				details.NextSleep = earliest(details.NextSleep, nextScheduledOperation(si, "SLEEP", now))
+				details.NextWake = earliest(details.NextWake, nextScheduledOperation(si, "WAKE_UP", now))
+			}
+		}
+
+		switch {
+		case scheduled == 0:
+			details.PowerState = PowerStateUnscheduled
+		case len(details.AsleepNamespaces) == 0:
+			details.PowerState = PowerStateAwake
+		case len(details.AsleepNamespaces) == scheduled:
+			details.PowerState = PowerStateAsleep
+		default:
+			details.PowerState = PowerStatePartial
+		}
+		tenant.Details = details
+	}
+	return response, nil
+}
+
+// lastOperation returns the last operation (SLEEP or WAKE_UP) executed on the SleepInfos of a namespace,
+// scheduled or manual, empty when none was executed
+func lastOperation(sleepInfos []kubegreenv1alpha1.SleepInfo) string {
+	var latest time.Time
+	operation := ""
+	for _, si := range sleepInfos {
+		if si.Status.OperationType != "" && si.Status.LastScheduleTime.Time.After(latest) {
+			latest, operation = si.Status.LastScheduleTime.Time, si.Status.OperationType
+		}
+		if manual := si.Status.LastManualOperation; manual != nil && manual.ExecutedAt.Time.After(latest) {
+			latest, operation = manual.ExecutedAt.Time, manual.OperationType
+		}
+	}
+	return operation
+}
+
+// nextScheduledOperation returns the next execution of operation by si, skipping paused SleepInfos
+// and starting after a pending suspension
+func nextScheduledOperation(si kubegreenv1alpha1.SleepInfo, operation string, now time.Time) time.Time {
+	if si.IsSuspended() {
+		return time.Time{}
+	}
+	from := now
+	if si.IsSuspendedUntil(now) {
+		from = si.Spec.SuspendScheduleUntil.Time
+	}
+	return nextTrigger(si, operation, from)
+}
+
+// earliest returns the earliest of current and t, ignoring a zero t
+func earliest(current *time.Time, t time.Time) *time.Time {
+	if t.IsZero() || (current != nil && !t.Before(*current)) {
+		return current
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// tenantMapResync is how often the tenant map is rebuilt from the namespace cache, in case an event was missed
+const tenantMapResync = 10 * time.Minute
+
+// tenantEntry is the tenant and suffix a namespace resolves to
+type tenantEntry struct {
+	tenant   string
+	suffix   string
+	labelled bool // resolved from the tenant label instead of the name
+}
+
+// tenantMap keeps the tenant of every namespace up to date from the namespace informer, so tenant
+// discovery is served from memory instead of listing the namespaces on every request
+type tenantMap struct {
+	labels NamespaceLabels
+
+	mu         sync.RWMutex
+	namespaces map[string]tenantEntry
+	tenants    []TenantInfo // sorted response, nil until rebuilt after a change
+	synced     bool
+}
+
+func newTenantMap(labels NamespaceLabels) *tenantMap {
+	return &tenantMap{labels: labels, namespaces: map[string]tenantEntry{}}
+}
+
+// resolve returns the tenant of a namespace: its labels when labelled, otherwise its {tenant}-{suffix} name
+func (m *tenantMap) resolve(ns *v1.Namespace) (tenantEntry, bool) {
+	if m.labels.Enabled() {
+		if tenant, suffix, ok := m.labels.split(*ns); ok {
+			return tenantEntry{tenant: tenant, suffix: suffix, labelled: true}, true
+		}
+	}
+	tenant, suffix, ok := SplitNamespaceName(ns.Name)
+	return tenantEntry{tenant: tenant, suffix: suffix}, ok
+}
+
+// set adds or updates a namespace
+func (m *tenantMap) set(ns *v1.Namespace) {
+	entry, ok := m.resolve(ns)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, found := m.namespaces[ns.Name]; found == ok && current == entry {
+		return
+	}
+	if ok {
+		m.namespaces[ns.Name] = entry
+	} else {
+		delete(m.namespaces, ns.Name)
+	}
+	m.tenants = nil
+}
+
+// remove deletes a namespace
+func (m *tenantMap) remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.namespaces[name]; !ok {
+		return
+	}
+	delete(m.namespaces, name)
+	m.tenants = nil
+}
+
+// replace rebuilds the map from every namespace and marks it as synced
+func (m *tenantMap) replace(namespaces []v1.Namespace) {
+	entries := make(map[string]tenantEntry, len(namespaces))
+	for i := range namespaces {
+		if entry, ok := m.resolve(&namespaces[i]); ok {
+			entries[namespaces[i].Name] = entry
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.namespaces = entries
+	m.tenants = nil
+	m.synced = true
+}
+
+// list returns the tenants with their sorted namespace suffixes, sorted by name. It returns false
+// until the map is synced.
+func (m *tenantMap) list() ([]TenantInfo, bool) {
+	m.mu.RLock()
+	if !m.synced {
+		m.mu.RUnlock()
+		return nil, false
+	}
+	if m.tenants != nil {
+		tenants := append([]TenantInfo(nil), m.tenants...)
+		m.mu.RUnlock()
+		return tenants, true
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tenants == nil {
+		suffixes := map[string][]string{}
+		for _, entry := range m.namespaces {
+			suffixes[entry.tenant] = append(suffixes[entry.tenant], entry.suffix)
+		}
+		tenants := make([]TenantInfo, 0, len(suffixes))
+		for tenant, namespaces := range suffixes {
+			sort.Strings(namespaces)
+			tenants = append(tenants, TenantInfo{Name: tenant, Namespaces: namespaces})
+		}
+		sort.Slice(tenants, func(i, j int) bool {
+			return tenants[i].Name < tenants[j].Name
+		})
+		m.tenants = tenants
+	}
+	return append([]TenantInfo(nil), m.tenants...), true
+}
+
+// labelled returns the namespaces carrying the tenant label, as the namespace resolver holds them.
+// It returns false until the map is synced.
+func (m *tenantMap) labelled() (map[string][2]string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.synced {
+		return nil, false
+	}
+	labelled := map[string][2]string{}
+	for namespace, entry := range m.namespaces {
+		if entry.labelled {
+			labelled[namespace] = [2]string{entry.tenant, entry.suffix}
+		}
+	}
+	return labelled, true
+}
+
+// WatchNamespaces keeps the tenant map up to date from the namespace informer of the shared cache.
+// Until the informer is synced, and when it can't be watched, the tenants are listed on every request.
+// The map is rebuilt from the cache every tenantMapResync in case an event was missed.
+func (s *ScheduleService) WatchNamespaces(ctx context.Context, informers cache.Informers) error {
+	tenants := newTenantMap(s.namespaceLabels)
+	informer, err := informers.GetInformer(ctx, &v1.Namespace{})
+	if err != nil {
+		return fmt.Errorf("failed to get Namespace informer: %w", err)
+	}
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if ns, ok := obj.(*v1.Namespace); ok {
+				tenants.set(ns)
+			}
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if ns, ok := newObj.(*v1.Namespace); ok {
+				tenants.set(ns)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if ns, ok := obj.(*v1.Namespace); ok {
+				tenants.remove(ns.Name)
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to watch namespaces: %w", err)
+	}
+	s.tenants.Store(tenants)
+
+	go func() {
+		if !informers.WaitForCacheSync(ctx) {
+			return
+		}
+		ticker := time.NewTicker(tenantMapResync)
+		defer ticker.Stop()
+		for {
+			namespaceList := &v1.NamespaceList{}
+			if err := s.client.List(ctx, namespaceList); err != nil {
+				s.logger.Error(err, "failed to resync the tenant map")
+			} else {
+				tenants.replace(namespaceList.Items)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTenantMap(t *testing.T) {
+	namespace := func(name string, labels map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	tenants := newTenantMap(NamespaceLabels{TenantLabel: "stratio.com/tenant", SuffixLabel: "stratio.com/role"})
+
+	// Nothing is served from the map until it is synced
+	tenants.set(namespace("bda-apps", nil))
+	_, ok := tenants.list()
+	require.False(t, ok)
+	_, ok = tenants.labelled()
+	require.False(t, ok)
+
+	tenants.replace([]v1.Namespace{
+		*namespace("bda-apps", nil),
+		*namespace("bda-rocket", nil),
+		*namespace("standalone", nil),
+		*namespace("datastores", map[string]string{"stratio.com/tenant": "bda", "stratio.com/role": "datastores"}),
+	})
+	list, ok := tenants.list()
+	require.True(t, ok)
+	require.Equal(t, []TenantInfo{{Name: "bda", Namespaces: []string{"apps", "datastores", "rocket"}}}, list)
+	labelled, ok := tenants.labelled()
+	require.True(t, ok)
+	require.Equal(t, map[string][2]string{"datastores": {"bda", "datastores"}}, labelled)
+
+	t.Run("namespace events update the tenants", func(t *testing.T) {
+		tenants.set(namespace("keos-apps", nil))
+		tenants.remove("bda-rocket")
+		// A relabelled namespace moves to its new tenant
+		tenants.set(namespace("datastores", map[string]string{"stratio.com/tenant": "keos", "stratio.com/role": "data"}))
+
+		list, ok := tenants.list()
+		require.True(t, ok)
+		require.Equal(t, []TenantInfo{
+			{Name: "bda", Namespaces: []string{"apps"}},
+			{Name: "keos", Namespaces: []string{"apps", "data"}},
+		}, list)
+	})
+
+	t.Run("list returns a copy", func(t *testing.T) {
+		list, _ := tenants.list()
+		list[0].Name = "changed"
+		list, _ = tenants.list()
+		require.Equal(t, "bda", list[0].Name)
+	})
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type tenantScheduleKey struct{}
+
+// withTenantSchedule sets the TenantSchedule owning the SleepInfos written with ctx
+func withTenantSchedule(ctx context.Context, tenantSchedule *kubegreenv1alpha1.TenantSchedule) context.Context {
+	return context.WithValue(ctx, tenantScheduleKey{}, tenantSchedule)
+}
+
+// tenantScheduleFromContext returns the TenantSchedule owning the SleepInfos written with ctx, if any
+func tenantScheduleFromContext(ctx context.Context) *kubegreenv1alpha1.TenantSchedule {
+	tenantSchedule, _ := ctx.Value(tenantScheduleKey{}).(*kubegreenv1alpha1.TenantSchedule)
+	return tenantSchedule
+}
+
+// setTenantScheduleOwner labels the SleepInfo with the TenantSchedule of ctx and sets it as its controller,
+// so the SleepInfo is garbage collected with it
+func (s *ScheduleService) setTenantScheduleOwner(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	tenantSchedule := tenantScheduleFromContext(ctx)
+	if tenantSchedule == nil {
+		return nil
+	}
+	if sleepInfo.Labels == nil {
+		sleepInfo.Labels = map[string]string{}
+	}
+	sleepInfo.Labels[kubegreenv1alpha1.TenantScheduleLabel] = tenantSchedule.Name
+	return controllerutil.SetControllerReference(tenantSchedule, sleepInfo, s.client.Scheme())
+}
+
+// TenantScheduleRequest returns the schedule creation request of a TenantSchedule, named as the TenantSchedule
+func TenantScheduleRequest(tenantSchedule *kubegreenv1alpha1.TenantSchedule) (CreateScheduleRequest, error) {
+	spec := tenantSchedule.Spec
+	req := CreateScheduleRequest{
+		Tenant:             spec.Tenant,
+		Off:                spec.Off,
+		On:                 spec.On,
+		OffCron:            spec.OffCron,
+		OnCron:             spec.OnCron,
+		Weekdays:           spec.Weekdays,
+		SleepDays:          spec.SleepDays,
+		WakeDays:           spec.WakeDays,
+		Namespaces:         spec.Namespaces,
+		ScheduleName:       tenantSchedule.Name,
+		Description:        spec.Description,
+		KarpenterNodePools: spec.KarpenterNodePools,
+	}
+	if spec.Duration != "" {
+		duration, err := time.ParseDuration(spec.Duration)
+		if err != nil || duration <= 0 {
+			return req, fmt.Errorf("invalid duration %q, it must be a positive duration like 8h or 10h30m", spec.Duration)
+		}
+		req.DurationHours = duration.Hours()
+	}
+	if spec.Delays != nil {
+		req.Delays = &DelayConfig{
+			PgHdfsDelay:      spec.Delays.PgHdfsDelay,
+			PgbouncerDelay:   spec.Delays.PgbouncerDelay,
+			DeploymentsDelay: spec.Delays.DeploymentsDelay,
+		}
+	}
+	for _, exclusion := range spec.Exclusions {
+		req.Exclusions = append(req.Exclusions, NamespaceExclusion{Namespace: exclusion.Namespace, Filter: exclusionFromFilterRef(exclusion.Filter)})
+	}
+	for _, inclusion := range spec.Inclusions {
+		req.Inclusions = append(req.Inclusions, NamespaceInclusion{Namespace: inclusion.Namespace, Filter: exclusionFromFilterRef(inclusion.Filter)})
+	}
+	for _, entry := range spec.SleepReplicas {
+		req.SleepReplicas = append(req.SleepReplicas, NamespaceSleepReplicas{Namespace: entry.Namespace, Filter: exclusionFromFilterRef(entry.Filter), Replicas: entry.Replicas})
+	}
+	if spec.Holidays != nil {
+		req.Holidays = &HolidayConfig{
+			ConfigMap:          spec.Holidays.ConfigMap,
+			ConfigMapNamespace: spec.Holidays.ConfigMapNamespace,
+			URL:                spec.Holidays.URL,
+			TimeZone:           spec.Holidays.TimeZone,
+			Policy:             string(spec.Holidays.Policy),
+		}
+	}
+	return req, nil
+}
+
+// ApplyTenantSchedule creates or updates the SleepInfo of the TenantSchedule in each of its namespaces,
+// as CreateSchedule does for the same request, and deletes it from the namespaces no longer selected.
+// The SleepInfos are controlled by the TenantSchedule. It returns the sorted namespaces of the SleepInfos.
+func (s *ScheduleService) ApplyTenantSchedule(ctx context.Context, tenantSchedule *kubegreenv1alpha1.TenantSchedule) ([]string, error) {
+	req, err := TenantScheduleRequest(tenantSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := map[string]bool{}
+	for suffix := range normalizeNamespaces(req.Namespaces) {
+		selected[fmt.Sprintf("%s-%s", req.Tenant, suffix)] = true
+	}
+
+	// A schedule of the same name not created by the TenantSchedule is left untouched
+	existing, err := s.listSleepInfosByScheduleName(ctx, tenantSchedule.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, si := range existing {
+		if selected[si.Namespace] && !metav1.IsControlledBy(&si, tenantSchedule) {
+			return nil, fmt.Errorf("schedule name '%s' already exists in namespace '%s' and is not managed by the TenantSchedule", tenantSchedule.Name, si.Namespace)
+		}
+	}
+
+	if err := s.createSchedule(withTenantSchedule(ctx, tenantSchedule), req, true); err != nil {
+		return nil, err
+	}
+
+	owned := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.reader.List(ctx, owned, client.MatchingLabels{kubegreenv1alpha1.TenantScheduleLabel: tenantSchedule.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list SleepInfos of the TenantSchedule: %w", err)
+	}
+	for _, si := range owned.Items {
+		if selected[si.Namespace] || !metav1.IsControlledBy(&si, tenantSchedule) {
+			continue
+		}
+		if err := s.deleteSleepInfo(ctx, si); err != nil {
+			return nil, fmt.Errorf("failed to delete SleepInfo %s in namespace %s: %w", si.Name, si.Namespace, err)
+		}
+		s.logger.Info("Namespace no longer in the TenantSchedule, SleepInfo deleted", "tenantSchedule", tenantSchedule.Name, "namespace", si.Namespace)
+	}
+
+	namespaces := make([]string, 0, len(selected))
+	for namespace := range selected {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
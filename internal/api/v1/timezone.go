@@ -225,6 +225,45 @@ func FromClusterToUserTimezone(clusterHHMM, clusterTZ, userTZ string) (TimeConve
 	}, nil
 }
 
+// parseClock validates a HH:MM time of a schedule and returns it zero padded. The times of the
+// schedules are stored as written by the user, with the user timezone as spec.timeZone: the controller
+// computes the instant of every occurrence with the offset of its own day, so they do not drift after
+// a DST change.
+func parseClock(hhmm string) (string, error) {
+	var hour, minute int
+	if _, err := fmt.Sscanf(hhmm, "%d:%d", &hour, &minute); err != nil {
+		return "", fmt.Errorf("invalid time format: %s (expected HH:MM)", hhmm)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return "", fmt.Errorf("invalid time values: hour=%d minute=%d", hour, minute)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), nil
+}
+
+// toScheduleTimezone returns the time and weekdays of a schedule in the timezone from as in the
+// timezone to. Schedules of the same timezone are returned as they are; the others, as the ones
+// stored in UTC by older versions, are converted with the offset of today.
+func toScheduleTimezone(hhmm, weekdays, from, to string) (string, string, error) {
+	if from == "" {
+		from = TZUTC
+	}
+	if from == to {
+		return hhmm, weekdays, nil
+	}
+	conv, err := ToUTCHHMMWithTimezone(hhmm, from, to)
+	if err != nil {
+		return "", "", err
+	}
+	if weekdays == "" || weekdays == "*" {
+		return conv.TimeUTC, weekdays, nil
+	}
+	shifted, err := ShiftWeekdaysStr(weekdays, conv.DayShift)
+	if err != nil {
+		return "", "", err
+	}
+	return conv.TimeUTC, shifted, nil
+}
+
 // AddMinutes adds minutes to a time string (HH:MM) and returns HH:MM
 func AddMinutes(hhmm string, minutes int) (string, error) {
 	var hour, minute int
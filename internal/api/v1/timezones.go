@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// zoneinfoDirs are the locations searched for the IANA database, after $ZONEINFO
+var zoneinfoDirs = []string{
+	"/usr/share/zoneinfo",
+	"/usr/lib/zoneinfo",
+	"/usr/share/lib/zoneinfo",
+}
+
+// timezoneRegions are the top-level IANA areas listed; legacy aliases (EST5EDT, Etc/..., posix/...) are skipped
+var timezoneRegions = map[string]bool{
+	"Africa": true, "America": true, "Antarctica": true, "Arctic": true, "Asia": true,
+	"Atlantic": true, "Australia": true, "Europe": true, "Indian": true, "Pacific": true,
+}
+
+var (
+	timezoneNamesOnce sync.Once
+	timezoneNames     []string
+)
+
+// TimezoneInfo describes an IANA timezone and its current offset
+type TimezoneInfo struct {
+	Name          string `json:"name"`          // IANA name (e.g. America/Bogota)
+	Region        string `json:"region"`        // Top-level area (e.g. America)
+	Offset        string `json:"offset"`        // Current UTC offset (e.g. -05:00)
+	OffsetSeconds int    `json:"offsetSeconds"` // Current UTC offset in seconds
+	Abbreviation  string `json:"abbreviation"`  // Current abbreviation (e.g. CET, -05)
+}
+
+// TimezoneListResponse lists the timezones available in the container
+type TimezoneListResponse struct {
+	Timezones []TimezoneInfo            `json:"timezones,omitempty"`
+	Regions   map[string][]TimezoneInfo `json:"regions,omitempty"` // Only when grouped by region
+	Count     int                       `json:"count"`
+}
+
+// ListTimezones returns the IANA timezones available in the container with their offset at now,
+// grouped by region when requested.
+func ListTimezones(now time.Time, groupByRegion bool) TimezoneListResponse {
+	timezoneNamesOnce.Do(func() {
+		timezoneNames = discoverTimezoneNames()
+	})
+
+	infos := make([]TimezoneInfo, 0, len(timezoneNames)+1)
+	infos = append(infos, timezoneInfo("UTC", time.UTC, now))
+	for _, name := range timezoneNames {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, timezoneInfo(name, loc, now))
+	}
+
+	response := TimezoneListResponse{Count: len(infos)}
+	if !groupByRegion {
+		response.Timezones = infos
+		return response
+	}
+	response.Regions = make(map[string][]TimezoneInfo)
+	for _, info := range infos {
+		response.Regions[info.Region] = append(response.Regions[info.Region], info)
+	}
+	return response
+}
+
+func timezoneInfo(name string, loc *time.Location, now time.Time) TimezoneInfo {
+	abbreviation, offset := now.In(loc).Zone()
+	region := name
+	if i := strings.Index(name, "/"); i > 0 {
+		region = name[:i]
+	}
+	return TimezoneInfo{
+		Name:          name,
+		Region:        region,
+		Offset:        formatUTCOffset(offset),
+		OffsetSeconds: offset,
+		Abbreviation:  abbreviation,
+	}
+}
+
+func formatUTCOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// discoverTimezoneNames walks the first IANA database found in the container
+func discoverTimezoneNames() []string {
+	dirs := zoneinfoDirs
+	if dir := os.Getenv("ZONEINFO"); dir != "" {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		var names []string
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			name, err := filepath.Rel(dir, path)
+			if err != nil {
+				return nil
+			}
+			name = filepath.ToSlash(name)
+			if i := strings.Index(name, "/"); i < 0 || !timezoneRegions[name[:i]] {
+				return nil
+			}
+			names = append(names, name)
+			return nil
+		})
+		if len(names) > 0 {
+			sort.Strings(names)
+			return names
+		}
+	}
+	return nil
+}
@@ -5,9 +5,17 @@ Copyright 2025.
 package v1
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -21,19 +29,27 @@ func ValidateCreateSchedule(req CreateScheduleRequest) error {
 		return fmt.Errorf("tenant is required")
 	}
 
-	if req.Off == "" {
-		return fmt.Errorf("off time is required")
+	if err := validateCronSchedule(req); err != nil {
+		return err
+	}
+
+	if req.Off == "" && req.OffCron == "" {
+		return fmt.Errorf("off time or offCron is required")
 	}
 
-	if !timePattern.MatchString(req.Off) {
+	if req.Off != "" && !timePattern.MatchString(req.Off) {
 		return fmt.Errorf("off time must be in HH:MM format (24-hour), got: %s", req.Off)
 	}
 
-	if req.On == "" {
-		return fmt.Errorf("on time is required")
+	if err := validateDurationHours(req.On, req.DurationHours); err != nil {
+		return err
+	}
+
+	if req.On == "" && req.DurationHours == 0 && req.OffCron == "" {
+		return fmt.Errorf("on time or durationHours is required")
 	}
 
-	if !timePattern.MatchString(req.On) {
+	if req.On != "" && !timePattern.MatchString(req.On) {
 		return fmt.Errorf("on time must be in HH:MM format (24-hour), got: %s", req.On)
 	}
 
@@ -70,13 +86,22 @@ func ValidateCreateSchedule(req CreateScheduleRequest) error {
 		}
 	}
 
-	return nil
+	if err := validateInclusions(req.Tenant, req.Inclusions); err != nil {
+		return err
+	}
+	if err := validateSleepReplicas(req.SleepReplicas); err != nil {
+		return err
+	}
+	if err := validateKarpenterNodePools(req.KarpenterNodePools); err != nil {
+		return err
+	}
+	return req.Holidays.validate()
 }
 
 // ValidateUpdateSchedule validates an UpdateScheduleRequest
 func ValidateUpdateSchedule(req UpdateScheduleRequest) error {
 	// At least one field must be provided
-	if req.Off == "" && req.On == "" && req.Weekdays == "" && req.SleepDays == "" && req.WakeDays == "" && len(req.Namespaces) == 0 {
+	if req.Off == "" && req.On == "" && req.DurationHours == 0 && req.Weekdays == "" && req.SleepDays == "" && req.WakeDays == "" && len(req.Namespaces) == 0 && req.Inclusions == nil && req.Holidays == nil && req.SleepReplicas == nil {
 		return fmt.Errorf("at least one field must be provided for update")
 	}
 
@@ -89,6 +114,10 @@ func ValidateUpdateSchedule(req UpdateScheduleRequest) error {
 		return fmt.Errorf("on time must be in HH:MM format (24-hour), got: %s", req.On)
 	}
 
+	if err := validateDurationHours(req.On, req.DurationHours); err != nil {
+		return err
+	}
+
 	// Validate weekdays if provided
 	if req.Weekdays != "" {
 		if _, err := HumanWeekdaysToKube(req.Weekdays); err != nil {
@@ -122,5 +151,209 @@ func ValidateUpdateSchedule(req UpdateScheduleRequest) error {
 		}
 	}
 
+	if err := validateSleepReplicas(req.SleepReplicas); err != nil {
+		return err
+	}
+	return req.Holidays.validate()
+}
+
+// ValidationIssue is a single problem found while validating a schedule
+type ValidationIssue struct {
+	Code      string `json:"code"`                // Machine-readable code (e.g. NAMESPACE_NOT_FOUND)
+	Field     string `json:"field,omitempty"`     // Request field the issue refers to
+	Namespace string `json:"namespace,omitempty"` // Namespace the issue refers to
+	Message   string `json:"message"`
+}
+
+// ScheduleValidationResult is the result of a dry validation of a schedule
+type ScheduleValidationResult struct {
+	Valid    bool              `json:"valid"` // True when there are no errors (warnings do not block creation)
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// ValidateScheduleRequest is a CreateScheduleRequest whose exclusions must match at least one resource
+type ValidateScheduleRequest struct {
+	CreateScheduleRequest
+}
+
+func (r *ScheduleValidationResult) addError(code, field, namespace, message string) {
+	r.Errors = append(r.Errors, ValidationIssue{Code: code, Field: field, Namespace: namespace, Message: message})
+}
+
+func (r *ScheduleValidationResult) addWarning(code, field, namespace, message string) {
+	r.Warnings = append(r.Warnings, ValidationIssue{Code: code, Field: field, Namespace: namespace, Message: message})
+}
+
+// ValidateSchedule runs ValidateCreateSchedule plus the cluster-aware checks done on creation (namespaces
+// exist, scheduleName unique, no overlap) and checks the exclusions match at least one resource.
+// Nothing is created.
+func (s *ScheduleService) ValidateSchedule(ctx context.Context, req ValidateScheduleRequest) (*ScheduleValidationResult, error) {
+	result := &ScheduleValidationResult{Errors: []ValidationIssue{}, Warnings: []ValidationIssue{}}
+	defer func() { result.Valid = len(result.Errors) == 0 }()
+
+	if err := ValidateCreateSchedule(req.CreateScheduleRequest); err != nil {
+		result.addError("INVALID_REQUEST", "", "", err.Error())
+		return result, nil
+	}
+	if err := req.applyDurationHours(); err != nil {
+		result.addError("INVALID_REQUEST", "durationHours", "", err.Error())
+		return result, nil
+	}
+	if req.OffCron == "" && req.Off == req.On {
+		result.addWarning("SAME_TIME", "on", "", "off and on times are equal, resources would wake up as soon as they sleep")
+	}
+	if req.Delays != nil {
+		delays := []struct{ field, value string }{
+			{"delays.pgHdfsDelay", req.Delays.PgHdfsDelay},
+			{"delays.pgbouncerDelay", req.Delays.PgbouncerDelay},
+			{"delays.deploymentsDelay", req.Delays.DeploymentsDelay},
+		}
+		for _, delay := range delays {
+			if _, err := parseDelayToMinutes(delay.value); err != nil {
+				result.addError("INVALID_DELAY", delay.field, "", err.Error())
+			}
+		}
+	}
+
+	selectedNamespaces := normalizeNamespaces(req.Namespaces)
+	if len(selectedNamespaces) == 0 {
+		result.addError("NO_NAMESPACES", "namespaces", "", "no namespaces selected, the schedule would not create any SleepInfo")
+		return result, nil
+	}
+	suffixes := make([]string, 0, len(selectedNamespaces))
+	for suffix := range selectedNamespaces {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+
+	existing := make(map[string]bool)
+	for _, suffix := range suffixes {
+		namespace := fmt.Sprintf("%s-%s", req.Tenant, suffix)
+		ns := &v1.Namespace{}
+		if err := s.reader.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+			}
+			result.addError("NAMESPACE_NOT_FOUND", "namespaces", namespace, fmt.Sprintf("namespace %s does not exist", namespace))
+			continue
+		}
+		existing[suffix] = true
+
+		if req.ScheduleName != "" {
+			if err := s.validateScheduleNameUniqueness(ctx, namespace, req.ScheduleName); err != nil {
+				result.addError("SCHEDULE_NAME_EXISTS", "scheduleName", namespace, err.Error())
+			}
+		}
+
+		resources, err := s.GetNamespaceResources(ctx, req.Tenant, suffix)
+		if err != nil {
+			return nil, err
+		}
+		counts := resources.ResourceCounts
+		if counts.Deployments+counts.StatefulSets+counts.CronJobs+counts.PgClusters+counts.HdfsClusters+
+			counts.OsClusters+counts.OsDashboardses+counts.KafkaClusters+counts.PgBouncers == 0 {
+			result.addWarning("NAMESPACE_EMPTY", "namespaces", namespace, fmt.Sprintf("namespace %s has no resources to put to sleep", namespace))
+		}
+	}
+
+	// Cron schedules have no off and on times to check the overlap with the existing schedules
+	if req.OffCron == "" {
+		if err := s.checkScheduleOverlap(ctx, req, existing, result); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, exclusion := range req.Exclusions {
+		field := fmt.Sprintf("exclusions[%d]", i)
+		suffix := strings.TrimPrefix(exclusion.Namespace, req.Tenant+"-")
+		namespace := fmt.Sprintf("%s-%s", req.Tenant, suffix)
+		if err := exclusion.Filter.validate(); err != nil {
+			result.addError("INVALID_EXCLUSION", field, namespace, err.Error())
+			continue
+		}
+		if !selectedNamespaces[suffix] {
+			result.addWarning("EXCLUSION_NAMESPACE_NOT_SELECTED", field, namespace, fmt.Sprintf("namespace %s is not part of the schedule, the exclusion has no effect", namespace))
+			continue
+		}
+		if !existing[suffix] {
+			continue
+		}
+		matches, err := s.countMatchingWorkloads(ctx, namespace, exclusion.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if matches == 0 {
+			result.addWarning("EXCLUSION_NO_MATCH", field, namespace, fmt.Sprintf("exclusion %s does not match any Deployment, StatefulSet or CronJob in %s", exclusion.Filter.key(), namespace))
+		}
+	}
+
+	return result, nil
+}
+
+// checkScheduleOverlap adds to result the overlap of the schedule with the existing schedules of the
+// namespaces, with the same conversion as createSchedule
+func (s *ScheduleService) checkScheduleOverlap(ctx context.Context, req ValidateScheduleRequest, existing map[string]bool, result *ScheduleValidationResult) error {
+	sleepDays := req.SleepDays
+	if sleepDays == "" {
+		sleepDays = req.Weekdays
+	}
+	wdSleep := "0-6"
+	if sleepDays != "" {
+		wdSleep, _ = HumanWeekdaysToKube(sleepDays)
+	}
+	offTime, err := parseClock(req.Off)
+	if err != nil {
+		result.addError("INVALID_REQUEST", "off", "", err.Error())
+		return nil
+	}
+	onTime, err := parseClock(req.On)
+	if err != nil {
+		result.addError("INVALID_REQUEST", "on", "", err.Error())
+		return nil
+	}
+	if err := s.validateScheduleOverlap(ctx, req.Tenant, existing, wdSleep, offTime, onTime, TZLocal, req.ScheduleName); err != nil {
+		switch {
+		case errors.Is(err, ErrScheduleOverlap):
+			result.addError("SCHEDULE_OVERLAP", "off", "", err.Error())
+		case errors.Is(err, ErrNamespaceAsleep):
+			result.addError("NAMESPACE_ASLEEP", "namespaces", "", err.Error())
+		default:
+			return err
+		}
+	}
 	return nil
 }
+
+// countMatchingWorkloads counts the Deployments, StatefulSets and CronJobs of a namespace the exclusion applies to
+func (s *ScheduleService) countMatchingWorkloads(ctx context.Context, namespace string, filter ExclusionFilter) (int, error) {
+	matches := 0
+	deployments := &appsv1.DeploymentList{}
+	if err := s.reader.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list deployments in %s: %w", namespace, err)
+	}
+	for _, d := range deployments.Items {
+		if filter.matches("Deployment", d.Name, d.Labels) {
+			matches++
+		}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := s.reader.List(ctx, statefulSets, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list statefulsets in %s: %w", namespace, err)
+	}
+	for _, sts := range statefulSets.Items {
+		if filter.matches("StatefulSet", sts.Name, sts.Labels) {
+			matches++
+		}
+	}
+	cronJobs := &batchv1.CronJobList{}
+	if err := s.reader.List(ctx, cronJobs, client.InNamespace(namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list cronjobs in %s: %w", namespace, err)
+	}
+	for _, cj := range cronJobs.Items {
+		if filter.matches("CronJob", cj.Name, cj.Labels) {
+			matches++
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,336 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Names of the wake stages of a namespace with datastores
+const (
+	WakeStageDatastores   = "datastores"
+	WakeStagePgBouncer    = "pgbouncer"
+	WakeStageApplications = "applications"
+)
+
+// classKinds are the kinds of the resources of each class, set as targets of the wake stages.
+// Custom patches use the Kind of their target as class.
+var classKinds = map[string]string{
+	ClassDeployments:          kubegreenv1alpha1.DeploymentTarget.Kind,
+	ClassStatefulSets:         kubegreenv1alpha1.StatefulSetTarget.Kind,
+	ClassCronJobs:             kubegreenv1alpha1.CronJobTarget.Kind,
+	ClassPgBouncer:            kubegreenv1alpha1.PgBouncerTarget.Kind,
+	ClassPostgres:             kubegreenv1alpha1.PgClusterTarget.Kind,
+	ClassHDFS:                 kubegreenv1alpha1.HDFSClusterTarget.Kind,
+	ClassOpenSearch:           kubegreenv1alpha1.OsClusterTarget.Kind,
+	ClassOpenSearchDashboards: kubegreenv1alpha1.OsDashboardsTarget.Kind,
+	ClassKafka:                kubegreenv1alpha1.KafkaClusterTarget.Kind,
+	ClassKnative:              kubegreenv1alpha1.KnativeServiceTarget.Kind,
+	ClassHPA:                  kubegreenv1alpha1.HorizontalPodAutoscalerTarget.Kind,
+	ClassKEDA:                 kubegreenv1alpha1.ScaledObjectTarget.Kind,
+	ClassJobs:                 kubegreenv1alpha1.JobTarget.Kind,
+}
+
+// classKind returns the kind of the resources of a class
+func classKind(class string) string {
+	if kind, ok := classKinds[class]; ok {
+		return kind
+	}
+	return class
+}
+
+// kindClass returns the class of the resources of a kind
+func kindClass(kind string) string {
+	for class, classKind := range classKinds {
+		if classKind == kind {
+			return class
+		}
+	}
+	return kind
+}
+
+// scheduleAnnotations returns the annotations of the SleepInfos of a schedule
+func scheduleAnnotations(scheduleName, description, userTimezone string) map[string]string {
+	annotations := map[string]string{}
+	if scheduleName != "" {
+		annotations["kube-green.stratio.com/schedule-name"] = scheduleName
+	}
+	if description != "" {
+		annotations["kube-green.stratio.com/schedule-description"] = description
+	}
+	if userTimezone != "" {
+		annotations["kube-green.stratio.com/user-timezone"] = userTimezone
+	}
+	return annotations
+}
+
+// sameWeekdays reports whether two weekdays expressions select the same days
+func sameWeekdays(a, b string) bool {
+	aDays, _ := ExpandWeekdaysStr(a)
+	bDays, _ := ExpandWeekdaysStr(b)
+	if len(aDays) != len(bDays) {
+		return false
+	}
+	for i, d := range aDays {
+		if d != bDays[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// setWeeklySchedule sets the sleep at offTime on the wdSleep weekdays and the wake up at onTime on the
+// wdWake weekdays. A SleepInfo has a single weekdays, so different sleep and wake weekdays are set as
+// cron expressions.
+func setWeeklySchedule(spec *kubegreenv1alpha1.SleepInfoSpec, offTime, onTime, wdSleep, wdWake string) error {
+	if sameWeekdays(wdSleep, wdWake) {
+		spec.Weekdays = wdSleep
+		spec.SleepTime = offTime
+		spec.WakeUpTime = onTime
+		return nil
+	}
+	sleepAt, err := parseTimeToCron(offTime, wdSleep, spec.TimeZone)
+	if err != nil {
+		return fmt.Errorf("invalid off time: %w", err)
+	}
+	wakeUpAt, err := parseTimeToCron(onTime, wdWake, spec.TimeZone)
+	if err != nil {
+		return fmt.Errorf("invalid on time: %w", err)
+	}
+	spec.Weekdays = ""
+	spec.SleepTime = sleepAt
+	spec.WakeUpTime = wakeUpAt
+	return nil
+}
+
+// weeklyClock returns the HH:MM time and the weekdays of a cron expression written by setWeeklySchedule
+func weeklyClock(expr string) (string, string, bool) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 || fields[2] != "*" || fields[3] != "*" {
+		return "", "", false
+	}
+	minute, err := strconv.Atoi(fields[0])
+	if err != nil || minute < 0 || minute > 59 {
+		return "", "", false
+	}
+	hour, err := strconv.Atoi(fields[1])
+	if err != nil || hour < 0 || hour > 23 {
+		return "", "", false
+	}
+	if _, err := ExpandWeekdaysStr(fields[4]); err != nil {
+		return "", "", false
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), fields[4], true
+}
+
+// withWeeklyClocks turns the cron times written by setWeeklySchedule back into times and weekdays, so an
+// update keeping the days of the schedule reads them from a schedule whose sleep and wake days differ
+func withWeeklyClocks(schedule *ScheduleResponse) {
+	for _, nsInfo := range schedule.Namespaces {
+		for i := range nsInfo.Schedule {
+			sched := &nsInfo.Schedule[i]
+			if sched.Weekdays != "" {
+				continue
+			}
+			if clock, weekdays, ok := weeklyClock(sched.Time); ok {
+				sched.Time, sched.Weekdays = clock, weekdays
+			}
+			if clock, _, ok := weeklyClock(sched.WakeTime); ok {
+				sched.WakeTime = clock
+			}
+		}
+	}
+}
+
+// datastoresWakeStages returns the wake up time and the wake stages of a namespace with datastores: the
+// datastores wake up at onPgHDFS, PgBouncer at onPgBouncer and the applications at onDeployments. The
+// wake up time is the earliest of them, and the stages are sorted by delay.
+func datastoresWakeStages(onPgHDFS, onPgBouncer, onDeployments string) (string, []kubegreenv1alpha1.WakeStage) {
+	stages := []struct {
+		name    string
+		at      string
+		classes []string
+	}{
+		{WakeStageDatastores, onPgHDFS, []string{ClassPostgres, ClassHDFS, ClassOpenSearch, ClassOpenSearchDashboards, ClassKafka}},
+		{WakeStagePgBouncer, onPgBouncer, []string{ClassPgBouncer}},
+		{WakeStageApplications, onDeployments, []string{ClassDeployments, ClassStatefulSets, ClassCronJobs, kubegreenv1alpha1.FlinkDeploymentTarget.Kind}},
+	}
+
+	// Offsets relative to the datastores, normalized to (-12h, 12h] so stages crossing midnight
+	// (23:58 -> 00:05) keep their order.
+	base := timeToMinutes(onPgHDFS)
+	offsets := make([]int, len(stages))
+	minOffset := 0
+	for i, stage := range stages {
+		offset := timeToMinutes(stage.at) - base
+		if offset > 720 {
+			offset -= 1440
+		} else if offset <= -720 {
+			offset += 1440
+		}
+		offsets[i] = offset
+		if offset < minOffset {
+			minOffset = offset
+		}
+	}
+	wakeAt, err := AddMinutes(onPgHDFS, minOffset)
+	if err != nil {
+		wakeAt = onPgHDFS
+	}
+
+	wakeStages := make([]kubegreenv1alpha1.WakeStage, 0, len(stages))
+	for i, stage := range stages {
+		wakeStage := kubegreenv1alpha1.WakeStage{Name: stage.name}
+		for _, class := range stage.classes {
+			wakeStage.Targets = append(wakeStage.Targets, kubegreenv1alpha1.FilterRef{Kind: classKind(class)})
+		}
+		if delay := offsets[i] - minOffset; delay > 0 {
+			wakeStage.Delay = fmt.Sprintf("%dm", delay)
+		}
+		wakeStages = append(wakeStages, wakeStage)
+	}
+	sort.SliceStable(wakeStages, func(i, j int) bool {
+		return wakeStages[i].GetDelay() < wakeStages[j].GetDelay()
+	})
+	return wakeAt, wakeStages
+}
+
+// deleteLegacyPairedSleepInfos deletes the SleepInfos of the namespace paired by one of pairIDs, the
+// separate sleep and wake SleepInfos created by previous versions for a schedule, except keep
+func (s *ScheduleService) deleteLegacyPairedSleepInfos(ctx context.Context, namespace, keep string, pairIDs ...string) error {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := s.reader.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("failed to list SleepInfos: %w", err)
+	}
+	pairIDs = append([]string{keep}, pairIDs...)
+	for _, si := range sleepInfoList.Items {
+		pairID := si.Annotations["kube-green.stratio.com/pair-id"]
+		if pairID == "" || si.Name == keep || !containsString(pairIDs, pairID) {
+			continue
+		}
+		if err := s.deleteSleepInfo(ctx, si); err != nil {
+			return fmt.Errorf("failed to delete paired SleepInfo %s: %w", si.Name, err)
+		}
+		s.logger.Info("Paired SleepInfo replaced by the wake stages", "name", si.Name, "namespace", si.Namespace, "pairID", pairID)
+	}
+	return nil
+}
+
+// wakeStep is the wake up of part of the resources of a SleepInfo, delay after its wake up time
+type wakeStep struct {
+	stage     string // empty for the resources matching no wake stage
+	delay     time.Duration
+	resources []string
+}
+
+// wakeSteps returns the wake ups of a SleepInfo in order: the resources matching no wake stage at
+// the wake up time, then the resources of each wake stage after its delay
+func wakeSteps(locale Locale, si kubegreenv1alpha1.SleepInfo) []wakeStep {
+	resources := determineManagedResources(locale, si, "wake")
+	if len(si.Spec.WakeStages) == 0 {
+		return []wakeStep{{resources: resources}}
+	}
+
+	steps := make([]wakeStep, 0, len(si.Spec.WakeStages)+1)
+	staged := map[string]bool{}
+	for _, stage := range si.Spec.WakeStages {
+		stageResources := wakeStageResources(stage)
+		for _, resource := range stageResources {
+			staged[resource] = true
+		}
+		steps = append(steps, wakeStep{stage: stage.Name, delay: stage.GetDelay(), resources: stageResources})
+	}
+	unstaged := []string{}
+	for _, resource := range resources {
+		if !staged[resource] {
+			unstaged = append(unstaged, resource)
+		}
+	}
+	if len(unstaged) > 0 {
+		steps = append([]wakeStep{{resources: unstaged}}, steps...)
+	}
+	return steps
+}
+
+// wakeStageResources returns the resources woken up by a wake stage: the class of the kind of each
+// target, or its name or label selector when it has no kind
+func wakeStageResources(stage kubegreenv1alpha1.WakeStage) []string {
+	resources := []string{}
+	for _, target := range stage.Targets {
+		resource := target.Name
+		if target.Kind != "" {
+			resource = kindClass(target.Kind)
+		} else if resource == "" {
+			resource = metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: target.MatchLabels, MatchExpressions: target.MatchExpressions})
+		}
+		if !containsString(resources, resource) {
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
+
+// classWakeDelay returns the delay after the wake up time of the first wake stage waking up the class
+func classWakeDelay(si kubegreenv1alpha1.SleepInfo, class string) time.Duration {
+	kind := classKind(class)
+	for _, stage := range si.GetWakeStages() {
+		for _, target := range stage.Targets {
+			if target.Kind == kind {
+				return stage.GetDelay()
+			}
+		}
+	}
+	return 0
+}
+
+// stagedTime returns the HH:MM time delay after hhmm, hhmm itself when it is a cron expression
+func stagedTime(hhmm string, delay time.Duration) string {
+	if delay <= 0 {
+		return hhmm
+	}
+	staged, err := AddMinutes(hhmm, int(delay/time.Minute))
+	if err != nil {
+		return hhmm
+	}
+	return staged
+}
+
+// sleepInfoSummaries returns the summaries of a SleepInfo. A SleepInfo sleeping and waking up its
+// resources has a sleep summary and a wake summary per wake step, like the sleep and wake SleepInfos
+// of the schedules created by previous versions.
+func (s *ScheduleService) sleepInfoSummaries(ctx context.Context, si kubegreenv1alpha1.SleepInfo) []SleepInfoSummary {
+	summary := s.buildSleepInfoSummary(ctx, si)
+	if _, paired := si.Annotations["kube-green.stratio.com/pair-role"]; paired || summary.Window != nil || si.Spec.SleepTime == "" || si.Spec.WakeUpTime == "" {
+		return []SleepInfoSummary{summary}
+	}
+
+	locale := LocaleFromContext(ctx)
+	sleep := summary
+	sleep.Role = "sleep"
+	sleep.Time = si.Spec.SleepTime
+	sleep.Resources = determineManagedResources(locale, si, "sleep")
+	sleep.Operation = buildOperationDescription(locale, "sleep", sleep.Resources)
+	summaries := []SleepInfoSummary{sleep}
+	for _, step := range wakeSteps(locale, si) {
+		wake := summary
+		wake.Role = "wake"
+		wake.Time = stagedTime(si.Spec.WakeUpTime, step.delay)
+		wake.WakeStage = step.stage
+		wake.Resources = step.resources
+		wake.Operation = buildOperationDescription(locale, "wake", step.resources)
+		summaries = append(summaries, wake)
+	}
+	return summaries
+}
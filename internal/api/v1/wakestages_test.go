@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+func TestWeeklyClock(t *testing.T) {
+	clock, weekdays, ok := weeklyClock("5 3 * * 1-5")
+	require.True(t, ok)
+	require.Equal(t, "03:05", clock)
+	require.Equal(t, "1-5", weekdays)
+
+	for _, expr := range []string{"22:00", "0 20 * * 6#1", "*/5 3 * * 1", "0 8 1 * *", "0 25 * * 1"} {
+		_, _, ok := weeklyClock(expr)
+		require.False(t, ok, expr)
+	}
+}
+
+func TestUpdateScheduleKeepsDifferentSleepAndWakeDays(t *testing.T) {
+	ctx := context.Background()
+	service, c := newTestService(t, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bda-apps"}})
+
+	require.NoError(t, service.CreateSchedule(ctx, CreateScheduleRequest{
+		Tenant:     "bda",
+		Off:        "22:00",
+		On:         "06:00",
+		SleepDays:  "viernes",
+		WakeDays:   "lunes",
+		Namespaces: []string{"apps"},
+	}))
+	before := &kubegreenv1alpha1.SleepInfoList{}
+	require.NoError(t, c.List(ctx, before, client.InNamespace("bda-apps")))
+	require.Len(t, before.Items, 1)
+	require.Equal(t, "", before.Items[0].Spec.Weekdays)
+	require.Equal(t, "00 22 * * 5", before.Items[0].Spec.SleepTime)
+	require.Equal(t, "00 06 * * 1", before.Items[0].Spec.WakeUpTime)
+
+	// Only the times change, the days are those of the schedule
+	require.NoError(t, service.UpdateSchedule(ctx, "bda", CreateScheduleRequest{Off: "23:00", On: "07:00"}))
+
+	after := &kubegreenv1alpha1.SleepInfoList{}
+	require.NoError(t, c.List(ctx, after, client.InNamespace("bda-apps")))
+	require.Len(t, after.Items, 1)
+	require.Equal(t, "", after.Items[0].Spec.Weekdays)
+	require.Equal(t, "00 23 * * 5", after.Items[0].Spec.SleepTime)
+	require.Equal(t, "00 07 * * 1", after.Items[0].Spec.WakeUpTime)
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kube-green/kube-green/internal/api/v1/auth"
+	"github.com/kube-green/kube-green/internal/notifications"
+)
+
+// WebhookSubscriptionRequest registers a callback URL, or email recipients, for the schedule lifecycle events
+type WebhookSubscriptionRequest struct {
+	URL      string   `json:"url,omitempty" example:"https://itsm.example.com/hooks/kube-green"` // Callback URL receiving the events as JSON POSTs, required without emails
+	Emails   []string `json:"emails,omitempty" example:"ops@example.com"`                        // Optional: recipients of the events by email instead of the URL, requires the SMTP settings
+	Digest   bool     `json:"digest,omitempty"`                                                  // Optional: email the weekly digest of the tenant (sleeps, wake ups and estimated savings) instead of the events
+	Tenant   string   `json:"tenant,omitempty" example:"bdadevdat"`                              // Optional: only events of this tenant (global subscriptions require admin role)
+	Events   []string `json:"events,omitempty" example:"schedule.created,sleep.executed"`        // Optional: event types, all of them when empty
+	Secret   string   `json:"secret,omitempty"`                                                  // Optional: HMAC-SHA256 key, the body signature is sent in X-Kube-Green-Signature
+	Format   string   `json:"format,omitempty" example:"teams"`                                  // Optional: body format, json (the event, default), teams (Adaptive Card for a Teams workflow webhook) or template
+	Template string   `json:"template,omitempty"`                                                // Optional: Go template of the body with format template, executed with the event. {{ json .Namespace }} quotes a value
+}
+
+// WebhookSubscriptionInfo is a registered subscription without its signing secret
+type WebhookSubscriptionInfo struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url,omitempty"`
+	Emails    []string `json:"emails,omitempty"`
+	Digest    bool     `json:"digest,omitempty"`
+	Tenant    string   `json:"tenant,omitempty"`
+	Events    []string `json:"events,omitempty"`
+	Signed    bool     `json:"signed"` // True when the deliveries are signed
+	Format    string   `json:"format,omitempty"`
+	Template  string   `json:"template,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+	CreatedBy string   `json:"createdBy,omitempty"`
+}
+
+func toWebhookSubscriptionInfo(sub notifications.Subscription) WebhookSubscriptionInfo {
+	return WebhookSubscriptionInfo{
+		ID:        sub.ID,
+		URL:       sub.URL,
+		Emails:    sub.Emails,
+		Digest:    sub.Digest,
+		Tenant:    sub.Tenant,
+		Events:    sub.Events,
+		Signed:    sub.Secret != "",
+		Format:    sub.Format,
+		Template:  sub.Template,
+		CreatedAt: sub.CreatedAt.Format(time.RFC3339),
+		CreatedBy: sub.CreatedBy,
+	}
+}
+
+// webhooksAvailable writes a 503 when notifications are not configured
+func (s *Server) webhooksAvailable(c *gin.Context) bool {
+	if s.subscriptions == nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Success: false,
+			Error:   "Webhook notifications are not enabled",
+			Code:    http.StatusServiceUnavailable,
+		})
+		return false
+	}
+	return true
+}
+
+// invalidateSubscriptions makes the notifier reload the subscriptions on the next event
+func (s *Server) invalidateSubscriptions() {
+	if invalidator, ok := s.notifier.(interface{ Invalidate() }); ok {
+		invalidator.Invalidate()
+	}
+}
+
+// handleListWebhooks lists the webhook subscriptions
+// @Summary List webhook subscriptions
+// @ID listWebhooks
+// @Description Lists the callback URLs and email recipients notified of the schedule lifecycle events. Signing secrets are never returned.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} APIResponse{data=[]WebhookSubscriptionInfo} "Subscriptions"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 503 {object} ErrorResponse "Webhook notifications not enabled"
+// @Router /api/v1/webhooks [get]
+func (s *Server) handleListWebhooks(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can manage webhooks",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if !s.webhooksAvailable(c) {
+		return
+	}
+
+	subs, err := s.subscriptions.List(c.Request.Context())
+	if err != nil {
+		s.logger.Error(err, "failed to list webhook subscriptions")
+		handleKubernetesError(c, err)
+		return
+	}
+	infos := make([]WebhookSubscriptionInfo, 0, len(subs))
+	for _, sub := range subs {
+		infos = append(infos, toWebhookSubscriptionInfo(sub))
+	}
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Data:    infos,
+	})
+}
+
+// handleCreateWebhook registers a webhook subscription
+// @Summary Register a webhook subscription
+// @ID createWebhook
+// @Description Registers a callback URL notified with a JSON POST on schedule created/updated/deleted and sleep/wake executed: the event, a Microsoft Teams Adaptive Card with format teams, or the body rendered by the Go template of the subscription with format template. With emails instead of url, the events are emailed to the recipients, or with digest a weekly digest of the sleeps, wake ups and estimated savings of the tenant. Deliveries are retried with exponential backoff on network errors, 429 and 5xx responses. Global subscriptions (without tenant) require admin role.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body WebhookSubscriptionRequest true "Subscription"
+// @Success 201 {object} APIResponse{data=WebhookSubscriptionInfo} "Subscription registered"
+// @Failure 400 {object} ErrorResponse "Invalid request parameters"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 503 {object} ErrorResponse "Webhook notifications not enabled"
+// @Router /api/v1/webhooks [post]
+func (s *Server) handleCreateWebhook(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanCreateSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can manage webhooks",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if !s.webhooksAvailable(c) {
+		return
+	}
+
+	var req WebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	if req.Tenant == "" && role.(string) != auth.RoleAdmin {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Admin access required for global subscriptions, set a tenant",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+
+	sub := notifications.Subscription{
+		URL:       req.URL,
+		Emails:    req.Emails,
+		Digest:    req.Digest,
+		Tenant:    req.Tenant,
+		Events:    req.Events,
+		Secret:    req.Secret,
+		Format:    req.Format,
+		Template:  req.Template,
+		CreatedBy: c.GetString("username"),
+	}
+	if err := sub.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	created, err := s.subscriptions.Add(c.Request.Context(), sub)
+	if err != nil {
+		s.logger.Error(err, "failed to register webhook subscription", "url", req.URL)
+		handleKubernetesError(c, err)
+		return
+	}
+	s.invalidateSubscriptions()
+
+	c.JSON(http.StatusCreated, APIResponse{
+		Success: true,
+		Message: "Webhook subscription registered",
+		Data:    toWebhookSubscriptionInfo(*created),
+	})
+}
+
+// handleDeleteWebhook removes a webhook subscription
+// @Summary Delete a webhook subscription
+// @ID deleteWebhook
+// @Description Removes a webhook subscription by ID
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} APIResponse "Subscription deleted"
+// @Failure 403 {object} ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} ErrorResponse "Subscription not found"
+// @Failure 503 {object} ErrorResponse "Webhook notifications not enabled"
+// @Router /api/v1/webhooks/{id} [delete]
+func (s *Server) handleDeleteWebhook(c *gin.Context) {
+	role, exists := c.Get("role")
+	if !exists || !auth.CanDeleteSchedule(role.(string)) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Success: false,
+			Error:   "Insufficient permissions. Only admin and operacion roles can manage webhooks",
+			Code:    http.StatusForbidden,
+		})
+		return
+	}
+	if !s.webhooksAvailable(c) {
+		return
+	}
+
+	if err := s.subscriptions.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if errors.Is(err, notifications.ErrSubscriptionNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Success: false,
+				Error:   err.Error(),
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+		s.logger.Error(err, "failed to delete webhook subscription", "id", c.Param("id"))
+		handleKubernetesError(c, err)
+		return
+	}
+	s.invalidateSubscriptions()
+
+	c.JSON(http.StatusOK, APIResponse{
+		Success: true,
+		Message: "Webhook subscription deleted",
+	})
+}
@@ -25,6 +25,26 @@ var (
 		"sábado":    6,
 	}
 
+	// DaysEN maps English day names and their abbreviations to numbers (0=Sunday, 6=Saturday)
+	DaysEN = map[string]int{
+		"sunday":    0,
+		"sun":       0,
+		"monday":    1,
+		"mon":       1,
+		"tuesday":   2,
+		"tue":       2,
+		"tues":      2,
+		"wednesday": 3,
+		"wed":       3,
+		"thursday":  4,
+		"thu":       4,
+		"thurs":     4,
+		"friday":    5,
+		"fri":       5,
+		"saturday":  6,
+		"sat":       6,
+	}
+
 	// DaysNumToES maps numbers to Spanish day names
 	DaysNumToES = map[int]string{
 		0: "domingo",
@@ -40,9 +60,19 @@ var (
 	numericPattern = regexp.MustCompile(`^\s*\d(?:\s*[-,]\s*\d)*\s*$`)
 )
 
-// HumanWeekdaysToKube converts human-readable weekdays to kube-green format
+// dayNumber returns the number of a Spanish or English day name (already lowercased, without accents)
+func dayNumber(name string) (int, bool) {
+	if n, ok := DaysES[name]; ok {
+		return n, true
+	}
+	n, ok := DaysEN[name]
+	return n, ok
+}
+
+// HumanWeekdaysToKube converts human-readable weekdays (Spanish or English) to kube-green format
 // Examples:
 //   - "lunes-viernes" -> "1-5"
+//   - "monday-friday" or "mon-fri" -> "1-5"
 //   - "viernes,sábado,domingo" -> "5,6,0"
 //   - "0-6" -> "0-6" (already in numeric format)
 func HumanWeekdaysToKube(s string) (string, error) {
@@ -79,12 +109,12 @@ func HumanWeekdaysToKube(s string) (string, error) {
 			startStr := strings.TrimSpace(rangeParts[0])
 			endStr := strings.TrimSpace(rangeParts[1])
 
-			start, ok := DaysES[startStr]
+			start, ok := dayNumber(startStr)
 			if !ok {
 				return "", fmt.Errorf("day not recognized in range start: %s", startStr)
 			}
 
-			end, ok := DaysES[endStr]
+			end, ok := dayNumber(endStr)
 			if !ok {
 				return "", fmt.Errorf("day not recognized in range end: %s", endStr)
 			}
@@ -105,7 +135,7 @@ func HumanWeekdaysToKube(s string) (string, error) {
 			}
 		} else {
 			// Single day
-			dayNum, ok := DaysES[p]
+			dayNum, ok := dayNumber(p)
 			if !ok {
 				return "", fmt.Errorf("day not recognized: %s", p)
 			}
@@ -0,0 +1,217 @@
+/*
+Copyright 2025.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// windowLocalLayout is the layout of window dates given in the user timezone
+const windowLocalLayout = "2006-01-02T15:04"
+
+// WindowScheduleRequest is a one-time sleep of the namespaces of a tenant between two dates
+type WindowScheduleRequest struct {
+	Start        string               `json:"start" binding:"required" example:"2024-12-24T18:00"` // RFC3339, or YYYY-MM-DDTHH:MM in the user timezone
+	End          string               `json:"end" binding:"required" example:"2025-01-02T08:00"`   // RFC3339, or YYYY-MM-DDTHH:MM in the user timezone
+	Namespaces   []string             `json:"namespaces" binding:"required" example:"datastores,apps"`
+	ScheduleName string               `json:"scheduleName,omitempty" example:"navidad"`
+	Description  string               `json:"description,omitempty" example:"Cierre de fin de año"`
+	Exclusions   []NamespaceExclusion `json:"exclusions,omitempty"`
+}
+
+// WindowScheduleResponse lists the window SleepInfos created for a tenant
+type WindowScheduleResponse struct {
+	Tenant     string       `json:"tenant"`
+	Start      time.Time    `json:"start"`
+	End        time.Time    `json:"end"`
+	SleepInfos []WindowItem `json:"sleepInfos"`
+}
+
+// WindowItem is a window SleepInfo created in a namespace
+type WindowItem struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// OneTimeWindow is the one-time sleep of a window SleepInfo
+type OneTimeWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// windowOf returns the window of si, nil for weekly schedules
+func windowOf(si kubegreenv1alpha1.SleepInfo) *OneTimeWindow {
+	if !si.IsWindow() {
+		return nil
+	}
+	return &OneTimeWindow{Start: si.Spec.Window.Start.UTC(), End: si.Spec.Window.End.UTC()}
+}
+
+// WeeklySchedule is one of the schedules of a SleepInfo with several schedules, in its time zone
+type WeeklySchedule struct {
+	Weekdays string `json:"weekdays,omitempty"`
+	SleepAt  string `json:"sleepAt"`
+	WakeUpAt string `json:"wakeUpAt"`
+}
+
+// schedulesOf returns the schedules of si, nil when it has only weekdays, sleepAt and wakeUpAt
+func schedulesOf(si kubegreenv1alpha1.SleepInfo) []WeeklySchedule {
+	if len(si.Spec.Schedules) == 0 {
+		return nil
+	}
+	schedules := make([]WeeklySchedule, 0, len(si.Spec.Schedules))
+	for _, schedule := range si.Spec.Schedules {
+		schedules = append(schedules, WeeklySchedule{Weekdays: schedule.Weekdays, SleepAt: schedule.SleepTime, WakeUpAt: schedule.WakeUpTime})
+	}
+	return schedules
+}
+
+// parseWindowTime parses an RFC3339 date, or a YYYY-MM-DDTHH:MM date in the user timezone
+func parseWindowTime(value, userTZ string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	loc, err := time.LoadLocation(userTZ)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %s: %w", userTZ, err)
+	}
+	t, err := time.ParseInLocation(windowLocalLayout, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid window date %q: expected RFC3339 or YYYY-MM-DDTHH:MM", value)
+	}
+	return t, nil
+}
+
+// CreateWindowSchedule creates, in every selected namespace, a SleepInfo that sleeps once at start and
+// wakes up at end, next to the recurring schedules. The controller deletes it once the window is over.
+// Resources are detected like in CreateSchedule, but operator clusters, PgBouncer and deployments all
+// wake up at end: windows do not stagger the wake.
+func (s *ScheduleService) CreateWindowSchedule(ctx context.Context, tenant string, req WindowScheduleRequest, now time.Time) (*WindowScheduleResponse, error) {
+	start, err := parseWindowTime(req.Start, TZLocal)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseWindowTime(req.End, TZLocal)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid window date %q: end must be after start", req.End)
+	}
+	if !end.After(now) {
+		return nil, fmt.Errorf("invalid window date %q: end is in the past", req.End)
+	}
+
+	selected := normalizeNamespaces(req.Namespaces)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("invalid window: at least one namespace is required")
+	}
+	for _, excl := range req.Exclusions {
+		if err := excl.Filter.validate(); err != nil {
+			return nil, fmt.Errorf("invalid exclusion: %w", err)
+		}
+	}
+	suffixes := make([]string, 0, len(selected))
+	for suffix := range selected {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+	for _, suffix := range suffixes {
+		if err := s.validateScheduleNameUniqueness(ctx, fmt.Sprintf("%s-%s", tenant, suffix), req.ScheduleName); err != nil {
+			return nil, err
+		}
+	}
+
+	response := &WindowScheduleResponse{Tenant: tenant, Start: start.UTC(), End: end.UTC(), SleepInfos: []WindowItem{}}
+	for _, suffix := range suffixes {
+		sleepInfo := s.buildWindowSleepInfo(ctx, tenant, suffix, start, end, req)
+		if err := s.client.Create(ctx, sleepInfo); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return nil, fmt.Errorf("schedule name '%s' already exists in namespace %s", sleepInfo.Name, sleepInfo.Namespace)
+			}
+			return nil, fmt.Errorf("failed to create window SleepInfo in %s: %w", sleepInfo.Namespace, err)
+		}
+		s.logger.Info("Window schedule created", "sleepinfo", sleepInfo.Name, "namespace", sleepInfo.Namespace, "start", start.UTC(), "end", end.UTC())
+		response.SleepInfos = append(response.SleepInfos, WindowItem{Namespace: sleepInfo.Namespace, Name: sleepInfo.Name})
+	}
+	return response, nil
+}
+
+// buildWindowSleepInfo returns the window SleepInfo of the namespace {tenant}-{suffix}, suspending what
+// the recurring schedule of the namespace would suspend
+func (s *ScheduleService) buildWindowSleepInfo(ctx context.Context, tenant, suffix string, start, end time.Time, req WindowScheduleRequest) *kubegreenv1alpha1.SleepInfo {
+	namespace := fmt.Sprintf("%s-%s", tenant, suffix)
+
+	resources, err := s.GetNamespaceResources(ctx, tenant, suffix)
+	if err != nil {
+		s.logger.Error(err, "failed to detect resources in namespace", "namespace", namespace)
+		resources = &NamespaceResourceInfo{Namespace: namespace, AutoExclusions: []ExclusionFilter{}}
+	}
+	policy := s.namespacePolicy(ctx, suffix)
+
+	excludeRefs := getExcludeRefsForOperators()
+	for _, autoExcl := range resources.AutoExclusions {
+		excludeRefs = append(excludeRefs, autoExcl.toFilterRef())
+	}
+	excludeRefs = append(excludeRefs, policy.excludeRefs()...)
+	for _, excl := range req.Exclusions {
+		if excl.Namespace == suffix || excl.Namespace == namespace {
+			excludeRefs = append(excludeRefs, excl.Filter.toFilterRef())
+		}
+	}
+
+	name := req.ScheduleName
+	if name == "" {
+		name = fmt.Sprintf("window-%s-%s", suffix, start.UTC().Format("20060102-1504"))
+	}
+	annotations := map[string]string{
+		"kube-green.stratio.com/user-timezone": TZLocal,
+	}
+	if req.ScheduleName != "" {
+		annotations["kube-green.stratio.com/schedule-name"] = req.ScheduleName
+	}
+	if req.Description != "" {
+		annotations["kube-green.stratio.com/schedule-description"] = req.Description
+	}
+
+	enabled := true
+	suspendStatefulSets := policy.suspendStatefulSets(resources.ResourceCounts.StatefulSets > 0 || resources.HasPgCluster)
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			TimeZone:            "UTC",
+			SuspendDeployments:  &enabled,
+			SuspendStatefulSets: &suspendStatefulSets,
+			SuspendCronjobs:     true,
+			ExcludeRef:          excludeRefs,
+			Window: &kubegreenv1alpha1.ScheduleWindow{
+				Start: metav1.NewTime(start.UTC()),
+				End:   metav1.NewTime(end.UTC()),
+			},
+		},
+	}
+	hasCRDs := policy.staggeredWake(resources.hasDatastores())
+	if hasCRDs {
+		sleepInfo.Spec.SuspendStatefulSets = &enabled
+		sleepInfo.Spec.SuspendDeploymentsPgbouncer = &enabled
+		sleepInfo.Spec.SuspendStatefulSetsPostgres = &enabled
+		sleepInfo.Spec.SuspendStatefulSetsHdfs = &enabled
+		sleepInfo.Spec.SuspendStatefulSetsOpenSearch = &enabled
+		sleepInfo.Spec.SuspendStatefulSetsOsDashboards = &enabled
+		sleepInfo.Spec.SuspendStatefulSetsKafka = &enabled
+	}
+	return sleepInfo
+}
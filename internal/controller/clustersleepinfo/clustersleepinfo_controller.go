@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+*/
+
+package clustersleepinfo
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterSleepInfoReconciler reconciles a ClusterSleepInfo object, creating its SleepInfo in every
+// selected namespace and deleting it from the namespaces no longer selected
+type ClusterSleepInfoReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Recorder, when set, records an Event on the ClusterSleepInfo when a namespace is skipped or fails
+	Recorder record.EventRecorder
+	// DefaultTimeZone is the timeZone of the SleepInfos of the ClusterSleepInfos without it, as the
+	// mutating webhook sets it on the other SleepInfos
+	DefaultTimeZone string
+}
+
+// +kubebuilder:rbac:groups=kube-green.com,resources=clustersleepinfos,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kube-green.com,resources=clustersleepinfos/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kube-green.com,resources=sleepinfos,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+func (r *ClusterSleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("clustersleepinfo", req.Name)
+
+	clusterSleepInfo := &kubegreenv1alpha1.ClusterSleepInfo{}
+	if err := r.Get(ctx, req.NamespacedName, clusterSleepInfo); err != nil {
+		// The SleepInfos are deleted by the garbage collector through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !clusterSleepInfo.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, err := r.getSelectedNamespaces(ctx, clusterSleepInfo)
+	if err != nil {
+		log.Error(err, "fails to list selected namespaces")
+		return ctrl.Result{}, err
+	}
+
+	applied := []string{}
+	skipped := []string{}
+	var applyErr error
+	for _, namespace := range namespaces {
+		ok, err := r.applySleepInfo(ctx, clusterSleepInfo, namespace)
+		if err != nil {
+			log.Error(err, "fails to apply SleepInfo", "namespace", namespace)
+			r.recordEvent(clusterSleepInfo, corev1.EventTypeWarning, "ApplyFailed", fmt.Sprintf("fails to apply SleepInfo in namespace %s: %s", namespace, err))
+			applyErr = err
+			continue
+		}
+		if !ok {
+			log.Info("namespace has a SleepInfo not managed by the ClusterSleepInfo, skipped", "namespace", namespace)
+			r.recordEvent(clusterSleepInfo, corev1.EventTypeWarning, "NamespaceSkipped", fmt.Sprintf("namespace %s already has a SleepInfo named %s", namespace, clusterSleepInfo.Name))
+			skipped = append(skipped, namespace)
+			continue
+		}
+		applied = append(applied, namespace)
+	}
+
+	if err := r.deleteUnselectedSleepInfos(ctx, log, clusterSleepInfo, namespaces); err != nil {
+		log.Error(err, "fails to delete SleepInfos of namespaces no longer selected")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateStatus(ctx, clusterSleepInfo, applied, skipped); err != nil {
+		log.Error(err, "fails to update status")
+		return ctrl.Result{}, err
+	}
+	log.Info("ClusterSleepInfo applied", "namespaces", len(applied), "skipped", len(skipped))
+
+	return ctrl.Result{}, applyErr
+}
+
+// getSelectedNamespaces returns the sorted names of the namespaces selected by the ClusterSleepInfo,
+// except the ones being deleted
+func (r *ClusterSleepInfoReconciler) getSelectedNamespaces(ctx context.Context, clusterSleepInfo *kubegreenv1alpha1.ClusterSleepInfo) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&clusterSleepInfo.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{}
+	for _, namespace := range namespaceList.Items {
+		if namespace.DeletionTimestamp.IsZero() {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// applySleepInfo creates or updates the SleepInfo of the ClusterSleepInfo in the namespace. It
+// returns false when the namespace has a SleepInfo of the same name not managed by the
+// ClusterSleepInfo, left untouched.
+func (r *ClusterSleepInfoReconciler) applySleepInfo(ctx context.Context, clusterSleepInfo *kubegreenv1alpha1.ClusterSleepInfo, namespace string) (bool, error) {
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterSleepInfo.Name,
+			Namespace: namespace,
+		},
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), sleepInfo); err == nil {
+		if !metav1.IsControlledBy(sleepInfo, clusterSleepInfo) {
+			return false, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, sleepInfo, func() error {
+		if sleepInfo.Labels == nil {
+			sleepInfo.Labels = map[string]string{}
+		}
+		sleepInfo.Labels[kubegreenv1alpha1.ClusterSleepInfoLabel] = clusterSleepInfo.Name
+		sleepInfo.Spec = *clusterSleepInfo.Spec.Template.DeepCopy()
+		sleepInfo.Spec.NamespaceSelector = nil
+		// Defaulted as by the mutating webhook, so the SleepInfo is not updated again on every reconcile
+		sleepInfo.Spec.Default(cmp.Or(r.DefaultTimeZone, kubegreenv1alpha1.DefaultTimeZone))
+		return controllerutil.SetControllerReference(clusterSleepInfo, sleepInfo, r.Scheme)
+	})
+	return err == nil, err
+}
+
+// deleteUnselectedSleepInfos deletes the SleepInfos of the ClusterSleepInfo in the namespaces no
+// longer selected
+func (r *ClusterSleepInfoReconciler) deleteUnselectedSleepInfos(ctx context.Context, log logr.Logger, clusterSleepInfo *kubegreenv1alpha1.ClusterSleepInfo, namespaces []string) error {
+	selected := map[string]bool{}
+	for _, namespace := range namespaces {
+		selected[namespace] = true
+	}
+
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := r.List(ctx, sleepInfoList, client.MatchingLabels{kubegreenv1alpha1.ClusterSleepInfoLabel: clusterSleepInfo.Name}); err != nil {
+		return err
+	}
+	for i := range sleepInfoList.Items {
+		sleepInfo := &sleepInfoList.Items[i]
+		if selected[sleepInfo.Namespace] || !metav1.IsControlledBy(sleepInfo, clusterSleepInfo) {
+			continue
+		}
+		log.Info("namespace no longer selected, deleting its SleepInfo", "namespace", sleepInfo.Namespace)
+		if err := r.Delete(ctx, sleepInfo); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ClusterSleepInfoReconciler) updateStatus(ctx context.Context, clusterSleepInfo *kubegreenv1alpha1.ClusterSleepInfo, applied, skipped []string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.ClusterSleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(clusterSleepInfo), latest); err != nil {
+			return err
+		}
+		latest.Status.Namespaces = applied
+		latest.Status.SkippedNamespaces = skipped
+		latest.Status.ObservedGeneration = latest.Generation
+		return r.Status().Update(ctx, latest)
+	})
+}
+
+func (r *ClusterSleepInfoReconciler) recordEvent(clusterSleepInfo *kubegreenv1alpha1.ClusterSleepInfo, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(clusterSleepInfo, eventType, reason, message)
+}
+
+// requestsForNamespace enqueues all the ClusterSleepInfos when a namespace changes, since its labels
+// may change the namespaces they select
+func (r *ClusterSleepInfoReconciler) requestsForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	clusterSleepInfoList := &kubegreenv1alpha1.ClusterSleepInfoList{}
+	if err := r.List(ctx, clusterSleepInfoList); err != nil {
+		r.Log.Error(err, "fails to list ClusterSleepInfos")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(clusterSleepInfoList.Items))
+	for _, clusterSleepInfo := range clusterSleepInfoList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterSleepInfo)})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterSleepInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubegreenv1alpha1.ClusterSleepInfo{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&kubegreenv1alpha1.SleepInfo{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace)).
+		Named("kubegreen-clustersleepinfo").
+		Complete(r)
+}
@@ -0,0 +1,152 @@
+package clustersleepinfo
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	template := kubegreenv1alpha1.SleepInfoSpec{
+		Weekdays:   "1-5",
+		SleepTime:  "20:00",
+		WakeUpTime: "08:00",
+		TimeZone:   "Europe/Rome",
+	}
+	// The SleepInfos get the defaults of the mutating webhook
+	expected := *template.DeepCopy()
+	expected.SuspendDeployments = getPtr(true)
+	expected.SuspendStatefulSets = getPtr(true)
+	clusterSleepInfo := &kubegreenv1alpha1.ClusterSleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "working-hours", UID: types.UID("cluster-uid"), Generation: 2},
+		Spec: kubegreenv1alpha1.ClusterSleepInfoSpec{
+			NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			Template:          template,
+		},
+	}
+	unmanaged := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: "dev-team"},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "*", SleepTime: "22:00"},
+	}
+	outdated := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "working-hours",
+			Namespace: "dev-api",
+			Labels:    map[string]string{kubegreenv1alpha1.ClusterSleepInfoLabel: "working-hours"},
+		},
+		Spec:   kubegreenv1alpha1.SleepInfoSpec{Weekdays: "*", SleepTime: "22:00"},
+		Status: kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateSleeping},
+	}
+	unselected := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "working-hours",
+			Namespace: "prod",
+			Labels:    map[string]string{kubegreenv1alpha1.ClusterSleepInfoLabel: "working-hours"},
+		},
+	}
+	for _, sleepInfo := range []*kubegreenv1alpha1.SleepInfo{outdated, unselected} {
+		sleepInfo.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+			Kind:       "ClusterSleepInfo",
+			Name:       clusterSleepInfo.Name,
+			UID:        clusterSleepInfo.UID,
+			Controller: getPtr(true),
+		}}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&kubegreenv1alpha1.ClusterSleepInfo{}, &kubegreenv1alpha1.SleepInfo{}).
+		WithObjects(
+			clusterSleepInfo,
+			namespace("dev-api", map[string]string{"env": "dev"}),
+			namespace("dev-web", map[string]string{"env": "dev"}),
+			namespace("dev-team", map[string]string{"env": "dev"}),
+			namespace("prod", map[string]string{"env": "prod"}),
+			unmanaged,
+			outdated,
+			unselected,
+		).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := ClusterSleepInfoReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Recorder: recorder}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(clusterSleepInfo)})
+	require.NoError(t, err)
+
+	t.Run("creates the SleepInfo in the selected namespaces", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "working-hours", Namespace: "dev-web"}, sleepInfo))
+		require.Equal(t, expected, sleepInfo.Spec)
+		require.Equal(t, "working-hours", sleepInfo.Labels[kubegreenv1alpha1.ClusterSleepInfoLabel])
+		require.True(t, metav1.IsControlledBy(sleepInfo, clusterSleepInfo))
+	})
+
+	t.Run("updates the SleepInfo keeping its status", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(outdated), sleepInfo))
+		require.Equal(t, expected, sleepInfo.Spec)
+		require.Equal(t, kubegreenv1alpha1.StateSleeping, sleepInfo.Status.CurrentState)
+	})
+
+	t.Run("skips a SleepInfo not managed by the ClusterSleepInfo", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(unmanaged), sleepInfo))
+		require.Equal(t, unmanaged.Spec, sleepInfo.Spec)
+		require.Equal(t, "Warning NamespaceSkipped namespace dev-team already has a SleepInfo named working-hours", <-recorder.Events)
+	})
+
+	t.Run("deletes the SleepInfo of the namespaces no longer selected", func(t *testing.T) {
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(unselected), &kubegreenv1alpha1.SleepInfo{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("updates the status", func(t *testing.T) {
+		updated := &kubegreenv1alpha1.ClusterSleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterSleepInfo), updated))
+		require.Equal(t, kubegreenv1alpha1.ClusterSleepInfoStatus{
+			Namespaces:         []string{"dev-api", "dev-web"},
+			SkippedNamespaces:  []string{"dev-team"},
+			ObservedGeneration: 2,
+		}, updated.Status)
+	})
+}
+
+func TestRequestsForNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kubegreenv1alpha1.ClusterSleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "nights"}},
+		&kubegreenv1alpha1.ClusterSleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "weekends"}},
+	).Build()
+	r := ClusterSleepInfoReconciler{Client: c, Log: logr.Discard()}
+
+	requests := r.requestsForNamespace(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}})
+	require.Len(t, requests, 2)
+	require.Equal(t, "nights", requests[0].Name)
+	require.Equal(t, "weekends", requests[1].Name)
+}
+
+func getPtr[T any](item T) *T {
+	return &item
+}
@@ -0,0 +1,161 @@
+// Package alertmanager creates and expires the Alertmanager silences of the SleepInfos asleep, through
+// the Alertmanager v2 API.
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+)
+
+const (
+	// DefaultNamespaceLabel is the label of the alerts holding their namespace
+	DefaultNamespaceLabel = "namespace"
+	// CreatedBy is the creator of the silences
+	CreatedBy = "kube-green"
+
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize is the largest response read from Alertmanager
+	maxResponseSize = 1 << 16
+)
+
+// Matcher is a label matcher of a silence
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// Silence is a silence of the alerts matching all its matchers between StartsAt and EndsAt
+type Silence struct {
+	// ID updates the silence with this ID, when set
+	ID        string    `json:"id,omitempty"`
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// Client manages the silences of an Alertmanager
+type Client struct {
+	// URL of Alertmanager, e.g. http://alertmanager-operated.monitoring:9093
+	URL string
+	// NamespaceLabel is the label of the alerts matched by the default silence, DefaultNamespaceLabel when empty
+	NamespaceLabel string
+	// BearerTokenFile is read on every request and sent as bearer token, when set
+	BearerTokenFile string
+	// HTTPClient defaults to a client with a 10s timeout
+	HTTPClient *http.Client
+}
+
+// Matchers returns the matchers of the silence of the SleepInfo: its alert silence matchers, or the
+// namespace label equal to its namespace
+func (c *Client) Matchers(sleepInfo *kubegreenv1alpha1.SleepInfo) []Matcher {
+	alertMatchers := sleepInfo.GetAlertMatchers()
+	if len(alertMatchers) == 0 {
+		label := c.NamespaceLabel
+		if label == "" {
+			label = DefaultNamespaceLabel
+		}
+		return []Matcher{{Name: label, Value: sleepInfo.Namespace, IsEqual: true}}
+	}
+	matchers := make([]Matcher, 0, len(alertMatchers))
+	for _, m := range alertMatchers {
+		matchers = append(matchers, Matcher{Name: m.Name, Value: m.Value, IsRegex: m.IsRegexp(), IsEqual: m.IsEqual()})
+	}
+	return matchers
+}
+
+// CreateSilence creates the silence, or updates it when it has an ID, returning its ID. A silence
+// to update no longer found, e.g. deleted by the garbage collection of Alertmanager, is created again.
+func (c *Client) CreateSilence(ctx context.Context, silence Silence) (string, error) {
+	body, err := json.Marshal(silence)
+	if err != nil {
+		return "", err
+	}
+	data, err := c.do(ctx, http.MethodPost, "/api/v2/silences", body)
+	if err != nil && silence.ID != "" && isNotFound(err) {
+		silence.ID = ""
+		return c.CreateSilence(ctx, silence)
+	}
+	if err != nil {
+		return "", fmt.Errorf("fails to create silence: %w", err)
+	}
+	response := struct {
+		SilenceID string `json:"silenceID"`
+	}{}
+	if err := json.Unmarshal(data, &response); err != nil || response.SilenceID == "" {
+		return "", fmt.Errorf("fails to create silence: invalid response %q", string(data))
+	}
+	return response.SilenceID, nil
+}
+
+// ExpireSilence expires the silence. A silence not found is ignored.
+func (c *Client) ExpireSilence(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("fails to expire silence %s: %w", id, err)
+	}
+	return nil
+}
+
+type statusError struct {
+	method string
+	path   string
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s returned unexpected status %d: %s", e.method, e.path, e.status, e.body)
+}
+
+func isNotFound(err error) bool {
+	statusErr, ok := err.(*statusError)
+	return ok && statusErr.status == http.StatusNotFound
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.URL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kube-green")
+	if c.BearerTokenFile != "" {
+		token, err := os.ReadFile(c.BearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("fails to read bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &statusError{method: method, path: path, status: resp.StatusCode, body: strings.TrimSpace(string(data))}
+	}
+	return data, nil
+}
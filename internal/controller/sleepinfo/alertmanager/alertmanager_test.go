@@ -0,0 +1,118 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatchers(t *testing.T) {
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "bdadevdat-apps"}}
+
+	require.Equal(t, []Matcher{{Name: "namespace", Value: "bdadevdat-apps", IsEqual: true}}, (&Client{}).Matchers(sleepInfo))
+	require.Equal(t, []Matcher{{Name: "kubernetes_namespace", Value: "bdadevdat-apps", IsEqual: true}}, (&Client{NamespaceLabel: "kubernetes_namespace"}).Matchers(sleepInfo))
+
+	sleepInfo.Spec.AlertSilence = &kubegreenv1alpha1.AlertSilence{Matchers: []kubegreenv1alpha1.AlertMatcher{
+		{Name: "tenant", Value: "bdadevdat"},
+		{Name: "severity", Value: "critical", Operator: kubegreenv1alpha1.AlertMatchNotEqual},
+		{Name: "alertname", Value: "Kube.*", Operator: kubegreenv1alpha1.AlertMatchRegexp},
+		{Name: "team", Value: "sre|platform", Operator: kubegreenv1alpha1.AlertMatchNotRegexp},
+	}}
+	require.Equal(t, []Matcher{
+		{Name: "tenant", Value: "bdadevdat", IsEqual: true},
+		{Name: "severity", Value: "critical"},
+		{Name: "alertname", Value: "Kube.*", IsRegex: true, IsEqual: true},
+		{Name: "team", Value: "sre|platform", IsRegex: true},
+	}, (&Client{}).Matchers(sleepInfo))
+}
+
+func TestClient(t *testing.T) {
+	startsAt := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	silence := Silence{
+		Matchers:  []Matcher{{Name: "namespace", Value: "bdadevdat-apps", IsEqual: true}},
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(13 * time.Hour),
+		CreatedBy: CreatedBy,
+		Comment:   "SleepInfo bdadevdat-apps/sleep asleep",
+	}
+
+	t.Run("creates and expires silences", func(t *testing.T) {
+		var requests []string
+		var created Silence
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requests = append(requests, req.Method+" "+req.URL.Path+" "+req.Header.Get("Authorization"))
+			switch {
+			case req.Method == http.MethodPost && req.URL.Path == "/api/v2/silences":
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&created))
+				_, _ = w.Write([]byte(`{"silenceID":"silence-1"}`))
+			case req.Method == http.MethodDelete && req.URL.Path == "/api/v2/silence/silence-1":
+			case req.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNotFound)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+		defer server.Close()
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("s3cret\n"), 0o600))
+		client := &Client{URL: server.URL + "/", BearerTokenFile: tokenFile}
+
+		id, err := client.CreateSilence(context.Background(), silence)
+		require.NoError(t, err)
+		require.Equal(t, "silence-1", id)
+		require.Equal(t, silence, created)
+
+		require.NoError(t, client.ExpireSilence(context.Background(), "silence-1"))
+		require.NoError(t, client.ExpireSilence(context.Background(), "deleted"))
+		require.Equal(t, []string{
+			"POST /api/v2/silences Bearer s3cret",
+			"DELETE /api/v2/silence/silence-1 Bearer s3cret",
+			"DELETE /api/v2/silence/deleted Bearer s3cret",
+		}, requests)
+	})
+
+	t.Run("creates again a silence to update not found", func(t *testing.T) {
+		var ids []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			received := Silence{}
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+			ids = append(ids, received.ID)
+			if received.ID != "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"silenceID":"silence-2"}`))
+		}))
+		defer server.Close()
+
+		updated := silence
+		updated.ID = "silence-1"
+		id, err := (&Client{URL: server.URL}).CreateSilence(context.Background(), updated)
+		require.NoError(t, err)
+		require.Equal(t, "silence-2", id)
+		require.Equal(t, []string{"silence-1", ""}, ids)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("silence invalid\n"))
+		}))
+		defer server.Close()
+		client := &Client{URL: server.URL}
+
+		_, err := client.CreateSilence(context.Background(), silence)
+		require.EqualError(t, err, "fails to create silence: POST /api/v2/silences returned unexpected status 500: silence invalid")
+		require.EqualError(t, client.ExpireSilence(context.Background(), "silence-1"), "fails to expire silence silence-1: DELETE /api/v2/silence/silence-1 returned unexpected status 500: silence invalid")
+	})
+}
@@ -0,0 +1,91 @@
+package sleepinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/alertmanager"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// alertSilenceMargin keeps the silence active after the next operation, for the wake up to complete
+	alertSilenceMargin = time.Hour
+	// defaultAlertSilenceDuration is the duration of the silence of a sleep without next operation
+	defaultAlertSilenceDuration = 24 * time.Hour
+)
+
+// silenceAlerts silences the alerts of the SleepInfo asleep until its next operation, nextSchedule.
+// The silence of a SleepInfo already asleep is updated. The silence is not required by the sleep, so
+// its errors are reported in the log and an Event.
+func (r *SleepInfoReconciler) silenceAlerts(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, nextSchedule, now time.Time) {
+	if r.Alertmanager == nil || sleepInfo.IsDryRun() || !sleepInfo.IsAlertSilenceEnabled() {
+		return
+	}
+	endsAt := nextSchedule
+	if !endsAt.After(now) {
+		endsAt = now.Add(defaultAlertSilenceDuration)
+	}
+	id, err := r.Alertmanager.CreateSilence(ctx, alertmanager.Silence{
+		ID:        sleepInfo.Status.AlertSilenceID,
+		Matchers:  r.Alertmanager.Matchers(sleepInfo),
+		StartsAt:  now,
+		EndsAt:    endsAt.Add(alertSilenceMargin),
+		CreatedBy: alertmanager.CreatedBy,
+		Comment:   fmt.Sprintf("SleepInfo %s/%s asleep", sleepInfo.Namespace, sleepInfo.Name),
+	})
+	if err != nil {
+		r.alertSilenceFailed(log, sleepInfo, err)
+		return
+	}
+	log.Info("alerts silenced", "silence", id, "until", endsAt.Add(alertSilenceMargin))
+	r.setAlertSilenceID(ctx, log, sleepInfo, id)
+}
+
+// expireAlertSilence expires the silence of the sleep once the wake up is complete
+func (r *SleepInfoReconciler) expireAlertSilence(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	id := sleepInfo.Status.AlertSilenceID
+	if r.Alertmanager == nil || id == "" {
+		return
+	}
+	if err := r.Alertmanager.ExpireSilence(ctx, id); err != nil {
+		r.alertSilenceFailed(log, sleepInfo, err)
+		return
+	}
+	log.Info("alert silence expired", "silence", id)
+	if sleepInfo.DeletionTimestamp.IsZero() {
+		r.setAlertSilenceID(ctx, log, sleepInfo, "")
+	}
+}
+
+func (r *SleepInfoReconciler) alertSilenceFailed(log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, err error) {
+	log.Error(err, "fails to update alert silence")
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "AlertSilenceFailed", "alert silence failed: %s", err)
+	}
+}
+
+// setAlertSilenceID records the silence of the sleep in the status of the SleepInfo
+func (r *SleepInfoReconciler) setAlertSilenceID(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, id string) {
+	sleepInfo.Status.AlertSilenceID = id
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), latest); err != nil {
+			return err
+		}
+		if latest.Status.AlertSilenceID == id {
+			return nil
+		}
+		latest.Status.AlertSilenceID = id
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		log.Error(err, "fails to update alert silence status")
+	}
+}
@@ -0,0 +1,130 @@
+package sleepinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/alertmanager"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAlertSilence(t *testing.T) {
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	wakeUpAt := now.Add(12 * time.Hour)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	var silences []alertmanager.Silence
+	var expired []string
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if req.Method == http.MethodDelete {
+			expired = append(expired, req.URL.Path)
+			return
+		}
+		silence := alertmanager.Silence{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&silence))
+		silences = append(silences, silence)
+		_, _ = w.Write([]byte(`{"silenceID":"silence-1"}`))
+	}))
+	defer server.Close()
+
+	newReconciler := func(sleepInfo *kubegreenv1alpha1.SleepInfo) (SleepInfoReconciler, client.Client, *record.FakeRecorder) {
+		silences, expired, fail = nil, nil, false
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}).
+			WithObjects(sleepInfo).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		return SleepInfoReconciler{
+			Client:       fakeClient,
+			Recorder:     recorder,
+			Alertmanager: &alertmanager.Client{URL: server.URL},
+		}, fakeClient, recorder
+	}
+	newSleepInfo := func() *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace"},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{SleepTime: "20:00", WakeUpTime: "08:00"},
+		}
+	}
+	silenceID := func(t *testing.T, c client.Client) string {
+		t.Helper()
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "my-namespace", Name: "sleep"}, updated))
+		return updated.Status.AlertSilenceID
+	}
+
+	t.Run("silences the namespace until the wake up", func(t *testing.T) {
+		sleepInfo := newSleepInfo()
+		r, c, _ := newReconciler(sleepInfo)
+
+		r.silenceAlerts(context.Background(), logr.Discard(), sleepInfo, wakeUpAt, now)
+
+		require.Equal(t, []alertmanager.Silence{{
+			Matchers:  []alertmanager.Matcher{{Name: "namespace", Value: "my-namespace", IsEqual: true}},
+			StartsAt:  now,
+			EndsAt:    wakeUpAt.Add(alertSilenceMargin),
+			CreatedBy: "kube-green",
+			Comment:   "SleepInfo my-namespace/sleep asleep",
+		}}, silences)
+		require.Equal(t, "silence-1", silenceID(t, c))
+
+		// Asleep again: the silence is updated
+		r.silenceAlerts(context.Background(), logr.Discard(), sleepInfo, time.Time{}, now)
+		require.Len(t, silences, 2)
+		require.Equal(t, "silence-1", silences[1].ID)
+		require.Equal(t, now.Add(defaultAlertSilenceDuration+alertSilenceMargin), silences[1].EndsAt)
+
+		r.expireAlertSilence(context.Background(), logr.Discard(), sleepInfo)
+		require.Equal(t, []string{"/api/v2/silence/silence-1"}, expired)
+		require.Empty(t, silenceID(t, c))
+	})
+
+	t.Run("not for disabled silences and dry runs", func(t *testing.T) {
+		sleepInfo := newSleepInfo()
+		sleepInfo.Spec.AlertSilence = &kubegreenv1alpha1.AlertSilence{Disabled: true}
+		r, _, _ := newReconciler(sleepInfo)
+		r.silenceAlerts(context.Background(), logr.Discard(), sleepInfo, wakeUpAt, now)
+
+		dryRun := newSleepInfo()
+		dryRun.Spec.DryRun = getPtr(true)
+		r.silenceAlerts(context.Background(), logr.Discard(), dryRun, wakeUpAt, now)
+
+		r.Alertmanager = nil
+		r.silenceAlerts(context.Background(), logr.Discard(), newSleepInfo(), wakeUpAt, now)
+		require.Empty(t, silences)
+	})
+
+	t.Run("records the failures", func(t *testing.T) {
+		sleepInfo := newSleepInfo()
+		sleepInfo.Status.AlertSilenceID = "silence-1"
+		r, c, recorder := newReconciler(sleepInfo)
+		fail = true
+
+		r.expireAlertSilence(context.Background(), logr.Discard(), sleepInfo)
+
+		require.Equal(t, "silence-1", silenceID(t, c))
+		require.Len(t, recorder.Events, 1)
+		require.Contains(t, <-recorder.Events, "Warning AlertSilenceFailed alert silence failed: fails to expire silence silence-1")
+	})
+}
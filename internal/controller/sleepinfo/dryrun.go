@@ -0,0 +1,90 @@
+package sleepinfo
+
+import (
+	"context"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxDryRunResources is the number of resources listed in status.lastDryRun, its counts include all
+// of them
+const maxDryRunResources = 100
+
+// dryRunOperation simulates the current operation of a dryRun SleepInfo: its resource client sends
+// the patches as dry run, so the API server validates them without changing the resources. The
+// secret records the operation, to alternate sleeps and wake ups as usual, but keeps the restore
+// patches of the last sleep. The status and the Events report the resources that would be patched.
+func (r *SleepInfoReconciler) dryRunOperation(
+	ctx context.Context,
+	log logr.Logger,
+	now time.Time,
+	secretName string,
+	sleepInfo *kubegreenv1alpha1.SleepInfo,
+	secret *v1.Secret,
+	sleepInfoData SleepInfoData,
+	resources resource.Resource,
+	events *operationEvents,
+) error {
+	if resources.HasResource() {
+		var err error
+		if sleepInfoData.IsSleepOperation() {
+			err = resources.Sleep(ctx)
+		} else {
+			err = resources.WakeUp(ctx)
+		}
+		if err != nil {
+			log.Error(err, "fails to handle dry run", "operation", sleepInfoData.CurrentOperationType)
+			events.dryRunFinished(err)
+			return err
+		}
+	}
+
+	if err := r.upsertSecret(ctx, log, now, secretName, sleepInfo.Namespace, sleepInfo, secret, sleepInfoData, resources); err != nil {
+		log.Error(err, "fails to update secret", "secret", secretName)
+		return err
+	}
+
+	dryRun := events.dryRunStatus(now)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), latest); err != nil {
+			return err
+		}
+		latest.Status.LastDryRun = dryRun
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		log.Error(err, "fails to update dry run status")
+	}
+	events.dryRunFinished(nil)
+	log.Info("dry run executed", "operation", sleepInfoData.CurrentOperationType, "resources", countResources(dryRun.ResourceCounts), "failed", len(dryRun.FailedResources))
+	return nil
+}
+
+// dryRunStatus returns the resources collected by a dry run
+func (e *operationEvents) dryRunStatus(now time.Time) *kubegreenv1alpha1.DryRunStatus {
+	status := &kubegreenv1alpha1.DryRunStatus{
+		OperationType:   e.operationType,
+		ExecutedAt:      metav1.NewTime(now),
+		Resources:       e.resources,
+		FailedResources: e.failed,
+	}
+	if len(e.patched) > 0 {
+		status.ResourceCounts = e.patched
+	}
+	if len(status.Resources) > maxDryRunResources {
+		status.Resources = status.Resources[:maxDryRunResources]
+	}
+	if len(status.FailedResources) > maxFailedResources {
+		status.FailedResources = status.FailedResources[:maxFailedResources]
+	}
+	return status
+}
@@ -0,0 +1,126 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDryRunOperation(t *testing.T) {
+	namespace := "my-namespace"
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			Weekdays:   "*",
+			SleepTime:  "20:00",
+			WakeUpTime: "08:00",
+			DryRun:     getPtr(true),
+		},
+	}
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(3))},
+		}
+	}
+	// The fake client ignores the dry run of the applies, discarded here as the API server does
+	dryRunApplies := 0
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(restMapper).
+		WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}).
+		WithObjects(sleepInfo.DeepCopy(), deployment("api"), deployment("frontend")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Apply: func(ctx context.Context, c client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+				applyOpts := &client.ApplyOptions{}
+				applyOpts.ApplyOptions(opts)
+				if len(applyOpts.DryRun) > 0 {
+					dryRunApplies++
+					return nil
+				}
+				return c.Apply(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := SleepInfoReconciler{
+		Client:         fakeClient,
+		Log:            logr.Discard(),
+		ManagerName:    testFieldManagerName,
+		Recorder:       recorder,
+		WorkloadEvents: true,
+	}
+	data := SleepInfoData{CurrentOperationType: sleepOperation}
+
+	resourceClient := r.resourceClient(context.Background(), r.Log, sleepInfo, data)
+	require.True(t, resourceClient.DryRun)
+	events := r.operationEvents(sleepInfo, data.CurrentOperationType)
+	resourceClient.Patched = events.resourcePatched
+	resourceClient.Failed = events.resourceFailed
+	resources, err := jsonpatch.NewResources(context.Background(), resourceClient, namespace, nil, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, r.dryRunOperation(context.Background(), r.Log, now, getSecretName(sleepInfo.Name), sleepInfo, nil, data, resources, events))
+
+	t.Run("leaves the resources unchanged", func(t *testing.T) {
+		require.Equal(t, 2, dryRunApplies)
+		for _, name := range []string{"api", "frontend"} {
+			res := &appsv1.Deployment{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, res))
+			require.Equal(t, int32(3), *res.Spec.Replicas)
+		}
+	})
+
+	t.Run("reports the resources in the status", func(t *testing.T) {
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.Equal(t, &kubegreenv1alpha1.DryRunStatus{
+			OperationType:  sleepOperation,
+			ExecutedAt:     updated.Status.LastDryRun.ExecutedAt,
+			ResourceCounts: map[string]int32{"Deployment": 2},
+			Resources: []kubegreenv1alpha1.DryRunResource{
+				{Kind: "Deployment", Name: "api"},
+				{Kind: "Deployment", Name: "frontend"},
+			},
+		}, updated.Status.LastDryRun)
+		require.True(t, now.Equal(updated.Status.LastDryRun.ExecutedAt.Time))
+		require.Empty(t, updated.Status.CurrentState)
+		require.Nil(t, updated.Status.LastSleepTime)
+	})
+
+	t.Run("records the operation without restore patches", func(t *testing.T) {
+		secret := &v1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: getSecretName(sleepInfo.Name), Namespace: namespace}, secret))
+		require.Equal(t, sleepOperation, secret.StringData[lastOperationKey])
+		require.NotContains(t, secret.Data, originalJSONPatchDataKey)
+	})
+
+	t.Run("records a single Event", func(t *testing.T) {
+		require.Equal(t, "Normal SleepDryRun dry run sleep: 2 resources would be slept (Deployment: 2)", <-recorder.Events)
+		require.Empty(t, recorder.Events)
+	})
+}
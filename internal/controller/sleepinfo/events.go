@@ -0,0 +1,202 @@
+package sleepinfo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+)
+
+// operationEvents records the Events of an operation on its SleepInfo and, with workloads, on every
+// resource slept, woken up or failed. It collects those resources even without recorder.
+type operationEvents struct {
+	recorder      record.EventRecorder
+	sleepInfo     *kubegreenv1alpha1.SleepInfo
+	operationType string
+	workloads     bool
+	// patched are the resources slept or woken up by kind
+	patched map[string]int32
+	// failed are the resources not slept or woken up
+	failed []kubegreenv1alpha1.FailedResource
+	// released are the CPU and memory requested by the workloads slept
+	released v1.ResourceList
+	// dryRun collects the resources the operation would patch in resources, without their Events
+	dryRun    bool
+	resources []kubegreenv1alpha1.DryRunResource
+}
+
+func (r *SleepInfoReconciler) operationEvents(sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string) *operationEvents {
+	return &operationEvents{
+		recorder:      r.Recorder,
+		sleepInfo:     sleepInfo,
+		operationType: operationType,
+		workloads:     r.WorkloadEvents,
+		patched:       map[string]int32{},
+		dryRun:        sleepInfo.IsDryRun(),
+	}
+}
+
+// operationName returns the name of the operation used in the reasons of Events and conditions
+func operationName(operationType string) string {
+	if operationType == wakeUpOperation {
+		return "WakeUp"
+	}
+	return "Sleep"
+}
+
+// resourcePatched is the Patched hook of the resource client
+func (e *operationEvents) resourcePatched(res unstructured.Unstructured) {
+	e.patched[res.GetKind()]++
+	if e.dryRun {
+		e.resources = append(e.resources, kubegreenv1alpha1.DryRunResource{Kind: res.GetKind(), Name: res.GetName()})
+		return
+	}
+	if e.operationType == sleepOperation {
+		e.released = addRequests(e.released, releasedRequests(res))
+	}
+	if e.recorder == nil || !e.workloads {
+		return
+	}
+	reason, action := "Slept", "slept"
+	if e.operationType == wakeUpOperation {
+		reason, action = "WokenUp", "woken up"
+	}
+	e.recorder.Eventf(&res, v1.EventTypeNormal, reason, "%s by SleepInfo %s", action, e.sleepInfo.Name)
+}
+
+// resourceFailed is the Failed hook of the resource client
+func (e *operationEvents) resourceFailed(res unstructured.Unstructured, reason string) {
+	e.failed = append(e.failed, kubegreenv1alpha1.FailedResource{Kind: res.GetKind(), Name: res.GetName(), Reason: reason})
+	if e.recorder == nil || !e.workloads || e.dryRun {
+		return
+	}
+	e.recorder.Eventf(&res, v1.EventTypeWarning, operationName(e.operationType)+"Failed", "%s by SleepInfo %s failed: %s", e.action(), e.sleepInfo.Name, reason)
+}
+
+// started records the start of a scheduled or manual operation
+func (e *operationEvents) started(manual bool) {
+	if e.recorder == nil {
+		return
+	}
+	trigger := "scheduled"
+	if manual {
+		trigger = "manual"
+	}
+	e.recorder.Eventf(e.sleepInfo, v1.EventTypeNormal, operationName(e.operationType)+"Started", "%s %s started", trigger, e.action())
+}
+
+// hookFailed records a failed hook of the operation, and whether it aborted the operation
+func (e *operationEvents) hookFailed(status kubegreenv1alpha1.HookStatus, aborted bool) {
+	if e.recorder == nil {
+		return
+	}
+	message := fmt.Sprintf("hook %s of %s failed: %s", status.Name, e.action(), status.Message)
+	if aborted {
+		message += ", " + e.action() + " aborted"
+	}
+	e.recorder.Event(e.sleepInfo, v1.EventTypeWarning, operationName(e.operationType)+"HookFailed", message)
+}
+
+// finished records the end of the operation: a warning with its error when it failed, otherwise the
+// resources slept or woken up by kind, and a warning with the resources failed when there are any
+func (e *operationEvents) finished(result operationResult) {
+	if e.recorder == nil {
+		return
+	}
+	operation := operationName(e.operationType)
+	if result.err != nil {
+		e.recorder.Eventf(e.sleepInfo, v1.EventTypeWarning, operation+"Failed", "%s failed: %s", e.action(), result.err)
+		return
+	}
+	message := fmt.Sprintf("%d resources %s", countResources(e.patched), e.pastAction())
+	if len(e.patched) > 0 {
+		message = fmt.Sprintf("%s (%s)", message, formatCounts(e.patched))
+	}
+	if result.continued {
+		message = "wake stages executed, " + message
+	}
+	if result.wakeStagesPending {
+		message += ", waiting for the next wake stages"
+	}
+	e.recorder.Event(e.sleepInfo, v1.EventTypeNormal, operation+"Succeeded", message)
+	if len(e.failed) > 0 {
+		e.recorder.Event(e.sleepInfo, v1.EventTypeWarning, operation+"PartiallyFailed", partialFailureMessage(e.operationType, e.patched, e.failed))
+	}
+}
+
+// dryRunFinished records the end of a dry run: a warning with its error when it failed, otherwise the
+// resources that would be slept or woken up by kind
+func (e *operationEvents) dryRunFinished(err error) {
+	if e.recorder == nil {
+		return
+	}
+	reason := operationName(e.operationType) + "DryRun"
+	if err != nil {
+		e.recorder.Eventf(e.sleepInfo, v1.EventTypeWarning, reason+"Failed", "dry run %s failed: %s", e.action(), err)
+		return
+	}
+	message := fmt.Sprintf("dry run %s: %d resources would be %s", e.action(), countResources(e.patched), e.pastAction())
+	if len(e.patched) > 0 {
+		message = fmt.Sprintf("%s (%s)", message, formatCounts(e.patched))
+	}
+	if len(e.failed) > 0 {
+		message = fmt.Sprintf("%s, %d would fail", message, len(e.failed))
+	}
+	e.recorder.Event(e.sleepInfo, v1.EventTypeNormal, reason, message)
+}
+
+func (e *operationEvents) action() string {
+	if e.operationType == wakeUpOperation {
+		return "wake up"
+	}
+	return "sleep"
+}
+
+func (e *operationEvents) pastAction() string {
+	if e.operationType == wakeUpOperation {
+		return "woken up"
+	}
+	return "slept"
+}
+
+// partialFailureMessage returns the resources failed out of those handled by kind, e.g.
+// 3 of 25 Deployment failed to sleep
+func partialFailureMessage(operationType string, patched map[string]int32, failed []kubegreenv1alpha1.FailedResource) string {
+	failedCounts := map[string]int32{}
+	for _, res := range failed {
+		failedCounts[res.Kind]++
+	}
+	kinds := make([]string, 0, len(failedCounts))
+	for kind := range failedCounts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	action := "sleep"
+	if operationType == wakeUpOperation {
+		action = "wake up"
+	}
+	items := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		items = append(items, fmt.Sprintf("%d of %d %s failed to %s", failedCounts[kind], failedCounts[kind]+patched[kind], kind, action))
+	}
+	return strings.Join(items, ", ")
+}
+
+// formatCounts returns the counts sorted by kind, e.g. Deployment: 2, StatefulSet: 1
+func formatCounts(counts map[string]int32) string {
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	items := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		items = append(items, fmt.Sprintf("%s: %d", kind, counts[kind]))
+	}
+	return strings.Join(items, ", ")
+}
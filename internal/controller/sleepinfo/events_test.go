@@ -0,0 +1,116 @@
+package sleepinfo
+
+import (
+	"errors"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestOperationEvents(t *testing.T) {
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: "my-namespace"}}
+	drain := func(recorder *record.FakeRecorder) []string {
+		events := []string{}
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		return events
+	}
+
+	t.Run("sleep", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder}
+		events := r.operationEvents(sleepInfo, sleepOperation)
+
+		events.started(false)
+		events.resourcePatched(unstructuredOf("Deployment", "api"))
+		events.resourcePatched(unstructuredOf("Deployment", "frontend"))
+		events.resourcePatched(unstructuredOf("StatefulSet", "db"))
+		events.finished(operationResult{operationType: sleepOperation})
+
+		require.Equal(t, []string{
+			"Normal SleepStarted scheduled sleep started",
+			"Normal SleepSucceeded 3 resources slept (Deployment: 2, StatefulSet: 1)",
+		}, drain(recorder))
+	})
+
+	t.Run("wake up with workload events", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder, WorkloadEvents: true}
+		events := r.operationEvents(sleepInfo, wakeUpOperation)
+
+		events.started(true)
+		events.resourcePatched(unstructuredOf("Deployment", "api"))
+		events.finished(operationResult{operationType: wakeUpOperation, wakeStagesPending: true})
+
+		require.Equal(t, []string{
+			"Normal WakeUpStarted manual wake up started",
+			"Normal WokenUp woken up by SleepInfo working-hours",
+			"Normal WakeUpSucceeded 1 resources woken up (Deployment: 1), waiting for the next wake stages",
+		}, drain(recorder))
+	})
+
+	t.Run("failed hook", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder}
+		events := r.operationEvents(sleepInfo, sleepOperation)
+
+		events.hookFailed(kubegreenv1alpha1.HookStatus{Name: "checkpoint", Message: "job checkpoint-x1 failed"}, true)
+
+		require.Equal(t, []string{
+			"Warning SleepHookFailed hook checkpoint of sleep failed: job checkpoint-x1 failed, sleep aborted",
+		}, drain(recorder))
+	})
+
+	t.Run("wake stages", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder}
+		events := r.operationEvents(sleepInfo, wakeUpOperation)
+
+		events.finished(operationResult{operationType: wakeUpOperation, continued: true})
+
+		require.Equal(t, []string{"Normal WakeUpSucceeded wake stages executed, 0 resources woken up"}, drain(recorder))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder}
+		events := r.operationEvents(sleepInfo, sleepOperation)
+
+		events.finished(operationResult{operationType: sleepOperation, err: errors.New("fails to list deployments")})
+
+		require.Equal(t, []string{"Warning SleepFailed sleep failed: fails to list deployments"}, drain(recorder))
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Recorder: recorder, WorkloadEvents: true}
+		events := r.operationEvents(sleepInfo, wakeUpOperation)
+
+		events.resourcePatched(unstructuredOf("Deployment", "api"))
+		events.resourceFailed(unstructuredOf("Deployment", "frontend"), "modified between sleep and wake up")
+		events.finished(operationResult{operationType: wakeUpOperation})
+
+		require.Equal(t, []string{
+			"Normal WokenUp woken up by SleepInfo working-hours",
+			"Warning WakeUpFailed wake up by SleepInfo working-hours failed: modified between sleep and wake up",
+			"Normal WakeUpSucceeded 1 resources woken up (Deployment: 1)",
+			"Warning WakeUpPartiallyFailed 1 of 2 Deployment failed to wake up",
+		}, drain(recorder))
+	})
+
+	t.Run("counts resources without recorder", func(t *testing.T) {
+		r := SleepInfoReconciler{WorkloadEvents: true}
+		events := r.operationEvents(sleepInfo, sleepOperation)
+
+		events.started(false)
+		events.resourcePatched(unstructuredOf("Deployment", "api"))
+		events.finished(operationResult{operationType: sleepOperation})
+
+		require.Equal(t, map[string]int32{"Deployment": 1}, events.patched)
+	})
+}
@@ -0,0 +1,86 @@
+package sleepinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// completesExecuteOnce returns whether the scheduled operation executed completes an ExecuteOnce
+// SleepInfo: a wake up, or a sleep when the SleepInfo has no wake up of its own
+func completesExecuteOnce(sleepInfo *kubegreenv1alpha1.SleepInfo, data SleepInfoData) bool {
+	if !sleepInfo.IsExecuteOnce() || sleepInfo.IsCompleted() || sleepInfo.IsWindow() {
+		return false
+	}
+	return data.IsWakeUpOperation() || (!sleepInfo.HasWakeUpSchedule() && sleepInfo.GetSleepDuration() == 0)
+}
+
+// completeExecuteOnce sets the completion time of an ExecuteOnce SleepInfo in its status, and
+// deletes it when it is awake and DeleteWhenCompleted is set
+func (r *SleepInfoReconciler) completeExecuteOnce(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, now time.Time) error {
+	completedAt := metav1.NewTime(now)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), latest); err != nil {
+			return err
+		}
+		latest.Status.CompletedAt = &completedAt
+		if err := r.Status().Update(ctx, latest); err != nil {
+			return err
+		}
+		sleepInfo.Status = latest.Status
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("fails to set completion time: %w", err)
+	}
+	log.Info("execute once SleepInfo completed")
+	if r.Recorder != nil {
+		r.Recorder.Event(sleepInfo, v1.EventTypeNormal, "Completed", "scheduled operations executed once, later ones are skipped")
+	}
+	if isToDeleteWhenCompleted(sleepInfo) {
+		return r.deleteCompleted(ctx, log, sleepInfo)
+	}
+	return nil
+}
+
+// isToDeleteWhenCompleted returns whether a completed SleepInfo is deleted: only once its resources
+// are awake, without wake stages left, since the secret of a SleepInfo asleep holds their restore patches
+func isToDeleteWhenCompleted(sleepInfo *kubegreenv1alpha1.SleepInfo) bool {
+	return sleepInfo.IsDeleteWhenCompleted() && sleepInfo.IsCompleted() && sleepInfo.Status.CurrentState == kubegreenv1alpha1.StateAwake
+}
+
+// deleteCompleted deletes a completed SleepInfo, with the sleep SleepInfo of its pair whose restore
+// patches it used. Their secrets are owned by them and garbage collected with them.
+func (r *SleepInfoReconciler) deleteCompleted(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	if pairID := sleepInfo.GetAnnotations()[pairIDAnnotation]; pairID != "" {
+		pairSleepInfos, err := listPairSleepInfos(ctx, r.Client, sleepInfo.Namespace, pairID)
+		if err != nil {
+			return fmt.Errorf("fails to list SleepInfos: %w", err)
+		}
+		for i := range pairSleepInfos {
+			pair := &pairSleepInfos[i]
+			annotations := pair.GetAnnotations()
+			if pair.Name == sleepInfo.Name || annotations[pairIDAnnotation] != pairID || annotations[pairRoleAnnotation] != pairRoleSleep || !pair.IsCompleted() {
+				continue
+			}
+			log.Info("execute once completed, deleting the sleep SleepInfo of the pair", "pair", pair.Name)
+			if err := r.Delete(ctx, pair); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("fails to delete SleepInfo %s: %w", pair.Name, err)
+			}
+		}
+	}
+	log.Info("execute once completed, deleting SleepInfo")
+	if err := r.Delete(ctx, sleepInfo); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("fails to delete completed SleepInfo: %w", err)
+	}
+	return nil
+}
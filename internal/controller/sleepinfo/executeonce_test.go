@@ -0,0 +1,151 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCompletesExecuteOnce(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name      string
+		spec      kubegreenv1alpha1.SleepInfoSpec
+		status    kubegreenv1alpha1.SleepInfoStatus
+		operation string
+		expected  bool
+	}{
+		{
+			name:      "not execute once",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{WakeUpTime: "08:00"},
+			operation: wakeUpOperation,
+		},
+		{
+			name:      "sleep with wake up",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), WakeUpTime: "08:00"},
+			operation: sleepOperation,
+		},
+		{
+			name:      "sleep with sleep duration",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), SleepDuration: &metav1.Duration{Duration: 10 * time.Hour}},
+			operation: sleepOperation,
+		},
+		{
+			name:      "wake up",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), WakeUpTime: "08:00"},
+			operation: wakeUpOperation,
+			expected:  true,
+		},
+		{
+			name:      "sleep only",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true)},
+			operation: sleepOperation,
+			expected:  true,
+		},
+		{
+			name:      "already completed",
+			spec:      kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true)},
+			status:    kubegreenv1alpha1.SleepInfoStatus{CompletedAt: &now},
+			operation: sleepOperation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sleepInfo := &kubegreenv1alpha1.SleepInfo{Spec: test.spec, Status: test.status}
+			require.Equal(t, test.expected, completesExecuteOnce(sleepInfo, SleepInfoData{CurrentOperationType: test.operation}))
+		})
+	}
+}
+
+func TestCompleteExecuteOnce(t *testing.T) {
+	namespace := "my-namespace"
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}).WithObjects(objects...).Build()
+	}
+	pairAnnotations := func(role string) map[string]string {
+		return map[string]string{pairIDAnnotation: "pair", pairRoleAnnotation: role}
+	}
+	exists := func(c client.Client, sleepInfo *kubegreenv1alpha1.SleepInfo) bool {
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), &kubegreenv1alpha1.SleepInfo{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("marks the SleepInfo completed", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "tonight", Namespace: namespace},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true)},
+			Status:     kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateSleeping},
+		}
+		recorder := record.NewFakeRecorder(10)
+		c := newClient(sleepInfo)
+		r := SleepInfoReconciler{Client: c, Recorder: recorder}
+
+		require.NoError(t, r.completeExecuteOnce(context.Background(), logr.Discard(), sleepInfo, now))
+
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.True(t, updated.IsCompleted())
+		require.Equal(t, now, updated.Status.CompletedAt.UTC())
+		require.Equal(t, "Normal Completed scheduled operations executed once, later ones are skipped", <-recorder.Events)
+	})
+
+	t.Run("keeps a SleepInfo completed asleep", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "tonight", Namespace: namespace},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), DeleteWhenCompleted: getPtr(true)},
+			Status:     kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateSleeping},
+		}
+		c := newClient(sleepInfo)
+		r := SleepInfoReconciler{Client: c}
+
+		require.NoError(t, r.completeExecuteOnce(context.Background(), logr.Discard(), sleepInfo, now))
+
+		require.True(t, exists(c, sleepInfo))
+	})
+
+	t.Run("deletes a SleepInfo completed awake with the sleep of its pair", func(t *testing.T) {
+		completedAt := metav1.NewTime(now.Add(-10 * time.Hour))
+		sleep := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep-tonight", Namespace: namespace, Annotations: pairAnnotations(pairRoleSleep)},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), DeleteWhenCompleted: getPtr(true)},
+			Status:     kubegreenv1alpha1.SleepInfoStatus{CompletedAt: &completedAt},
+		}
+		wake := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "wake-tonight", Namespace: namespace, Annotations: pairAnnotations(pairRoleWake)},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{ExecuteOnce: getPtr(true), DeleteWhenCompleted: getPtr(true)},
+			Status:     kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateAwake},
+		}
+		other := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: namespace},
+		}
+		c := newClient(sleep, wake, other)
+		r := SleepInfoReconciler{Client: c}
+
+		require.NoError(t, r.completeExecuteOnce(context.Background(), logr.Discard(), wake, now))
+
+		require.False(t, exists(c, wake))
+		require.False(t, exists(c, sleep))
+		require.True(t, exists(c, other))
+	})
+}
@@ -0,0 +1,117 @@
+package sleepinfo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// wakesUpOnDeletion returns whether the resources of the SleepInfo are woken up when it is deleted
+func (r *SleepInfoReconciler) wakesUpOnDeletion(sleepInfo *kubegreenv1alpha1.SleepInfo) bool {
+	if sleepInfo.Spec.WakeUpOnDeletion != nil {
+		return *sleepInfo.Spec.WakeUpOnDeletion
+	}
+	return r.WakeUpOnDeletion
+}
+
+// reconcileFinalizer adds the wake up finalizer to the SleepInfos waking up their resources on
+// deletion, and removes it from the others. Once the SleepInfo is deleted, it wakes up the resources
+// still asleep before removing the finalizer. It returns true when the SleepInfo is being deleted.
+func (r *SleepInfoReconciler) reconcileFinalizer(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) (bool, error) {
+	hasFinalizer := controllerutil.ContainsFinalizer(sleepInfo, kubegreenv1alpha1.WakeUpFinalizer)
+	if sleepInfo.DeletionTimestamp.IsZero() {
+		wakesUp := r.wakesUpOnDeletion(sleepInfo)
+		if wakesUp == hasFinalizer {
+			return false, nil
+		}
+		if wakesUp {
+			controllerutil.AddFinalizer(sleepInfo, kubegreenv1alpha1.WakeUpFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(sleepInfo, kubegreenv1alpha1.WakeUpFinalizer)
+		}
+		if err := r.Update(ctx, sleepInfo); err != nil {
+			return false, fmt.Errorf("fails to update wake up finalizer: %w", err)
+		}
+		return false, nil
+	}
+	if !hasFinalizer {
+		return true, nil
+	}
+
+	secret, err := r.getSecret(ctx, getSecretName(sleepInfo.Name), sleepInfo.Namespace)
+	if client.IgnoreNotFound(err) != nil {
+		return true, err
+	}
+	if err := loadSleepInfoState(ctx, r.Client, sleepInfo.Namespace, sleepInfo.Name, secret); err != nil {
+		return true, err
+	}
+	if isAsleep(secret) {
+		if err := r.wakeUpOnDeletion(ctx, log, sleepInfo, secret); err != nil {
+			log.Error(err, "fails to wake up resources of deleted SleepInfo")
+			if r.Recorder != nil {
+				r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "WakeUpOnDeletionFailed", "wake up before deletion failed, remove the %s finalizer to delete it anyway: %s", kubegreenv1alpha1.WakeUpFinalizer, err)
+			}
+			return true, err
+		}
+	}
+	r.expireAlertSilence(ctx, log, sleepInfo)
+	r.restoreNodes(ctx, log, sleepInfo)
+	r.restoreNodePools(ctx, log, sleepInfo)
+
+	controllerutil.RemoveFinalizer(sleepInfo, kubegreenv1alpha1.WakeUpFinalizer)
+	if err := r.Update(ctx, sleepInfo); client.IgnoreNotFound(err) != nil {
+		return true, fmt.Errorf("fails to remove wake up finalizer: %w", err)
+	}
+	return true, nil
+}
+
+// isAsleep returns whether the last operation recorded in the secret is a sleep, or a wake up with
+// stages left
+func isAsleep(secret *v1.Secret) bool {
+	if secret == nil || secret.Data == nil {
+		return false
+	}
+	return string(secret.Data[lastOperationKey]) == sleepOperation || len(secret.Data[wakeStagesDataKey]) > 0
+}
+
+// wakeUpOnDeletion wakes up all the resources of a deleted SleepInfo with the restore patches of its
+// secret, wake stages included. Its schedule is not read, so an invalid one does not block the deletion.
+func (r *SleepInfoReconciler) wakeUpOnDeletion(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, secret *v1.Secret) error {
+	restorePatches, err := jsonpatch.GetOriginalInfoToRestore(secret.Data[originalJSONPatchDataKey])
+	if err != nil {
+		return fmt.Errorf("fails to read restore patches: %w", err)
+	}
+	sleptGenerations, err := jsonpatch.GetSleepGenerationsToRestore(secret.Data[sleptGenerationsDataKey])
+	if err != nil {
+		return fmt.Errorf("fails to read slept resource generations: %w", err)
+	}
+	if len(restorePatches) == 0 {
+		return nil
+	}
+	log.Info("SleepInfo deleted while asleep, waking up its resources")
+	if r.Recorder != nil {
+		r.Recorder.Event(sleepInfo, v1.EventTypeNormal, "WakeUpOnDeletion", "deleted while asleep, waking up its resources")
+	}
+
+	resourceClient := r.resourceClient(ctx, log, sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation})
+	events := r.operationEvents(sleepInfo, wakeUpOperation)
+	resourceClient.Patched = events.resourcePatched
+	resourceClient.Failed = events.resourceFailed
+	resources, err := jsonpatch.NewResources(ctx, resourceClient, sleepInfo.Namespace, restorePatches, sleptGenerations)
+	if err != nil {
+		return fmt.Errorf("fails to get resources: %w", err)
+	}
+	operationStart := time.Now()
+	err = resources.WakeUp(ctx)
+	r.observeOperationDuration(sleepInfo, wakeUpOperation, operationStart)
+	events.finished(operationResult{operationType: wakeUpOperation, err: err})
+	return err
+}
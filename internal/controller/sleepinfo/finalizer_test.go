@@ -0,0 +1,114 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileFinalizer(t *testing.T) {
+	namespace := "my-namespace"
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(objects...).Build()
+	}
+	secret := func(operation string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: getSecretName("sleep"), Namespace: namespace},
+			Data: map[string][]byte{
+				lastOperationKey:         []byte(operation),
+				originalJSONPatchDataKey: []byte(`{"` + kubegreenv1alpha1.DeploymentTarget.String() + `":{"api":"{\"spec\":{\"replicas\":3}}"}}`),
+			},
+		}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(0))},
+	}
+	replicas := func(c client.Client) int32 {
+		res := &appsv1.Deployment{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deployment), res))
+		return *res.Spec.Replicas
+	}
+
+	t.Run("adds the finalizer by default of the flag", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace}}
+		r := SleepInfoReconciler{Client: newClient(sleepInfo), WakeUpOnDeletion: true}
+
+		deleted, err := r.reconcileFinalizer(context.Background(), logr.Discard(), sleepInfo)
+		require.NoError(t, err)
+		require.False(t, deleted)
+
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.Equal(t, []string{kubegreenv1alpha1.WakeUpFinalizer}, updated.Finalizers)
+	})
+
+	t.Run("removes the finalizer when disabled", func(t *testing.T) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace, Finalizers: []string{kubegreenv1alpha1.WakeUpFinalizer}},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{WakeUpOnDeletion: getPtr(false)},
+		}
+		r := SleepInfoReconciler{Client: newClient(sleepInfo), WakeUpOnDeletion: true}
+
+		deleted, err := r.reconcileFinalizer(context.Background(), logr.Discard(), sleepInfo)
+		require.NoError(t, err)
+		require.False(t, deleted)
+
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.Empty(t, updated.Finalizers)
+	})
+
+	t.Run("wakes up the resources asleep before deletion", func(t *testing.T) {
+		now := metav1.Now()
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{
+			Name: "sleep", Namespace: namespace, Finalizers: []string{kubegreenv1alpha1.WakeUpFinalizer}, DeletionTimestamp: &now,
+		}}
+		recorder := record.NewFakeRecorder(10)
+		c := newClient(sleepInfo, deployment.DeepCopy(), secret(sleepOperation))
+		r := SleepInfoReconciler{Client: c, Log: logr.Discard(), Recorder: recorder}
+
+		deleted, err := r.reconcileFinalizer(context.Background(), logr.Discard(), sleepInfo)
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.Equal(t, int32(3), replicas(c))
+		require.True(t, apierrors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), &kubegreenv1alpha1.SleepInfo{})))
+		require.Equal(t, "Normal WakeUpOnDeletion deleted while asleep, waking up its resources", <-recorder.Events)
+		require.Equal(t, "Normal WakeUpSucceeded 1 resources woken up (Deployment: 1)", <-recorder.Events)
+	})
+
+	t.Run("does not wake up the resources already awake", func(t *testing.T) {
+		now := metav1.Now()
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{
+			Name: "sleep", Namespace: namespace, Finalizers: []string{kubegreenv1alpha1.WakeUpFinalizer}, DeletionTimestamp: &now,
+		}}
+		c := newClient(sleepInfo, deployment.DeepCopy(), secret(wakeUpOperation))
+		r := SleepInfoReconciler{Client: c, Log: logr.Discard()}
+
+		deleted, err := r.reconcileFinalizer(context.Background(), logr.Discard(), sleepInfo)
+		require.NoError(t, err)
+		require.True(t, deleted)
+		require.Equal(t, int32(0), replicas(c))
+		require.True(t, apierrors.IsNotFound(c.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), &kubegreenv1alpha1.SleepInfo{})))
+	})
+}
@@ -0,0 +1,54 @@
+package sleepinfo
+
+import (
+	"context"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/holidays"
+
+	"github.com/go-logr/logr"
+)
+
+// isHolidaySkipped returns true if the holiday policy of the SleepInfo skips the current operation:
+// skipSleep skips the sleep and forceSleep the wake up when today is in the holiday calendar, the
+// cluster one if the SleepInfo has none. When the calendar cannot be read the operation is executed
+// as usual.
+func (r *SleepInfoReconciler) isHolidaySkipped(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, data SleepInfoData, now time.Time) bool {
+	policy := sleepInfo.GetHolidayPolicy()
+	switch {
+	case policy == kubegreenv1alpha1.HolidayPolicySkipSleep && data.IsSleepOperation():
+	case policy == kubegreenv1alpha1.HolidayPolicyForceSleep && data.IsWakeUpOperation():
+	default:
+		return false
+	}
+
+	calendar := sleepInfo.Spec.HolidayCalendar
+	if calendar == nil {
+		calendar = r.HolidayCalendar
+	}
+	if calendar == nil {
+		log.Info("holiday policy without holiday calendar, running the schedule as usual", "policy", policy)
+		return false
+	}
+	timeZone := calendar.TimeZone
+	if timeZone == "" {
+		timeZone = sleepInfo.Spec.TimeZone
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		log.Error(err, "invalid holiday calendar time zone, using UTC", "timeZone", timeZone)
+		loc = time.UTC
+	}
+
+	loader := r.Holidays
+	if loader == nil {
+		loader = &holidays.Loader{Client: r.Client}
+	}
+	holidayDates, err := loader.Load(ctx, *calendar, sleepInfo.Namespace, now)
+	if err != nil {
+		log.Error(err, "unable to load holiday calendar, running the schedule as usual")
+		return false
+	}
+	return holidayDates.IsHoliday(now, loc)
+}
@@ -0,0 +1,172 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/holidays"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestIsHolidaySkipped(t *testing.T) {
+	testLogger := zap.New(zap.UseDevMode(true))
+	fakeClient := fake.NewClientBuilder().WithObjects(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "festivos", Namespace: "my-namespace"},
+		Data:       map[string]string{holidays.DatesKey: "2024-12-25\n"},
+	}).Build()
+	r := SleepInfoReconciler{
+		Log:      testLogger,
+		Holidays: &holidays.Loader{Client: fakeClient},
+	}
+
+	sleepData := SleepInfoData{CurrentOperationType: sleepOperation}
+	wakeUpData := SleepInfoData{CurrentOperationType: wakeUpOperation}
+	christmas := time.Date(2024, 12, 25, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar *kubegreenv1alpha1.HolidayCalendar
+		policy   kubegreenv1alpha1.HolidayPolicy
+		timeZone string
+		data     SleepInfoData
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "without calendar",
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			data:     sleepData,
+			now:      christmas,
+			expected: false,
+		},
+		{
+			name:     "ignore policy",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicyIgnore,
+			data:     sleepData,
+			now:      christmas,
+			expected: false,
+		},
+		{
+			name:     "skipSleep skips the sleep on holidays",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			data:     sleepData,
+			now:      christmas,
+			expected: true,
+		},
+		{
+			name:     "skipSleep runs the wake up on holidays",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			data:     wakeUpData,
+			now:      christmas,
+			expected: false,
+		},
+		{
+			name:     "forceSleep skips the wake up on holidays",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicyForceSleep,
+			data:     wakeUpData,
+			now:      christmas,
+			expected: true,
+		},
+		{
+			name:     "forceSleep runs the sleep on holidays",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicyForceSleep,
+			data:     sleepData,
+			now:      christmas,
+			expected: false,
+		},
+		{
+			name:     "runs on working days",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			data:     sleepData,
+			now:      christmas.AddDate(0, 0, 1),
+			expected: false,
+		},
+		{
+			name:     "uses the time zone of the SleepInfo",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			timeZone: "America/Bogota",
+			data:     sleepData,
+			now:      time.Date(2024, 12, 26, 3, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "the calendar time zone takes precedence",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos", TimeZone: "UTC"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			timeZone: "America/Bogota",
+			data:     sleepData,
+			now:      time.Date(2024, 12, 26, 3, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "runs the schedule if the calendar is missing",
+			calendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "not-exists"},
+			policy:   kubegreenv1alpha1.HolidayPolicySkipSleep,
+			data:     sleepData,
+			now:      christmas,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sleepInfo := &kubegreenv1alpha1.SleepInfo{
+				ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace"},
+				Spec: kubegreenv1alpha1.SleepInfoSpec{
+					TimeZone:        test.timeZone,
+					HolidayCalendar: test.calendar,
+					HolidayPolicy:   test.policy,
+				},
+			}
+			require.Equal(t, test.expected, r.isHolidaySkipped(context.Background(), testLogger, sleepInfo, test.data, test.now))
+		})
+	}
+
+	t.Run("cluster holiday calendar", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().WithObjects(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-holidays", Namespace: "kube-green"},
+			Data:       map[string]string{holidays.DatesKey: "2024-12-25\n"},
+		}, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "festivos", Namespace: "my-namespace"},
+			Data:       map[string]string{holidays.DatesKey: "2024-12-26\n"},
+		}).Build()
+		r := SleepInfoReconciler{
+			Holidays:        &holidays.Loader{Client: fakeClient},
+			HolidayCalendar: &kubegreenv1alpha1.HolidayCalendar{ConfigMap: "cluster-holidays", ConfigMapNamespace: "kube-green"},
+		}
+		sleepInfo := func(calendar *kubegreenv1alpha1.HolidayCalendar) *kubegreenv1alpha1.SleepInfo {
+			return &kubegreenv1alpha1.SleepInfo{
+				ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace"},
+				Spec: kubegreenv1alpha1.SleepInfoSpec{
+					HolidayCalendar: calendar,
+					HolidayPolicy:   kubegreenv1alpha1.HolidayPolicyForceSleep,
+				},
+			}
+		}
+
+		require.True(t, r.isHolidaySkipped(context.Background(), testLogger, sleepInfo(nil), wakeUpData, christmas))
+		require.False(t, r.isHolidaySkipped(context.Background(), testLogger, sleepInfo(nil), wakeUpData, christmas.AddDate(0, 0, 1)))
+
+		own := sleepInfo(&kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"})
+		require.False(t, r.isHolidaySkipped(context.Background(), testLogger, own, wakeUpData, christmas))
+		require.True(t, r.isHolidaySkipped(context.Background(), testLogger, own, wakeUpData, christmas.AddDate(0, 0, 1)))
+
+		ignored := sleepInfo(nil)
+		ignored.Spec.HolidayPolicy = ""
+		require.False(t, r.isHolidaySkipped(context.Background(), testLogger, ignored, wakeUpData, christmas))
+	})
+}
@@ -0,0 +1,230 @@
+package holidays
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DatesKey is the ConfigMap key listing the holidays
+	DatesKey = "holidays"
+
+	dateLayout = "2006-01-02"
+	// maxICSSize is the largest iCalendar feed read from a URL
+	maxICSSize = 4 << 20
+	// defaultCacheTTL is how long an iCalendar feed is reused before it is downloaded again
+	defaultCacheTTL = time.Hour
+	// defaultTimeout is the timeout of the iCalendar download
+	defaultTimeout = 10 * time.Second
+)
+
+// Calendar is a set of holiday dates
+type Calendar map[string]bool
+
+// IsHoliday returns true if the date of t in loc is a holiday
+func (c Calendar) IsHoliday(t time.Time, loc *time.Location) bool {
+	return c[t.In(loc).Format(dateLayout)]
+}
+
+// ParseDates parses one YYYY-MM-DD date per line. Text after a # and empty lines are ignored.
+func ParseDates(data string) (Calendar, error) {
+	calendar := Calendar{}
+	for i, line := range strings.Split(data, "\n") {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		date, err := time.Parse(dateLayout, line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday at line %d: %s is not a YYYY-MM-DD date", i+1, line)
+		}
+		calendar[date.Format(dateLayout)] = true
+	}
+	return calendar, nil
+}
+
+// ParseICS parses the events of an iCalendar feed: every day from DTSTART to DTEND is a holiday.
+// All-day events end the day before DTEND, as DTEND is exclusive, and an event without DTEND lasts
+// one day. Recurrence rules are not expanded.
+func ParseICS(data []byte) (Calendar, error) {
+	calendar := Calendar{}
+	var start, end string
+	inEvent := false
+	for _, line := range unfoldICSLines(string(data)) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end = true, "", ""
+			}
+		case "DTSTART":
+			start = value
+		case "DTEND":
+			end = value
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if err := addICSEvent(calendar, start, end); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return calendar, nil
+}
+
+func unfoldICSLines(data string) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxICSSize)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func addICSEvent(calendar Calendar, start, end string) error {
+	if start == "" {
+		return fmt.Errorf("invalid iCalendar event: DTSTART is missing")
+	}
+	first, allDay, err := parseICSDate(start)
+	if err != nil {
+		return err
+	}
+	last := first
+	if end != "" {
+		endDate, endAllDay, err := parseICSDate(end)
+		if err != nil {
+			return err
+		}
+		last = endDate
+		if endAllDay && allDay {
+			last = endDate.AddDate(0, 0, -1)
+		}
+	}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		calendar[day.Format(dateLayout)] = true
+	}
+	return nil
+}
+
+// parseICSDate returns the date of a DATE or DATE-TIME value and whether it is a DATE
+func parseICSDate(value string) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < len("20060102") {
+		return time.Time{}, false, fmt.Errorf("invalid iCalendar date %s", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid iCalendar date %s", value)
+	}
+	return date, len(value) == 8, nil
+}
+
+type cachedCalendar struct {
+	calendar  Calendar
+	fetchedAt time.Time
+}
+
+// Loader reads the holiday calendars of the SleepInfos. iCalendar feeds are cached for CacheTTL,
+// ConfigMaps are read on every use.
+type Loader struct {
+	Client     client.Reader
+	HTTPClient *http.Client
+	CacheTTL   time.Duration
+
+	mu    sync.Mutex
+	feeds map[string]cachedCalendar
+}
+
+// Load returns the holidays of calendar, whose ConfigMap defaults to namespace
+func (l *Loader) Load(ctx context.Context, calendar kubegreenv1alpha1.HolidayCalendar, namespace string, now time.Time) (Calendar, error) {
+	if calendar.URL != "" {
+		return l.loadURL(ctx, calendar.URL, now)
+	}
+	if calendar.ConfigMapNamespace != "" {
+		namespace = calendar.ConfigMapNamespace
+	}
+	configMap := &v1.ConfigMap{}
+	if err := l.Client.Get(ctx, client.ObjectKey{Name: calendar.ConfigMap, Namespace: namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("fails to get holiday ConfigMap %s/%s: %w", namespace, calendar.ConfigMap, err)
+	}
+	return ParseDates(configMap.Data[DatesKey])
+}
+
+func (l *Loader) loadURL(ctx context.Context, url string, now time.Time) (Calendar, error) {
+	ttl := l.CacheTTL
+	if ttl == 0 {
+		ttl = defaultCacheTTL
+	}
+	l.mu.Lock()
+	cached, ok := l.feeds[url]
+	l.mu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < ttl {
+		return cached.calendar, nil
+	}
+
+	calendar, err := l.fetch(ctx, url)
+	if err != nil {
+		if ok {
+			// Keep using the last calendar downloaded while the feed is unavailable
+			return cached.calendar, nil
+		}
+		return nil, err
+	}
+	l.mu.Lock()
+	if l.feeds == nil {
+		l.feeds = map[string]cachedCalendar{}
+	}
+	l.feeds[url] = cachedCalendar{calendar: calendar, fetchedAt: now}
+	l.mu.Unlock()
+	return calendar, nil
+}
+
+func (l *Loader) fetch(ctx context.Context, url string) (Calendar, error) {
+	httpClient := l.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid holiday calendar url %s: %w", url, err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fails to download holiday calendar %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fails to download holiday calendar %s: status %d", url, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxICSSize))
+	if err != nil {
+		return nil, fmt.Errorf("fails to read holiday calendar %s: %w", url, err)
+	}
+	return ParseICS(data)
+}
@@ -0,0 +1,131 @@
+package holidays
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const icsFeed = "BEGIN:VCALENDAR\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241226\r\n" +
+	"SUMMARY:Navidad\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241230\r\n" +
+	"DTEND;VALUE=DATE:\r\n" +
+	" 20250102\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20250106T000000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseDates(t *testing.T) {
+	t.Run("dates with comments", func(t *testing.T) {
+		calendar, err := ParseDates("# Festivos\n2024-12-25 # Navidad\n\n2025-01-01\n")
+		require.NoError(t, err)
+		require.Equal(t, Calendar{"2024-12-25": true, "2025-01-01": true}, calendar)
+	})
+
+	t.Run("fails with an invalid date", func(t *testing.T) {
+		_, err := ParseDates("2024-12-25\n25/12/2024")
+		require.EqualError(t, err, "invalid holiday at line 2: 25/12/2024 is not a YYYY-MM-DD date")
+	})
+}
+
+func TestParseICS(t *testing.T) {
+	t.Run("all-day, multi-day and date-time events", func(t *testing.T) {
+		calendar, err := ParseICS([]byte(icsFeed))
+		require.NoError(t, err)
+		require.Equal(t, Calendar{
+			"2024-12-25": true,
+			"2024-12-30": true,
+			"2024-12-31": true,
+			"2025-01-01": true,
+			"2025-01-06": true,
+		}, calendar)
+	})
+
+	t.Run("fails with an invalid date", func(t *testing.T) {
+		_, err := ParseICS([]byte("BEGIN:VEVENT\nDTSTART:2024\nEND:VEVENT\n"))
+		require.EqualError(t, err, "invalid iCalendar date 2024")
+	})
+}
+
+func TestIsHoliday(t *testing.T) {
+	calendar := Calendar{"2024-12-25": true}
+	bogota, err := time.LoadLocation("America/Bogota")
+	require.NoError(t, err)
+
+	// 2024-12-26 03:00 UTC is still the 25th in Bogota
+	now := time.Date(2024, 12, 26, 3, 0, 0, 0, time.UTC)
+	require.True(t, calendar.IsHoliday(now, bogota))
+	require.False(t, calendar.IsHoliday(now, time.UTC))
+}
+
+func TestLoader(t *testing.T) {
+	now := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("from a ConfigMap", func(t *testing.T) {
+		loader := &Loader{
+			Client: fake.NewClientBuilder().WithObjects(&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "festivos", Namespace: "kube-green"},
+				Data:       map[string]string{DatesKey: "2024-12-25"},
+			}).Build(),
+		}
+
+		calendar, err := loader.Load(context.Background(), kubegreenv1alpha1.HolidayCalendar{
+			ConfigMap:          "festivos",
+			ConfigMapNamespace: "kube-green",
+		}, "tenant-apps", now)
+		require.NoError(t, err)
+		require.Equal(t, Calendar{"2024-12-25": true}, calendar)
+
+		_, err = loader.Load(context.Background(), kubegreenv1alpha1.HolidayCalendar{ConfigMap: "festivos"}, "tenant-apps", now)
+		require.ErrorContains(t, err, "fails to get holiday ConfigMap tenant-apps/festivos")
+	})
+
+	t.Run("from a cached URL", func(t *testing.T) {
+		requests := 0
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(status)
+			fmt.Fprint(w, icsFeed)
+		}))
+		defer server.Close()
+
+		loader := &Loader{CacheTTL: time.Hour}
+		calendar := kubegreenv1alpha1.HolidayCalendar{URL: server.URL}
+
+		holidays, err := loader.Load(context.Background(), calendar, "tenant-apps", now)
+		require.NoError(t, err)
+		require.True(t, holidays["2024-12-25"])
+
+		_, err = loader.Load(context.Background(), calendar, "tenant-apps", now.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 1, requests)
+
+		// The last calendar is kept while the feed fails
+		status = http.StatusInternalServerError
+		holidays, err = loader.Load(context.Background(), calendar, "tenant-apps", now.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.True(t, holidays["2024-12-25"])
+		require.Equal(t, 2, requests)
+
+		_, err = (&Loader{}).Load(context.Background(), calendar, "tenant-apps", now)
+		require.EqualError(t, err, fmt.Sprintf("fails to download holiday calendar %s: status 500", server.URL))
+	})
+}
@@ -0,0 +1,389 @@
+package sleepinfo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// hookPollInterval is how often the Jobs of the running hooks are checked
+	hookPollInterval = 10 * time.Second
+
+	hookSleepInfoLabel = "kube-green.stratio.com/sleepinfo"
+	hookNameLabel      = "kube-green.stratio.com/hook"
+
+	// maxHookJobPrefixLength leaves room in the 63 characters of a Job name for the generated suffix
+	maxHookJobPrefixLength = 57
+)
+
+// hooksResult is the result of the hooks of an operation
+type hooksResult struct {
+	// pending is true while a hook Job is running
+	pending bool
+	// failed is the failed hook aborting the operation
+	failed *kubegreenv1alpha1.HookStatus
+	// failures are the hooks failed by this run of the hooks
+	failures []kubegreenv1alpha1.HookStatus
+}
+
+// runHooks runs the hooks of the operation in order, recording them in the status of the SleepInfo.
+// HTTP hooks are called at once, the Jobs of the hooks are created and checked on the next
+// reconciles while pending, as the hooks waiting for the resources woken up to be ready. A run
+// finished within the schedule delta is not run again, so the retries of its operation do not
+// repeat the hooks.
+func (r *SleepInfoReconciler) runHooks(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string, hooks []kubegreenv1alpha1.Hook, now time.Time) (hooksResult, error) {
+	var statuses, others []kubegreenv1alpha1.HookStatus
+	for _, status := range sleepInfo.Status.Hooks {
+		if status.OperationType == operationType {
+			statuses = append(statuses, status)
+		} else {
+			others = append(others, status)
+		}
+	}
+
+	if running := isHookRunning(sleepInfo, operationType); !running && r.isHookRunRecent(statuses, now) {
+		return hooksResult{failed: abortingHook(hooks, statuses)}, nil
+	} else if !running {
+		statuses = nil
+	}
+
+	result := hooksResult{}
+	for _, hook := range hooks {
+		status := findHookStatus(statuses, hook.Name)
+		if status == nil {
+			statuses = append(statuses, kubegreenv1alpha1.HookStatus{
+				Name:          hook.Name,
+				OperationType: operationType,
+				Phase:         kubegreenv1alpha1.HookPhasePending,
+				StartedAt:     metav1.NewTime(now),
+			})
+			status = &statuses[len(statuses)-1]
+		}
+		previousPhase := status.Phase
+		switch status.Phase {
+		case kubegreenv1alpha1.HookPhasePending:
+			if hook.WaitForReady && !r.isWokenUpReady(ctx, log, sleepInfo, hook, status.StartedAt.Time, now) {
+				break
+			}
+			*status = r.startHook(ctx, log, sleepInfo, operationType, hook, now)
+		case kubegreenv1alpha1.HookPhaseRunning:
+			r.checkHookJob(ctx, log, sleepInfo.Namespace, hook, status, now)
+		}
+		if status.Phase == kubegreenv1alpha1.HookPhasePending || status.Phase == kubegreenv1alpha1.HookPhaseRunning {
+			result.pending = true
+			break
+		}
+		if status.Phase == kubegreenv1alpha1.HookPhaseFailed && previousPhase != kubegreenv1alpha1.HookPhaseFailed {
+			result.failures = append(result.failures, *status)
+		}
+		if status.Phase == kubegreenv1alpha1.HookPhaseFailed && hook.IsAbortOnFailure() {
+			failed := *status
+			result.failed = &failed
+			break
+		}
+	}
+
+	sleepInfo.Status.Hooks = append(others, statuses...)
+	if err := r.Status().Update(ctx, sleepInfo); err != nil {
+		return hooksResult{}, fmt.Errorf("fails to update hooks status: %w", err)
+	}
+	return result, nil
+}
+
+// isHookRunRecent returns true if the hooks have finished within twice the schedule delta, the time
+// their operation is retried
+func (r *SleepInfoReconciler) isHookRunRecent(statuses []kubegreenv1alpha1.HookStatus, now time.Time) bool {
+	if len(statuses) == 0 {
+		return false
+	}
+	scheduleDelta := time.Duration(r.SleepDelta) * time.Second
+	for _, status := range statuses {
+		if status.FinishedAt == nil || now.Sub(status.FinishedAt.Time) > 2*scheduleDelta {
+			return false
+		}
+	}
+	return true
+}
+
+// startHook calls the HTTP hook, or creates the Job of the hook
+func (r *SleepInfoReconciler) startHook(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string, hook kubegreenv1alpha1.Hook, now time.Time) kubegreenv1alpha1.HookStatus {
+	status := kubegreenv1alpha1.HookStatus{
+		Name:          hook.Name,
+		OperationType: operationType,
+		Phase:         kubegreenv1alpha1.HookPhaseRunning,
+		StartedAt:     metav1.NewTime(now),
+	}
+	if hook.HTTP != nil {
+		if err := r.callHTTPHook(ctx, hook.HTTP, hook.GetTimeout()); err != nil {
+			log.Info("hook failed", "hook", hook.Name, "error", err.Error())
+			finishHook(&status, kubegreenv1alpha1.HookPhaseFailed, err.Error(), now)
+			return status
+		}
+		log.Info("hook succeeded", "hook", hook.Name)
+		finishHook(&status, kubegreenv1alpha1.HookPhaseSucceeded, "", now)
+		return status
+	}
+
+	job, err := r.createHookJob(ctx, sleepInfo, hook)
+	if err != nil {
+		log.Info("hook failed", "hook", hook.Name, "error", err.Error())
+		finishHook(&status, kubegreenv1alpha1.HookPhaseFailed, err.Error(), now)
+		return status
+	}
+	log.Info("hook job created", "hook", hook.Name, "job", job.Name)
+	status.Job = job.Name
+	return status
+}
+
+// callHTTPHook calls the URL of the hook, failing without a 2xx response. The HTTP hooks must be
+// enabled, and their host allowed, by the operator: the controller calls them from inside the cluster.
+func (r *SleepInfoReconciler) callHTTPHook(ctx context.Context, hook *kubegreenv1alpha1.HTTPHook, timeout time.Duration) error {
+	if !r.HTTPHooks {
+		return fmt.Errorf("HTTP hooks are disabled, enable them with --http-hooks")
+	}
+	hookURL, err := url.Parse(hook.URL)
+	if err != nil {
+		return err
+	}
+	if !isHookHostAllowed(hookURL.Hostname(), r.HTTPHookAllowedHosts) {
+		return fmt.Errorf("host %s is not allowed for HTTP hooks", hookURL.Hostname())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := hook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, method, hook.URL, strings.NewReader(hook.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "kube-green-hook")
+	for name, value := range hook.Headers {
+		req.Header.Set(name, value)
+	}
+
+	// Redirects are not followed, so an allowed host cannot send the call elsewhere
+	httpClient := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned unexpected status %d", method, hook.URL, resp.StatusCode)
+	}
+	return nil
+}
+
+// isHookHostAllowed returns true if the host matches one of the allowed hosts, exactly or, for the
+// entries starting with "*.", as a subdomain. Every host is allowed without allowed hosts.
+func isHookHostAllowed(host string, allowedHosts []string) bool {
+	if len(allowedHosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if suffix, ok := strings.CutPrefix(allowed, "*"); ok && strings.HasSuffix(host, suffix) && host != suffix[1:] {
+			return true
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// createHookJob creates the Job of the hook in the namespace of the SleepInfo, owned by it and
+// excluded from its sleep
+func (r *SleepInfoReconciler) createHookJob(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, hook kubegreenv1alpha1.Hook) (*batchv1.Job, error) {
+	template := hook.Job.DeepCopy()
+	job := &batchv1.Job{
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}
+	job.Name = ""
+	job.GenerateName = hookJobPrefix(sleepInfo.Name, hook.Name)
+	job.Namespace = sleepInfo.Namespace
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	job.Labels[hookSleepInfoLabel] = sleepInfo.Name
+	job.Labels[hookNameLabel] = hook.Name
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[kubegreenv1alpha1.ExcludeAnnotation] = "true"
+	job.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+			Kind:       "SleepInfo",
+			Name:       sleepInfo.Name,
+			UID:        sleepInfo.UID,
+		},
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyNever
+	}
+	if err := r.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("fails to create job of hook %s: %w", hook.Name, err)
+	}
+	return job, nil
+}
+
+// hookJobPrefix returns the generate name of the Jobs of a hook
+func hookJobPrefix(sleepInfoName, hookName string) string {
+	prefix := fmt.Sprintf("%s-%s", sleepInfoName, hookName)
+	if len(prefix) > maxHookJobPrefixLength {
+		prefix = strings.TrimRight(prefix[:maxHookJobPrefixLength], "-.")
+	}
+	return prefix + "-"
+}
+
+// checkHookJob updates the running hook with its Job: succeeded once complete, failed when the Job
+// fails, is deleted or exceeds the timeout of the hook
+func (r *SleepInfoReconciler) checkHookJob(ctx context.Context, log logr.Logger, namespace string, hook kubegreenv1alpha1.Hook, status *kubegreenv1alpha1.HookStatus, now time.Time) {
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: status.Job}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			finishHook(status, kubegreenv1alpha1.HookPhaseFailed, fmt.Sprintf("job %s not found", status.Job), now)
+			return
+		}
+		log.Error(err, "fails to get hook job", "hook", hook.Name, "job", status.Job)
+		return
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			log.Info("hook succeeded", "hook", hook.Name, "job", job.Name)
+			finishHook(status, kubegreenv1alpha1.HookPhaseSucceeded, "", now)
+			return
+		case batchv1.JobFailed:
+			log.Info("hook failed", "hook", hook.Name, "job", job.Name, "reason", condition.Reason)
+			finishHook(status, kubegreenv1alpha1.HookPhaseFailed, fmt.Sprintf("job %s failed: %s", job.Name, condition.Message), now)
+			return
+		}
+	}
+	if timeout := hook.GetTimeout(); now.Sub(status.StartedAt.Time) > timeout {
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "fails to delete hook job", "hook", hook.Name, "job", job.Name)
+		}
+		log.Info("hook timed out", "hook", hook.Name, "job", job.Name)
+		finishHook(status, kubegreenv1alpha1.HookPhaseFailed, fmt.Sprintf("job %s timed out after %s", job.Name, timeout), now)
+	}
+}
+
+func finishHook(status *kubegreenv1alpha1.HookStatus, phase kubegreenv1alpha1.HookPhase, message string, now time.Time) {
+	finishedAt := metav1.NewTime(now)
+	status.Phase = phase
+	status.Message = message
+	status.FinishedAt = &finishedAt
+}
+
+func findHookStatus(statuses []kubegreenv1alpha1.HookStatus, name string) *kubegreenv1alpha1.HookStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// isHookRunning returns true if a hook of the operation is running, or waiting to run
+func isHookRunning(sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string) bool {
+	for _, status := range sleepInfo.Status.Hooks {
+		if status.OperationType != operationType {
+			continue
+		}
+		if status.Phase == kubegreenv1alpha1.HookPhasePending || status.Phase == kubegreenv1alpha1.HookPhaseRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// abortingHook returns the first failed hook aborting its operation
+func abortingHook(hooks []kubegreenv1alpha1.Hook, statuses []kubegreenv1alpha1.HookStatus) *kubegreenv1alpha1.HookStatus {
+	for _, hook := range hooks {
+		status := findHookStatus(statuses, hook.Name)
+		if status != nil && status.Phase == kubegreenv1alpha1.HookPhaseFailed && hook.IsAbortOnFailure() {
+			failed := *status
+			return &failed
+		}
+	}
+	return nil
+}
+
+// isWokenUpReady returns true once the resources woken up by the SleepInfo are ready, or the hook has
+// waited for them since waitingSince for the ready timeout
+func (r *SleepInfoReconciler) isWokenUpReady(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, hook kubegreenv1alpha1.Hook, waitingSince, now time.Time) bool {
+	if deadline := waitingSince.Add(kubegreenv1alpha1.DefaultWakeStageReadyTimeout); !now.Before(deadline) {
+		log.Info("resources not ready before the timeout, running the hook", "hook", hook.Name)
+		return true
+	}
+	ready, err := r.areResourcesReady(ctx, sleepInfo, func(_ kubegreenv1alpha1.PatchTarget, res unstructured.Unstructured) bool {
+		return res.GetAnnotations()[kubegreenv1alpha1.ExcludeAnnotation] != "true"
+	})
+	if err != nil {
+		log.Error(err, "fails to check readiness of the resources woken up", "hook", hook.Name)
+		return false
+	}
+	if !ready {
+		log.Info("hook waiting for the resources woken up to be ready", "hook", hook.Name)
+	}
+	return ready
+}
+
+// runPostWakeHooks runs the post-wake hooks of the SleepInfo: when start is true at the end of a wake
+// up, otherwise only while they are running. It returns when to check them again, 0 when they are
+// done. The wake up is done, so errors are logged and the hooks are checked again later.
+func (r *SleepInfoReconciler) runPostWakeHooks(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, start bool, now time.Time) time.Duration {
+	if sleepInfo.IsDryRun() || len(sleepInfo.Spec.PostWakeHooks) == 0 || (!start && !isHookRunning(sleepInfo, wakeUpOperation)) {
+		return 0
+	}
+	// The status of the SleepInfo has been updated by the wake up
+	if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), sleepInfo); err != nil {
+		log.Error(err, "fails to run post-wake hooks")
+		return hookPollInterval
+	}
+	hooks, err := r.runHooks(ctx, log, sleepInfo, wakeUpOperation, sleepInfo.Spec.PostWakeHooks, now)
+	if err != nil {
+		log.Error(err, "fails to run post-wake hooks")
+		return hookPollInterval
+	}
+	events := r.operationEvents(sleepInfo, wakeUpOperation)
+	for _, failure := range hooks.failures {
+		events.hookFailed(failure, false)
+	}
+	if hooks.pending {
+		return hookPollInterval
+	}
+	return 0
+}
@@ -0,0 +1,389 @@
+package sleepinfo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRunHooks(t *testing.T) {
+	namespace := "my-namespace"
+	now := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	newReconciler := func(sleepInfo *kubegreenv1alpha1.SleepInfo, objects ...client.Object) (SleepInfoReconciler, client.Client) {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(restMapper).
+			WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}).
+			WithObjects(append(objects, sleepInfo)...).
+			Build()
+		return SleepInfoReconciler{Client: fakeClient, SleepDelta: 60, ManagerName: testFieldManagerName, HTTPHooks: true}, fakeClient
+	}
+	newSleepInfo := func(hooks ...kubegreenv1alpha1.Hook) *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace, UID: "sleep-uid"},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{SleepTime: "20:00", PreSleepHooks: hooks},
+		}
+	}
+	httpHook := func(name, url string) kubegreenv1alpha1.Hook {
+		return kubegreenv1alpha1.Hook{Name: name, HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}}
+	}
+	jobHook := func(name string) kubegreenv1alpha1.Hook {
+		return kubegreenv1alpha1.Hook{Name: name, Timeout: "5m", Job: &batchv1.JobTemplateSpec{
+			Spec: batchv1.JobSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "checkpoint", Image: "busybox"}},
+			}}},
+		}}
+	}
+	updatedHooks := func(t *testing.T, c client.Client) []kubegreenv1alpha1.HookStatus {
+		t.Helper()
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "sleep"}, updated))
+		return updated.Status.Hooks
+	}
+
+	t.Run("calls the HTTP hooks in order", func(t *testing.T) {
+		var calls []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			calls = append(calls, req.Method+" "+req.URL.Path+" "+req.Header.Get("X-Token")+" "+string(body))
+		}))
+		defer server.Close()
+		flush := httpHook("flush", server.URL+"/flush")
+		flush.HTTP.Headers = map[string]string{"X-Token": "secret"}
+		flush.HTTP.Body = `{"reason":"sleep"}`
+		checkpoint := httpHook("checkpoint", server.URL+"/checkpoint")
+		checkpoint.HTTP.Method = http.MethodPut
+		sleepInfo := newSleepInfo(flush, checkpoint)
+		r, c := newReconciler(sleepInfo)
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.Equal(t, hooksResult{}, result)
+		require.Equal(t, []string{`POST /flush secret {"reason":"sleep"}`, "PUT /checkpoint  "}, calls)
+
+		hooks := updatedHooks(t, c)
+		require.Len(t, hooks, 2)
+		for _, hook := range hooks {
+			require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, hook.Phase)
+			require.Equal(t, sleepOperation, hook.OperationType)
+			require.NotNil(t, hook.FinishedAt)
+		}
+	})
+
+	t.Run("a failed hook aborts the operation", func(t *testing.T) {
+		called := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			called++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		sleepInfo := newSleepInfo(httpHook("flush", server.URL), httpHook("checkpoint", server.URL))
+		r, c := newReconciler(sleepInfo)
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.NotNil(t, result.failed)
+		require.Equal(t, "flush", result.failed.Name)
+		require.Contains(t, result.failed.Message, "returned unexpected status 503")
+		require.Equal(t, []kubegreenv1alpha1.HookStatus{*result.failed}, result.failures)
+		require.Equal(t, 1, called)
+		require.Len(t, updatedHooks(t, c), 1)
+
+		t.Run("and is not run again within the schedule delta", func(t *testing.T) {
+			result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.Equal(t, "flush", result.failed.Name)
+			require.Empty(t, result.failures)
+			require.Equal(t, 1, called)
+		})
+
+		t.Run("but on the next operation", func(t *testing.T) {
+			_, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(24*time.Hour))
+			require.NoError(t, err)
+			require.Equal(t, 2, called)
+		})
+	})
+
+	t.Run("HTTP hooks fail when disabled", func(t *testing.T) {
+		sleepInfo := newSleepInfo(httpHook("flush", "http://cache.my-namespace.svc/flush"))
+		r, _ := newReconciler(sleepInfo)
+		r.HTTPHooks = false
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.Equal(t, "HTTP hooks are disabled, enable them with --http-hooks", result.failed.Message)
+	})
+
+	t.Run("HTTP hooks only call the allowed hosts", func(t *testing.T) {
+		sleepInfo := newSleepInfo(httpHook("flush", "http://169.254.169.254/latest/meta-data"))
+		r, _ := newReconciler(sleepInfo)
+		r.HTTPHookAllowedHosts = []string{"*.svc.cluster.local"}
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.Equal(t, "host 169.254.169.254 is not allowed for HTTP hooks", result.failed.Message)
+	})
+
+	t.Run("HTTP hooks do not follow redirects", func(t *testing.T) {
+		redirected := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Path == "/elsewhere" {
+				redirected = true
+				return
+			}
+			http.Redirect(w, req, "/elsewhere", http.StatusFound)
+		}))
+		defer server.Close()
+		sleepInfo := newSleepInfo(httpHook("flush", server.URL+"/flush"))
+		r, _ := newReconciler(sleepInfo)
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.Contains(t, result.failed.Message, "returned unexpected status 302")
+		require.False(t, redirected)
+	})
+
+	t.Run("a failed hook continuing the operation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if strings.HasSuffix(req.URL.Path, "/flush") {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+		defer server.Close()
+		flush := httpHook("flush", server.URL+"/flush")
+		flush.FailurePolicy = kubegreenv1alpha1.HookFailurePolicyContinue
+		sleepInfo := newSleepInfo(flush, httpHook("checkpoint", server.URL+"/checkpoint"))
+		r, c := newReconciler(sleepInfo)
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.Nil(t, result.failed)
+		require.Len(t, result.failures, 1)
+		hooks := updatedHooks(t, c)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseFailed, hooks[0].Phase)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, hooks[1].Phase)
+	})
+
+	t.Run("waits for the job of the hook", func(t *testing.T) {
+		sleepInfo := newSleepInfo(jobHook("checkpoint"))
+		r, c := newReconciler(sleepInfo)
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		require.True(t, result.pending)
+		require.True(t, isHookRunning(sleepInfo, sleepOperation))
+
+		hooks := updatedHooks(t, c)
+		require.Len(t, hooks, 1)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseRunning, hooks[0].Phase)
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: hooks[0].Job}, job))
+		require.True(t, strings.HasPrefix(job.Name, "sleep-checkpoint-"))
+		require.Equal(t, map[string]string{hookSleepInfoLabel: "sleep", hookNameLabel: "checkpoint"}, job.Labels)
+		require.Equal(t, "true", job.Annotations[kubegreenv1alpha1.ExcludeAnnotation])
+		require.Equal(t, "sleep", job.OwnerReferences[0].Name)
+		require.Equal(t, v1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+
+		result, err = r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, result.pending)
+
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+		require.NoError(t, c.Status().Update(context.Background(), job))
+		result, err = r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, hooksResult{}, result)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, updatedHooks(t, c)[0].Phase)
+	})
+
+	t.Run("a failed job fails the hook", func(t *testing.T) {
+		sleepInfo := newSleepInfo(jobHook("checkpoint"))
+		r, c := newReconciler(sleepInfo)
+		_, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+
+		job := &batchv1.Job{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: updatedHooks(t, c)[0].Job}, job))
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+		require.NoError(t, c.Status().Update(context.Background(), job))
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, result.failed)
+		require.Equal(t, "job "+job.Name+" failed: BackoffLimitExceeded", result.failed.Message)
+	})
+
+	t.Run("a job exceeding the timeout is deleted", func(t *testing.T) {
+		sleepInfo := newSleepInfo(jobHook("checkpoint"))
+		r, c := newReconciler(sleepInfo)
+		_, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		jobName := updatedHooks(t, c)[0].Job
+
+		result, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now.Add(6*time.Minute))
+		require.NoError(t, err)
+		require.NotNil(t, result.failed)
+		require.Equal(t, "job "+jobName+" timed out after 5m0s", result.failed.Message)
+		err = c.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: jobName}, &batchv1.Job{})
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("keeps the hooks of the other operation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+		defer server.Close()
+		sleepInfo := newSleepInfo(httpHook("flush", server.URL))
+		wakeUpHook := kubegreenv1alpha1.HookStatus{Name: "warm-up", OperationType: wakeUpOperation, Phase: kubegreenv1alpha1.HookPhaseSucceeded, StartedAt: metav1.NewTime(now.Add(-12 * time.Hour))}
+		sleepInfo.Status.Hooks = []kubegreenv1alpha1.HookStatus{
+			wakeUpHook,
+			{Name: "flush", OperationType: sleepOperation, Phase: kubegreenv1alpha1.HookPhaseFailed, StartedAt: metav1.NewTime(now.Add(-24 * time.Hour))},
+		}
+		r, c := newReconciler(sleepInfo)
+
+		_, err := r.runHooks(context.Background(), logr.Discard(), sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		require.NoError(t, err)
+		hooks := updatedHooks(t, c)
+		require.Len(t, hooks, 2)
+		require.Equal(t, "warm-up", hooks[0].Name)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, hooks[1].Phase)
+		require.Equal(t, now.Unix(), hooks[1].StartedAt.Unix())
+	})
+}
+
+func TestPostWakeHooks(t *testing.T) {
+	namespace := "my-namespace"
+	now := time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	deployment := func(readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(2))},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: readyReplicas},
+		}
+	}
+	setup := func(t *testing.T, hook kubegreenv1alpha1.Hook, objects ...client.Object) (SleepInfoReconciler, *kubegreenv1alpha1.SleepInfo, *record.FakeRecorder) {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{SleepTime: "20:00", WakeUpTime: "08:00", PostWakeHooks: []kubegreenv1alpha1.Hook{hook}},
+		}
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(restMapper).
+			WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}, &appsv1.Deployment{}).
+			WithObjects(append(objects, sleepInfo)...).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		return SleepInfoReconciler{Client: fakeClient, SleepDelta: 60, ManagerName: testFieldManagerName, Recorder: recorder, HTTPHooks: true}, sleepInfo, recorder
+	}
+	newServer := func(t *testing.T, status int) (string, *int) {
+		called := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			called++
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL, &called
+	}
+
+	t.Run("run at the end of the wake up", func(t *testing.T) {
+		url, called := newServer(t, http.StatusOK)
+		r, sleepInfo, _ := setup(t, kubegreenv1alpha1.Hook{Name: "warm-up", HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}})
+
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, false, now))
+		require.Zero(t, *called)
+
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, true, now))
+		require.Equal(t, 1, *called)
+		require.Len(t, sleepInfo.Status.Hooks, 1)
+		require.Equal(t, wakeUpOperation, sleepInfo.Status.Hooks[0].OperationType)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, sleepInfo.Status.Hooks[0].Phase)
+	})
+
+	t.Run("a failed hook is recorded", func(t *testing.T) {
+		url, _ := newServer(t, http.StatusInternalServerError)
+		r, sleepInfo, recorder := setup(t, kubegreenv1alpha1.Hook{Name: "smoke-test", HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}})
+
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, true, now))
+		require.Equal(t, kubegreenv1alpha1.HookPhaseFailed, sleepInfo.Status.Hooks[0].Phase)
+		require.Len(t, recorder.Events, 1)
+		require.Equal(t, "Warning WakeUpHookFailed hook smoke-test of wake up failed: POST "+url+" returned unexpected status 500", <-recorder.Events)
+	})
+
+	t.Run("waits for the resources woken up to be ready", func(t *testing.T) {
+		url, called := newServer(t, http.StatusOK)
+		r, sleepInfo, _ := setup(t, kubegreenv1alpha1.Hook{Name: "warm-up", WaitForReady: true, HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}}, deployment(0))
+
+		require.Equal(t, hookPollInterval, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, true, now))
+		require.Zero(t, *called)
+		require.Equal(t, kubegreenv1alpha1.HookPhasePending, sleepInfo.Status.Hooks[0].Phase)
+
+		require.NoError(t, r.Status().Update(context.Background(), deployment(2)))
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, false, now.Add(time.Minute)))
+		require.Equal(t, 1, *called)
+		require.Equal(t, kubegreenv1alpha1.HookPhaseSucceeded, sleepInfo.Status.Hooks[0].Phase)
+	})
+
+	t.Run("runs anyway once the ready timeout is over", func(t *testing.T) {
+		url, called := newServer(t, http.StatusOK)
+		r, sleepInfo, _ := setup(t, kubegreenv1alpha1.Hook{Name: "warm-up", WaitForReady: true, HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}}, deployment(0))
+
+		require.Equal(t, hookPollInterval, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, true, now))
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, false, now.Add(kubegreenv1alpha1.DefaultWakeStageReadyTimeout)))
+		require.Equal(t, 1, *called)
+	})
+
+	t.Run("not run by dry runs", func(t *testing.T) {
+		url, called := newServer(t, http.StatusOK)
+		r, sleepInfo, _ := setup(t, kubegreenv1alpha1.Hook{Name: "warm-up", HTTP: &kubegreenv1alpha1.HTTPHook{URL: url}})
+		sleepInfo.Spec.DryRun = getPtr(true)
+
+		require.Zero(t, r.runPostWakeHooks(context.Background(), logr.Discard(), sleepInfo, true, now))
+		require.Zero(t, *called)
+		require.Empty(t, sleepInfo.Status.Hooks)
+	})
+}
+
+func TestHookJobPrefix(t *testing.T) {
+	require.Equal(t, "sleep-checkpoint-", hookJobPrefix("sleep", "checkpoint"))
+	prefix := hookJobPrefix(strings.Repeat("a", 40), strings.Repeat("b", 40))
+	require.Len(t, prefix, maxHookJobPrefixLength+1)
+}
+
+func TestIsHookHostAllowed(t *testing.T) {
+	allowed := []string{"hooks.example.com", "*.svc.cluster.local"}
+	require.True(t, isHookHostAllowed("anything", nil))
+	require.True(t, isHookHostAllowed("hooks.example.com", allowed))
+	require.True(t, isHookHostAllowed("Cache.My-Namespace.svc.cluster.local", allowed))
+	require.False(t, isHookHostAllowed("svc.cluster.local", allowed))
+	require.False(t, isHookHostAllowed("evil.example.com", allowed))
+	require.False(t, isHookHostAllowed("hooks.example.com.evil.io", allowed))
+}
@@ -0,0 +1,14 @@
+package jsonpatch
+
+import (
+	"github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/patcher"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// elasticsearchPatcher returns the patcher scaling all the nodeSets of an Elasticsearch cluster
+func elasticsearchPatcher(res unstructured.Unstructured) (*patcher.Patcher, error) {
+	nodeSets, _, _ := unstructured.NestedSlice(res.Object, "spec", "nodeSets")
+	return patcher.New([]byte(v1alpha1.NodeSetsPatch(len(nodeSets))))
+}
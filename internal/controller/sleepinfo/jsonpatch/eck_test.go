@@ -0,0 +1,61 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestElasticsearchPatcher(t *testing.T) {
+	elasticsearch := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "elasticsearch.k8s.elastic.co/v1",
+		"kind":       "Elasticsearch",
+		"metadata":   map[string]interface{}{"name": "logs"},
+		"spec": map[string]interface{}{
+			"nodeSets": []interface{}{
+				map[string]interface{}{"name": "masters", "count": int64(3)},
+				map[string]interface{}{"name": "data", "count": int64(2)},
+			},
+		},
+	}}
+	original, err := json.Marshal(elasticsearch.Object)
+	require.NoError(t, err)
+
+	t.Run("scales all the nodeSets", func(t *testing.T) {
+		resource := genericResource{patchData: v1alpha1.ElasticsearchPatch}
+
+		p, err := resource.patcherFor(nil, elasticsearch)
+		require.NoError(t, err)
+		patched, err := p.Exec(original)
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{
+			"apiVersion": "elasticsearch.k8s.elastic.co/v1",
+			"kind": "Elasticsearch",
+			"metadata": {"name": "logs"},
+			"spec": {"nodeSets": [{"name": "masters", "count": 0}, {"name": "data", "count": 0}]}
+		}`, string(patched))
+	})
+
+	t.Run("uses the sleep patch annotation first", func(t *testing.T) {
+		resource := genericResource{patchData: v1alpha1.ElasticsearchPatch}
+		annotated := *elasticsearch.DeepCopy()
+		annotated.SetAnnotations(map[string]string{v1alpha1.SleepPatchAnnotation: `[{"op": "replace", "path": "/spec/nodeSets/1/count", "value": 0}]`})
+
+		p, err := resource.patcherFor(nil, annotated)
+		require.NoError(t, err)
+		patched, err := p.Exec(original)
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{
+			"apiVersion": "elasticsearch.k8s.elastic.co/v1",
+			"kind": "Elasticsearch",
+			"metadata": {"name": "logs"},
+			"spec": {"nodeSets": [{"name": "masters", "count": 3}, {"name": "data", "count": 0}]}
+		}`, string(patched))
+	})
+}
@@ -0,0 +1,72 @@
+package jsonpatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSleepExcludeAnnotation(t *testing.T) {
+	namespace := "my-namespace"
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	replicas := int32(3)
+	excluded := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "database",
+			Namespace:   namespace,
+			Annotations: map[string]string{v1alpha1.ExcludeAnnotation: "True"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	notExcluded := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   namespace,
+			Annotations: map[string]string{v1alpha1.ExcludeAnnotation: "false"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(restMapper).WithObjects(excluded, notExcluded).Build()
+	sleepInfo := &v1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+	}
+
+	failures := []string{}
+	resources, err := NewResources(context.Background(), resource.ResourceClient{
+		Client:           c,
+		SleepInfo:        sleepInfo,
+		Log:              logr.Discard(),
+		FieldManagerName: "kube-green",
+		Failed: func(res unstructured.Unstructured, reason string) {
+			failures = append(failures, reason)
+		},
+	}, namespace, map[string]RestorePatches{}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, resources.Sleep(context.Background()))
+	require.Empty(t, failures)
+
+	updated := &appsv1.Deployment{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(excluded), updated))
+	require.Equal(t, int32(3), *updated.Spec.Replicas)
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(notExcluded), updated))
+	require.Equal(t, int32(0), *updated.Spec.Replicas)
+
+	restorePatches, err := resources.GetOriginalInfoToSave()
+	require.NoError(t, err)
+	require.NotContains(t, string(restorePatches), "database")
+}
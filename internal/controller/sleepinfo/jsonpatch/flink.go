@@ -0,0 +1,28 @@
+package jsonpatch
+
+import (
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// keepFlinkJobs drops the FlinkDeployments without a job, e.g. the session clusters, which have
+// nothing to suspend. The FlinkDeployments already slept are kept to be resumed.
+func (g managedResources) keepFlinkJobs() {
+	flinkDeployments, ok := g.resMapping[v1alpha1.FlinkDeploymentTarget]
+	if !ok {
+		return
+	}
+
+	kept := []unstructured.Unstructured{}
+	for _, flinkDeployment := range flinkDeployments.data {
+		_, hasJob, _ := unstructured.NestedMap(flinkDeployment.Object, "spec", "job")
+		if _, isSlept := flinkDeployments.restorePatches[flinkDeployment.GetName()]; isSlept || hasJob {
+			kept = append(kept, flinkDeployment)
+		}
+	}
+	flinkDeployments.data = kept
+	if len(kept) == 0 {
+		delete(g.resMapping, v1alpha1.FlinkDeploymentTarget)
+	}
+}
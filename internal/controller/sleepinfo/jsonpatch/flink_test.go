@@ -0,0 +1,65 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKeepFlinkJobs(t *testing.T) {
+	flinkDeployment := func(name string, spec map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "flink.apache.org/v1beta1",
+			"kind":       "FlinkDeployment",
+			"metadata":   map[string]interface{}{"name": name},
+			"spec":       spec,
+		}}
+	}
+	applicationSpec := map[string]interface{}{
+		"job": map[string]interface{}{"jarURI": "local:///opt/flink/job.jar", "state": "running"},
+	}
+
+	t.Run("keeps the deployments with a job", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.FlinkDeploymentTarget: {
+					restorePatches: RestorePatches{"slept": `{"spec":{"job":{"state":"running"}}}`},
+					data: []unstructured.Unstructured{
+						flinkDeployment("application", applicationSpec),
+						flinkDeployment("session", map[string]interface{}{"flinkVersion": "v1_18"}),
+						flinkDeployment("slept", map[string]interface{}{}),
+					},
+				},
+			},
+		}
+
+		resources.keepFlinkJobs()
+
+		names := []string{}
+		for _, item := range resources.resMapping[v1alpha1.FlinkDeploymentTarget].data {
+			names = append(names, item.GetName())
+		}
+		require.Equal(t, []string{"application", "slept"}, names)
+	})
+
+	t.Run("removes the target without jobs to suspend", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.FlinkDeploymentTarget: {
+					restorePatches: RestorePatches{},
+					data:           []unstructured.Unstructured{flinkDeployment("session", map[string]interface{}{})},
+				},
+			},
+		}
+
+		resources.keepFlinkJobs()
+
+		require.NotContains(t, resources.resMapping, v1alpha1.FlinkDeploymentTarget)
+	})
+}
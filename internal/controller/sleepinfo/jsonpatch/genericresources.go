@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+	"github.com/kube-green/kube-green/internal/patcher"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,6 +43,90 @@ func newGenericResource(res resource.ResourceClient, patchData v1alpha1.Patch, r
 	}
 }
 
+// patcherFor returns the patcher of a resource: the patch of its sleep patch annotation, otherwise
+// targetPatcher, unless the SleepInfo keeps some replicas of the resource during sleep or the
+// resource is an Elasticsearch cluster, whose patch depends on its nodeSets.
+func (g genericResource) patcherFor(targetPatcher *patcher.Patcher, res unstructured.Unstructured) (*patcher.Patcher, error) {
+	if sleepPatch := res.GetAnnotations()[v1alpha1.SleepPatchAnnotation]; sleepPatch != "" {
+		return patcher.New([]byte(sleepPatch))
+	}
+	if g.patchData == v1alpha1.ElasticsearchPatch {
+		return elasticsearchPatcher(res)
+	}
+	patch := g.SleepInfo.GetResourcePatch(g.patchData, res.GetName(), res.GetLabels())
+	if patch == g.patchData {
+		return targetPatcher, nil
+	}
+	return patcher.New([]byte(patch.Patch))
+}
+
+// patched reports a resource slept or woken up to the Patched hook of the client
+func (g genericResource) patched(res unstructured.Unstructured) {
+	if g.Patched != nil {
+		g.Patched(res)
+	}
+}
+
+// failed reports a resource not slept or woken up because of an error to the Failed and
+// OperationError hooks of the client
+func (g genericResource) failed(res unstructured.Unstructured, reason string) {
+	if g.Failed != nil {
+		g.Failed(res, reason)
+	}
+	g.operationError(res, resource.ErrorReasonPatchFailed)
+}
+
+// drifted reports a resource not woken up because it changed since the sleep to the Failed and
+// OperationError hooks of the client
+func (g genericResource) drifted(res unstructured.Unstructured, reason string) {
+	if g.Failed != nil {
+		g.Failed(res, reason)
+	}
+	g.operationError(res, resource.ErrorReasonDrift)
+}
+
+// missingRestorePatch reports a slept resource not woken up because its restore patch is missing to
+// the OperationError hook of the client
+func (g genericResource) missingRestorePatch(res unstructured.Unstructured) {
+	g.operationError(res, resource.ErrorReasonMissingRestorePatch)
+}
+
+// operationError reports a resource to the OperationError hook of the client
+func (g genericResource) operationError(res unstructured.Unstructured, reason string) {
+	if g.OperationError != nil {
+		g.OperationError(res, reason)
+	}
+}
+
+// targetDone reports the time taken by the target since start to the TargetDone hook of the client
+func (g genericResource) targetDone(start time.Time) {
+	if g.TargetDone != nil {
+		g.TargetDone(g.patchData.Target, time.Since(start))
+	}
+}
+
+// ignoresOwnerReferences returns true if the resource is patched even when it is managed by
+// another controller, from its target or its annotation
+func (g genericResource) ignoresOwnerReferences(res unstructured.Unstructured) bool {
+	return g.IgnoreOwnerTargets[g.patchData.Target] || strings.EqualFold(res.GetAnnotations()[v1alpha1.IgnoreOwnerReferencesAnnotation], "true")
+}
+
+// isManagedByAnotherController returns true if the resource is managed by another controller, which
+// handles its sleep. The CRDs of the top level resources are patched anyway, as the targets and
+// resources ignoring their ownerReferences.
+func (g genericResource) isManagedByAnotherController(res unstructured.Unstructured) bool {
+	switch res.GetKind() {
+	case "PgBouncer", "PgCluster", "HDFSCluster", "OsCluster", "OsDashboards", "KafkaCluster", "Elasticsearch", "Kibana":
+		return false
+	}
+	return metav1.GetControllerOfNoCopy(&res) != nil && !g.ignoresOwnerReferences(res)
+}
+
+// isExcludedByAnnotation returns true if the resource is annotated to be never put to sleep
+func isExcludedByAnnotation(res unstructured.Unstructured) bool {
+	return strings.EqualFold(res.GetAnnotations()[v1alpha1.ExcludeAnnotation], "true")
+}
+
 func (c genericResource) getListByNamespace(ctx context.Context, namespace string, target v1alpha1.PatchTarget) ([]unstructured.Unstructured, error) {
 	// TODO: manage optional version. So it will be possible to manage also multiple
 	// version of the same resource
@@ -77,9 +165,15 @@ func (g genericResource) getListOptions(namespace string, target v1alpha1.PatchT
 	includeRef := g.SleepInfo.GetIncludeRef()
 	excludeRef := g.SleepInfo.GetExcludeRef()
 	fieldsToInclude := getFieldToInclude(includeRef, target)
-	labelsToInclude := getLabelsToInclude(includeRef)
+	labelsToInclude, err := getLabelsToInclude(includeRef)
+	if err != nil {
+		return nil, err
+	}
 	fieldsToExclude := getFieldToExclude(excludeRef, target)
-	labelsToExclude := getLabelsToExclude(excludeRef)
+	labelsToExclude, err := getLabelsToExclude(excludeRef)
+	if err != nil {
+		return nil, err
+	}
 
 	// Combine fields to include and exclude into a single field selector
 	var fieldSelectors []string
@@ -145,22 +239,60 @@ func matchPatchTargetAndFilterRef(target v1alpha1.PatchTarget, filterRef v1alpha
 	return strings.HasPrefix(filterRef.APIVersion, fmt.Sprintf("%s/", target.Group)) && filterRef.Kind == target.Kind
 }
 
-func getLabelsToExclude(excludeRef []v1alpha1.FilterRef) []string {
+// negatedOperators maps the operator of an excluded requirement to the one selecting the resources to keep
+var negatedOperators = map[metav1.LabelSelectorOperator]selection.Operator{
+	metav1.LabelSelectorOpIn:           selection.NotIn,
+	metav1.LabelSelectorOpNotIn:        selection.In,
+	metav1.LabelSelectorOpExists:       selection.DoesNotExist,
+	metav1.LabelSelectorOpDoesNotExist: selection.Exists,
+}
+
+// selectionOperators maps the operator of an included requirement to the one selecting the resources to keep
+var selectionOperators = map[metav1.LabelSelectorOperator]selection.Operator{
+	metav1.LabelSelectorOpIn:           selection.In,
+	metav1.LabelSelectorOpNotIn:        selection.NotIn,
+	metav1.LabelSelectorOpExists:       selection.Exists,
+	metav1.LabelSelectorOpDoesNotExist: selection.DoesNotExist,
+}
+
+func getLabelsToExclude(excludeRef []v1alpha1.FilterRef) ([]string, error) {
 	labelsToExclude := []string{}
 	for _, exclude := range excludeRef {
 		for k, v := range exclude.MatchLabels {
 			labelsToExclude = append(labelsToExclude, fmt.Sprintf("%s!=%s", k, v))
 		}
+		for _, expression := range exclude.MatchExpressions {
+			operator, ok := negatedOperators[expression.Operator]
+			if !ok {
+				return nil, fmt.Errorf("invalid operator %q in excludeRef matchExpressions", expression.Operator)
+			}
+			requirement, err := labels.NewRequirement(expression.Key, operator, expression.Values)
+			if err != nil {
+				return nil, err
+			}
+			labelsToExclude = append(labelsToExclude, requirement.String())
+		}
 	}
-	return labelsToExclude
+	return labelsToExclude, nil
 }
 
-func getLabelsToInclude(includeRef []v1alpha1.FilterRef) []string {
+func getLabelsToInclude(includeRef []v1alpha1.FilterRef) ([]string, error) {
 	labelsToInclude := []string{}
 	for _, include := range includeRef {
 		for k, v := range include.MatchLabels {
 			labelsToInclude = append(labelsToInclude, fmt.Sprintf("%s==%s", k, v))
 		}
+		for _, expression := range include.MatchExpressions {
+			operator, ok := selectionOperators[expression.Operator]
+			if !ok {
+				return nil, fmt.Errorf("invalid operator %q in includeRef matchExpressions", expression.Operator)
+			}
+			requirement, err := labels.NewRequirement(expression.Key, operator, expression.Values)
+			if err != nil {
+				return nil, err
+			}
+			labelsToInclude = append(labelsToInclude, requirement.String())
+		}
 	}
-	return labelsToInclude
+	return labelsToInclude, nil
 }
@@ -0,0 +1,42 @@
+package jsonpatch
+
+import (
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// keepRunningJobs drops the Jobs already finished, since suspending them has no effect. The Jobs
+// already suspended are kept to be resumed.
+func (g managedResources) keepRunningJobs() {
+	jobs, ok := g.resMapping[v1alpha1.JobTarget]
+	if !ok {
+		return
+	}
+
+	kept := []unstructured.Unstructured{}
+	for _, job := range jobs.data {
+		if _, isSlept := jobs.restorePatches[job.GetName()]; isSlept || !isJobFinished(job) {
+			kept = append(kept, job)
+		}
+	}
+	jobs.data = kept
+	if len(kept) == 0 {
+		delete(g.resMapping, v1alpha1.JobTarget)
+	}
+}
+
+// isJobFinished returns true if the Job has the Complete or Failed condition
+func isJobFinished(job unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(job.Object, "status", "conditions")
+	for _, item := range conditions {
+		condition, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if (condition["type"] == "Complete" || condition["type"] == "Failed") && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
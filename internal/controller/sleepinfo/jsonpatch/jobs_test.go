@@ -0,0 +1,70 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKeepRunningJobs(t *testing.T) {
+	job := func(name string, conditions ...map[string]interface{}) unstructured.Unstructured {
+		items := []interface{}{}
+		for _, condition := range conditions {
+			items = append(items, condition)
+		}
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "batch/v1",
+			"kind":       "Job",
+			"metadata":   map[string]interface{}{"name": name},
+			"status":     map[string]interface{}{"conditions": items},
+		}}
+	}
+
+	t.Run("keeps running and suspended jobs", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.JobTarget: {
+					restorePatches: RestorePatches{"suspended": `{"spec":{"suspend":null}}`},
+					data: []unstructured.Unstructured{
+						job("running"),
+						job("suspended", map[string]interface{}{"type": "Suspended", "status": "True"}),
+						job("completed", map[string]interface{}{"type": "Complete", "status": "True"}),
+						job("failed", map[string]interface{}{"type": "Failed", "status": "True"}),
+						job("not-failed", map[string]interface{}{"type": "Failed", "status": "False"}),
+					},
+				},
+			},
+		}
+
+		resources.keepRunningJobs()
+
+		names := []string{}
+		for _, item := range resources.resMapping[v1alpha1.JobTarget].data {
+			names = append(names, item.GetName())
+		}
+		require.Equal(t, []string{"running", "suspended", "not-failed"}, names)
+	})
+
+	t.Run("removes the target without running jobs", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.JobTarget: {
+					restorePatches: RestorePatches{},
+					data: []unstructured.Unstructured{
+						job("completed", map[string]interface{}{"type": "Complete", "status": "True"}),
+					},
+				},
+			},
+		}
+
+		resources.keepRunningJobs()
+
+		require.NotContains(t, resources.resMapping, v1alpha1.JobTarget)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
@@ -12,7 +13,6 @@ import (
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/go-logr/logr"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -76,6 +76,10 @@ func NewResources(ctx context.Context, res resource.ResourceClient, namespace st
 
 		resources.resMapping[patchData.Target] = generic
 	}
+	resources.keepScaledObjectsOfSleptWorkloads()
+	resources.keepRunningJobs()
+	resources.keepKafkasWithoutNodePools()
+	resources.keepFlinkJobs()
 
 	return resources, nil
 }
@@ -89,8 +93,38 @@ func (g managedResources) HasResource() bool {
 	return false
 }
 
+// AnnounceSleep annotates the resources to sleep with the time of their sleep, in the
+// SleepAtAnnotation. Resources already annotated with it are not patched again.
+func (g managedResources) AnnounceSleep(ctx context.Context, sleepAt time.Time) error {
+	value := sleepAt.UTC().Format(time.RFC3339)
+	for _, resourceWrapper := range g.resMapping {
+		for _, resource := range resourceWrapper.data {
+			if isExcludedByAnnotation(resource) || resourceWrapper.isManagedByAnotherController(resource) {
+				continue
+			}
+			if resource.GetAnnotations()[v1alpha1.SleepAtAnnotation] == value {
+				continue
+			}
+			announced := resource.DeepCopy()
+			annotations := announced.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[v1alpha1.SleepAtAnnotation] = value
+			announced.SetAnnotations(annotations)
+			if err := resourceWrapper.Patch(ctx, &resource, announced); err != nil {
+				return fmt.Errorf("fails to announce sleep of %s %s: %w", resource.GetKind(), resource.GetName(), err)
+			}
+			g.logger.Info("sleep announced", "resourceName", resource.GetName(), "resourceKind", resource.GetKind(), "sleepAt", value)
+		}
+		resourceWrapper.isCacheInvalid = true
+	}
+	return nil
+}
+
 func (g managedResources) Sleep(ctx context.Context) error {
 	for _, resourceWrapper := range g.resMapping {
+		start := time.Now()
 		if resourceWrapper.patchData.Patch == "" {
 			return fmt.Errorf(`%w: invalid empty patch`, ErrJSONPatch)
 		}
@@ -108,17 +142,23 @@ func (g managedResources) Sleep(ctx context.Context) error {
 		}
 
 		for _, resource := range resourceWrapper.data {
+			if isExcludedByAnnotation(resource) {
+				g.logger.Info("resource excluded by annotation, skipped",
+					"resourceName", resource.GetName(),
+					"resourceKind", resource.GetKind(),
+				)
+				continue
+			}
+
 			// This will skip resources that are managed by another controller, since
 			// we should manage the sleep on the controller itself.
 			// Some examples are:
 			// - Pod managed by ReplicaSet managed by Deployment
 			// - Pod managed by Job managed by CronJob
-			// EXCEPCIÓN: No saltar CRDs (PgBouncer, PgCluster, HDFSCluster, OsCluster, OsDashboards, KafkaCluster) aunque tengan ownerReferences,
-			// ya que estos son recursos de nivel superior que debemos gestionar directamente.
+			// EXCEPCIÓN: No saltar CRDs (PgBouncer, PgCluster, HDFSCluster, OsCluster, OsDashboards, KafkaCluster, Elasticsearch, Kibana) aunque tengan ownerReferences,
+			// ya que estos son recursos de nivel superior que debemos gestionar directamente. Tampoco los targets configurados o recursos anotados para ignorar ownerReferences.
 			resourceKind := resource.GetKind()
-			isCRD := resourceKind == "PgBouncer" || resourceKind == "PgCluster" || resourceKind == "HDFSCluster" || resourceKind == "OsCluster" || resourceKind == "OsDashboards" || resourceKind == "KafkaCluster"
-
-			if metav1.GetControllerOfNoCopy(&resource) != nil && !isCRD {
+			if resourceWrapper.isManagedByAnotherController(resource) {
 				g.logger.Info("resource is managed by another controller, skipped",
 					"resourceName", resource.GetName(),
 					"resourceKind", resourceKind,
@@ -134,14 +174,20 @@ func (g managedResources) Sleep(ctx context.Context) error {
 				return fmt.Errorf("%w: %s", ErrJSONPatch, err)
 			}
 
+			resourcePatcher, err := resourceWrapper.patcherFor(patcherFn, resource)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrJSONPatch, err)
+			}
+
 			// Now attempt to apply the patch
-			modified, err := patcherFn.Exec(original)
+			modified, err := resourcePatcher.Exec(original)
 			if err != nil {
 				g.logger.Error(err, "fails to apply patch",
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 					"patch", resourceWrapper.patchData.Patch,
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to apply patch: %s", err))
 				// CRITICAL: Even if patch fails, we need to save the original state
 				// The resource might have been modified in a previous attempt (e.g., replicas set to 0)
 				// We need to re-read the current state from cluster and create a restore patch
@@ -204,6 +250,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to create restore patch: %s", err))
 				// Continue with next resource instead of stopping entire operation
 				continue
 			}
@@ -230,6 +277,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to read patched resource: %s", err))
 				// Restore patch already saved, continue with next resource
 				continue
 			}
@@ -244,6 +292,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 					"resourceKind", resource.GetKind(),
 					"restorePatchSaved", true,
 				)
+				resourceWrapper.failed(resource, err.Error())
 				// Continue with next resource instead of stopping entire operation
 				continue
 			}
@@ -251,6 +300,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 				"resourceName", resource.GetName(),
 				"resourceKind", resource.GetKind(),
 			)
+			resourceWrapper.patched(resource)
 			currentResource := &unstructured.Unstructured{}
 			currentResource.SetGroupVersionKind(resource.GroupVersionKind())
 			currentResource.SetName(resource.GetName())
@@ -265,6 +315,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 			}
 			resourceWrapper.isCacheInvalid = true
 		}
+		resourceWrapper.targetDone(start)
 	}
 
 	return nil
@@ -272,6 +323,7 @@ func (g managedResources) Sleep(ctx context.Context) error {
 
 func (g managedResources) WakeUp(ctx context.Context) error {
 	for _, resourceWrapper := range g.resMapping {
+		start := time.Now()
 		if resourceWrapper.isCacheInvalid {
 			var err error
 			resourceWrapper.data, err = resourceWrapper.getListByNamespace(ctx, g.namespace, resourceWrapper.patchData.Target)
@@ -286,13 +338,15 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 		}
 
 		for _, resource := range resourceWrapper.data {
+			if resourceWrapper.WakeUpFilter != nil && !resourceWrapper.WakeUpFilter(resourceWrapper.patchData.Target, resource) {
+				continue
+			}
+
 			// Skip resources managed by another controller
-			// EXCEPCIÓN: No saltar CRDs (PgBouncer, PgCluster, HDFSCluster, OsCluster, OsDashboards, KafkaCluster) aunque tengan ownerReferences,
-			// ya que estos son recursos de nivel superior que debemos gestionar directamente.
+			// EXCEPCIÓN: No saltar CRDs (PgBouncer, PgCluster, HDFSCluster, OsCluster, OsDashboards, KafkaCluster, Elasticsearch, Kibana) aunque tengan ownerReferences,
+			// ya que estos son recursos de nivel superior que debemos gestionar directamente. Tampoco los targets configurados o recursos anotados para ignorar ownerReferences.
 			resourceKind := resource.GetKind()
-			isCRD := resourceKind == "PgBouncer" || resourceKind == "PgCluster" || resourceKind == "HDFSCluster" || resourceKind == "OsCluster" || resourceKind == "OsDashboards" || resourceKind == "KafkaCluster"
-
-			if metav1.GetControllerOfNoCopy(&resource) != nil && !isCRD {
+			if resourceWrapper.isManagedByAnotherController(resource) {
 				g.logger.Info("resource is managed by another controller, skipped",
 					"resourceName", resource.GetName(),
 					"resourceKind", resourceKind,
@@ -306,24 +360,39 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 				return fmt.Errorf("%w: %s", ErrJSONPatch, err)
 			}
 
-			// EXTENSIÓN PRIORITARIA: Para CRDs con patches dinámicos (PgCluster, HDFSCluster, OsCluster, KafkaCluster),
+			// EXTENSIÓN PRIORITARIA: Para CRDs con patches dinámicos (PgCluster, HDFSCluster, OsCluster, KafkaCluster) y targets configurados con wakePatch,
 			// aplicar el patch de WAKE directamente sin verificar el restore patch.
 			// Estos patches están diseñados para ser aplicados siempre, independientemente del estado del restore patch.
-			isCRDWithDynamicPatch := resourceKind == "PgCluster" || resourceKind == "HDFSCluster" || resourceKind == "OsCluster" || resourceKind == "KafkaCluster"
+			isCRDWithDynamicPatch := resourceKind == "PgCluster" || resourceKind == "HDFSCluster" || resourceKind == "OsCluster" || resourceKind == "KafkaCluster" ||
+				resourceWrapper.WakePatchTargets[resourceWrapper.patchData.Target]
+			dynamicPatch, dynamicPatcher := resourceWrapper.patchData.Patch, patcherFn
+			// Los recursos anotados con su propio patch de wake lo aplican en lugar del restore patch
+			if wakePatch := resource.GetAnnotations()[v1alpha1.WakePatchAnnotation]; wakePatch != "" {
+				dynamicPatcher, err = patcher.New([]byte(wakePatch))
+				if err != nil {
+					g.logger.Error(err, "invalid wake patch annotation, skipped",
+						"resourceName", resource.GetName(),
+						"resourceKind", resourceKind,
+					)
+					resourceWrapper.failed(resource, fmt.Sprintf("invalid wake patch annotation: %s", err))
+					continue
+				}
+				isCRDWithDynamicPatch, dynamicPatch = true, wakePatch
+			}
 
-			if isCRDWithDynamicPatch && resourceWrapper.patchData.Patch != "" {
+			if isCRDWithDynamicPatch && dynamicPatch != "" {
 				// Para CRDs con patches dinámicos, aplicar el patch directamente sin verificar restore patch
 				g.logger.Info("applying dynamic patch for CRD (ignoring restore patch verification)",
 					"resourceName", resource.GetName(),
 					"resourceKind", resourceKind,
-					"patch", resourceWrapper.patchData.Patch,
+					"patch", dynamicPatch,
 				)
 
-				modified, err := patcherFn.Exec(current)
+				modified, err := dynamicPatcher.Exec(current)
 				if err != nil {
 					// EXTENSIÓN: Manejar casos donde el patch falla por operación incorrecta
 					// Los patches de WAKE usan "replace" pero si falla, intentar con "add"
-					patchStr := resourceWrapper.patchData.Patch
+					patchStr := dynamicPatch
 					if strings.Contains(patchStr, "annotations") {
 						if strings.Contains(patchStr, "op: replace") {
 							// Si replace falla (anotación no existe, aunque debería), intentar con add
@@ -345,8 +414,9 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 						g.logger.Error(err, "fails to apply dynamic patch",
 							"resourceName", resource.GetName(),
 							"resourceKind", resourceKind,
-							"patch", resourceWrapper.patchData.Patch,
+							"patch", dynamicPatch,
 						)
+						resourceWrapper.failed(resource, fmt.Sprintf("fails to apply patch: %s", err))
 						continue
 					}
 				}
@@ -362,6 +432,7 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 						"resourceName", resource.GetName(),
 						"resourceKind", resourceKind,
 					)
+					resourceWrapper.failed(resource, err.Error())
 					// Continue with next resource instead of stopping entire operation
 					continue
 				}
@@ -369,6 +440,7 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resourceKind,
 				)
+				resourceWrapper.patched(resource)
 				resourceWrapper.isCacheInvalid = true
 				continue
 			}
@@ -381,34 +453,57 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				if _, slept := resourceWrapper.sleptGenerations[resource.GetName()]; slept {
+					resourceWrapper.missingRestorePatch(resource)
+				}
 				continue
 			}
+			// The restore policy decides what to do with the resources modified since the sleep: Skip
+			// leaves them as they are, Merge restores them unless the fields changed by the sleep were
+			// modified, Overwrite always restores those fields.
+			restorePolicy := resourceWrapper.SleepInfo.GetRestorePolicy()
 			if expectedGeneration, ok := resourceWrapper.sleptGenerations[resource.GetName()]; ok && expectedGeneration > 0 && resource.GetGeneration() != expectedGeneration {
-				g.logger.Info("resource modified after sleep and before wake up, skip wake up",
+				if restorePolicy == v1alpha1.RestorePolicySkip {
+					g.logger.Info("resource modified after sleep and before wake up, skip wake up",
+						"resourceName", resource.GetName(),
+						"resourceKind", resource.GetKind(),
+						"expectedGeneration", expectedGeneration,
+						"currentGeneration", resource.GetGeneration(),
+					)
+					resourceWrapper.drifted(resource, "modified after sleep")
+					continue
+				}
+				g.logger.Info("resource modified after sleep and before wake up, restored by restore policy",
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
-					"expectedGeneration", expectedGeneration,
-					"currentGeneration", resource.GetGeneration(),
+					"restorePolicy", restorePolicy,
 				)
-				continue
+			}
+
+			resourcePatcher, err := resourceWrapper.patcherFor(patcherFn, resource)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrJSONPatch, err)
 			}
 
 			// Comportamiento original: usar restore patch si está disponible (solo para recursos nativos y PgBouncer)
-			isResourceChanged, err := patcherFn.IsResourceChanged(current)
+			isResourceChanged, err := resourcePatcher.IsResourceChanged(current)
 			if err != nil {
 				g.logger.Error(err, "fails to calculate if resource is changed",
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 					"patch", resourceWrapper.patchData.Patch,
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to check changes since sleep: %s", err))
 				continue
 			}
-			if isResourceChanged {
+			if isResourceChanged && restorePolicy != v1alpha1.RestorePolicyOverwrite {
 				g.logger.Info("resource modified between sleep and wake up, skip wake up",
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 					"patch", resourceWrapper.patchData.Patch,
+					"restorePolicy", restorePolicy,
 				)
+				resourceWrapper.drifted(resource, "modified between sleep and wake up")
 				continue
 			}
 
@@ -418,6 +513,7 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to apply restore patch: %s", err))
 				// Continue with next resource instead of stopping entire operation
 				continue
 			}
@@ -428,6 +524,7 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				resourceWrapper.failed(resource, fmt.Sprintf("fails to read restored resource: %s", err))
 				// Continue with next resource instead of stopping entire operation
 				continue
 			}
@@ -441,12 +538,15 @@ func (g managedResources) WakeUp(ctx context.Context) error {
 					"resourceName", resource.GetName(),
 					"resourceKind", resource.GetKind(),
 				)
+				resourceWrapper.failed(resource, err.Error())
 				// Continue with next resource instead of stopping entire operation
 				// The restore patch is already saved, so we can retry later
 				continue
 			}
+			resourceWrapper.patched(resource)
 			resourceWrapper.isCacheInvalid = true
 		}
+		resourceWrapper.targetDone(start)
 	}
 
 	return nil
@@ -0,0 +1,70 @@
+package jsonpatch
+
+import (
+	"strings"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// keepScaledObjectsOfSleptWorkloads drops the KEDA ScaledObjects whose scale target is not put to
+// sleep by the SleepInfo, so pausing them does not scale down workloads kube-green does not manage.
+// The ScaledObjects already paused are kept to be woken up. The ScaledObject patches set by the user,
+// without suspendKEDA, are applied to all the ScaledObjects as any other patch.
+func (g managedResources) keepScaledObjectsOfSleptWorkloads() {
+	scaledObjects, ok := g.resMapping[v1alpha1.ScaledObjectTarget]
+	if !ok || !scaledObjects.SleepInfo.IsKEDAToSuspend() {
+		return
+	}
+
+	sleptWorkloads := map[string]struct{}{}
+	for target, res := range g.resMapping {
+		if target == v1alpha1.ScaledObjectTarget {
+			continue
+		}
+		for _, item := range res.data {
+			sleptWorkloads[workloadKey(target.Group, target.Kind, item.GetName())] = struct{}{}
+		}
+	}
+
+	kept := []unstructured.Unstructured{}
+	for _, scaledObject := range scaledObjects.data {
+		if _, isSlept := scaledObjects.restorePatches[scaledObject.GetName()]; isSlept {
+			kept = append(kept, scaledObject)
+			continue
+		}
+		if _, ok := sleptWorkloads[scaleTargetKey(scaledObject)]; ok {
+			kept = append(kept, scaledObject)
+			continue
+		}
+		g.logger.Info("scaledObject target is not put to sleep, skipped", "resourceName", scaledObject.GetName())
+	}
+	scaledObjects.data = kept
+	if len(kept) == 0 {
+		delete(g.resMapping, v1alpha1.ScaledObjectTarget)
+	}
+}
+
+// scaleTargetKey returns the key of the workload scaled by a ScaledObject. As in KEDA, the target
+// is a Deployment when kind and apiVersion are not set.
+func scaleTargetKey(scaledObject unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "name")
+	kind, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "kind")
+	apiVersion, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "apiVersion")
+	if kind == "" {
+		kind = v1alpha1.DeploymentTarget.Kind
+	}
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+	}
+	group := ""
+	if idx := strings.LastIndex(apiVersion, "/"); idx >= 0 {
+		group = apiVersion[:idx]
+	}
+	return workloadKey(group, kind, name)
+}
+
+func workloadKey(group, kind, name string) string {
+	return kind + "." + group + "/" + name
+}
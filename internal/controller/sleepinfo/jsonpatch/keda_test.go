@@ -0,0 +1,104 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKeepScaledObjectsOfSleptWorkloads(t *testing.T) {
+	scaledObject := func(name string, scaleTargetRef map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "keda.sh/v1alpha1",
+			"kind":       "ScaledObject",
+			"metadata":   map[string]interface{}{"name": name},
+			"spec":       map[string]interface{}{"scaleTargetRef": scaleTargetRef},
+		}}
+	}
+	workload := func(kind, name string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+	}
+	suspendKEDA := true
+	resourceClient := resource.ResourceClient{
+		SleepInfo: &v1alpha1.SleepInfo{Spec: v1alpha1.SleepInfoSpec{SuspendKEDA: &suspendKEDA}},
+	}
+
+	t.Run("keeps the ScaledObjects of slept workloads", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.DeploymentTarget: {
+					data: []unstructured.Unstructured{workload("Deployment", "api")},
+				},
+				v1alpha1.StatefulSetTarget: {
+					data: []unstructured.Unstructured{workload("StatefulSet", "db")},
+				},
+				v1alpha1.ScaledObjectTarget: {
+					ResourceClient: resourceClient,
+					restorePatches: RestorePatches{"paused": "{}"},
+					data: []unstructured.Unstructured{
+						scaledObject("api", map[string]interface{}{"name": "api"}),
+						scaledObject("db", map[string]interface{}{"name": "db", "kind": "StatefulSet", "apiVersion": "apps/v1"}),
+						scaledObject("worker", map[string]interface{}{"name": "worker"}),
+						scaledObject("db-as-deployment", map[string]interface{}{"name": "db"}),
+						scaledObject("paused", map[string]interface{}{"name": "deleted"}),
+					},
+				},
+			},
+		}
+
+		resources.keepScaledObjectsOfSleptWorkloads()
+
+		names := []string{}
+		for _, item := range resources.resMapping[v1alpha1.ScaledObjectTarget].data {
+			names = append(names, item.GetName())
+		}
+		require.Equal(t, []string{"api", "db", "paused"}, names)
+	})
+
+	t.Run("removes the target without ScaledObjects to patch", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.ScaledObjectTarget: {
+					ResourceClient: resourceClient,
+					restorePatches: RestorePatches{},
+					data: []unstructured.Unstructured{
+						scaledObject("api", map[string]interface{}{"name": "api"}),
+					},
+				},
+			},
+		}
+
+		resources.keepScaledObjectsOfSleptWorkloads()
+
+		require.NotContains(t, resources.resMapping, v1alpha1.ScaledObjectTarget)
+	})
+	t.Run("keeps all the ScaledObjects patched without suspendKEDA", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.ScaledObjectTarget: {
+					ResourceClient: resource.ResourceClient{SleepInfo: &v1alpha1.SleepInfo{}},
+					restorePatches: RestorePatches{},
+					data: []unstructured.Unstructured{
+						scaledObject("api", map[string]interface{}{"name": "api"}),
+					},
+				},
+			},
+		}
+
+		resources.keepScaledObjectsOfSleptWorkloads()
+
+		require.Len(t, resources.resMapping[v1alpha1.ScaledObjectTarget].data, 1)
+	})
+}
@@ -0,0 +1,101 @@
+package jsonpatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWakeUpRestorePolicy(t *testing.T) {
+	namespace := "my-namespace"
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	restorePatches := map[string]RestorePatches{
+		v1alpha1.DeploymentTarget.String(): {"api": `{"spec":{"replicas":3}}`},
+	}
+	sleptGenerations := map[string]SleptResourceGenerations{
+		v1alpha1.DeploymentTarget.String(): {"api": 2},
+	}
+
+	tests := []struct {
+		name             string
+		restorePolicy    v1alpha1.RestorePolicy
+		replicas         int32
+		expectedReplicas int32
+		expectedFailure  string
+	}{
+		{
+			name:             "skip - other fields modified",
+			replicas:         0,
+			expectedReplicas: 0,
+			expectedFailure:  "modified after sleep",
+		},
+		{
+			name:             "merge - other fields modified",
+			restorePolicy:    v1alpha1.RestorePolicyMerge,
+			replicas:         0,
+			expectedReplicas: 3,
+		},
+		{
+			name:             "merge - slept fields modified",
+			restorePolicy:    v1alpha1.RestorePolicyMerge,
+			replicas:         1,
+			expectedReplicas: 1,
+			expectedFailure:  "modified between sleep and wake up",
+		},
+		{
+			name:             "overwrite - slept fields modified",
+			restorePolicy:    v1alpha1.RestorePolicyOverwrite,
+			replicas:         1,
+			expectedReplicas: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace, Generation: 3},
+				Spec:       appsv1.DeploymentSpec{Replicas: &test.replicas},
+			}
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(restMapper).WithObjects(deployment).Build()
+			sleepInfo := &v1alpha1.SleepInfo{
+				ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+				Spec:       v1alpha1.SleepInfoSpec{RestorePolicy: test.restorePolicy},
+			}
+			failures := []string{}
+			resources, err := NewResources(context.Background(), resource.ResourceClient{
+				Client:    c,
+				SleepInfo: sleepInfo,
+				Log:       logr.Discard(),
+				Failed: func(res unstructured.Unstructured, reason string) {
+					failures = append(failures, reason)
+				},
+			}, namespace, restorePatches, sleptGenerations)
+			require.NoError(t, err)
+
+			require.NoError(t, resources.WakeUp(context.Background()))
+
+			updated := &appsv1.Deployment{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(deployment), updated))
+			require.Equal(t, test.expectedReplicas, *updated.Spec.Replicas)
+			if test.expectedFailure == "" {
+				require.Empty(t, failures)
+			} else {
+				require.Equal(t, []string{test.expectedFailure}, failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package jsonpatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestListResourcesMatchExpressions(t *testing.T) {
+	namespace := "my-namespace"
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+	deployment := func(name string, labels map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}}
+	}
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithRESTMapper(restMapper).WithObjects(
+		deployment("core-api", map[string]string{"team": "core"}),
+		deployment("core-db", map[string]string{"team": "core", "critical": "true"}),
+		deployment("frontend", map[string]string{"team": "web"}),
+		deployment("unlabeled", nil),
+	).Build()
+
+	tests := []struct {
+		name          string
+		spec          v1alpha1.SleepInfoSpec
+		expected      []string
+		expectedError string
+	}{
+		{
+			name: "exclude everything not labeled team=core",
+			spec: v1alpha1.SleepInfoSpec{ExcludeRef: []v1alpha1.FilterRef{{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"core"}},
+				},
+			}}},
+			expected: []string{"core-api", "core-db"},
+		},
+		{
+			name: "exclude resources with a label",
+			spec: v1alpha1.SleepInfoSpec{ExcludeRef: []v1alpha1.FilterRef{{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "critical", Operator: metav1.LabelSelectorOpExists},
+				},
+			}}},
+			expected: []string{"core-api", "frontend", "unlabeled"},
+		},
+		{
+			name: "include resources with a label value and without another label",
+			spec: v1alpha1.SleepInfoSpec{IncludeRef: []v1alpha1.FilterRef{{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"core", "web"}},
+					{Key: "critical", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			}}},
+			expected: []string{"core-api", "frontend"},
+		},
+		{
+			name: "include resources not labeled with a value",
+			spec: v1alpha1.SleepInfoSpec{IncludeRef: []v1alpha1.FilterRef{{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"core"}},
+				},
+			}}},
+			expected: []string{"frontend", "unlabeled"},
+		},
+		{
+			name: "invalid include operator",
+			spec: v1alpha1.SleepInfoSpec{IncludeRef: []v1alpha1.FilterRef{{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "replicas", Operator: "Gt", Values: []string{"1"}},
+				},
+			}}},
+			expectedError: `invalid operator "Gt" in includeRef matchExpressions`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			generic := newGenericResource(resource.ResourceClient{
+				Client:    c,
+				SleepInfo: &v1alpha1.SleepInfo{Spec: test.spec},
+				Log:       logr.Discard(),
+			}, v1alpha1.Patch{Target: v1alpha1.DeploymentTarget}, RestorePatches{}, nil)
+
+			items, err := generic.getListByNamespace(context.Background(), namespace, v1alpha1.DeploymentTarget)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, item := range items {
+				names = append(names, item.GetName())
+			}
+			require.ElementsMatch(t, test.expected, names)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package jsonpatch
+
+import (
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const strimziNodePoolsAnnotation = "strimzi.io/node-pools"
+
+// keepKafkasWithoutNodePools drops the Strimzi Kafka clusters using node pools, whose brokers are
+// scaled through their KafkaNodePools. The Kafka clusters already slept are kept to be woken up.
+func (g managedResources) keepKafkasWithoutNodePools() {
+	kafkas, ok := g.resMapping[v1alpha1.StrimziKafkaTarget]
+	if !ok {
+		return
+	}
+
+	kept := []unstructured.Unstructured{}
+	for _, kafka := range kafkas.data {
+		if _, isSlept := kafkas.restorePatches[kafka.GetName()]; isSlept || !usesNodePools(kafka) {
+			kept = append(kept, kafka)
+		}
+	}
+	kafkas.data = kept
+	if len(kept) == 0 {
+		delete(g.resMapping, v1alpha1.StrimziKafkaTarget)
+	}
+}
+
+// usesNodePools returns true if the Kafka cluster has node pools, or lacks the kafka and zookeeper
+// replicas scaled by the Kafka patch
+func usesNodePools(kafka unstructured.Unstructured) bool {
+	if kafka.GetAnnotations()[strimziNodePoolsAnnotation] == "enabled" {
+		return true
+	}
+	_, hasKafkaReplicas, _ := unstructured.NestedInt64(kafka.Object, "spec", "kafka", "replicas")
+	_, hasZookeeperReplicas, _ := unstructured.NestedInt64(kafka.Object, "spec", "zookeeper", "replicas")
+	return !hasKafkaReplicas || !hasZookeeperReplicas
+}
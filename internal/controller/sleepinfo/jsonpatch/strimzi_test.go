@@ -0,0 +1,67 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestKeepKafkasWithoutNodePools(t *testing.T) {
+	kafka := func(name string, annotations map[string]interface{}, spec map[string]interface{}) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "kafka.strimzi.io/v1beta2",
+			"kind":       "Kafka",
+			"metadata":   map[string]interface{}{"name": name, "annotations": annotations},
+			"spec":       spec,
+		}}
+	}
+	zookeeperSpec := map[string]interface{}{
+		"kafka":     map[string]interface{}{"replicas": int64(3)},
+		"zookeeper": map[string]interface{}{"replicas": int64(3)},
+	}
+
+	t.Run("keeps the clusters without node pools", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.StrimziKafkaTarget: {
+					restorePatches: RestorePatches{"slept": `{"spec":{"kafka":{"replicas":3}}}`},
+					data: []unstructured.Unstructured{
+						kafka("zookeeper", nil, zookeeperSpec),
+						kafka("node-pools", map[string]interface{}{strimziNodePoolsAnnotation: "enabled"}, zookeeperSpec),
+						kafka("kraft", map[string]interface{}{strimziNodePoolsAnnotation: "enabled", "strimzi.io/kraft": "enabled"}, map[string]interface{}{}),
+						kafka("slept", map[string]interface{}{strimziNodePoolsAnnotation: "enabled"}, map[string]interface{}{}),
+					},
+				},
+			},
+		}
+
+		resources.keepKafkasWithoutNodePools()
+
+		names := []string{}
+		for _, item := range resources.resMapping[v1alpha1.StrimziKafkaTarget].data {
+			names = append(names, item.GetName())
+		}
+		require.Equal(t, []string{"zookeeper", "slept"}, names)
+	})
+
+	t.Run("removes the target without Kafka clusters to patch", func(t *testing.T) {
+		resources := managedResources{
+			logger: logr.Discard(),
+			resMapping: map[v1alpha1.PatchTarget]*genericResource{
+				v1alpha1.StrimziKafkaTarget: {
+					restorePatches: RestorePatches{},
+					data:           []unstructured.Unstructured{kafka("kraft", nil, map[string]interface{}{})},
+				},
+			},
+		}
+
+		resources.keepKafkasWithoutNodePools()
+
+		require.NotContains(t, resources.resMapping, v1alpha1.StrimziKafkaTarget)
+	})
+}
@@ -0,0 +1,159 @@
+package sleepinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// limitsBeforeSleepAnnotation holds the limits of a NodePool scaled to zero by a sleep, restored before the wake up
+const limitsBeforeSleepAnnotation = "kube-green.com/limits-before-sleep"
+
+var nodePoolGVK = schema.GroupVersionKind{Group: "karpenter.sh", Version: "v1", Kind: "NodePool"}
+
+// sleepLimits are the limits of the NodePools asleep, no new node is provisioned
+var sleepLimits = map[string]interface{}{string(v1.ResourceCPU): "0", string(v1.ResourceMemory): "0"}
+
+// +kubebuilder:rbac:groups=karpenter.sh,resources=nodepools,verbs=get;patch
+
+// sleepNodePools scales the limits of the Karpenter NodePools of the SleepInfo to zero, once its
+// resources are asleep. A NodePool already asleep, e.g. by another SleepInfo of the same
+// ClusterSleepInfo, keeps its limits before the sleep. The NodePools are not required by the sleep,
+// so its errors are reported in the log and an Event.
+func (r *SleepInfoReconciler) sleepNodePools(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	if sleepInfo.Spec.Karpenter == nil || sleepInfo.IsDryRun() {
+		return
+	}
+	for _, name := range sleepInfo.Spec.Karpenter.NodePools {
+		nodePool, err := r.getNodePool(ctx, name)
+		if err != nil {
+			r.nodePoolFailed(log, sleepInfo, err)
+			continue
+		}
+		if _, asleep := nodePool.GetAnnotations()[limitsBeforeSleepAnnotation]; asleep {
+			continue
+		}
+		limits, _, err := unstructured.NestedMap(nodePool.Object, "spec", "limits")
+		if err != nil {
+			r.nodePoolFailed(log, sleepInfo, fmt.Errorf("invalid limits of NodePool %s: %w", name, err))
+			continue
+		}
+		if limits == nil {
+			limits = map[string]interface{}{}
+		}
+		saved, err := json.Marshal(limits)
+		if err != nil {
+			r.nodePoolFailed(log, sleepInfo, err)
+			continue
+		}
+		patch := client.MergeFrom(nodePool.DeepCopy())
+		setAnnotation(nodePool, limitsBeforeSleepAnnotation, string(saved))
+		if err := unstructured.SetNestedMap(nodePool.Object, sleepLimits, "spec", "limits"); err != nil {
+			r.nodePoolFailed(log, sleepInfo, err)
+			continue
+		}
+		if err := r.Patch(ctx, nodePool, patch); err != nil {
+			r.nodePoolFailed(log, sleepInfo, fmt.Errorf("fails to scale NodePool %s to zero: %w", name, err))
+			continue
+		}
+		log.Info("NodePool scaled to zero", "nodePool", name)
+	}
+}
+
+// restoreNodePoolsIn returns how long is left before the limits of the NodePools are restored for the
+// wake up at wakeUpAt, 0 when they are to restore or there is nothing to restore
+func restoreNodePoolsIn(sleepInfo *kubegreenv1alpha1.SleepInfo, wakeUpAt, now time.Time) time.Duration {
+	if sleepInfo.Spec.Karpenter == nil || sleepInfo.IsDryRun() || !wakeUpAt.After(now) {
+		return 0
+	}
+	if restoreAt := wakeUpAt.Add(-sleepInfo.Spec.Karpenter.GetRestoreBefore()); now.Before(restoreAt) {
+		return restoreAt.Sub(now)
+	}
+	return 0
+}
+
+// prepareWakeUp restores the limits of the NodePools of the SleepInfo within restoreBefore of its
+// next wake up, at wakeUpAt. It returns how long is left before they are restored, 0 when they are
+// restored or there is nothing to restore.
+func (r *SleepInfoReconciler) prepareWakeUp(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, data SleepInfoData, wakeUpAt, now time.Time) time.Duration {
+	if sleepInfo.Spec.Karpenter == nil || !data.IsWakeUpOperation() || !wakeUpAt.After(now) {
+		return 0
+	}
+	if restoreIn := restoreNodePoolsIn(sleepInfo, wakeUpAt, now); restoreIn > 0 {
+		return restoreIn
+	}
+	r.restoreNodePools(ctx, log, sleepInfo)
+	return 0
+}
+
+// restoreNodePools restores the limits of the Karpenter NodePools of the SleepInfo scaled to zero
+func (r *SleepInfoReconciler) restoreNodePools(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	if sleepInfo.Spec.Karpenter == nil || sleepInfo.IsDryRun() {
+		return
+	}
+	for _, name := range sleepInfo.Spec.Karpenter.NodePools {
+		nodePool, err := r.getNodePool(ctx, name)
+		if err != nil {
+			r.nodePoolFailed(log, sleepInfo, err)
+			continue
+		}
+		saved, asleep := nodePool.GetAnnotations()[limitsBeforeSleepAnnotation]
+		if !asleep {
+			continue
+		}
+		limits := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(saved), &limits); err != nil {
+			r.nodePoolFailed(log, sleepInfo, fmt.Errorf("invalid %s annotation of NodePool %s: %w", limitsBeforeSleepAnnotation, name, err))
+			continue
+		}
+		patch := client.MergeFrom(nodePool.DeepCopy())
+		annotations := nodePool.GetAnnotations()
+		delete(annotations, limitsBeforeSleepAnnotation)
+		nodePool.SetAnnotations(annotations)
+		if len(limits) == 0 {
+			unstructured.RemoveNestedField(nodePool.Object, "spec", "limits")
+		} else if err := unstructured.SetNestedMap(nodePool.Object, limits, "spec", "limits"); err != nil {
+			r.nodePoolFailed(log, sleepInfo, err)
+			continue
+		}
+		if err := r.Patch(ctx, nodePool, patch); err != nil {
+			r.nodePoolFailed(log, sleepInfo, fmt.Errorf("fails to restore NodePool %s: %w", name, err))
+			continue
+		}
+		log.Info("NodePool limits restored", "nodePool", name)
+	}
+}
+
+func (r *SleepInfoReconciler) getNodePool(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	nodePool := &unstructured.Unstructured{}
+	nodePool.SetGroupVersionKind(nodePoolGVK)
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, nodePool); err != nil {
+		return nil, fmt.Errorf("fails to get NodePool %s: %w", name, err)
+	}
+	return nodePool, nil
+}
+
+func setAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
+
+func (r *SleepInfoReconciler) nodePoolFailed(log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, err error) {
+	log.Error(err, "fails to update Karpenter NodePool")
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "NodePoolUpdateFailed", "Karpenter NodePool update failed: %s", err)
+	}
+}
@@ -0,0 +1,106 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKarpenterNodePools(t *testing.T) {
+	wakeUpAt := time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{nodePoolGVK.GroupVersion()})
+	restMapper.Add(nodePoolGVK, meta.RESTScopeRoot)
+
+	newNodePool := func(name string, limits map[string]interface{}) *unstructured.Unstructured {
+		nodePool := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"disruption": map[string]interface{}{"consolidationPolicy": "WhenEmpty"}},
+		}}
+		nodePool.SetGroupVersionKind(nodePoolGVK)
+		nodePool.SetName(name)
+		if limits != nil {
+			require.NoError(t, unstructured.SetNestedMap(nodePool.Object, limits, "spec", "limits"))
+		}
+		return nodePool
+	}
+	limits := func(t *testing.T, c client.Client, name string) (map[string]interface{}, map[string]string) {
+		t.Helper()
+		nodePool, err := (&SleepInfoReconciler{Client: c}).getNodePool(context.Background(), name)
+		require.NoError(t, err)
+		limits, _, err := unstructured.NestedMap(nodePool.Object, "spec", "limits")
+		require.NoError(t, err)
+		return limits, nodePool.GetAnnotations()
+	}
+	newSleepInfo := func(nodePools ...string) *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "bdadevdat-apps"},
+			Spec: kubegreenv1alpha1.SleepInfoSpec{
+				SleepTime:  "20:00",
+				WakeUpTime: "08:00",
+				Karpenter:  &kubegreenv1alpha1.Karpenter{NodePools: nodePools},
+			},
+		}
+	}
+
+	t.Run("scales the node pools to zero until the wake up", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).WithObjects(
+			newNodePool("apps", map[string]interface{}{"cpu": "100", "memory": "400Gi"}),
+			newNodePool("data", nil),
+		).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Client: c, Recorder: recorder}
+		sleepInfo := newSleepInfo("apps", "data", "missing")
+
+		r.sleepNodePools(context.Background(), logr.Discard(), sleepInfo)
+
+		appsLimits, annotations := limits(t, c, "apps")
+		require.Equal(t, sleepLimits, appsLimits)
+		require.JSONEq(t, `{"cpu":"100","memory":"400Gi"}`, annotations[limitsBeforeSleepAnnotation])
+		dataLimits, annotations := limits(t, c, "data")
+		require.Equal(t, sleepLimits, dataLimits)
+		require.Equal(t, "{}", annotations[limitsBeforeSleepAnnotation])
+		require.Contains(t, <-recorder.Events, "NodePoolUpdateFailed")
+
+		// Asleep by another SleepInfo: the limits before the sleep are kept
+		r.sleepNodePools(context.Background(), logr.Discard(), newSleepInfo("apps"))
+		_, annotations = limits(t, c, "apps")
+		require.JSONEq(t, `{"cpu":"100","memory":"400Gi"}`, annotations[limitsBeforeSleepAnnotation])
+
+		require.Equal(t, time.Minute, r.prepareWakeUp(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation}, wakeUpAt, wakeUpAt.Add(-6*time.Minute)))
+		appsLimits, _ = limits(t, c, "apps")
+		require.Equal(t, sleepLimits, appsLimits)
+
+		require.Zero(t, r.prepareWakeUp(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation}, wakeUpAt, wakeUpAt.Add(-5*time.Minute)))
+		appsLimits, annotations = limits(t, c, "apps")
+		require.Equal(t, map[string]interface{}{"cpu": "100", "memory": "400Gi"}, appsLimits)
+		require.NotContains(t, annotations, limitsBeforeSleepAnnotation)
+		dataLimits, annotations = limits(t, c, "data")
+		require.Nil(t, dataLimits)
+		require.NotContains(t, annotations, limitsBeforeSleepAnnotation)
+	})
+
+	t.Run("restores the node pools only before a wake up", func(t *testing.T) {
+		sleepInfo := newSleepInfo("apps")
+		sleepInfo.Spec.Karpenter.RestoreBefore = &metav1.Duration{Duration: 15 * time.Minute}
+
+		require.Equal(t, 45*time.Minute, restoreNodePoolsIn(sleepInfo, wakeUpAt, wakeUpAt.Add(-time.Hour)))
+		require.Zero(t, restoreNodePoolsIn(sleepInfo, wakeUpAt, wakeUpAt.Add(-10*time.Minute)))
+		require.Zero(t, (&SleepInfoReconciler{}).prepareWakeUp(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: sleepOperation}, wakeUpAt, wakeUpAt.Add(-10*time.Minute)))
+	})
+}
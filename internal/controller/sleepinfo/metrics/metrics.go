@@ -1,12 +1,59 @@
 package metrics
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 type Metrics struct {
 	CurrentSleepInfo *prometheus.GaugeVec
+	// FailedResources are the resources the last operation of a SleepInfo failed to sleep or wake up, by kind
+	FailedResources *prometheus.GaugeVec
+	// RestoreDataBytes is the size of the compressed restore patches stored in the secret of a SleepInfo
+	RestoreDataBytes *prometheus.GaugeVec
+	// SuspendedResources are the resources asleep by namespace and kind, summed over the SleepInfos of
+	// the namespace
+	SuspendedResources *prometheus.GaugeVec
+	// OperationDuration is the time taken by the sleeps and wake ups of a SleepInfo
+	OperationDuration *prometheus.HistogramVec
+	// TargetOperationDuration is the time taken by the sleeps and wake ups of a SleepInfo by patch target
+	TargetOperationDuration *prometheus.HistogramVec
+	// OperationErrors counts the resources failed to sleep or wake up, skipped on wake up because
+	// they changed since the sleep or because their restore patch is missing, by kind and reason
+	OperationErrors *prometheus.CounterVec
+	// SavedCPUCores and SavedMemoryBytes are the requests released by the workloads asleep by
+	// namespace, summed over the SleepInfos of the namespace
+	SavedCPUCores    *prometheus.GaugeVec
+	SavedMemoryBytes *prometheus.GaugeVec
+	// SavedCPUCoreHours are the CPU core hours released by the workloads slept by namespace
+	SavedCPUCoreHours *prometheus.CounterVec
+	suspended         *suspendedResources
+	saved             *savedResources
+}
+
+// operationDurationBuckets go from 50ms to almost 2 minutes, for the operator CRDs slow to patch
+var operationDurationBuckets = prometheus.ExponentialBuckets(0.05, 2, 12)
+
+// savedResources are the CPU cores and memory bytes released by every SleepInfo, by namespace, since
+// they were last set
+type savedResources struct {
+	mu       sync.Mutex
+	requests map[string]map[string]savedRequests
+	now      func() time.Time
+}
+
+type savedRequests struct {
+	cpu, memory float64
+	since       time.Time
+}
+
+// suspendedResources are the resources asleep by kind of every SleepInfo, by namespace
+type suspendedResources struct {
+	mu     sync.Mutex
+	counts map[string]map[string]map[string]int32
 }
 
 func SetupMetricsOrDie(prefix string) Metrics {
@@ -16,6 +63,55 @@ func SetupMetricsOrDie(prefix string) Metrics {
 			Name:      "current_sleepinfo",
 			Help:      "Info about SleepInfo resource",
 		}, []string{"name", "namespace"}),
+		FailedResources: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "failed_resources",
+			Help:      "Resources the last operation of the SleepInfo failed to sleep or wake up",
+		}, []string{"name", "namespace", "operation", "kind"}),
+		RestoreDataBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "restore_data_bytes",
+			Help:      "Size in bytes of the compressed restore patches stored in the secret of the SleepInfo",
+		}, []string{"name", "namespace"}),
+		SuspendedResources: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "suspended_resources",
+			Help:      "Resources currently asleep in the namespace",
+		}, []string{"namespace", "kind"}),
+		OperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "operation_duration_seconds",
+			Help:      "Time taken to sleep or wake up the resources of the SleepInfo",
+			Buckets:   operationDurationBuckets,
+		}, []string{"name", "namespace", "operation"}),
+		TargetOperationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "target_operation_duration_seconds",
+			Help:      "Time taken to sleep or wake up the resources of a patch target of the SleepInfo",
+			Buckets:   operationDurationBuckets,
+		}, []string{"name", "namespace", "operation", "target"}),
+		OperationErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "operation_errors_total",
+			Help:      "Resources failed to sleep or wake up, by kind and reason",
+		}, []string{"operation", "kind", "reason"}),
+		SavedCPUCores: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "saved_cpu_cores",
+			Help:      "CPU cores requested by the workloads currently asleep in the namespace",
+		}, []string{"namespace"}),
+		SavedMemoryBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: prefix,
+			Name:      "saved_memory_bytes",
+			Help:      "Memory bytes requested by the workloads currently asleep in the namespace",
+		}, []string{"namespace"}),
+		SavedCPUCoreHours: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: prefix,
+			Name:      "saved_cpu_core_hours_total",
+			Help:      "CPU core hours requested by the workloads slept in the namespace, while they were asleep",
+		}, []string{"namespace"}),
+		suspended: &suspendedResources{counts: map[string]map[string]map[string]int32{}},
+		saved:     &savedResources{requests: map[string]map[string]savedRequests{}, now: time.Now},
 	}
 	return sleepInfoMetrics
 }
@@ -23,6 +119,90 @@ func SetupMetricsOrDie(prefix string) Metrics {
 func (customMetrics Metrics) MustRegister(registry metrics.RegistererGatherer) Metrics {
 	registry.MustRegister(
 		customMetrics.CurrentSleepInfo,
+		customMetrics.FailedResources,
+		customMetrics.RestoreDataBytes,
+		customMetrics.SuspendedResources,
+		customMetrics.OperationDuration,
+		customMetrics.TargetOperationDuration,
+		customMetrics.OperationErrors,
+		customMetrics.SavedCPUCores,
+		customMetrics.SavedMemoryBytes,
+		customMetrics.SavedCPUCoreHours,
 	)
 	return customMetrics
 }
+
+// SetSuspendedResources sets the resources asleep by kind of a SleepInfo, nil once woken up, and
+// updates the totals of its namespace
+func (customMetrics Metrics) SetSuspendedResources(name, namespace string, counts map[string]int32) {
+	if customMetrics.SuspendedResources == nil {
+		return
+	}
+	s := customMetrics.suspended
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(counts) == 0 {
+		delete(s.counts[namespace], name)
+	} else {
+		if s.counts[namespace] == nil {
+			s.counts[namespace] = map[string]map[string]int32{}
+		}
+		s.counts[namespace][name] = counts
+	}
+	totals := map[string]int32{}
+	for _, sleepInfoCounts := range s.counts[namespace] {
+		for kind, count := range sleepInfoCounts {
+			totals[kind] += count
+		}
+	}
+	if len(s.counts[namespace]) == 0 {
+		delete(s.counts, namespace)
+	}
+	customMetrics.SuspendedResources.DeletePartialMatch(prometheus.Labels{"namespace": namespace})
+	for kind, count := range totals {
+		customMetrics.SuspendedResources.With(prometheus.Labels{
+			"namespace": namespace,
+			"kind":      kind,
+		}).Set(float64(count))
+	}
+}
+
+// SetSavedResources sets the CPU cores and memory bytes released by the workloads asleep of a
+// SleepInfo, zero once woken up, and updates the totals of its namespace. The core hours released
+// since the previous call for the SleepInfo are added to the counter of the namespace, so they grow on
+// every reconcile and not only on wake up.
+func (customMetrics Metrics) SetSavedResources(name, namespace string, cpu, memory float64) {
+	if customMetrics.SavedCPUCores == nil {
+		return
+	}
+	s := customMetrics.saved
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if previous, ok := s.requests[namespace][name]; ok && previous.cpu > 0 {
+		customMetrics.SavedCPUCoreHours.With(prometheus.Labels{"namespace": namespace}).Add(previous.cpu * now.Sub(previous.since).Hours())
+	}
+	if cpu == 0 && memory == 0 {
+		delete(s.requests[namespace], name)
+	} else {
+		if s.requests[namespace] == nil {
+			s.requests[namespace] = map[string]savedRequests{}
+		}
+		s.requests[namespace][name] = savedRequests{cpu: cpu, memory: memory, since: now}
+	}
+	totalCPU, totalMemory := 0.0, 0.0
+	for _, requests := range s.requests[namespace] {
+		totalCPU += requests.cpu
+		totalMemory += requests.memory
+	}
+	if len(s.requests[namespace]) == 0 {
+		delete(s.requests, namespace)
+		customMetrics.SavedCPUCores.Delete(prometheus.Labels{"namespace": namespace})
+		customMetrics.SavedMemoryBytes.Delete(prometheus.Labels{"namespace": namespace})
+		return
+	}
+	customMetrics.SavedCPUCores.With(prometheus.Labels{"namespace": namespace}).Set(totalCPU)
+	customMetrics.SavedMemoryBytes.With(prometheus.Labels{"namespace": namespace}).Set(totalMemory)
+}
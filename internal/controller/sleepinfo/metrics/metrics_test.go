@@ -3,6 +3,7 @@ package metrics
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -20,6 +21,34 @@ func getAndUseMetrics() Metrics {
 		"name":      "test_name",
 		"namespace": "test_namespace",
 	}).Set(1)
+	m.FailedResources.With(prometheus.Labels{
+		"name":      "test_name",
+		"namespace": "test_namespace",
+		"operation": "SLEEP",
+		"kind":      "Deployment",
+	}).Set(3)
+	m.RestoreDataBytes.With(prometheus.Labels{
+		"name":      "test_name",
+		"namespace": "test_namespace",
+	}).Set(2048)
+	m.SetSuspendedResources("test_name", "test_namespace", map[string]int32{"Deployment": 2})
+	m.OperationDuration.With(prometheus.Labels{
+		"name":      "test_name",
+		"namespace": "test_namespace",
+		"operation": "SLEEP",
+	}).Observe(0.3)
+	m.TargetOperationDuration.With(prometheus.Labels{
+		"name":      "test_name",
+		"namespace": "test_namespace",
+		"operation": "SLEEP",
+		"target":    "Deployment.apps",
+	}).Observe(0.2)
+	m.OperationErrors.With(prometheus.Labels{
+		"operation": "WAKE_UP",
+		"kind":      "Deployment",
+		"reason":    "Drift",
+	}).Add(2)
+	m.SetSavedResources("test_name", "test_namespace", 1.5, 1024)
 
 	return m
 }
@@ -39,6 +68,150 @@ func TestMetrics(t *testing.T) {
 		`)
 		require.NoError(t, testutil.CollectAndCompare(m.CurrentSleepInfo, buf))
 	})
+
+	t.Run("FailedResources", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.FailedResources)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_failed_resources Resources the last operation of the SleepInfo failed to sleep or wake up
+		# TYPE test_prefix_failed_resources gauge
+		test_prefix_failed_resources{kind="Deployment",name="test_name",namespace="test_namespace",operation="SLEEP"} 3
+		`)
+		require.NoError(t, testutil.CollectAndCompare(m.FailedResources, buf))
+	})
+
+	t.Run("RestoreDataBytes", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.RestoreDataBytes)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_restore_data_bytes Size in bytes of the compressed restore patches stored in the secret of the SleepInfo
+		# TYPE test_prefix_restore_data_bytes gauge
+		test_prefix_restore_data_bytes{name="test_name",namespace="test_namespace"} 2048
+		`)
+		require.NoError(t, testutil.CollectAndCompare(m.RestoreDataBytes, buf))
+	})
+
+	t.Run("SuspendedResources", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.SuspendedResources)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_suspended_resources Resources currently asleep in the namespace
+		# TYPE test_prefix_suspended_resources gauge
+		test_prefix_suspended_resources{kind="Deployment",namespace="test_namespace"} 2
+		`)
+		require.NoError(t, testutil.CollectAndCompare(m.SuspendedResources, buf))
+	})
+
+	t.Run("SuspendedResources sums the SleepInfos of the namespace", func(t *testing.T) {
+		m := getAndUseMetrics()
+		m.SetSuspendedResources("other", "test_namespace", map[string]int32{"Deployment": 1, "CronJob": 4})
+		m.SetSuspendedResources("other", "other_namespace", map[string]int32{"StatefulSet": 1})
+
+		require.Equal(t, float64(3), testutil.ToFloat64(m.SuspendedResources.WithLabelValues("test_namespace", "Deployment")))
+		require.Equal(t, float64(4), testutil.ToFloat64(m.SuspendedResources.WithLabelValues("test_namespace", "CronJob")))
+
+		m.SetSuspendedResources("other", "test_namespace", nil)
+		m.SetSuspendedResources("other", "other_namespace", nil)
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_suspended_resources Resources currently asleep in the namespace
+		# TYPE test_prefix_suspended_resources gauge
+		test_prefix_suspended_resources{kind="Deployment",namespace="test_namespace"} 2
+		`)
+		require.NoError(t, testutil.CollectAndCompare(m.SuspendedResources, buf))
+	})
+
+	t.Run("OperationDuration", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.OperationDuration)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		require.Equal(t, 1, testutil.CollectAndCount(m.OperationDuration, "test_prefix_operation_duration_seconds"))
+		m.OperationDuration.With(prometheus.Labels{
+			"name":      "test_name",
+			"namespace": "test_namespace",
+			"operation": "WAKE_UP",
+		}).Observe(1.5)
+		require.Equal(t, 2, testutil.CollectAndCount(m.OperationDuration, "test_prefix_operation_duration_seconds"))
+	})
+
+	t.Run("TargetOperationDuration", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.TargetOperationDuration)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		require.Equal(t, 1, testutil.CollectAndCount(m.TargetOperationDuration, "test_prefix_target_operation_duration_seconds"))
+	})
+
+	t.Run("OperationErrors", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		prob, err := testutil.CollectAndLint(m.OperationErrors)
+		require.NoError(t, err)
+		require.Nil(t, prob)
+
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_operation_errors_total Resources failed to sleep or wake up, by kind and reason
+		# TYPE test_prefix_operation_errors_total counter
+		test_prefix_operation_errors_total{kind="Deployment",operation="WAKE_UP",reason="Drift"} 2
+		`)
+		require.NoError(t, testutil.CollectAndCompare(m.OperationErrors, buf))
+	})
+
+	t.Run("SavedResources", func(t *testing.T) {
+		m := getAndUseMetrics()
+
+		for _, collector := range []prometheus.Collector{m.SavedCPUCores, m.SavedMemoryBytes} {
+			prob, err := testutil.CollectAndLint(collector)
+			require.NoError(t, err)
+			require.Nil(t, prob)
+		}
+
+		buf := bytes.NewBufferString(`
+		# HELP test_prefix_saved_cpu_cores CPU cores requested by the workloads currently asleep in the namespace
+		# TYPE test_prefix_saved_cpu_cores gauge
+		test_prefix_saved_cpu_cores{namespace="test_namespace"} 1.5
+		# HELP test_prefix_saved_memory_bytes Memory bytes requested by the workloads currently asleep in the namespace
+		# TYPE test_prefix_saved_memory_bytes gauge
+		test_prefix_saved_memory_bytes{namespace="test_namespace"} 1024
+		`)
+		registry := prometheus.NewPedanticRegistry()
+		registry.MustRegister(m.SavedCPUCores, m.SavedMemoryBytes)
+		require.NoError(t, testutil.GatherAndCompare(registry, buf))
+	})
+
+	t.Run("SavedCPUCoreHours grows while asleep", func(t *testing.T) {
+		m := getAndUseMetrics()
+		start := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+		now := start
+		m.saved.now = func() time.Time { return now }
+
+		m.SetSavedResources("other", "test_namespace", 2, 0)
+		now = start.Add(90 * time.Minute)
+		m.SetSavedResources("other", "test_namespace", 2, 0)
+		require.Equal(t, float64(3), testutil.ToFloat64(m.SavedCPUCoreHours.WithLabelValues("test_namespace")))
+		require.Equal(t, float64(3.5), testutil.ToFloat64(m.SavedCPUCores.WithLabelValues("test_namespace")))
+
+		now = start.Add(2 * time.Hour)
+		m.SetSavedResources("other", "test_namespace", 0, 0)
+		require.Equal(t, float64(4), testutil.ToFloat64(m.SavedCPUCoreHours.WithLabelValues("test_namespace")))
+		require.Equal(t, float64(1.5), testutil.ToFloat64(m.SavedCPUCores.WithLabelValues("test_namespace")))
+	})
 }
 
 func TestSetupMetricsAndRegister(t *testing.T) {
@@ -47,5 +220,5 @@ func TestSetupMetricsAndRegister(t *testing.T) {
 
 	count, err := testutil.GatherAndCount(registry)
 	require.NoError(t, err)
-	require.Equal(t, 1, count)
+	require.Equal(t, 9, count)
 }
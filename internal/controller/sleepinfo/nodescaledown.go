@@ -0,0 +1,231 @@
+package sleepinfo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// nodeScaleDownAnnotation is the action on the nodes released by a sleep, reverted before the wake up
+	nodeScaleDownAnnotation = "kube-green.com/node-scale-down"
+	// scaleDownDisabledAnnotation prevents cluster-autoscaler from removing a node
+	scaleDownDisabledAnnotation = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+	// nodeNameField selects the pods of a node
+	nodeNameField = "spec.nodeName"
+
+	// nodeScaleDownPollInterval is the interval between the checks of the nodes still running workloads
+	nodeScaleDownPollInterval = 30 * time.Second
+	// nodeScaleDownTimeout is how long after the sleep the nodes still running workloads are checked
+	nodeScaleDownTimeout = 10 * time.Minute
+)
+
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=list
+
+// scaleDownNodes releases the nodes of the SleepInfo asleep since sleptAt left without workloads. The
+// pods slept take a while to terminate, and the other SleepInfos of the node pool may sleep later, so
+// the nodes still running workloads are checked again every nodeScaleDownPollInterval for
+// nodeScaleDownTimeout: it returns the time of the next check, 0 when none. The nodes are not required
+// by the sleep, so its errors are reported in the log and an Event.
+func (r *SleepInfoReconciler) scaleDownNodes(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, sleptAt, now time.Time) time.Duration {
+	if !r.NodeScaleDown || sleepInfo.Spec.NodeScaleDown == nil || sleepInfo.IsDryRun() || !now.Before(sleptAt.Add(nodeScaleDownTimeout)) {
+		return 0
+	}
+	nodes := &v1.NodeList{}
+	if err := r.List(ctx, nodes, client.MatchingLabels(sleepInfo.Spec.NodeScaleDown.NodeSelector)); err != nil {
+		r.nodeScaleDownFailed(log, sleepInfo, err)
+		return 0
+	}
+	action := sleepInfo.Spec.NodeScaleDown.GetAction()
+	released := slices.Clone(sleepInfo.Status.ScaledDownNodes)
+	busy := false
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		// Released by this sleep or by another SleepInfo of the node pool
+		if _, ok := node.Annotations[nodeScaleDownAnnotation]; ok {
+			continue
+		}
+		hasWorkloads, err := r.hasWorkloads(ctx, node.Name)
+		if err != nil {
+			r.nodeScaleDownFailed(log, sleepInfo, err)
+			continue
+		}
+		if hasWorkloads {
+			busy = true
+			continue
+		}
+		changed, err := r.releaseNode(ctx, node, action)
+		if err != nil {
+			r.nodeScaleDownFailed(log, sleepInfo, err)
+			continue
+		}
+		if changed && !slices.Contains(released, node.Name) {
+			log.Info("node released", "node", node.Name, "action", action)
+			released = append(released, node.Name)
+		}
+	}
+	r.setScaledDownNodes(ctx, log, sleepInfo, released)
+	if busy {
+		return min(nodeScaleDownPollInterval, sleptAt.Add(nodeScaleDownTimeout).Sub(now))
+	}
+	return 0
+}
+
+// restoreNodes reverts the action on the nodes released by the sleeps before the wake up: the nodes
+// of the node pool, and those listed in the status if the node pool changed meanwhile
+func (r *SleepInfoReconciler) restoreNodes(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	if !r.NodeScaleDown || (sleepInfo.Spec.NodeScaleDown == nil && len(sleepInfo.Status.ScaledDownNodes) == 0) {
+		return
+	}
+	nodes := &v1.NodeList{}
+	if sleepInfo.Spec.NodeScaleDown != nil {
+		if err := r.List(ctx, nodes, client.MatchingLabels(sleepInfo.Spec.NodeScaleDown.NodeSelector)); err != nil {
+			r.nodeScaleDownFailed(log, sleepInfo, err)
+			return
+		}
+	}
+	for _, name := range sleepInfo.Status.ScaledDownNodes {
+		if slices.ContainsFunc(nodes.Items, func(node v1.Node) bool { return node.Name == name }) {
+			continue
+		}
+		node := v1.Node{}
+		// The nodes released to cluster-autoscaler are usually removed
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, &node); err != nil {
+			if !apierrors.IsNotFound(err) {
+				r.nodeScaleDownFailed(log, sleepInfo, err)
+			}
+			continue
+		}
+		nodes.Items = append(nodes.Items, node)
+	}
+	failed := false
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		changed, err := r.restoreNode(ctx, node)
+		if err != nil {
+			r.nodeScaleDownFailed(log, sleepInfo, err)
+			failed = true
+			continue
+		}
+		if changed {
+			log.Info("node restored", "node", node.Name)
+		}
+	}
+	if !failed && sleepInfo.DeletionTimestamp.IsZero() {
+		r.setScaledDownNodes(ctx, log, sleepInfo, nil)
+	}
+}
+
+// hasWorkloads returns true if the node runs pods other than the DaemonSet and static pods
+func (r *SleepInfoReconciler) hasWorkloads(ctx context.Context, nodeName string) (bool, error) {
+	pods := &v1.PodList{}
+	if err := r.List(ctx, pods, client.MatchingFields{nodeNameField: nodeName}); err != nil {
+		return false, err
+	}
+	for _, pod := range pods.Items {
+		if isWorkloadPod(pod) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isWorkloadPod(pod v1.Pod) bool {
+	if !pod.DeletionTimestamp.IsZero() || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[v1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	owner := metav1.GetControllerOf(&pod)
+	return owner == nil || owner.Kind != "DaemonSet"
+}
+
+// releaseNode applies the action to the node, recording it in the node. It returns false if the node
+// is already unschedulable, tainted or not protected from cluster-autoscaler, to keep it as it is.
+func (r *SleepInfoReconciler) releaseNode(ctx context.Context, node *v1.Node, action kubegreenv1alpha1.NodeScaleDownAction) (bool, error) {
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	switch action {
+	case kubegreenv1alpha1.NodeScaleDownCordon:
+		if node.Spec.Unschedulable {
+			return false, nil
+		}
+		node.Spec.Unschedulable = true
+	case kubegreenv1alpha1.NodeScaleDownTaint:
+		if slices.ContainsFunc(node.Spec.Taints, isNodeAsleepTaint) {
+			return false, nil
+		}
+		node.Spec.Taints = append(node.Spec.Taints, v1.Taint{Key: kubegreenv1alpha1.NodeAsleepTaint, Effect: v1.TaintEffectNoSchedule})
+	case kubegreenv1alpha1.NodeScaleDownScaleDown:
+		if node.Annotations[scaleDownDisabledAnnotation] != "true" {
+			return false, nil
+		}
+		delete(node.Annotations, scaleDownDisabledAnnotation)
+	default:
+		return false, nil
+	}
+	metav1.SetMetaDataAnnotation(&node.ObjectMeta, nodeScaleDownAnnotation, string(action))
+	return true, r.Patch(ctx, node, patch)
+}
+
+// restoreNode reverts the action recorded in the node. It returns false if the node was not released.
+func (r *SleepInfoReconciler) restoreNode(ctx context.Context, node *v1.Node) (bool, error) {
+	action, ok := node.Annotations[nodeScaleDownAnnotation]
+	if !ok {
+		return false, nil
+	}
+	patch := client.MergeFromWithOptions(node.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	switch kubegreenv1alpha1.NodeScaleDownAction(action) {
+	case kubegreenv1alpha1.NodeScaleDownCordon:
+		node.Spec.Unschedulable = false
+	case kubegreenv1alpha1.NodeScaleDownTaint:
+		node.Spec.Taints = slices.DeleteFunc(node.Spec.Taints, isNodeAsleepTaint)
+	case kubegreenv1alpha1.NodeScaleDownScaleDown:
+		node.Annotations[scaleDownDisabledAnnotation] = "true"
+	}
+	delete(node.Annotations, nodeScaleDownAnnotation)
+	return true, r.Patch(ctx, node, patch)
+}
+
+func isNodeAsleepTaint(taint v1.Taint) bool {
+	return taint.Key == kubegreenv1alpha1.NodeAsleepTaint
+}
+
+func (r *SleepInfoReconciler) nodeScaleDownFailed(log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, err error) {
+	log.Error(err, "fails to scale down nodes")
+	if r.Recorder != nil {
+		r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "NodeScaleDownFailed", "node scale-down failed: %s", err)
+	}
+}
+
+// setScaledDownNodes records the nodes released by the sleep in the status of the SleepInfo
+func (r *SleepInfoReconciler) setScaledDownNodes(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, nodes []string) {
+	if slices.Equal(sleepInfo.Status.ScaledDownNodes, nodes) {
+		return
+	}
+	sleepInfo.Status.ScaledDownNodes = nodes
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), latest); err != nil {
+			return err
+		}
+		if slices.Equal(latest.Status.ScaledDownNodes, nodes) {
+			return nil
+		}
+		latest.Status.ScaledDownNodes = nodes
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		log.Error(err, "fails to update scaled down nodes status")
+	}
+}
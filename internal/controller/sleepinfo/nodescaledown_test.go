@@ -0,0 +1,184 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNodeScaleDown(t *testing.T) {
+	sleptAt := time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	poolLabels := map[string]string{"node-pool": "bdadevdat"}
+	newNode := func(name string, annotations map[string]string) *v1.Node {
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: poolLabels, Annotations: annotations}}
+	}
+	newPod := func(name, nodeName string, owner *metav1.OwnerReference) *v1.Pod {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "bdadevdat-apps"},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+		}
+		if owner != nil {
+			pod.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return pod
+	}
+	daemonSet := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "node-exporter", UID: "1", Controller: getPtr(true)}
+	newSleepInfo := func(action kubegreenv1alpha1.NodeScaleDownAction) *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "bdadevdat-apps"},
+			Spec: kubegreenv1alpha1.SleepInfoSpec{
+				SleepTime:     "20:00",
+				WakeUpTime:    "08:00",
+				NodeScaleDown: &kubegreenv1alpha1.NodeScaleDown{NodeSelector: poolLabels, Action: action},
+			},
+		}
+	}
+	newReconciler := func(objects ...client.Object) (SleepInfoReconciler, client.Client) {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&kubegreenv1alpha1.SleepInfo{}).
+			WithIndex(&v1.Pod{}, nodeNameField, func(obj client.Object) []string {
+				return []string{obj.(*v1.Pod).Spec.NodeName}
+			}).
+			WithObjects(objects...).
+			Build()
+		return SleepInfoReconciler{Client: fakeClient, Recorder: record.NewFakeRecorder(10), NodeScaleDown: true}, fakeClient
+	}
+	getNode := func(t *testing.T, c client.Client, name string) *v1.Node {
+		t.Helper()
+		node := &v1.Node{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, node))
+		return node
+	}
+	scaledDownNodes := func(t *testing.T, c client.Client) []string {
+		t.Helper()
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "bdadevdat-apps", Name: "sleep"}, updated))
+		return updated.Status.ScaledDownNodes
+	}
+
+	t.Run("cordons the nodes without workloads until the wake up", func(t *testing.T) {
+		sleepInfo := newSleepInfo("")
+		terminating := newPod("terminating", "node-2", nil)
+		terminating.DeletionTimestamp = &metav1.Time{Time: sleptAt}
+		terminating.Finalizers = []string{"kube-green.com/test"}
+		r, c := newReconciler(sleepInfo,
+			newNode("node-1", nil), newNode("node-2", nil), newNode("node-3", nil),
+			&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other-pool"}},
+			newPod("exporter", "node-1", daemonSet), terminating, newPod("api", "node-3", nil),
+		)
+
+		requeue := r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+
+		require.Equal(t, nodeScaleDownPollInterval, requeue)
+		require.True(t, getNode(t, c, "node-1").Spec.Unschedulable)
+		require.True(t, getNode(t, c, "node-2").Spec.Unschedulable)
+		require.False(t, getNode(t, c, "node-3").Spec.Unschedulable)
+		require.False(t, getNode(t, c, "other-pool").Spec.Unschedulable)
+		require.Equal(t, "Cordon", getNode(t, c, "node-1").Annotations[nodeScaleDownAnnotation])
+		require.Equal(t, []string{"node-1", "node-2"}, scaledDownNodes(t, c))
+
+		// The pods of node-3 terminated
+		require.NoError(t, c.Delete(context.Background(), newPod("api", "node-3", nil)))
+		requeue = r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt.Add(time.Minute))
+		require.Zero(t, requeue)
+		require.True(t, getNode(t, c, "node-3").Spec.Unschedulable)
+		require.Equal(t, []string{"node-1", "node-2", "node-3"}, scaledDownNodes(t, c))
+
+		r.restoreNodes(context.Background(), logr.Discard(), sleepInfo)
+		for _, name := range []string{"node-1", "node-2", "node-3"} {
+			node := getNode(t, c, name)
+			require.False(t, node.Spec.Unschedulable)
+			require.NotContains(t, node.Annotations, nodeScaleDownAnnotation)
+		}
+		require.Empty(t, scaledDownNodes(t, c))
+	})
+
+	t.Run("keeps the nodes cordoned by others", func(t *testing.T) {
+		sleepInfo := newSleepInfo(kubegreenv1alpha1.NodeScaleDownCordon)
+		cordoned := newNode("node-1", nil)
+		cordoned.Spec.Unschedulable = true
+		r, c := newReconciler(sleepInfo, cordoned)
+
+		r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+		require.Empty(t, scaledDownNodes(t, c))
+
+		r.restoreNodes(context.Background(), logr.Discard(), sleepInfo)
+		require.True(t, getNode(t, c, "node-1").Spec.Unschedulable)
+	})
+
+	t.Run("taints the nodes", func(t *testing.T) {
+		sleepInfo := newSleepInfo(kubegreenv1alpha1.NodeScaleDownTaint)
+		node := newNode("node-1", nil)
+		node.Spec.Taints = []v1.Taint{{Key: "dedicated", Value: "bdadevdat", Effect: v1.TaintEffectNoSchedule}}
+		r, c := newReconciler(sleepInfo, node)
+
+		r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+		require.Equal(t, []v1.Taint{
+			{Key: "dedicated", Value: "bdadevdat", Effect: v1.TaintEffectNoSchedule},
+			{Key: kubegreenv1alpha1.NodeAsleepTaint, Effect: v1.TaintEffectNoSchedule},
+		}, getNode(t, c, "node-1").Spec.Taints)
+
+		r.restoreNodes(context.Background(), logr.Discard(), sleepInfo)
+		require.Equal(t, []v1.Taint{{Key: "dedicated", Value: "bdadevdat", Effect: v1.TaintEffectNoSchedule}}, getNode(t, c, "node-1").Spec.Taints)
+	})
+
+	t.Run("lets cluster-autoscaler remove the nodes", func(t *testing.T) {
+		sleepInfo := newSleepInfo(kubegreenv1alpha1.NodeScaleDownScaleDown)
+		r, c := newReconciler(sleepInfo,
+			newNode("node-1", map[string]string{scaleDownDisabledAnnotation: "true"}),
+			newNode("node-2", map[string]string{scaleDownDisabledAnnotation: "true"}),
+			newNode("node-3", nil),
+		)
+
+		r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+		require.NotContains(t, getNode(t, c, "node-1").Annotations, scaleDownDisabledAnnotation)
+		require.Equal(t, []string{"node-1", "node-2"}, scaledDownNodes(t, c))
+
+		// cluster-autoscaler removed node-1
+		require.NoError(t, c.Delete(context.Background(), newNode("node-1", nil)))
+		sleepInfo.Spec.NodeScaleDown.NodeSelector = map[string]string{"node-pool": "renamed"}
+		r.restoreNodes(context.Background(), logr.Discard(), sleepInfo)
+		require.Equal(t, "true", getNode(t, c, "node-2").Annotations[scaleDownDisabledAnnotation])
+		require.NotContains(t, getNode(t, c, "node-3").Annotations, scaleDownDisabledAnnotation)
+		require.Empty(t, scaledDownNodes(t, c))
+	})
+
+	t.Run("stops checking the nodes after the timeout", func(t *testing.T) {
+		sleepInfo := newSleepInfo("")
+		r, c := newReconciler(sleepInfo, newNode("node-1", nil), newPod("api", "node-1", nil))
+
+		require.Equal(t, 10*time.Second, r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt.Add(nodeScaleDownTimeout-10*time.Second)))
+		require.Zero(t, r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt.Add(nodeScaleDownTimeout)))
+		require.False(t, getNode(t, c, "node-1").Spec.Unschedulable)
+	})
+
+	t.Run("disabled in the manager or dry run", func(t *testing.T) {
+		sleepInfo := newSleepInfo("")
+		r, c := newReconciler(sleepInfo, newNode("node-1", nil))
+		r.NodeScaleDown = false
+		r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+		require.False(t, getNode(t, c, "node-1").Spec.Unschedulable)
+
+		r.NodeScaleDown = true
+		sleepInfo.Spec.DryRun = getPtr(true)
+		r.scaleDownNodes(context.Background(), logr.Discard(), sleepInfo, sleptAt, sleptAt)
+		require.False(t, getNode(t, c, "node-1").Spec.Unschedulable)
+	})
+}
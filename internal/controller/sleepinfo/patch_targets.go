@@ -0,0 +1,72 @@
+package sleepinfo
+
+import (
+	"context"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/patchtargets"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+)
+
+// resourceClient returns the client of the resources of sleepInfo for the current operation, with
+// the patches of the operation and of the configured patch targets
+func (r *SleepInfoReconciler) resourceClient(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, sleepInfoData SleepInfoData) resource.ResourceClient {
+	resourceClient := resource.ResourceClient{
+		Client:           r.Client,
+		SleepInfo:        r.withOperationPatches(log, sleepInfo, sleepInfoData),
+		Log:              log,
+		FieldManagerName: r.ManagerName,
+		DryRun:           sleepInfo.IsDryRun(),
+	}
+	if !resourceClient.DryRun {
+		resourceClient.TargetDone = r.observeTargetDuration(sleepInfo, sleepInfoData.CurrentOperationType)
+		resourceClient.OperationError = r.countOperationError(sleepInfoData.CurrentOperationType)
+	}
+	targets, err := r.PatchTargets.Load(ctx)
+	if err != nil {
+		log.Error(err, "fails to load some patch targets, skipped")
+	}
+	addPatchTargets(log, &resourceClient, targets, sleepInfoData)
+	return resourceClient
+}
+
+// addPatchTargets adds the patches of the configured targets for the current operation. They follow
+// the SleepInfo patches, so they override the built-in patch of their target when it is enabled.
+// On wake up, a target without wakePatch is restored as any other resource, except the built-in
+// targets with a wake patch, which keep it.
+func addPatchTargets(log logr.Logger, resourceClient *resource.ResourceClient, targets []patchtargets.Target, sleepInfoData SleepInfoData) {
+	enabled := map[kubegreenv1alpha1.PatchTarget]bool{}
+	for _, patch := range resourceClient.SleepInfo.GetPatches() {
+		enabled[patch.Target] = true
+	}
+	for _, target := range targets {
+		patchTarget := target.PatchTarget()
+		if target.IsBuiltIn() && !enabled[patchTarget] {
+			continue
+		}
+		patch := target.SleepPatch
+		if sleepInfoData.IsWakeUpOperation() {
+			if target.WakePatch != "" {
+				patch = target.WakePatch
+				if resourceClient.WakePatchTargets == nil {
+					resourceClient.WakePatchTargets = map[kubegreenv1alpha1.PatchTarget]bool{}
+				}
+				resourceClient.WakePatchTargets[patchTarget] = true
+			} else if target.HasBuiltInWakePatch() {
+				patch = ""
+			}
+		}
+		if patch != "" {
+			resourceClient.SleepInfo.Spec.Patches = append(resourceClient.SleepInfo.Spec.Patches, kubegreenv1alpha1.Patch{Target: patchTarget, Patch: patch})
+			log.V(1).Info("added patch target", "group", target.Group, "kind", target.Kind)
+		}
+		if target.IgnoreOwnerReferences {
+			if resourceClient.IgnoreOwnerTargets == nil {
+				resourceClient.IgnoreOwnerTargets = map[kubegreenv1alpha1.PatchTarget]bool{}
+			}
+			resourceClient.IgnoreOwnerTargets[patchTarget] = true
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/metrics"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/patchtargets"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAddPatchTargets(t *testing.T) {
+	redis := patchtargets.Target{
+		Group:                 "redis.example.com",
+		Kind:                  "RedisCluster",
+		SleepPatch:            "[{op: add, path: /spec/suspended, value: true}]",
+		WakePatch:             "[{op: add, path: /spec/suspended, value: false}]",
+		IgnoreOwnerReferences: true,
+	}
+	pgCluster := patchtargets.Target{
+		Group:      kubegreenv1alpha1.PgClusterTarget.Group,
+		Kind:       kubegreenv1alpha1.PgClusterTarget.Kind,
+		SleepPatch: "[{op: add, path: /metadata/annotations/shutdown, value: 'true'}]",
+	}
+	patchesOf := func(resourceClient *resource.ResourceClient, target kubegreenv1alpha1.PatchTarget) []string {
+		patches := []string{}
+		for _, patch := range resourceClient.SleepInfo.Spec.Patches {
+			if patch.Target == target {
+				patches = append(patches, patch.Patch)
+			}
+		}
+		return patches
+	}
+	newClient := func(suspendPostgres bool, operation string) *resource.ResourceClient {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{
+			Spec: kubegreenv1alpha1.SleepInfoSpec{SuspendStatefulSetsPostgres: getPtr(suspendPostgres)},
+		}
+		r := SleepInfoReconciler{}
+		resourceClient := r.resourceClient(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: operation})
+		return &resourceClient
+	}
+
+	t.Run("adds the sleep patch of new targets", func(t *testing.T) {
+		resourceClient := newClient(false, sleepOperation)
+		addPatchTargets(logr.Discard(), resourceClient, []patchtargets.Target{redis, pgCluster}, SleepInfoData{CurrentOperationType: sleepOperation})
+
+		require.Equal(t, []string{redis.SleepPatch}, patchesOf(resourceClient, redis.PatchTarget()))
+		require.Empty(t, patchesOf(resourceClient, kubegreenv1alpha1.PgClusterTarget), "disabled built-in target")
+		require.True(t, resourceClient.IgnoreOwnerTargets[redis.PatchTarget()])
+		require.Empty(t, resourceClient.WakePatchTargets)
+	})
+
+	t.Run("overrides an enabled built-in target", func(t *testing.T) {
+		resourceClient := newClient(true, sleepOperation)
+		addPatchTargets(logr.Discard(), resourceClient, []patchtargets.Target{pgCluster}, SleepInfoData{CurrentOperationType: sleepOperation})
+
+		patches := patchesOf(resourceClient, kubegreenv1alpha1.PgClusterTarget)
+		require.Equal(t, pgCluster.SleepPatch, patches[len(patches)-1])
+	})
+
+	t.Run("applies the wake patch on wake up", func(t *testing.T) {
+		resourceClient := newClient(true, wakeUpOperation)
+		builtInWakePatches := patchesOf(resourceClient, kubegreenv1alpha1.PgClusterTarget)
+		addPatchTargets(logr.Discard(), resourceClient, []patchtargets.Target{redis, pgCluster}, SleepInfoData{CurrentOperationType: wakeUpOperation})
+
+		require.Equal(t, []string{redis.WakePatch}, patchesOf(resourceClient, redis.PatchTarget()))
+		require.True(t, resourceClient.WakePatchTargets[redis.PatchTarget()])
+		require.Equal(t, builtInWakePatches, patchesOf(resourceClient, kubegreenv1alpha1.PgClusterTarget), "keeps the built-in wake patch")
+	})
+}
+
+func TestResourceClientWithPatchTargets(t *testing.T) {
+	namespace := "my-namespace"
+	owned := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "owned",
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "example.com/v1", Kind: "App", Name: "app", UID: "uid", Controller: getPtr(true)},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: getPtr(int32(2))},
+	}
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-green-patch-targets", Namespace: "kube-green"},
+		Data: map[string]string{patchtargets.TargetsKey: `
+- group: apps
+  kind: Deployment
+  ignoreOwnerReferences: true
+  sleepPatch: |
+    - op: add
+      path: /spec/replicas
+      value: 0
+`},
+	}
+	fakeClient := fakeDeploymentClient(owned, configMap)
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace}}
+	replicas := func() int32 {
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(owned), res))
+		return *res.Spec.Replicas
+	}
+	sleep := func(r SleepInfoReconciler) {
+		resources, err := jsonpatch.NewResources(context.Background(), r.resourceClient(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: sleepOperation}), namespace, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, resources.Sleep(context.Background()))
+	}
+
+	t.Run("skips the resources managed by another controller", func(t *testing.T) {
+		sleep(SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName})
+		require.Equal(t, int32(2), replicas())
+	})
+
+	t.Run("patches them for targets ignoring owner references", func(t *testing.T) {
+		sleep(SleepInfoReconciler{
+			Client:       fakeClient,
+			ManagerName:  testFieldManagerName,
+			PatchTargets: &patchtargets.Loader{Client: fakeClient, ConfigMap: "kube-green-patch-targets", Namespace: "kube-green"},
+		})
+		require.Equal(t, int32(0), replicas())
+	})
+
+	t.Run("records the duration of every patch target", func(t *testing.T) {
+		m := metrics.SetupMetricsOrDie("test")
+		sleep(SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName, Metrics: m})
+
+		require.Equal(t, 1, testutil.CollectAndCount(m.TargetOperationDuration))
+		require.Equal(t, 1, m.TargetOperationDuration.DeletePartialMatch(prometheus.Labels{
+			"name":      "sleep",
+			"namespace": namespace,
+			"operation": sleepOperation,
+			"target":    "Deployment.apps",
+		}))
+	})
+}
+
+func TestResourceAnnotationPatches(t *testing.T) {
+	namespace := "my-namespace"
+	annotated := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "annotated",
+			Namespace: namespace,
+			Annotations: map[string]string{
+				kubegreenv1alpha1.SleepPatchAnnotation:            "[{op: add, path: /spec/replicas, value: 1}]",
+				kubegreenv1alpha1.WakePatchAnnotation:             "[{op: add, path: /spec/replicas, value: 5}]",
+				kubegreenv1alpha1.IgnoreOwnerReferencesAnnotation: "true",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "example.com/v1", Kind: "App", Name: "app", UID: "uid", Controller: getPtr(true)},
+			},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: getPtr(int32(3))},
+	}
+	fakeClient := fakeDeploymentClient(annotated)
+	r := SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName}
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace}}
+	replicas := func() int32 {
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(annotated), res))
+		return *res.Spec.Replicas
+	}
+
+	resources, err := jsonpatch.NewResources(context.Background(), r.resourceClient(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: sleepOperation}), namespace, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, resources.Sleep(context.Background()))
+	require.Equal(t, int32(1), replicas())
+
+	restorePatches := map[string]jsonpatch.RestorePatches{
+		kubegreenv1alpha1.DeploymentTarget.String(): {"annotated": `{"spec":{"replicas":3}}`},
+	}
+	resources, err = jsonpatch.NewResources(context.Background(), r.resourceClient(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation}), namespace, restorePatches, nil)
+	require.NoError(t, err)
+	require.NoError(t, resources.WakeUp(context.Background()))
+	require.Equal(t, int32(5), replicas())
+}
+
+func TestResourceClientOperationErrors(t *testing.T) {
+	namespace := "my-namespace"
+	deployment := func(name string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(replicas)},
+		}
+	}
+	fakeClient := fakeDeploymentClient(deployment("lost", 0), deployment("created", 1), deployment("changed", 3))
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace}}
+	m := metrics.SetupMetricsOrDie("test")
+	r := SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName, Metrics: m}
+
+	resources, err := jsonpatch.NewResources(context.Background(), r.resourceClient(context.Background(), logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation}), namespace,
+		map[string]jsonpatch.RestorePatches{"Deployment.apps": {"changed": `{"spec":{"replicas":2}}`}},
+		map[string]jsonpatch.SleptResourceGenerations{"Deployment.apps": {"lost": 1, "changed": 1}},
+	)
+	require.NoError(t, err)
+	require.NoError(t, resources.WakeUp(context.Background()))
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.OperationErrors.WithLabelValues(wakeUpOperation, "Deployment", resource.ErrorReasonMissingRestorePatch)))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.OperationErrors.WithLabelValues(wakeUpOperation, "Deployment", resource.ErrorReasonDrift)))
+	require.Equal(t, 2, testutil.CollectAndCount(m.OperationErrors))
+}
@@ -0,0 +1,249 @@
+package patchtargets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/patcher"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// TargetsKey is the ConfigMap key listing the patch targets
+const TargetsKey = "targets.yaml"
+
+var crdListGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinitionList"}
+
+// builtInTargets are the targets patched by the SleepInfo fields, e.g. suspendStatefulSetsPostgres.
+// A configured target overriding one of them is only patched when the SleepInfo enables it.
+var builtInTargets = []kubegreenv1alpha1.PatchTarget{
+	kubegreenv1alpha1.DeploymentTarget,
+	kubegreenv1alpha1.StatefulSetTarget,
+	kubegreenv1alpha1.CronJobTarget,
+	kubegreenv1alpha1.JobTarget,
+	kubegreenv1alpha1.HorizontalPodAutoscalerTarget,
+	kubegreenv1alpha1.KnativeServiceTarget,
+	kubegreenv1alpha1.ScaledObjectTarget,
+	kubegreenv1alpha1.PgBouncerTarget,
+	kubegreenv1alpha1.PgClusterTarget,
+	kubegreenv1alpha1.HDFSClusterTarget,
+	kubegreenv1alpha1.OsClusterTarget,
+	kubegreenv1alpha1.OsDashboardsTarget,
+	kubegreenv1alpha1.KafkaClusterTarget,
+}
+
+// builtInWakeTargets are the built-in targets woken up with a wake patch instead of their restore patch
+var builtInWakeTargets = []kubegreenv1alpha1.PatchTarget{
+	kubegreenv1alpha1.PgClusterTarget,
+	kubegreenv1alpha1.HDFSClusterTarget,
+	kubegreenv1alpha1.OsClusterTarget,
+	kubegreenv1alpha1.KafkaClusterTarget,
+}
+
+// Target is a resource kind slept and woken up with patches configured at runtime, e.g. the CRD of
+// a new operator. A target with the group and kind of a built-in one overrides its patches.
+type Target struct {
+	Group string `json:"group"`
+	Kind  string `json:"kind"`
+	// SleepPatch is applied on sleep, the wake up restores the resource as it was before it
+	SleepPatch string `json:"sleepPatch"`
+	// WakePatch, when set, is applied on wake up instead of restoring the resource
+	WakePatch string `json:"wakePatch,omitempty"`
+	// IgnoreOwnerReferences patches the resources even when they are managed by another controller
+	IgnoreOwnerReferences bool `json:"ignoreOwnerReferences,omitempty"`
+}
+
+// PatchTarget returns the group and kind of the target
+func (t Target) PatchTarget() kubegreenv1alpha1.PatchTarget {
+	return kubegreenv1alpha1.PatchTarget{Group: t.Group, Kind: t.Kind}
+}
+
+// IsBuiltIn returns true if the target overrides a built-in target
+func (t Target) IsBuiltIn() bool {
+	return containsTarget(builtInTargets, t.PatchTarget())
+}
+
+// HasBuiltInWakePatch returns true if the target overrides a built-in target with a wake patch,
+// which is kept on wake up when the target has no wakePatch
+func (t Target) HasBuiltInWakePatch() bool {
+	return containsTarget(builtInWakeTargets, t.PatchTarget())
+}
+
+func containsTarget(targets []kubegreenv1alpha1.PatchTarget, target kubegreenv1alpha1.PatchTarget) bool {
+	for _, item := range targets {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses and validates a YAML list of targets
+func Parse(data []byte) ([]Target, error) {
+	targets := []Target{}
+	if err := yaml.Unmarshal(data, &targets); err != nil {
+		return nil, fmt.Errorf("invalid patch targets: %w", err)
+	}
+	seen := map[kubegreenv1alpha1.PatchTarget]bool{}
+	for i, target := range targets {
+		if strings.TrimSpace(target.Kind) == "" {
+			return nil, fmt.Errorf("invalid patch targets: target %d has no kind", i)
+		}
+		if seen[target.PatchTarget()] {
+			return nil, fmt.Errorf("invalid patch targets: duplicated target %s", target.PatchTarget().GroupKind())
+		}
+		seen[target.PatchTarget()] = true
+		if strings.TrimSpace(target.SleepPatch) == "" {
+			return nil, fmt.Errorf("invalid patch targets: target %s has no sleepPatch", target.Kind)
+		}
+		if err := target.validatePatches(); err != nil {
+			return nil, fmt.Errorf("invalid patch targets: %w", err)
+		}
+	}
+	return targets, nil
+}
+
+func (t Target) validatePatches() error {
+	if _, err := patcher.New([]byte(t.SleepPatch)); err != nil {
+		return fmt.Errorf("sleepPatch of %s: %w", t.Kind, err)
+	}
+	if t.WakePatch == "" {
+		return nil
+	}
+	if _, err := patcher.New([]byte(t.WakePatch)); err != nil {
+		return fmt.Errorf("wakePatch of %s: %w", t.Kind, err)
+	}
+	return nil
+}
+
+// Loader reads the patch targets from a ConfigMap and from the annotated CustomResourceDefinitions
+// on every use, so operators are supported without restarting kube-green.
+type Loader struct {
+	Client    client.Reader
+	ConfigMap string
+	Namespace string
+	// Presets are the names of the presets whose targets are added, see PresetNames
+	Presets []string
+	// DiscoverCRDs adds the kinds of the CustomResourceDefinitions with the sleep patch annotation,
+	// found through RESTMapper
+	DiscoverCRDs bool
+	RESTMapper   meta.RESTMapper
+}
+
+// Load returns the targets of the presets, of the annotated CustomResourceDefinitions and of the
+// ConfigMap, each one overriding the previous ones. Invalid CustomResourceDefinitions are skipped:
+// the valid targets are returned with their error.
+func (l *Loader) Load(ctx context.Context) ([]Target, error) {
+	if l == nil {
+		return nil, nil
+	}
+	targets, presetsErr := PresetTargets(l.Presets)
+	var discoverErr error
+	if l.DiscoverCRDs {
+		var discovered []Target
+		discovered, discoverErr = l.discover(ctx)
+		targets = override(targets, discovered)
+	}
+	configured, err := l.loadConfigMap(ctx)
+	if err != nil {
+		return targets, errors.Join(presetsErr, discoverErr, err)
+	}
+	return override(targets, configured), errors.Join(presetsErr, discoverErr)
+}
+
+// override returns targets with the entries of overrides replacing those with the same group and kind
+func override(targets, overrides []Target) []Target {
+	if len(overrides) == 0 {
+		return targets
+	}
+	overridden := patchTargetsOf(overrides)
+	result := []Target{}
+	for _, target := range targets {
+		if !containsTarget(overridden, target.PatchTarget()) {
+			result = append(result, target)
+		}
+	}
+	return append(result, overrides...)
+}
+
+func patchTargetsOf(targets []Target) []kubegreenv1alpha1.PatchTarget {
+	result := make([]kubegreenv1alpha1.PatchTarget, 0, len(targets))
+	for _, target := range targets {
+		result = append(result, target.PatchTarget())
+	}
+	return result
+}
+
+// discover returns the targets of the CustomResourceDefinitions with the sleep patch annotation.
+// Only their metadata is read: the group and the plural come from their name, the kind from RESTMapper.
+func (l *Loader) discover(ctx context.Context) ([]Target, error) {
+	crds := &metav1.PartialObjectMetadataList{}
+	crds.SetGroupVersionKind(crdListGVK)
+	if err := l.Client.List(ctx, crds); err != nil {
+		return nil, fmt.Errorf("fails to list CustomResourceDefinitions: %w", err)
+	}
+	targets := []Target{}
+	var errs []error
+	for _, crd := range crds.Items {
+		annotations := crd.GetAnnotations()
+		if annotations[kubegreenv1alpha1.SleepPatchAnnotation] == "" {
+			continue
+		}
+		target, err := l.crdTarget(crd.GetName(), annotations)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CustomResourceDefinition %s: %w", crd.GetName(), err))
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets, errors.Join(errs...)
+}
+
+func (l *Loader) crdTarget(name string, annotations map[string]string) (Target, error) {
+	plural, group, ok := strings.Cut(name, ".")
+	if !ok {
+		return Target{}, fmt.Errorf("invalid name")
+	}
+	gvk, err := l.RESTMapper.KindFor(schema.GroupVersionResource{Group: group, Resource: plural})
+	if err != nil {
+		return Target{}, fmt.Errorf("fails to get kind: %w", err)
+	}
+	target := Target{
+		Group:                 group,
+		Kind:                  gvk.Kind,
+		SleepPatch:            annotations[kubegreenv1alpha1.SleepPatchAnnotation],
+		WakePatch:             annotations[kubegreenv1alpha1.WakePatchAnnotation],
+		IgnoreOwnerReferences: strings.EqualFold(annotations[kubegreenv1alpha1.IgnoreOwnerReferencesAnnotation], "true"),
+	}
+	if err := target.validatePatches(); err != nil {
+		return Target{}, err
+	}
+	return target, nil
+}
+
+func (l *Loader) loadConfigMap(ctx context.Context) ([]Target, error) {
+	if l.ConfigMap == "" {
+		return nil, nil
+	}
+	configMap := &v1.ConfigMap{}
+	if err := l.Client.Get(ctx, client.ObjectKey{Name: l.ConfigMap, Namespace: l.Namespace}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fails to get patch targets ConfigMap %s/%s: %w", l.Namespace, l.ConfigMap, err)
+	}
+	targets, err := Parse([]byte(configMap.Data[TargetsKey]))
+	if err != nil {
+		return nil, fmt.Errorf("ConfigMap %s/%s: %w", l.Namespace, l.ConfigMap, err)
+	}
+	return targets, nil
+}
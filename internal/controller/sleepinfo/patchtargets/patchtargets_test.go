@@ -0,0 +1,242 @@
+package patchtargets
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const targetsYAML = `
+- group: redis.example.com
+  kind: RedisCluster
+  ignoreOwnerReferences: true
+  sleepPatch: |
+    - op: add
+      path: /spec/suspended
+      value: true
+  wakePatch: |
+    - op: add
+      path: /spec/suspended
+      value: false
+- group: postgres.stratio.com
+  kind: PgBouncer
+  sleepPatch: |
+    - op: add
+      path: /spec/instances
+      value: 0
+`
+
+func TestParse(t *testing.T) {
+	t.Run("targets", func(t *testing.T) {
+		targets, err := Parse([]byte(targetsYAML))
+		require.NoError(t, err)
+		require.Len(t, targets, 2)
+		require.Equal(t, kubegreenv1alpha1.PatchTarget{Group: "redis.example.com", Kind: "RedisCluster"}, targets[0].PatchTarget())
+		require.True(t, targets[0].IgnoreOwnerReferences)
+		require.NotEmpty(t, targets[0].WakePatch)
+		require.False(t, targets[0].IsBuiltIn())
+		require.True(t, targets[1].IsBuiltIn())
+		require.False(t, targets[1].HasBuiltInWakePatch())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		targets, err := Parse(nil)
+		require.NoError(t, err)
+		require.Empty(t, targets)
+	})
+
+	tests := []struct {
+		name  string
+		data  string
+		error string
+	}{
+		{
+			name:  "without kind",
+			data:  "- group: redis.example.com\n  sleepPatch: '[]'",
+			error: "invalid patch targets: target 0 has no kind",
+		},
+		{
+			name:  "without sleep patch",
+			data:  "- group: redis.example.com\n  kind: RedisCluster",
+			error: "invalid patch targets: target RedisCluster has no sleepPatch",
+		},
+		{
+			name:  "duplicated",
+			data:  "- kind: RedisCluster\n  sleepPatch: '[]'\n- kind: RedisCluster\n  sleepPatch: '[]'",
+			error: "invalid patch targets: duplicated target RedisCluster",
+		},
+		{
+			name:  "invalid sleep patch",
+			data:  "- kind: RedisCluster\n  sleepPatch: 'op: add'",
+			error: "invalid patch targets: sleepPatch of RedisCluster",
+		},
+		{
+			name:  "invalid wake patch",
+			data:  "- kind: RedisCluster\n  sleepPatch: '[]'\n  wakePatch: 'op: add'",
+			error: "invalid patch targets: wakePatch of RedisCluster",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.data))
+			require.ErrorContains(t, err, test.error)
+		})
+	}
+}
+
+func TestLoader(t *testing.T) {
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-green-patch-targets", Namespace: "kube-green"},
+		Data:       map[string]string{TargetsKey: targetsYAML},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	t.Run("reads the ConfigMap", func(t *testing.T) {
+		loader := &Loader{Client: fakeClient, ConfigMap: "kube-green-patch-targets", Namespace: "kube-green"}
+		targets, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 2)
+	})
+
+	t.Run("without ConfigMap", func(t *testing.T) {
+		var loader *Loader
+		targets, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, targets)
+
+		loader = &Loader{Client: fakeClient, ConfigMap: "not-found", Namespace: "kube-green"}
+		targets, err = loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, targets)
+	})
+
+	t.Run("invalid targets", func(t *testing.T) {
+		invalid := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "kube-green"},
+			Data:       map[string]string{TargetsKey: "- group: redis.example.com"},
+		}
+		loader := &Loader{Client: fake.NewClientBuilder().WithObjects(invalid).Build(), ConfigMap: "invalid", Namespace: "kube-green"}
+		_, err := loader.Load(context.Background())
+		require.ErrorContains(t, err, "ConfigMap kube-green/invalid: invalid patch targets")
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	crd := func(name string, annotations map[string]string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		}
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(schema.GroupVersionKind{Group: "redis.example.com", Version: "v1", Kind: "RedisCluster"}, meta.RESTScopeNamespace)
+	restMapper.Add(schema.GroupVersionKind{Group: "cache.example.com", Version: "v1", Kind: "Memcached"}, meta.RESTScopeNamespace)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		crd("redisclusters.redis.example.com", map[string]string{
+			kubegreenv1alpha1.SleepPatchAnnotation:            "[{op: add, path: /spec/suspended, value: true}]",
+			kubegreenv1alpha1.WakePatchAnnotation:             "[{op: add, path: /spec/suspended, value: false}]",
+			kubegreenv1alpha1.IgnoreOwnerReferencesAnnotation: "true",
+		}),
+		crd("memcacheds.cache.example.com", map[string]string{
+			kubegreenv1alpha1.SleepPatchAnnotation: "[{op: add, path: /spec/replicas, value: 0}]",
+		}),
+		crd("unknowns.example.com", map[string]string{
+			kubegreenv1alpha1.SleepPatchAnnotation: "[{op: add, path: /spec/replicas, value: 0}]",
+		}),
+		crd("others.example.com", nil),
+	).Build()
+
+	t.Run("targets of the annotated CRDs", func(t *testing.T) {
+		loader := &Loader{Client: fakeClient, DiscoverCRDs: true, RESTMapper: restMapper}
+		targets, err := loader.Load(context.Background())
+		require.ErrorContains(t, err, "CustomResourceDefinition unknowns.example.com: fails to get kind")
+		require.ElementsMatch(t, []Target{
+			{
+				Group:                 "redis.example.com",
+				Kind:                  "RedisCluster",
+				SleepPatch:            "[{op: add, path: /spec/suspended, value: true}]",
+				WakePatch:             "[{op: add, path: /spec/suspended, value: false}]",
+				IgnoreOwnerReferences: true,
+			},
+			{
+				Group:      "cache.example.com",
+				Kind:       "Memcached",
+				SleepPatch: "[{op: add, path: /spec/replicas, value: 0}]",
+			},
+		}, targets)
+	})
+
+	t.Run("the ConfigMap overrides them", func(t *testing.T) {
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-green-patch-targets", Namespace: "kube-green"},
+			Data:       map[string]string{TargetsKey: targetsYAML},
+		}
+		loader := &Loader{
+			Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap, crd("redisclusters.redis.example.com", map[string]string{kubegreenv1alpha1.SleepPatchAnnotation: "[]"})).Build(),
+			ConfigMap:    "kube-green-patch-targets",
+			Namespace:    "kube-green",
+			DiscoverCRDs: true,
+			RESTMapper:   restMapper,
+		}
+		targets, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 2)
+		require.Equal(t, "RedisCluster", targets[0].Kind)
+		require.True(t, targets[0].IgnoreOwnerReferences)
+	})
+}
+
+func TestPresets(t *testing.T) {
+	t.Run("targets of the presets", func(t *testing.T) {
+		targets, err := PresetTargets([]string{"redis", " mongodb"})
+		require.NoError(t, err)
+		require.Equal(t, []kubegreenv1alpha1.PatchTarget{
+			{Group: "redis.redis.opstreelabs.in", Kind: "RedisCluster"},
+			{Group: "mongodbcommunity.mongodb.com", Kind: "MongoDBCommunity"},
+		}, patchTargetsOf(targets))
+		for _, target := range targets {
+			require.NoError(t, target.validatePatches())
+			require.False(t, target.IsBuiltIn())
+		}
+	})
+
+	t.Run("unknown preset", func(t *testing.T) {
+		_, err := PresetTargets([]string{"redis", "cassandra"})
+		require.EqualError(t, err, `unknown patch targets preset "cassandra", available presets: mongodb, redis`)
+	})
+
+	t.Run("the ConfigMap overrides them", func(t *testing.T) {
+		configMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-green-patch-targets", Namespace: "kube-green"},
+			Data: map[string]string{TargetsKey: `
+- group: mongodbcommunity.mongodb.com
+  kind: MongoDBCommunity
+  sleepPatch: "[{op: add, path: /spec/suspended, value: true}]"
+`},
+		}
+		loader := &Loader{
+			Client:    fake.NewClientBuilder().WithObjects(configMap).Build(),
+			ConfigMap: "kube-green-patch-targets",
+			Namespace: "kube-green",
+			Presets:   []string{"redis", "mongodb"},
+		}
+		targets, err := loader.Load(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 2)
+		require.Equal(t, "RedisCluster", targets[0].Kind)
+		require.Equal(t, "[{op: add, path: /spec/suspended, value: true}]", targets[1].SleepPatch)
+	})
+}
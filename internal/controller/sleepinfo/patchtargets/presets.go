@@ -0,0 +1,62 @@
+package patchtargets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// +kubebuilder:rbac:groups=redis.redis.opstreelabs.in,resources=redisclusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=mongodbcommunity.mongodb.com,resources=mongodbcommunity,verbs=get;list;watch;update;patch
+
+// presets are the targets of common data operators, enabled by name. The targets of the ConfigMap
+// and of the annotated CustomResourceDefinitions override them, e.g. to adapt a patch to another
+// version of the operator.
+var presets = map[string][]Target{
+	// Opstree redis-operator: the leaders and followers of a RedisCluster are scaled to its clusterSize.
+	// A standalone Redis has no size to scale.
+	"redis": {
+		{
+			Group: "redis.redis.opstreelabs.in",
+			Kind:  "RedisCluster",
+			SleepPatch: `
+- op: replace
+  path: /spec/clusterSize
+  value: 0`,
+		},
+	},
+	// MongoDB Community operator: the members of the replica set
+	"mongodb": {
+		{
+			Group: "mongodbcommunity.mongodb.com",
+			Kind:  "MongoDBCommunity",
+			SleepPatch: `
+- op: replace
+  path: /spec/members
+  value: 0`,
+		},
+	},
+}
+
+// PresetNames returns the names of the available presets
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PresetTargets returns the targets of the presets with the given names
+func PresetTargets(names []string) ([]Target, error) {
+	var targets []Target
+	for _, name := range names {
+		preset, ok := presets[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown patch targets preset %q, available presets: %s", name, strings.Join(PresetNames(), ", "))
+		}
+		targets = override(targets, preset)
+	}
+	return targets, nil
+}
@@ -0,0 +1,34 @@
+package sleepinfo
+
+import (
+	"context"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/go-logr/logr"
+)
+
+// announceSleep annotates the resources to sleep with the time of the next sleep, at sleepAt, once it
+// is due within the preSleepDelay of the SleepInfo. It returns how long is left before the sleep is
+// to announce, 0 when it is announced or there is nothing to announce.
+func (r *SleepInfoReconciler) announceSleep(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, data SleepInfoData, sleepAt, now time.Time) (time.Duration, error) {
+	delay := sleepInfo.GetPreSleepDelay()
+	if delay == 0 || !data.IsSleepOperation() || !sleepAt.After(now) {
+		return 0, nil
+	}
+	// A snoozed sleep is not due at its schedule
+	if snoozeUntil, snoozed := sleepInfo.GetSnoozeUntil(); snoozed && snoozeUntil.After(now) {
+		return 0, nil
+	}
+	if announceAt := sleepAt.Add(-delay); now.Before(announceAt) {
+		return announceAt.Sub(now), nil
+	}
+
+	resources, err := jsonpatch.NewResources(ctx, r.resourceClient(ctx, log, sleepInfo, data), sleepInfo.Namespace, data.OriginalGenericResourceInfo, data.SleptResourceGenerations)
+	if err != nil {
+		return 0, err
+	}
+	return 0, resources.AnnounceSleep(ctx, sleepAt)
+}
@@ -0,0 +1,92 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestAnnounceSleep(t *testing.T) {
+	namespace := "my-namespace"
+	sleepAt := time.Date(2024, 3, 4, 20, 0, 0, 0, time.UTC)
+	deployment := func(name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(2))},
+		}
+	}
+	sleepInfo := func(delay time.Duration) *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+			Spec:       kubegreenv1alpha1.SleepInfoSpec{PreSleepDelay: &metav1.Duration{Duration: delay}},
+		}
+	}
+	sleepData := SleepInfoData{CurrentOperationType: sleepOperation}
+
+	t.Run("annotates the resources to sleep within the delay", func(t *testing.T) {
+		fakeClient := fakeDeploymentClient(deployment("api", nil), deployment("excluded", map[string]string{kubegreenv1alpha1.ExcludeAnnotation: "true"}))
+		r := SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName}
+
+		announceIn, err := r.announceSleep(context.Background(), logr.Discard(), sleepInfo(15*time.Minute), sleepData, sleepAt, sleepAt.Add(-10*time.Minute))
+		require.NoError(t, err)
+		require.Zero(t, announceIn)
+
+		annotations := func(name string) map[string]string {
+			res := &appsv1.Deployment{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, res))
+			return res.GetAnnotations()
+		}
+		require.Equal(t, "2024-03-04T20:00:00Z", annotations("api")[kubegreenv1alpha1.SleepAtAnnotation])
+		require.NotContains(t, annotations("excluded"), kubegreenv1alpha1.SleepAtAnnotation)
+
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "api"}, res))
+		require.Equal(t, int32(2), *res.Spec.Replicas)
+	})
+
+	t.Run("waits for the delay before the sleep", func(t *testing.T) {
+		fakeClient := fakeDeploymentClient(deployment("api", nil))
+		r := SleepInfoReconciler{Client: fakeClient, ManagerName: testFieldManagerName}
+
+		announceIn, err := r.announceSleep(context.Background(), logr.Discard(), sleepInfo(15*time.Minute), sleepData, sleepAt, sleepAt.Add(-time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 45*time.Minute, announceIn)
+
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "api"}, res))
+		require.NotContains(t, res.GetAnnotations(), kubegreenv1alpha1.SleepAtAnnotation)
+	})
+
+	t.Run("nothing to announce", func(t *testing.T) {
+		r := SleepInfoReconciler{Client: fakeDeploymentClient(), ManagerName: testFieldManagerName}
+		now := sleepAt.Add(-10 * time.Minute)
+
+		for _, tc := range []struct {
+			name      string
+			sleepInfo *kubegreenv1alpha1.SleepInfo
+			data      SleepInfoData
+		}{
+			{name: "without delay", sleepInfo: &kubegreenv1alpha1.SleepInfo{}, data: sleepData},
+			{name: "before a wake up", sleepInfo: sleepInfo(15 * time.Minute), data: SleepInfoData{CurrentOperationType: wakeUpOperation}},
+			{name: "snoozed", sleepInfo: func() *kubegreenv1alpha1.SleepInfo {
+				snoozed := sleepInfo(15 * time.Minute)
+				snoozed.Annotations = map[string]string{kubegreenv1alpha1.SnoozeUntilAnnotation: sleepAt.Add(time.Hour).Format(time.RFC3339)}
+				return snoozed
+			}(), data: sleepData},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				announceIn, err := r.announceSleep(context.Background(), logr.Discard(), tc.sleepInfo, tc.data, sleepAt, now)
+				require.NoError(t, err)
+				require.Zero(t, announceIn)
+			})
+		}
+	})
+}
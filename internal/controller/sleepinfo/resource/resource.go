@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -15,8 +16,21 @@ import (
 
 var ErrInvalidClient = errors.New("invalid client")
 
+// Reasons of the errors reported to the OperationError hook
+const (
+	// ErrorReasonPatchFailed is a resource not slept or woken up because of an error
+	ErrorReasonPatchFailed = "PatchFailed"
+	// ErrorReasonDrift is a resource not woken up because it changed since the sleep
+	ErrorReasonDrift = "Drift"
+	// ErrorReasonMissingRestorePatch is a resource slept by kube-green not woken up because its
+	// restore patch is missing
+	ErrorReasonMissingRestorePatch = "MissingRestorePatch"
+)
+
 type Resource interface {
 	HasResource() bool
+	// AnnounceSleep annotates the resources to sleep with the time of their sleep
+	AnnounceSleep(ctx context.Context, sleepAt time.Time) error
 	Sleep(ctx context.Context) error
 	WakeUp(ctx context.Context) error
 	GetOriginalInfoToSave() ([]byte, error)
@@ -28,13 +42,36 @@ type ResourceClient struct {
 	SleepInfo        *kubegreenv1alpha1.SleepInfo
 	Log              logr.Logger
 	FieldManagerName string
+	// DryRun sends the patches as dry run: the API server validates them without persisting them
+	DryRun bool
+	// WakeUpFilter, when set, restricts the wake up to the resources it returns true for
+	WakeUpFilter func(target kubegreenv1alpha1.PatchTarget, res unstructured.Unstructured) bool
+	// IgnoreOwnerTargets are patched even when their resources are managed by another controller
+	IgnoreOwnerTargets map[kubegreenv1alpha1.PatchTarget]bool
+	// WakePatchTargets are woken up applying their patch, instead of their restore patch
+	WakePatchTargets map[kubegreenv1alpha1.PatchTarget]bool
+	// Patched, when set, is called with every resource slept or woken up
+	Patched func(res unstructured.Unstructured)
+	// Failed, when set, is called with every resource not slept or woken up because of an error, or
+	// skipped on wake up because it changed since the sleep
+	Failed func(res unstructured.Unstructured, reason string)
+	// TargetDone, when set, is called with the time taken to sleep or wake up the resources of every
+	// patch target
+	TargetDone func(target kubegreenv1alpha1.PatchTarget, duration time.Duration)
+	// OperationError, when set, is called with every resource reported to Failed and every slept
+	// resource skipped on wake up without restore patch, with one of the ErrorReason constants
+	OperationError func(res unstructured.Unstructured, reason string)
 }
 
 func (r ResourceClient) Patch(ctx context.Context, oldObj, newObj client.Object) error {
 	if err := r.IsClientValid(); err != nil {
 		return err
 	}
-	if err := r.Client.Patch(ctx, newObj, client.MergeFrom(oldObj)); err != nil {
+	opts := []client.PatchOption{}
+	if r.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Client.Patch(ctx, newObj, client.MergeFrom(oldObj), opts...); err != nil {
 		if client.IgnoreNotFound(err) == nil {
 			return nil
 		}
@@ -50,11 +87,11 @@ func (r ResourceClient) SSAPatch(ctx context.Context, newObj *unstructured.Unstr
 	}
 	newObj.SetManagedFields(nil)
 	newObj.SetResourceVersion("")
-	if err := r.Client.Apply(ctx,
-		client.ApplyConfigurationFromUnstructured(newObj),
-		client.FieldOwner(r.FieldManagerName),
-		client.ForceOwnership,
-	); err != nil {
+	opts := []client.ApplyOption{client.FieldOwner(r.FieldManagerName), client.ForceOwnership}
+	if r.DryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Client.Apply(ctx, client.ApplyConfigurationFromUnstructured(newObj), opts...); err != nil {
 		if client.IgnoreNotFound(err) == nil {
 			return nil
 		}
@@ -112,6 +112,29 @@ func TestResource(t *testing.T) {
 			require.Equal(t, deployRes, actualDeployment)
 		})
 
+		t.Run("does not persist a dry run patch", func(t *testing.T) {
+			k8sClient := fake.NewClientBuilder().WithRuntimeObjects(deployRes).Build()
+			c := ResourceClient{
+				SleepInfo: &kubegreenv1alpha1.SleepInfo{},
+				Log:       logr.Discard(),
+				Client:    k8sClient,
+				DryRun:    true,
+			}
+
+			newD1 := deployRes.DeepCopy()
+			newD1.Spec.Template.Spec.Containers[0].Image = newImageName
+
+			require.NoError(t, c.Patch(context.Background(), deployRes, newD1))
+
+			actualDeployment := &appsv1.Deployment{}
+			err := k8sClient.Get(context.Background(), types.NamespacedName{
+				Name:      deployRes.Name,
+				Namespace: deployRes.Namespace,
+			}, actualDeployment)
+			require.NoError(t, err)
+			require.Equal(t, deployRes.Spec, actualDeployment.Spec)
+		})
+
 		t.Run("does not throw if resource not found", func(t *testing.T) {
 			k8sClient := fake.NewClientBuilder().Build()
 			c := ResourceClient{
@@ -0,0 +1,64 @@
+package restoredata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ChunkSecretName returns the name of the secret storing the chunk at index of the restore data of a
+// secret
+func ChunkSecretName(secretName string, index int) string {
+	return fmt.Sprintf("%s-%d", secretName, index)
+}
+
+// Split splits the restore data in chunks of at most size bytes
+func Split(data []byte, size int) [][]byte {
+	chunks := [][]byte{}
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	if len(data) > 0 {
+		chunks = append(chunks, data)
+	}
+	return chunks
+}
+
+// NewIndex returns the index entry listing the secrets storing the chunks of the restore data
+func NewIndex(names []string) ([]byte, error) {
+	return json.Marshal(names)
+}
+
+// ParseIndex returns the secrets listed by an index entry, in the order of their chunks
+func ParseIndex(index []byte) ([]string, error) {
+	if len(index) == 0 {
+		return nil, nil
+	}
+	names := []string{}
+	if err := json.Unmarshal(index, &names); err != nil {
+		return nil, fmt.Errorf("fails to read restore data chunks index: %w", err)
+	}
+	return names, nil
+}
+
+// Join returns the restore data split in the secrets listed by an index entry, each storing its chunk
+// in key
+func Join(ctx context.Context, c client.Reader, namespace string, index []byte, key string) ([]byte, error) {
+	names, err := ParseIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	data := []byte{}
+	for _, name := range names {
+		chunk := &v1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, chunk); err != nil {
+			return nil, fmt.Errorf("fails to read restore data chunk %s: %w", name, err)
+		}
+		data = append(data, chunk.Data[key]...)
+	}
+	return data, nil
+}
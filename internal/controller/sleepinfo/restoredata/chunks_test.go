@@ -0,0 +1,67 @@
+package restoredata
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestChunks(t *testing.T) {
+	namespace := "my-namespace"
+	key := "original-resource-info"
+	data := []byte("gzip+base64:H4sIAAAAAAAA/6pWcs5PSQ3ILypRslIqS8wpTQUAAAD//w==")
+
+	t.Run("splits the data in chunks", func(t *testing.T) {
+		chunks := Split(data, 16)
+
+		require.Len(t, chunks, 4)
+		for _, chunk := range chunks[:3] {
+			require.Len(t, chunk, 16)
+		}
+		require.Equal(t, data, append(append(append(append([]byte{}, chunks[0]...), chunks[1]...), chunks[2]...), chunks[3]...))
+		require.Equal(t, [][]byte{data}, Split(data, len(data)))
+		require.Empty(t, Split(nil, 16))
+	})
+
+	t.Run("joins the chunks of the index", func(t *testing.T) {
+		chunks := Split(data, 16)
+		names := []string{}
+		c := fake.NewClientBuilder()
+		for i, chunk := range chunks {
+			name := ChunkSecretName("sleepinfo-sleep", i)
+			names = append(names, name)
+			c = c.WithObjects(&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Data:       map[string][]byte{key: chunk},
+			})
+		}
+		require.Equal(t, "sleepinfo-sleep-3", names[3])
+		index, err := NewIndex(names)
+		require.NoError(t, err)
+
+		joined, err := Join(context.Background(), c.Build(), namespace, index, key)
+		require.NoError(t, err)
+		require.Equal(t, data, joined)
+
+		parsed, err := ParseIndex(index)
+		require.NoError(t, err)
+		require.Equal(t, names, parsed)
+	})
+
+	t.Run("fails with a missing chunk", func(t *testing.T) {
+		index, err := NewIndex([]string{"sleepinfo-sleep-0"})
+		require.NoError(t, err)
+
+		_, err = Join(context.Background(), fake.NewClientBuilder().Build(), namespace, index, key)
+		require.ErrorContains(t, err, "fails to read restore data chunk sleepinfo-sleep-0")
+	})
+
+	t.Run("fails with an invalid index", func(t *testing.T) {
+		_, err := ParseIndex([]byte("sleepinfo-sleep-0"))
+		require.ErrorContains(t, err, "fails to read restore data chunks index")
+	})
+}
@@ -0,0 +1,134 @@
+package restoredata
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// aesGCMPrefix marks the payloads compressed with gzip, encrypted with AES-GCM and encoded in base64,
+// with the nonce before the ciphertext
+const aesGCMPrefix = "aes-gcm+base64:"
+
+// EncryptionKeySecretKey is the key of the Secret storing the AES key, of 16, 24 or 32 bytes
+const EncryptionKeySecretKey = "key"
+
+// ErrMissingEncryptionKey is returned decoding encrypted restore data without an encryption key
+var ErrMissingEncryptionKey = errors.New("restore data is encrypted and no encryption key is configured")
+
+// EncryptionKey reads the key encrypting the restore data from a Secret of the kube-green namespace.
+// It is read on every use, so the Secret can be created after the controller starts.
+type EncryptionKey struct {
+	Client    client.Reader
+	Namespace string
+	Secret    string
+}
+
+// Get returns the encryption key, nil if no Secret is configured
+func (k *EncryptionKey) Get(ctx context.Context) ([]byte, error) {
+	if k == nil || k.Secret == "" {
+		return nil, nil
+	}
+	secret := &v1.Secret{}
+	if err := k.Client.Get(ctx, client.ObjectKey{Namespace: k.Namespace, Name: k.Secret}, secret); err != nil {
+		return nil, fmt.Errorf("fails to read restore data encryption key: %w", err)
+	}
+	key := secret.Data[EncryptionKeySecretKey]
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("restore data encryption key of secret %s/%s has %d bytes, 16, 24 or 32 expected", k.Namespace, k.Secret, len(key))
+	}
+}
+
+// EncodeWithKey compresses the restore data and, with a key, encrypts it. Encoded data is returned as is.
+func EncodeWithKey(data, key []byte) ([]byte, error) {
+	if key == nil {
+		return Encode(data)
+	}
+	if len(data) == 0 || IsEncrypted(data) {
+		return data, nil
+	}
+	plain, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := compress(plain)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("fails to encrypt restore data: %w", err)
+	}
+	return encodeBase64(aesGCMPrefix, gcm.Seal(nonce, nonce, compressed, nil)), nil
+}
+
+// DecodeWithKey returns the restore data stored in a secret, compressed, encrypted or not. The key is
+// only needed by encrypted data.
+func DecodeWithKey(data, key []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return Decode(data)
+	}
+	if key == nil {
+		return nil, ErrMissingEncryptionKey
+	}
+	encrypted, err := decodeBase64(aesGCMPrefix, data)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, errors.New("fails to decrypt restore data: payload too short")
+	}
+	compressed, err := gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("fails to decrypt restore data: %w", err)
+	}
+	return decompress(compressed)
+}
+
+// IsEncrypted returns true if the restore data is encrypted
+func IsEncrypted(data []byte) bool {
+	return hasPrefix(data, aesGCMPrefix)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid restore data encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func encodeBase64(prefix string, data []byte) []byte {
+	encoded := make([]byte, len(prefix)+base64.StdEncoding.EncodedLen(len(data)))
+	copy(encoded, prefix)
+	base64.StdEncoding.Encode(encoded[len(prefix):], data)
+	return encoded
+}
+
+func decodeBase64(prefix string, data []byte) ([]byte, error) {
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(data)-len(prefix)))
+	n, err := base64.StdEncoding.Decode(decoded, data[len(prefix):])
+	if err != nil {
+		return nil, fmt.Errorf("fails to decode restore data: %w", err)
+	}
+	return decoded[:n], nil
+}
@@ -0,0 +1,126 @@
+package restoredata
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEncryption(t *testing.T) {
+	data := []byte(`{"Deployment.apps":{"api":"[{\"op\":\"add\",\"path\":\"/spec/replicas\",\"value\":3}]"}}`)
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	t.Run("round trip", func(t *testing.T) {
+		encrypted, err := EncodeWithKey(data, key)
+		require.NoError(t, err)
+		require.True(t, IsEncrypted(encrypted))
+		require.True(t, bytes.HasPrefix(encrypted, []byte("aes-gcm+base64:")))
+		require.NotContains(t, string(encrypted), "replicas")
+
+		decoded, err := DecodeWithKey(encrypted, key)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+
+	t.Run("every encryption has its own nonce", func(t *testing.T) {
+		first, err := EncodeWithKey(data, key)
+		require.NoError(t, err)
+		second, err := EncodeWithKey(data, key)
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+
+	t.Run("encrypts compressed data", func(t *testing.T) {
+		compressed, err := Encode(data)
+		require.NoError(t, err)
+
+		encrypted, err := EncodeWithKey(compressed, key)
+		require.NoError(t, err)
+		require.True(t, IsEncrypted(encrypted))
+		decoded, err := DecodeWithKey(encrypted, key)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+
+	t.Run("without key the data is compressed only", func(t *testing.T) {
+		encoded, err := EncodeWithKey(data, nil)
+		require.NoError(t, err)
+		require.True(t, IsCompressed(encoded))
+
+		decoded, err := DecodeWithKey(encoded, key)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+
+	t.Run("encrypted data needs the key", func(t *testing.T) {
+		encrypted, err := EncodeWithKey(data, key)
+		require.NoError(t, err)
+
+		_, err = Decode(encrypted)
+		require.ErrorIs(t, err, ErrMissingEncryptionKey)
+		_, err = DecodeWithKey(encrypted, nil)
+		require.ErrorIs(t, err, ErrMissingEncryptionKey)
+		_, err = DecodeWithKey(encrypted, []byte("fedcba9876543210fedcba9876543210"))
+		require.ErrorContains(t, err, "fails to decrypt restore data")
+
+		again, err := Encode(encrypted)
+		require.NoError(t, err)
+		require.Equal(t, encrypted, again)
+	})
+}
+
+func TestEncryptionKey(t *testing.T) {
+	namespace := "kube-green"
+	secret := func(key string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "restore-key", Namespace: namespace},
+			Data:       map[string][]byte{EncryptionKeySecretKey: []byte(key)},
+		}
+	}
+
+	t.Run("reads the key of the secret", func(t *testing.T) {
+		k := &EncryptionKey{
+			Client:    fake.NewClientBuilder().WithObjects(secret("0123456789abcdef")).Build(),
+			Namespace: namespace,
+			Secret:    "restore-key",
+		}
+
+		key, err := k.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []byte("0123456789abcdef"), key)
+	})
+
+	t.Run("no key without secret", func(t *testing.T) {
+		var k *EncryptionKey
+		key, err := k.Get(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, key)
+	})
+
+	t.Run("invalid key length", func(t *testing.T) {
+		k := &EncryptionKey{
+			Client:    fake.NewClientBuilder().WithObjects(secret("short")).Build(),
+			Namespace: namespace,
+			Secret:    "restore-key",
+		}
+
+		_, err := k.Get(context.Background())
+		require.EqualError(t, err, "restore data encryption key of secret kube-green/restore-key has 5 bytes, 16, 24 or 32 expected")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		k := &EncryptionKey{
+			Client:    fake.NewClientBuilder().Build(),
+			Namespace: namespace,
+			Secret:    "restore-key",
+		}
+
+		_, err := k.Get(context.Background())
+		require.ErrorContains(t, err, "fails to read restore data encryption key")
+	})
+}
@@ -0,0 +1,74 @@
+package restoredata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// gzipPrefix marks the payloads compressed with gzip and encoded in base64. Payloads without it are
+// the plain JSON written before the compression.
+const gzipPrefix = "gzip+base64:"
+
+// Encode compresses the restore data to be stored in a secret. Compressed data is returned as is.
+func Encode(data []byte) ([]byte, error) {
+	if len(data) == 0 || IsCompressed(data) || IsEncrypted(data) {
+		return data, nil
+	}
+	compressed, err := compress(data)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBase64(gzipPrefix, compressed), nil
+}
+
+// Decode returns the restore data stored in a secret, compressed or not. Encrypted data needs
+// DecodeWithKey.
+func Decode(data []byte) ([]byte, error) {
+	if IsEncrypted(data) {
+		return nil, ErrMissingEncryptionKey
+	}
+	if !IsCompressed(data) {
+		return data, nil
+	}
+	compressed, err := decodeBase64(gzipPrefix, data)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(compressed)
+}
+
+// IsCompressed returns true if the restore data is compressed
+func IsCompressed(data []byte) bool {
+	return hasPrefix(data, gzipPrefix)
+}
+
+func hasPrefix(data []byte, prefix string) bool {
+	return bytes.HasPrefix(data, []byte(prefix))
+}
+
+func compress(data []byte) ([]byte, error) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("fails to compress restore data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("fails to compress restore data: %w", err)
+	}
+	return compressed.Bytes(), nil
+}
+
+func decompress(compressed []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("fails to decompress restore data: %w", err)
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("fails to decompress restore data: %w", err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,70 @@
+package restoredata
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreData(t *testing.T) {
+	data := []byte(`{"Deployment.apps":{"api":"[{\"op\":\"add\",\"path\":\"/spec/replicas\",\"value\":3}]"}}`)
+
+	t.Run("round trip", func(t *testing.T) {
+		encoded, err := Encode(data)
+		require.NoError(t, err)
+		require.True(t, IsCompressed(encoded))
+		require.True(t, bytes.HasPrefix(encoded, []byte("gzip+base64:")))
+
+		decoded, err := Decode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	})
+
+	t.Run("compressed data is not compressed again", func(t *testing.T) {
+		encoded, err := Encode(data)
+		require.NoError(t, err)
+
+		again, err := Encode(encoded)
+		require.NoError(t, err)
+		require.Equal(t, encoded, again)
+	})
+
+	t.Run("plain JSON is decoded as is", func(t *testing.T) {
+		decoded, err := Decode(data)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+
+		decoded, err = Decode(nil)
+		require.NoError(t, err)
+		require.Nil(t, decoded)
+	})
+
+	t.Run("empty data is not compressed", func(t *testing.T) {
+		encoded, err := Encode(nil)
+		require.NoError(t, err)
+		require.Nil(t, encoded)
+	})
+
+	t.Run("compresses repeated patches", func(t *testing.T) {
+		var large bytes.Buffer
+		large.WriteString("{\"Deployment.apps\":{")
+		for i := 0; i < 1000; i++ {
+			fmt.Fprintf(&large, `"deployment-%d":"[{\"op\":\"add\",\"path\":\"/spec/replicas\",\"value\":1}]",`, i)
+		}
+		large.WriteString(`"last":"[]"}}`)
+
+		encoded, err := Encode(large.Bytes())
+		require.NoError(t, err)
+		require.Less(t, len(encoded), large.Len()/5)
+	})
+
+	t.Run("invalid compressed data", func(t *testing.T) {
+		_, err := Decode([]byte("gzip+base64:not base64!"))
+		require.ErrorContains(t, err, "fails to decode restore data")
+
+		_, err = Decode([]byte("gzip+base64:bm90IGd6aXA="))
+		require.ErrorContains(t, err, "fails to decompress restore data")
+	})
+}
@@ -0,0 +1,80 @@
+package sleepinfo
+
+import (
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// releasedRequests returns the CPU and memory requested by the pods of a workload before its sleep:
+// the requests of the containers of its pod template, times its replicas. Resources without pod
+// template, as CronJobs, release nothing.
+func releasedRequests(res unstructured.Unstructured) v1.ResourceList {
+	template, found, err := unstructured.NestedMap(res.Object, "spec", "template")
+	if err != nil || !found {
+		return nil
+	}
+	podTemplate := v1.PodTemplateSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, &podTemplate); err != nil {
+		return nil
+	}
+	replicas, found, err := unstructured.NestedInt64(res.Object, "spec", "replicas")
+	if err != nil {
+		return nil
+	}
+	if !found {
+		replicas = 1
+	}
+
+	requests := v1.ResourceList{}
+	for _, container := range podTemplate.Spec.Containers {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			request, ok := container.Resources.Requests[name]
+			if !ok {
+				continue
+			}
+			request.Mul(replicas)
+			total := requests[name]
+			total.Add(request)
+			requests[name] = total
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return requests
+}
+
+// addRequests returns the sum of the requests, nil if both are empty
+func addRequests(a, b v1.ResourceList) v1.ResourceList {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	sum := v1.ResourceList{}
+	for _, requests := range []v1.ResourceList{a, b} {
+		for name, request := range requests {
+			total := sum[name]
+			total.Add(request)
+			sum[name] = total
+		}
+	}
+	return sum
+}
+
+// sleepSavedResources returns the requests released by the workloads asleep after a sleep releasing
+// released: added to those of the previous sleep when the SleepInfo is still asleep, since its
+// workloads already asleep are not patched again
+func sleepSavedResources(status kubegreenv1alpha1.SleepInfoStatus, released v1.ResourceList) v1.ResourceList {
+	if status.CurrentState != kubegreenv1alpha1.StateSleeping {
+		return addRequests(nil, released)
+	}
+	return addRequests(status.SavedResources, released)
+}
+
+// setSavedResourcesMetric sets the CPU and memory released by the workloads asleep of the SleepInfo
+func (r *SleepInfoReconciler) setSavedResourcesMetric(sleepInfo *kubegreenv1alpha1.SleepInfo, saved v1.ResourceList) {
+	cpu, memory := saved[v1.ResourceCPU], saved[v1.ResourceMemory]
+	r.Metrics.SetSavedResources(sleepInfo.Name, sleepInfo.Namespace, cpu.AsApproximateFloat64(), memory.AsApproximateFloat64())
+}
@@ -0,0 +1,86 @@
+package sleepinfo
+
+import (
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestReleasedRequests(t *testing.T) {
+	toUnstructured := func(obj runtime.Object) unstructured.Unstructured {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		require.NoError(t, err)
+		return unstructured.Unstructured{Object: data}
+	}
+	container := func(cpu, memory string) v1.Container {
+		requests := v1.ResourceList{}
+		if cpu != "" {
+			requests[v1.ResourceCPU] = apiresource.MustParse(cpu)
+		}
+		if memory != "" {
+			requests[v1.ResourceMemory] = apiresource.MustParse(memory)
+		}
+		return v1.Container{Name: "c", Resources: v1.ResourceRequirements{Requests: requests}}
+	}
+	deployment := func(replicas *int32, containers ...v1.Container) unstructured.Unstructured {
+		return toUnstructured(&appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Replicas: replicas,
+				Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: containers}},
+			},
+		})
+	}
+
+	t.Run("requests of the containers times the replicas", func(t *testing.T) {
+		requests := releasedRequests(deployment(getPtr(int32(3)), container("250m", "128Mi"), container("100m", "")))
+
+		require.Equal(t, "1050m", requests.Cpu().String())
+		require.Equal(t, "384Mi", requests.Memory().String())
+	})
+
+	t.Run("one replica by default", func(t *testing.T) {
+		requests := releasedRequests(deployment(nil, container("1", "1Gi")))
+
+		require.Equal(t, "1", requests.Cpu().String())
+		require.Equal(t, "1Gi", requests.Memory().String())
+	})
+
+	t.Run("nothing without requests", func(t *testing.T) {
+		require.Nil(t, releasedRequests(deployment(getPtr(int32(2)), container("", ""))))
+		requests := releasedRequests(deployment(getPtr(int32(0)), container("1", "1Gi")))
+		require.True(t, requests.Cpu().IsZero())
+	})
+
+	t.Run("nothing without pod template", func(t *testing.T) {
+		require.Nil(t, releasedRequests(toUnstructured(&batchv1.CronJob{})))
+	})
+}
+
+func TestSleepSavedResources(t *testing.T) {
+	released := v1.ResourceList{v1.ResourceCPU: apiresource.MustParse("500m")}
+	previous := v1.ResourceList{v1.ResourceCPU: apiresource.MustParse("1"), v1.ResourceMemory: apiresource.MustParse("1Gi")}
+
+	t.Run("adds the requests of a sleep while asleep", func(t *testing.T) {
+		saved := sleepSavedResources(kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateSleeping, SavedResources: previous}, released)
+
+		require.Equal(t, "1500m", saved.Cpu().String())
+		require.Equal(t, "1Gi", saved.Memory().String())
+		require.Equal(t, "1", previous.Cpu().String())
+	})
+
+	t.Run("replaces them otherwise", func(t *testing.T) {
+		saved := sleepSavedResources(kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateTransitioning, SavedResources: previous}, released)
+
+		require.Equal(t, "500m", saved.Cpu().String())
+		require.True(t, saved.Memory().IsZero())
+		require.Nil(t, sleepSavedResources(kubegreenv1alpha1.SleepInfoStatus{}, nil))
+	})
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"time"
 
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
 	"github.com/go-logr/logr"
 	"github.com/robfig/cron/v3"
 )
 
 func (r *SleepInfoReconciler) getNextSchedule(log logr.Logger, data SleepInfoData, now time.Time) (bool, time.Time, time.Duration, error) {
 	scheduleDelta := time.Duration(r.SleepDelta) * time.Second
+	if data.IsWakeUpOperation() && !data.WakeUpAt.IsZero() {
+		return r.getSleepDurationWakeUp(log, data, now)
+	}
 	sched, err := getCronParsed(data.CurrentOperationSchedule)
 	if err != nil {
 		return false, time.Time{}, 0, fmt.Errorf("current schedule not valid: %s", err)
@@ -37,6 +42,9 @@ func (r *SleepInfoReconciler) getNextSchedule(log logr.Logger, data SleepInfoDat
 			return false, time.Time{}, 0, fmt.Errorf("next op schedule not valid: %s", err)
 		}
 		nextSchedule = nextOpSched.Next(now.Add(scheduleDelta))
+		if data.IsSleepOperation() && data.SleepDuration > 0 {
+			nextSchedule = now.Add(data.SleepDuration)
+		}
 	}
 	requeueAfter = getRequeueAfter(nextSchedule, now)
 	log.Info("is time to execute", "execute", isToExecute, "next", nextSchedule, "last", lastSchedule, "now", now)
@@ -44,12 +52,60 @@ func (r *SleepInfoReconciler) getNextSchedule(log logr.Logger, data SleepInfoDat
 	return isToExecute, nextSchedule, requeueAfter, nil
 }
 
+// getSleepDurationWakeUp returns whether the wake up due after the sleep duration is to execute, and
+// the next operation time: the wake up itself, or the next scheduled sleep once it is executed.
+func (r *SleepInfoReconciler) getSleepDurationWakeUp(log logr.Logger, data SleepInfoData, now time.Time) (bool, time.Time, time.Duration, error) {
+	scheduleDelta := time.Duration(r.SleepDelta) * time.Second
+	nextSchedule := data.WakeUpAt
+	isToExecute := !now.Before(data.WakeUpAt.Add(-scheduleDelta))
+	if isToExecute {
+		nextOpSched, err := getCronParsed(data.NextOperationSchedule)
+		if err != nil {
+			return false, time.Time{}, 0, fmt.Errorf("next op schedule not valid: %s", err)
+		}
+		nextSchedule = nextOpSched.Next(now.Add(scheduleDelta))
+	}
+	requeueAfter := getRequeueAfter(nextSchedule, now)
+	log.Info("is time to execute wake up after sleep duration", "execute", isToExecute, "next", nextSchedule, "wakeUpAt", data.WakeUpAt, "now", now)
+
+	return isToExecute, nextSchedule, requeueAfter, nil
+}
+
+// getWindowSchedule returns whether the current operation of a one-time window is to execute, the
+// next operation time and the requeue. finished is true when nothing is left to do: the wake up has
+// already been done, or the window ended before the sleep could be executed.
+func (r *SleepInfoReconciler) getWindowSchedule(log logr.Logger, window *kubegreenv1alpha1.ScheduleWindow, data SleepInfoData, now time.Time) (isToExecute bool, nextSchedule time.Time, requeueAfter time.Duration, finished bool) {
+	scheduleDelta := time.Duration(r.SleepDelta) * time.Second
+	start, end := window.Start.Time, window.End.Time
+
+	switch {
+	case data.IsWakeUpOperation():
+		nextSchedule = end
+		isToExecute = !now.Before(end.Add(-scheduleDelta))
+	case !data.LastSchedule.Before(start.Add(-scheduleDelta)) || !now.Before(end):
+		// The secret records a wake up done during the window, or the sleep was missed
+		finished = true
+	default:
+		nextSchedule = start
+		isToExecute = !now.Before(start.Add(-scheduleDelta))
+		if isToExecute {
+			nextSchedule = end
+		}
+	}
+	if !finished {
+		requeueAfter = getRequeueAfter(nextSchedule, now)
+	}
+	log.Info("is time to execute window", "execute", isToExecute, "next", nextSchedule, "finished", finished, "start", start, "end", end, "now", now)
+
+	return isToExecute, nextSchedule, requeueAfter, finished
+}
+
 func getRequeueAfter(schedule, now time.Time) time.Duration {
 	return schedule.Sub(now)
 }
 
 func getCronParsed(schedule string) (cron.Schedule, error) {
-	return cron.ParseStandard(schedule)
+	return kubegreenv1alpha1.ParseSchedule(schedule)
 }
 
 func isTimeInDelta(t1, t2 time.Time, delta time.Duration) bool {
@@ -5,7 +5,10 @@ import (
 	"testing"
 	"time"
 
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
@@ -393,6 +396,128 @@ func TestSchedule(t *testing.T) {
 	}
 }
 
+func TestSleepDurationSchedule(t *testing.T) {
+	sleepInfoReconciler := SleepInfoReconciler{
+		Log:        zap.New(zap.UseDevMode(true)),
+		SleepDelta: 60,
+	}
+	rome, err := time.LoadLocation("Europe/Rome")
+	require.NoError(t, err)
+	// Sleep at 20:00 the day before the change to daylight saving time in Rome
+	lastSleep := time.Date(2026, 3, 28, 20, 0, 0, 0, rome)
+	data := SleepInfoData{
+		CurrentOperationType:     wakeUpOperation,
+		CurrentOperationSchedule: "CRON_TZ=Europe/Rome 00 20 * * *",
+		NextOperationSchedule:    "CRON_TZ=Europe/Rome 00 20 * * *",
+		LastSchedule:             lastSleep,
+		SleepDuration:            10 * time.Hour,
+		WakeUpAt:                 lastSleep.Add(10 * time.Hour),
+	}
+
+	t.Run("waits for the sleep duration across daylight saving time", func(t *testing.T) {
+		now := lastSleep.Add(time.Hour)
+		isToExecute, nextSchedule, requeueAfter, err := sleepInfoReconciler.getNextSchedule(sleepInfoReconciler.Log, data, now)
+		require.NoError(t, err)
+		require.False(t, isToExecute)
+		require.Equal(t, time.Date(2026, 3, 29, 7, 0, 0, 0, rome), nextSchedule.In(rome))
+		require.Equal(t, 9*time.Hour, requeueAfter)
+	})
+
+	t.Run("wakes up once the sleep duration is elapsed", func(t *testing.T) {
+		now := lastSleep.Add(10*time.Hour - 30*time.Second)
+		isToExecute, nextSchedule, _, err := sleepInfoReconciler.getNextSchedule(sleepInfoReconciler.Log, data, now)
+		require.NoError(t, err)
+		require.True(t, isToExecute)
+		require.Equal(t, time.Date(2026, 3, 29, 20, 0, 0, 0, rome), nextSchedule.In(rome))
+	})
+
+	t.Run("wakes up a missed wake up", func(t *testing.T) {
+		isToExecute, _, _, err := sleepInfoReconciler.getNextSchedule(sleepInfoReconciler.Log, data, lastSleep.Add(12*time.Hour))
+		require.NoError(t, err)
+		require.True(t, isToExecute)
+	})
+
+	t.Run("schedules the wake up after the sleep executed", func(t *testing.T) {
+		sleepData := SleepInfoData{
+			CurrentOperationType:     sleepOperation,
+			CurrentOperationSchedule: "CRON_TZ=Europe/Rome 00 20 * * *",
+			NextOperationSchedule:    "CRON_TZ=Europe/Rome 00 20 * * *",
+			SleepDuration:            10 * time.Hour,
+		}
+		isToExecute, nextSchedule, requeueAfter, err := sleepInfoReconciler.getNextSchedule(sleepInfoReconciler.Log, sleepData, lastSleep)
+		require.NoError(t, err)
+		require.True(t, isToExecute)
+		require.Equal(t, lastSleep.Add(10*time.Hour), nextSchedule)
+		require.Equal(t, 10*time.Hour, requeueAfter)
+	})
+}
+
+func TestSchedulesSchedule(t *testing.T) {
+	sleepInfoReconciler := SleepInfoReconciler{
+		Log:        zap.New(zap.UseDevMode(true)),
+		SleepDelta: 60,
+	}
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			Schedules: []kubegreenv1alpha1.WeeklySchedule{
+				{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+				{Weekdays: "1-5", SleepTime: "13:00", WakeUpTime: "14:00"},
+			},
+			TimeZone: "UTC",
+		},
+	}
+	secretOf := func(operation string, at time.Time) *v1.Secret {
+		return &v1.Secret{Data: map[string][]byte{
+			lastOperationKey: []byte(operation),
+			lastScheduleKey:  []byte(at.Format(time.RFC3339)),
+		}}
+	}
+	tuesday := func(hour int) time.Time {
+		return time.Date(2026, 3, 10, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		secret       *v1.Secret
+		now          time.Time
+		operation    string
+		nextSchedule time.Time
+	}{
+		{
+			name:         "sleeps at the lunch break",
+			now:          tuesday(13),
+			operation:    sleepOperation,
+			nextSchedule: tuesday(14),
+		},
+		{
+			name:         "wakes up after the lunch break",
+			secret:       secretOf(sleepOperation, tuesday(13)),
+			now:          tuesday(14),
+			operation:    wakeUpOperation,
+			nextSchedule: tuesday(22),
+		},
+		{
+			name:         "sleeps at night",
+			secret:       secretOf(wakeUpOperation, tuesday(14)),
+			now:          tuesday(22),
+			operation:    sleepOperation,
+			nextSchedule: time.Date(2026, 3, 11, 6, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := getSleepInfoData(test.secret, sleepInfo)
+			require.NoError(t, err)
+			require.Equal(t, test.operation, data.CurrentOperationType)
+
+			isToExecute, nextSchedule, _, err := sleepInfoReconciler.getNextSchedule(sleepInfoReconciler.Log, data, test.now)
+			require.NoError(t, err)
+			require.True(t, isToExecute)
+			require.Equal(t, test.nextSchedule, nextSchedule.UTC())
+		})
+	}
+}
+
 func TestTestIsTimeInDeltaMs(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
@@ -533,3 +658,120 @@ func getTime(t *testing.T, mockNowRaw string) time.Time {
 	require.NoError(t, err)
 	return now
 }
+
+func TestRequeueBeforePendingManualAction(t *testing.T) {
+	tests := []struct {
+		name         string
+		requeueAfter time.Duration
+		pending      time.Duration
+		expected     time.Duration
+	}{
+		{name: "no pending manual action", requeueAfter: 2 * time.Hour, pending: 0, expected: 2 * time.Hour},
+		{name: "pending manual action before next schedule", requeueAfter: 2 * time.Hour, pending: 5 * time.Minute, expected: 5 * time.Minute},
+		{name: "next schedule before pending manual action", requeueAfter: time.Minute, pending: 7 * time.Minute, expected: time.Minute},
+		{name: "no requeue scheduled", requeueAfter: 0, pending: 5 * time.Minute, expected: 5 * time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, requeueBeforePendingManualAction(test.requeueAfter, test.pending))
+		})
+	}
+}
+
+func TestWindowSchedule(t *testing.T) {
+	sleepInfoReconciler := SleepInfoReconciler{
+		Log:        zap.New(zap.UseDevMode(true)),
+		SleepDelta: 60,
+	}
+	window := &kubegreenv1alpha1.ScheduleWindow{
+		Start: metav1.NewTime(time.Date(2024, 12, 24, 18, 0, 0, 0, time.UTC)),
+		End:   metav1.NewTime(time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		name         string
+		now          time.Time
+		data         SleepInfoData
+		isToExecute  bool
+		nextSchedule time.Time
+		finished     bool
+	}{
+		{
+			name:         "before the start waits for the sleep",
+			now:          time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: sleepOperation},
+			nextSchedule: window.Start.Time,
+		},
+		{
+			name:         "sleeps within the delta before the start",
+			now:          time.Date(2024, 12, 24, 17, 59, 30, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: sleepOperation},
+			isToExecute:  true,
+			nextSchedule: window.End.Time,
+		},
+		{
+			name:         "sleeps late during the window",
+			now:          time.Date(2024, 12, 28, 10, 0, 0, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: sleepOperation},
+			isToExecute:  true,
+			nextSchedule: window.End.Time,
+		},
+		{
+			name:     "sleep missed after the end",
+			now:      time.Date(2025, 1, 2, 8, 0, 0, 0, time.UTC),
+			data:     SleepInfoData{CurrentOperationType: sleepOperation},
+			finished: true,
+		},
+		{
+			name: "sleep after a manual wake before the start",
+			now:  time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC),
+			data: SleepInfoData{
+				CurrentOperationType: sleepOperation,
+				LastSchedule:         time.Date(2024, 12, 24, 9, 0, 0, 0, time.UTC),
+			},
+			nextSchedule: window.Start.Time,
+		},
+		{
+			name: "already woken up during the window",
+			now:  time.Date(2024, 12, 30, 10, 0, 0, 0, time.UTC),
+			data: SleepInfoData{
+				CurrentOperationType: sleepOperation,
+				LastSchedule:         time.Date(2024, 12, 29, 9, 0, 0, 0, time.UTC),
+			},
+			finished: true,
+		},
+		{
+			name:         "waits for the wake up",
+			now:          time.Date(2024, 12, 30, 10, 0, 0, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: wakeUpOperation, LastSchedule: window.Start.Time},
+			nextSchedule: window.End.Time,
+		},
+		{
+			name:         "wakes up at the end",
+			now:          time.Date(2025, 1, 2, 7, 59, 30, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: wakeUpOperation, LastSchedule: window.Start.Time},
+			isToExecute:  true,
+			nextSchedule: window.End.Time,
+		},
+		{
+			name:         "wakes up late after the end",
+			now:          time.Date(2025, 1, 3, 10, 0, 0, 0, time.UTC),
+			data:         SleepInfoData{CurrentOperationType: wakeUpOperation, LastSchedule: window.Start.Time},
+			isToExecute:  true,
+			nextSchedule: window.End.Time,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			isToExecute, nextSchedule, requeueAfter, finished := sleepInfoReconciler.getWindowSchedule(sleepInfoReconciler.Log, window, test.data, test.now)
+			require.Equal(t, test.isToExecute, isToExecute)
+			require.Equal(t, test.finished, finished)
+			require.Equal(t, test.nextSchedule, nextSchedule)
+			if !finished {
+				require.Equal(t, test.nextSchedule.Sub(test.now), requeueAfter)
+			}
+		})
+	}
+}
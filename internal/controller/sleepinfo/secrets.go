@@ -4,13 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +23,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// restoreDataChunkSize is the size of the largest restore patches stored in a secret, under the
+// 1MiB limit of the secrets with room for their other keys
+var restoreDataChunkSize = 900 * 1024
+
 func (r *SleepInfoReconciler) getSecret(ctx context.Context, secretName, namespaceName string) (*v1.Secret, error) {
 	secret := &v1.Secret{}
 	err := r.Get(ctx, client.ObjectKey{
@@ -30,9 +37,155 @@ func (r *SleepInfoReconciler) getSecret(ctx context.Context, secretName, namespa
 		r.Log.Info("failed to get secret", "name", secretName, "namespace", namespaceName, "error", err)
 		return nil, err
 	}
+	if err := readRestoreData(ctx, r.Client, r.RestoreDataKey, secret); err != nil {
+		return nil, err
+	}
 	return secret, nil
 }
 
+// readRestoreData sets in a secret its restore patches, joined from their chunks if split, decrypted
+// and decompressed: the controller handles them as JSON until they are written again. The index of
+// the chunks is kept, to delete them once no longer used.
+func readRestoreData(ctx context.Context, c client.Reader, key *restoredata.EncryptionKey, secret *v1.Secret) error {
+	if index := secret.Data[restoreDataChunksKey]; len(index) > 0 {
+		data, err := restoredata.Join(ctx, c, secret.Namespace, index, originalJSONPatchDataKey)
+		if err != nil {
+			return fmt.Errorf("fails to read restore patches of secret %s: %w", secret.Name, err)
+		}
+		secret.Data[originalJSONPatchDataKey] = data
+	}
+	data, ok := secret.Data[originalJSONPatchDataKey]
+	if !ok {
+		return nil
+	}
+	var encryptionKey []byte
+	if restoredata.IsEncrypted(data) {
+		var err error
+		if encryptionKey, err = key.Get(ctx); err != nil {
+			return fmt.Errorf("fails to read restore patches of secret %s: %w", secret.Name, err)
+		}
+	}
+	decoded, err := restoredata.DecodeWithKey(data, encryptionKey)
+	if err != nil {
+		return fmt.Errorf("fails to read restore patches of secret %s: %w", secret.Name, err)
+	}
+	secret.Data[originalJSONPatchDataKey] = decoded
+	return nil
+}
+
+// storeRestoreData compresses the restore patches of a secret to be written, encrypted when
+// RestoreDataKey is set, returning their size. Patches larger than restoreDataChunkSize are split in
+// the secrets <secret>-0..n, written here before the secret, which stores their index in place of
+// the patches.
+func (r *SleepInfoReconciler) storeRestoreData(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, secret *v1.Secret) (int, error) {
+	data := secret.Data[originalJSONPatchDataKey]
+	if len(data) == 0 {
+		return 0, nil
+	}
+	key, err := r.RestoreDataKey.Get(ctx)
+	if err != nil {
+		return 0, err
+	}
+	encoded, err := restoredata.EncodeWithKey(data, key)
+	if err != nil {
+		return 0, err
+	}
+	if len(encoded) <= restoreDataChunkSize {
+		secret.Data[originalJSONPatchDataKey] = encoded
+		return len(encoded), nil
+	}
+	names := []string{}
+	for i, chunk := range restoredata.Split(encoded, restoreDataChunkSize) {
+		name := restoredata.ChunkSecretName(secret.Name, i)
+		if err := r.upsertRestoreDataChunk(ctx, sleepInfo, secret.Namespace, secret.Name, name, chunk); err != nil {
+			return 0, err
+		}
+		names = append(names, name)
+	}
+	index, err := restoredata.NewIndex(names)
+	if err != nil {
+		return 0, err
+	}
+	delete(secret.Data, originalJSONPatchDataKey)
+	secret.Data[restoreDataChunksKey] = index
+	return len(encoded), nil
+}
+
+// upsertRestoreDataChunk writes a chunk of the restore patches of a secret. A secret with its name
+// not storing a chunk of that secret, e.g. the one of a SleepInfo named <name>-0, is not overwritten.
+func (r *SleepInfoReconciler) upsertRestoreDataChunk(
+	ctx context.Context,
+	sleepInfo *kubegreenv1alpha1.SleepInfo,
+	namespace, secretName, name string,
+	chunk []byte,
+) error {
+	newSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by":              r.ManagerName,
+				"kube-green.stratio.com/restore-data-chunk": secretName,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+					Kind:       "SleepInfo",
+					Name:       sleepInfo.Name,
+					UID:        sleepInfo.UID,
+				},
+			},
+		},
+		Data: map[string][]byte{
+			originalJSONPatchDataKey: chunk,
+		},
+	}
+	existing := &v1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, existing); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		return r.Create(ctx, newSecret)
+	}
+	if existing.Labels["kube-green.stratio.com/restore-data-chunk"] != secretName {
+		return fmt.Errorf("secret %s exists and does not store restore patches of secret %s", name, secretName)
+	}
+	newSecret.ResourceVersion = existing.ResourceVersion
+	return r.Update(ctx, newSecret)
+}
+
+// deleteStaleRestoreDataChunks deletes the chunks of the restore patches of the previous secret not
+// used by the written one
+func (r *SleepInfoReconciler) deleteStaleRestoreDataChunks(ctx context.Context, previous, written *v1.Secret) error {
+	if previous == nil || previous.Data == nil {
+		return nil
+	}
+	previousNames, err := restoredata.ParseIndex(previous.Data[restoreDataChunksKey])
+	if err != nil {
+		return err
+	}
+	names, err := restoredata.ParseIndex(written.Data[restoreDataChunksKey])
+	if err != nil {
+		return err
+	}
+	for _, name := range previousNames {
+		if slices.Contains(names, name) {
+			continue
+		}
+		chunk := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: written.Namespace}}
+		if err := r.Delete(ctx, chunk); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretMetadataAnnotations are the SleepInfo annotations copied to the secret, by secret key
+var secretMetadataAnnotations = map[string]string{
+	userTimezoneKey: "kube-green.stratio.com/user-timezone",
+	scheduleNameKey: "kube-green.stratio.com/schedule-name",
+}
+
 func getSecretName(name string) string {
 	return fmt.Sprintf("sleepinfo-%s", name)
 }
@@ -80,8 +233,16 @@ func (r SleepInfoReconciler) upsertSecret(
 	if resources.HasResource() {
 		newSecret.StringData[lastOperationKey] = sleepInfoData.CurrentOperationType
 	}
+	// The controller is the only writer of the secret: the metadata set by the REST API on the
+	// SleepInfo annotations is copied for the readers of the secret
+	for key, annotation := range secretMetadataAnnotations {
+		if value := sleepInfo.GetAnnotations()[annotation]; value != "" {
+			newSecret.StringData[key] = value
+		}
+	}
 
-	if resources.HasResource() && sleepInfoData.IsSleepOperation() {
+	// A dry run sleep patches nothing, so it keeps the restore patches of the last sleep
+	if resources.HasResource() && sleepInfoData.IsSleepOperation() && !sleepInfo.IsDryRun() {
 		data, err := resources.GetOriginalInfoToSave()
 		if err != nil {
 			logger.Error(err, "failed to get original resource info to save")
@@ -203,6 +364,33 @@ func (r SleepInfoReconciler) upsertSecret(
 		}
 	}
 
+	if sleepInfoData.WakeStages != nil && sleepInfoData.IsWakeUpOperation() {
+		progress, err := json.Marshal(sleepInfoData.WakeStages)
+		if err != nil {
+			logger.Error(err, "failed to marshal wake stages progress")
+			return err
+		}
+		newSecret.Data[wakeStagesDataKey] = progress
+	}
+
+	restoreData := newSecret.Data[originalJSONPatchDataKey]
+	generationsData := newSecret.Data[sleptGenerationsDataKey]
+	if r.RestoreStateCRD {
+		if err := r.saveSleepInfoState(ctx, now, sleepInfo, newSecret); err != nil {
+			logger.Error(err, "failed to save SleepInfoState")
+			return err
+		}
+	}
+
+	size, err := r.storeRestoreData(ctx, sleepInfo, newSecret)
+	if err != nil {
+		logger.Error(err, "failed to store restore patches")
+		return err
+	}
+	if size > 0 {
+		r.setRestoreDataBytes(sleepInfo, size)
+	}
+
 	if secret == nil {
 		if err := r.Create(ctx, newSecret); err != nil {
 			return err
@@ -214,9 +402,17 @@ func (r SleepInfoReconciler) upsertSecret(
 		}
 		logger.Info("secret updated")
 	}
+	if err := r.deleteStaleRestoreDataChunks(ctx, secret, newSecret); err != nil {
+		logger.Error(err, "failed to delete stale restore patches chunks")
+	}
 
-	if data, ok := newSecret.Data[originalJSONPatchDataKey]; ok && len(data) > 0 {
-		if err := r.upsertRestoreSecret(ctx, namespace, sleepInfo, data, newSecret.Data[sleptGenerationsDataKey]); err != nil {
+	// With the restore patches back in the secret the SleepInfoState is stale. The SleepInfoState,
+	// not overwritten by the API, needs no emergency copy.
+	if len(restoreData) > 0 && !r.RestoreStateCRD {
+		if err := r.deleteSleepInfoState(ctx, sleepInfo); err != nil {
+			logger.Error(err, "failed to delete SleepInfoState")
+		}
+		if err := r.upsertRestoreSecret(ctx, namespace, sleepInfo, restoreData, generationsData); err != nil {
 			logger.Error(err, "failed to upsert emergency restore secret")
 		}
 	}
@@ -272,9 +468,15 @@ func (r *SleepInfoReconciler) upsertRestoreSecret(
 	}
 
 	if err != nil && client.IgnoreNotFound(err) == nil {
+		if _, err := r.storeRestoreData(ctx, sleepInfo, newSecret); err != nil {
+			return err
+		}
 		return r.Create(ctx, newSecret)
 	}
 	if restoreSecret.Data != nil {
+		if err := readRestoreData(ctx, r.Client, r.RestoreDataKey, restoreSecret); err != nil {
+			return err
+		}
 		if existing := restoreSecret.Data[originalJSONPatchDataKey]; len(existing) > 0 {
 			existingCount := countRestorePatchesFromBytes(existing)
 			newCount := countRestorePatchesFromBytes(data)
@@ -287,8 +489,14 @@ func (r *SleepInfoReconciler) upsertRestoreSecret(
 			}
 		}
 	}
+	if _, err := r.storeRestoreData(ctx, sleepInfo, newSecret); err != nil {
+		return err
+	}
 	newSecret.ResourceVersion = restoreSecret.ResourceVersion
-	return r.Update(ctx, newSecret)
+	if err := r.Update(ctx, newSecret); err != nil {
+		return err
+	}
+	return r.deleteStaleRestoreDataChunks(ctx, restoreSecret, newSecret)
 }
 
 func (r *SleepInfoReconciler) getEmergencyRestorePatches(
@@ -304,6 +512,9 @@ func (r *SleepInfoReconciler) getEmergencyRestorePatches(
 	if secret == nil || secret.Data == nil {
 		return nil, nil, nil
 	}
+	if err := readRestoreData(ctx, r.Client, r.RestoreDataKey, secret); err != nil {
+		return nil, nil, err
+	}
 	restorePatches, err := jsonpatch.GetOriginalInfoToRestore(secret.Data[originalJSONPatchDataKey])
 	if err != nil {
 		return nil, nil, err
@@ -404,6 +615,10 @@ func countRestorePatchesFromBytes(data []byte) int {
 	if len(data) == 0 {
 		return 0
 	}
+	data, err := restoredata.Decode(data)
+	if err != nil {
+		return 0
+	}
 	parsed := map[string]jsonpatch.RestorePatches{}
 	if err := json.Unmarshal(data, &parsed); err != nil {
 		return 0
@@ -482,3 +697,14 @@ func (r *SleepInfoReconciler) setRestoreIncomplete(ctx context.Context, sleepInf
 		return r.Update(ctx, latest)
 	})
 }
+
+// setRestoreDataBytes records the size of the restore patches stored in the secret of the SleepInfo
+func (r *SleepInfoReconciler) setRestoreDataBytes(sleepInfo *kubegreenv1alpha1.SleepInfo, size int) {
+	if r.Metrics.RestoreDataBytes == nil {
+		return
+	}
+	r.Metrics.RestoreDataBytes.With(prometheus.Labels{
+		"name":      sleepInfo.Name,
+		"namespace": sleepInfo.Namespace,
+	}).Set(float64(size))
+}
@@ -9,15 +9,20 @@ import (
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/internal/mocks"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/metrics"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
 	"github.com/kube-green/kube-green/internal/testutil"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
@@ -437,6 +442,222 @@ func TestUpsertSecrets(t *testing.T) {
 		require.EqualError(t, err, "error during create")
 	})
 
+	t.Run("stores the restore patches compressed", func(t *testing.T) {
+		client := fakeDeploymentClient(&d1, &d2, &d3)
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			SleepDelta:  60,
+			ManagerName: managerName,
+			Metrics:     metrics.SetupMetricsOrDie("test"),
+		}
+		sleepInfoData := SleepInfoData{
+			CurrentOperationType: sleepOperation,
+		}
+		resources, err := jsonpatch.NewResources(context.Background(), resource.ResourceClient{
+			Client:           client,
+			Log:              testLogger,
+			SleepInfo:        sleepInfo,
+			FieldManagerName: testFieldManagerName,
+		}, namespace, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, resources.Sleep(context.Background()))
+
+		err = r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, nil, sleepInfoData, resources)
+		require.NoError(t, err)
+
+		for _, name := range []string{secretName, getRestoreSecretName(sleepInfo.Name)} {
+			stored := &v1.Secret{}
+			require.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKey{Name: name, Namespace: namespace}, stored))
+			require.True(t, restoredata.IsCompressed(stored.Data[originalJSONPatchDataKey]), name)
+			decoded, err := restoredata.Decode(stored.Data[originalJSONPatchDataKey])
+			require.NoError(t, err)
+			require.JSONEq(t, `{"Deployment.apps":{"deployment1":"{\"spec\":{\"replicas\":1}}","deployment2":"{\"spec\":{\"replicas\":4}}"}}`, string(decoded))
+			if name == secretName {
+				require.Equal(t, float64(len(stored.Data[originalJSONPatchDataKey])), promtestutil.ToFloat64(r.Metrics.RestoreDataBytes.WithLabelValues(sleepInfo.Name, sleepInfo.Namespace)))
+			}
+		}
+	})
+
+	t.Run("splits oversized restore patches in chunks", func(t *testing.T) {
+		defer func(size int) { restoreDataChunkSize = size }(restoreDataChunkSize)
+		restoreDataChunkSize = 64
+		client := fakeDeploymentClient(&d1, &d2, &d3)
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			ManagerName: managerName,
+		}
+		resources, err := jsonpatch.NewResources(context.Background(), resource.ResourceClient{
+			Client:           client,
+			Log:              testLogger,
+			SleepInfo:        sleepInfo,
+			FieldManagerName: testFieldManagerName,
+		}, namespace, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, resources.Sleep(context.Background()))
+
+		err = r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, nil, SleepInfoData{CurrentOperationType: sleepOperation}, resources)
+		require.NoError(t, err)
+
+		stored := &v1.Secret{}
+		require.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKey{Name: secretName, Namespace: namespace}, stored))
+		require.NotContains(t, stored.Data, originalJSONPatchDataKey)
+		chunks, err := restoredata.ParseIndex(stored.Data[restoreDataChunksKey])
+		require.NoError(t, err)
+		require.Greater(t, len(chunks), 1)
+		require.Equal(t, secretName+"-0", chunks[0])
+		chunk := &v1.Secret{}
+		require.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKey{Name: chunks[0], Namespace: namespace}, chunk))
+		require.Len(t, chunk.Data[originalJSONPatchDataKey], 64)
+		require.Equal(t, secretName, chunk.Labels["kube-green.stratio.com/restore-data-chunk"])
+		require.Equal(t, sleepInfo.UID, chunk.OwnerReferences[0].UID)
+
+		expected := `{"Deployment.apps":{"deployment1":"{\"spec\":{\"replicas\":1}}","deployment2":"{\"spec\":{\"replicas\":4}}"}}`
+		secret, err := r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.JSONEq(t, expected, string(secret.Data[originalJSONPatchDataKey]))
+		restorePatches, _, err := r.getEmergencyRestorePatches(context.Background(), sleepInfo, namespace)
+		require.NoError(t, err)
+		require.Len(t, restorePatches["Deployment.apps"], 2)
+
+		t.Run("deletes the chunks no longer used", func(t *testing.T) {
+			restoreDataChunkSize = 900 * 1024
+			err := r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, secret, SleepInfoData{CurrentOperationType: wakeUpOperation}, resources)
+			require.NoError(t, err)
+
+			secret, err := r.getSecret(context.Background(), secretName, namespace)
+			require.NoError(t, err)
+			require.NotContains(t, secret.Data, restoreDataChunksKey)
+			require.JSONEq(t, expected, string(secret.Data[originalJSONPatchDataKey]))
+			for _, name := range chunks {
+				err := client.Get(context.Background(), ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &v1.Secret{})
+				require.True(t, apierrors.IsNotFound(err), name)
+			}
+		})
+	})
+
+	t.Run("copies the user timezone and the schedule name of the annotations", func(t *testing.T) {
+		client := fakeDeploymentClient(&d1)
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			ManagerName: managerName,
+		}
+		sleepInfo := sleepInfo.DeepCopy()
+		sleepInfo.Annotations = map[string]string{
+			"kube-green.stratio.com/user-timezone": "America/Bogota",
+			"kube-green.stratio.com/schedule-name": "night",
+		}
+		resources, err := jsonpatch.NewResources(context.Background(), resource.ResourceClient{
+			Client:           client,
+			Log:              testLogger,
+			SleepInfo:        sleepInfo,
+			FieldManagerName: testFieldManagerName,
+		}, namespace, nil, nil)
+		require.NoError(t, err)
+
+		err = r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, nil, SleepInfoData{CurrentOperationType: sleepOperation}, resources)
+		require.NoError(t, err)
+
+		secret, err := r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.Equal(t, "America/Bogota", string(secret.Data[userTimezoneKey]))
+		require.Equal(t, "night", string(secret.Data[scheduleNameKey]))
+	})
+
+	t.Run("does not overwrite a secret named as a chunk", func(t *testing.T) {
+		defer func(size int) { restoreDataChunkSize = size }(restoreDataChunkSize)
+		restoreDataChunkSize = 64
+		client := fakeDeploymentClient(&d1, &d2, &d3, getSecret(mockSecretSpec{
+			namespace: namespace,
+			name:      secretName + "-0",
+			data: map[string][]byte{
+				lastOperationKey: []byte(sleepOperation),
+			},
+		}))
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			ManagerName: managerName,
+		}
+		resources, err := jsonpatch.NewResources(context.Background(), resource.ResourceClient{
+			Client:           client,
+			Log:              testLogger,
+			SleepInfo:        sleepInfo,
+			FieldManagerName: testFieldManagerName,
+		}, namespace, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, resources.Sleep(context.Background()))
+
+		err = r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, nil, SleepInfoData{CurrentOperationType: sleepOperation}, resources)
+		require.EqualError(t, err, "secret secret-name-0 exists and does not store restore patches of secret secret-name")
+	})
+
+	t.Run("encrypts the restore patches with the key of the secret", func(t *testing.T) {
+		client := fakeDeploymentClient(&d1, &d2, &d3, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "restore-key", Namespace: "kube-green"},
+			Data:       map[string][]byte{restoredata.EncryptionKeySecretKey: []byte("0123456789abcdef")},
+		})
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			ManagerName: managerName,
+			RestoreDataKey: &restoredata.EncryptionKey{
+				Client:    client,
+				Namespace: "kube-green",
+				Secret:    "restore-key",
+			},
+		}
+		resources, err := jsonpatch.NewResources(context.Background(), resource.ResourceClient{
+			Client:           client,
+			Log:              testLogger,
+			SleepInfo:        sleepInfo,
+			FieldManagerName: testFieldManagerName,
+		}, namespace, nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, resources.Sleep(context.Background()))
+
+		err = r.upsertSecret(context.Background(), testLogger, now, secretName, namespace, sleepInfo, nil, SleepInfoData{CurrentOperationType: sleepOperation}, resources)
+		require.NoError(t, err)
+
+		for _, name := range []string{secretName, getRestoreSecretName(sleepInfo.Name)} {
+			stored := &v1.Secret{}
+			require.NoError(t, client.Get(context.Background(), ctrlclient.ObjectKey{Name: name, Namespace: namespace}, stored))
+			require.True(t, restoredata.IsEncrypted(stored.Data[originalJSONPatchDataKey]), name)
+			require.NotContains(t, string(stored.Data[originalJSONPatchDataKey]), "deployment1")
+		}
+		secret, err := r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"Deployment.apps":{"deployment1":"{\"spec\":{\"replicas\":1}}","deployment2":"{\"spec\":{\"replicas\":4}}"}}`, string(secret.Data[originalJSONPatchDataKey]))
+		restorePatches, _, err := r.getEmergencyRestorePatches(context.Background(), sleepInfo, namespace)
+		require.NoError(t, err)
+		require.Len(t, restorePatches["Deployment.apps"], 2)
+
+		r.RestoreDataKey = nil
+		_, err = r.getSecret(context.Background(), secretName, namespace)
+		require.ErrorIs(t, err, restoredata.ErrMissingEncryptionKey)
+	})
+
+	t.Run("reads restore patches stored uncompressed", func(t *testing.T) {
+		client := fakeDeploymentClient(getSecret(mockSecretSpec{
+			namespace: namespace,
+			name:      secretName,
+			data: map[string][]byte{
+				originalJSONPatchDataKey: []byte(`{"Deployment.apps":{"deployment1":"{\"spec\":{\"replicas\":1}}"}}`),
+			},
+		}))
+		r := SleepInfoReconciler{
+			Client:      client,
+			Log:         testLogger,
+			ManagerName: managerName,
+		}
+
+		secret, err := r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.Equal(t, `{"Deployment.apps":{"deployment1":"{\"spec\":{\"replicas\":1}}"}}`, string(secret.Data[originalJSONPatchDataKey]))
+	})
+
 	t.Run("fails to update secret", func(t *testing.T) {
 		existentSecret := getSecret(mockSecretSpec{
 			namespace:       namespace,
@@ -0,0 +1,63 @@
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// Shard is the part of the namespaces reconciled by a controller replica: the namespaces whose hash
+// modulo Count is Index. The zero value, or a Count of 1, reconciles all the namespaces.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// Validate returns an error if the index is not one of the Count shards
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got: %d", s.Count)
+	}
+	if s.Index < 0 || (s.Index > 0 && s.Index >= s.Count) {
+		return fmt.Errorf("shard index must be between 0 and %d, got: %d", max(s.Count-1, 0), s.Index)
+	}
+	return nil
+}
+
+// IsEnabled returns true if the namespaces are split between more than one shard
+func (s Shard) IsEnabled() bool {
+	return s.Count > 1
+}
+
+// IsFirst returns true for the shard which also runs the cluster-scoped controllers
+func (s Shard) IsFirst() bool {
+	return s.Index == 0
+}
+
+// Owns returns true if the namespace belongs to the shard
+func (s Shard) Owns(namespace string) bool {
+	if !s.IsEnabled() {
+		return true
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index
+}
+
+// LeaderElectionID returns the leader election ID of the shard, so that each shard elects its own
+// leader among the replicas running it
+func (s Shard) LeaderElectionID(id string) string {
+	if !s.IsEnabled() {
+		return id
+	}
+	return fmt.Sprintf("shard-%d-%s", s.Index, id)
+}
+
+// Predicate filters the events of the objects in the namespaces of the shard
+func (s Shard) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.Owns(obj.GetNamespace())
+	})
+}
@@ -0,0 +1,60 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestShard(t *testing.T) {
+	t.Run("validate", func(t *testing.T) {
+		require.NoError(t, Shard{}.Validate())
+		require.NoError(t, Shard{Index: 2, Count: 3}.Validate())
+		require.EqualError(t, Shard{Index: 3, Count: 3}.Validate(), "shard index must be between 0 and 2, got: 3")
+		require.EqualError(t, Shard{Index: 1}.Validate(), "shard index must be between 0 and 0, got: 1")
+		require.EqualError(t, Shard{Count: -1}.Validate(), "shard count must not be negative, got: -1")
+	})
+
+	t.Run("without sharding owns all the namespaces", func(t *testing.T) {
+		for _, shard := range []Shard{{}, {Count: 1}} {
+			require.False(t, shard.IsEnabled())
+			require.True(t, shard.Owns("my-namespace"))
+			require.Equal(t, "2bd226ed.kube-green.com", shard.LeaderElectionID("2bd226ed.kube-green.com"))
+		}
+	})
+
+	t.Run("every namespace belongs to exactly one shard", func(t *testing.T) {
+		counts := make([]int, 3)
+		for i := 0; i < 300; i++ {
+			namespace := fmt.Sprintf("tenant-%d", i)
+			owners := 0
+			for index := range counts {
+				if (Shard{Index: index, Count: 3}).Owns(namespace) {
+					owners++
+					counts[index]++
+				}
+			}
+			require.Equal(t, 1, owners, namespace)
+		}
+		for _, count := range counts {
+			require.Greater(t, count, 50)
+		}
+	})
+
+	t.Run("leader election ID", func(t *testing.T) {
+		require.Equal(t, "shard-1-2bd226ed.kube-green.com", Shard{Index: 1, Count: 3}.LeaderElectionID("2bd226ed.kube-green.com"))
+	})
+
+	t.Run("predicate", func(t *testing.T) {
+		shard := Shard{Index: 0, Count: 2}
+		pred := shard.Predicate()
+		for _, namespace := range []string{"tenant-1", "tenant-2", "tenant-3"} {
+			obj := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "sleepinfo", Namespace: namespace}}
+			require.Equal(t, shard.Owns(namespace), pred.Create(event.CreateEvent{Object: obj}))
+		}
+	})
+}
@@ -11,20 +11,30 @@ import (
 	"time"
 
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/alertmanager"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/holidays"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/metrics"
-	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/patchtargets"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/sharding"
+	"github.com/kube-green/kube-green/internal/notifications"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
@@ -32,7 +42,10 @@ const (
 	lastScheduleKey               = "scheduled-at"
 	lastOperationKey              = "operation-type"
 	originalJSONPatchDataKey      = "original-resource-info"
+	restoreDataChunksKey          = "original-resource-info-chunks"
 	sleptGenerationsDataKey       = "sleep-resource-generations"
+	userTimezoneKey               = "user-timezone"
+	scheduleNameKey               = "schedule-name"
 	replicasBeforeSleepAnnotation = "sleepinfo.kube-green.com/replicas-before-sleep"
 
 	sleepOperation  = "SLEEP"
@@ -54,6 +67,36 @@ type SleepInfoReconciler struct {
 	SleepDelta              int64
 	ManagerName             string
 	MaxConcurrentReconciles int
+	// Notifier, when set, receives an event for every executed sleep and wake up
+	Notifier notifications.Notifier
+	// Holidays reads the holiday calendars of the SleepInfos
+	Holidays *holidays.Loader
+	// HolidayCalendar, when set, is the cluster holiday calendar of the SleepInfos with a holiday
+	// policy and without holiday calendar
+	HolidayCalendar *kubegreenv1alpha1.HolidayCalendar
+	// PatchTargets, when set, reads the patch targets configured for new operators
+	PatchTargets *patchtargets.Loader
+	// Recorder, when set, records an Event on the SleepInfo when an operation starts, succeeds or fails
+	Recorder record.EventRecorder
+	// WorkloadEvents also records an Event on every resource slept or woken up
+	WorkloadEvents bool
+	// WakeUpOnDeletion is the default of spec.wakeUpOnDeletion
+	WakeUpOnDeletion bool
+	// Shard restricts the reconciled SleepInfos to the namespaces of a shard
+	Shard sharding.Shard
+	// RestoreStateCRD stores the restore patches in a SleepInfoState instead of the secret
+	RestoreStateCRD bool
+	// RestoreDataKey, when set, encrypts the restore patches stored in the secrets
+	RestoreDataKey *restoredata.EncryptionKey
+	// Alertmanager, when set, silences the alerts of the SleepInfos asleep
+	Alertmanager *alertmanager.Client
+	// NodeScaleDown enables spec.nodeScaleDown, which needs access to the nodes and the pods
+	NodeScaleDown bool
+	// HTTPHooks enables the HTTP hooks, failed otherwise since the controller calls them from the cluster
+	HTTPHooks bool
+	// HTTPHookAllowedHosts, when set, are the only hosts the HTTP hooks can call; "*.example.com"
+	// allows the subdomains
+	HTTPHookAllowedHosts []string
 }
 
 type realClock struct{}
@@ -72,6 +115,12 @@ type Clock interface {
 // +kubebuilder:rbac:groups=kube-green.com,resources=sleepinfos/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kube-green.com,resources=sleepinfos/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kube-green.com,resources=sleepinfostates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=create;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -88,17 +137,34 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// requeue (we'll need to wait for a new notification), and we can get them
 		// on deleted requests.
 		if apierrors.IsNotFound(err) {
-			r.Metrics.CurrentSleepInfo.Delete(prometheus.Labels{
-				"name":      req.Name,
-				"namespace": req.Namespace,
-			})
+			r.deleteMetrics(req)
 		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// Skip reconcile: nothing is done until the annotation is removed, except finalizing a deletion
+	if sleepInfo.IsReconcileSkipped() && sleepInfo.DeletionTimestamp.IsZero() {
+		log.Info("reconcile skipped by annotation", "annotation", kubegreenv1alpha1.SkipReconcileAnnotation)
+		r.markReconcileSkipped(ctx, log, sleepInfo)
+		return ctrl.Result{}, nil
+	}
+	if err := r.reconcileTargetNamespaces(ctx, log, sleepInfo); err != nil {
+		log.Error(err, "fails to apply SleepInfo to target namespaces")
+		return ctrl.Result{}, err
+	}
+	if deleted, err := r.reconcileFinalizer(ctx, log, sleepInfo); deleted || err != nil {
+		if err == nil {
+			r.deleteMetrics(req)
+		}
+		return ctrl.Result{}, err
+	}
 	r.Metrics.CurrentSleepInfo.With(prometheus.Labels{
 		"name":      req.Name,
 		"namespace": req.Namespace,
 	}).Set(1)
+	// The resources asleep and their requests are set from the status too, to be exposed again after
+	// a restart
+	r.Metrics.SetSuspendedResources(req.Name, req.Namespace, sleepInfo.Status.SuspendedResourceCounts)
+	r.setSavedResourcesMetric(sleepInfo, sleepInfo.Status.SavedResources)
 
 	secretName := getSecretName(req.Name)
 	secret, err := r.getSecret(ctx, secretName, req.Namespace)
@@ -106,27 +172,48 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Error(err, "unable to fetch namespace", "namespaceName", req.Namespace)
 		return ctrl.Result{}, err
 	}
+	if err := loadSleepInfoState(ctx, r.Client, req.Namespace, req.Name, secret); err != nil {
+		log.Error(err, "unable to fetch SleepInfoState")
+		return ctrl.Result{}, err
+	}
 	sleepInfoData, err := getSleepInfoData(secret, sleepInfo)
 	if err != nil {
 		log.Error(err, "unable to get secret data")
 		return ctrl.Result{}, err
 	}
+	if isToDeleteWhenCompleted(sleepInfo) {
+		return ctrl.Result{}, r.deleteCompleted(ctx, log, sleepInfo)
+	}
 	now := r.Now()
 
 	manualAction := ""
 	manualActionAt := ""
 	manualActionValid := false
 	manualActionShouldClear := false
+	var manualActionPending time.Duration
 	if sleepInfo.Annotations != nil {
 		manualAction = strings.ToLower(strings.TrimSpace(sleepInfo.Annotations[manualActionAnnotation]))
 		manualActionAt = strings.TrimSpace(sleepInfo.Annotations[manualActionTimeAnnotion])
 	}
 
-	isToExecute, nextSchedule, requeueAfter, err := r.getNextSchedule(log, sleepInfoData, now)
-	if err != nil {
-		log.Error(err, "unable to update deployment with 0 replicas")
-		return ctrl.Result{}, err
+	var isToExecute bool
+	var nextSchedule time.Time
+	var requeueAfter time.Duration
+	if sleepInfo.IsWindow() {
+		var finished bool
+		isToExecute, nextSchedule, requeueAfter, finished = r.getWindowSchedule(log, sleepInfo.Spec.Window, sleepInfoData, now)
+		if finished {
+			return ctrl.Result{}, r.deleteFinishedWindow(ctx, log, sleepInfo)
+		}
+	} else {
+		isToExecute, nextSchedule, requeueAfter, err = r.getNextSchedule(log, sleepInfoData, now)
+		if err != nil {
+			log.Error(err, "unable to update deployment with 0 replicas")
+			r.updateNotReadyStatus(ctx, log, sleepInfo, "InvalidSchedule", err)
+			return ctrl.Result{}, err
+		}
 	}
+	r.observeGeneration(ctx, log, sleepInfo)
 	cronIsToExecute := isToExecute
 
 	if manualAction == "sleep" || manualAction == "wake" {
@@ -141,6 +228,11 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				log.Info("manual action expired, ignoring manual action", "manualAt", manualActionAt, "sleepinfo", sleepInfo.Name)
 				manualActionValid = false
 				manualActionShouldClear = true
+			} else if parsedAt.After(now) {
+				// Staged manual action (e.g. the later steps of a wake-now): keep it pending until its time.
+				log.Info("manual action scheduled, waiting", "manualAt", manualActionAt, "sleepinfo", sleepInfo.Name)
+				manualActionValid = false
+				manualActionPending = parsedAt.Sub(now)
 			}
 		}
 	}
@@ -159,7 +251,7 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		// with the opposite operation, getNextSchedule already advanced nextSchedule to the
 		// next operation in the original sequence (which is now the wrong one). Recalculate
 		// using CurrentOperationSchedule so requeueAfter points to the skipped operation.
-		if cronIsToExecute && sleepInfoData.CurrentOperationType != originalOperationType {
+		if cronIsToExecute && sleepInfoData.CurrentOperationType != originalOperationType && !sleepInfo.IsWindow() {
 			if nextOpSched, parseErr := getCronParsed(sleepInfoData.CurrentOperationSchedule); parseErr == nil {
 				scheduleDelta := time.Duration(r.SleepDelta) * time.Second
 				nextSchedule = nextOpSched.Next(now.Add(scheduleDelta))
@@ -171,6 +263,11 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				)
 			}
 		}
+		// The wake up after a sleep duration is due from the manual sleep, not from a schedule
+		if sleepInfoData.IsSleepOperation() && sleepInfoData.SleepDuration > 0 {
+			nextSchedule = now.Add(sleepInfoData.SleepDuration)
+			requeueAfter = getRequeueAfter(nextSchedule, now)
+		}
 	}
 	// Suspension check: skip cron schedule if suspended unless a manual action is active.
 	// Manual actions (kube-green.stratio.com/manual-action annotation) always override the suspension.
@@ -183,15 +280,141 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			"sleepinfo", sleepInfo.Name,
 		)
 		r.reconcilePairedStatus(ctx, log, sleepInfo, req.Namespace)
-		return ctrl.Result{RequeueAfter: suspendRequeue}, nil
+		return ctrl.Result{RequeueAfter: requeueBeforePendingManualAction(suspendRequeue, manualActionPending)}, nil
+	}
+	// Pause check: a suspended schedule is skipped until resumed; the spec change triggers a new reconcile.
+	if !manualActionValid && sleepInfo.IsSuspended() {
+		log.Info("schedule paused", "sleepinfo", sleepInfo.Name)
+		r.reconcilePairedStatus(ctx, log, sleepInfo, req.Namespace)
+		return ctrl.Result{RequeueAfter: manualActionPending}, nil
+	}
+	// Snooze check: the scheduled sleep is skipped until snooze-until, then executed once.
+	snoozeDue := false
+	if snoozeUntil, snoozed := sleepInfo.GetSnoozeUntil(); snoozed && !manualActionValid {
+		if now.Before(snoozeUntil) {
+			snoozePending := snoozeUntil.Sub(now)
+			if isToExecute && sleepInfoData.IsSleepOperation() {
+				log.Info("scheduled sleep snoozed", "snoozeUntil", snoozeUntil, "sleepinfo", sleepInfo.Name)
+				r.reconcilePairedStatus(ctx, log, sleepInfo, req.Namespace)
+				return ctrl.Result{RequeueAfter: requeueBeforePendingManualAction(snoozePending, manualActionPending)}, nil
+			}
+			requeueAfter = requeueBeforePendingManualAction(requeueAfter, snoozePending)
+		} else {
+			snoozeDue = true
+			if !isToExecute && sleepInfoData.IsSleepOperation() {
+				log.Info("snooze expired, executing delayed sleep", "snoozeUntil", snoozeUntil, "sleepinfo", sleepInfo.Name)
+				isToExecute = true
+				// The cron already moved past the snoozed sleep: wait for the next wake up
+				if nextOpSched, parseErr := getCronParsed(sleepInfoData.NextOperationSchedule); parseErr == nil {
+					nextSchedule = nextOpSched.Next(now)
+					requeueAfter = getRequeueAfter(nextSchedule, now)
+				}
+			}
+		}
+	}
+	// Holiday check: the holiday policy skips the scheduled operation, which runs again at its next occurrence.
+	if isToExecute && !manualActionValid && !snoozeDue && !sleepInfo.IsWindow() && r.isHolidaySkipped(ctx, log, sleepInfo, sleepInfoData, now) {
+		if currentOpSched, parseErr := getCronParsed(sleepInfoData.CurrentOperationSchedule); parseErr == nil {
+			nextSchedule = currentOpSched.Next(now.Add(time.Duration(r.SleepDelta) * time.Second))
+			requeueAfter = getRequeueAfter(nextSchedule, now)
+		}
+		log.Info("scheduled operation skipped on holiday", "operation", sleepInfoData.CurrentOperationType, "policy", sleepInfo.GetHolidayPolicy(), "sleepinfo", sleepInfo.Name)
+		isToExecute = false
+	}
+	// Pre-sleep hooks: the sleep waits for its hooks, and is skipped when one of them fails. A sleep
+	// waiting for the Job of a hook is executed once the Job finishes, even after its schedule.
+	if !sleepInfo.IsDryRun() && len(sleepInfo.Spec.PreSleepHooks) > 0 && ((isToExecute && sleepInfoData.IsSleepOperation()) || isHookRunning(sleepInfo, sleepOperation)) {
+		hooks, err := r.runHooks(ctx, log, sleepInfo, sleepOperation, sleepInfo.Spec.PreSleepHooks, now)
+		if err != nil {
+			log.Error(err, "fails to run pre-sleep hooks")
+			return ctrl.Result{}, err
+		}
+		events := r.operationEvents(sleepInfo, sleepOperation)
+		for _, failure := range hooks.failures {
+			events.hookFailed(failure, hooks.failed != nil && failure.Name == hooks.failed.Name)
+		}
+		switch {
+		case hooks.pending:
+			log.Info("waiting for pre-sleep hooks", "sleepinfo", sleepInfo.Name)
+			return ctrl.Result{RequeueAfter: hookPollInterval}, nil
+		case hooks.failed != nil:
+			if manualActionValid {
+				if err := r.clearManualAction(ctx, sleepInfo); err != nil {
+					log.Error(err, "failed to clear manual action annotation")
+				}
+			}
+			if currentOpSched, parseErr := getCronParsed(sleepInfoData.CurrentOperationSchedule); parseErr == nil && !sleepInfo.IsWindow() {
+				nextSchedule = currentOpSched.Next(now.Add(time.Duration(r.SleepDelta) * time.Second))
+				requeueAfter = getRequeueAfter(nextSchedule, now)
+			}
+			log.Info("sleep aborted by failed pre-sleep hook", "hook", hooks.failed.Name, "message", hooks.failed.Message, "sleepinfo", sleepInfo.Name)
+			isToExecute = false
+		case !isToExecute:
+			log.Info("pre-sleep hooks finished, executing sleep", "sleepinfo", sleepInfo.Name)
+			isToExecute = true
+			// The cron already moved past the sleep: wait for the next wake up
+			if nextOpSched, parseErr := getCronParsed(sleepInfoData.NextOperationSchedule); parseErr == nil && sleepInfoData.IsSleepOperation() && !sleepInfo.IsWindow() {
+				nextSchedule = nextOpSched.Next(now)
+				if sleepInfoData.SleepDuration > 0 {
+					nextSchedule = now.Add(sleepInfoData.SleepDuration)
+				}
+				requeueAfter = getRequeueAfter(nextSchedule, now)
+			}
+			sleepInfoData.CurrentOperationType = sleepOperation
+		}
 	}
 	scheduleLog := log.WithValues("now", r.Now(), "next run", nextSchedule, "requeue", requeueAfter)
 
 	if !isToExecute {
 		scheduleLog.Info("skip execution")
 		r.reconcilePairedStatus(ctx, log, sleepInfo, req.Namespace)
+		if snoozeDue {
+			r.clearSnooze(ctx, log, sleepInfo)
+		}
+		// Pre-sleep delay: the resources are annotated with the time of their sleep before it
+		announceIn, err := r.announceSleep(ctx, log, sleepInfo, sleepInfoData, nextSchedule, now)
+		if err != nil {
+			log.Error(err, "fails to announce sleep")
+			return ctrl.Result{}, err
+		}
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, announceIn)
+		// Karpenter: the limits of the NodePools are restored shortly before the wake up
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.prepareWakeUp(ctx, log, sleepInfo, sleepInfoData, nextSchedule, now))
+		// Wake stages: the stages of the last wake up are executed once their delay is over
+		if sleepInfoData.WakeStages != nil {
+			nextStage, err := r.wakeUpPendingStages(ctx, log, sleepInfo, secret, sleepInfoData, now)
+			if err != nil {
+				log.Error(err, "fails to handle wake stages")
+				return ctrl.Result{
+					Requeue: true,
+				}, err
+			}
+			requeueAfter = requeueBeforePendingManualAction(requeueAfter, nextStage)
+			if nextStage == 0 {
+				// The last wake stage completes the wake up
+				r.expireAlertSilence(ctx, log, sleepInfo)
+				requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.runPostWakeHooks(ctx, log, sleepInfo, true, now))
+			}
+			if nextStage == 0 && sleepInfo.IsDeleteWhenCompleted() && sleepInfo.IsCompleted() {
+				return ctrl.Result{}, r.deleteCompleted(ctx, log, sleepInfo)
+			}
+		} else {
+			// Post-wake hooks: the hooks of the last wake up are checked while running
+			requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.runPostWakeHooks(ctx, log, sleepInfo, false, now))
+		}
+		// Node scale-down: the nodes of the last sleep still running workloads are checked again
+		if sleepInfo.Status.CurrentState == kubegreenv1alpha1.StateSleeping && sleepInfo.Status.LastSleepTime != nil {
+			requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.scaleDownNodes(ctx, log, sleepInfo, sleepInfo.Status.LastSleepTime.Time, now))
+		}
 		return ctrl.Result{
-			RequeueAfter: requeueAfter,
+			RequeueAfter: requeueBeforePendingManualAction(requeueAfter, manualActionPending),
+		}, nil
+	}
+	// Execute once: a completed SleepInfo skips its scheduled operations, manual actions still work
+	if !manualActionValid && sleepInfo.IsCompleted() {
+		scheduleLog.Info("execute once completed, skip execution")
+		return ctrl.Result{
+			RequeueAfter: requeueBeforePendingManualAction(requeueAfter, manualActionPending),
 		}, nil
 	}
 	scheduleLog.WithValues("last schedule", now, "status", sleepInfo.Status).Info("last schedule value")
@@ -200,7 +423,7 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	restorePatches := sleepInfoData.OriginalGenericResourceInfo
 	sleptGenerations := sleepInfoData.SleptResourceGenerations
 	if sleepInfoData.IsWakeUpOperation() {
-		relatedPatches, relatedGenerations, err := getRelatedRestorePatches(ctx, r.Client, log, sleepInfo, req.Namespace)
+		relatedPatches, relatedGenerations, err := getRelatedRestorePatches(ctx, r.Client, r.RestoreDataKey, log, sleepInfo, req.Namespace)
 		if err != nil {
 			log.Error(err, "failed to get related restore patches, using current ones")
 		} else if (relatedPatches != nil && len(relatedPatches) > 0) || (relatedGenerations != nil && len(relatedGenerations) > 0) {
@@ -258,62 +481,72 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// EXTENSIÓN: Agregar patches dinámicos para PgCluster, HDFSCluster, OsCluster y KafkaCluster según operación
-	// Los patches de anotaciones dependen de si es SLEEP (shutdown=true) o WAKE (shutdown=false)
-	sleepInfoWithPatches := sleepInfo.DeepCopy()
-	if sleepInfoData.IsSleepOperation() {
-		if sleepInfo.IsPostgresToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.PgclusterSleepPatch)
-			log.Info("added pgcluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsHdfsToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.HdfsclusterSleepPatch)
-			log.Info("added hdfscluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsOpenSearchToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.OsclusterSleepPatch)
-			log.Info("added oscluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsKafkaToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.KafkaclusterSleepPatch)
-			log.Info("added kafkacluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-	} else if sleepInfoData.IsWakeUpOperation() {
-		if sleepInfo.IsPostgresToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.PgclusterWakePatch)
-			log.Info("added pgcluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsHdfsToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.HdfsclusterWakePatch)
-			log.Info("added hdfscluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsOpenSearchToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.OsclusterWakePatch)
-			log.Info("added oscluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
-		if sleepInfo.IsKafkaToSuspend() {
-			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.KafkaclusterWakePatch)
-			log.Info("added kafkacluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", req.Namespace)
-		}
+	resourceClient := r.resourceClient(ctx, log, sleepInfo, sleepInfoData)
+
+	var wakeStages *WakeStagesProgress
+	var wakeUpFilter func(kubegreenv1alpha1.PatchTarget, unstructured.Unstructured) bool
+	if sleepInfoData.IsWakeUpOperation() && !sleepInfo.IsDryRun() {
+		wakeUpFilter, wakeStages = startWakeStages(sleepInfo, now)
 	}
+	sleepInfoData.WakeStages = wakeStages
+	resourceClient.WakeUpFilter = wakeUpFilter
+	events := r.operationEvents(sleepInfo, sleepInfoData.CurrentOperationType)
+	resourceClient.Patched = events.resourcePatched
+	resourceClient.Failed = events.resourceFailed
 
-	resources, err := jsonpatch.NewResources(ctx, resource.ResourceClient{
-		Client:           r.Client,
-		SleepInfo:        sleepInfoWithPatches,
-		Log:              log,
-		FieldManagerName: r.ManagerName,
-	}, req.Namespace, restorePatches, sleptGenerations)
+	resources, err := jsonpatch.NewResources(ctx, resourceClient, req.Namespace, restorePatches, sleptGenerations)
 	if err != nil {
 		log.Error(err, "fails to get resources")
 		return ctrl.Result{}, err
 	}
 
-	if err := r.handleSleepInfoStatus(ctx, now, sleepInfo, sleepInfoData.CurrentOperationType); err != nil {
+	if sleepInfo.IsDryRun() {
+		if err := r.dryRunOperation(ctx, log, now, secretName, sleepInfo, secret, sleepInfoData, resources, events); err != nil {
+			return ctrl.Result{
+				Requeue: true,
+			}, err
+		}
+		if manualActionValid || manualActionShouldClear {
+			if err := r.clearManualAction(ctx, sleepInfo); err != nil {
+				log.Error(err, "failed to clear manual action annotation")
+			}
+		}
+		if snoozeDue {
+			r.clearSnooze(ctx, log, sleepInfo)
+		}
+		return ctrl.Result{
+			RequeueAfter: requeueBeforePendingManualAction(requeueAfter, manualActionPending),
+		}, nil
+	}
+
+	if sleepInfoData.IsWakeUpOperation() {
+		// The nodes released and the NodePools scaled to zero by the sleep are restored before the
+		// resources wake up
+		r.restoreNodes(ctx, log, sleepInfo)
+		r.restoreNodePools(ctx, log, sleepInfo)
+	}
+
+	var manualOperation *kubegreenv1alpha1.ManualOperationStatus
+	if manualActionValid {
+		manualOperation = &kubegreenv1alpha1.ManualOperationStatus{
+			Action:        manualAction,
+			OperationType: sleepInfoData.CurrentOperationType,
+			ExecutedAt:    metav1.NewTime(now),
+		}
+		if parsedAt, err := time.Parse(time.RFC3339, manualActionAt); err == nil {
+			requestedAt := metav1.NewTime(parsedAt)
+			manualOperation.RequestedAt = &requestedAt
+		}
+	}
+	if err := r.handleSleepInfoStatus(ctx, now, sleepInfo, sleepInfoData.CurrentOperationType, manualOperation); err != nil {
 		log.Error(err, "unable to update sleepInfo status")
 		return ctrl.Result{}, err
 	}
 	log.V(8).Info("update status info")
 	r.syncPairedSleepInfoStatus(ctx, log, sleepInfo, sleepInfoData.CurrentOperationType, req.Namespace, now)
 
+	events.started(manualActionValid)
+
 	logSecret := log.WithValues("secret", secretName)
 	if !resources.HasResource() {
 		if err = r.upsertSecret(ctx, log, now, secretName, req.Namespace, sleepInfo, secret, sleepInfoData, resources); err != nil {
@@ -322,8 +555,14 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 				Requeue: true,
 			}, nil
 		}
+		result := operationResult{operationType: sleepInfoData.CurrentOperationType, wakeStagesPending: wakeStages != nil}
+		r.finishOperation(ctx, log, sleepInfo, events, result, now)
+		if sleepInfoData.IsWakeUpOperation() && wakeStages == nil {
+			r.expireAlertSilence(ctx, log, sleepInfo)
+			requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.runPostWakeHooks(ctx, log, sleepInfo, true, now))
+		}
 
-		if sleepInfoData.IsSleepOperation() {
+		if sleepInfoData.IsSleepOperation() && !sleepInfo.IsWindow() {
 			requeueAfter, err = skipWakeUpIfSleepNotPerformed(sleepInfoData.CurrentOperationSchedule, nextSchedule, now)
 			if err != nil {
 				log.Error(err, "fails to parse cron - 0 deployment")
@@ -336,23 +575,44 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			logMsg = "deployments, statefulsets and cronjobs are not to suspend"
 		}
 		log.WithValues("requeueAfter", requeueAfter).Info(logMsg)
+		if snoozeDue {
+			r.clearSnooze(ctx, log, sleepInfo)
+		}
+		if isWindowWokenUp(sleepInfo, sleepInfoData, now) {
+			return ctrl.Result{}, r.deleteFinishedWindow(ctx, log, sleepInfo)
+		}
+		if !manualActionValid && completesExecuteOnce(sleepInfo, sleepInfoData) {
+			if err := r.completeExecuteOnce(ctx, log, sleepInfo, now); err != nil {
+				log.Error(err, "fails to complete execute once")
+				return ctrl.Result{}, err
+			}
+		}
 
 		return ctrl.Result{
-			RequeueAfter: requeueAfter,
+			RequeueAfter: requeueBeforePendingManualAction(requeueAfter, manualActionPending),
 		}, nil
 	}
 
+	operationStart := time.Now()
 	switch {
 	case sleepInfoData.IsSleepOperation():
-		if err := resources.Sleep(ctx); err != nil {
+		err := resources.Sleep(ctx)
+		r.observeOperationDuration(sleepInfo, sleepOperation, operationStart)
+		if err != nil {
 			log.Error(err, "fails to handle sleep")
+			result := operationResult{operationType: sleepOperation, err: err}
+			r.finishOperation(ctx, log, sleepInfo, events, result, now)
 			return ctrl.Result{
 				Requeue: true,
 			}, err
 		}
 	case sleepInfoData.IsWakeUpOperation():
-		if err := resources.WakeUp(ctx); err != nil {
+		err := resources.WakeUp(ctx)
+		r.observeOperationDuration(sleepInfo, wakeUpOperation, operationStart)
+		if err != nil {
 			log.Error(err, "fails to handle wake up")
+			result := operationResult{operationType: wakeUpOperation, err: err}
+			r.finishOperation(ctx, log, sleepInfo, events, result, now)
 			return ctrl.Result{
 				Requeue: true,
 			}, err
@@ -368,22 +628,231 @@ func (r *SleepInfoReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}, nil
 	}
 
+	result := operationResult{
+		operationType:     sleepInfoData.CurrentOperationType,
+		suspendedCounts:   suspendedResourceCounts(resources),
+		wakeStagesPending: wakeStages != nil,
+	}
+	r.finishOperation(ctx, log, sleepInfo, events, result, now)
+	r.notifyOperation(sleepInfo, sleepInfoData.CurrentOperationType, manualActionValid, wakeStages != nil, now)
+	if sleepInfoData.IsSleepOperation() {
+		r.silenceAlerts(ctx, log, sleepInfo, nextSchedule, now)
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.scaleDownNodes(ctx, log, sleepInfo, now, now))
+		r.sleepNodePools(ctx, log, sleepInfo)
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, restoreNodePoolsIn(sleepInfo, nextSchedule, now))
+	} else if wakeStages != nil {
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, nextWakeStageIn(sleepInfo.GetWakeStages(), *wakeStages, now))
+	} else {
+		// The wake up without stages left is complete: its alerts are no longer silenced, and its
+		// post-wake hooks run
+		r.expireAlertSilence(ctx, log, sleepInfo)
+		requeueAfter = requeueBeforePendingManualAction(requeueAfter, r.runPostWakeHooks(ctx, log, sleepInfo, true, now))
+	}
+
 	if manualActionValid || manualActionShouldClear {
 		if err := r.clearManualAction(ctx, sleepInfo); err != nil {
 			log.Error(err, "failed to clear manual action annotation")
 		}
 	}
+	if snoozeDue {
+		r.clearSnooze(ctx, log, sleepInfo)
+	}
+	if isWindowWokenUp(sleepInfo, sleepInfoData, now) {
+		return ctrl.Result{}, r.deleteFinishedWindow(ctx, log, sleepInfo)
+	}
+	if !manualActionValid && completesExecuteOnce(sleepInfo, sleepInfoData) {
+		if err := r.completeExecuteOnce(ctx, log, sleepInfo, now); err != nil {
+			log.Error(err, "fails to complete execute once")
+			return ctrl.Result{}, err
+		}
+	}
 
 	return ctrl.Result{
-		RequeueAfter: requeueAfter,
+		RequeueAfter: requeueBeforePendingManualAction(requeueAfter, manualActionPending),
 	}, nil
 }
 
+// deleteMetrics deletes the metrics of a deleted SleepInfo
+func (r *SleepInfoReconciler) deleteMetrics(req ctrl.Request) {
+	r.Metrics.CurrentSleepInfo.Delete(prometheus.Labels{
+		"name":      req.Name,
+		"namespace": req.Namespace,
+	})
+	r.Metrics.FailedResources.DeletePartialMatch(prometheus.Labels{
+		"name":      req.Name,
+		"namespace": req.Namespace,
+	})
+	r.Metrics.RestoreDataBytes.Delete(prometheus.Labels{
+		"name":      req.Name,
+		"namespace": req.Namespace,
+	})
+	r.Metrics.SetSuspendedResources(req.Name, req.Namespace, nil)
+	r.Metrics.SetSavedResources(req.Name, req.Namespace, 0, 0)
+	r.Metrics.OperationDuration.DeletePartialMatch(prometheus.Labels{
+		"name":      req.Name,
+		"namespace": req.Namespace,
+	})
+	r.Metrics.TargetOperationDuration.DeletePartialMatch(prometheus.Labels{
+		"name":      req.Name,
+		"namespace": req.Namespace,
+	})
+}
+
+// observeOperationDuration records the time taken by a sleep or a wake up of the SleepInfo since start
+func (r *SleepInfoReconciler) observeOperationDuration(sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string, start time.Time) {
+	if r.Metrics.OperationDuration == nil {
+		return
+	}
+	r.Metrics.OperationDuration.With(prometheus.Labels{
+		"name":      sleepInfo.Name,
+		"namespace": sleepInfo.Namespace,
+		"operation": operationType,
+	}).Observe(time.Since(start).Seconds())
+}
+
+// observeTargetDuration returns the TargetDone hook recording the time taken by every patch target of
+// a sleep or a wake up of the SleepInfo
+func (r *SleepInfoReconciler) observeTargetDuration(sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string) func(kubegreenv1alpha1.PatchTarget, time.Duration) {
+	if r.Metrics.TargetOperationDuration == nil {
+		return nil
+	}
+	return func(target kubegreenv1alpha1.PatchTarget, duration time.Duration) {
+		r.Metrics.TargetOperationDuration.With(prometheus.Labels{
+			"name":      sleepInfo.Name,
+			"namespace": sleepInfo.Namespace,
+			"operation": operationType,
+			"target":    target.String(),
+		}).Observe(duration.Seconds())
+	}
+}
+
+// countOperationError returns the OperationError hook counting the resources failed to sleep or wake
+// up by kind and reason
+func (r *SleepInfoReconciler) countOperationError(operationType string) func(unstructured.Unstructured, string) {
+	if r.Metrics.OperationErrors == nil {
+		return nil
+	}
+	return func(res unstructured.Unstructured, reason string) {
+		r.Metrics.OperationErrors.With(prometheus.Labels{
+			"operation": operationType,
+			"kind":      res.GetKind(),
+			"reason":    reason,
+		}).Inc()
+	}
+}
+
+// withOperationPatches returns a copy of sleepInfo with the patches of the current operation of the
+// CRDs managed through annotations: shutdown=true on sleep and shutdown=false on wake up.
+func (r *SleepInfoReconciler) withOperationPatches(log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, sleepInfoData SleepInfoData) *kubegreenv1alpha1.SleepInfo {
+	sleepInfoWithPatches := sleepInfo.DeepCopy()
+	if sleepInfoData.IsSleepOperation() {
+		if sleepInfo.IsPostgresToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.PgclusterSleepPatch)
+			log.Info("added pgcluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsHdfsToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.HdfsclusterSleepPatch)
+			log.Info("added hdfscluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsOpenSearchToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.OsclusterSleepPatch)
+			log.Info("added oscluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsKafkaToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.KafkaclusterSleepPatch)
+			log.Info("added kafkacluster sleep patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+	} else if sleepInfoData.IsWakeUpOperation() {
+		if sleepInfo.IsPostgresToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.PgclusterWakePatch)
+			log.Info("added pgcluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsHdfsToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.HdfsclusterWakePatch)
+			log.Info("added hdfscluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsOpenSearchToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.OsclusterWakePatch)
+			log.Info("added oscluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+		if sleepInfo.IsKafkaToSuspend() {
+			sleepInfoWithPatches.Spec.Patches = append(sleepInfoWithPatches.Spec.Patches, kubegreenv1alpha1.KafkaclusterWakePatch)
+			log.Info("added kafkacluster wake patch", "sleepinfo", sleepInfo.GetName(), "namespace", sleepInfo.Namespace)
+		}
+	}
+	return sleepInfoWithPatches
+}
+
+// isWindowWokenUp returns true when the wake up of a one-time window has been executed once the
+// window started, so the SleepInfo has nothing left to do. A manual wake before the start keeps it.
+func isWindowWokenUp(sleepInfo *kubegreenv1alpha1.SleepInfo, data SleepInfoData, now time.Time) bool {
+	return sleepInfo.IsWindow() && data.IsWakeUpOperation() && !now.Before(sleepInfo.Spec.Window.Start.Time)
+}
+
+// deleteFinishedWindow deletes a one-time window SleepInfo once it is over. Its secret is owned by
+// the SleepInfo and garbage collected with it.
+func (r *SleepInfoReconciler) deleteFinishedWindow(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	log.Info("window finished, deleting SleepInfo", "start", sleepInfo.Spec.Window.Start, "end", sleepInfo.Spec.Window.End)
+	if err := r.Delete(ctx, sleepInfo); client.IgnoreNotFound(err) != nil {
+		log.Error(err, "fails to delete finished window")
+		return err
+	}
+	return nil
+}
+
+// notifyOperation sends the sleep.executed or wake.executed event of an executed operation. The wake
+// ups completed report the sleep they end and the requests it released, read from the status of
+// the SleepInfo before the wake up.
+func (r *SleepInfoReconciler) notifyOperation(sleepInfo *kubegreenv1alpha1.SleepInfo, operationType string, manual, wakeStagesPending bool, now time.Time) {
+	if r.Notifier == nil {
+		return
+	}
+	eventType := notifications.EventSleepExecuted
+	if operationType == wakeUpOperation {
+		eventType = notifications.EventWakeExecuted
+	}
+	trigger := "scheduled"
+	if manual {
+		trigger = "manual"
+	}
+	data := map[string]string{"operation": operationType, "trigger": trigger}
+	if operationType == wakeUpOperation {
+		if wakeStagesPending {
+			data[notifications.DataWakeStagesPending] = "true"
+		} else if status := sleepInfo.Status; status.LastSleepTime != nil {
+			data[notifications.DataSleptAt] = status.LastSleepTime.UTC().Format(time.RFC3339)
+			if cpu, ok := status.SavedResources[v1.ResourceCPU]; ok {
+				data[notifications.DataSavedCPU] = cpu.String()
+			}
+			if memory, ok := status.SavedResources[v1.ResourceMemory]; ok {
+				data[notifications.DataSavedMemory] = memory.String()
+			}
+		}
+	}
+	tenant := sleepInfo.Namespace
+	if i := strings.LastIndex(tenant, "-"); i > 0 {
+		tenant = tenant[:i]
+	}
+	r.Notifier.Notify(notifications.Event{
+		Type:         eventType,
+		Time:         now.UTC(),
+		Source:       "controller",
+		Tenant:       tenant,
+		Namespace:    sleepInfo.Namespace,
+		SleepInfo:    sleepInfo.Name,
+		ScheduleName: sleepInfo.Annotations["kube-green.stratio.com/schedule-name"],
+		Data:         data,
+	})
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SleepInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	if r.Clock == nil {
 		r.Clock = realClock{}
 	}
+	if r.Holidays == nil {
+		r.Holidays = &holidays.Loader{Client: mgr.GetClient()}
+	}
 
 	pred := predicate.Funcs{
 		CreateFunc: func(event.CreateEvent) bool {
@@ -396,6 +865,10 @@ func (r *SleepInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			if e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() {
 				return true
 			}
+			// Deleted SleepInfos with the wake up finalizer wake up their resources
+			if e.ObjectOld.GetDeletionTimestamp().IsZero() && !e.ObjectNew.GetDeletionTimestamp().IsZero() {
+				return true
+			}
 			oldAnn := e.ObjectOld.GetAnnotations()
 			newAnn := e.ObjectNew.GetAnnotations()
 			oldAction := ""
@@ -413,6 +886,14 @@ func (r *SleepInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				newAnn[manualActionTimeAnnotion] != oldAnn[manualActionTimeAnnotion] {
 				return true
 			}
+			if oldAnn[kubegreenv1alpha1.SnoozeUntilAnnotation] != newAnn[kubegreenv1alpha1.SnoozeUntilAnnotation] &&
+				newAnn[kubegreenv1alpha1.SnoozeUntilAnnotation] != "" {
+				return true
+			}
+			// Removing the skip-reconcile annotation resumes the schedule
+			if oldAnn[kubegreenv1alpha1.SkipReconcileAnnotation] != newAnn[kubegreenv1alpha1.SkipReconcileAnnotation] {
+				return true
+			}
 			return false
 		},
 		DeleteFunc: func(event.DeleteEvent) bool {
@@ -422,13 +903,16 @@ func (r *SleepInfoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			return false
 		},
 	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &kubegreenv1alpha1.SleepInfo{}, pairIDIndex, indexPairID); err != nil {
+		return fmt.Errorf("fails to index SleepInfos by pair-id: %w", err)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&kubegreenv1alpha1.SleepInfo{}).
+		For(&kubegreenv1alpha1.SleepInfo{}, builder.WithPredicates(r.Shard.Predicate(), pred)).
+		Watches(&v1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.requestsForNamespace), builder.WithPredicates(namespaceLabelsChanged)).
 		Named("kubegreen-sleepinfo").
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
 		}).
-		WithEventFilter(pred).
 		Complete(r)
 }
 
@@ -451,6 +935,25 @@ func (r *SleepInfoReconciler) clearManualAction(ctx context.Context, sleepInfo *
 	})
 }
 
+// clearSnooze removes the snooze annotation once the delayed sleep has been handled
+func (r *SleepInfoReconciler) clearSnooze(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	key := client.ObjectKeyFromObject(sleepInfo)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, key, latest); err != nil {
+			return err
+		}
+		if _, exists := latest.Annotations[kubegreenv1alpha1.SnoozeUntilAnnotation]; !exists {
+			return nil
+		}
+		delete(latest.Annotations, kubegreenv1alpha1.SnoozeUntilAnnotation)
+		return r.Update(ctx, latest)
+	})
+	if err != nil {
+		log.Error(err, "failed to clear snooze annotation")
+	}
+}
+
 func (r *SleepInfoReconciler) getSleepInfo(ctx context.Context, req ctrl.Request) (*kubegreenv1alpha1.SleepInfo, error) {
 	sleepInfo := &kubegreenv1alpha1.SleepInfo{}
 	if err := r.Get(ctx, req.NamespacedName, sleepInfo); err != nil {
@@ -459,6 +962,15 @@ func (r *SleepInfoReconciler) getSleepInfo(ctx context.Context, req ctrl.Request
 	return sleepInfo, nil
 }
 
+// requeueBeforePendingManualAction shortens requeueAfter so that a pending manual action
+// (manual-at in the future) is executed on time.
+func requeueBeforePendingManualAction(requeueAfter, pending time.Duration) time.Duration {
+	if pending > 0 && (requeueAfter <= 0 || pending < requeueAfter) {
+		return pending
+	}
+	return requeueAfter
+}
+
 func skipWakeUpIfSleepNotPerformed(currentOperationCronSchedule string, nextSchedule, now time.Time) (time.Duration, error) {
 	nextOpSched, err := getCronParsed(currentOperationCronSchedule)
 	if err != nil {
@@ -475,10 +987,16 @@ func (r SleepInfoReconciler) handleSleepInfoStatus(
 	now time.Time,
 	currentSleepInfo *kubegreenv1alpha1.SleepInfo,
 	currentOperationType string,
+	manualOperation *kubegreenv1alpha1.ManualOperationStatus,
 ) error {
 	sleepInfo := currentSleepInfo.DeepCopy()
 	sleepInfo.Status.LastScheduleTime = metav1.NewTime(now)
 	sleepInfo.Status.OperationType = currentOperationType
+	sleepInfo.Status.CurrentState = kubegreenv1alpha1.StateTransitioning
+	setReadyCondition(&sleepInfo.Status, sleepInfo.Generation, nil, "")
+	if manualOperation != nil {
+		sleepInfo.Status.LastManualOperation = manualOperation
+	}
 	return r.Status().Update(ctx, sleepInfo)
 }
 
@@ -508,15 +1026,15 @@ func (r SleepInfoReconciler) reconcilePairedStatus(
 	}
 
 	currentRole := annotations[pairRoleAnnotation]
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := r.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+	pairSleepInfos, err := listPairSleepInfos(ctx, r.Client, namespace, pairID)
+	if err != nil {
 		log.Error(err, "reconcilePairedStatus: failed to list SleepInfos")
 		return
 	}
-	log.Info("reconcilePairedStatus: listed SleepInfos", "count", len(sleepInfoList.Items))
+	log.Info("reconcilePairedStatus: listed SleepInfos", "count", len(pairSleepInfos))
 
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
+	for i := range pairSleepInfos {
+		si := &pairSleepInfos[i]
 		if si.Name == currentSleepInfo.Name {
 			continue
 		}
@@ -573,14 +1091,14 @@ func (r SleepInfoReconciler) syncPairedSleepInfoStatus(
 	}
 	currentRole := annotations[pairRoleAnnotation]
 
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := r.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+	pairSleepInfos, err := listPairSleepInfos(ctx, r.Client, namespace, pairID)
+	if err != nil {
 		log.Error(err, "syncPairedStatus: failed to list SleepInfos")
 		return
 	}
 
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
+	for i := range pairSleepInfos {
+		si := &pairSleepInfos[i]
 		if si.Name == currentSleepInfo.Name {
 			continue
 		}
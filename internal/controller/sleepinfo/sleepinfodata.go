@@ -19,6 +19,13 @@ type SleepInfoData struct {
 	NextOperationSchedule       string
 	OriginalGenericResourceInfo map[string]jsonpatch.RestorePatches
 	SleptResourceGenerations    map[string]jsonpatch.SleptResourceGenerations
+	// WakeStages is the progress of the wake up in progress, nil when no wake stage is pending
+	WakeStages *WakeStagesProgress
+	// SleepDuration is how long the resources sleep, 0 when the wake up is set by a schedule
+	SleepDuration time.Duration
+	// WakeUpAt is the wake up time computed from the last sleep and SleepDuration, zero when the
+	// current operation is not a wake up after a sleep duration
+	WakeUpAt time.Time
 }
 
 func (s SleepInfoData) IsWakeUpOperation() bool {
@@ -30,6 +37,10 @@ func (s SleepInfoData) IsSleepOperation() bool {
 }
 
 func getSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo) (SleepInfoData, error) {
+	if sleepInfo.IsWindow() {
+		return getWindowSleepInfoData(secret, sleepInfo)
+	}
+
 	sleepSchedule, err := sleepInfo.GetSleepSchedule()
 	if err != nil {
 		return SleepInfoData{}, err
@@ -43,6 +54,7 @@ func getSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo)
 		CurrentOperationType:     sleepOperation,
 		CurrentOperationSchedule: sleepSchedule,
 		NextOperationSchedule:    wakeUpSchedule,
+		SleepDuration:            sleepInfo.GetSleepDuration(),
 	}
 	if wakeUpSchedule == "" {
 		sleepInfoData.NextOperationSchedule = sleepSchedule
@@ -72,6 +84,9 @@ func getSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo)
 	if sleepInfoData.SleptResourceGenerations, err = jsonpatch.GetSleepGenerationsToRestore(data[sleptGenerationsDataKey]); err != nil {
 		return SleepInfoData{}, fmt.Errorf("fails to set slept resource generations in SleepInfo %s: %s", sleepInfo.Name, err)
 	}
+	if sleepInfoData.WakeStages, err = getWakeStagesProgress(data[wakeStagesDataKey]); err != nil {
+		return SleepInfoData{}, fmt.Errorf("fails to set wake stages progress in SleepInfo %s: %s", sleepInfo.Name, err)
+	}
 	// This will convert old secret format, where the original deployment and
 	// cronjob states were stored in a different key
 	sleepInfoData.OriginalGenericResourceInfo, err = convertOldSecretDataToNewFormat(sleepInfoData.OriginalGenericResourceInfo, data)
@@ -101,6 +116,11 @@ func getSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo)
 		if sleepInfoData.CurrentOperationType != wakeUpOperation {
 			sleepInfoData.CurrentOperationType = wakeUpOperation
 		}
+	} else if sleepInfoData.SleepDuration > 0 && lastOperation == sleepOperation {
+		// The wake up has no schedule: it is due once the sleep duration has elapsed since the last
+		// sleep, which is elapsed time and so not shifted by daylight saving time changes
+		sleepInfoData.CurrentOperationType = wakeUpOperation
+		sleepInfoData.WakeUpAt = lastSchedule.Add(sleepInfoData.SleepDuration)
 	}
 	// NOTA: La lógica de pair-role se aplica tanto antes como después de leer el Secret
 	// para garantizar que funcione correctamente incluso con Secrets desactualizados
@@ -108,6 +128,41 @@ func getSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo)
 	return sleepInfoData, nil
 }
 
+// getWindowSleepInfoData returns the data of a one-time window, which has no cron schedules:
+// it sleeps first and wakes up once the secret records the sleep.
+func getWindowSleepInfoData(secret *v1.Secret, sleepInfo *kubegreenv1alpha1.SleepInfo) (SleepInfoData, error) {
+	sleepInfoData := SleepInfoData{
+		CurrentOperationType: sleepOperation,
+	}
+	if secret == nil || secret.Data == nil {
+		return sleepInfoData, nil
+	}
+	data := secret.Data
+
+	var err error
+	if sleepInfoData.OriginalGenericResourceInfo, err = jsonpatch.GetOriginalInfoToRestore(data[originalJSONPatchDataKey]); err != nil {
+		return SleepInfoData{}, fmt.Errorf("fails to set original resource info to restore in SleepInfo %s: %s", sleepInfo.Name, err)
+	}
+	if sleepInfoData.SleptResourceGenerations, err = jsonpatch.GetSleepGenerationsToRestore(data[sleptGenerationsDataKey]); err != nil {
+		return SleepInfoData{}, fmt.Errorf("fails to set slept resource generations in SleepInfo %s: %s", sleepInfo.Name, err)
+	}
+	// Secrets pre-created by the API record a lowercase operation at creation time, not an execution
+	lastOperation := string(data[lastOperationKey])
+	if lastOperation != sleepOperation && lastOperation != wakeUpOperation {
+		return sleepInfoData, nil
+	}
+	lastSchedule, err := time.Parse(time.RFC3339, string(data[lastScheduleKey]))
+	if err != nil {
+		return SleepInfoData{}, fmt.Errorf("fails to parse %s: %s", lastScheduleKey, err)
+	}
+	sleepInfoData.LastSchedule = lastSchedule
+
+	if lastOperation == sleepOperation {
+		sleepInfoData.CurrentOperationType = wakeUpOperation
+	}
+	return sleepInfoData, nil
+}
+
 const (
 	replicasBeforeSleepKey   = "deployment-replicas"
 	originalCronjobStatusKey = "cronjobs-info"
@@ -6,6 +6,7 @@ import (
 
 	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/restoredata"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -18,13 +19,44 @@ const (
 	pairRoleAnnotation = "kube-green.stratio.com/pair-role"
 	pairRoleSleep      = "sleep"
 	pairRoleWake       = "wake"
+
+	// pairIDIndex is the field index of the pair-id annotation of the SleepInfos
+	pairIDIndex = pairIDAnnotation
 )
 
+// indexPairID returns the pair-id of a SleepInfo as the key of the pairIDIndex
+func indexPairID(obj client.Object) []string {
+	if pairID := obj.GetAnnotations()[pairIDAnnotation]; pairID != "" {
+		return []string{pairID}
+	}
+	return nil
+}
+
+// listPairSleepInfos returns the SleepInfos of the namespace with the pair-id, through the pairIDIndex of
+// the cache. It falls back to listing the namespace when the index is not registered.
+func listPairSleepInfos(ctx context.Context, c client.Reader, namespace, pairID string) ([]kubegreenv1alpha1.SleepInfo, error) {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := c.List(ctx, sleepInfoList, client.InNamespace(namespace), client.MatchingFields{pairIDIndex: pairID}); err == nil {
+		return sleepInfoList.Items, nil
+	}
+	if err := c.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	sleepInfos := []kubegreenv1alpha1.SleepInfo{}
+	for _, si := range sleepInfoList.Items {
+		if si.GetAnnotations()[pairIDAnnotation] == pairID {
+			sleepInfos = append(sleepInfos, si)
+		}
+	}
+	return sleepInfos, nil
+}
+
 // getRelatedRestorePatches busca restore patches de SleepInfos relacionados mediante anotaciones pair-id
 // Esta función permite que un SleepInfo de "wake" encuentre los restore patches guardados por un SleepInfo de "sleep"
 func getRelatedRestorePatches(
 	ctx context.Context,
 	c client.Client,
+	key *restoredata.EncryptionKey,
 	logger logr.Logger,
 	currentSleepInfo *kubegreenv1alpha1.SleepInfo,
 	namespace string,
@@ -43,16 +75,16 @@ func getRelatedRestorePatches(
 
 	logger.Info("buscando restore patches de SleepInfo relacionado", "pair-id", pairID, "rol-actual", currentRole)
 
-	// Buscar todos los SleepInfos en el namespace
-	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
-	if err := c.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+	// Buscar los SleepInfos del namespace con el mismo pair-id
+	pairSleepInfos, err := listPairSleepInfos(ctx, c, namespace, pairID)
+	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list SleepInfos: %w", err)
 	}
 
 	// Encontrar el SleepInfo relacionado con rol "sleep" y mismo pair-id
 	var relatedSleepInfo *kubegreenv1alpha1.SleepInfo
-	for i := range sleepInfoList.Items {
-		si := &sleepInfoList.Items[i]
+	for i := range pairSleepInfos {
+		si := &pairSleepInfos[i]
 		if si.Name == currentSleepInfo.Name {
 			continue // Skip el actual
 		}
@@ -81,6 +113,15 @@ func getRelatedRestorePatches(
 		return nil, nil, nil // No es un error crítico, simplemente no hay restore patches
 	}
 
+	if err := readRestoreData(ctx, c, key, relatedSecret); err != nil {
+		logger.Error(err, "failed to decode restore patches from related SleepInfo secret", "secret", relatedSecretName)
+		return nil, nil, nil
+	}
+	if err := loadSleepInfoState(ctx, c, namespace, relatedSleepInfo.Name, relatedSecret); err != nil {
+		logger.Error(err, "failed to get SleepInfoState of related SleepInfo", "sleepinfo", relatedSleepInfo.Name)
+		return nil, nil, nil
+	}
+
 	// Extraer restore patches del secret relacionado
 	if relatedSecret.Data == nil {
 		return nil, nil, nil
@@ -0,0 +1,60 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestListPairSleepInfos(t *testing.T) {
+	namespace := "my-namespace"
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	newSleepInfo := func(name, namespace, pairID string) *kubegreenv1alpha1.SleepInfo {
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+		if pairID != "" {
+			sleepInfo.Annotations = map[string]string{pairIDAnnotation: pairID, pairRoleAnnotation: pairRoleSleep}
+		}
+		return sleepInfo
+	}
+	objects := []client.Object{
+		newSleepInfo("sleep-tonight", namespace, "tonight"),
+		newSleepInfo("wake-tonight", namespace, "tonight"),
+		newSleepInfo("sleep-weekend", namespace, "weekend"),
+		newSleepInfo("working-hours", namespace, ""),
+		newSleepInfo("sleep-tonight", "other-namespace", "tonight"),
+	}
+	names := func(sleepInfos []kubegreenv1alpha1.SleepInfo) []string {
+		result := []string{}
+		for _, si := range sleepInfos {
+			result = append(result, si.Name)
+		}
+		return result
+	}
+
+	t.Run("through the pair-id index", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithIndex(&kubegreenv1alpha1.SleepInfo{}, pairIDIndex, indexPairID).WithObjects(objects...).Build()
+
+		sleepInfos, err := listPairSleepInfos(context.Background(), c, namespace, "tonight")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"sleep-tonight", "wake-tonight"}, names(sleepInfos))
+	})
+
+	t.Run("without the index", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+		sleepInfos, err := listPairSleepInfos(context.Background(), c, namespace, "tonight")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"sleep-tonight", "wake-tonight"}, names(sleepInfos))
+	})
+}
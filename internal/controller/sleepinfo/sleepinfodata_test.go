@@ -22,6 +22,17 @@ func TestSleepInfoData(t *testing.T) {
 			WakeUpTime: "09:00",
 		},
 	}
+	windowSleepInfo := &v1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "sleepinfo-window",
+		},
+		Spec: v1alpha1.SleepInfoSpec{
+			Window: &v1alpha1.ScheduleWindow{
+				Start: metav1.NewTime(time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC)),
+				End:   metav1.NewTime(time.Date(2021, 1, 2, 8, 0, 0, 0, time.UTC)),
+			},
+		},
+	}
 	lastScheduleValue := "2021-01-01T00:00:00Z"
 	lastSchedule, err := time.Parse(time.RFC3339, lastScheduleValue)
 	require.NoError(t, err)
@@ -236,6 +247,76 @@ func TestSleepInfoData(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "wakes up after the sleep duration since the last sleep",
+				secret: &v1.Secret{
+					Data: map[string][]byte{
+						originalJSONPatchDataKey: []byte(`{"ReplicaSet.apps":{"echo-service-replicaset":"{\"spec\":{\"replicas\":2}}"}}`),
+						lastScheduleKey:          []byte("2021-01-01T00:00:00Z"),
+						lastOperationKey:         []byte(sleepOperation),
+					},
+				},
+				sleepInfo: &v1alpha1.SleepInfo{
+					Spec: v1alpha1.SleepInfoSpec{
+						Weekdays:      "0-5",
+						SleepTime:     "19:00",
+						SleepDuration: &metav1.Duration{Duration: 10 * time.Hour},
+					},
+				},
+				expected: SleepInfoData{
+					LastSchedule:             lastSchedule,
+					CurrentOperationType:     wakeUpOperation,
+					CurrentOperationSchedule: "00 19 * * 0-5",
+					NextOperationSchedule:    "00 19 * * 0-5",
+					SleepDuration:            10 * time.Hour,
+					WakeUpAt:                 lastSchedule.Add(10 * time.Hour),
+					OriginalGenericResourceInfo: map[string]jsonpatch.RestorePatches{
+						"ReplicaSet.apps": {
+							"echo-service-replicaset": "{\"spec\":{\"replicas\":2}}",
+						},
+					},
+				},
+			},
+			{
+				name:      "window without secret sleeps first",
+				sleepInfo: windowSleepInfo,
+				expected: SleepInfoData{
+					CurrentOperationType: sleepOperation,
+				},
+			},
+			{
+				name: "window ignores the secret created with the SleepInfo",
+				secret: &v1.Secret{
+					Data: map[string][]byte{
+						lastScheduleKey:  []byte("2021-01-01T00:00:00Z"),
+						lastOperationKey: []byte("sleep"),
+					},
+				},
+				sleepInfo: windowSleepInfo,
+				expected: SleepInfoData{
+					CurrentOperationType: sleepOperation,
+				},
+			},
+			{
+				name: "window wakes up after the sleep",
+				secret: &v1.Secret{
+					Data: map[string][]byte{
+						originalJSONPatchDataKey: []byte(`{"ReplicaSet.apps":{"echo-service-replicaset":"{\"spec\":{\"replicas\":2}}"}}`),
+						lastScheduleKey:          []byte("2021-01-01T00:00:00Z"),
+						lastOperationKey:         []byte(sleepOperation),
+					},
+				},
+				sleepInfo: windowSleepInfo,
+				expected: SleepInfoData{
+					LastSchedule:         lastSchedule,
+					CurrentOperationType: wakeUpOperation,
+					OriginalGenericResourceInfo: map[string]jsonpatch.RestorePatches{
+						"ReplicaSet.apps": {
+							"echo-service-replicaset": "{\"spec\":{\"replicas\":2}}",
+						},
+					},
+				},
+			},
 		}
 
 		for _, tc := range testCases {
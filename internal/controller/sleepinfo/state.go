@@ -0,0 +1,214 @@
+package sleepinfo
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getSleepInfoState returns the SleepInfoState of a SleepInfo, nil if it does not exist or its CRD is
+// not installed
+func getSleepInfoState(ctx context.Context, c client.Reader, namespace, name string) (*kubegreenv1alpha1.SleepInfoState, error) {
+	state := &kubegreenv1alpha1.SleepInfoState{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, state); err != nil {
+		if client.IgnoreNotFound(err) == nil || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return state, nil
+}
+
+// loadSleepInfoState sets the restore patches of the SleepInfoState of a SleepInfo in the data of its
+// secret, where the controller reads them. The SleepInfoState replaces the restore patches of the
+// secret, which are only read until the first save to the SleepInfoState.
+func loadSleepInfoState(ctx context.Context, c client.Reader, namespace, name string, secret *v1.Secret) error {
+	if secret == nil {
+		return nil
+	}
+	state, err := getSleepInfoState(ctx, c, namespace, name)
+	if err != nil || state == nil {
+		return err
+	}
+	restorePatches, sleptGenerations := getSleepInfoStateRestoreData(state)
+	data, err := json.Marshal(restorePatches)
+	if err != nil {
+		return err
+	}
+	generationsData, err := json.Marshal(sleptGenerations)
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[originalJSONPatchDataKey] = data
+	secret.Data[sleptGenerationsDataKey] = generationsData
+	return nil
+}
+
+// saveSleepInfoState moves the restore patches of the secret to be written to the SleepInfoState of
+// the SleepInfo
+func (r *SleepInfoReconciler) saveSleepInfoState(
+	ctx context.Context,
+	now time.Time,
+	sleepInfo *kubegreenv1alpha1.SleepInfo,
+	secret *v1.Secret,
+) error {
+	data, ok := secret.Data[originalJSONPatchDataKey]
+	if !ok || len(data) == 0 {
+		return nil
+	}
+	restorePatches, err := jsonpatch.GetOriginalInfoToRestore(data)
+	if err != nil {
+		return err
+	}
+	sleptGenerations, err := jsonpatch.GetSleepGenerationsToRestore(secret.Data[sleptGenerationsDataKey])
+	if err != nil {
+		return err
+	}
+	state, err := getSleepInfoState(ctx, r.Client, sleepInfo.Namespace, sleepInfo.Name)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &kubegreenv1alpha1.SleepInfoState{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      sleepInfo.Name,
+				Namespace: sleepInfo.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": r.ManagerName,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+						Kind:       "SleepInfo",
+						Name:       sleepInfo.Name,
+						UID:        sleepInfo.UID,
+					},
+				},
+			},
+		}
+	}
+	spec := newSleepInfoStateSpec(sleepInfo.Name, now, state.Spec, restorePatches, sleptGenerations)
+	if state.ResourceVersion == "" {
+		state.Spec = spec
+		if err := r.Create(ctx, state); err != nil {
+			return err
+		}
+	} else if !equality.Semantic.DeepEqual(state.Spec.Targets, spec.Targets) {
+		state.Spec = spec
+		if err := r.Update(ctx, state); err != nil {
+			return err
+		}
+	}
+	delete(secret.Data, originalJSONPatchDataKey)
+	delete(secret.Data, sleptGenerationsDataKey)
+	return nil
+}
+
+// deleteSleepInfoState deletes the SleepInfoState of the SleepInfo, once its restore patches are
+// stored in the secret again
+func (r *SleepInfoReconciler) deleteSleepInfoState(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	state := &kubegreenv1alpha1.SleepInfoState{
+		ObjectMeta: metav1.ObjectMeta{Name: sleepInfo.Name, Namespace: sleepInfo.Namespace},
+	}
+	if err := r.Delete(ctx, state); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// newSleepInfoStateSpec returns the spec storing the restore patches, with the save time of the
+// previous spec for the kinds whose patches did not change
+func newSleepInfoStateSpec(
+	sleepInfoName string,
+	now time.Time,
+	previous kubegreenv1alpha1.SleepInfoStateSpec,
+	restorePatches map[string]jsonpatch.RestorePatches,
+	sleptGenerations map[string]jsonpatch.SleptResourceGenerations,
+) kubegreenv1alpha1.SleepInfoStateSpec {
+	savedAt := metav1.NewTime(now)
+	spec := kubegreenv1alpha1.SleepInfoStateSpec{
+		SleepInfo: sleepInfoName,
+		SavedAt:   previous.SavedAt,
+	}
+	targets := map[string]struct{}{}
+	for target := range restorePatches {
+		targets[target] = struct{}{}
+	}
+	for target := range sleptGenerations {
+		targets[target] = struct{}{}
+	}
+	for target := range targets {
+		names := map[string]struct{}{}
+		for name := range restorePatches[target] {
+			names[name] = struct{}{}
+		}
+		for name := range sleptGenerations[target] {
+			names[name] = struct{}{}
+		}
+		entry := kubegreenv1alpha1.SleepInfoStateTarget{Target: target}
+		for name := range names {
+			entry.Resources = append(entry.Resources, kubegreenv1alpha1.SleepInfoStateResource{
+				Name:       name,
+				Patch:      restorePatches[target][name],
+				Generation: sleptGenerations[target][name],
+			})
+		}
+		slices.SortFunc(entry.Resources, func(a, b kubegreenv1alpha1.SleepInfoStateResource) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		entry.SavedAt = &savedAt
+		for _, previousEntry := range previous.Targets {
+			if previousEntry.Target == target && equality.Semantic.DeepEqual(previousEntry.Resources, entry.Resources) {
+				entry.SavedAt = previousEntry.SavedAt
+			}
+		}
+		spec.Targets = append(spec.Targets, entry)
+	}
+	slices.SortFunc(spec.Targets, func(a, b kubegreenv1alpha1.SleepInfoStateTarget) int {
+		return strings.Compare(a.Target, b.Target)
+	})
+	if spec.SavedAt == nil || !equality.Semantic.DeepEqual(previous.Targets, spec.Targets) {
+		spec.SavedAt = &savedAt
+	}
+	return spec
+}
+
+// getSleepInfoStateRestoreData returns the restore patches and the slept generations stored in a
+// SleepInfoState
+func getSleepInfoStateRestoreData(
+	state *kubegreenv1alpha1.SleepInfoState,
+) (map[string]jsonpatch.RestorePatches, map[string]jsonpatch.SleptResourceGenerations) {
+	restorePatches := map[string]jsonpatch.RestorePatches{}
+	sleptGenerations := map[string]jsonpatch.SleptResourceGenerations{}
+	for _, target := range state.Spec.Targets {
+		for _, res := range target.Resources {
+			if res.Patch != "" {
+				if restorePatches[target.Target] == nil {
+					restorePatches[target.Target] = jsonpatch.RestorePatches{}
+				}
+				restorePatches[target.Target][res.Name] = res.Patch
+			}
+			if res.Generation != 0 {
+				if sleptGenerations[target.Target] == nil {
+					sleptGenerations[target.Target] = jsonpatch.SleptResourceGenerations{}
+				}
+				sleptGenerations[target.Target][res.Name] = res.Generation
+			}
+		}
+	}
+	return restorePatches, sleptGenerations
+}
@@ -0,0 +1,226 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSleepInfoState(t *testing.T) {
+	namespace := "my-namespace"
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "apps", Version: "v1"}})
+	restMapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace, UID: "sleep-uid"},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			Weekdays:   "*",
+			SleepTime:  "20:00",
+			WakeUpTime: "08:00",
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(3))},
+	}
+	secretName := getSecretName(sleepInfo.Name)
+	newReconciler := func(restoreStateCRD bool, objects ...client.Object) *SleepInfoReconciler {
+		return &SleepInfoReconciler{
+			Client: fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRESTMapper(restMapper).
+				WithObjects(sleepInfo.DeepCopy(), deployment.DeepCopy()).
+				WithObjects(objects...).
+				Build(),
+			Log:             logr.Discard(),
+			ManagerName:     testFieldManagerName,
+			RestoreStateCRD: restoreStateCRD,
+		}
+	}
+	getData := func(t *testing.T, r *SleepInfoReconciler) (*v1.Secret, SleepInfoData) {
+		t.Helper()
+		secret, err := r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		// The fake client does not merge the stringData in the data, as the API server does
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for key, value := range secret.StringData {
+			secret.Data[key] = []byte(value)
+		}
+		require.NoError(t, loadSleepInfoState(context.Background(), r.Client, namespace, sleepInfo.Name, secret))
+		data, err := getSleepInfoData(secret, sleepInfo)
+		require.NoError(t, err)
+		return secret, data
+	}
+	execute := func(t *testing.T, r *SleepInfoReconciler, secret *v1.Secret, data SleepInfoData) {
+		t.Helper()
+		resourceClient := r.resourceClient(context.Background(), r.Log, sleepInfo, data)
+		resources, err := jsonpatch.NewResources(context.Background(), resourceClient, namespace, data.OriginalGenericResourceInfo, data.SleptResourceGenerations)
+		require.NoError(t, err)
+		if data.IsSleepOperation() {
+			require.NoError(t, resources.Sleep(context.Background()))
+		} else {
+			require.NoError(t, resources.WakeUp(context.Background()))
+		}
+		require.NoError(t, r.upsertSecret(context.Background(), r.Log, now, secretName, namespace, sleepInfo, secret, data, resources))
+	}
+	getState := func(t *testing.T, r *SleepInfoReconciler) (*kubegreenv1alpha1.SleepInfoState, error) {
+		t.Helper()
+		state := &kubegreenv1alpha1.SleepInfoState{}
+		err := r.Get(context.Background(), client.ObjectKey{Name: sleepInfo.Name, Namespace: namespace}, state)
+		return state, err
+	}
+	getReplicas := func(t *testing.T, r *SleepInfoReconciler) int32 {
+		t.Helper()
+		res := &appsv1.Deployment{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(deployment), res))
+		return *res.Spec.Replicas
+	}
+
+	t.Run("stores the restore patches in the SleepInfoState", func(t *testing.T) {
+		r := newReconciler(true)
+
+		execute(t, r, nil, SleepInfoData{CurrentOperationType: sleepOperation})
+		require.Equal(t, int32(0), getReplicas(t, r))
+
+		state, err := getState(t, r)
+		require.NoError(t, err)
+		require.Equal(t, sleepInfo.Name, state.Spec.SleepInfo)
+		require.Equal(t, sleepInfo.UID, state.OwnerReferences[0].UID)
+		require.True(t, now.Equal(state.Spec.SavedAt.Time))
+		require.Len(t, state.Spec.Targets, 1)
+		require.Equal(t, "Deployment.apps", state.Spec.Targets[0].Target)
+		require.Len(t, state.Spec.Targets[0].Resources, 1)
+		require.Equal(t, "api", state.Spec.Targets[0].Resources[0].Name)
+		require.NotEmpty(t, state.Spec.Targets[0].Resources[0].Patch)
+
+		secret, data := getData(t, r)
+		require.Equal(t, sleepOperation, string(secret.Data[lastOperationKey]))
+		require.Contains(t, data.OriginalGenericResourceInfo["Deployment.apps"], "api")
+		require.Equal(t, wakeUpOperation, data.CurrentOperationType)
+
+		restoreSecret := &v1.Secret{}
+		err = r.Get(context.Background(), client.ObjectKey{Name: getRestoreSecretName(sleepInfo.Name), Namespace: namespace}, restoreSecret)
+		require.True(t, apierrors.IsNotFound(err))
+
+		execute(t, r, secret, data)
+		require.Equal(t, int32(3), getReplicas(t, r))
+		secret, err = r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.NotContains(t, secret.Data, originalJSONPatchDataKey)
+	})
+
+	t.Run("moves the restore patches of the secret to the SleepInfoState", func(t *testing.T) {
+		r := newReconciler(false)
+		execute(t, r, nil, SleepInfoData{CurrentOperationType: sleepOperation})
+		secret, data := getData(t, r)
+		require.Contains(t, secret.Data, originalJSONPatchDataKey)
+
+		r.RestoreStateCRD = true
+		execute(t, r, secret, data)
+		require.Equal(t, int32(3), getReplicas(t, r))
+
+		state, err := getState(t, r)
+		require.NoError(t, err)
+		require.Equal(t, "api", state.Spec.Targets[0].Resources[0].Name)
+		secret, err = r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.NotContains(t, secret.Data, originalJSONPatchDataKey)
+	})
+
+	t.Run("moves the restore patches of the SleepInfoState back to the secret", func(t *testing.T) {
+		r := newReconciler(true)
+		execute(t, r, nil, SleepInfoData{CurrentOperationType: sleepOperation})
+		secret, data := getData(t, r)
+
+		r.RestoreStateCRD = false
+		execute(t, r, secret, data)
+		require.Equal(t, int32(3), getReplicas(t, r))
+
+		_, err := getState(t, r)
+		require.True(t, apierrors.IsNotFound(err))
+		secret, err = r.getSecret(context.Background(), secretName, namespace)
+		require.NoError(t, err)
+		require.Contains(t, secret.Data, originalJSONPatchDataKey)
+	})
+}
+
+func TestNewSleepInfoStateSpec(t *testing.T) {
+	before := metav1.NewTime(time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC))
+	now := time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC)
+	previous := kubegreenv1alpha1.SleepInfoStateSpec{
+		SleepInfo: "sleep",
+		SavedAt:   &before,
+		Targets: []kubegreenv1alpha1.SleepInfoStateTarget{
+			{
+				Target:    "CronJob.batch",
+				SavedAt:   &before,
+				Resources: []kubegreenv1alpha1.SleepInfoStateResource{{Name: "report", Patch: `[{"op":"remove","path":"/spec/suspend"}]`}},
+			},
+			{
+				Target:    "Deployment.apps",
+				SavedAt:   &before,
+				Resources: []kubegreenv1alpha1.SleepInfoStateResource{{Name: "api", Patch: `[{"op":"add","path":"/spec/replicas","value":3}]`, Generation: 2}},
+			},
+		},
+	}
+	restorePatches := map[string]jsonpatch.RestorePatches{
+		"CronJob.batch":   {"report": `[{"op":"remove","path":"/spec/suspend"}]`},
+		"Deployment.apps": {"api": `[{"op":"add","path":"/spec/replicas","value":3}]`, "web": `[{"op":"add","path":"/spec/replicas","value":1}]`},
+	}
+	sleptGenerations := map[string]jsonpatch.SleptResourceGenerations{
+		"Deployment.apps": {"api": 2, "web": 5},
+	}
+
+	t.Run("keeps the save time of the unchanged kinds", func(t *testing.T) {
+		spec := newSleepInfoStateSpec("sleep", now, previous, restorePatches, sleptGenerations)
+
+		require.True(t, now.Equal(spec.SavedAt.Time))
+		require.Equal(t, "CronJob.batch", spec.Targets[0].Target)
+		require.Equal(t, &before, spec.Targets[0].SavedAt)
+		require.Equal(t, "Deployment.apps", spec.Targets[1].Target)
+		require.True(t, now.Equal(spec.Targets[1].SavedAt.Time))
+		require.Equal(t, []kubegreenv1alpha1.SleepInfoStateResource{
+			{Name: "api", Patch: `[{"op":"add","path":"/spec/replicas","value":3}]`, Generation: 2},
+			{Name: "web", Patch: `[{"op":"add","path":"/spec/replicas","value":1}]`, Generation: 5},
+		}, spec.Targets[1].Resources)
+	})
+
+	t.Run("reads back the restore data", func(t *testing.T) {
+		state := &kubegreenv1alpha1.SleepInfoState{Spec: newSleepInfoStateSpec("sleep", now, previous, restorePatches, sleptGenerations)}
+
+		patches, generations := getSleepInfoStateRestoreData(state)
+		require.Equal(t, restorePatches, patches)
+		require.Equal(t, sleptGenerations, generations)
+	})
+
+	t.Run("unchanged restore data keeps the save time", func(t *testing.T) {
+		spec := newSleepInfoStateSpec("sleep", now, previous, map[string]jsonpatch.RestorePatches{
+			"CronJob.batch":   {"report": `[{"op":"remove","path":"/spec/suspend"}]`},
+			"Deployment.apps": {"api": `[{"op":"add","path":"/spec/replicas","value":3}]`},
+		}, map[string]jsonpatch.SleptResourceGenerations{"Deployment.apps": {"api": 2}})
+
+		require.Equal(t, previous, spec)
+	})
+}
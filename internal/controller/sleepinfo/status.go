@@ -0,0 +1,270 @@
+package sleepinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// operationResult is the outcome of a sleep or a wake up, reported in the SleepInfo status
+type operationResult struct {
+	operationType string
+	// suspendedCounts are the resources slept by kind, after a sleep
+	suspendedCounts map[string]int32
+	// savedResources are the CPU and memory requested by the workloads asleep, after a sleep
+	savedResources v1.ResourceList
+	// wakeStagesPending is true when the wake up has stages left
+	wakeStagesPending bool
+	// continued is true for the later stages of a wake up, which keep the time of its first stage
+	continued bool
+	// patched are the resources slept or woken up by kind, failedResources those not slept or woken up
+	patched         map[string]int32
+	failedResources []kubegreenv1alpha1.FailedResource
+	err             error
+}
+
+// maxFailedResources is the number of failed resources listed in the status, the Degraded condition
+// counts all of them
+const maxFailedResources = 50
+
+// apply sets the state, the times, the counts, the observed generation and the LastOperationSucceeded
+// condition of the result.
+// A failed operation leaves the SleepInfo Transitioning, with its previous times and counts.
+func (result operationResult) apply(status *kubegreenv1alpha1.SleepInfoStatus, generation int64, now time.Time) {
+	status.ObservedGeneration = generation
+	operation := operationName(result.operationType)
+	if result.err != nil {
+		status.CurrentState = kubegreenv1alpha1.StateTransitioning
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kubegreenv1alpha1.ConditionLastOperationSucceeded,
+			Status:             metav1.ConditionFalse,
+			Reason:             operation + "Failed",
+			Message:            result.err.Error(),
+			ObservedGeneration: generation,
+		})
+		return
+	}
+
+	at := metav1.NewTime(now)
+	message := ""
+	switch {
+	case result.operationType == sleepOperation:
+		status.CurrentState = kubegreenv1alpha1.StateSleeping
+		status.LastSleepTime = &at
+		status.SuspendedResourceCounts = result.suspendedCounts
+		status.SavedResources = result.savedResources
+		message = fmt.Sprintf("%d resources slept", countResources(result.suspendedCounts))
+	case result.wakeStagesPending:
+		status.CurrentState = kubegreenv1alpha1.StateTransitioning
+		message = "wake up in progress, waiting for its next stages"
+	default:
+		status.CurrentState = kubegreenv1alpha1.StateAwake
+		status.SuspendedResourceCounts = nil
+		status.SavedResources = nil
+		message = "resources woken up"
+	}
+	if result.operationType == wakeUpOperation && !result.continued {
+		status.LastWakeUpTime = &at
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               kubegreenv1alpha1.ConditionLastOperationSucceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             operation + "Succeeded",
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+
+	status.FailedResources = result.failedResources
+	if len(status.FailedResources) > maxFailedResources {
+		status.FailedResources = status.FailedResources[:maxFailedResources]
+	}
+	degraded := metav1.Condition{
+		Type:               kubegreenv1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             "AllResourcesPatched",
+		ObservedGeneration: generation,
+	}
+	if len(result.failedResources) > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = operation + "PartiallyFailed"
+		degraded.Message = partialFailureMessage(result.operationType, result.patched, result.failedResources)
+	}
+	meta.SetStatusCondition(&status.Conditions, degraded)
+}
+
+func countResources(counts map[string]int32) int32 {
+	total := int32(0)
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+// suspendedResourceCounts returns the number of resources slept by kind, from their restore patches
+func suspendedResourceCounts(resources resource.Resource) map[string]int32 {
+	data, err := resources.GetOriginalInfoToSave()
+	if err != nil || data == nil {
+		return nil
+	}
+	restorePatches := map[string]jsonpatch.RestorePatches{}
+	if err := json.Unmarshal(data, &restorePatches); err != nil {
+		return nil
+	}
+	counts := map[string]int32{}
+	for target, patches := range restorePatches {
+		// targets are saved as Kind.group
+		kind, _, _ := strings.Cut(target, ".")
+		counts[kind] += int32(len(patches))
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	return counts
+}
+
+// updateOperationStatus reports the result of an operation in the status of the SleepInfo
+func (r *SleepInfoReconciler) updateOperationStatus(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, result operationResult, now time.Time) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(sleepInfo), latest); err != nil {
+			return err
+		}
+		result.apply(&latest.Status, latest.Generation, now)
+		return r.Status().Update(ctx, latest)
+	})
+	if err != nil {
+		log.Error(err, "fails to update operation status")
+	}
+}
+
+// finishOperation reports the result of an operation, with the resources it patched and failed, in
+// the status, the Events and the metrics of the SleepInfo
+func (r *SleepInfoReconciler) finishOperation(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, events *operationEvents, result operationResult, now time.Time) {
+	result.patched = events.patched
+	result.failedResources = events.failed
+	if result.operationType == sleepOperation {
+		result.savedResources = sleepSavedResources(sleepInfo.Status, events.released)
+	}
+	r.updateOperationStatus(ctx, log, sleepInfo, result, now)
+	events.finished(result)
+	r.setFailedResourcesMetric(sleepInfo, result)
+	r.setSuspendedResourcesMetric(sleepInfo, result)
+}
+
+// setSuspendedResourcesMetric sets the resources asleep of the SleepInfo after an operation, and the
+// requests they released, as its status.suspendedResourceCounts and status.savedResources: kept by
+// failed operations and by wake ups with stages left
+func (r *SleepInfoReconciler) setSuspendedResourcesMetric(sleepInfo *kubegreenv1alpha1.SleepInfo, result operationResult) {
+	switch {
+	case result.err != nil || result.wakeStagesPending:
+		return
+	case result.operationType == sleepOperation:
+		r.Metrics.SetSuspendedResources(sleepInfo.Name, sleepInfo.Namespace, result.suspendedCounts)
+		r.setSavedResourcesMetric(sleepInfo, result.savedResources)
+	default:
+		r.Metrics.SetSuspendedResources(sleepInfo.Name, sleepInfo.Namespace, nil)
+		r.setSavedResourcesMetric(sleepInfo, nil)
+	}
+}
+
+// setFailedResourcesMetric sets the resources failed by the last operation of the SleepInfo by kind
+func (r *SleepInfoReconciler) setFailedResourcesMetric(sleepInfo *kubegreenv1alpha1.SleepInfo, result operationResult) {
+	if r.Metrics.FailedResources == nil {
+		return
+	}
+	r.Metrics.FailedResources.DeletePartialMatch(prometheus.Labels{"name": sleepInfo.Name, "namespace": sleepInfo.Namespace})
+	for _, res := range result.failedResources {
+		r.Metrics.FailedResources.With(prometheus.Labels{
+			"name":      sleepInfo.Name,
+			"namespace": sleepInfo.Namespace,
+			"operation": result.operationType,
+			"kind":      res.Kind,
+		}).Inc()
+	}
+}
+
+// setReadyCondition sets the Ready condition and the observed generation of the SleepInfo status: false
+// with the reason the schedule cannot be handled, true once it is handled. It removes the
+// ReconcileSkipped condition, as the SleepInfo is reconciled again
+func setReadyCondition(status *kubegreenv1alpha1.SleepInfoStatus, generation int64, err error, reason string) {
+	status.ObservedGeneration = generation
+	condition := metav1.Condition{
+		Type:               kubegreenv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Scheduled",
+		ObservedGeneration: generation,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+	meta.RemoveStatusCondition(&status.Conditions, kubegreenv1alpha1.ConditionReconcileSkipped)
+}
+
+// updateNotReadyStatus sets the Ready condition to false when the schedule cannot be handled
+func (r *SleepInfoReconciler) updateNotReadyStatus(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, reason string, cause error) {
+	latest := sleepInfo.DeepCopy()
+	setReadyCondition(&latest.Status, latest.Generation, cause, reason)
+	if err := r.Status().Update(ctx, latest); err != nil {
+		log.Error(err, "fails to update ready condition")
+	}
+}
+
+// observeGeneration sets the Ready condition and the observed generation of a SleepInfo with a valid
+// schedule whose status does not report its generation yet, e.g. once created or after a spec change,
+// so its health is known before its first operation
+func (r *SleepInfoReconciler) observeGeneration(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	ready := meta.FindStatusCondition(sleepInfo.Status.Conditions, kubegreenv1alpha1.ConditionReady)
+	skipped := meta.FindStatusCondition(sleepInfo.Status.Conditions, kubegreenv1alpha1.ConditionReconcileSkipped)
+	if sleepInfo.Status.ObservedGeneration == sleepInfo.Generation && ready != nil &&
+		ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == sleepInfo.Generation && skipped == nil {
+		return
+	}
+	latest := sleepInfo.DeepCopy()
+	setReadyCondition(&latest.Status, latest.Generation, nil, "")
+	if err := r.Status().Update(ctx, latest); err != nil {
+		log.Error(err, "fails to update observed generation")
+		return
+	}
+	// The later status updates of the reconcile start from this one
+	sleepInfo.Status = latest.Status
+	sleepInfo.ResourceVersion = latest.ResourceVersion
+}
+
+// markReconcileSkipped sets the ReconcileSkipped condition and the observed generation of a SleepInfo
+// skipped with the skip-reconcile annotation, so its status tells the spec is seen but not handled
+func (r *SleepInfoReconciler) markReconcileSkipped(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) {
+	skipped := meta.FindStatusCondition(sleepInfo.Status.Conditions, kubegreenv1alpha1.ConditionReconcileSkipped)
+	if sleepInfo.Status.ObservedGeneration == sleepInfo.Generation && skipped != nil &&
+		skipped.Status == metav1.ConditionTrue && skipped.ObservedGeneration == sleepInfo.Generation {
+		return
+	}
+	latest := sleepInfo.DeepCopy()
+	latest.Status.ObservedGeneration = latest.Generation
+	meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{
+		Type:               kubegreenv1alpha1.ConditionReconcileSkipped,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SkipReconcileAnnotation",
+		Message:            "reconciliation skipped by the " + kubegreenv1alpha1.SkipReconcileAnnotation + " annotation",
+		ObservedGeneration: latest.Generation,
+	})
+	if err := r.Status().Update(ctx, latest); err != nil {
+		log.Error(err, "fails to update reconcile skipped condition")
+	}
+}
@@ -0,0 +1,297 @@
+package sleepinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/metrics"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/resource"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type savedResources struct {
+	resource.Resource
+	originalInfo []byte
+}
+
+func (s savedResources) GetOriginalInfoToSave() ([]byte, error) {
+	return s.originalInfo, nil
+}
+
+func TestOperationResult(t *testing.T) {
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+	sleptAt := metav1.NewTime(now.Add(-10 * time.Hour))
+
+	t.Run("sleep", func(t *testing.T) {
+		status := kubegreenv1alpha1.SleepInfoStatus{}
+		operationResult{operationType: sleepOperation, suspendedCounts: map[string]int32{"Deployment": 3, "StatefulSet": 1}}.apply(&status, 2, now)
+
+		require.Equal(t, kubegreenv1alpha1.StateSleeping, status.CurrentState)
+		require.Equal(t, now, status.LastSleepTime.Time)
+		require.Nil(t, status.LastWakeUpTime)
+		require.Equal(t, map[string]int32{"Deployment": 3, "StatefulSet": 1}, status.SuspendedResourceCounts)
+		condition := meta.FindStatusCondition(status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded)
+		require.Equal(t, metav1.ConditionTrue, condition.Status)
+		require.Equal(t, "SleepSucceeded", condition.Reason)
+		require.Equal(t, "4 resources slept", condition.Message)
+		require.Equal(t, int64(2), condition.ObservedGeneration)
+		require.Equal(t, int64(2), status.ObservedGeneration)
+	})
+
+	t.Run("wake up", func(t *testing.T) {
+		status := kubegreenv1alpha1.SleepInfoStatus{LastSleepTime: &sleptAt, SuspendedResourceCounts: map[string]int32{"Deployment": 3}}
+		operationResult{operationType: wakeUpOperation}.apply(&status, 1, now)
+
+		require.Equal(t, kubegreenv1alpha1.StateAwake, status.CurrentState)
+		require.Equal(t, now, status.LastWakeUpTime.Time)
+		require.Equal(t, &sleptAt, status.LastSleepTime)
+		require.Nil(t, status.SuspendedResourceCounts)
+		require.True(t, meta.IsStatusConditionTrue(status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded))
+	})
+
+	t.Run("wake up with stages left", func(t *testing.T) {
+		status := kubegreenv1alpha1.SleepInfoStatus{SuspendedResourceCounts: map[string]int32{"Deployment": 3}}
+		operationResult{operationType: wakeUpOperation, wakeStagesPending: true}.apply(&status, 1, now)
+
+		require.Equal(t, kubegreenv1alpha1.StateTransitioning, status.CurrentState)
+		require.Equal(t, now, status.LastWakeUpTime.Time)
+		require.Equal(t, map[string]int32{"Deployment": 3}, status.SuspendedResourceCounts)
+
+		operationResult{operationType: wakeUpOperation, continued: true}.apply(&status, 1, now.Add(10*time.Minute))
+		require.Equal(t, kubegreenv1alpha1.StateAwake, status.CurrentState)
+		require.Equal(t, now, status.LastWakeUpTime.Time)
+		require.Nil(t, status.SuspendedResourceCounts)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		status := kubegreenv1alpha1.SleepInfoStatus{}
+		operationResult{
+			operationType:   sleepOperation,
+			patched:         map[string]int32{"Deployment": 22, "StatefulSet": 1},
+			failedResources: []kubegreenv1alpha1.FailedResource{{Kind: "Deployment", Name: "api", Reason: "conflict"}, {Kind: "Deployment", Name: "web", Reason: "conflict"}, {Kind: "Deployment", Name: "worker", Reason: "conflict"}},
+		}.apply(&status, 1, now)
+
+		require.Equal(t, kubegreenv1alpha1.StateSleeping, status.CurrentState)
+		require.Len(t, status.FailedResources, 3)
+		degraded := meta.FindStatusCondition(status.Conditions, kubegreenv1alpha1.ConditionDegraded)
+		require.Equal(t, metav1.ConditionTrue, degraded.Status)
+		require.Equal(t, "SleepPartiallyFailed", degraded.Reason)
+		require.Equal(t, "3 of 25 Deployment failed to sleep", degraded.Message)
+
+		operationResult{operationType: wakeUpOperation, patched: map[string]int32{"Deployment": 25}}.apply(&status, 1, now)
+		require.Nil(t, status.FailedResources)
+		require.True(t, meta.IsStatusConditionFalse(status.Conditions, kubegreenv1alpha1.ConditionDegraded))
+	})
+
+	t.Run("lists a limited number of failed resources", func(t *testing.T) {
+		failed := make([]kubegreenv1alpha1.FailedResource, maxFailedResources+10)
+		for i := range failed {
+			failed[i] = kubegreenv1alpha1.FailedResource{Kind: "Deployment", Name: fmt.Sprintf("deployment-%d", i), Reason: "conflict"}
+		}
+		status := kubegreenv1alpha1.SleepInfoStatus{}
+		operationResult{operationType: sleepOperation, failedResources: failed}.apply(&status, 1, now)
+
+		require.Len(t, status.FailedResources, maxFailedResources)
+		require.Equal(t, "60 of 60 Deployment failed to sleep", meta.FindStatusCondition(status.Conditions, kubegreenv1alpha1.ConditionDegraded).Message)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		status := kubegreenv1alpha1.SleepInfoStatus{LastSleepTime: &sleptAt, SuspendedResourceCounts: map[string]int32{"Deployment": 3}}
+		operationResult{operationType: wakeUpOperation, err: errors.New("deployment api not patched")}.apply(&status, 1, now)
+
+		require.Equal(t, kubegreenv1alpha1.StateTransitioning, status.CurrentState)
+		require.Nil(t, status.LastWakeUpTime)
+		require.Equal(t, map[string]int32{"Deployment": 3}, status.SuspendedResourceCounts)
+		condition := meta.FindStatusCondition(status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded)
+		require.Equal(t, metav1.ConditionFalse, condition.Status)
+		require.Equal(t, "WakeUpFailed", condition.Reason)
+		require.Equal(t, "deployment api not patched", condition.Message)
+	})
+}
+
+func TestSuspendedResourceCounts(t *testing.T) {
+	resources := savedResources{originalInfo: []byte(`{
+		"Deployment.apps": {"api": "{}", "frontend": "{}"},
+		"StatefulSet.apps": {"db": "{}"},
+		"PgCluster.postgres.stratio.com": {"pg": "{}"}
+	}`)}
+	require.Equal(t, map[string]int32{"Deployment": 2, "StatefulSet": 1, "PgCluster": 1}, suspendedResourceCounts(resources))
+
+	require.Nil(t, suspendedResourceCounts(savedResources{}))
+	require.Nil(t, suspendedResourceCounts(savedResources{originalInfo: []byte(`{}`)}))
+}
+
+func TestUpdateOperationStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace", Generation: 3},
+		Status:     kubegreenv1alpha1.SleepInfoStatus{OperationType: sleepOperation},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sleepInfo).WithStatusSubresource(sleepInfo).Build()
+	r := SleepInfoReconciler{Client: fakeClient}
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+
+	r.updateOperationStatus(context.Background(), logr.Discard(), sleepInfo, operationResult{operationType: sleepOperation, suspendedCounts: map[string]int32{"Deployment": 1}}, now)
+
+	updated := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+	require.Equal(t, sleepOperation, updated.Status.OperationType)
+	require.Equal(t, kubegreenv1alpha1.StateSleeping, updated.Status.CurrentState)
+	require.Equal(t, map[string]int32{"Deployment": 1}, updated.Status.SuspendedResourceCounts)
+	require.Equal(t, int64(3), meta.FindStatusCondition(updated.Status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded).ObservedGeneration)
+
+	t.Run("not ready", func(t *testing.T) {
+		r.updateNotReadyStatus(context.Background(), logr.Discard(), updated, "InvalidSchedule", errors.New("invalid weekdays"))
+
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		ready := meta.FindStatusCondition(updated.Status.Conditions, kubegreenv1alpha1.ConditionReady)
+		require.Equal(t, metav1.ConditionFalse, ready.Status)
+		require.Equal(t, "InvalidSchedule", ready.Reason)
+		require.Equal(t, "invalid weekdays", ready.Message)
+		require.Equal(t, int64(3), updated.Status.ObservedGeneration)
+		require.True(t, meta.IsStatusConditionTrue(updated.Status.Conditions, kubegreenv1alpha1.ConditionLastOperationSucceeded))
+	})
+}
+
+func TestObserveGeneration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace", Generation: 2},
+		Status:     kubegreenv1alpha1.SleepInfoStatus{CurrentState: kubegreenv1alpha1.StateAwake},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sleepInfo).WithStatusSubresource(sleepInfo).Build()
+	r := SleepInfoReconciler{Client: fakeClient}
+	current := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), current))
+
+	r.observeGeneration(context.Background(), logr.Discard(), current)
+
+	updated := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+	require.Equal(t, int64(2), updated.Status.ObservedGeneration)
+	ready := meta.FindStatusCondition(updated.Status.Conditions, kubegreenv1alpha1.ConditionReady)
+	require.Equal(t, metav1.ConditionTrue, ready.Status)
+	require.Equal(t, int64(2), ready.ObservedGeneration)
+	require.Equal(t, kubegreenv1alpha1.StateAwake, updated.Status.CurrentState)
+	require.Equal(t, updated.ResourceVersion, current.ResourceVersion, "the later updates of the reconcile must not conflict")
+
+	t.Run("not updated when up to date", func(t *testing.T) {
+		r.observeGeneration(context.Background(), logr.Discard(), current)
+
+		latest := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), latest))
+		require.Equal(t, updated.ResourceVersion, latest.ResourceVersion)
+	})
+}
+
+func TestSkipReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "sleep",
+			Namespace:   "my-namespace",
+			Generation:  2,
+			Annotations: map[string]string{kubegreenv1alpha1.SkipReconcileAnnotation: "true"},
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{Weekdays: "*", SleepTime: "*:*"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sleepInfo).WithStatusSubresource(sleepInfo).Build()
+	r := SleepInfoReconciler{Client: fakeClient, Log: logr.Discard()}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(sleepInfo)})
+	require.NoError(t, err)
+	require.Equal(t, ctrl.Result{}, result)
+
+	updated := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+	require.Equal(t, int64(2), updated.Status.ObservedGeneration)
+	require.Len(t, updated.Status.Conditions, 1)
+	skipped := meta.FindStatusCondition(updated.Status.Conditions, kubegreenv1alpha1.ConditionReconcileSkipped)
+	require.NotNil(t, skipped)
+	require.Equal(t, metav1.ConditionTrue, skipped.Status)
+	require.Equal(t, "SkipReconcileAnnotation", skipped.Reason)
+	require.Equal(t, int64(2), skipped.ObservedGeneration)
+	require.Empty(t, updated.Finalizers)
+
+	t.Run("condition is removed once the SleepInfo is reconciled again", func(t *testing.T) {
+		r.observeGeneration(context.Background(), logr.Discard(), updated)
+
+		resumed := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), resumed))
+		require.Nil(t, meta.FindStatusCondition(resumed.Status.Conditions, kubegreenv1alpha1.ConditionReconcileSkipped))
+		require.True(t, meta.IsStatusConditionTrue(resumed.Status.Conditions, kubegreenv1alpha1.ConditionReady))
+	})
+}
+
+func TestFinishOperation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: "my-namespace"}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sleepInfo).WithStatusSubresource(sleepInfo).Build()
+	r := SleepInfoReconciler{Client: fakeClient, Metrics: metrics.SetupMetricsOrDie("kube_green")}
+	now := time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC)
+
+	events := r.operationEvents(sleepInfo, sleepOperation)
+	frontend := unstructuredOf("Deployment", "frontend")
+	require.NoError(t, unstructured.SetNestedField(frontend.Object, int64(2), "spec", "replicas"))
+	require.NoError(t, unstructured.SetNestedSlice(frontend.Object, []interface{}{
+		map[string]interface{}{"name": "frontend", "resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "500m", "memory": "256Mi"}}},
+	}, "spec", "template", "spec", "containers"))
+	events.resourcePatched(frontend)
+	events.resourceFailed(unstructuredOf("Deployment", "api"), "conflict")
+	r.finishOperation(context.Background(), logr.Discard(), sleepInfo, events, operationResult{operationType: sleepOperation, suspendedCounts: map[string]int32{"Deployment": 1}}, now)
+
+	updated := &kubegreenv1alpha1.SleepInfo{}
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+	require.Equal(t, []kubegreenv1alpha1.FailedResource{{Kind: "Deployment", Name: "api", Reason: "conflict"}}, updated.Status.FailedResources)
+	require.Equal(t, "1 of 2 Deployment failed to sleep", meta.FindStatusCondition(updated.Status.Conditions, kubegreenv1alpha1.ConditionDegraded).Message)
+	require.Equal(t, float64(1), testutil.ToFloat64(r.Metrics.FailedResources.With(prometheus.Labels{
+		"name": "sleep", "namespace": "my-namespace", "operation": sleepOperation, "kind": "Deployment",
+	})))
+	require.Equal(t, float64(1), testutil.ToFloat64(r.Metrics.SuspendedResources.With(prometheus.Labels{
+		"namespace": "my-namespace", "kind": "Deployment",
+	})))
+	require.Equal(t, "1", updated.Status.SavedResources.Cpu().String())
+	require.Equal(t, "512Mi", updated.Status.SavedResources.Memory().String())
+	require.Equal(t, float64(1), testutil.ToFloat64(r.Metrics.SavedCPUCores.WithLabelValues("my-namespace")))
+	require.Equal(t, float64(512*1024*1024), testutil.ToFloat64(r.Metrics.SavedMemoryBytes.WithLabelValues("my-namespace")))
+
+	r.finishOperation(context.Background(), logr.Discard(), sleepInfo, r.operationEvents(sleepInfo, wakeUpOperation), operationResult{operationType: wakeUpOperation}, now)
+	require.Equal(t, 0, testutil.CollectAndCount(r.Metrics.FailedResources))
+	require.Equal(t, 0, testutil.CollectAndCount(r.Metrics.SuspendedResources))
+	require.Equal(t, 0, testutil.CollectAndCount(r.Metrics.SavedCPUCores))
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+	require.Nil(t, updated.Status.SavedResources)
+}
+
+func unstructuredOf(kind, name string) unstructured.Unstructured {
+	res := unstructured.Unstructured{}
+	res.SetAPIVersion("apps/v1")
+	res.SetKind(kind)
+	res.SetName(name)
+	res.SetNamespace("my-namespace")
+	return res
+}
@@ -0,0 +1,220 @@
+package sleepinfo
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileTargetNamespaces keeps a copy of a SleepInfo with namespaceSelector in every namespace it
+// selects, and deletes the copies of the namespaces no longer selected. The target namespaces
+// finalizer deletes all the copies once the SleepInfo, or its namespaceSelector, is deleted.
+func (r *SleepInfoReconciler) reconcileTargetNamespaces(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo) error {
+	hasFinalizer := controllerutil.ContainsFinalizer(sleepInfo, kubegreenv1alpha1.TargetNamespacesFinalizer)
+	if !sleepInfo.DeletionTimestamp.IsZero() || sleepInfo.Spec.NamespaceSelector == nil {
+		if !hasFinalizer {
+			return nil
+		}
+		if err := r.deleteUnselectedCopies(ctx, log, sleepInfo, nil); err != nil {
+			return err
+		}
+		controllerutil.RemoveFinalizer(sleepInfo, kubegreenv1alpha1.TargetNamespacesFinalizer)
+		if err := r.Update(ctx, sleepInfo); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("fails to remove target namespaces finalizer: %w", err)
+		}
+		return nil
+	}
+
+	if !hasFinalizer {
+		controllerutil.AddFinalizer(sleepInfo, kubegreenv1alpha1.TargetNamespacesFinalizer)
+		if err := r.Update(ctx, sleepInfo); err != nil {
+			return fmt.Errorf("fails to add target namespaces finalizer: %w", err)
+		}
+	}
+
+	namespaces, err := r.getTargetNamespaces(ctx, sleepInfo)
+	if err != nil {
+		return err
+	}
+	if err := r.removeUnauthorizedNamespaces(ctx, log, sleepInfo, namespaces); err != nil {
+		return err
+	}
+	for namespace := range namespaces {
+		applied, err := r.applyCopy(ctx, sleepInfo, namespace)
+		if err != nil {
+			return fmt.Errorf("fails to apply SleepInfo in namespace %s: %w", namespace, err)
+		}
+		if !applied {
+			log.Info("target namespace has a SleepInfo with the same name, skipped", "targetNamespace", namespace)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "NamespaceSkipped", "namespace %s already has a SleepInfo named %s", namespace, sleepInfo.Name)
+			}
+		}
+	}
+	return r.deleteUnselectedCopies(ctx, log, sleepInfo, namespaces)
+}
+
+// getTargetNamespaces returns the namespaces selected by the namespaceSelector of the SleepInfo,
+// except its own namespace and the ones being deleted
+func (r *SleepInfoReconciler) getTargetNamespaces(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo) (map[string]bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sleepInfo.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	namespaceList := &v1.NamespaceList{}
+	if err := r.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("fails to list target namespaces: %w", err)
+	}
+	namespaces := map[string]bool{}
+	for _, namespace := range namespaceList.Items {
+		if namespace.Name != sleepInfo.Namespace && namespace.DeletionTimestamp.IsZero() {
+			namespaces[namespace.Name] = true
+		}
+	}
+	return namespaces, nil
+}
+
+// removeUnauthorizedNamespaces drops the target namespaces where the user who set the namespaceSelector
+// cannot create SleepInfos. The webhook checks it on admission, but namespaces labelled afterwards are
+// only checked here; their copies, if any, are deleted as unselected.
+func (r *SleepInfoReconciler) removeUnauthorizedNamespaces(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, namespaces map[string]bool) error {
+	requester, ok := sleepInfo.NamespaceSelectorRequester()
+	if !ok {
+		if len(namespaces) > 0 {
+			log.Info("namespaceSelector without requester, SleepInfo not copied", "annotation", kubegreenv1alpha1.NamespaceSelectorRequesterAnnotation)
+			if r.Recorder != nil {
+				r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "NamespaceNotAuthorized", "the user who set the namespaceSelector is unknown, apply the SleepInfo again to copy it")
+			}
+		}
+		clear(namespaces)
+		return nil
+	}
+
+	for namespace := range namespaces {
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:   requester.Username,
+				UID:    requester.UID,
+				Groups: requester.Groups,
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      "create",
+					Group:     kubegreenv1alpha1.GroupVersion.Group,
+					Resource:  "sleepinfos",
+				},
+			},
+		}
+		if err := r.Create(ctx, review); err != nil {
+			return fmt.Errorf("fails to review access to namespace %s: %w", namespace, err)
+		}
+		if review.Status.Allowed {
+			continue
+		}
+		delete(namespaces, namespace)
+		log.Info("requester not allowed in target namespace, skipped", "targetNamespace", namespace, "user", requester.Username)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(sleepInfo, v1.EventTypeWarning, "NamespaceNotAuthorized", "%s is not allowed to create SleepInfos in namespace %s", requester.Username, namespace)
+		}
+	}
+	return nil
+}
+
+// isCopyOf returns whether a SleepInfo is the copy of sleepInfo in a target namespace
+func isCopyOf(copied, sleepInfo *kubegreenv1alpha1.SleepInfo) bool {
+	return copied.Name == sleepInfo.Name && copied.Labels[kubegreenv1alpha1.SourceNamespaceLabel] == sleepInfo.Namespace
+}
+
+// applyCopy creates or updates the copy of the SleepInfo in the namespace. It returns false when the
+// namespace has a SleepInfo of the same name which is not a copy, left untouched.
+func (r *SleepInfoReconciler) applyCopy(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, namespace string) (bool, error) {
+	copied := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sleepInfo.Name,
+			Namespace: namespace,
+		},
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(copied), copied); err == nil {
+		if !isCopyOf(copied, sleepInfo) {
+			return false, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, copied, func() error {
+		if copied.Labels == nil {
+			copied.Labels = map[string]string{}
+		}
+		copied.Labels[kubegreenv1alpha1.SourceNamespaceLabel] = sleepInfo.Namespace
+		copied.Spec = *sleepInfo.Spec.DeepCopy()
+		copied.Spec.NamespaceSelector = nil
+		return nil
+	})
+	return err == nil, err
+}
+
+// deleteUnselectedCopies deletes the copies of the SleepInfo in the namespaces not selected
+func (r *SleepInfoReconciler) deleteUnselectedCopies(ctx context.Context, log logr.Logger, sleepInfo *kubegreenv1alpha1.SleepInfo, namespaces map[string]bool) error {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := r.List(ctx, sleepInfoList, client.MatchingLabels{kubegreenv1alpha1.SourceNamespaceLabel: sleepInfo.Namespace}); err != nil {
+		return fmt.Errorf("fails to list SleepInfos of target namespaces: %w", err)
+	}
+	for i := range sleepInfoList.Items {
+		copied := &sleepInfoList.Items[i]
+		if namespaces[copied.Namespace] || !isCopyOf(copied, sleepInfo) {
+			continue
+		}
+		log.Info("target namespace no longer selected, deleting its SleepInfo", "targetNamespace", copied.Namespace)
+		if err := r.Delete(ctx, copied); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("fails to delete SleepInfo in namespace %s: %w", copied.Namespace, err)
+		}
+	}
+	return nil
+}
+
+// namespaceLabelsChanged filters the namespace events changing the namespaces selected by a
+// namespaceSelector: creations and label updates
+var namespaceLabelsChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool {
+		return true
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !maps.Equal(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+	},
+	DeleteFunc: func(event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(event.GenericEvent) bool {
+		return false
+	},
+}
+
+// requestsForNamespace enqueues the SleepInfos with namespaceSelector of the shard when a namespace
+// changes, since its labels may change the namespaces they select
+func (r *SleepInfoReconciler) requestsForNamespace(ctx context.Context, _ client.Object) []reconcile.Request {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := r.List(ctx, sleepInfoList); err != nil {
+		r.Log.Error(err, "fails to list SleepInfos")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, sleepInfo := range sleepInfoList.Items {
+		if sleepInfo.Spec.NamespaceSelector != nil && r.Shard.Owns(sleepInfo.Namespace) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&sleepInfo)})
+		}
+	}
+	return requests
+}
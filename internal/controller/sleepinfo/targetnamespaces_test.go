@@ -0,0 +1,169 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/sharding"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestReconcileTargetNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	namespace := func(name string, labels map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	sourceLabels := map[string]string{kubegreenv1alpha1.SourceNamespaceLabel: "control"}
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "working-hours",
+			Namespace:   "control",
+			Annotations: map[string]string{kubegreenv1alpha1.NamespaceSelectorRequesterAnnotation: `{"username":"jane","groups":["devs"]}`},
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			Weekdays:          "1-5",
+			SleepTime:         "20:00",
+			WakeUpTime:        "08:00",
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+		},
+	}
+	notCopy := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: "dev-team"},
+		Spec:       kubegreenv1alpha1.SleepInfoSpec{Weekdays: "*", SleepTime: "22:00"},
+	}
+	unselected := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: "prod", Labels: sourceLabels},
+	}
+	newClient := func(objects ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			namespace("control", map[string]string{"env": "dev"}),
+			namespace("dev-api", map[string]string{"env": "dev"}),
+			namespace("dev-team", map[string]string{"env": "dev"}),
+			namespace("prod", map[string]string{"env": "prod"}),
+		).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				require.Equal(t, []string{"devs"}, review.Spec.Groups)
+				review.Status.Allowed = review.Spec.User == "jane" && review.Spec.ResourceAttributes.Namespace != "dev-secret"
+				return nil
+			},
+		}).Build()
+	}
+	getCopy := func(c client.Client, namespace string) (*kubegreenv1alpha1.SleepInfo, error) {
+		copied := &kubegreenv1alpha1.SleepInfo{}
+		err := c.Get(context.Background(), client.ObjectKey{Name: sleepInfo.Name, Namespace: namespace}, copied)
+		return copied, err
+	}
+
+	t.Run("applies the SleepInfo to the namespaces selected", func(t *testing.T) {
+		source := sleepInfo.DeepCopy()
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Client: newClient(source, notCopy.DeepCopy(), unselected.DeepCopy()), Recorder: recorder}
+
+		require.NoError(t, r.reconcileTargetNamespaces(context.Background(), logr.Discard(), source))
+
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.Equal(t, []string{kubegreenv1alpha1.TargetNamespacesFinalizer}, updated.Finalizers)
+
+		copied, err := getCopy(r.Client, "dev-api")
+		require.NoError(t, err)
+		require.Equal(t, "control", copied.Labels[kubegreenv1alpha1.SourceNamespaceLabel])
+		require.Nil(t, copied.Spec.NamespaceSelector)
+		require.Equal(t, "20:00", copied.Spec.SleepTime)
+
+		copied, err = getCopy(r.Client, "dev-team")
+		require.NoError(t, err)
+		require.Equal(t, notCopy.Spec, copied.Spec)
+		require.Equal(t, "Warning NamespaceSkipped namespace dev-team already has a SleepInfo named working-hours", <-recorder.Events)
+
+		_, err = getCopy(r.Client, "prod")
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("skips the namespaces the requester cannot create SleepInfos in", func(t *testing.T) {
+		source := sleepInfo.DeepCopy()
+		recorder := record.NewFakeRecorder(10)
+		denied := &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "working-hours", Namespace: "dev-secret", Labels: sourceLabels},
+		}
+		r := SleepInfoReconciler{Client: newClient(source, namespace("dev-secret", map[string]string{"env": "dev"}), denied), Recorder: recorder}
+
+		require.NoError(t, r.reconcileTargetNamespaces(context.Background(), logr.Discard(), source))
+
+		_, err := getCopy(r.Client, "dev-api")
+		require.NoError(t, err)
+		_, err = getCopy(r.Client, "dev-secret")
+		require.True(t, apierrors.IsNotFound(err))
+		require.Equal(t, "Warning NamespaceNotAuthorized jane is not allowed to create SleepInfos in namespace dev-secret", <-recorder.Events)
+	})
+
+	t.Run("does not copy without requester", func(t *testing.T) {
+		source := sleepInfo.DeepCopy()
+		source.Annotations = nil
+		recorder := record.NewFakeRecorder(10)
+		r := SleepInfoReconciler{Client: newClient(source, unselected.DeepCopy()), Recorder: recorder}
+
+		require.NoError(t, r.reconcileTargetNamespaces(context.Background(), logr.Discard(), source))
+
+		_, err := getCopy(r.Client, "dev-api")
+		require.True(t, apierrors.IsNotFound(err))
+		_, err = getCopy(r.Client, "prod")
+		require.True(t, apierrors.IsNotFound(err))
+		require.Contains(t, <-recorder.Events, "NamespaceNotAuthorized")
+	})
+
+	t.Run("deletes the copies when the namespaceSelector is removed", func(t *testing.T) {
+		source := sleepInfo.DeepCopy()
+		source.Finalizers = []string{kubegreenv1alpha1.TargetNamespacesFinalizer}
+		source.Spec.NamespaceSelector = nil
+		r := SleepInfoReconciler{Client: newClient(source, notCopy.DeepCopy(), unselected.DeepCopy())}
+
+		require.NoError(t, r.reconcileTargetNamespaces(context.Background(), logr.Discard(), source))
+
+		updated := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(sleepInfo), updated))
+		require.Empty(t, updated.Finalizers)
+		_, err := getCopy(r.Client, "prod")
+		require.True(t, apierrors.IsNotFound(err))
+		_, err = getCopy(r.Client, "dev-team")
+		require.NoError(t, err)
+	})
+
+	t.Run("enqueues the SleepInfos with namespaceSelector", func(t *testing.T) {
+		r := SleepInfoReconciler{Client: newClient(sleepInfo.DeepCopy(), notCopy.DeepCopy()), Log: logr.Discard()}
+
+		requests := r.requestsForNamespace(context.Background(), namespace("dev-web", nil))
+		require.Len(t, requests, 1)
+		require.Equal(t, client.ObjectKeyFromObject(sleepInfo), requests[0].NamespacedName)
+	})
+
+	t.Run("enqueues only the SleepInfos of the shard", func(t *testing.T) {
+		shard := sharding.Shard{Index: 0, Count: 2}
+		if shard.Owns(sleepInfo.Namespace) {
+			shard.Index = 1
+		}
+		r := SleepInfoReconciler{Client: newClient(sleepInfo.DeepCopy()), Log: logr.Discard(), Shard: shard}
+
+		require.Empty(t, r.requestsForNamespace(context.Background(), namespace("dev-web", nil)))
+	})
+}
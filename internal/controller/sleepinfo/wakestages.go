@@ -0,0 +1,231 @@
+package sleepinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	wakeStagesDataKey = "wake-stages"
+
+	// wakeStageReadyInterval is how often the readiness of a stage is checked while the next stage waits for it
+	wakeStageReadyInterval = 30 * time.Second
+)
+
+// WakeStagesProgress is the progress of a wake up with wake stages, saved in the secret until
+// its last stage is executed.
+type WakeStagesProgress struct {
+	// StartedAt is the time of the wake up, the delays of the stages start from it
+	StartedAt time.Time `json:"startedAt"`
+	// Done is the number of stages already executed
+	Done int `json:"done"`
+	// StageAt is the time the last stages were executed, the ready timeout of a stage starts from it
+	StageAt time.Time `json:"stageAt,omitempty"`
+}
+
+func getWakeStagesProgress(data []byte) (*WakeStagesProgress, error) {
+	if data == nil {
+		return nil, nil
+	}
+	progress := &WakeStagesProgress{}
+	if err := json.Unmarshal(data, progress); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// dueWakeStages returns the end (excluded) of the stages to execute after the first done ones, for
+// a wake up started at startedAt: the stages whose delay is over at now, up to the first stage the
+// next ones wait to be ready. Stages are executed in order, so it stops at the first one to wait for.
+func dueWakeStages(stages []kubegreenv1alpha1.WakeStage, done int, startedAt, now time.Time) int {
+	for i := done; i < len(stages); i++ {
+		if startedAt.Add(stages[i].GetDelay()).After(now) {
+			return i
+		}
+		if stages[i].WaitForReady {
+			return i + 1
+		}
+	}
+	return len(stages)
+}
+
+// nextWakeStageIn returns the time left to the first stage not done, 0 when all stages are done.
+// When the stage waits for the previous one to be ready, it is at least the readiness check interval.
+func nextWakeStageIn(stages []kubegreenv1alpha1.WakeStage, progress WakeStagesProgress, now time.Time) time.Duration {
+	if progress.Done >= len(stages) {
+		return 0
+	}
+	next := progress.StartedAt.Add(stages[progress.Done].GetDelay()).Sub(now)
+	if progress.Done > 0 && stages[progress.Done-1].WaitForReady && next < wakeStageReadyInterval {
+		return wakeStageReadyInterval
+	}
+	return next
+}
+
+// wakeStagesFilter returns the wake up filter of the stages from (included) to to (excluded), and
+// of the resources matching no stage when withUnstaged is true.
+func wakeStagesFilter(sleepInfo *kubegreenv1alpha1.SleepInfo, from, to int, withUnstaged bool) func(kubegreenv1alpha1.PatchTarget, unstructured.Unstructured) bool {
+	return func(target kubegreenv1alpha1.PatchTarget, res unstructured.Unstructured) bool {
+		stage := sleepInfo.GetWakeStage(target, res.GetName(), res.GetLabels())
+		if stage < 0 {
+			return withUnstaged
+		}
+		return stage >= from && stage < to
+	}
+}
+
+// startWakeStages returns the wake up filter and the progress of a wake up starting at now: the
+// resources matching no stage wake up at once, with the stages without delay. The progress is nil
+// when the SleepInfo has no wake stages, or when all of them are already due.
+func startWakeStages(sleepInfo *kubegreenv1alpha1.SleepInfo, now time.Time) (func(kubegreenv1alpha1.PatchTarget, unstructured.Unstructured) bool, *WakeStagesProgress) {
+	stages := sleepInfo.GetWakeStages()
+	if len(stages) == 0 {
+		return nil, nil
+	}
+	progress := &WakeStagesProgress{StartedAt: now, Done: dueWakeStages(stages, 0, now, now), StageAt: now}
+	filter := wakeStagesFilter(sleepInfo, 0, progress.Done, true)
+	if progress.Done == len(stages) {
+		return filter, nil
+	}
+	return filter, progress
+}
+
+// wakeUpPendingStages executes the stages of the wake up in progress whose delay is over. It
+// returns the time left to the next stage, 0 when the wake up is complete.
+func (r *SleepInfoReconciler) wakeUpPendingStages(
+	ctx context.Context,
+	log logr.Logger,
+	sleepInfo *kubegreenv1alpha1.SleepInfo,
+	secret *v1.Secret,
+	sleepInfoData SleepInfoData,
+	now time.Time,
+) (time.Duration, error) {
+	stages := sleepInfo.GetWakeStages()
+	progress := *sleepInfoData.WakeStages
+	due := dueWakeStages(stages, progress.Done, progress.StartedAt, now)
+	if due <= progress.Done {
+		return nextWakeStageIn(stages, progress, now), nil
+	}
+	if progress.Done > 0 && stages[progress.Done-1].WaitForReady {
+		previous := stages[progress.Done-1]
+		readyDeadline := progress.StageAt.Add(previous.GetReadyTimeout())
+		if now.Before(readyDeadline) {
+			ready, err := r.isWakeStageReady(ctx, sleepInfo, progress.Done-1)
+			if err != nil {
+				log.Error(err, "fails to check wake stage readiness", "stage", previous.Name)
+			}
+			if !ready {
+				log.Info("waiting for wake stage to be ready", "stage", previous.Name, "timeout", readyDeadline)
+				return min(wakeStageReadyInterval, readyDeadline.Sub(now)), nil
+			}
+		} else {
+			log.Info("wake stage not ready before its timeout, starting the next stage", "stage", previous.Name)
+		}
+	}
+
+	sleepInfoData.CurrentOperationType = wakeUpOperation
+	resourceClient := r.resourceClient(ctx, log, sleepInfo, sleepInfoData)
+	resourceClient.WakeUpFilter = wakeStagesFilter(sleepInfo, progress.Done, due, false)
+	events := r.operationEvents(sleepInfo, wakeUpOperation)
+	resourceClient.Patched = events.resourcePatched
+	resourceClient.Failed = events.resourceFailed
+	resources, err := jsonpatch.NewResources(ctx, resourceClient, sleepInfo.Namespace, sleepInfoData.OriginalGenericResourceInfo, sleepInfoData.SleptResourceGenerations)
+	if err != nil {
+		return 0, fmt.Errorf("fails to get resources: %w", err)
+	}
+	operationStart := time.Now()
+	err = resources.WakeUp(ctx)
+	r.observeOperationDuration(sleepInfo, wakeUpOperation, operationStart)
+	if err != nil {
+		result := operationResult{operationType: wakeUpOperation, continued: true, err: err}
+		r.finishOperation(ctx, log, sleepInfo, events, result, now)
+		return 0, fmt.Errorf("fails to handle wake up: %w", err)
+	}
+	for _, stage := range stages[progress.Done:due] {
+		log.Info("wake stage executed", "stage", stage.Name, "delay", stage.Delay)
+	}
+
+	progress.Done = due
+	progress.StageAt = now
+	sleepInfoData.WakeStages = &progress
+	if progress.Done == len(stages) {
+		sleepInfoData.WakeStages = nil
+	}
+	if err := r.upsertSecret(ctx, log, now, getSecretName(sleepInfo.Name), sleepInfo.Namespace, sleepInfo, secret, sleepInfoData, resources); err != nil {
+		return 0, fmt.Errorf("fails to update secret: %w", err)
+	}
+	result := operationResult{operationType: wakeUpOperation, continued: true, wakeStagesPending: sleepInfoData.WakeStages != nil}
+	r.finishOperation(ctx, log, sleepInfo, events, result, now)
+	return nextWakeStageIn(stages, progress, now), nil
+}
+
+// isWakeStageReady returns whether the resources woken up by a stage are ready
+func (r *SleepInfoReconciler) isWakeStageReady(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, stage int) (bool, error) {
+	return r.areResourcesReady(ctx, sleepInfo, func(target kubegreenv1alpha1.PatchTarget, res unstructured.Unstructured) bool {
+		return sleepInfo.GetWakeStage(target, res.GetName(), res.GetLabels()) == stage
+	})
+}
+
+// areResourcesReady returns whether the resources of the wake up patch targets matching match are ready
+func (r *SleepInfoReconciler) areResourcesReady(ctx context.Context, sleepInfo *kubegreenv1alpha1.SleepInfo, match func(kubegreenv1alpha1.PatchTarget, unstructured.Unstructured) bool) (bool, error) {
+	wakeUp := r.resourceClient(ctx, logr.Discard(), sleepInfo, SleepInfoData{CurrentOperationType: wakeUpOperation}).SleepInfo
+	seenTargets := map[kubegreenv1alpha1.PatchTarget]struct{}{}
+	for _, patchData := range wakeUp.GetPatches() {
+		if _, exists := seenTargets[patchData.Target]; exists {
+			continue
+		}
+		seenTargets[patchData.Target] = struct{}{}
+
+		restMapping, err := r.Client.RESTMapper().RESTMapping(patchData.Target.GroupKind())
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		resourceList := &unstructured.UnstructuredList{}
+		resourceList.SetGroupVersionKind(restMapping.GroupVersionKind)
+		if err := r.List(ctx, resourceList, client.InNamespace(sleepInfo.Namespace)); err != nil {
+			return false, err
+		}
+		for _, item := range resourceList.Items {
+			if match(patchData.Target, item) && !isResourceReady(item) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// isResourceReady returns whether a woken up resource is ready: all its replicas are ready for the
+// resources with replicas, otherwise its Ready condition is true or its phase is running. The
+// resources without any of them have nothing to wait for.
+func isResourceReady(res unstructured.Unstructured) bool {
+	if replicas, found, _ := unstructured.NestedInt64(res.Object, "spec", "replicas"); found {
+		readyReplicas, _, _ := unstructured.NestedInt64(res.Object, "status", "readyReplicas")
+		return readyReplicas >= replicas
+	}
+	conditions, _, _ := unstructured.NestedSlice(res.Object, "status", "conditions")
+	for _, item := range conditions {
+		if condition, ok := item.(map[string]interface{}); ok && condition["type"] == "Ready" {
+			return condition["status"] == "True"
+		}
+	}
+	if phase, found, _ := unstructured.NestedString(res.Object, "status", "phase"); found {
+		return strings.EqualFold(phase, "running") || strings.EqualFold(phase, "ready")
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package sleepinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	"github.com/kube-green/kube-green/internal/controller/sleepinfo/jsonpatch"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestWakeStages(t *testing.T) {
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			WakeStages: []kubegreenv1alpha1.WakeStage{
+				{Name: "databases", Targets: []kubegreenv1alpha1.FilterRef{{Kind: "StatefulSet"}}},
+				{Name: "poolers", Targets: []kubegreenv1alpha1.FilterRef{{MatchLabels: map[string]string{"tier": "pooler"}}}, Delay: "5m"},
+				{Name: "apps", Targets: []kubegreenv1alpha1.FilterRef{{Kind: "Deployment"}}, Delay: "7m"},
+			},
+		},
+	}
+	startedAt := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	resource := func(name string, labels map[string]string) unstructured.Unstructured {
+		res := unstructured.Unstructured{}
+		res.SetName(name)
+		res.SetLabels(labels)
+		return res
+	}
+
+	t.Run("due stages", func(t *testing.T) {
+		stages := sleepInfo.Spec.WakeStages
+		require.Equal(t, 1, dueWakeStages(stages, 0, startedAt, startedAt))
+		require.Equal(t, 1, dueWakeStages(stages, 0, startedAt, startedAt.Add(4*time.Minute)))
+		require.Equal(t, 2, dueWakeStages(stages, 0, startedAt, startedAt.Add(5*time.Minute)))
+		require.Equal(t, 3, dueWakeStages(stages, 0, startedAt, startedAt.Add(time.Hour)))
+	})
+
+	t.Run("next stage", func(t *testing.T) {
+		stages := sleepInfo.Spec.WakeStages
+		require.Equal(t, 3*time.Minute, nextWakeStageIn(stages, WakeStagesProgress{StartedAt: startedAt, Done: 1}, startedAt.Add(2*time.Minute)))
+		require.Equal(t, time.Duration(0), nextWakeStageIn(stages, WakeStagesProgress{StartedAt: startedAt, Done: 3}, startedAt.Add(8*time.Minute)))
+	})
+
+	t.Run("start wakes up the resources without stage and the stages without delay", func(t *testing.T) {
+		filter, progress := startWakeStages(sleepInfo, startedAt)
+		require.Equal(t, &WakeStagesProgress{StartedAt: startedAt, Done: 1, StageAt: startedAt}, progress)
+		require.True(t, filter(kubegreenv1alpha1.StatefulSetTarget, resource("db", nil)))
+		require.True(t, filter(kubegreenv1alpha1.CronJobTarget, resource("report", nil)))
+		require.False(t, filter(kubegreenv1alpha1.DeploymentTarget, resource("pooler", map[string]string{"tier": "pooler"})))
+		require.False(t, filter(kubegreenv1alpha1.DeploymentTarget, resource("api", nil)))
+	})
+
+	t.Run("pending stages wake up only their resources", func(t *testing.T) {
+		filter := wakeStagesFilter(sleepInfo, 1, 2, false)
+		require.True(t, filter(kubegreenv1alpha1.DeploymentTarget, resource("pooler", map[string]string{"tier": "pooler"})))
+		require.False(t, filter(kubegreenv1alpha1.StatefulSetTarget, resource("db", nil)))
+		require.False(t, filter(kubegreenv1alpha1.CronJobTarget, resource("report", nil)))
+		require.False(t, filter(kubegreenv1alpha1.DeploymentTarget, resource("api", nil)))
+	})
+
+	t.Run("without stages", func(t *testing.T) {
+		filter, progress := startWakeStages(&kubegreenv1alpha1.SleepInfo{}, startedAt)
+		require.Nil(t, filter)
+		require.Nil(t, progress)
+	})
+
+	t.Run("strimzi wakes up the kafka clusters before the applications", func(t *testing.T) {
+		strimzi := &kubegreenv1alpha1.SleepInfo{Spec: kubegreenv1alpha1.SleepInfoSpec{SuspendStrimzi: getPtr(true)}}
+		filter, progress := startWakeStages(strimzi, startedAt)
+		require.Equal(t, &WakeStagesProgress{StartedAt: startedAt, Done: 2, StageAt: startedAt}, progress)
+		require.True(t, filter(kubegreenv1alpha1.StrimziKafkaNodePoolTarget, resource("brokers", nil)))
+		require.True(t, filter(kubegreenv1alpha1.StrimziKafkaTarget, resource("kafka", nil)))
+		require.True(t, filter(kubegreenv1alpha1.CronJobTarget, resource("report", nil)))
+		require.False(t, filter(kubegreenv1alpha1.StrimziKafkaConnectTarget, resource("connect", nil)))
+		require.False(t, filter(kubegreenv1alpha1.DeploymentTarget, resource("api", nil)))
+		require.Equal(t, wakeStageReadyInterval, nextWakeStageIn(strimzi.GetWakeStages(), *progress, startedAt))
+	})
+}
+
+func TestWakeUpPendingStages(t *testing.T) {
+	namespace := "my-namespace"
+	startedAt := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(0))},
+		}
+	}
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			WakeStages: []kubegreenv1alpha1.WakeStage{
+				{Name: "backend", Targets: []kubegreenv1alpha1.FilterRef{{Name: "backend"}}, Delay: "5m"},
+				{Name: "frontend", Targets: []kubegreenv1alpha1.FilterRef{{Name: "frontend"}}, Delay: "10m"},
+			},
+		},
+	}
+	secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: getSecretName(sleepInfo.Name), Namespace: namespace}}
+	fakeClient := fakeDeploymentClient(deployment("backend"), deployment("frontend"), deployment("other"), secret)
+	r := SleepInfoReconciler{
+		Client:      fakeClient,
+		Log:         zap.New(zap.UseDevMode(true)),
+		ManagerName: testFieldManagerName,
+	}
+	data := SleepInfoData{
+		CurrentOperationType: sleepOperation,
+		OriginalGenericResourceInfo: map[string]jsonpatch.RestorePatches{
+			kubegreenv1alpha1.DeploymentTarget.String(): {
+				"backend":  `{"spec":{"replicas":2}}`,
+				"frontend": `{"spec":{"replicas":3}}`,
+				"other":    `{"spec":{"replicas":1}}`,
+			},
+		},
+		WakeStages: &WakeStagesProgress{StartedAt: startedAt},
+	}
+	replicas := func(name string) int32 {
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, res))
+		return *res.Spec.Replicas
+	}
+
+	t.Run("waits for the delay of the next stage", func(t *testing.T) {
+		next, err := r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 3*time.Minute, next)
+		require.Equal(t, int32(0), replicas("backend"))
+	})
+
+	t.Run("wakes up the due stage", func(t *testing.T) {
+		next, err := r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(6*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, 4*time.Minute, next)
+		require.Equal(t, int32(2), replicas("backend"))
+		require.Equal(t, int32(0), replicas("frontend"))
+		require.Equal(t, int32(0), replicas("other"))
+
+		updated := &v1.Secret{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(secret), updated))
+		progress, err := getWakeStagesProgress(updated.Data[wakeStagesDataKey])
+		require.NoError(t, err)
+		require.Equal(t, &WakeStagesProgress{StartedAt: startedAt, Done: 1, StageAt: startedAt.Add(6 * time.Minute)}, progress)
+	})
+}
+
+func TestWakeStageReadiness(t *testing.T) {
+	namespace := "my-namespace"
+	startedAt := time.Date(2024, 1, 1, 7, 0, 0, 0, time.UTC)
+	sleepInfo := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "sleep", Namespace: namespace},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{
+			WakeStages: []kubegreenv1alpha1.WakeStage{
+				{Name: "backend", Targets: []kubegreenv1alpha1.FilterRef{{Name: "backend"}}, WaitForReady: true, ReadyTimeout: "5m"},
+				{Name: "frontend", Targets: []kubegreenv1alpha1.FilterRef{{Name: "frontend"}}},
+			},
+		},
+	}
+
+	t.Run("a stage waiting for readiness ends the stages to execute", func(t *testing.T) {
+		stages := sleepInfo.Spec.WakeStages
+		require.Equal(t, 1, dueWakeStages(stages, 0, startedAt, startedAt))
+		require.Equal(t, 2, dueWakeStages(stages, 1, startedAt, startedAt))
+		require.Equal(t, wakeStageReadyInterval, nextWakeStageIn(stages, WakeStagesProgress{StartedAt: startedAt, Done: 1}, startedAt))
+	})
+
+	t.Run("resource readiness", func(t *testing.T) {
+		resource := func(object map[string]interface{}) unstructured.Unstructured {
+			return unstructured.Unstructured{Object: object}
+		}
+		require.False(t, isResourceReady(resource(map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2)}})))
+		require.False(t, isResourceReady(resource(map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"readyReplicas": int64(1)},
+		})))
+		require.True(t, isResourceReady(resource(map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": int64(2)},
+			"status": map[string]interface{}{"readyReplicas": int64(2)},
+		})))
+		require.False(t, isResourceReady(resource(map[string]interface{}{
+			"status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "False"}}},
+		})))
+		require.True(t, isResourceReady(resource(map[string]interface{}{
+			"status": map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}}},
+		})))
+		require.False(t, isResourceReady(resource(map[string]interface{}{"status": map[string]interface{}{"phase": "Starting"}})))
+		require.True(t, isResourceReady(resource(map[string]interface{}{"status": map[string]interface{}{"phase": "Running"}})))
+		require.True(t, isResourceReady(resource(map[string]interface{}{"metadata": map[string]interface{}{"name": "no-status"}})))
+	})
+
+	t.Run("the next stage waits for the previous one to be ready", func(t *testing.T) {
+		backend := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(2))},
+		}
+		frontend := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(0))},
+		}
+		secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: getSecretName(sleepInfo.Name), Namespace: namespace}}
+		fakeClient := fakeDeploymentClient(backend, frontend, secret)
+		r := SleepInfoReconciler{
+			Client:      fakeClient,
+			Log:         zap.New(zap.UseDevMode(true)),
+			ManagerName: testFieldManagerName,
+		}
+		data := SleepInfoData{
+			CurrentOperationType: sleepOperation,
+			OriginalGenericResourceInfo: map[string]jsonpatch.RestorePatches{
+				kubegreenv1alpha1.DeploymentTarget.String(): {"frontend": `{"spec":{"replicas":3}}`},
+			},
+			WakeStages: &WakeStagesProgress{StartedAt: startedAt, Done: 1, StageAt: startedAt},
+		}
+		frontendReplicas := func() int32 {
+			res := &appsv1.Deployment{}
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(frontend), res))
+			return *res.Spec.Replicas
+		}
+
+		next, err := r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, wakeStageReadyInterval, next)
+		require.Equal(t, int32(0), frontendReplicas())
+
+		next, err = r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(4*time.Minute+50*time.Second))
+		require.NoError(t, err)
+		require.Equal(t, 10*time.Second, next, "the wait ends at the ready timeout")
+
+		backend.Status.ReadyReplicas = 2
+		require.NoError(t, fakeClient.Status().Update(context.Background(), backend))
+		next, err = r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), next)
+		require.Equal(t, int32(3), frontendReplicas())
+	})
+
+	t.Run("the next stage starts once the ready timeout is over", func(t *testing.T) {
+		frontend := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(0))},
+		}
+		backend := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Replicas: getPtr(int32(2))},
+		}
+		secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: getSecretName(sleepInfo.Name), Namespace: namespace}}
+		fakeClient := fakeDeploymentClient(backend, frontend, secret)
+		r := SleepInfoReconciler{
+			Client:      fakeClient,
+			Log:         zap.New(zap.UseDevMode(true)),
+			ManagerName: testFieldManagerName,
+		}
+		data := SleepInfoData{
+			CurrentOperationType: sleepOperation,
+			OriginalGenericResourceInfo: map[string]jsonpatch.RestorePatches{
+				kubegreenv1alpha1.DeploymentTarget.String(): {"frontend": `{"spec":{"replicas":3}}`},
+			},
+			WakeStages: &WakeStagesProgress{StartedAt: startedAt, Done: 1, StageAt: startedAt},
+		}
+
+		next, err := r.wakeUpPendingStages(context.Background(), r.Log, sleepInfo, secret, data, startedAt.Add(6*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), next)
+		res := &appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(frontend), res))
+		require.Equal(t, int32(3), *res.Spec.Replicas)
+	})
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+*/
+
+package tenantschedule
+
+import (
+	"context"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// ScheduleApplier applies a TenantSchedule to the namespaces of its tenant, returning the namespaces
+// where its SleepInfo is applied. It is implemented by the ScheduleService of the REST API, so a
+// TenantSchedule creates the same SleepInfos as the equivalent API request.
+type ScheduleApplier interface {
+	ApplyTenantSchedule(ctx context.Context, tenantSchedule *kubegreenv1alpha1.TenantSchedule) ([]string, error)
+}
+
+// TenantScheduleReconciler reconciles a TenantSchedule object, fanning it out into the SleepInfos of
+// the namespaces of its tenant
+type TenantScheduleReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// Recorder, when set, records an Event on the TenantSchedule when it fails to be applied
+	Recorder record.EventRecorder
+	// Applier creates, updates and deletes the SleepInfos of the TenantSchedule
+	Applier ScheduleApplier
+}
+
+// +kubebuilder:rbac:groups=kube-green.com,resources=tenantschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kube-green.com,resources=tenantschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=kube-green.com,resources=sleepinfos,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+func (r *TenantScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.Log.WithValues("tenantschedule", req.Name)
+
+	tenantSchedule := &kubegreenv1alpha1.TenantSchedule{}
+	if err := r.Get(ctx, req.NamespacedName, tenantSchedule); err != nil {
+		// The SleepInfos are deleted by the garbage collector through their owner reference
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !tenantSchedule.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, applyErr := r.Applier.ApplyTenantSchedule(ctx, tenantSchedule)
+	if applyErr != nil {
+		log.Error(applyErr, "fails to apply TenantSchedule")
+		r.recordEvent(tenantSchedule, corev1.EventTypeWarning, "ApplyFailed", applyErr.Error())
+		namespaces = tenantSchedule.Status.Namespaces
+	}
+
+	if err := r.updateStatus(ctx, tenantSchedule, namespaces, applyErr); err != nil {
+		log.Error(err, "fails to update status")
+		return ctrl.Result{}, err
+	}
+	if applyErr == nil {
+		log.Info("TenantSchedule applied", "tenant", tenantSchedule.Spec.Tenant, "namespaces", len(namespaces))
+	}
+
+	return ctrl.Result{}, applyErr
+}
+
+// updateStatus sets the namespaces of the SleepInfos and the error of the apply. The observed
+// generation is only updated when the TenantSchedule is applied.
+func (r *TenantScheduleReconciler) updateStatus(ctx context.Context, tenantSchedule *kubegreenv1alpha1.TenantSchedule, namespaces []string, applyErr error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest := &kubegreenv1alpha1.TenantSchedule{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(tenantSchedule), latest); err != nil {
+			return err
+		}
+		latest.Status.Namespaces = namespaces
+		latest.Status.Error = ""
+		if applyErr != nil {
+			latest.Status.Error = applyErr.Error()
+		} else {
+			latest.Status.ObservedGeneration = tenantSchedule.Generation
+		}
+		return r.Status().Update(ctx, latest)
+	})
+}
+
+func (r *TenantScheduleReconciler) recordEvent(tenantSchedule *kubegreenv1alpha1.TenantSchedule, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(tenantSchedule, eventType, reason, message)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TenantScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kubegreenv1alpha1.TenantSchedule{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&kubegreenv1alpha1.SleepInfo{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("kubegreen-tenantschedule").
+		Complete(r)
+}
@@ -0,0 +1,187 @@
+package tenantschedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	apiv1 "github.com/kube-green/kube-green/internal/api/v1"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeApplier struct {
+	namespaces []string
+	err        error
+}
+
+func (f fakeApplier) ApplyTenantSchedule(context.Context, *kubegreenv1alpha1.TenantSchedule) ([]string, error) {
+	return f.namespaces, f.err
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newTenantSchedule() *kubegreenv1alpha1.TenantSchedule {
+	return &kubegreenv1alpha1.TenantSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "horario-laboral", UID: types.UID("tenant-schedule-uid"), Generation: 2},
+		Spec: kubegreenv1alpha1.TenantScheduleSpec{
+			Tenant:     "bdadevdat",
+			Off:        "22:00",
+			On:         "06:00",
+			Weekdays:   "1-5",
+			Namespaces: []string{"apps", "rocket"},
+		},
+	}
+}
+
+func TestReconcileStatus(t *testing.T) {
+	scheme := newScheme(t)
+
+	t.Run("sets the namespaces and the observed generation", func(t *testing.T) {
+		tenantSchedule := newTenantSchedule()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(tenantSchedule).WithObjects(tenantSchedule).Build()
+		r := TenantScheduleReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Applier: fakeApplier{namespaces: []string{"bdadevdat-apps", "bdadevdat-rocket"}}}
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tenantSchedule)})
+		require.NoError(t, err)
+
+		updated := &kubegreenv1alpha1.TenantSchedule{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tenantSchedule), updated))
+		require.Equal(t, kubegreenv1alpha1.TenantScheduleStatus{
+			Namespaces:         []string{"bdadevdat-apps", "bdadevdat-rocket"},
+			ObservedGeneration: 2,
+		}, updated.Status)
+	})
+
+	t.Run("reports the apply error keeping the namespaces", func(t *testing.T) {
+		tenantSchedule := newTenantSchedule()
+		tenantSchedule.Status = kubegreenv1alpha1.TenantScheduleStatus{Namespaces: []string{"bdadevdat-apps"}, ObservedGeneration: 1}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(tenantSchedule).WithObjects(tenantSchedule).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := TenantScheduleReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Recorder: recorder, Applier: fakeApplier{err: errors.New("invalid off time")}}
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tenantSchedule)})
+		require.EqualError(t, err, "invalid off time")
+
+		updated := &kubegreenv1alpha1.TenantSchedule{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tenantSchedule), updated))
+		require.Equal(t, kubegreenv1alpha1.TenantScheduleStatus{
+			Namespaces:         []string{"bdadevdat-apps"},
+			Error:              "invalid off time",
+			ObservedGeneration: 1,
+		}, updated.Status)
+		require.Equal(t, "Warning ApplyFailed invalid off time", <-recorder.Events)
+	})
+
+	t.Run("ignores a deleted TenantSchedule", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := TenantScheduleReconciler{Client: c, Log: logr.Discard(), Scheme: scheme, Applier: fakeApplier{err: errors.New("not called")}}
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "horario-laboral"}})
+		require.NoError(t, err)
+	})
+}
+
+func TestReconcileWithScheduleService(t *testing.T) {
+	scheme := newScheme(t)
+	tenantSchedule := newTenantSchedule()
+	namespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	unmanaged := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "horario-laboral",
+			Namespace:   "bdadevdat-rocket",
+			Annotations: map[string]string{"kube-green.stratio.com/schedule-name": "horario-laboral"},
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{Weekdays: "*", SleepTime: "20:00", WakeUpTime: "08:00"},
+	}
+	unselected := &kubegreenv1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "horario-laboral",
+			Namespace: "bdadevdat-intelligence",
+			Labels:    map[string]string{kubegreenv1alpha1.TenantScheduleLabel: "horario-laboral"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kubegreenv1alpha1.GroupVersion.String(),
+				Kind:       "TenantSchedule",
+				Name:       tenantSchedule.Name,
+				UID:        tenantSchedule.UID,
+				Controller: getPtr(true),
+			}},
+		},
+		Spec: kubegreenv1alpha1.SleepInfoSpec{Weekdays: "1-5", SleepTime: "22:00", WakeUpTime: "06:00"},
+	}
+
+	newReconciler := func(objects ...client.Object) (TenantScheduleReconciler, client.Client) {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&kubegreenv1alpha1.TenantSchedule{}, &kubegreenv1alpha1.SleepInfo{}).
+			WithObjects(objects...).
+			Build()
+		return TenantScheduleReconciler{
+			Client:  c,
+			Log:     logr.Discard(),
+			Scheme:  scheme,
+			Applier: apiv1.NewScheduleService(c, logr.Discard()),
+		}, c
+	}
+
+	t.Run("creates the controlled SleepInfos and deletes the unselected ones", func(t *testing.T) {
+		r, c := newReconciler(tenantSchedule.DeepCopy(), namespace("bdadevdat-apps"), namespace("bdadevdat-rocket"), namespace("bdadevdat-intelligence"), unselected.DeepCopy())
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tenantSchedule)})
+		require.NoError(t, err)
+
+		for _, ns := range []string{"bdadevdat-apps", "bdadevdat-rocket"} {
+			sleepInfo := &kubegreenv1alpha1.SleepInfo{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "horario-laboral", Namespace: ns}, sleepInfo))
+			require.Equal(t, "22:00", sleepInfo.Spec.SleepTime)
+			require.Equal(t, "06:00", sleepInfo.Spec.WakeUpTime)
+			require.Equal(t, "horario-laboral", sleepInfo.Labels[kubegreenv1alpha1.TenantScheduleLabel])
+			require.Equal(t, "horario-laboral", sleepInfo.Annotations["kube-green.stratio.com/schedule-name"])
+			require.True(t, metav1.IsControlledBy(sleepInfo, tenantSchedule))
+		}
+
+		err = c.Get(context.Background(), client.ObjectKeyFromObject(unselected), &kubegreenv1alpha1.SleepInfo{})
+		require.True(t, apierrors.IsNotFound(err))
+
+		updated := &kubegreenv1alpha1.TenantSchedule{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(tenantSchedule), updated))
+		require.Equal(t, []string{"bdadevdat-apps", "bdadevdat-rocket"}, updated.Status.Namespaces)
+		require.Empty(t, updated.Status.Error)
+	})
+
+	t.Run("leaves a schedule of the same name not created by the TenantSchedule", func(t *testing.T) {
+		r, c := newReconciler(tenantSchedule.DeepCopy(), namespace("bdadevdat-apps"), namespace("bdadevdat-rocket"), unmanaged.DeepCopy())
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tenantSchedule)})
+		require.ErrorContains(t, err, "already exists in namespace 'bdadevdat-rocket'")
+
+		sleepInfo := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(unmanaged), sleepInfo))
+		require.Equal(t, unmanaged.Spec, sleepInfo.Spec)
+		require.False(t, metav1.IsControlledBy(sleepInfo, tenantSchedule))
+	})
+}
+
+func getPtr[T any](item T) *T {
+	return &item
+}
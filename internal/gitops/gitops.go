@@ -0,0 +1,369 @@
+// Package gitops commits files to a Git repository through the GitHub REST API (github.com or GitHub
+// Enterprise), for the clusters where Flux or Argo CD applies the manifests of the repository. No git
+// binary or clone is needed: every write is a commit of the contents API.
+package gitops
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAPIURL is the GitHub REST API of github.com
+	DefaultAPIURL = "https://api.github.com"
+	// PullRequestTitle is the title of the pull requests opened by kube-green
+	PullRequestTitle = "kube-green schedule changes"
+
+	defaultTimeout = 30 * time.Second
+	// maxResponseSize is the largest response read from the API
+	maxResponseSize = 1 << 22
+	// writeAttempts is the number of attempts of a write whose file changed meanwhile
+	writeAttempts = 3
+)
+
+var (
+	// ErrNotFound is returned when a file is not in the repository
+	ErrNotFound = errors.New("file not found in the GitOps repository")
+	// ErrExists is returned when creating a file already in the repository
+	ErrExists = errors.New("file already exists in the GitOps repository")
+)
+
+// Repository writes the files of a directory of a GitHub repository
+type Repository struct {
+	// APIURL of GitHub, DefaultAPIURL when empty. GitHub Enterprise serves it at https://{host}/api/v3
+	APIURL string
+	// Repository is the owner/name of the repository
+	Repository string
+	// Branch receives the commits, the default branch of the repository when empty
+	Branch string
+	// PullRequestBase, when set, makes the commits go to Branch, created from PullRequestBase when missing,
+	// and opens a pull request of Branch into PullRequestBase unless one is open already
+	PullRequestBase string
+	// Path is the directory of the files in the repository, the root when empty
+	Path string
+	// TokenFile is read on every request and sent as bearer token, when set
+	TokenFile string
+	// HTTPClient defaults to a client with a 30s timeout
+	HTTPClient *http.Client
+
+	// mu serializes the writes, so the commits of concurrent requests do not conflict
+	mu sync.Mutex
+}
+
+// Validate returns an error when the repository is not configured correctly
+func (r *Repository) Validate() error {
+	if owner, name, ok := strings.Cut(r.Repository, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("invalid GitOps repository %q, expected owner/name", r.Repository)
+	}
+	if r.PullRequestBase != "" && (r.Branch == "" || r.Branch == r.PullRequestBase) {
+		return fmt.Errorf("the pull requests into %s need a different GitOps branch", r.PullRequestBase)
+	}
+	return nil
+}
+
+// String returns the repository, branch and path written
+func (r *Repository) String() string {
+	s := r.Repository
+	if r.Branch != "" {
+		s += "@" + r.Branch
+	}
+	return s + ":/" + strings.Trim(r.Path, "/")
+}
+
+type contentFile struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	SHA      string `json:"sha"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+// Read returns the content of a file of the directory
+func (r *Repository) Read(ctx context.Context, name string) ([]byte, error) {
+	file, err := r.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if file.Encoding != "base64" {
+		return nil, fmt.Errorf("fails to read %s: unsupported encoding %q", name, file.Encoding)
+	}
+	return base64.StdEncoding.DecodeString(file.Content)
+}
+
+// List returns the names of the files of a subdirectory of the directory, none when it does not exist
+func (r *Repository) List(ctx context.Context, dir string) ([]string, error) {
+	data, err := r.do(ctx, http.MethodGet, r.contentsPath(dir)+r.ref(), nil)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fails to list %s: %w", dir, err)
+	}
+	entries := []contentFile{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("fails to list %s: it is not a directory", dir)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Type == "file" {
+			names = append(names, entry.Name)
+		}
+	}
+	return names, nil
+}
+
+// Write commits a file of the directory, created or replaced
+func (r *Repository) Write(ctx context.Context, name string, content []byte, message string) error {
+	return r.write(ctx, name, content, message, false)
+}
+
+// Create commits a new file of the directory, ErrExists when it is in the repository already
+func (r *Repository) Create(ctx context.Context, name string, content []byte, message string) error {
+	return r.write(ctx, name, content, message, true)
+}
+
+func (r *Repository) write(ctx context.Context, name string, content []byte, message string, create bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.prepareBranch(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < writeAttempts; attempt++ {
+		var sha string
+		file, getErr := r.get(ctx, name)
+		switch {
+		case getErr == nil && create:
+			return ErrExists
+		case getErr == nil:
+			sha = file.SHA
+		case !errors.Is(getErr, ErrNotFound):
+			return getErr
+		}
+		request := r.commitRequest(message)
+		request["content"] = base64.StdEncoding.EncodeToString(content)
+		if sha != "" {
+			request["sha"] = sha
+		}
+		_, err = r.do(ctx, http.MethodPut, r.contentsPath(name), request)
+		// The file changed between the read and the write: 409 when updated, 422 when created
+		if !isStatus(err, http.StatusConflict) && !isStatus(err, http.StatusUnprocessableEntity) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("fails to commit %s: %w", name, err)
+	}
+	return r.openPullRequest(ctx)
+}
+
+// Delete commits the removal of a file of the directory, ErrNotFound when it is not in the repository
+func (r *Repository) Delete(ctx context.Context, name string, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.prepareBranch(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 0; attempt < writeAttempts; attempt++ {
+		file, getErr := r.get(ctx, name)
+		if getErr != nil {
+			return getErr
+		}
+		request := r.commitRequest(message)
+		request["sha"] = file.SHA
+		_, err = r.do(ctx, http.MethodDelete, r.contentsPath(name), request)
+		if !isStatus(err, http.StatusConflict) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("fails to delete %s: %w", name, err)
+	}
+	return r.openPullRequest(ctx)
+}
+
+func (r *Repository) get(ctx context.Context, name string) (*contentFile, error) {
+	data, err := r.do(ctx, http.MethodGet, r.contentsPath(name)+r.ref(), nil)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fails to read %s: %w", name, err)
+	}
+	file := &contentFile{}
+	if err := json.Unmarshal(data, file); err != nil || file.Type != "file" {
+		return nil, fmt.Errorf("fails to read %s: it is not a file", name)
+	}
+	return file, nil
+}
+
+func (r *Repository) commitRequest(message string) map[string]interface{} {
+	request := map[string]interface{}{"message": message}
+	if r.Branch != "" {
+		request["branch"] = r.Branch
+	}
+	return request
+}
+
+// prepareBranch creates the branch of the pull requests from their base when it does not exist, e.g.
+// deleted once its last pull request was merged
+func (r *Repository) prepareBranch(ctx context.Context) error {
+	if r.PullRequestBase == "" {
+		return nil
+	}
+	_, err := r.do(ctx, http.MethodGet, r.repoPath("git/ref/heads/"+r.Branch), nil)
+	if !isStatus(err, http.StatusNotFound) {
+		return err
+	}
+	data, err := r.do(ctx, http.MethodGet, r.repoPath("git/ref/heads/"+r.PullRequestBase), nil)
+	if err != nil {
+		return fmt.Errorf("fails to read branch %s: %w", r.PullRequestBase, err)
+	}
+	base := struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}{}
+	if err := json.Unmarshal(data, &base); err != nil || base.Object.SHA == "" {
+		return fmt.Errorf("fails to read branch %s: invalid response %q", r.PullRequestBase, string(data))
+	}
+	_, err = r.do(ctx, http.MethodPost, r.repoPath("git/refs"), map[string]string{
+		"ref": "refs/heads/" + r.Branch,
+		"sha": base.Object.SHA,
+	})
+	if err != nil && !isStatus(err, http.StatusUnprocessableEntity) {
+		return fmt.Errorf("fails to create branch %s: %w", r.Branch, err)
+	}
+	return nil
+}
+
+// openPullRequest opens the pull request of the branch into its base, unless one is open already
+func (r *Repository) openPullRequest(ctx context.Context) error {
+	if r.PullRequestBase == "" {
+		return nil
+	}
+	owner, _, _ := strings.Cut(r.Repository, "/")
+	query := url.Values{"state": {"open"}, "head": {owner + ":" + r.Branch}, "base": {r.PullRequestBase}}
+	data, err := r.do(ctx, http.MethodGet, r.repoPath("pulls")+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("fails to list pull requests: %w", err)
+	}
+	pulls := []json.RawMessage{}
+	if err := json.Unmarshal(data, &pulls); err != nil {
+		return fmt.Errorf("fails to list pull requests: invalid response %q", string(data))
+	}
+	if len(pulls) > 0 {
+		return nil
+	}
+	_, err = r.do(ctx, http.MethodPost, r.repoPath("pulls"), map[string]string{
+		"title": PullRequestTitle,
+		"head":  r.Branch,
+		"base":  r.PullRequestBase,
+		"body":  "Schedules changed through the kube-green API. They are applied to the cluster once merged.",
+	})
+	if err != nil {
+		return fmt.Errorf("fails to open pull request: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) repoPath(p string) string {
+	return "/repos/" + r.Repository + "/" + p
+}
+
+func (r *Repository) contentsPath(name string) string {
+	escaped := []string{}
+	for _, segment := range strings.Split(path.Join(strings.Trim(r.Path, "/"), name), "/") {
+		if segment != "" {
+			escaped = append(escaped, url.PathEscape(segment))
+		}
+	}
+	return r.repoPath("contents/" + strings.Join(escaped, "/"))
+}
+
+func (r *Repository) ref() string {
+	if r.Branch == "" {
+		return ""
+	}
+	return "?ref=" + url.QueryEscape(r.Branch)
+}
+
+type statusError struct {
+	method string
+	path   string
+	status int
+	body   string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s %s returned unexpected status %d: %s", e.method, e.path, e.status, e.body)
+}
+
+func isStatus(err error, status int) bool {
+	statusErr := &statusError{}
+	return errors.As(err, &statusErr) && statusErr.status == status
+}
+
+func (r *Repository) do(ctx context.Context, method, p string, request interface{}) ([]byte, error) {
+	var body io.Reader
+	if request != nil {
+		content, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(content)
+	}
+	apiURL := r.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(apiURL, "/")+p, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "kube-green")
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.TokenFile != "" {
+		token, err := os.ReadFile(r.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("fails to read the GitOps token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &statusError{method: method, path: p, status: resp.StatusCode, body: strings.TrimSpace(string(data))}
+	}
+	return data, nil
+}
@@ -0,0 +1,191 @@
+package gitops
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHub serves the contents, refs and pulls API of a repository owner/repo
+type fakeGitHub struct {
+	mu       sync.Mutex
+	files    map[string]string // branch:path to content
+	branches map[string]string // branch to sha
+	pulls    []map[string]string
+	commits  []string
+	auth     string
+}
+
+func newFakeGitHub() *fakeGitHub {
+	return &fakeGitHub{files: map[string]string{}, branches: map[string]string{"main": "base-sha"}}
+}
+
+func (f *fakeGitHub) sha(content string) string {
+	return fmt.Sprintf("%x", len(content)) + "-" + base64.RawURLEncoding.EncodeToString([]byte(content))
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = r.Header.Get("Authorization")
+	body := map[string]string{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	p := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/")
+
+	switch {
+	case strings.HasPrefix(p, "contents/"):
+		file := strings.TrimPrefix(p, "contents/")
+		branch := r.URL.Query().Get("ref")
+		if branch == "" {
+			branch = body["branch"]
+		}
+		if branch == "" {
+			branch = "main"
+		}
+		key := branch + ":" + file
+		content, found := f.files[key]
+		switch r.Method {
+		case http.MethodGet:
+			if found {
+				writeJSON(w, http.StatusOK, map[string]string{"type": "file", "name": filepath.Base(file), "sha": f.sha(content),
+					"encoding": "base64", "content": base64.StdEncoding.EncodeToString([]byte(content))})
+				return
+			}
+			entries := []map[string]string{}
+			for k := range f.files {
+				if name, ok := strings.CutPrefix(k, key+"/"); ok && !strings.Contains(name, "/") {
+					entries = append(entries, map[string]string{"type": "file", "name": name})
+				}
+			}
+			if len(entries) == 0 {
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+				return
+			}
+			writeJSON(w, http.StatusOK, entries)
+		case http.MethodPut:
+			switch {
+			case found && body["sha"] != f.sha(content):
+				writeJSON(w, http.StatusConflict, map[string]string{"message": "sha does not match"})
+				return
+			case !found && body["sha"] != "":
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+				return
+			}
+			decoded, _ := base64.StdEncoding.DecodeString(body["content"])
+			f.files[key] = string(decoded)
+			f.commits = append(f.commits, branch+": "+body["message"])
+			writeJSON(w, http.StatusOK, map[string]string{})
+		case http.MethodDelete:
+			if !found || body["sha"] != f.sha(content) {
+				writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+				return
+			}
+			delete(f.files, key)
+			f.commits = append(f.commits, branch+": "+body["message"])
+			writeJSON(w, http.StatusOK, map[string]string{})
+		}
+	case strings.HasPrefix(p, "git/ref/heads/"):
+		sha, found := f.branches[strings.TrimPrefix(p, "git/ref/heads/")]
+		if !found {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"object": map[string]string{"sha": sha}})
+	case p == "git/refs":
+		f.branches[strings.TrimPrefix(body["ref"], "refs/heads/")] = body["sha"]
+		writeJSON(w, http.StatusCreated, map[string]string{})
+	case p == "pulls" && r.Method == http.MethodGet:
+		open := []map[string]string{}
+		for _, pull := range f.pulls {
+			if "owner:"+pull["head"] == r.URL.Query().Get("head") && pull["base"] == r.URL.Query().Get("base") {
+				open = append(open, pull)
+			}
+		}
+		writeJSON(w, http.StatusOK, open)
+	case p == "pulls":
+		f.pulls = append(f.pulls, body)
+		writeJSON(w, http.StatusCreated, map[string]string{})
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+
+	t.Run("commits to the branch", func(t *testing.T) {
+		github := newFakeGitHub()
+		server := httptest.NewServer(github)
+		defer server.Close()
+		repo := &Repository{APIURL: server.URL, Repository: "owner/repo", Path: "/clusters/dev/", TokenFile: tokenFile}
+		require.NoError(t, repo.Validate())
+
+		require.NoError(t, repo.Write(ctx, "tenant-apps/sleepinfo-a.yaml", []byte("a: 1\n"), "add a"))
+		require.NoError(t, repo.Write(ctx, "tenant-apps/sleepinfo-a.yaml", []byte("a: 2\n"), "update a"))
+		require.Equal(t, "a: 2\n", github.files["main:clusters/dev/tenant-apps/sleepinfo-a.yaml"])
+		require.Equal(t, []string{"main: add a", "main: update a"}, github.commits)
+		require.Equal(t, "Bearer secret", github.auth)
+
+		content, err := repo.Read(ctx, "tenant-apps/sleepinfo-a.yaml")
+		require.NoError(t, err)
+		require.Equal(t, "a: 2\n", string(content))
+
+		require.ErrorIs(t, repo.Create(ctx, "tenant-apps/sleepinfo-a.yaml", []byte("a: 3\n"), "create a"), ErrExists)
+		require.NoError(t, repo.Create(ctx, "tenant-apps/sleepinfo-b.yaml", []byte("b: 1\n"), "create b"))
+
+		names, err := repo.List(ctx, "tenant-apps")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"sleepinfo-a.yaml", "sleepinfo-b.yaml"}, names)
+		names, err = repo.List(ctx, "other")
+		require.NoError(t, err)
+		require.Empty(t, names)
+
+		require.NoError(t, repo.Delete(ctx, "tenant-apps/sleepinfo-b.yaml", "delete b"))
+		require.ErrorIs(t, repo.Delete(ctx, "tenant-apps/sleepinfo-b.yaml", "delete b"), ErrNotFound)
+		_, err = repo.Read(ctx, "tenant-apps/sleepinfo-b.yaml")
+		require.ErrorIs(t, err, ErrNotFound)
+		require.Empty(t, github.pulls)
+	})
+
+	t.Run("opens a pull request", func(t *testing.T) {
+		github := newFakeGitHub()
+		server := httptest.NewServer(github)
+		defer server.Close()
+		repo := &Repository{APIURL: server.URL + "/", Repository: "owner/repo", Branch: "kube-green", PullRequestBase: "main"}
+		require.NoError(t, repo.Validate())
+
+		require.NoError(t, repo.Write(ctx, "ns/sleepinfo-a.yaml", []byte("a: 1\n"), "add a"))
+		require.NoError(t, repo.Write(ctx, "ns/sleepinfo-b.yaml", []byte("b: 1\n"), "add b"))
+		require.Equal(t, "base-sha", github.branches["kube-green"])
+		require.Equal(t, []string{"kube-green: add a", "kube-green: add b"}, github.commits)
+		require.Len(t, github.pulls, 1)
+		require.Equal(t, "kube-green", github.pulls[0]["head"])
+		require.Equal(t, "main", github.pulls[0]["base"])
+		require.Empty(t, github.files["main:ns/sleepinfo-a.yaml"])
+	})
+
+	t.Run("validates the configuration", func(t *testing.T) {
+		require.Error(t, (&Repository{Repository: "repo"}).Validate())
+		require.Error(t, (&Repository{Repository: "owner/repo/extra"}).Validate())
+		require.Error(t, (&Repository{Repository: "owner/repo", PullRequestBase: "main"}).Validate())
+		require.Error(t, (&Repository{Repository: "owner/repo", Branch: "main", PullRequestBase: "main"}).Validate())
+	})
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2025.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DigestSecretName is the secret holding the operations of the week not sent yet in a digest
+	DigestSecretName = "kube-green-notifications-digest"
+	digestKey        = "digest.json"
+)
+
+// Data of the wake.executed events used by the digest to estimate the savings
+const (
+	DataSleptAt           = "sleptAt"           // Time of the sleep woken up, RFC3339
+	DataSavedCPU          = "savedCpu"          // CPU requests released by the sleep, as a quantity
+	DataSavedMemory       = "savedMemory"       // Memory requests released by the sleep, as a quantity
+	DataWakeStagesPending = "wakeStagesPending" // true when the wake up has stages left
+)
+
+// DigestConfig configures the weekly digest
+type DigestConfig struct {
+	Day           time.Weekday  // Day of the week the digest is sent, in UTC
+	Hour          int           // Hour of the day the digest is sent, in UTC
+	CheckInterval time.Duration // How often the digest is saved and checked for sending, default 1m
+	Mailer        Mailer        // Sends the digests
+}
+
+// digestStats are the operations executed in a namespace and the requests they released
+type digestStats struct {
+	Sleeps         int     `json:"sleeps"`
+	WakeUps        int     `json:"wakeUps"`
+	CPUCoreHours   float64 `json:"cpuCoreHours"`
+	MemoryGiBHours float64 `json:"memoryGiBHours"`
+}
+
+func (s *digestStats) add(other digestStats) {
+	s.Sleeps += other.Sleeps
+	s.WakeUps += other.WakeUps
+	s.CPUCoreHours += other.CPUCoreHours
+	s.MemoryGiBHours += other.MemoryGiBHours
+}
+
+type digestState struct {
+	Since    time.Time `json:"since"`
+	LastSent time.Time `json:"lastSent"`
+	// Tenants are the stats of the namespaces of each tenant
+	Tenants map[string]map[string]*digestStats `json:"tenants,omitempty"`
+}
+
+func (s *digestState) record(tenant, namespace string, stats digestStats) {
+	if s.Tenants == nil {
+		s.Tenants = map[string]map[string]*digestStats{}
+	}
+	if s.Tenants[tenant] == nil {
+		s.Tenants[tenant] = map[string]*digestStats{}
+	}
+	if s.Tenants[tenant][namespace] == nil {
+		s.Tenants[tenant][namespace] = &digestStats{}
+	}
+	s.Tenants[tenant][namespace].add(stats)
+}
+
+// Digest records the sleeps and wake ups executed, and emails every week to the digest subscriptions
+// the operations of their tenant with the CPU and memory hours released. It is a manager Runnable
+// run by the leader, the replica executing the operations. The operations not sent yet are saved in
+// the DigestSecretName secret, so they survive restarts.
+type Digest struct {
+	config    DigestConfig
+	client    client.Client
+	namespace string
+	store     subscriptionLister
+	log       logr.Logger
+
+	mu      sync.Mutex
+	state   digestState
+	pending digestState // Operations recorded before the state is loaded
+	loaded  bool
+	dirty   bool
+}
+
+// NewDigest creates a digest saved in namespace and sent to the digest subscriptions of store
+func NewDigest(c client.Client, namespace string, store subscriptionLister, log logr.Logger, config DigestConfig) *Digest {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = time.Minute
+	}
+	return &Digest{
+		config:    config,
+		client:    c,
+		namespace: namespace,
+		store:     store,
+		log:       log,
+	}
+}
+
+// Notify records the executed sleeps and wake ups, the other events are ignored
+func (d *Digest) Notify(event Event) {
+	if event.Tenant == "" {
+		return
+	}
+	stats := digestStats{}
+	switch event.Type {
+	case EventSleepExecuted:
+		stats.Sleeps = 1
+	case EventWakeExecuted:
+		if event.Data[DataWakeStagesPending] == "true" {
+			return
+		}
+		stats.WakeUps = 1
+	default:
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if event.Type == EventWakeExecuted {
+		since := d.state.Since
+		if !d.loaded {
+			since = time.Time{}
+		}
+		stats.CPUCoreHours, stats.MemoryGiBHours = savedHours(event, since)
+	}
+	if !d.loaded {
+		d.pending.record(event.Tenant, event.Namespace, stats)
+		return
+	}
+	d.state.record(event.Tenant, event.Namespace, stats)
+	d.dirty = true
+}
+
+// savedHours returns the CPU core-hours and memory GiB-hours released by the sleep woken up by the
+// event, counted from since when it slept before
+func savedHours(event Event, since time.Time) (float64, float64) {
+	sleptAt, err := time.Parse(time.RFC3339, event.Data[DataSleptAt])
+	if err != nil {
+		return 0, 0
+	}
+	if sleptAt.Before(since) {
+		sleptAt = since
+	}
+	hours := event.Time.Sub(sleptAt).Hours()
+	if hours <= 0 {
+		return 0, 0
+	}
+	var cpu, memory float64
+	if q, err := resource.ParseQuantity(event.Data[DataSavedCPU]); err == nil {
+		cpu = q.AsApproximateFloat64() * hours
+	}
+	if q, err := resource.ParseQuantity(event.Data[DataSavedMemory]); err == nil {
+		memory = q.AsApproximateFloat64() / (1 << 30) * hours
+	}
+	return cpu, memory
+}
+
+// Start saves the recorded operations and sends the digests when due until ctx is done
+func (d *Digest) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.config.CheckInterval)
+	defer ticker.Stop()
+	d.check(ctx, time.Now().UTC())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			d.check(ctx, time.Now().UTC())
+		}
+	}
+}
+
+// NeedLeaderElection is true: the leader executes the operations and sends their digest
+func (d *Digest) NeedLeaderElection() bool {
+	return true
+}
+
+// check loads the state the first time, sends the digests when due and saves the state when changed
+func (d *Digest) check(ctx context.Context, now time.Time) {
+	if err := d.load(ctx, now); err != nil {
+		d.log.Error(err, "failed to load notifications digest")
+		return
+	}
+
+	d.mu.Lock()
+	due := d.state.LastSent.Before(lastDigestTime(now, d.config.Day, d.config.Hour))
+	d.mu.Unlock()
+	if due {
+		d.send(ctx, now)
+	}
+
+	d.mu.Lock()
+	if !d.dirty {
+		d.mu.Unlock()
+		return
+	}
+	data, err := json.Marshal(d.state)
+	d.dirty = false
+	d.mu.Unlock()
+	if err == nil {
+		err = d.save(ctx, data)
+	}
+	if err != nil {
+		d.log.Error(err, "failed to save notifications digest")
+		d.mu.Lock()
+		d.dirty = true
+		d.mu.Unlock()
+	}
+}
+
+// lastDigestTime returns the last time the digest was due at or before now
+func lastDigestTime(now time.Time, day time.Weekday, hour int) time.Time {
+	now = now.UTC()
+	at := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	for at.Weekday() != day || at.After(now) {
+		at = at.AddDate(0, 0, -1)
+	}
+	return at
+}
+
+// send emails the digest of the recorded operations to the digest subscriptions and starts a new one
+func (d *Digest) send(ctx context.Context, now time.Time) {
+	subs, err := d.store.List(ctx)
+	if err != nil {
+		d.log.Error(err, "failed to list notification subscriptions for the digest")
+		return
+	}
+
+	d.mu.Lock()
+	state := d.state
+	d.state = digestState{Since: now, LastSent: now}
+	d.dirty = true
+	d.mu.Unlock()
+
+	for _, sub := range subs {
+		if !sub.Digest {
+			continue
+		}
+		if d.config.Mailer == nil {
+			d.log.Info("email notifications not configured, skipping digest subscription", "subscription", sub.ID)
+			continue
+		}
+		tenants := []string{sub.Tenant}
+		if sub.Tenant == "" {
+			tenants = sortedKeys(state.Tenants)
+		}
+		for _, tenant := range tenants {
+			subject, body := digestEmail(tenant, state.Tenants[tenant], state.Since, now)
+			if err := d.config.Mailer.Send(ctx, sub.Emails, subject, body); err != nil {
+				d.log.Error(err, "failed to email digest", "subscription", sub.ID, "tenant", tenant)
+			}
+		}
+	}
+}
+
+// digestEmail returns the subject and the body of the digest of the tenant
+func digestEmail(tenant string, namespaces map[string]*digestStats, since, now time.Time) (string, string) {
+	total := digestStats{}
+	for _, stats := range namespaces {
+		total.add(*stats)
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "kube-green weekly digest of tenant %s\n", tenant)
+	fmt.Fprintf(&body, "From %s to %s\n\n", since.UTC().Format("2006-01-02 15:04 MST"), now.UTC().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&body, "Sleeps: %d\n", total.Sleeps)
+	fmt.Fprintf(&body, "Wake ups: %d\n", total.WakeUps)
+	fmt.Fprintf(&body, "Estimated savings: %.2f CPU core-hours, %.2f GiB-hours of memory\n", total.CPUCoreHours, total.MemoryGiBHours)
+	if len(namespaces) > 0 {
+		body.WriteString("\n")
+		w := tabwriter.NewWriter(&body, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAMESPACE\tSLEEPS\tWAKE UPS\tCPU CORE-HOURS\tMEMORY GIB-HOURS")
+		for _, namespace := range sortedKeys(namespaces) {
+			stats := namespaces[namespace]
+			fmt.Fprintf(w, "%s\t%d\t%d\t%.2f\t%.2f\n", namespace, stats.Sleeps, stats.WakeUps, stats.CPUCoreHours, stats.MemoryGiBHours)
+		}
+		_ = w.Flush()
+	}
+	return "[kube-green] Weekly digest: " + tenant, body.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// load reads the saved state once, adding the operations recorded before. Without a saved state, the
+// first digest is sent at the next due time.
+func (d *Digest) load(ctx context.Context, now time.Time) error {
+	d.mu.Lock()
+	loaded := d.loaded
+	d.mu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	state := digestState{Since: now, LastSent: now}
+	secret := &v1.Secret{}
+	err := d.client.Get(ctx, client.ObjectKey{Name: DigestSecretName, Namespace: d.namespace}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return fmt.Errorf("failed to get secret %s: %w", DigestSecretName, err)
+	case len(secret.Data[digestKey]) > 0:
+		if err := json.Unmarshal(secret.Data[digestKey], &state); err != nil {
+			return fmt.Errorf("invalid %s in secret %s: %w", digestKey, DigestSecretName, err)
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for tenant, namespaces := range d.pending.Tenants {
+		for namespace, stats := range namespaces {
+			state.record(tenant, namespace, *stats)
+		}
+	}
+	d.state = state
+	d.pending = digestState{}
+	d.loaded = true
+	d.dirty = true
+	return nil
+}
+
+func (d *Digest) save(ctx context.Context, data []byte) error {
+	secret := &v1.Secret{}
+	err := d.client.Get(ctx, client.ObjectKey{Name: DigestSecretName, Namespace: d.namespace}, secret)
+	if apierrors.IsNotFound(err) {
+		secret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      DigestSecretName,
+				Namespace: d.namespace,
+				Labels:    map[string]string{"app.kubernetes.io/managed-by": "kube-green"},
+			},
+			Type: v1.SecretTypeOpaque,
+			Data: map[string][]byte{digestKey: data},
+		}
+		return d.client.Create(ctx, secret)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", DigestSecretName, err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[digestKey] = data
+	return d.client.Update(ctx, secret)
+}
+
+// Notifiers sends the events to each notifier
+type Notifiers []Notifier
+
+// Notify sends the event to the notifiers
+func (n Notifiers) Notify(event Event) {
+	for _, notifier := range n {
+		notifier.Notify(event)
+	}
+}
+
+// ParseWeekday parses the English name of a day of the week, case insensitive
+func ParseWeekday(value string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), value) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day of the week %q", value)
+}
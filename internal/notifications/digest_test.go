@@ -0,0 +1,151 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLastDigestTime(t *testing.T) {
+	// 2026-03-02 is a Monday
+	monday := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+	require.Equal(t, monday, lastDigestTime(monday, time.Monday, 8))
+	require.Equal(t, monday, lastDigestTime(monday.Add(50*time.Hour), time.Monday, 8))
+	require.Equal(t, monday.AddDate(0, 0, -7), lastDigestTime(monday.Add(-time.Minute), time.Monday, 8))
+	require.Equal(t, monday.AddDate(0, 0, -3).Add(-8*time.Hour), lastDigestTime(monday, time.Friday, 0))
+}
+
+func TestDigest(t *testing.T) {
+	since := time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+	saved := func(c client.Client) digestState {
+		secret := &v1.Secret{}
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: DigestSecretName, Namespace: "kube-green"}, secret))
+		state := digestState{}
+		require.NoError(t, json.Unmarshal(secret.Data[digestKey], &state))
+		return state
+	}
+	wake := func(namespace string, at time.Time, data map[string]string) Event {
+		return Event{Type: EventWakeExecuted, Time: at, Tenant: "bdadevdat", Namespace: namespace, Data: data}
+	}
+
+	t.Run("records the operations and estimates the savings", func(t *testing.T) {
+		c := fake.NewClientBuilder().Build()
+		digest := NewDigest(c, "kube-green", staticSubscriptions{}, logr.Discard(), DigestConfig{Day: time.Monday, Hour: 8})
+
+		// Recorded before the state is loaded
+		digest.Notify(Event{Type: EventSleepExecuted, Time: since.Add(12 * time.Hour), Tenant: "bdadevdat", Namespace: "bdadevdat-apps"})
+		digest.check(context.Background(), since.Add(12*time.Hour))
+
+		digest.Notify(wake("bdadevdat-apps", since.Add(24*time.Hour), map[string]string{
+			DataSleptAt:     since.Add(12 * time.Hour).Format(time.RFC3339),
+			DataSavedCPU:    "1500m",
+			DataSavedMemory: "2Gi",
+		}))
+		// Slept before the digest: counted since its start
+		digest.Notify(wake("bdadevdat-data", since.Add(36*time.Hour), map[string]string{
+			DataSleptAt:  since.Format(time.RFC3339),
+			DataSavedCPU: "1",
+		}))
+		digest.Notify(wake("bdadevdat-data", since.Add(3*time.Hour), map[string]string{DataWakeStagesPending: "true"}))
+		digest.Notify(Event{Type: EventScheduleCreated, Tenant: "bdadevdat"})
+		digest.Notify(Event{Type: EventSleepExecuted, Time: since.Add(time.Hour)})
+		digest.check(context.Background(), since.Add(36*time.Hour))
+
+		state := saved(c)
+		require.Equal(t, since.Add(12*time.Hour), state.Since)
+		require.Equal(t, map[string]map[string]*digestStats{
+			"bdadevdat": {
+				"bdadevdat-apps": {Sleeps: 1, WakeUps: 1, CPUCoreHours: 18, MemoryGiBHours: 24},
+				"bdadevdat-data": {WakeUps: 1, CPUCoreHours: 24},
+			},
+		}, state.Tenants)
+	})
+
+	t.Run("emails the digest of each tenant when due", func(t *testing.T) {
+		state := digestState{
+			Since:    since,
+			LastSent: since,
+			Tenants: map[string]map[string]*digestStats{
+				"bdadevdat": {
+					"bdadevdat-apps": {Sleeps: 5, WakeUps: 5, CPUCoreHours: 60.25, MemoryGiBHours: 120},
+					"bdadevdat-data": {Sleeps: 5, WakeUps: 4, CPUCoreHours: 10, MemoryGiBHours: 20.5},
+				},
+				"bdadevprd": {
+					"bdadevprd-apps": {Sleeps: 1},
+				},
+			},
+		}
+		data, err := json.Marshal(state)
+		require.NoError(t, err)
+		c := fake.NewClientBuilder().WithObjects(&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: DigestSecretName, Namespace: "kube-green"},
+			Data:       map[string][]byte{digestKey: data},
+		}).Build()
+		mailer := &fakeMailer{}
+		subs := staticSubscriptions{
+			{ID: "events", Emails: []string{"events@example.com"}},
+			{ID: "tenant", Emails: []string{"ops@example.com"}, Tenant: "bdadevdat", Digest: true},
+			{ID: "global", Emails: []string{"admin@example.com"}, Digest: true},
+			{ID: "idle", Emails: []string{"idle@example.com"}, Tenant: "bdadevidl", Digest: true},
+		}
+		digest := NewDigest(c, "kube-green", subs, logr.Discard(), DigestConfig{Day: time.Monday, Hour: 8, Mailer: mailer})
+
+		digest.check(context.Background(), since.AddDate(0, 0, 7).Add(-time.Minute))
+		require.Empty(t, mailer.emails())
+
+		sentAt := since.AddDate(0, 0, 7).Add(time.Minute)
+		digest.check(context.Background(), sentAt)
+		emails := mailer.emails()
+		require.Len(t, emails, 4)
+		require.Equal(t, []string{"ops@example.com"}, emails[0].to)
+		require.Equal(t, "[kube-green] Weekly digest: bdadevdat", emails[0].subject)
+		require.Equal(t, `kube-green weekly digest of tenant bdadevdat
+From 2026-03-02 08:00 UTC to 2026-03-09 08:01 UTC
+
+Sleeps: 10
+Wake ups: 9
+Estimated savings: 70.25 CPU core-hours, 140.50 GiB-hours of memory
+
+NAMESPACE       SLEEPS  WAKE UPS  CPU CORE-HOURS  MEMORY GIB-HOURS
+bdadevdat-apps  5       5         60.25           120.00
+bdadevdat-data  5       4         10.00           20.50
+`, emails[0].body)
+		require.Equal(t, []string{"admin@example.com"}, emails[1].to)
+		require.Equal(t, emails[0].body, emails[1].body)
+		require.Equal(t, "[kube-green] Weekly digest: bdadevprd", emails[2].subject)
+		require.Equal(t, "[kube-green] Weekly digest: bdadevidl", emails[3].subject)
+		require.Contains(t, emails[3].body, "Sleeps: 0\n")
+
+		require.Equal(t, digestState{Since: sentAt, LastSent: sentAt}, saved(c))
+		digest.check(context.Background(), sentAt.Add(time.Hour))
+		require.Len(t, mailer.emails(), 4)
+	})
+}
+
+func TestNotifiers(t *testing.T) {
+	first, second := &fakeMailer{}, &fakeMailer{}
+	notifiers := Notifiers{
+		notifierFunc(func(event Event) { _ = first.Send(context.Background(), nil, event.Type, "") }),
+		notifierFunc(func(event Event) { _ = second.Send(context.Background(), nil, event.Type, "") }),
+	}
+
+	notifiers.Notify(Event{Type: EventSleepExecuted})
+
+	require.Len(t, first.emails(), 1)
+	require.Len(t, second.emails(), 1)
+}
+
+type notifierFunc func(Event)
+
+func (f notifierFunc) Notify(event Event) {
+	f(event)
+}
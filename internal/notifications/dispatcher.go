@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// DispatcherConfig configures the delivery of the events
+type DispatcherConfig struct {
+	Workers       int           // Concurrent deliveries, default 4
+	QueueSize     int           // Pending events, events are dropped when full. Default 1000
+	MaxRetries    int           // Retries after the first failed attempt, default 3. Negative disables retries
+	RetryBackoff  time.Duration // Wait before the first retry, doubled on each retry. Default 1s
+	Timeout       time.Duration // Timeout of each attempt, default 10s
+	CacheDuration time.Duration // How long the subscriptions are cached, default 30s
+	Mailer        Mailer        // Sends the events of the email subscriptions, skipped when nil
+}
+
+func (c DispatcherConfig) withDefaults() DispatcherConfig {
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.QueueSize <= 0 {
+		c.QueueSize = 1000
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = 3
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = time.Second
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.CacheDuration <= 0 {
+		c.CacheDuration = 30 * time.Second
+	}
+	return c
+}
+
+type subscriptionLister interface {
+	List(ctx context.Context) ([]Subscription, error)
+}
+
+// Dispatcher queues the events and POSTs or emails them to the matching subscriptions with retries.
+// It is a manager Runnable: events are delivered once Start is running.
+type Dispatcher struct {
+	config     DispatcherConfig
+	store      subscriptionLister
+	httpClient *http.Client
+	log        logr.Logger
+	queue      chan Event
+
+	mu        sync.Mutex
+	subs      []Subscription
+	subsUntil time.Time
+}
+
+// NewDispatcher creates a dispatcher reading the subscriptions from store
+func NewDispatcher(store subscriptionLister, log logr.Logger, config DispatcherConfig) *Dispatcher {
+	config = config.withDefaults()
+	return &Dispatcher{
+		config:     config,
+		store:      store,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		log:        log,
+		queue:      make(chan Event, config.QueueSize),
+	}
+}
+
+// Notify queues the event without blocking, dropping it when the queue is full
+func (d *Dispatcher) Notify(event Event) {
+	if event.ID == "" {
+		event.ID = newID()
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	select {
+	case d.queue <- event:
+	default:
+		d.log.Info("notification queue full, dropping event", "type", event.Type, "tenant", event.Tenant)
+	}
+}
+
+// Start delivers the queued events until ctx is done
+func (d *Dispatcher) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < d.config.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event := <-d.queue:
+					d.dispatch(ctx, event)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection is false: every replica delivers the events it produces
+func (d *Dispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Invalidate drops the cached subscriptions, e.g. after registering a new one
+func (d *Dispatcher) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subsUntil = time.Time{}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, event Event) {
+	subs, err := d.subscriptions(ctx)
+	if err != nil {
+		d.log.Error(err, "failed to list notification subscriptions", "type", event.Type)
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.log.Error(err, "failed to encode event", "type", event.Type)
+		return
+	}
+	for _, sub := range subs {
+		if !sub.Matches(event) {
+			continue
+		}
+		if len(sub.Emails) > 0 {
+			d.email(ctx, sub, event)
+			continue
+		}
+		subBody, err := sub.Body(event, body)
+		if err != nil {
+			d.log.Error(err, "failed to render notification", "subscription", sub.ID, "format", sub.Format, "type", event.Type)
+			continue
+		}
+		if err := d.deliver(ctx, sub, event, subBody); err != nil {
+			d.log.Error(err, "failed to deliver notification", "subscription", sub.ID, "url", sub.URL, "type", event.Type, "event", event.ID)
+		}
+	}
+}
+
+func (d *Dispatcher) subscriptions(ctx context.Context) ([]Subscription, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if time.Now().Before(d.subsUntil) {
+		return d.subs, nil
+	}
+	subs, err := d.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.subs = subs
+	d.subsUntil = time.Now().Add(d.config.CacheDuration)
+	return subs, nil
+}
+
+// email sends the event to the recipients of the subscription
+func (d *Dispatcher) email(ctx context.Context, sub Subscription, event Event) {
+	if d.config.Mailer == nil {
+		d.log.Info("email notifications not configured, skipping subscription", "subscription", sub.ID, "type", event.Type)
+		return
+	}
+	subject, body := eventEmail(event)
+	err := d.retry(ctx, func() (bool, error) {
+		err := d.config.Mailer.Send(ctx, sub.Emails, subject, body)
+		return isRetryableMailError(err), err
+	})
+	if err != nil {
+		d.log.Error(err, "failed to email notification", "subscription", sub.ID, "type", event.Type, "event", event.ID)
+	}
+}
+
+// deliver POSTs the event, retrying with exponential backoff on network errors, 429 and 5xx responses
+func (d *Dispatcher) deliver(ctx context.Context, sub Subscription, event Event, body []byte) error {
+	return d.retry(ctx, func() (bool, error) {
+		return d.post(ctx, sub, event, body)
+	})
+}
+
+// retry runs attempt until it succeeds, fails with a non retryable error or runs out of retries,
+// doubling the backoff between the attempts
+func (d *Dispatcher) retry(ctx context.Context, attempt func() (bool, error)) error {
+	backoff := d.config.RetryBackoff
+	var lastErr error
+	for i := 0; i <= d.config.MaxRetries; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		retryable, err := attempt()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return lastErr
+}
+
+func (d *Dispatcher) post(ctx context.Context, sub Subscription, event Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kube-green-notifier")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	if sub.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(sub.Secret, body))
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected status %d", resp.StatusCode)
+}
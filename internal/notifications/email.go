@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Keys of the SMTP settings secret
+const (
+	SMTPHostKey     = "host"
+	SMTPPortKey     = "port"     // Default 587
+	SMTPUsernameKey = "username" // Optional: PLAIN authentication
+	SMTPPasswordKey = "password"
+	SMTPFromKey     = "from"
+	SMTPTLSKey      = "tls" // starttls (the default), tls for implicit TLS, or none
+)
+
+// TLS modes of the SMTP connection
+const (
+	SMTPStartTLS = "starttls"
+	SMTPTLS      = "tls"
+	SMTPNoTLS    = "none"
+)
+
+// errInvalidSMTPSettings marks the errors of the settings, the emails are not retried
+var errInvalidSMTPSettings = errors.New("invalid SMTP settings")
+
+// Mailer sends emails
+type Mailer interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// SMTPMailer sends the emails through the SMTP server configured in a secret of the kube-green
+// namespace. The secret is read on every email, so its changes are applied without a restart.
+type SMTPMailer struct {
+	Client    client.Client
+	Namespace string
+	Secret    string
+	Timeout   time.Duration // Timeout of each email, default 30s
+}
+
+type smtpSettings struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+	tls      string
+}
+
+func (m *SMTPMailer) settings(ctx context.Context) (smtpSettings, error) {
+	secret := &v1.Secret{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: m.Secret, Namespace: m.Namespace}, secret); err != nil {
+		return smtpSettings{}, fmt.Errorf("failed to get secret %s: %w", m.Secret, err)
+	}
+	settings := smtpSettings{
+		host:     string(secret.Data[SMTPHostKey]),
+		port:     string(secret.Data[SMTPPortKey]),
+		username: string(secret.Data[SMTPUsernameKey]),
+		password: string(secret.Data[SMTPPasswordKey]),
+		from:     string(secret.Data[SMTPFromKey]),
+		tls:      string(secret.Data[SMTPTLSKey]),
+	}
+	if settings.port == "" {
+		settings.port = "587"
+	}
+	if settings.tls == "" {
+		settings.tls = SMTPStartTLS
+	}
+	switch {
+	case settings.host == "":
+		return smtpSettings{}, fmt.Errorf("%w: secret %s has no %s", errInvalidSMTPSettings, m.Secret, SMTPHostKey)
+	case settings.from == "":
+		return smtpSettings{}, fmt.Errorf("%w: secret %s has no %s", errInvalidSMTPSettings, m.Secret, SMTPFromKey)
+	case settings.tls != SMTPStartTLS && settings.tls != SMTPTLS && settings.tls != SMTPNoTLS:
+		return smtpSettings{}, fmt.Errorf("%w: invalid %s %q, valid modes: %s, %s, %s", errInvalidSMTPSettings, SMTPTLSKey, settings.tls, SMTPStartTLS, SMTPTLS, SMTPNoTLS)
+	}
+	if _, err := mail.ParseAddress(settings.from); err != nil {
+		return smtpSettings{}, fmt.Errorf("%w: invalid %s: %s", errInvalidSMTPSettings, SMTPFromKey, err)
+	}
+	return settings, nil
+}
+
+// Send sends a plain text email to the recipients
+func (m *SMTPMailer) Send(ctx context.Context, to []string, subject, body string) error {
+	settings, err := m.settings(ctx)
+	if err != nil {
+		return err
+	}
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(settings.host, settings.port)
+	tlsConfig := &tls.Config{ServerName: settings.host, MinVersion: tls.VersionTLS12}
+	var conn net.Conn
+	if settings.tls == SMTPTLS {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	c, err := smtp.NewClient(conn, settings.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if settings.tls == SMTPStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%w: %s does not support STARTTLS", errInvalidSMTPSettings, addr)
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if settings.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", settings.username, settings.password, settings.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(settings.from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMessage(settings.from, to, subject, body, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMessage returns the plain text UTF-8 message with its headers
+func buildMessage(from string, to []string, subject, body string, now time.Time) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	return msg.Bytes()
+}
+
+// isRetryableMailError returns false for the invalid settings and the permanent (5xx) SMTP replies
+func isRetryableMailError(err error) bool {
+	if errors.Is(err, errInvalidSMTPSettings) {
+		return false
+	}
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code < 500
+	}
+	return true
+}
+
+// eventEmail returns the subject and the body of the email of the event
+func eventEmail(event Event) (string, string) {
+	subject := "[kube-green] " + eventTitle(event)
+	if event.Namespace != "" {
+		subject += ": " + event.Namespace
+	} else if event.Tenant != "" {
+		subject += ": " + event.Tenant
+	}
+	var body strings.Builder
+	fmt.Fprintf(&body, "kube-green: %s\n\n", eventTitle(event))
+	for _, fact := range eventFacts(event) {
+		fmt.Fprintf(&body, "%s: %s\n", fact.Title, fact.Value)
+	}
+	return subject, body.String()
+}
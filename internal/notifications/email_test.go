@@ -0,0 +1,179 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type sentEmail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []sentEmail
+}
+
+func (m *fakeMailer) Send(_ context.Context, to []string, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = append(m.sent, sentEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func (m *fakeMailer) emails() []sentEmail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]sentEmail{}, m.sent...)
+}
+
+// fakeSMTPServer accepts one email without TLS nor authentication, returning its envelope and data
+func fakeSMTPServer(t *testing.T) (string, <-chan []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+		lines := []string{}
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(line, "MAIL"), strings.HasPrefix(line, "RCPT"):
+				lines = append(lines, line)
+				reply("250 OK")
+			case line == "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					data = strings.TrimRight(data, "\r\n")
+					if data == "." {
+						break
+					}
+					lines = append(lines, data)
+				}
+				reply("250 OK")
+			case line == "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("500 unknown command")
+			}
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestSMTPMailer(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "kube-green"},
+		Data: map[string][]byte{
+			SMTPHostKey: []byte(host),
+			SMTPPortKey: []byte(port),
+			SMTPFromKey: []byte("kube-green@example.com"),
+			SMTPTLSKey:  []byte(SMTPNoTLS),
+		},
+	}
+	mailer := &SMTPMailer{
+		Client:    fake.NewClientBuilder().WithObjects(secret).Build(),
+		Namespace: "kube-green",
+		Secret:    "smtp",
+		Timeout:   5 * time.Second,
+	}
+
+	err = mailer.Send(context.Background(), []string{"ops@example.com", "dev@example.com"}, "[kube-green] Sleep executed", "Tenant: bdadevdat\n")
+	require.NoError(t, err)
+
+	select {
+	case lines := <-received:
+		require.Equal(t, "MAIL FROM:<kube-green@example.com>", lines[0])
+		require.Equal(t, "RCPT TO:<ops@example.com>", lines[1])
+		require.Equal(t, "RCPT TO:<dev@example.com>", lines[2])
+		require.Contains(t, lines, "To: ops@example.com, dev@example.com")
+		require.Contains(t, lines, "Subject: [kube-green] Sleep executed")
+		require.Contains(t, lines, "Content-Type: text/plain; charset=UTF-8")
+		require.Equal(t, "Tenant: bdadevdat", lines[len(lines)-1])
+	case <-time.After(5 * time.Second):
+		t.Fatal("email not received")
+	}
+
+	t.Run("invalid settings are not retried", func(t *testing.T) {
+		secret := secret.DeepCopy()
+		secret.Data[SMTPTLSKey] = []byte("ssl")
+		mailer := &SMTPMailer{Client: fake.NewClientBuilder().WithObjects(secret).Build(), Namespace: "kube-green", Secret: "smtp"}
+
+		err := mailer.Send(context.Background(), []string{"ops@example.com"}, "subject", "body")
+		require.ErrorContains(t, err, `invalid tls "ssl"`)
+		require.False(t, isRetryableMailError(err))
+	})
+}
+
+func TestEmailSubscriptions(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		require.NoError(t, Subscription{Emails: []string{"ops@example.com"}, Events: []string{EventSleepExecuted}}.Validate())
+		require.NoError(t, Subscription{Emails: []string{"Ops <ops@example.com>"}, Tenant: "bdadevdat", Digest: true}.Validate())
+		require.EqualError(t, Subscription{Digest: true}.Validate(), "digest requires emails")
+		require.EqualError(t, Subscription{URL: "https://hooks.example.com", Emails: []string{"ops@example.com"}}.Validate(), "url and emails are exclusive")
+		require.EqualError(t, Subscription{Emails: []string{"ops@example.com"}, Format: FormatTeams}.Validate(), "secret, format and template are not supported by email subscriptions")
+		require.EqualError(t, Subscription{Emails: []string{"ops@example.com"}, Digest: true, Events: []string{EventSleepExecuted}}.Validate(), "events are not supported by digest subscriptions")
+		require.ErrorContains(t, Subscription{Emails: []string{"ops"}}.Validate(), `invalid email "ops"`)
+	})
+
+	t.Run("the dispatcher emails the events", func(t *testing.T) {
+		mailer := &fakeMailer{}
+		subs := staticSubscriptions{
+			{ID: "digest", Emails: []string{"digest@example.com"}, Digest: true},
+			{ID: "email", Emails: []string{"ops@example.com"}, Tenant: "bdadevdat"},
+		}
+		dispatcher := NewDispatcher(subs, logr.Discard(), DispatcherConfig{Mailer: mailer})
+
+		dispatcher.dispatch(context.Background(), Event{
+			Type:      EventSleepExecuted,
+			Time:      time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC),
+			Tenant:    "bdadevdat",
+			Namespace: "bdadevdat-apps",
+			SleepInfo: "working-hours",
+		})
+
+		require.Equal(t, []sentEmail{{
+			to:      []string{"ops@example.com"},
+			subject: "[kube-green] Sleep executed: bdadevdat-apps",
+			body:    "kube-green: Sleep executed\n\nTenant: bdadevdat\nNamespace: bdadevdat-apps\nSleepInfo: working-hours\nTime: 2026-03-02T20:00:00Z\n",
+		}}, mailer.emails())
+	})
+}
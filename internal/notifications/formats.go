@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Formats of the body delivered to a subscription
+const (
+	FormatJSON     = "json"     // The Event, the default
+	FormatTeams    = "teams"    // A Microsoft Teams message with an Adaptive Card, for the Teams workflows webhooks
+	FormatTemplate = "template" // The Template of the subscription executed with the Event
+)
+
+// Formats lists the formats of a subscription
+var Formats = []string{FormatJSON, FormatTeams, FormatTemplate}
+
+// eventTitles are the titles of the Teams cards by event type
+var eventTitles = map[string]string{
+	EventScheduleCreated: "Schedule created",
+	EventScheduleUpdated: "Schedule updated",
+	EventScheduleDeleted: "Schedule deleted",
+	EventSleepExecuted:   "Sleep executed",
+	EventWakeExecuted:    "Wake up executed",
+}
+
+// templateFuncs are the functions of the subscription templates: json encodes a value, so that strings
+// are quoted and escaped in JSON bodies
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+func isFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTemplate(text string) (*template.Template, error) {
+	return template.New("notification").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+}
+
+// validateFormat checks the format of the subscription and its template
+func (s Subscription) validateFormat() error {
+	if s.Format != "" && !isFormat(s.Format) {
+		return fmt.Errorf("invalid format %q, valid formats: %v", s.Format, Formats)
+	}
+	if s.Format != FormatTemplate {
+		if s.Template != "" {
+			return fmt.Errorf("template requires format %q", FormatTemplate)
+		}
+		return nil
+	}
+	if strings.TrimSpace(s.Template) == "" {
+		return fmt.Errorf("format %q requires a template", FormatTemplate)
+	}
+	if _, err := parseTemplate(s.Template); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+}
+
+// Body returns the body delivered to the subscription for the event, eventJSON when its format is json
+func (s Subscription) Body(event Event, eventJSON []byte) ([]byte, error) {
+	switch s.Format {
+	case FormatTeams:
+		return json.Marshal(teamsMessage(event))
+	case FormatTemplate:
+		tmpl, err := parseTemplate(s.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		var body bytes.Buffer
+		if err := tmpl.Execute(&body, event); err != nil {
+			return nil, fmt.Errorf("fails to execute template: %w", err)
+		}
+		return body.Bytes(), nil
+	default:
+		return eventJSON, nil
+	}
+}
+
+type eventFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+func eventTitle(event Event) string {
+	if title, ok := eventTitles[event.Type]; ok {
+		return title
+	}
+	return event.Type
+}
+
+// eventFacts returns the fields of the event set, then its data sorted by key
+func eventFacts(event Event) []eventFact {
+	facts := []eventFact{}
+	addFact := func(name, value string) {
+		if value != "" {
+			facts = append(facts, eventFact{Title: name, Value: value})
+		}
+	}
+	addFact("Tenant", event.Tenant)
+	addFact("Namespace", event.Namespace)
+	addFact("SleepInfo", event.SleepInfo)
+	addFact("Schedule", event.ScheduleName)
+	addFact("Time", event.Time.UTC().Format(time.RFC3339))
+	keys := make([]string, 0, len(event.Data))
+	for key := range event.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		addFact(key, event.Data[key])
+	}
+	return facts
+}
+
+// teamsMessage returns the Teams message of the event: an Adaptive Card with its title and its fields
+// as facts
+func teamsMessage(event Event) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []interface{}{
+			map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []interface{}{
+						map[string]interface{}{"type": "TextBlock", "text": "kube-green: " + eventTitle(event), "weight": "Bolder", "size": "Medium", "wrap": true},
+						map[string]interface{}{"type": "FactSet", "facts": eventFacts(event)},
+					},
+				},
+			},
+		},
+	}
+}
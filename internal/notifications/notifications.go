@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+*/
+
+// Package notifications delivers schedule lifecycle events to the callback URLs and email recipients
+// registered by users, and emails them a weekly digest of the executed operations.
+package notifications
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// Event types
+const (
+	EventScheduleCreated = "schedule.created"
+	EventScheduleUpdated = "schedule.updated"
+	EventScheduleDeleted = "schedule.deleted"
+	EventSleepExecuted   = "sleep.executed"
+	EventWakeExecuted    = "wake.executed"
+)
+
+// EventTypes lists the event types a subscription can filter on
+var EventTypes = []string{
+	EventScheduleCreated,
+	EventScheduleUpdated,
+	EventScheduleDeleted,
+	EventSleepExecuted,
+	EventWakeExecuted,
+}
+
+// Delivery headers
+const (
+	SignatureHeader = "X-Kube-Green-Signature" // sha256=<hex HMAC of the body>, only when the subscription has a secret
+	EventHeader     = "X-Kube-Green-Event"
+	DeliveryHeader  = "X-Kube-Green-Delivery"
+)
+
+// Event is the JSON body POSTed to the subscribed URLs
+type Event struct {
+	ID           string            `json:"id"`
+	Type         string            `json:"type"`
+	Time         time.Time         `json:"time"`
+	Source       string            `json:"source"` // api or controller
+	Tenant       string            `json:"tenant,omitempty"`
+	Namespace    string            `json:"namespace,omitempty"`
+	SleepInfo    string            `json:"sleepInfo,omitempty"`
+	ScheduleName string            `json:"scheduleName,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+// Notifier sends events. Implementations must not block the caller.
+type Notifier interface {
+	Notify(event Event)
+}
+
+// Subscription is a callback URL, or email recipients, registered for the events of a tenant, or of every
+// tenant when Tenant is empty
+type Subscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url,omitempty"`
+	Emails    []string  `json:"emails,omitempty"` // Recipients of the events by email, instead of the URL
+	Digest    bool      `json:"digest,omitempty"` // Email the weekly digest of the tenant instead of its events
+	Tenant    string    `json:"tenant,omitempty"`
+	Events    []string  `json:"events,omitempty"`   // Event types to deliver, all of them when empty
+	Secret    string    `json:"secret,omitempty"`   // HMAC-SHA256 key used to sign the body
+	Format    string    `json:"format,omitempty"`   // Format of the body: json (the default), teams or template
+	Template  string    `json:"template,omitempty"` // Go template of the body with format template, executed with the Event
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+}
+
+// Matches reports whether the event must be delivered to the subscription
+func (s Subscription) Matches(event Event) bool {
+	if s.Digest {
+		return false
+	}
+	if s.Tenant != "" && s.Tenant != event.Tenant {
+		return false
+	}
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, eventType := range s.Events {
+		if eventType == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the URL or the email recipients, event types and format of the subscription
+func (s Subscription) Validate() error {
+	if len(s.Emails) > 0 || s.Digest {
+		return s.validateEmail()
+	}
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q: must be an absolute http(s) URL", s.URL)
+	}
+	if err := validateEventTypes(s.Events); err != nil {
+		return err
+	}
+	return s.validateFormat()
+}
+
+// validateEmail checks the email subscription: its recipients, without the settings of the URL deliveries
+func (s Subscription) validateEmail() error {
+	if len(s.Emails) == 0 {
+		return errors.New("digest requires emails")
+	}
+	if s.URL != "" {
+		return errors.New("url and emails are exclusive")
+	}
+	if s.Secret != "" || s.Format != "" || s.Template != "" {
+		return errors.New("secret, format and template are not supported by email subscriptions")
+	}
+	if s.Digest && len(s.Events) > 0 {
+		return errors.New("events are not supported by digest subscriptions")
+	}
+	for _, email := range s.Emails {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return fmt.Errorf("invalid email %q: %w", email, err)
+		}
+	}
+	return validateEventTypes(s.Events)
+}
+
+func validateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !isEventType(eventType) {
+			return fmt.Errorf("invalid event type %q, valid types: %v", eventType, EventTypes)
+		}
+	}
+	return nil
+}
+
+func isEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature header value of body: sha256=<hex HMAC-SHA256(secret, body)>
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSubscriptions []Subscription
+
+func (s staticSubscriptions) List(context.Context) ([]Subscription, error) {
+	return s, nil
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	event := Event{Type: EventSleepExecuted, Tenant: "bdadevdat"}
+
+	require.True(t, Subscription{}.Matches(event))
+	require.True(t, Subscription{Tenant: "bdadevdat", Events: []string{EventSleepExecuted}}.Matches(event))
+	require.False(t, Subscription{Tenant: "bdadevprd"}.Matches(event))
+	require.False(t, Subscription{Events: []string{EventWakeExecuted}}.Matches(event))
+}
+
+func TestSubscriptionValidate(t *testing.T) {
+	require.NoError(t, Subscription{URL: "https://hooks.example.com/kube-green"}.Validate())
+	require.Error(t, Subscription{URL: "hooks.example.com"}.Validate())
+	require.Error(t, Subscription{URL: "ftp://hooks.example.com"}.Validate())
+	require.Error(t, Subscription{URL: "https://hooks.example.com", Events: []string{"unknown"}}.Validate())
+}
+
+func TestDispatcherDeliversSignedEventWithRetries(t *testing.T) {
+	var attempts int32
+	received := make(chan Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, Sign("s3cret", body), r.Header.Get(SignatureHeader))
+		require.Equal(t, EventWakeExecuted, r.Header.Get(EventHeader))
+
+		var event Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		received <- event
+	}))
+	defer server.Close()
+
+	subs := staticSubscriptions{
+		{ID: "other", URL: server.URL, Tenant: "other"},
+		{ID: "tenant", URL: server.URL, Tenant: "bdadevdat", Secret: "s3cret"},
+	}
+	dispatcher := NewDispatcher(subs, logr.Discard(), DispatcherConfig{RetryBackoff: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = dispatcher.Start(ctx)
+	}()
+
+	dispatcher.Notify(Event{Type: EventWakeExecuted, Tenant: "bdadevdat", Namespace: "bdadevdat-apps"})
+
+	select {
+	case event := <-received:
+		require.NotEmpty(t, event.ID)
+		require.Equal(t, "bdadevdat-apps", event.Namespace)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not delivered")
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestSubscriptionFormats(t *testing.T) {
+	event := Event{
+		ID:        "abc",
+		Type:      EventSleepExecuted,
+		Time:      time.Date(2026, 3, 2, 20, 0, 0, 0, time.UTC),
+		Tenant:    "bdadevdat",
+		Namespace: "bdadevdat-apps",
+		SleepInfo: "working-hours",
+		Data:      map[string]string{"manual": "false"},
+	}
+	eventJSON, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	t.Run("json", func(t *testing.T) {
+		body, err := Subscription{}.Body(event, eventJSON)
+		require.NoError(t, err)
+		require.Equal(t, eventJSON, body)
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		body, err := Subscription{Format: FormatTeams}.Body(event, eventJSON)
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"type": "message",
+			"attachments": [{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": {
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type": "AdaptiveCard",
+					"version": "1.4",
+					"body": [
+						{"type": "TextBlock", "text": "kube-green: Sleep executed", "weight": "Bolder", "size": "Medium", "wrap": true},
+						{"type": "FactSet", "facts": [
+							{"title": "Tenant", "value": "bdadevdat"},
+							{"title": "Namespace", "value": "bdadevdat-apps"},
+							{"title": "SleepInfo", "value": "working-hours"},
+							{"title": "Time", "value": "2026-03-02T20:00:00Z"},
+							{"title": "manual", "value": "false"}
+						]}
+					]
+				}
+			}]
+		}`, string(body))
+	})
+
+	t.Run("template", func(t *testing.T) {
+		sub := Subscription{URL: "https://hooks.example.com", Format: FormatTemplate, Template: `{"summary": {{ json .Type }}, "ns": {{ json .Namespace }}, "manual": {{ json (index .Data "manual") }}}`}
+		require.NoError(t, sub.Validate())
+
+		body, err := sub.Body(event, eventJSON)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"summary": "sleep.executed", "ns": "bdadevdat-apps", "manual": "false"}`, string(body))
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		url := "https://hooks.example.com"
+		require.NoError(t, Subscription{URL: url, Format: FormatTeams}.Validate())
+		require.EqualError(t, Subscription{URL: url, Format: "slack"}.Validate(), `invalid format "slack", valid formats: [json teams template]`)
+		require.EqualError(t, Subscription{URL: url, Format: FormatTemplate}.Validate(), `format "template" requires a template`)
+		require.EqualError(t, Subscription{URL: url, Template: "{}"}.Validate(), `template requires format "template"`)
+		require.ErrorContains(t, Subscription{URL: url, Format: FormatTemplate, Template: "{{ .Type "}.Validate(), "invalid template")
+	})
+}
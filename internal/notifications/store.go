@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+*/
+
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SecretName is the secret holding the subscriptions (they include the signing secrets)
+	SecretName      = "kube-green-webhooks"
+	subscriptionKey = "subscriptions.json"
+)
+
+// ErrSubscriptionNotFound is returned when deleting an unknown subscription
+var ErrSubscriptionNotFound = errors.New("subscription not found")
+
+// Store persists the subscriptions in a secret of the kube-green namespace
+type Store struct {
+	client    client.Client
+	namespace string
+}
+
+// NewStore creates a store backed by the SecretName secret in namespace
+func NewStore(c client.Client, namespace string) *Store {
+	return &Store{client: c, namespace: namespace}
+}
+
+// List returns the registered subscriptions
+func (s *Store) List(ctx context.Context) ([]Subscription, error) {
+	secret := &v1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: SecretName, Namespace: s.namespace}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return []Subscription{}, nil
+		}
+		return nil, fmt.Errorf("failed to get secret %s: %w", SecretName, err)
+	}
+	return decodeSubscriptions(secret)
+}
+
+// Add validates and registers a subscription, returning it with its generated ID
+func (s *Store) Add(ctx context.Context, sub Subscription) (*Subscription, error) {
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	sub.ID = newID()
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now().UTC()
+	}
+	err := s.update(ctx, func(subs []Subscription) ([]Subscription, error) {
+		return append(subs, sub), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// Delete removes a subscription by ID
+func (s *Store) Delete(ctx context.Context, id string) error {
+	return s.update(ctx, func(subs []Subscription) ([]Subscription, error) {
+		for i := range subs {
+			if subs[i].ID == id {
+				return append(subs[:i], subs[i+1:]...), nil
+			}
+		}
+		return nil, ErrSubscriptionNotFound
+	})
+}
+
+func (s *Store) update(ctx context.Context, mutate func([]Subscription) ([]Subscription, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret := &v1.Secret{}
+		err := s.client.Get(ctx, client.ObjectKey{Name: SecretName, Namespace: s.namespace}, secret)
+		create := apierrors.IsNotFound(err)
+		if err != nil && !create {
+			return fmt.Errorf("failed to get secret %s: %w", SecretName, err)
+		}
+
+		subs := []Subscription{}
+		if !create {
+			if subs, err = decodeSubscriptions(secret); err != nil {
+				return err
+			}
+		}
+		subs, err = mutate(subs)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(subs)
+		if err != nil {
+			return err
+		}
+
+		if create {
+			secret = &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      SecretName,
+					Namespace: s.namespace,
+					Labels:    map[string]string{"app.kubernetes.io/managed-by": "kube-green"},
+				},
+				Type: v1.SecretTypeOpaque,
+				Data: map[string][]byte{subscriptionKey: data},
+			}
+			return s.client.Create(ctx, secret)
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[subscriptionKey] = data
+		return s.client.Update(ctx, secret)
+	})
+}
+
+func decodeSubscriptions(secret *v1.Secret) ([]Subscription, error) {
+	subs := []Subscription{}
+	data := secret.Data[subscriptionKey]
+	if len(data) == 0 {
+		return subs, nil
+	}
+	if err := json.Unmarshal(data, &subs); err != nil {
+		return nil, fmt.Errorf("invalid %s in secret %s: %w", subscriptionKey, SecretName, err)
+	}
+	return subs, nil
+}
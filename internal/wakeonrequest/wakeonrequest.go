@@ -0,0 +1,303 @@
+// Package wakeonrequest wakes up a namespace asleep on the first request to its applications. The
+// requests failing while its workloads are asleep are routed to the Handler, e.g. as the default
+// backend of the ingress-nginx custom errors, which wakes up the SleepInfos of the namespace with a
+// manual wake up and answers with a "waking up" page reloaded after Retry-After.
+package wakeonrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EnabledLabel opts a namespace in: only the namespaces labelled "true" are woken up by a request
+	EnabledLabel = "kube-green.stratio.com/wake-on-request"
+	// NamespaceHeader is the namespace of a request, set by the ingress-nginx custom errors
+	NamespaceHeader = "X-Namespace"
+	// PathPrefix serves the requests of a namespace at /wake/{namespace}/, for the proxies that cannot
+	// set a header
+	PathPrefix = "/wake/"
+	// DefaultRetryAfter is the time after which the "waking up" page is reloaded
+	DefaultRetryAfter = 30 * time.Second
+	// EventReason is the reason of the Event of a SleepInfo woken up by a request
+	EventReason = "WakeOnRequest"
+
+	manualActionAnnotation = "kube-green.stratio.com/manual-action"
+	manualAtAnnotation     = "kube-green.stratio.com/manual-at"
+	pairRoleAnnotation     = "kube-green.stratio.com/pair-role"
+	originalURIHeader      = "X-Original-URI"
+	// cooldown is the time a namespace woken up is not woken up again, while its SleepInfos catch up
+	cooldown = time.Minute
+	// maxURILength is the longest request URI written in the Events
+	maxURILength = 200
+)
+
+// Results of a request, the result label of the requests metric
+const (
+	ResultWoken    = "woken"    // the namespace was asleep and is waking up
+	ResultWaking   = "waking"   // the namespace is already waking up
+	ResultAwake    = "awake"    // the namespace is awake, its applications are starting or failing
+	ResultDisabled = "disabled" // the namespace is not labelled with EnabledLabel
+	ResultError    = "error"    // the wake up failed
+)
+
+// unknownNamespace is the namespace label of the requests whose namespace does not exist or is not
+// enabled: the namespace is set by the client, it is not trusted as a label value
+const unknownNamespace = "unknown"
+
+var errNoNamespace = errors.New("the request has no namespace")
+
+// NewRequestsMetric returns the counter of the requests of the Handler, by namespace and result
+func NewRequestsMetric(prefix string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: prefix,
+		Name:      "wake_on_request_total",
+		Help:      "Requests received for the namespaces asleep, by result",
+	}, []string{"namespace", "result"})
+}
+
+// Handler wakes up the namespaces of the requests it receives
+type Handler struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Log      logr.Logger
+	// RetryAfter is the time after which the page is reloaded, DefaultRetryAfter when zero
+	RetryAfter time.Duration
+	// Requests counts the requests by namespace and result, when set
+	Requests *prometheus.CounterVec
+	// Now defaults to time.Now
+	Now func() time.Time
+
+	mu    sync.Mutex
+	woken map[string]time.Time // last wake up of each namespace
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace, err := namespaceOf(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	metricNamespace, result := unknownNamespace, ResultDisabled
+	enabled, err := h.enabled(r.Context(), namespace)
+	if enabled {
+		metricNamespace = namespace
+		result, err = h.wake(r.Context(), namespace, requestURI(r))
+	}
+	if err != nil {
+		h.Log.Error(err, "wake on request failed", "namespace", namespace)
+		result = ResultError
+	}
+	if h.Requests != nil {
+		h.Requests.WithLabelValues(metricNamespace, result).Inc()
+	}
+	if result == ResultDisabled {
+		http.Error(w, fmt.Sprintf("namespace %s is not woken up by requests", namespace), http.StatusNotFound)
+		return
+	}
+	h.writePage(w, r, namespace, result)
+}
+
+// enabled returns whether the namespace exists and is labelled with EnabledLabel
+func (h *Handler) enabled(ctx context.Context, namespace string) (bool, error) {
+	ns := &v1.Namespace{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return ns.Labels[EnabledLabel] == "true", nil
+}
+
+// wake wakes up the SleepInfos of an enabled namespace asleep
+func (h *Handler) wake(ctx context.Context, namespace, uri string) (string, error) {
+	sleepInfoList := &kubegreenv1alpha1.SleepInfoList{}
+	if err := h.Client.List(ctx, sleepInfoList, client.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("fails to list SleepInfos: %w", err)
+	}
+	asleep, waking := false, false
+	for _, sleepInfo := range sleepInfoList.Items {
+		switch {
+		case sleepInfo.Status.CurrentState == kubegreenv1alpha1.StateTransitioning:
+			waking = true
+		case strings.EqualFold(sleepInfo.Annotations[manualActionAnnotation], "wake"):
+			waking = true
+		case sleepInfo.Status.CurrentState == kubegreenv1alpha1.StateSleeping:
+			asleep = true
+		}
+	}
+	if !asleep {
+		if waking {
+			return ResultWaking, nil
+		}
+		return ResultAwake, nil
+	}
+
+	// One wake up per namespace at a time, while the cache catches up with the manual action: the
+	// namespace is reserved before its SleepInfos are patched, without holding the lock for the
+	// other namespaces
+	now := h.now()
+	h.mu.Lock()
+	previous := h.woken[namespace]
+	if waking || now.Sub(previous) < cooldown {
+		h.mu.Unlock()
+		return ResultWaking, nil
+	}
+	if h.woken == nil {
+		h.woken = map[string]time.Time{}
+	}
+	h.woken[namespace] = now
+	h.mu.Unlock()
+
+	// As a manual wake up, the SleepInfos of the sleep role of a pair are left alone
+	for i := range sleepInfoList.Items {
+		sleepInfo := &sleepInfoList.Items[i]
+		if sleepInfo.Annotations[pairRoleAnnotation] == "sleep" || !sleepInfo.DeletionTimestamp.IsZero() {
+			continue
+		}
+		patch := client.MergeFrom(sleepInfo.DeepCopy())
+		if sleepInfo.Annotations == nil {
+			sleepInfo.Annotations = map[string]string{}
+		}
+		sleepInfo.Annotations[manualActionAnnotation] = "wake"
+		sleepInfo.Annotations[manualAtAnnotation] = now.UTC().Format(time.RFC3339)
+		if err := h.Client.Patch(ctx, sleepInfo, patch); err != nil {
+			// The next request retries the wake up
+			h.mu.Lock()
+			h.woken[namespace] = previous
+			h.mu.Unlock()
+			return "", fmt.Errorf("fails to wake up SleepInfo %s: %w", sleepInfo.Name, err)
+		}
+		if h.Recorder != nil {
+			h.Recorder.Eventf(sleepInfo, v1.EventTypeNormal, EventReason, "Woken up by a request to %s", uri)
+		}
+	}
+	h.Log.Info("namespace woken up by a request", "namespace", namespace, "uri", uri)
+	return ResultWoken, nil
+}
+
+func (h *Handler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+// namespaceOf returns the namespace of a request: the NamespaceHeader, or the namespace of a PathPrefix path
+func namespaceOf(r *http.Request) (string, error) {
+	namespace := r.Header.Get(NamespaceHeader)
+	if namespace == "" {
+		if rest, ok := strings.CutPrefix(r.URL.Path, PathPrefix); ok {
+			namespace, _, _ = strings.Cut(rest, "/")
+		}
+	}
+	if namespace == "" {
+		return "", errNoNamespace
+	}
+	return namespace, nil
+}
+
+// requestURI returns the host and URI of the request, as received by the ingress
+func requestURI(r *http.Request) string {
+	uri := r.Header.Get(originalURIHeader)
+	if uri == "" {
+		uri = r.URL.RequestURI()
+	}
+	uri = r.Host + uri
+	if len(uri) > maxURILength {
+		uri = uri[:maxURILength] + "..."
+	}
+	return uri
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{ .RetryAfter }}">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: sans-serif; text-align: center; margin-top: 15vh; color: #2d3748; }
+p { color: #718096; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<p>{{ .Message }}</p>
+<p>This page reloads in {{ .RetryAfter }} seconds.</p>
+</body>
+</html>
+`))
+
+// writePage answers with the state of the namespace, as a page reloaded after Retry-After
+func (h *Handler) writePage(w http.ResponseWriter, r *http.Request, namespace, result string) {
+	retryAfter := h.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = DefaultRetryAfter
+	}
+	seconds := strconv.Itoa(int(retryAfter.Seconds()))
+	title, message := "Waking up", fmt.Sprintf("The applications of %s were asleep and are starting.", namespace)
+	switch result {
+	case ResultAwake:
+		title, message = "Starting", fmt.Sprintf("The applications of %s are awake but not ready yet.", namespace)
+	case ResultError:
+		title, message = "Wake up failed", fmt.Sprintf("The applications of %s could not be woken up, retrying.", namespace)
+	}
+
+	w.Header().Set("Retry-After", seconds)
+	w.Header().Set("Cache-Control", "no-store")
+	if !strings.Contains(r.Header.Get("Accept"), "text/html") {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "%s: %s Retry after %s seconds.\n", title, message, seconds)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if err := pageTemplate.Execute(w, map[string]string{"Title": title, "Message": message, "RetryAfter": seconds}); err != nil {
+		h.Log.Error(err, "fails to write the wake up page")
+	}
+}
+
+// Server serves the Handler as a manager runnable
+type Server struct {
+	Addr    string
+	Handler http.Handler
+}
+
+// Start serves the requests until ctx is done
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{Addr: s.Addr, Handler: s.Handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection is false: every replica of the manager serves the requests
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,184 @@
+package wakeonrequest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, kubegreenv1alpha1.AddToScheme(scheme))
+
+	namespace := func(name string, enabled bool) *v1.Namespace {
+		ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if enabled {
+			ns.Labels = map[string]string{EnabledLabel: "true"}
+		}
+		return ns
+	}
+	sleepInfo := func(namespace, name, state string, annotations map[string]string) *kubegreenv1alpha1.SleepInfo {
+		return &kubegreenv1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Annotations: annotations},
+			Status:     kubegreenv1alpha1.SleepInfoStatus{CurrentState: state},
+		}
+	}
+	newHandlerWithInterceptor := func(funcs interceptor.Funcs, objects ...client.Object) (*Handler, client.Client, *record.FakeRecorder) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithInterceptorFuncs(funcs).Build()
+		recorder := record.NewFakeRecorder(10)
+		return &Handler{
+			Client:     c,
+			Recorder:   recorder,
+			Log:        logr.Discard(),
+			RetryAfter: 15 * time.Second,
+			Requests:   NewRequestsMetric("kube_green"),
+			Now:        func() time.Time { return now },
+		}, c, recorder
+	}
+	newHandler := func(objects ...client.Object) (*Handler, client.Client, *record.FakeRecorder) {
+		return newHandlerWithInterceptor(interceptor.Funcs{}, objects...)
+	}
+	serve := func(h *Handler, r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("wakes up a namespace asleep", func(t *testing.T) {
+		h, c, recorder := newHandler(
+			namespace("dev-apps", true),
+			sleepInfo("dev-apps", "night", kubegreenv1alpha1.StateSleeping, nil),
+			sleepInfo("dev-apps", "night-sleep", kubegreenv1alpha1.StateSleeping, map[string]string{pairRoleAnnotation: "sleep"}),
+		)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Host = "app.dev.example.com"
+		r.Header.Set(NamespaceHeader, "dev-apps")
+		r.Header.Set(originalURIHeader, "/orders?page=2")
+		r.Header.Set("Accept", "text/html")
+
+		w := serve(h, r)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, "15", w.Header().Get("Retry-After"))
+		require.Contains(t, w.Body.String(), `<meta http-equiv="refresh" content="15">`)
+		require.Contains(t, w.Body.String(), "Waking up")
+
+		woken := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "dev-apps", Name: "night"}, woken))
+		require.Equal(t, "wake", woken.Annotations[manualActionAnnotation])
+		require.Equal(t, "2026-03-02T09:00:00Z", woken.Annotations[manualAtAnnotation])
+		sleeper := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "dev-apps", Name: "night-sleep"}, sleeper))
+		require.Empty(t, sleeper.Annotations[manualActionAnnotation])
+		require.Equal(t, "Normal WakeOnRequest Woken up by a request to app.dev.example.com/orders?page=2", <-recorder.Events)
+		require.Equal(t, 1.0, testutil.ToFloat64(h.Requests.WithLabelValues("dev-apps", ResultWoken)))
+
+		// The wake up is not requested again while it is pending
+		w = serve(h, r)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, 1.0, testutil.ToFloat64(h.Requests.WithLabelValues("dev-apps", ResultWaking)))
+		require.Empty(t, recorder.Events)
+	})
+
+	t.Run("reads the namespace from the path", func(t *testing.T) {
+		h, c, _ := newHandler(
+			namespace("dev-apps", true),
+			sleepInfo("dev-apps", "night", kubegreenv1alpha1.StateSleeping, nil),
+		)
+		w := serve(h, httptest.NewRequest(http.MethodGet, "/wake/dev-apps/api/orders", nil))
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		require.True(t, strings.HasPrefix(w.Body.String(), "Waking up:"))
+
+		woken := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "dev-apps", Name: "night"}, woken))
+		require.Equal(t, "wake", woken.Annotations[manualActionAnnotation])
+	})
+
+	t.Run("does not wake up the namespaces not enabled", func(t *testing.T) {
+		h, c, _ := newHandler(
+			namespace("prod-apps", false),
+			sleepInfo("prod-apps", "night", kubegreenv1alpha1.StateSleeping, nil),
+		)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(NamespaceHeader, "prod-apps")
+		require.Equal(t, http.StatusNotFound, serve(h, r).Code)
+		r.Header.Set(NamespaceHeader, "missing")
+		require.Equal(t, http.StatusNotFound, serve(h, r).Code)
+		require.Equal(t, http.StatusNotFound, serve(h, httptest.NewRequest(http.MethodGet, "/", nil)).Code)
+		// The namespaces of the requests are only used as labels once known to be enabled
+		require.Equal(t, 2.0, testutil.ToFloat64(h.Requests.WithLabelValues(unknownNamespace, ResultDisabled)))
+		require.Equal(t, 1, testutil.CollectAndCount(h.Requests))
+
+		sleeping := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "prod-apps", Name: "night"}, sleeping))
+		require.Empty(t, sleeping.Annotations[manualActionAnnotation])
+	})
+
+	t.Run("does not wake up a namespace awake", func(t *testing.T) {
+		h, c, _ := newHandler(
+			namespace("dev-apps", true),
+			sleepInfo("dev-apps", "night", kubegreenv1alpha1.StateAwake, nil),
+		)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(NamespaceHeader, "dev-apps")
+		w := serve(h, r)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Contains(t, w.Body.String(), "Starting")
+		require.Equal(t, 1.0, testutil.ToFloat64(h.Requests.WithLabelValues("dev-apps", ResultAwake)))
+
+		awake := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "dev-apps", Name: "night"}, awake))
+		require.Empty(t, awake.Annotations[manualActionAnnotation])
+	})
+
+	t.Run("retries a wake up whose patch failed, without holding the lock while patching", func(t *testing.T) {
+		var h *Handler
+		fail := true
+		h, c, _ := newHandlerWithInterceptor(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				require.True(t, h.mu.TryLock(), "the lock is held while patching")
+				h.mu.Unlock()
+				if fail {
+					return errors.New("patch failed")
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		},
+			namespace("dev-apps", true),
+			sleepInfo("dev-apps", "night", kubegreenv1alpha1.StateSleeping, nil),
+		)
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(NamespaceHeader, "dev-apps")
+		w := serve(h, r)
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Contains(t, w.Body.String(), "Wake up failed")
+		require.Equal(t, 1.0, testutil.ToFloat64(h.Requests.WithLabelValues("dev-apps", ResultError)))
+
+		fail = false
+		serve(h, r)
+		require.Equal(t, 1.0, testutil.ToFloat64(h.Requests.WithLabelValues("dev-apps", ResultWoken)))
+		woken := &kubegreenv1alpha1.SleepInfo{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKey{Namespace: "dev-apps", Name: "night"}, woken))
+		require.Equal(t, "wake", woken.Annotations[manualActionAnnotation])
+	})
+}
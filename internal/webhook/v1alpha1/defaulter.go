@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedBy      = "kube-green"
+)
+
+type customDefaulter struct {
+	// TimeZone is the timeZone of the SleepInfos without it
+	TimeZone string
+}
+
+// +kubebuilder:webhook:path=/mutate-kube-green-com-v1alpha1-sleepinfo,mutating=true,failurePolicy=fail,sideEffects=None,groups=kube-green.com,resources=sleepinfos,verbs=create;update,versions=v1alpha1,name=msleepinfo.kb.io,admissionReviewVersions=v1
+var _ webhook.CustomDefaulter = &customDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type. It
+// normalizes the SleepInfos as the REST API writes them, so the SleepInfos created with kubectl or
+// GitOps behave the same.
+func (d *customDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	s, ok := obj.(*v1alpha1.SleepInfo)
+	if !ok {
+		return fmt.Errorf("fails to decode SleepInfo")
+	}
+	sleepinfolog.Info("default", "name", s.Name, "namespace", s.Namespace)
+
+	s.Spec.Default(d.TimeZone)
+	// Keep the managed-by label of the SleepInfos deployed by Helm or other tools
+	if _, ok := s.Labels[managedByLabel]; !ok {
+		if s.Labels == nil {
+			s.Labels = map[string]string{}
+		}
+		s.Labels[managedByLabel] = managedBy
+	}
+	return recordNamespaceSelectorRequester(ctx, s)
+}
+
+// recordNamespaceSelectorRequester stores the user of the request in the requester annotation when it
+// sets or changes the namespaceSelector, or touches the annotation, so the controller can check at
+// every reconcile that this user can create SleepInfos in the namespaces selected. Updates leaving
+// both unchanged, such as the finalizers added by the controller, keep the recorded user.
+func recordNamespaceSelectorRequester(ctx context.Context, s *v1alpha1.SleepInfo) error {
+	if s.Spec.NamespaceSelector == nil {
+		delete(s.Annotations, v1alpha1.NamespaceSelectorRequesterAnnotation)
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fails to get the user of the request: %w", err)
+	}
+	if req.Operation == admissionv1.Update && len(req.OldObject.Raw) > 0 {
+		old := &v1alpha1.SleepInfo{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return fmt.Errorf("fails to decode the previous SleepInfo: %w", err)
+		}
+		if equality.Semantic.DeepEqual(old.Spec.NamespaceSelector, s.Spec.NamespaceSelector) &&
+			old.Annotations[v1alpha1.NamespaceSelectorRequesterAnnotation] == s.Annotations[v1alpha1.NamespaceSelectorRequesterAnnotation] {
+			return nil
+		}
+	}
+
+	requester, err := json.Marshal(v1alpha1.Requester{
+		Username: req.UserInfo.Username,
+		UID:      req.UserInfo.UID,
+		Groups:   req.UserInfo.Groups,
+	})
+	if err != nil {
+		return fmt.Errorf("fails to encode the user of the request: %w", err)
+	}
+	if s.Annotations == nil {
+		s.Annotations = map[string]string{}
+	}
+	s.Annotations[v1alpha1.NamespaceSelectorRequesterAnnotation] = string(requester)
+	return nil
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"github.com/robfig/cron/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// overlapHorizon is how far ahead the windows asleep of the SleepInfos are compared
+	overlapHorizon = 7 * 24 * time.Hour
+	// maxWindows bounds the windows asleep computed for a schedule running every few minutes
+	maxWindows = 500
+)
+
+type asleepWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+// schedule is a SleepInfo, or a sleep SleepInfo with the wake SleepInfos of its pair, whose
+// windows asleep are known
+type schedule struct {
+	name  string
+	sleep *v1alpha1.SleepInfo
+	wakes []*v1alpha1.SleepInfo
+}
+
+// overlapWarnings warns about the other SleepInfos of the namespace putting to sleep the same kinds
+// of resources with windows asleep overlapping those of the SleepInfo in the next overlapHorizon: a
+// sleep or a wake up of one while the other keeps the resources asleep or awake, the last operation
+// wins. Identical windows are not an overlap. The SleepInfos are still accepted, since their
+// includeRef and excludeRef may select different resources.
+func (v *customValidator) overlapWarnings(ctx context.Context, s *v1alpha1.SleepInfo, now time.Time) admission.Warnings {
+	if s.Annotations[pairRoleAnnotation] == "" && !hasWindows(s) {
+		return nil
+	}
+	sleepInfoList := &v1alpha1.SleepInfoList{}
+	if err := v.reader().List(ctx, sleepInfoList, client.InNamespace(s.Namespace)); err != nil {
+		sleepinfolog.Error(err, "fails to list the SleepInfos to check the overlaps", "namespace", s.Namespace)
+		return nil
+	}
+	sleepInfos := []*v1alpha1.SleepInfo{s}
+	for i := range sleepInfoList.Items {
+		si := &sleepInfoList.Items[i]
+		if si.Name != s.Name && si.DeletionTimestamp.IsZero() {
+			sleepInfos = append(sleepInfos, si)
+		}
+	}
+	schedules := groupSchedules(sleepInfos)
+	current := schedules[scheduleKey(s)]
+	windows := current.windows(now)
+	if len(windows) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(schedules))
+	for key := range schedules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	warnings := admission.Warnings{}
+	for _, key := range keys {
+		other := schedules[key]
+		if other == current || !sameTargets(current.sleep, other.sleep) {
+			continue
+		}
+		if at, ok := firstOverlap(windows, other.windows(now), now); ok {
+			warnings = append(warnings, fmt.Sprintf("the windows asleep overlap those of %s, which also puts to sleep its resources: "+
+				"at %s one of them sleeps or wakes up the resources the other keeps awake or asleep",
+				other.name, at.UTC().Format("Mon 15:04 MST")))
+		}
+	}
+	return warnings
+}
+
+func hasWindows(s *v1alpha1.SleepInfo) bool {
+	return s.IsWindow() || s.HasWakeUpSchedule() || s.GetSleepDuration() > 0
+}
+
+func scheduleKey(s *v1alpha1.SleepInfo) string {
+	if pairID := s.Annotations[pairIDAnnotation]; pairID != "" && s.Annotations[pairRoleAnnotation] != "" {
+		return "pair " + pairID
+	}
+	return s.Name
+}
+
+// groupSchedules groups the SleepInfos of each pair
+func groupSchedules(sleepInfos []*v1alpha1.SleepInfo) map[string]*schedule {
+	schedules := map[string]*schedule{}
+	for _, si := range sleepInfos {
+		key := scheduleKey(si)
+		if schedules[key] == nil {
+			schedules[key] = &schedule{name: "SleepInfo " + key}
+		}
+		if si.Annotations[pairRoleAnnotation] == pairRoleWake {
+			schedules[key].wakes = append(schedules[key].wakes, si)
+			continue
+		}
+		schedules[key].sleep = si
+	}
+	return schedules
+}
+
+// windows returns the windows asleep of the schedule from overlapHorizon before now to
+// overlapHorizon after now, nil when they are not known: without wake up or sleep SleepInfo
+func (s *schedule) windows(now time.Time) []asleepWindow {
+	if s.sleep == nil {
+		return nil
+	}
+	from, to := now.Add(-overlapHorizon), now.Add(overlapHorizon)
+	if s.sleep.IsWindow() {
+		window := s.sleep.Spec.Window
+		if window.End.Time.Before(from) || window.Start.Time.After(to) {
+			return nil
+		}
+		return []asleepWindow{{start: window.Start.Time, end: window.End.Time}}
+	}
+
+	sleepSchedule, err := parseSchedule(s.sleep.GetSleepSchedule())
+	if err != nil {
+		return nil
+	}
+	wakeUpAfter := s.wakeUpAfter()
+	if wakeUpAfter == nil {
+		return nil
+	}
+	windows := []asleepWindow{}
+	for t := from; len(windows) < maxWindows; {
+		start := sleepSchedule.Next(t)
+		if start.IsZero() || start.After(to) {
+			break
+		}
+		end := wakeUpAfter(start)
+		if end.IsZero() {
+			break
+		}
+		windows = append(windows, asleepWindow{start: start, end: end})
+		t = start
+	}
+	return windows
+}
+
+// wakeUpAfter returns the function returning the wake up after a sleep: the first wake SleepInfo of
+// the pair, sleepDuration, or wakeUpAt or the schedules. It returns nil when the schedule does not wake up.
+func (s *schedule) wakeUpAfter() func(time.Time) time.Time {
+	if s.sleep.Annotations[pairRoleAnnotation] == pairRoleSleep {
+		wakeUps := []cron.Schedule{}
+		for _, wake := range s.wakes {
+			if wakeUp, err := parseSchedule(wake.GetSleepSchedule()); err == nil {
+				wakeUps = append(wakeUps, wakeUp)
+			}
+		}
+		if len(wakeUps) == 0 {
+			return nil
+		}
+		return func(t time.Time) time.Time {
+			first := time.Time{}
+			for _, wakeUp := range wakeUps {
+				if next := wakeUp.Next(t); !next.IsZero() && (first.IsZero() || next.Before(first)) {
+					first = next
+				}
+			}
+			return first
+		}
+	}
+	if sleepDuration := s.sleep.GetSleepDuration(); sleepDuration > 0 {
+		return func(t time.Time) time.Time { return t.Add(sleepDuration) }
+	}
+	if !s.sleep.HasWakeUpSchedule() {
+		return nil
+	}
+	wakeUp, err := parseSchedule(s.sleep.GetWakeUpSchedule())
+	if err != nil {
+		return nil
+	}
+	return wakeUp.Next
+}
+
+func parseSchedule(spec string, err error) (cron.Schedule, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v1alpha1.ParseSchedule(spec)
+}
+
+// firstOverlap returns the first sleep or wake up from now of a window strictly inside a window of
+// the other windows
+func firstOverlap(windows, others []asleepWindow, now time.Time) (time.Time, bool) {
+	first := time.Time{}
+	check := func(at time.Time, windows []asleepWindow) {
+		if at.Before(now) {
+			return
+		}
+		for _, window := range windows {
+			if at.After(window.start) && at.Before(window.end) && (first.IsZero() || at.Before(first)) {
+				first = at
+			}
+		}
+	}
+	for _, window := range windows {
+		check(window.start, others)
+		check(window.end, others)
+	}
+	for _, window := range others {
+		check(window.start, windows)
+		check(window.end, windows)
+	}
+	return first, !first.IsZero()
+}
+
+// sameTargets returns whether the SleepInfos may put to sleep the same resources: they sleep the
+// same kinds, and do not include resources with different names only
+func sameTargets(a, b *v1alpha1.SleepInfo) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	kinds := targetKinds(a)
+	shared := false
+	for kind := range targetKinds(b) {
+		shared = shared || kinds[kind]
+	}
+	if !shared {
+		return false
+	}
+	namesA, okA := includedNames(a)
+	namesB, okB := includedNames(b)
+	if !okA || !okB {
+		return true
+	}
+	for name := range namesB {
+		if namesA[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// targetKinds returns the kinds of the resources put to sleep by the SleepInfo
+func targetKinds(s *v1alpha1.SleepInfo) map[string]bool {
+	kinds := map[string]bool{}
+	for _, patch := range s.GetPatches() {
+		kinds[patch.Target.Kind+"."+patch.Target.Group] = true
+	}
+	for _, target := range []struct {
+		suspended bool
+		target    v1alpha1.PatchTarget
+	}{
+		{s.IsPostgresToSuspend(), v1alpha1.PgClusterTarget},
+		{s.IsHdfsToSuspend(), v1alpha1.HDFSClusterTarget},
+		{s.IsOpenSearchToSuspend(), v1alpha1.OsClusterTarget},
+		{s.IsKafkaToSuspend(), v1alpha1.KafkaClusterTarget},
+	} {
+		if target.suspended {
+			kinds[target.target.Kind+"."+target.target.Group] = true
+		}
+	}
+	return kinds
+}
+
+// includedNames returns the names of the resources included by the SleepInfo, false when it does not
+// include resources by name only
+func includedNames(s *v1alpha1.SleepInfo) (map[string]bool, bool) {
+	if len(s.Spec.IncludeRef) == 0 {
+		return nil, false
+	}
+	names := map[string]bool{}
+	for _, ref := range s.Spec.IncludeRef {
+		if ref.Name == "" || len(ref.MatchLabels) > 0 || len(ref.MatchExpressions) > 0 {
+			return nil, false
+		}
+		names[ref.Name] = true
+	}
+	return names, true
+}
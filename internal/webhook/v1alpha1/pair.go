@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kube-green/kube-green/api/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pairIDAnnotation   = "kube-green.stratio.com/pair-id"
+	pairRoleAnnotation = "kube-green.stratio.com/pair-role"
+	pairRoleSleep      = "sleep"
+	pairRoleWake       = "wake"
+)
+
+// validatePair checks the paired SleepInfo convention: a pair has one sleep SleepInfo, created
+// before its wake SleepInfos, and the wake SleepInfos wake up at their sleepAt, so they do not set
+// wakeUpAt. The staged wake up has several wake SleepInfos in the same pair.
+func (v *customValidator) validatePair(ctx context.Context, s *v1alpha1.SleepInfo) error {
+	role, ok := s.Annotations[pairRoleAnnotation]
+	if !ok {
+		return nil
+	}
+	if role != pairRoleSleep && role != pairRoleWake {
+		return fmt.Errorf("%s annotation %q is invalid: must be %s or %s", pairRoleAnnotation, role, pairRoleSleep, pairRoleWake)
+	}
+	pairID := s.Annotations[pairIDAnnotation]
+	if pairID == "" {
+		return fmt.Errorf("%s annotation is required with %s", pairIDAnnotation, pairRoleAnnotation)
+	}
+	if role == pairRoleWake && s.HasWakeUpSchedule() {
+		return fmt.Errorf("wake SleepInfo of pair %s must not set wakeUpAt or schedules: it wakes up at its sleepAt", pairID)
+	}
+
+	sleepInfoList := &v1alpha1.SleepInfoList{}
+	if err := v.reader().List(ctx, sleepInfoList, client.InNamespace(s.Namespace)); err != nil {
+		return fmt.Errorf("fails to list the SleepInfos of pair %s: %w", pairID, err)
+	}
+	sleepName := ""
+	for _, si := range sleepInfoList.Items {
+		if si.Name != s.Name && si.DeletionTimestamp.IsZero() && si.Annotations[pairIDAnnotation] == pairID && si.Annotations[pairRoleAnnotation] == pairRoleSleep {
+			sleepName = si.Name
+			break
+		}
+	}
+	if role == pairRoleSleep && sleepName != "" {
+		return fmt.Errorf("pair %s already has the sleep SleepInfo %s", pairID, sleepName)
+	}
+	if role == pairRoleWake && sleepName == "" {
+		return fmt.Errorf("pair %s has no sleep SleepInfo in namespace %s", pairID, s.Namespace)
+	}
+	return nil
+}
+
+// pairChanged returns whether an update changes the pairing of the SleepInfo. The SleepInfos being
+// deleted are not validated again, so that their finalizers can always be removed.
+func pairChanged(old, new *v1alpha1.SleepInfo) bool {
+	if !new.DeletionTimestamp.IsZero() {
+		return false
+	}
+	return old.Annotations[pairIDAnnotation] != new.Annotations[pairIDAnnotation] ||
+		old.Annotations[pairRoleAnnotation] != new.Annotations[pairRoleAnnotation] ||
+		old.HasWakeUpSchedule() != new.HasWakeUpSchedule()
+}
+
+// reader reads the SleepInfos of a pair from the API server, since the sleep SleepInfo is created
+// right before its wake SleepInfos and may not be in the cache yet
+func (v *customValidator) reader() client.Reader {
+	if v.Reader != nil {
+		return v.Reader
+	}
+	return v.Client
+}
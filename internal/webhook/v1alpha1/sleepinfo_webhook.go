@@ -7,9 +7,18 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/kube-green/kube-green/api/v1alpha1"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,17 +32,30 @@ var sleepinfolog = logf.Log.WithName("sleepinfo-resource")
 
 type customValidator struct {
 	Client client.Client
+	// Reader reads the SleepInfos of a pair, without the cache of Client
+	Reader client.Reader
 }
 
-func SetupWebhookWithManager(mgr ctrl.Manager) error {
+// SetupWebhookWithManager registers the webhooks of the SleepInfos. The SleepInfos without timeZone
+// are defaulted to timeZone.
+func SetupWebhookWithManager(mgr ctrl.Manager, timeZone string) error {
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return fmt.Errorf("default time zone %s is invalid: %w", timeZone, err)
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.SleepInfo{}).
+		WithDefaulter(&customDefaulter{
+			TimeZone: timeZone,
+		}).
 		WithValidator(&customValidator{
 			Client: mgr.GetClient(),
+			Reader: mgr.GetAPIReader(),
 		}).
 		Complete()
 }
 
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
 // +kubebuilder:webhook:path=/validate-kube-green-com-v1alpha1-sleepinfo,mutating=false,failurePolicy=fail,sideEffects=None,groups=kube-green.com,resources=sleepinfos,verbs=create;update,versions=v1alpha1,name=vsleepinfo.kb.io,admissionReviewVersions=v1
 var _ webhook.CustomValidator = &customValidator{}
 
@@ -45,18 +67,199 @@ func (v *customValidator) ValidateCreate(ctx context.Context, obj runtime.Object
 	}
 	sleepinfolog.Info("validate create", "name", s.Name, "namespace", s.Namespace)
 
-	return s.Validate(v.Client)
+	warnings, err := s.Validate(v.Client)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.validatePair(ctx, s); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, v.overlapWarnings(ctx, s, time.Now())...)
+	if s.Spec.NodeScaleDown != nil {
+		if err := v.validateNodeScaleDown(ctx); err != nil {
+			return warnings, err
+		}
+	}
+	if err := v.validateHookJobs(ctx, s); err != nil {
+		return warnings, err
+	}
+	if s.Spec.NamespaceSelector == nil {
+		return warnings, nil
+	}
+	return warnings, v.validateTargetNamespaces(ctx, s)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (v *customValidator) ValidateUpdate(ctx context.Context, _, new runtime.Object) (admission.Warnings, error) {
+func (v *customValidator) ValidateUpdate(ctx context.Context, old, new runtime.Object) (admission.Warnings, error) {
 	s, ok := new.(*v1alpha1.SleepInfo)
 	if !ok {
 		return nil, fmt.Errorf("fails to decode SleepInfo")
 	}
 	sleepinfolog.Info("validate update", "name", s.Name, "namespace", s.Namespace)
 
-	return s.Validate(v.Client)
+	warnings, err := s.Validate(v.Client)
+	if err != nil {
+		return warnings, err
+	}
+	oldSleepInfo, ok := old.(*v1alpha1.SleepInfo)
+	if !ok || pairChanged(oldSleepInfo, s) {
+		if err := v.validatePair(ctx, s); err != nil {
+			return warnings, err
+		}
+	}
+	if !ok || pairChanged(oldSleepInfo, s) || !equality.Semantic.DeepEqual(oldSleepInfo.Spec, s.Spec) {
+		warnings = append(warnings, v.overlapWarnings(ctx, s, time.Now())...)
+	}
+	if s.Spec.NodeScaleDown != nil && (!ok || !equality.Semantic.DeepEqual(oldSleepInfo.Spec.NodeScaleDown, s.Spec.NodeScaleDown)) {
+		if err := v.validateNodeScaleDown(ctx); err != nil {
+			return warnings, err
+		}
+	}
+	if !ok || !equality.Semantic.DeepEqual(oldSleepInfo.Spec.PreSleepHooks, s.Spec.PreSleepHooks) ||
+		!equality.Semantic.DeepEqual(oldSleepInfo.Spec.PostWakeHooks, s.Spec.PostWakeHooks) {
+		if err := v.validateHookJobs(ctx, s); err != nil {
+			return warnings, err
+		}
+	}
+	if s.Spec.NamespaceSelector == nil {
+		return warnings, nil
+	}
+	if ok && equality.Semantic.DeepEqual(oldSleepInfo.Spec.NamespaceSelector, s.Spec.NamespaceSelector) {
+		return warnings, nil
+	}
+	return warnings, v.validateTargetNamespaces(ctx, s)
+}
+
+// validateTargetNamespaces checks that the user creating or updating a SleepInfo with
+// namespaceSelector is allowed to create SleepInfos in all the namespaces selected, since the
+// controller copies it there.
+func (v *customValidator) validateTargetNamespaces(ctx context.Context, s *v1alpha1.SleepInfo) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fails to get the user of the request: %w", err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.Spec.NamespaceSelector)
+	if err != nil {
+		return fmt.Errorf("namespaceSelector is invalid: %w", err)
+	}
+	namespaceList := &v1.NamespaceList{}
+	if err := v.Client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return fmt.Errorf("fails to list the namespaces selected: %w", err)
+	}
+
+	denied := []string{}
+	for _, namespace := range namespaceList.Items {
+		if namespace.Name == s.Namespace {
+			continue
+		}
+		review := subjectAccessReview(req, &authorizationv1.ResourceAttributes{
+			Namespace: namespace.Name,
+			Verb:      "create",
+			Group:     v1alpha1.GroupVersion.Group,
+			Resource:  "sleepinfos",
+		})
+		if err := v.Client.Create(ctx, review); err != nil {
+			return fmt.Errorf("fails to review access to namespace %s: %w", namespace.Name, err)
+		}
+		if !review.Status.Allowed {
+			denied = append(denied, namespace.Name)
+		}
+	}
+	if len(denied) > 0 {
+		sort.Strings(denied)
+		return fmt.Errorf("not allowed to create SleepInfos in namespaces: %s", strings.Join(denied, ", "))
+	}
+	return nil
+}
+
+// validateNodeScaleDown checks that the user setting or changing nodeScaleDown is allowed to patch
+// nodes, since the controller cordons, taints or releases the nodes selected by its nodeSelector.
+func (v *customValidator) validateNodeScaleDown(ctx context.Context) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fails to get the user of the request: %w", err)
+	}
+	review := subjectAccessReview(req, &authorizationv1.ResourceAttributes{
+		Verb:     "patch",
+		Resource: "nodes",
+	})
+	if err := v.Client.Create(ctx, review); err != nil {
+		return fmt.Errorf("fails to review access to nodes: %w", err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("nodeScaleDown is not allowed: %s cannot patch nodes", req.UserInfo.Username)
+	}
+	return nil
+}
+
+// validateHookJobs checks that the user setting Job hooks is allowed to create Jobs in the namespace of
+// the SleepInfo, and to request tokens of the ServiceAccounts the Jobs run as, since the controller
+// creates the Jobs with its own permissions.
+func (v *customValidator) validateHookJobs(ctx context.Context, s *v1alpha1.SleepInfo) error {
+	serviceAccounts := map[string]bool{}
+	hasJobs := false
+	for _, hook := range append(slices.Clone(s.Spec.PreSleepHooks), s.Spec.PostWakeHooks...) {
+		if hook.Job == nil {
+			continue
+		}
+		hasJobs = true
+		if name := hook.Job.Spec.Template.Spec.ServiceAccountName; name != "" {
+			serviceAccounts[name] = true
+		}
+	}
+	if !hasJobs {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("fails to get the user of the request: %w", err)
+	}
+
+	review := subjectAccessReview(req, &authorizationv1.ResourceAttributes{
+		Namespace: s.Namespace,
+		Verb:      "create",
+		Group:     "batch",
+		Resource:  "jobs",
+	})
+	if err := v.Client.Create(ctx, review); err != nil {
+		return fmt.Errorf("fails to review access to jobs: %w", err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("job hooks are not allowed: %s cannot create jobs in namespace %s", req.UserInfo.Username, s.Namespace)
+	}
+	for _, name := range slices.Sorted(maps.Keys(serviceAccounts)) {
+		review := subjectAccessReview(req, &authorizationv1.ResourceAttributes{
+			Namespace:   s.Namespace,
+			Verb:        "create",
+			Resource:    "serviceaccounts",
+			Subresource: "token",
+			Name:        name,
+		})
+		if err := v.Client.Create(ctx, review); err != nil {
+			return fmt.Errorf("fails to review access to service account %s: %w", name, err)
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("job hooks are not allowed: %s cannot run as service account %s", req.UserInfo.Username, name)
+		}
+	}
+	return nil
+}
+
+// subjectAccessReview returns the review of the access of the user of the request to a resource
+func subjectAccessReview(req admission.Request, attributes *authorizationv1.ResourceAttributes) *authorizationv1.SubjectAccessReview {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	return &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               req.UserInfo.Username,
+			Groups:             req.UserInfo.Groups,
+			UID:                req.UserInfo.UID,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -6,12 +6,23 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/kube-green/kube-green/api/v1alpha1"
 	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 func TestSleepInfoValidation(t *testing.T) {
@@ -98,3 +109,448 @@ func TestSleepInfoValidation(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSleepInfoTargetNamespacesValidation(t *testing.T) {
+	namespace := func(name string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": "dev"}}}
+	}
+	reviewed := []string{}
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(namespace("control"), namespace("dev-api"), namespace("dev-web")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				require.Equal(t, "jane", review.Spec.User)
+				reviewed = append(reviewed, review.Spec.ResourceAttributes.Namespace)
+				review.Status.Allowed = review.Spec.ResourceAttributes.Namespace == "dev-api"
+				return nil
+			},
+		}).Build()
+	customValidator := &customValidator{
+		Client: fakeClient,
+	}
+	sleepInfo := &v1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "name",
+			Namespace: "control",
+		},
+		Spec: v1alpha1.SleepInfoSpec{
+			SleepTime:         "20:00",
+			Weekdays:          "1-5",
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+		},
+	}
+	ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "jane"}},
+	})
+
+	t.Run("create - denied namespaces", func(t *testing.T) {
+		reviewed = []string{}
+		_, err := customValidator.ValidateCreate(ctx, sleepInfo)
+		require.EqualError(t, err, "not allowed to create SleepInfos in namespaces: dev-web")
+		require.ElementsMatch(t, []string{"dev-api", "dev-web"}, reviewed)
+	})
+
+	t.Run("create - without admission request", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(context.Background(), sleepInfo)
+		require.ErrorContains(t, err, "fails to get the user of the request")
+	})
+
+	t.Run("update - namespaceSelector unchanged", func(t *testing.T) {
+		reviewed = []string{}
+		_, err := customValidator.ValidateUpdate(ctx, sleepInfo.DeepCopy(), sleepInfo)
+		require.NoError(t, err)
+		require.Empty(t, reviewed)
+	})
+
+	t.Run("update - namespaceSelector changed", func(t *testing.T) {
+		oldSleepInfo := sleepInfo.DeepCopy()
+		oldSleepInfo.Spec.NamespaceSelector = nil
+		_, err := customValidator.ValidateUpdate(ctx, oldSleepInfo, sleepInfo)
+		require.EqualError(t, err, "not allowed to create SleepInfos in namespaces: dev-web")
+	})
+}
+
+func TestSleepInfoNodeScaleDownValidation(t *testing.T) {
+	reviewed := 0
+	fakeClient := fake.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				reviewed++
+				require.Equal(t, "nodes", review.Spec.ResourceAttributes.Resource)
+				require.Equal(t, "patch", review.Spec.ResourceAttributes.Verb)
+				review.Status.Allowed = review.Spec.User == "admin"
+				return nil
+			},
+		}).Build()
+	customValidator := &customValidator{Client: fakeClient}
+	sleepInfo := &v1alpha1.SleepInfo{
+		ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		Spec: v1alpha1.SleepInfoSpec{
+			SleepTime:     "20:00",
+			Weekdays:      "1-5",
+			NodeScaleDown: &v1alpha1.NodeScaleDown{NodeSelector: map[string]string{"pool": "dev"}},
+		},
+	}
+	userContext := func(username string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: username}},
+		})
+	}
+
+	t.Run("create - allowed to patch nodes", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("admin"), sleepInfo)
+		require.NoError(t, err)
+	})
+
+	t.Run("create - not allowed to patch nodes", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("jane"), sleepInfo)
+		require.EqualError(t, err, "nodeScaleDown is not allowed: jane cannot patch nodes")
+	})
+
+	t.Run("update - nodeScaleDown unchanged", func(t *testing.T) {
+		reviewed = 0
+		_, err := customValidator.ValidateUpdate(userContext("jane"), sleepInfo.DeepCopy(), sleepInfo)
+		require.NoError(t, err)
+		require.Zero(t, reviewed)
+	})
+
+	t.Run("update - nodeScaleDown changed", func(t *testing.T) {
+		updated := sleepInfo.DeepCopy()
+		updated.Spec.NodeScaleDown.NodeSelector = map[string]string{"pool": "system"}
+		_, err := customValidator.ValidateUpdate(userContext("jane"), sleepInfo, updated)
+		require.EqualError(t, err, "nodeScaleDown is not allowed: jane cannot patch nodes")
+	})
+}
+
+func TestSleepInfoHookJobsValidation(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				attributes := review.Spec.ResourceAttributes
+				switch {
+				case attributes.Resource == "jobs":
+					review.Status.Allowed = review.Spec.User != "viewer"
+				case attributes.Resource == "serviceaccounts" && attributes.Subresource == "token":
+					review.Status.Allowed = attributes.Name == "checkpoint"
+				}
+				return nil
+			},
+		}).Build()
+	customValidator := &customValidator{Client: fakeClient}
+	newSleepInfo := func(serviceAccountName string) *v1alpha1.SleepInfo {
+		return &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: v1alpha1.SleepInfoSpec{
+				SleepTime: "20:00",
+				Weekdays:  "1-5",
+				PreSleepHooks: []v1alpha1.Hook{{
+					Name: "checkpoint",
+					Job: &batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+						ServiceAccountName: serviceAccountName,
+						Containers:         []v1.Container{{Name: "checkpoint", Image: "busybox"}},
+					}}}},
+				}},
+			},
+		}
+	}
+	userContext := func(username string) context.Context {
+		return admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: username}},
+		})
+	}
+
+	t.Run("allowed to create jobs", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("jane"), newSleepInfo(""))
+		require.NoError(t, err)
+	})
+
+	t.Run("not allowed to create jobs", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("viewer"), newSleepInfo(""))
+		require.EqualError(t, err, "job hooks are not allowed: viewer cannot create jobs in namespace namespace")
+	})
+
+	t.Run("allowed to run as the service account", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("jane"), newSleepInfo("checkpoint"))
+		require.NoError(t, err)
+	})
+
+	t.Run("not allowed to run as the service account", func(t *testing.T) {
+		_, err := customValidator.ValidateCreate(userContext("jane"), newSleepInfo("cluster-admin"))
+		require.EqualError(t, err, "job hooks are not allowed: jane cannot run as service account cluster-admin")
+	})
+
+	t.Run("update - hooks unchanged", func(t *testing.T) {
+		sleepInfo := newSleepInfo("cluster-admin")
+		_, err := customValidator.ValidateUpdate(userContext("jane"), sleepInfo.DeepCopy(), sleepInfo)
+		require.NoError(t, err)
+	})
+}
+
+func TestSleepInfoPairValidation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	paired := func(name, pairID, role string) *v1alpha1.SleepInfo {
+		return &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "bdadevdat-datastores",
+				Annotations: map[string]string{pairIDAnnotation: pairID, pairRoleAnnotation: role},
+			},
+			Spec: v1alpha1.SleepInfoSpec{
+				SleepTime: "20:00",
+				Weekdays:  "1-5",
+			},
+		}
+	}
+	sleep := paired("sleep-weekend", "weekend", pairRoleSleep)
+	customValidator := &customValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(sleep, paired("wake-weekend-pg-hdfs", "weekend", pairRoleWake)).Build(),
+	}
+
+	tests := []struct {
+		name      string
+		sleepInfo *v1alpha1.SleepInfo
+		expected  string
+	}{
+		{
+			name:      "staged wake up",
+			sleepInfo: paired("wake-weekend", "weekend", pairRoleWake),
+		},
+		{
+			name:      "wake without sleep",
+			sleepInfo: paired("wake-nightly", "nightly", pairRoleWake),
+			expected:  "pair nightly has no sleep SleepInfo in namespace bdadevdat-datastores",
+		},
+		{
+			name:      "duplicate sleep",
+			sleepInfo: paired("sleep-weekend-2", "weekend", pairRoleSleep),
+			expected:  "pair weekend already has the sleep SleepInfo sleep-weekend",
+		},
+		{
+			name: "wake with wakeUpAt",
+			sleepInfo: func() *v1alpha1.SleepInfo {
+				wake := paired("wake-weekend", "weekend", pairRoleWake)
+				wake.Spec.WakeUpTime = "08:00"
+				return wake
+			}(),
+			expected: "wake SleepInfo of pair weekend must not set wakeUpAt or schedules: it wakes up at its sleepAt",
+		},
+		{
+			name:      "unknown role",
+			sleepInfo: paired("wake-weekend", "weekend", "window"),
+			expected:  `kube-green.stratio.com/pair-role annotation "window" is invalid: must be sleep or wake`,
+		},
+		{
+			name:      "role without pair",
+			sleepInfo: paired("wake-weekend", "", pairRoleWake),
+			expected:  "kube-green.stratio.com/pair-id annotation is required with kube-green.stratio.com/pair-role",
+		},
+	}
+	for _, test := range tests {
+		t.Run("create - "+test.name, func(t *testing.T) {
+			_, err := customValidator.ValidateCreate(context.Background(), test.sleepInfo)
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	t.Run("update - sleep itself", func(t *testing.T) {
+		updated := sleep.DeepCopy()
+		updated.Spec.WakeUpTime = "08:00"
+		_, err := customValidator.ValidateUpdate(context.Background(), sleep, updated)
+		require.NoError(t, err)
+	})
+
+	t.Run("update - pair unchanged", func(t *testing.T) {
+		orphan := paired("wake-nightly", "nightly", pairRoleWake)
+		updated := orphan.DeepCopy()
+		updated.Finalizers = []string{v1alpha1.WakeUpFinalizer}
+		_, err := customValidator.ValidateUpdate(context.Background(), orphan, updated)
+		require.NoError(t, err)
+	})
+
+	t.Run("update - pair changed", func(t *testing.T) {
+		wake := paired("wake-weekend", "weekend", pairRoleWake)
+		updated := paired("wake-weekend", "nightly", pairRoleWake)
+		_, err := customValidator.ValidateUpdate(context.Background(), wake, updated)
+		require.EqualError(t, err, "pair nightly has no sleep SleepInfo in namespace bdadevdat-datastores")
+	})
+}
+
+func TestSleepInfoDefaulting(t *testing.T) {
+	defaulter := &customDefaulter{TimeZone: "Europe/Madrid"}
+
+	t.Run("defaults the spec and the managed-by label", func(t *testing.T) {
+		sleepInfo := &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec:       v1alpha1.SleepInfoSpec{Weekdays: "5,1,2,3,4", SleepTime: "20:00"},
+		}
+		require.NoError(t, defaulter.Default(context.Background(), sleepInfo))
+		require.Equal(t, "1-5", sleepInfo.Spec.Weekdays)
+		require.Equal(t, "Europe/Madrid", sleepInfo.Spec.TimeZone)
+		require.True(t, *sleepInfo.Spec.SuspendDeployments)
+		require.True(t, *sleepInfo.Spec.SuspendStatefulSets)
+		require.Equal(t, map[string]string{managedByLabel: managedBy}, sleepInfo.Labels)
+	})
+
+	t.Run("keeps the managed-by label of other tools", func(t *testing.T) {
+		sleepInfo := &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace", Labels: map[string]string{managedByLabel: "Helm"}},
+			Spec:       v1alpha1.SleepInfoSpec{Weekdays: "1-5", SleepTime: "20:00", TimeZone: "America/Bogota"},
+		}
+		require.NoError(t, defaulter.Default(context.Background(), sleepInfo))
+		require.Equal(t, "Helm", sleepInfo.Labels[managedByLabel])
+		require.Equal(t, "America/Bogota", sleepInfo.Spec.TimeZone)
+	})
+
+	t.Run("records the user setting the namespaceSelector", func(t *testing.T) {
+		sleepInfo := &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "name",
+				Namespace:   "namespace",
+				Annotations: map[string]string{v1alpha1.NamespaceSelectorRequesterAnnotation: `{"username":"admin"}`},
+			},
+			Spec: v1alpha1.SleepInfoSpec{
+				Weekdays:          "1-5",
+				SleepTime:         "20:00",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+		}
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				UserInfo:  authenticationv1.UserInfo{Username: "jane", Groups: []string{"devs"}},
+			},
+		})
+		require.NoError(t, defaulter.Default(ctx, sleepInfo))
+		requester, ok := sleepInfo.NamespaceSelectorRequester()
+		require.True(t, ok)
+		require.Equal(t, v1alpha1.Requester{Username: "jane", Groups: []string{"devs"}}, requester)
+	})
+
+	t.Run("keeps the requester on updates leaving the namespaceSelector unchanged", func(t *testing.T) {
+		sleepInfo := &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "name",
+				Namespace:   "namespace",
+				Annotations: map[string]string{v1alpha1.NamespaceSelectorRequesterAnnotation: `{"username":"jane"}`},
+			},
+			Spec: v1alpha1.SleepInfoSpec{
+				Weekdays:          "1-5",
+				SleepTime:         "20:00",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+		}
+		old, err := json.Marshal(sleepInfo)
+		require.NoError(t, err)
+		ctx := admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Update,
+				UserInfo:  authenticationv1.UserInfo{Username: "system:serviceaccount:kube-green:controller"},
+				OldObject: runtime.RawExtension{Raw: old},
+			},
+		})
+		sleepInfo.Finalizers = []string{v1alpha1.TargetNamespacesFinalizer}
+		require.NoError(t, defaulter.Default(ctx, sleepInfo))
+		requester, ok := sleepInfo.NamespaceSelectorRequester()
+		require.True(t, ok)
+		require.Equal(t, "jane", requester.Username)
+	})
+}
+
+func TestSleepInfoOverlapWarnings(t *testing.T) {
+	now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	suspend := false
+	newSleepInfo := func(name, weekdays, sleepAt, wakeUpAt string) *v1alpha1.SleepInfo {
+		return &v1alpha1.SleepInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "bdadevdat-apps"},
+			Spec: v1alpha1.SleepInfoSpec{
+				Weekdays:   weekdays,
+				SleepTime:  sleepAt,
+				WakeUpTime: wakeUpAt,
+				TimeZone:   "UTC",
+			},
+		}
+	}
+	weekendSleep := newSleepInfo("sleep-weekend", "5", "22:00", "")
+	weekendSleep.Annotations = map[string]string{pairIDAnnotation: "weekend", pairRoleAnnotation: pairRoleSleep}
+	weekendSleep.Spec.SuspendDeployments = &suspend
+	weekendSleep.Spec.SuspendStatefulSets = &suspend
+	weekendSleep.Spec.SuspendCronjobs = true
+	weekendWake := newSleepInfo("wake-weekend", "1", "08:00", "")
+	weekendWake.Annotations = map[string]string{pairIDAnnotation: "weekend", pairRoleAnnotation: pairRoleWake}
+	customValidator := &customValidator{
+		Reader: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newSleepInfo("nightly", "1-5", "22:00", "06:00"),
+			weekendSleep,
+			weekendWake,
+		).Build(),
+	}
+
+	t.Run("wakes up the resources asleep", func(t *testing.T) {
+		warnings := customValidator.overlapWarnings(context.Background(), newSleepInfo("evening", "1-4", "20:00", "22:30"), now)
+		require.Equal(t, admission.Warnings{
+			"the windows asleep overlap those of SleepInfo nightly, which also puts to sleep its resources: " +
+				"at Wed 22:00 UTC one of them sleeps or wakes up the resources the other keeps awake or asleep",
+		}, warnings)
+	})
+
+	t.Run("overlaps a pair", func(t *testing.T) {
+		cronJobs := newSleepInfo("friday", "5", "20:00", "23:00")
+		cronJobs.Spec.SuspendDeployments = &suspend
+		cronJobs.Spec.SuspendStatefulSets = &suspend
+		cronJobs.Spec.SuspendCronjobs = true
+		warnings := customValidator.overlapWarnings(context.Background(), cronJobs, now)
+		require.Len(t, warnings, 1)
+		require.Contains(t, warnings[0], "SleepInfo pair weekend")
+		require.Contains(t, warnings[0], "at Fri 22:00 UTC")
+	})
+
+	t.Run("same windows", func(t *testing.T) {
+		require.Empty(t, customValidator.overlapWarnings(context.Background(), newSleepInfo("nightly-copy", "1-5", "22:00", "06:00"), now))
+	})
+
+	t.Run("other resources", func(t *testing.T) {
+		cronJobs := newSleepInfo("evening", "1-4", "20:00", "22:30")
+		cronJobs.Spec.SuspendDeployments = &suspend
+		cronJobs.Spec.SuspendStatefulSets = &suspend
+		cronJobs.Spec.SuspendCronjobs = true
+		require.Empty(t, customValidator.overlapWarnings(context.Background(), cronJobs, now))
+	})
+
+	t.Run("without wake up", func(t *testing.T) {
+		require.Empty(t, customValidator.overlapWarnings(context.Background(), newSleepInfo("evening", "1-4", "20:00", ""), now))
+	})
+}
+
+func TestSameTargets(t *testing.T) {
+	included := func(names ...string) *v1alpha1.SleepInfo {
+		sleepInfo := &v1alpha1.SleepInfo{}
+		for _, name := range names {
+			sleepInfo.Spec.IncludeRef = append(sleepInfo.Spec.IncludeRef, v1alpha1.FilterRef{Kind: "Deployment", Name: name})
+		}
+		return sleepInfo
+	}
+	require.True(t, sameTargets(included(), included("api")))
+	require.True(t, sameTargets(included("api", "web"), included("web")))
+	require.False(t, sameTargets(included("api"), included("web")))
+	require.False(t, sameTargets(included(), nil))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+*/
+
+// Package apiclient is the Go client of the kube-green REST API. The request and response types and a
+// method of Client per endpoint are generated by hack/sdkgen, with make sdk, from the OpenAPI definition
+// of the API (swagger.json), so they match the types of the server.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client calls the REST API of kube-green
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken authenticates the requests with a bearer token: a JWT of /api/v1/auth/login or a
+// Kubernetes token, depending on the authentication of the server
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sends the requests with httpClient instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g. http://kube-green:8080
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{baseURL: strings.TrimSuffix(baseURL, "/"), httpClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// APIError is an error response of the API
+type APIError struct {
+	StatusCode int
+	Message    string // error of the response, or its body when it is not JSON
+	Body       []byte // the whole response, e.g. a ScheduleWriteErrorResponse
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kube-green API: %s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// call sends a request to the API and returns the body of its successful response
+func (c *Client) call(ctx context.Context, method, path string, query url.Values, request interface{}) ([]byte, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var body io.Reader
+	if request != nil {
+		content, err := json.Marshal(request)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the request: %w", err)
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(content)), Body: content}
+		errorResponse := struct {
+			Error string `json:"error"`
+		}{}
+		if json.Unmarshal(content, &errorResponse) == nil && errorResponse.Error != "" {
+			apiErr.Message = errorResponse.Error
+		}
+		return nil, apiErr
+	}
+	return content, nil
+}
+
+// decodeData decodes the data of an APIResponse into out
+func decodeData(body []byte, out interface{}) error {
+	response := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("invalid API response: %w", err)
+	}
+	if len(response.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response.Data, out)
+}
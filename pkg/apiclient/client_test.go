@@ -0,0 +1,62 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var lastRequest *http.Request
+	var lastBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastRequest = r
+		lastBody, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/schedules/bdadevdat/next":
+			_, _ = w.Write([]byte(`{"success":true,"data":{"tenant":"bdadevdat","occurrences":[]}}`))
+		case "/api/v1/schedules":
+			_, _ = w.Write([]byte(`{"success":true,"message":"created"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"success":false,"error":"no schedules found for tenant other","code":"NOT_FOUND"}`))
+		}
+	}))
+	defer server.Close()
+	c := New(server.URL+"/", WithToken("secret"))
+
+	t.Run("decodes the data of the response", func(t *testing.T) {
+		next, err := c.GetNextOperation(context.Background(), "bdadevdat", GetNextOperationParams{Count: 3})
+		require.NoError(t, err)
+		require.Equal(t, "bdadevdat", next.Tenant)
+		require.Equal(t, "3", lastRequest.URL.Query().Get("count"))
+		require.Equal(t, "Bearer secret", lastRequest.Header.Get("Authorization"))
+	})
+
+	t.Run("sends the request body", func(t *testing.T) {
+		response, err := c.CreateSchedule(context.Background(), CreateScheduleRequest{Tenant: "bdadevdat", Off: "22:00"})
+		require.NoError(t, err)
+		require.Equal(t, "created", response.Message)
+		require.Equal(t, "application/json", lastRequest.Header.Get("Content-Type"))
+		request := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(lastBody, &request))
+		require.Equal(t, "bdadevdat", request["tenant"])
+		require.Equal(t, "22:00", request["off"])
+	})
+
+	t.Run("returns the error of the API", func(t *testing.T) {
+		_, err := c.GetNextOperation(context.Background(), "other", GetNextOperationParams{})
+		apiErr := &APIError{}
+		require.True(t, errors.As(err, &apiErr))
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+		require.Equal(t, "no schedules found for tenant other", apiErr.Message)
+		require.Empty(t, lastRequest.URL.RawQuery)
+	})
+}
@@ -0,0 +1,1979 @@
+// Code generated by hack/sdkgen from swagger.json. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	kubegreenv1alpha1 "github.com/kube-green/kube-green/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// APIResponse is generated from the v1.APIResponse definition.
+//
+// Standard API response structure
+type APIResponse struct {
+	// Optional response data
+	Data json.RawMessage `json:"data,omitempty"`
+	// Optional error message (if success is false)
+	Error string `json:"error,omitempty"`
+	// Optional success message
+	Message string `json:"message,omitempty"`
+	// Indicates if the operation was successful
+	Success bool `json:"success,omitempty"`
+}
+
+// BulkDeleteItemResult is generated from the v1.BulkDeleteItemResult definition.
+type BulkDeleteItemResult struct {
+	Error string `json:"error,omitempty"`
+	// SleepInfo name, selector deletions only
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// deleted, not_found or failed
+	Status string `json:"status,omitempty"`
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// BulkDeleteResponse is generated from the v1.BulkDeleteResponse definition.
+type BulkDeleteResponse struct {
+	Deleted  int                    `json:"deleted,omitempty"`
+	Failed   int                    `json:"failed,omitempty"`
+	NotFound int                    `json:"notFound,omitempty"`
+	Results  []BulkDeleteItemResult `json:"results,omitempty"`
+}
+
+// CloneItemResult is generated from the v1.CloneItemResult definition.
+type CloneItemResult struct {
+	Delays *DelayConfig `json:"delays,omitempty"`
+	Error  string       `json:"error,omitempty"`
+	// Custom exclusions, re-resolved for the target
+	Exclusions []ExclusionFilter `json:"exclusions,omitempty"`
+	// Inclusions, re-resolved for the target
+	Inclusions []ExclusionFilter `json:"inclusions,omitempty"`
+	// Full target namespace name
+	Namespace string `json:"namespace,omitempty"`
+	// User timezone
+	Off string `json:"off,omitempty"`
+	// User timezone
+	On           string `json:"on,omitempty"`
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Full source namespace name
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+	// created or failed
+	Status string `json:"status,omitempty"`
+	Tenant string `json:"tenant,omitempty"`
+}
+
+// CloneScheduleRequest is generated from the v1.CloneScheduleRequest definition.
+type CloneScheduleRequest struct {
+	// Optional source namespace suffix
+	Namespace string `json:"namespace,omitempty"`
+	// Optional source schedule name
+	ScheduleName string        `json:"scheduleName,omitempty"`
+	Targets      []CloneTarget `json:"targets"`
+}
+
+// CloneScheduleResponse is generated from the v1.CloneScheduleResponse definition.
+type CloneScheduleResponse struct {
+	Created      int               `json:"created,omitempty"`
+	Failed       int               `json:"failed,omitempty"`
+	Results      []CloneItemResult `json:"results,omitempty"`
+	SourceTenant string            `json:"sourceTenant,omitempty"`
+}
+
+// CloneTarget is generated from the v1.CloneTarget definition.
+type CloneTarget struct {
+	Namespaces []string `json:"namespaces,omitempty"`
+	Tenant     string   `json:"tenant"`
+}
+
+// CreateScheduleRequest is generated from the v1.CreateScheduleRequest definition.
+//
+// Request to create a new sleep/wake schedule for a tenant
+type CreateScheduleRequest struct {
+	// Always applies to cluster (field is ignored but kept for compatibility)
+	Apply bool `json:"apply,omitempty"`
+	// Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"})
+	Delays *DelayConfig `json:"delays,omitempty"`
+	// Optional: description of the schedule
+	Description string `json:"description,omitempty"`
+	// Optional: hours asleep after off, instead of on (e.g. 8 or 10.5)
+	DurationHours float64 `json:"durationHours,omitempty"`
+	// Optional: resources of each namespace never put to sleep, added to the automatic exclusions
+	Exclusions []NamespaceExclusion `json:"exclusions,omitempty"`
+	// Optional: sleep and wake up once at the next scheduled times, then delete the schedule
+	ExecuteOnce bool `json:"executeOnce,omitempty"`
+	// Optional: holiday calendar and what the schedule does on holidays
+	Holidays *HolidayConfig `json:"holidays,omitempty"`
+	// Optional: only the matching resources of each namespace are put to sleep
+	Inclusions []NamespaceInclusion `json:"inclusions,omitempty"`
+	// Optional: Karpenter NodePools scaled to zero while asleep, restored 5 minutes before the wake up
+	KarpenterNodePools []string `json:"karpenterNodePools,omitempty"`
+	// Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso)
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Sleep time in local timezone (HH:MM format, 24-hour)
+	Off string `json:"off,omitempty"`
+	// Optional: cron expression of the sleep in local timezone, instead of off and weekdays (6#1: first Saturday of the month)
+	OffCron string `json:"offCron,omitempty"`
+	// Wake time in local timezone (HH:MM format, 24-hour)
+	On string `json:"on,omitempty"`
+	// Optional: cron expression of the wake up in local timezone, instead of on
+	OnCron string `json:"onCron,omitempty"`
+	// Optional: name to identify this schedule (allows multiple schedules per namespace)
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Optional: specific days for sleep (overrides weekdays)
+	SleepDays string `json:"sleepDays,omitempty"`
+	// Optional: replicas kept during sleep by the matching workloads of each namespace
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`
+	// Tenant name (e.g., bdadevdat, bdadevprd)
+	Tenant string `json:"tenant"`
+	// Optional: specific days for wake (overrides weekdays)
+	WakeDays string `json:"wakeDays,omitempty"`
+	// Days of week (human format: "lunes-viernes", or numeric: "1-5")
+	Weekdays string `json:"weekdays,omitempty"`
+	// Frontend format: specific days for sleep (mapped to SleepDays)
+	WeekdaysSleep string `json:"weekdaysSleep,omitempty"`
+	// Frontend format: specific days for wake (mapped to WakeDays)
+	WeekdaysWake string `json:"weekdaysWake,omitempty"`
+}
+
+// CreateUserRequest is generated from the v1.CreateUserRequest definition.
+type CreateUserRequest struct {
+	Password string `json:"password"`
+	Role     string `json:"role"`
+	Username string `json:"username"`
+}
+
+// DelayConfig is generated from the v1.DelayConfig definition.
+type DelayConfig struct {
+	// Delay for Deployments (e.g., "7m")
+	DeploymentsDelay string `json:"deploymentsDelay,omitempty"`
+	// Delay for PgCluster + HDFSCluster (e.g., "0m", "5m")
+	PgHdfsDelay string `json:"pgHdfsDelay,omitempty"`
+	// Delay for PgBouncer (e.g., "5m")
+	PgbouncerDelay string `json:"pgbouncerDelay,omitempty"`
+}
+
+// DriftField is generated from the v1.DriftField definition.
+type DriftField struct {
+	// Value of the spec
+	Actual string `json:"actual,omitempty"`
+	// Value applied by the API
+	Expected string `json:"expected,omitempty"`
+	// sleepTime, wakeUpTime, weekdays or timeZone
+	Field string `json:"field,omitempty"`
+}
+
+// DriftReport is generated from the v1.DriftReport definition.
+type DriftReport struct {
+	CheckedAt  string             `json:"checkedAt,omitempty"`
+	SleepInfos []DriftedSleepInfo `json:"sleepInfos,omitempty"`
+}
+
+// DriftedSleepInfo is generated from the v1.DriftedSleepInfo definition.
+type DriftedSleepInfo struct {
+	Drift        []DriftField `json:"drift,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	Namespace    string       `json:"namespace,omitempty"`
+	ScheduleName string       `json:"scheduleName,omitempty"`
+	Tenant       string       `json:"tenant,omitempty"`
+}
+
+// EffectiveScheduleResponse is generated from the v1.EffectiveScheduleResponse definition.
+type EffectiveScheduleResponse struct {
+	// Sorted by class name
+	Classes []ResourceClassSchedule `json:"classes,omitempty"`
+	// Full namespace name
+	Namespace string `json:"namespace,omitempty"`
+	// SleepInfos merged into the timeline
+	SleepInfos []string `json:"sleepInfos,omitempty"`
+	Tenant     string   `json:"tenant,omitempty"`
+	// Timezone of the user* fields
+	UserTimezone string `json:"userTimezone,omitempty"`
+}
+
+// ErrorResponse is generated from the v1.ErrorResponse definition.
+//
+// Error response structure
+type ErrorResponse struct {
+	// HTTP status code
+	Code int `json:"code,omitempty"`
+	// Error message
+	Error string `json:"error,omitempty"`
+	// Always false for error responses
+	Success bool `json:"success,omitempty"`
+}
+
+// ExclusionFilter is generated from the v1.ExclusionFilter definition.
+type ExclusionFilter struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	// In, NotIn, Exists or DoesNotExist
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+	MatchLabels      map[string]string                 `json:"matchLabels,omitempty"`
+	Name             string                            `json:"name,omitempty"`
+}
+
+// FilterRef is generated from the v1.FilterRef definition.
+type FilterRef struct {
+	APIVersion       string                            `json:"apiVersion,omitempty"`
+	Kind             string                            `json:"kind,omitempty"`
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+	MatchLabels      map[string]string                 `json:"matchLabels,omitempty"`
+	Name             string                            `json:"name,omitempty"`
+}
+
+// HolidayConfig is generated from the v1.HolidayConfig definition.
+type HolidayConfig struct {
+	// ConfigMap with one YYYY-MM-DD date per line in its "holidays" key
+	ConfigMap string `json:"configMap,omitempty"`
+	// Namespace of the ConfigMap, the namespace of each SleepInfo if empty
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
+	// skipSleep keeps the namespaces awake on holidays, forceSleep keeps them asleep
+	Policy string `json:"policy,omitempty"`
+	// Time zone of the holiday dates, the user timezone if empty
+	TimeZone string `json:"timeZone,omitempty"`
+	// iCalendar (ICS) feed, instead of the ConfigMap
+	URL string `json:"url,omitempty"`
+}
+
+// LoginRequest is generated from the auth.LoginRequest definition.
+type LoginRequest struct {
+	Password string `json:"password"`
+	Username string `json:"username"`
+}
+
+// ManualOperationItem is generated from the v1.ManualOperationItem definition.
+type ManualOperationItem struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Later than requestedAt for staged wake steps
+	ScheduledAt string `json:"scheduledAt,omitempty"`
+}
+
+// ManualOperationResponse is generated from the v1.ManualOperationResponse definition.
+type ManualOperationResponse struct {
+	Action      string                `json:"action,omitempty"`
+	RequestedAt string                `json:"requestedAt,omitempty"`
+	SleepInfos  []ManualOperationItem `json:"sleepInfos,omitempty"`
+	Tenant      string                `json:"tenant,omitempty"`
+}
+
+// ManualScheduleRequest is generated from the v1.ManualScheduleRequest definition.
+type ManualScheduleRequest struct {
+	// "sleep" or "wake"
+	Action string `json:"action"`
+	// Optional: target namespace suffix
+	Namespace string `json:"namespace,omitempty"`
+	// Optional: target specific schedule name
+	ScheduleName string `json:"scheduleName,omitempty"`
+}
+
+// NamespaceExclusion is generated from the v1.NamespaceExclusion definition.
+type NamespaceExclusion struct {
+	Filter    *ExclusionFilter `json:"filter,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+}
+
+// NamespaceFailure is generated from the v1.NamespaceFailure definition.
+type NamespaceFailure struct {
+	Error string `json:"error,omitempty"`
+	// Namespace suffix
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NamespaceInclusion is generated from the v1.NamespaceInclusion definition.
+type NamespaceInclusion struct {
+	Filter    *ExclusionFilter `json:"filter,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+}
+
+// NamespaceInfo is generated from the v1.NamespaceInfo definition.
+type NamespaceInfo struct {
+	// Schedule description if set
+	Description string `json:"description,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	// Chronologically ordered schedule
+	Schedule []SleepInfoSummary `json:"schedule,omitempty"`
+	// Schedule name if set
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Human-readable summary
+	Summary  *ScheduleSummary `json:"summary,omitempty"`
+	Timezone string           `json:"timezone,omitempty"`
+	Weekdays string           `json:"weekdays,omitempty"`
+}
+
+// NamespacePolicy is generated from the v1.NamespacePolicy definition.
+type NamespacePolicy struct {
+	// Added to every schedule of the suffix
+	DefaultExclusions []ExclusionFilter `json:"defaultExclusions,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	// Overrides the CRD detection
+	StaggeredWake bool   `json:"staggeredWake,omitempty"`
+	Suffix        string `json:"suffix,omitempty"`
+	// Overrides the StatefulSets detection
+	SuspendStatefulSets bool `json:"suspendStatefulSets,omitempty"`
+}
+
+// NamespacePolicyListResponse is generated from the v1.NamespacePolicyListResponse definition.
+type NamespacePolicyListResponse struct {
+	Policies []NamespacePolicy `json:"policies,omitempty"`
+}
+
+// NamespaceResourceInfo is generated from the v1.NamespaceResourceInfo definition.
+type NamespaceResourceInfo struct {
+	AutoExclusions  []ExclusionFilter `json:"autoExclusions,omitempty"`
+	HasHdfsCluster  bool              `json:"hasHdfsCluster,omitempty"`
+	HasKafkaCluster bool              `json:"hasKafkaCluster,omitempty"`
+	HasMongoDB      bool              `json:"hasMongoDB,omitempty"`
+	HasOsCluster    bool              `json:"hasOsCluster,omitempty"`
+	HasOsDashboards bool              `json:"hasOsDashboards,omitempty"`
+	HasPgBouncer    bool              `json:"hasPgBouncer,omitempty"`
+	HasPgCluster    bool              `json:"hasPgCluster,omitempty"`
+	HasRedisCluster bool              `json:"hasRedisCluster,omitempty"`
+	HasVirtualizer  bool              `json:"hasVirtualizer,omitempty"`
+	Namespace       string            `json:"namespace,omitempty"`
+	ResourceCounts  *ResourceCounts   `json:"resourceCounts,omitempty"`
+}
+
+// NamespaceSavings is generated from the v1.NamespaceSavings definition.
+type NamespaceSavings struct {
+	// Core-hours saved per week
+	CPUCoreHours float64 `json:"cpuCoreHours,omitempty"`
+	// Requested CPU cores of the covered workloads
+	CPUCores      float64 `json:"cpuCores,omitempty"`
+	EstimatedCost float64 `json:"estimatedCost,omitempty"`
+	// Requested memory (GiB) of the covered workloads
+	MemoryGB float64 `json:"memoryGB,omitempty"`
+	// GiB-hours saved per week
+	MemoryGBHours float64 `json:"memoryGBHours,omitempty"`
+	Namespace     string  `json:"namespace,omitempty"`
+	// Hours per week the namespace is asleep according to its schedule
+	WeeklySleepHours float64 `json:"weeklySleepHours,omitempty"`
+	// Deployments and StatefulSets covered by the SleepInfos
+	Workloads int `json:"workloads,omitempty"`
+}
+
+// NamespaceServicesResponse is generated from the v1.NamespaceServicesResponse definition.
+type NamespaceServicesResponse struct {
+	Namespace string        `json:"namespace,omitempty"`
+	Services  []ServiceInfo `json:"services,omitempty"`
+}
+
+// NamespaceSleepReplicas is generated from the v1.NamespaceSleepReplicas definition.
+type NamespaceSleepReplicas struct {
+	Filter    *ExclusionFilter `json:"filter,omitempty"`
+	Namespace string           `json:"namespace,omitempty"`
+	// Replicas kept during sleep
+	Replicas int `json:"replicas,omitempty"`
+}
+
+// NamespaceStatusResponse is generated from the v1.NamespaceStatusResponse definition.
+type NamespaceStatusResponse struct {
+	// True when no SleepInfo reports errors
+	Healthy bool `json:"healthy,omitempty"`
+	// Full namespace name
+	Namespace  string            `json:"namespace,omitempty"`
+	SleepInfos []SleepInfoStatus `json:"sleepInfos,omitempty"`
+	Tenant     string            `json:"tenant,omitempty"`
+}
+
+// NextOccurrencesResponse is generated from the v1.NextOccurrencesResponse definition.
+type NextOccurrencesResponse struct {
+	// Occurrences computed per SleepInfo operation
+	Count       int                   `json:"count,omitempty"`
+	Occurrences []ScheduledOccurrence `json:"occurrences,omitempty"`
+	Tenant      string                `json:"tenant,omitempty"`
+}
+
+// NextOperationResponse is generated from the v1.NextOperationResponse definition.
+type NextOperationResponse struct {
+	Description string `json:"description,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	// "SLEEP" or "WAKE_UP"
+	Operation string `json:"operation,omitempty"`
+	Tenant    string `json:"tenant,omitempty"`
+	Time      string `json:"time,omitempty"`
+}
+
+// OneTimeWindow is generated from the v1.OneTimeWindow definition.
+type OneTimeWindow struct {
+	End   string `json:"end,omitempty"`
+	Start string `json:"start,omitempty"`
+}
+
+// RefreshRequest is generated from the auth.RefreshRequest definition.
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// ResourceClassSchedule is generated from the v1.ResourceClassSchedule definition.
+type ResourceClassSchedule struct {
+	// Deployments, StatefulSets, CronJobs, Postgres, ... or the Kind of a custom patch
+	Class string `json:"class,omitempty"`
+	// Sorted by sleep time
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+}
+
+// ResourceCounts is generated from the v1.ResourceCounts definition.
+type ResourceCounts struct {
+	CronJobs       int `json:"cronJobs,omitempty"`
+	Deployments    int `json:"deployments,omitempty"`
+	HdfsClusters   int `json:"hdfsClusters,omitempty"`
+	KafkaClusters  int `json:"kafkaClusters,omitempty"`
+	MongoDBs       int `json:"mongoDBs,omitempty"`
+	OsClusters     int `json:"osClusters,omitempty"`
+	OsDashboardses int `json:"osDashboardses,omitempty"`
+	PgBouncers     int `json:"pgBouncers,omitempty"`
+	PgClusters     int `json:"pgClusters,omitempty"`
+	RedisClusters  int `json:"redisClusters,omitempty"`
+	StatefulSets   int `json:"statefulSets,omitempty"`
+}
+
+// SavingsResponse is generated from the v1.SavingsResponse definition.
+type SavingsResponse struct {
+	CPUCoreHours float64 `json:"cpuCoreHours,omitempty"`
+	Currency     string  `json:"currency,omitempty"`
+	// Only when a price is configured
+	EstimatedCost float64 `json:"estimatedCost,omitempty"`
+	// Start of the estimated week
+	From             string             `json:"from,omitempty"`
+	MemoryGBHours    float64            `json:"memoryGBHours,omitempty"`
+	Namespaces       []NamespaceSavings `json:"namespaces,omitempty"`
+	PricePerCoreHour float64            `json:"pricePerCoreHour,omitempty"`
+	PricePerGBHour   float64            `json:"pricePerGBHour,omitempty"`
+	Tenant           string             `json:"tenant,omitempty"`
+	To               string             `json:"to,omitempty"`
+}
+
+// ScheduleExport is generated from the v1.ScheduleExport definition.
+type ScheduleExport struct {
+	// Only when includeSecrets=true
+	Secrets    []SecretMetadata              `json:"secrets,omitempty"`
+	SleepInfos []kubegreenv1alpha1.SleepInfo `json:"sleepInfos,omitempty"`
+	Tenant     string                        `json:"tenant,omitempty"`
+}
+
+// SchedulePreviewResponse is generated from the v1.SchedulePreviewResponse definition.
+type SchedulePreviewResponse struct {
+	Days int    `json:"days,omitempty"`
+	From string `json:"from,omitempty"`
+	// Namespace suffix, when filtered
+	Namespace   string                `json:"namespace,omitempty"`
+	Occurrences []ScheduledOccurrence `json:"occurrences,omitempty"`
+	Tenant      string                `json:"tenant,omitempty"`
+	To          string                `json:"to,omitempty"`
+}
+
+// ScheduleResponse is generated from the v1.ScheduleResponse definition.
+type ScheduleResponse struct {
+	Namespaces map[string]NamespaceInfo `json:"namespaces,omitempty"`
+	Tenant     string                   `json:"tenant,omitempty"`
+}
+
+// ScheduleSummary is generated from the v1.ScheduleSummary definition.
+type ScheduleSummary struct {
+	// Human-readable description
+	Description string `json:"description,omitempty"`
+	// List of operations in order
+	Operations []string `json:"operations,omitempty"`
+	// When resources go to sleep
+	SleepTime string `json:"sleepTime,omitempty"`
+	// When resources wake up
+	WakeTime string `json:"wakeTime,omitempty"`
+}
+
+// ScheduleValidationResult is generated from the v1.ScheduleValidationResult definition.
+type ScheduleValidationResult struct {
+	Errors []ValidationIssue `json:"errors,omitempty"`
+	// True when there are no errors (warnings do not block creation)
+	Valid    bool              `json:"valid,omitempty"`
+	Warnings []ValidationIssue `json:"warnings,omitempty"`
+}
+
+// ScheduleWindow is generated from the v1.ScheduleWindow definition.
+type ScheduleWindow struct {
+	// Resources of the class left untouched
+	ExcludeRef []FilterRef `json:"excludeRef,omitempty"`
+	// When set, only these resources of the class are handled
+	IncludeRef   []FilterRef `json:"includeRef,omitempty"`
+	Paused       bool        `json:"paused,omitempty"`
+	ScheduleName string      `json:"scheduleName,omitempty"`
+	// Empty when the class is only woken up (e.g. by a wake stage without sleep)
+	SleepAt   string `json:"sleepAt,omitempty"`
+	SleepDays []int  `json:"sleepDays,omitempty"`
+	// SleepInfo executing the sleep
+	SleepInfo      string `json:"sleepInfo,omitempty"`
+	SuspendedUntil string `json:"suspendedUntil,omitempty"`
+	TimeZone       string `json:"timeZone,omitempty"`
+	UserSleepAt    string `json:"userSleepAt,omitempty"`
+	UserSleepDays  []int  `json:"userSleepDays,omitempty"`
+	UserWakeAt     string `json:"userWakeAt,omitempty"`
+	UserWakeDays   []int  `json:"userWakeDays,omitempty"`
+	// Empty when the class is never woken up by the schedule
+	WakeAt   string `json:"wakeAt,omitempty"`
+	WakeDays []int  `json:"wakeDays,omitempty"`
+	// SleepInfo executing the wake, different for pairs
+	WakeSleepInfo string `json:"wakeSleepInfo,omitempty"`
+}
+
+// ScheduleWriteErrorResponse is generated from the v1.ScheduleWriteErrorResponse definition.
+type ScheduleWriteErrorResponse struct {
+	Code    int                  `json:"code,omitempty"`
+	Error   string               `json:"error,omitempty"`
+	Report  *ScheduleWriteReport `json:"report,omitempty"`
+	Success bool                 `json:"success,omitempty"`
+}
+
+// ScheduleWriteReport is generated from the v1.ScheduleWriteReport definition.
+type ScheduleWriteReport struct {
+	// Every SleepInfo written is rolled back
+	Consistent bool `json:"consistent,omitempty"`
+	// Namespaces whose SleepInfos failed to be written
+	Failed []NamespaceFailure `json:"failed,omitempty"`
+	// SleepInfos left with the new spec, their rollback failed
+	NotRolledBack []string `json:"notRolledBack,omitempty"`
+	// SleepInfos (namespace/name) deleted or restored to their previous spec
+	RolledBack []string `json:"rolledBack,omitempty"`
+}
+
+// ScheduledOccurrence is generated from the v1.ScheduledOccurrence definition.
+type ScheduledOccurrence struct {
+	Description string `json:"description,omitempty"`
+	// Namespace suffix (datastores, apps, ...)
+	Namespace string `json:"namespace,omitempty"`
+	// "SLEEP" or "WAKE_UP"
+	Operation string `json:"operation,omitempty"`
+	// Resources handled at this step (staged wakes have one entry per step)
+	Resources    []string `json:"resources,omitempty"`
+	ScheduleName string   `json:"scheduleName,omitempty"`
+	SleepInfo    string   `json:"sleepInfo,omitempty"`
+	// Instant in cluster timezone (UTC)
+	Time string `json:"time,omitempty"`
+	// Same instant in the user timezone (RFC3339)
+	UserTime     string `json:"userTime,omitempty"`
+	UserTimezone string `json:"userTimezone,omitempty"`
+	// Wake stage of the SleepInfo executed at this step, if any
+	WakeStage string `json:"wakeStage,omitempty"`
+}
+
+// SecretMetadata is generated from the v1.SecretMetadata definition.
+type SecretMetadata struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Data keys present in the Secret (values are never exported)
+	Keys      []string          `json:"keys,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+}
+
+// ServiceInfo is generated from the v1.ServiceInfo definition.
+type ServiceInfo struct {
+	Annotations   map[string]string `json:"annotations,omitempty"`
+	Kind          string            `json:"kind,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Name          string            `json:"name,omitempty"`
+	ReadyReplicas int               `json:"readyReplicas,omitempty"`
+	Replicas      int               `json:"replicas,omitempty"`
+	Status        string            `json:"status,omitempty"`
+}
+
+// SleepInfoStatus is generated from the v1.SleepInfoStatus definition.
+type SleepInfoStatus struct {
+	// Ready, LastOperationSucceeded and Degraded
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Sleeping, Awake or Transitioning, from status
+	CurrentState string `json:"currentState,omitempty"`
+	// Invalid spec, missed operations and warning events
+	Errors []string `json:"errors,omitempty"`
+	// Resources the last operation failed on
+	FailedResources     []kubegreenv1alpha1.FailedResource       `json:"failedResources,omitempty"`
+	LastManualOperation *kubegreenv1alpha1.ManualOperationStatus `json:"lastManualOperation,omitempty"`
+	// SLEEP or WAKE_UP, from status
+	LastOperation string `json:"lastOperation,omitempty"`
+	// status.lastScheduleTime
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+	// status.lastSleepTime
+	LastSleepTime string `json:"lastSleepTime,omitempty"`
+	// status.lastWakeUpTime
+	LastWakeUpTime string `json:"lastWakeUpTime,omitempty"`
+	Name           string `json:"name,omitempty"`
+	// schedule, window, manual-action, snooze or suspension-end
+	NextRequeueReason string `json:"nextRequeueReason,omitempty"`
+	// When the controller is expected to act next
+	NextRequeueTime string `json:"nextRequeueTime,omitempty"`
+	// Paused schedules are never requeued by the cron
+	Paused bool `json:"paused,omitempty"`
+	// sleep or wake, not yet executed
+	PendingManualAction string `json:"pendingManualAction,omitempty"`
+	// The controller executed at least one operation
+	Processed bool `json:"processed,omitempty"`
+	// The secret or the SleepInfoState holds restore patches for a wake
+	RestoreDataPresent bool `json:"restoreDataPresent,omitempty"`
+	// sleep, wake, window, or sleep/wake for single objects
+	Role string `json:"role,omitempty"`
+	// Operation recorded in the secret
+	SecretOperation string `json:"secretOperation,omitempty"`
+	// The sleepinfo-<name> secret exists
+	SecretPresent bool `json:"secretPresent,omitempty"`
+	// Last operation recorded in the secret
+	SecretScheduledAt string `json:"secretScheduledAt,omitempty"`
+	// status.suspendedResourceCounts
+	SuspendedResources map[string]int32 `json:"suspendedResources,omitempty"`
+	// Pending suspension deadline
+	SuspendedUntil string `json:"suspendedUntil,omitempty"`
+}
+
+// SleepInfoSummary is generated from the v1.SleepInfoSummary definition.
+type SleepInfoSummary struct {
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// When an executeOnce SleepInfo ran its operations
+	CompletedAt string `json:"completedAt,omitempty"`
+	// Schedule description if set
+	Description string `json:"description,omitempty"`
+	// Fields of the spec changed since the API applied it
+	Drift []DriftField `json:"drift,omitempty"`
+	// True when the spec was changed since the API applied it, e.g. by kubectl edit
+	Drifted bool `json:"drifted,omitempty"`
+	// Exclusion filters
+	ExcludeRef []FilterRef `json:"excludeRef,omitempty"`
+	// True when the schedule runs once and is then deleted
+	ExecuteOnce bool `json:"executeOnce,omitempty"`
+	// Holiday calendar and policy, if set
+	Holidays *HolidayConfig `json:"holidays,omitempty"`
+	// Inclusion filters, only matching resources sleep
+	IncludeRef []FilterRef `json:"includeRef,omitempty"`
+	// Karpenter NodePools scaled to zero while asleep
+	KarpenterNodePools []string `json:"karpenterNodePools,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	Namespace          string   `json:"namespace,omitempty"`
+	// Human-readable description
+	Operation string `json:"operation,omitempty"`
+	// True when the schedule is paused until resumed
+	Paused bool `json:"paused,omitempty"`
+	// List of resources managed (Postgres, HDFS, PgBouncer, Deployments, etc.)
+	Resources []string `json:"resources,omitempty"`
+	// "sleep" or "wake"
+	Role string `json:"role,omitempty"`
+	// Schedule name if set
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Replicas kept during sleep instead of zero
+	SleepReplicas []SleepReplicasConfig `json:"sleepReplicas,omitempty"`
+	// True when paused through spec.suspend
+	Suspend bool `json:"suspend,omitempty"`
+	// Non-nil when schedule is temporarily suspended
+	SuspendScheduleUntil string `json:"suspendScheduleUntil,omitempty"`
+	// Sleep or wake time, in TimeZone
+	Time string `json:"time,omitempty"`
+	// Timezone of Time, WakeTime and Weekdays (the user timezone, "UTC" for older schedules)
+	TimeZone string `json:"timeZone,omitempty"`
+	// User timezone (e.g. "America/Bogota") — authoritative source, no annotation parsing needed
+	UserTimezone string `json:"userTimezone,omitempty"`
+	// Wake stage of the SleepInfo woken up at Time, if any
+	WakeStage string `json:"wakeStage,omitempty"`
+	WakeTime  string `json:"wakeTime,omitempty"`
+	Weekdays  string `json:"weekdays,omitempty"`
+	// One-time sleep, deleted once over
+	Window *OneTimeWindow `json:"window,omitempty"`
+}
+
+// SleepReplicasConfig is generated from the v1.SleepReplicasConfig definition.
+type SleepReplicasConfig struct {
+	Filter *ExclusionFilter `json:"filter,omitempty"`
+	// Replicas kept during sleep
+	Replicas int `json:"replicas,omitempty"`
+}
+
+// SnoozeItem is generated from the v1.SnoozeItem definition.
+type SnoozeItem struct {
+	Name           string `json:"name,omitempty"`
+	Namespace      string `json:"namespace,omitempty"`
+	ScheduledSleep string `json:"scheduledSleep,omitempty"`
+	SnoozedUntil   string `json:"snoozedUntil,omitempty"`
+}
+
+// SnoozeRequest is generated from the v1.SnoozeRequest definition.
+type SnoozeRequest struct {
+	// Go duration, e.g. 30m, 2h, 1h30m
+	Duration string `json:"duration"`
+	// Optional namespace suffix filter
+	Namespace string `json:"namespace,omitempty"`
+	// Optional schedule name filter
+	ScheduleName string `json:"scheduleName,omitempty"`
+}
+
+// SnoozeResponse is generated from the v1.SnoozeResponse definition.
+type SnoozeResponse struct {
+	Duration   string       `json:"duration,omitempty"`
+	SleepInfos []SnoozeItem `json:"sleepInfos,omitempty"`
+	Tenant     string       `json:"tenant,omitempty"`
+}
+
+// SuspendScheduleRequest is generated from the v1.SuspendScheduleRequest definition.
+type SuspendScheduleRequest struct {
+	// Optional: target namespace suffix
+	Namespace string `json:"namespace,omitempty"`
+	// Optional: target specific schedule name
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Until is the RFC3339 datetime until which the schedule is suspended (e.g. "2026-06-30T00:00:00Z")
+	Until string `json:"until"`
+}
+
+// SuspendedServiceInfo is generated from the v1.SuspendedServiceInfo definition.
+type SuspendedServiceInfo struct {
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// SleepInfo that put the service to sleep
+	SleepInfo   string `json:"sleepInfo,omitempty"`
+	SuspendedAt string `json:"suspendedAt,omitempty"`
+	WillWakeAt  string `json:"willWakeAt,omitempty"`
+}
+
+// SuspendedServicesResponse is generated from the v1.SuspendedServicesResponse definition.
+type SuspendedServicesResponse struct {
+	Suspended []SuspendedServiceInfo `json:"suspended,omitempty"`
+	Tenant    string                 `json:"tenant,omitempty"`
+}
+
+// TenantDetails is generated from the v1.TenantDetails definition.
+type TenantDetails struct {
+	// Suffixes whose last operation was a sleep
+	AsleepNamespaces []string `json:"asleepNamespaces,omitempty"`
+	NextSleep        string   `json:"nextSleep,omitempty"`
+	NextWake         string   `json:"nextWake,omitempty"`
+	// asleep, awake, partial or unscheduled
+	PowerState string `json:"powerState,omitempty"`
+	// Namespace suffix -> has SleepInfos
+	ScheduledNamespaces map[string]bool `json:"scheduledNamespaces,omitempty"`
+	SleepInfoCount      int             `json:"sleepInfoCount,omitempty"`
+}
+
+// TenantInfo is generated from the v1.TenantInfo definition.
+type TenantInfo struct {
+	CreatedAt string `json:"createdAt,omitempty"`
+	// Only with ?details=true
+	Details    *TenantDetails `json:"details,omitempty"`
+	Name       string         `json:"name,omitempty"`
+	Namespaces []string       `json:"namespaces,omitempty"`
+}
+
+// TenantListResponse is generated from the v1.TenantListResponse definition.
+type TenantListResponse struct {
+	Tenants []TenantInfo `json:"tenants,omitempty"`
+}
+
+// TimezoneInfo is generated from the v1.TimezoneInfo definition.
+type TimezoneInfo struct {
+	// Current abbreviation (e.g. CET, -05)
+	Abbreviation string `json:"abbreviation,omitempty"`
+	// IANA name (e.g. America/Bogota)
+	Name string `json:"name,omitempty"`
+	// Current UTC offset (e.g. -05:00)
+	Offset string `json:"offset,omitempty"`
+	// Current UTC offset in seconds
+	OffsetSeconds int `json:"offsetSeconds,omitempty"`
+	// Top-level area (e.g. America)
+	Region string `json:"region,omitempty"`
+}
+
+// TimezoneListResponse is generated from the v1.TimezoneListResponse definition.
+type TimezoneListResponse struct {
+	Count int `json:"count,omitempty"`
+	// Only when grouped by region
+	Regions   map[string][]TimezoneInfo `json:"regions,omitempty"`
+	Timezones []TimezoneInfo            `json:"timezones,omitempty"`
+}
+
+// UpdatePasswordRequest is generated from the v1.UpdatePasswordRequest definition.
+type UpdatePasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// UpdateRoleRequest is generated from the v1.UpdateRoleRequest definition.
+type UpdateRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// UpdateScheduleRequest is generated from the v1.UpdateScheduleRequest definition.
+//
+// Request to update an existing sleep/wake schedule for a tenant (all fields optional)
+type UpdateScheduleRequest struct {
+	// Always applies to cluster (field is ignored)
+	Apply bool `json:"apply,omitempty"`
+	// Optional: hours asleep after off, instead of on (e.g. 8 or 10.5)
+	DurationHours float64 `json:"durationHours,omitempty"`
+	// Optional: replaces the holiday calendar, kept when omitted
+	Holidays *HolidayConfig `json:"holidays,omitempty"`
+	// Optional: replaces the inclusions, kept when omitted
+	Inclusions []NamespaceInclusion `json:"inclusions,omitempty"`
+	// Optional: limit to specific namespaces
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Sleep time in local timezone (HH:MM format, 24-hour)
+	Off string `json:"off,omitempty"`
+	// Wake time in local timezone (HH:MM format, 24-hour)
+	On string `json:"on,omitempty"`
+	// Optional: specific days for sleep (overrides weekdays)
+	SleepDays string `json:"sleepDays,omitempty"`
+	// Optional: replaces the replicas kept during sleep, kept when omitted
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`
+	// Optional: specific days for wake (overrides weekdays)
+	WakeDays string `json:"wakeDays,omitempty"`
+	// Days of week (human format: "lunes-viernes", or numeric: "1-5")
+	Weekdays string `json:"weekdays,omitempty"`
+	// Frontend format: specific days for sleep (mapped to sleepDays)
+	WeekdaysSleep string `json:"weekdaysSleep,omitempty"`
+	// Frontend format: specific days for wake (mapped to wakeDays)
+	WeekdaysWake string `json:"weekdaysWake,omitempty"`
+}
+
+// UserInfo is generated from the github_com_kube-green_kube-green_internal_api_v1.UserInfo definition.
+//
+// User information returned by the API
+type UserInfo struct {
+	// User role: admin, operacion, or lectura
+	Role string `json:"role,omitempty"`
+	// Username
+	Username string `json:"username,omitempty"`
+}
+
+// ValidateScheduleRequest is generated from the v1.ValidateScheduleRequest definition.
+type ValidateScheduleRequest struct {
+	// Always applies to cluster (field is ignored but kept for compatibility)
+	Apply bool `json:"apply,omitempty"`
+	// Optional: custom delays for staggered wake-up (e.g., {"pgHdfsDelay": "0m", "pgbouncerDelay": "5m", "deploymentsDelay": "7m"})
+	Delays *DelayConfig `json:"delays,omitempty"`
+	// Optional: description of the schedule
+	Description string `json:"description,omitempty"`
+	// Optional: hours asleep after off, instead of on (e.g. 8 or 10.5)
+	DurationHours float64 `json:"durationHours,omitempty"`
+	// Optional: resources of each namespace never put to sleep, added to the automatic exclusions
+	Exclusions []NamespaceExclusion `json:"exclusions,omitempty"`
+	// Optional: sleep and wake up once at the next scheduled times, then delete the schedule
+	ExecuteOnce bool `json:"executeOnce,omitempty"`
+	// Optional: holiday calendar and what the schedule does on holidays
+	Holidays *HolidayConfig `json:"holidays,omitempty"`
+	// Optional: only the matching resources of each namespace are put to sleep
+	Inclusions []NamespaceInclusion `json:"inclusions,omitempty"`
+	// Optional: Karpenter NodePools scaled to zero while asleep, restored 5 minutes before the wake up
+	KarpenterNodePools []string `json:"karpenterNodePools,omitempty"`
+	// Optional: limit to specific namespaces (datastores, apps, rocket, intelligence, airflowsso)
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Sleep time in local timezone (HH:MM format, 24-hour)
+	Off string `json:"off,omitempty"`
+	// Optional: cron expression of the sleep in local timezone, instead of off and weekdays (6#1: first Saturday of the month)
+	OffCron string `json:"offCron,omitempty"`
+	// Wake time in local timezone (HH:MM format, 24-hour)
+	On string `json:"on,omitempty"`
+	// Optional: cron expression of the wake up in local timezone, instead of on
+	OnCron string `json:"onCron,omitempty"`
+	// Optional: name to identify this schedule (allows multiple schedules per namespace)
+	ScheduleName string `json:"scheduleName,omitempty"`
+	// Optional: specific days for sleep (overrides weekdays)
+	SleepDays string `json:"sleepDays,omitempty"`
+	// Optional: replicas kept during sleep by the matching workloads of each namespace
+	SleepReplicas []NamespaceSleepReplicas `json:"sleepReplicas,omitempty"`
+	// Tenant name (e.g., bdadevdat, bdadevprd)
+	Tenant string `json:"tenant"`
+	// Optional: specific days for wake (overrides weekdays)
+	WakeDays string `json:"wakeDays,omitempty"`
+	// Days of week (human format: "lunes-viernes", or numeric: "1-5")
+	Weekdays string `json:"weekdays,omitempty"`
+	// Frontend format: specific days for sleep (mapped to SleepDays)
+	WeekdaysSleep string `json:"weekdaysSleep,omitempty"`
+	// Frontend format: specific days for wake (mapped to WakeDays)
+	WeekdaysWake string `json:"weekdaysWake,omitempty"`
+}
+
+// ValidationIssue is generated from the v1.ValidationIssue definition.
+type ValidationIssue struct {
+	// Machine-readable code (e.g. NAMESPACE_NOT_FOUND)
+	Code string `json:"code,omitempty"`
+	// Request field the issue refers to
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Namespace the issue refers to
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// WebhookSubscriptionInfo is generated from the v1.WebhookSubscriptionInfo definition.
+type WebhookSubscriptionInfo struct {
+	CreatedAt string   `json:"createdAt,omitempty"`
+	CreatedBy string   `json:"createdBy,omitempty"`
+	Digest    bool     `json:"digest,omitempty"`
+	Emails    []string `json:"emails,omitempty"`
+	Events    []string `json:"events,omitempty"`
+	Format    string   `json:"format,omitempty"`
+	ID        string   `json:"id,omitempty"`
+	// True when the deliveries are signed
+	Signed   bool   `json:"signed,omitempty"`
+	Template string `json:"template,omitempty"`
+	Tenant   string `json:"tenant,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// WebhookSubscriptionRequest is generated from the v1.WebhookSubscriptionRequest definition.
+type WebhookSubscriptionRequest struct {
+	// Optional: email the weekly digest of the tenant (sleeps, wake ups and estimated savings) instead of the events
+	Digest bool `json:"digest,omitempty"`
+	// Optional: recipients of the events by email instead of the URL, requires the SMTP settings
+	Emails []string `json:"emails,omitempty"`
+	// Optional: event types, all of them when empty
+	Events []string `json:"events,omitempty"`
+	// Optional: body format, json (the event, default), teams (Adaptive Card for a Teams workflow webhook) or template
+	Format string `json:"format,omitempty"`
+	// Optional: HMAC-SHA256 key, the body signature is sent in X-Kube-Green-Signature
+	Secret string `json:"secret,omitempty"`
+	// Optional: Go template of the body with format template, executed with the event. {{ json .Namespace }} quotes a value
+	Template string `json:"template,omitempty"`
+	// Optional: only events of this tenant (global subscriptions require admin role)
+	Tenant string `json:"tenant,omitempty"`
+	// Callback URL receiving the events as JSON POSTs, required without emails
+	URL string `json:"url,omitempty"`
+}
+
+// WindowItem is generated from the v1.WindowItem definition.
+type WindowItem struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// WindowScheduleRequest is generated from the v1.WindowScheduleRequest definition.
+type WindowScheduleRequest struct {
+	Description string `json:"description,omitempty"`
+	// RFC3339, or YYYY-MM-DDTHH:MM in the user timezone
+	End          string               `json:"end"`
+	Exclusions   []NamespaceExclusion `json:"exclusions,omitempty"`
+	Namespaces   []string             `json:"namespaces"`
+	ScheduleName string               `json:"scheduleName,omitempty"`
+	// RFC3339, or YYYY-MM-DDTHH:MM in the user timezone
+	Start string `json:"start"`
+}
+
+// WindowScheduleResponse is generated from the v1.WindowScheduleResponse definition.
+type WindowScheduleResponse struct {
+	End        string       `json:"end,omitempty"`
+	SleepInfos []WindowItem `json:"sleepInfos,omitempty"`
+	Start      string       `json:"start,omitempty"`
+	Tenant     string       `json:"tenant,omitempty"`
+}
+
+// AuthLogin calls POST /api/v1/auth/login: Login.
+//
+// Authenticates a user and returns JWT access and refresh tokens
+func (c *Client) AuthLogin(ctx context.Context, request LoginRequest) (map[string]interface{}, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/auth/login", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AuthMe calls GET /api/v1/auth/me: Get current user info.
+//
+// Returns information about the currently authenticated user
+func (c *Client) AuthMe(ctx context.Context) (map[string]interface{}, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/auth/me", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AuthRefresh calls POST /api/v1/auth/refresh: Refresh token.
+//
+// Refreshes an access token using a refresh token
+func (c *Client) AuthRefresh(ctx context.Context, request RefreshRequest) (map[string]interface{}, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/auth/refresh", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Info calls GET /api/v1/info: API information endpoint.
+//
+// Returns information about the API
+func (c *Client) Info(ctx context.Context) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/info", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListNamespacePolicies calls GET /api/v1/namespace-policies: List namespace policies.
+//
+// Returns the known namespace suffixes and how schedules are created in them: whether StatefulSets are suspended, whether the wake is staggered and the default exclusions. Unset behaviors rely on the resource detection of the namespace. The catalogue is loaded from the built-in suffixes, the --api-namespace-policies file and the --api-namespace-policy-configmap ConfigMap.
+func (c *Client) ListNamespacePolicies(ctx context.Context) (*NamespacePolicyListResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/namespace-policies", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NamespacePolicyListResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetEffectiveScheduleParams are the query parameters of GetEffectiveSchedule.
+type GetEffectiveScheduleParams struct {
+	// Namespace suffix (required)
+	Namespace string
+}
+
+// GetEffectiveSchedule calls GET /api/v1/namespaces/{tenant}/effective-schedule: Get the effective schedule of a namespace.
+//
+// Merges every SleepInfo of the namespace (single objects, sleep/wake pairs, staggered wake stages and exclusions) into one normalized timeline per resource class (Deployments, StatefulSets, CronJobs, Postgres, HDFS, PgBouncer, ... or the Kind of a custom patch), telling when each class sleeps and wakes in the cluster and user timezones
+func (c *Client) GetEffectiveSchedule(ctx context.Context, tenant string, params GetEffectiveScheduleParams) (*EffectiveScheduleResponse, error) {
+	query := url.Values{}
+	query.Set("namespace", params.Namespace)
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/namespaces/"+url.PathEscape(tenant)+"/effective-schedule", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &EffectiveScheduleResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNamespaceResourcesParams are the query parameters of GetNamespaceResources.
+type GetNamespaceResourcesParams struct {
+	// Namespace suffix (required)
+	Namespace string
+}
+
+// GetNamespaceResources calls GET /api/v1/namespaces/{tenant}/resources: Get resources for a namespace.
+//
+// Detects CRDs and resource counts for a tenant namespace
+func (c *Client) GetNamespaceResources(ctx context.Context, tenant string, params GetNamespaceResourcesParams) (*NamespaceResourceInfo, error) {
+	query := url.Values{}
+	query.Set("namespace", params.Namespace)
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/namespaces/"+url.PathEscape(tenant)+"/resources", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NamespaceResourceInfo{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNamespaceServicesParams are the query parameters of GetNamespaceServices.
+type GetNamespaceServicesParams struct {
+	// Namespace suffix (required)
+	Namespace string
+}
+
+// GetNamespaceServices calls GET /api/v1/namespaces/{tenant}/services: Get services for a namespace.
+//
+// Lists deployments, statefulsets and cronjobs for a tenant namespace
+func (c *Client) GetNamespaceServices(ctx context.Context, tenant string, params GetNamespaceServicesParams) (*NamespaceServicesResponse, error) {
+	query := url.Values{}
+	query.Set("namespace", params.Namespace)
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/namespaces/"+url.PathEscape(tenant)+"/services", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NamespaceServicesResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListSchedulesParams are the query parameters of ListSchedules.
+type ListSchedulesParams struct {
+	// Only tenants whose name starts with this prefix
+	TenantPrefix string
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Only SleepInfos belonging to this schedule name
+	ScheduleName string
+	// Alias of scheduleName: returns the SleepInfos of the named schedule across all namespaces, resolved through the schedule-name index
+	Name string
+	// Maximum number of tenants to return (0 = all)
+	Limit int
+	// Continue token returned by the previous page (X-Continue-Token header)
+	Continue string
+}
+
+// ListSchedules calls GET /api/v1/schedules: List all schedules.
+//
+// Lists SleepInfo schedules across all namespaces grouped by tenant, sorted by tenant name. Supports optional filters and limit/continue pagination (limit counts tenants). The pagination state is returned in the X-Total-Count and X-Continue-Token response headers so the response body stays a plain list.
+func (c *Client) ListSchedules(ctx context.Context, params ListSchedulesParams) ([]ScheduleResponse, error) {
+	query := url.Values{}
+	if params.TenantPrefix != "" {
+		query.Set("tenantPrefix", params.TenantPrefix)
+	}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	if params.Name != "" {
+		query.Set("name", params.Name)
+	}
+	if params.Limit != 0 {
+		query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Continue != "" {
+		query.Set("continue", params.Continue)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []ScheduleResponse
+	if err := decodeData(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateSchedule calls POST /api/v1/schedules: Create a new schedule.
+//
+// Creates SleepInfo configurations for a tenant. Automatically converts local time (America/Bogota) to UTC and handles timezone day shifts. Creates schedules for all namespaces (datastores, apps, rocket, intelligence, airflowsso) unless filtered.
+func (c *Client) CreateSchedule(ctx context.Context, request CreateScheduleRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BulkDeleteSchedulesParams are the query parameters of BulkDeleteSchedules.
+type BulkDeleteSchedulesParams struct {
+	// Comma separated tenants
+	Tenants string
+	// Namespace suffix (optional)
+	Namespace string
+	// Schedule name (optional)
+	ScheduleName string
+	// SleepInfo label selector
+	LabelSelector string
+	// SleepInfo annotation selector
+	AnnotationSelector string
+}
+
+// BulkDeleteSchedules calls DELETE /api/v1/schedules: Bulk delete schedules.
+//
+// Deletes the schedules of several tenants (tenants=a,b) or the SleepInfos matching a label and/or annotation selector, concurrently. Optional filters: namespace, scheduleName, and tenants when a selector is set. At least one of tenants, labelSelector or annotationSelector is required. Returns the result of every tenant (tenants only) or SleepInfo (selectors).
+func (c *Client) BulkDeleteSchedules(ctx context.Context, params BulkDeleteSchedulesParams) (*BulkDeleteResponse, error) {
+	query := url.Values{}
+	if params.Tenants != "" {
+		query.Set("tenants", params.Tenants)
+	}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	if params.LabelSelector != "" {
+		query.Set("labelSelector", params.LabelSelector)
+	}
+	if params.AnnotationSelector != "" {
+		query.Set("annotationSelector", params.AnnotationSelector)
+	}
+	body, err := c.call(ctx, http.MethodDelete, "/api/v1/schedules", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &BulkDeleteResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDriftedSleepInfosParams are the query parameters of GetDriftedSleepInfos.
+type GetDriftedSleepInfosParams struct {
+	// Tenant filter
+	Tenant string
+}
+
+// GetDriftedSleepInfos calls GET /api/v1/schedules/drifted: Get drifted SleepInfos.
+//
+// Returns the SleepInfos whose times, weekdays or timezone were changed since the API applied them, e.g. by kubectl edit, from the last background check (every 5 minutes). Re-sync them with PUT /api/v1/schedules/{tenant}, or adopt the manual change by sending its values.
+func (c *Client) GetDriftedSleepInfos(ctx context.Context, params GetDriftedSleepInfosParams) (*DriftReport, error) {
+	query := url.Values{}
+	if params.Tenant != "" {
+		query.Set("tenant", params.Tenant)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/drifted", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &DriftReport{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAllNextOperations calls GET /api/v1/schedules/next: Get next operation across all tenants.
+//
+// Returns the earliest next scheduled operation across all tenants
+func (c *Client) GetAllNextOperations(ctx context.Context) (*NextOperationResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/next", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NextOperationResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAllSuspendedServices calls GET /api/v1/schedules/suspended: Get all suspended services.
+//
+// Returns all currently suspended services across all tenants
+func (c *Client) GetAllSuspendedServices(ctx context.Context) ([]SuspendedServiceInfo, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/suspended", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []SuspendedServiceInfo
+	if err := decodeData(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValidateSchedule calls POST /api/v1/schedules/validate: Validate a schedule.
+//
+// Runs the same validations as the schedule creation plus cluster-aware checks (namespaces exist, scheduleName is unique, no overlap with existing schedules, exclusions match at least one resource) and returns the errors and warnings found. Nothing is created.
+func (c *Client) ValidateSchedule(ctx context.Context, request ValidateScheduleRequest) (*ScheduleValidationResult, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/validate", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &ScheduleValidationResult{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetScheduleParams are the query parameters of GetSchedule.
+type GetScheduleParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso). Leave empty to get all namespaces
+	Namespace string
+}
+
+// GetSchedule calls GET /api/v1/schedules/{tenant}: Get schedule for tenant.
+//
+// Returns all SleepInfo configurations for a specific tenant, grouped by namespace. If namespace parameter is not provided, returns all namespaces. If namespace is provided (datastores, apps, rocket, intelligence, airflowsso), returns only that namespace.
+func (c *Client) GetSchedule(ctx context.Context, tenant string, params GetScheduleParams) (*ScheduleResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &ScheduleResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateSchedule calls PUT /api/v1/schedules/{tenant}: Update a schedule.
+//
+// Updates SleepInfo configurations for a tenant. Missing fields are extracted from existing schedule. At least 'off' or 'on' time must be provided.
+func (c *Client) UpdateSchedule(ctx context.Context, tenant string, request UpdateScheduleRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPut, "/api/v1/schedules/"+url.PathEscape(tenant), nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteScheduleParams are the query parameters of DeleteSchedule.
+type DeleteScheduleParams struct {
+	// Namespace suffix (optional)
+	Namespace string
+	// Schedule name (optional)
+	ScheduleName string
+}
+
+// DeleteSchedule calls DELETE /api/v1/schedules/{tenant}: Delete a schedule.
+//
+// Deletes SleepInfo configurations and associated secrets for a tenant. Optional filters: namespace, scheduleName.
+func (c *Client) DeleteSchedule(ctx context.Context, tenant string, params DeleteScheduleParams) (*APIResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodDelete, "/api/v1/schedules/"+url.PathEscape(tenant), query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetScheduleCalendarParams are the query parameters of GetScheduleCalendar.
+type GetScheduleCalendarParams struct {
+	// Namespace suffix filter
+	Namespace string
+	// Bearer token for calendar subscriptions
+	AccessToken string
+}
+
+// GetScheduleCalendar calls GET /api/v1/schedules/{tenant}/calendar.ics: Get the schedule calendar (iCal).
+//
+// Returns an iCalendar (RFC 5545) feed with weekly recurring events spanning from each sleep to the matching wake, in the user timezone, so teams can subscribe from Outlook or Google Calendar and know when their environments are off. Calendar clients that cannot send headers can pass the token as access_token query parameter.
+func (c *Client) GetScheduleCalendar(ctx context.Context, tenant string, params GetScheduleCalendarParams) (string, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.AccessToken != "" {
+		query.Set("access_token", params.AccessToken)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/calendar.ics", query, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// CloneSchedule calls POST /api/v1/schedules/{tenant}/clone: Clone schedules.
+//
+// Copies the schedules of a tenant (or a single namespace/schedule) to a set of target tenants and namespaces: sleep and wake times, weekdays, staggered wake delays and custom exclusions. Exclusions detected automatically in the source namespace are re-detected in each target, and label values naming the source namespace or tenant are rewritten. Each target is created independently and reported in the results.
+func (c *Client) CloneSchedule(ctx context.Context, tenant string, request CloneScheduleRequest) (*CloneScheduleResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/clone", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &CloneScheduleResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportScheduleParams are the query parameters of ExportSchedule.
+type ExportScheduleParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Output format: yaml or json
+	Format string
+	// Include the metadata of the associated secrets
+	IncludeSecrets bool
+}
+
+// ExportSchedule calls GET /api/v1/schedules/{tenant}/export: Export schedule manifests.
+//
+// Returns the SleepInfo manifests of a tenant without server-populated fields (status, uid, resourceVersion, managedFields), so they can be committed to Git or applied on another cluster. format=yaml (default) returns a multi-document YAML file; format=json returns the manifests inside the standard APIResponse. With includeSecrets=true the metadata of the sleepinfo-* Secrets is added (never their values).
+func (c *Client) ExportSchedule(ctx context.Context, tenant string, params ExportScheduleParams) (*ScheduleExport, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.Format != "" {
+		query.Set("format", params.Format)
+	}
+	if params.IncludeSecrets {
+		query.Set("includeSecrets", strconv.FormatBool(params.IncludeSecrets))
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/export", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &ScheduleExport{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ManualScheduleAction calls POST /api/v1/schedules/{tenant}/manual: Manual sleep/wake action.
+//
+// Triggers a manual sleep or wake operation without changing the schedule
+func (c *Client) ManualScheduleAction(ctx context.Context, tenant string, request ManualScheduleRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/manual", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNextOperationParams are the query parameters of GetNextOperation.
+type GetNextOperationParams struct {
+	// Number of occurrences per operation (1-50). Switches the response to NextOccurrencesResponse
+	Count int
+}
+
+// GetNextOperation calls GET /api/v1/schedules/{tenant}/next: Get next scheduled operation for tenant.
+//
+// Returns the next scheduled sleep or wake operation for a specific tenant. When count is set, returns instead the next count concrete executions of every sleep and wake operation (including each staggered wake step of datastores) in chronological order, in both cluster (UTC) and user timezone, so a UI can render a calendar.
+func (c *Client) GetNextOperation(ctx context.Context, tenant string, params GetNextOperationParams) (*NextOccurrencesResponse, error) {
+	query := url.Values{}
+	if params.Count != 0 {
+		query.Set("count", strconv.Itoa(params.Count))
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/next", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NextOccurrencesResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PauseScheduleParams are the query parameters of PauseSchedule.
+type PauseScheduleParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Only SleepInfos belonging to this schedule name
+	ScheduleName string
+}
+
+// PauseSchedule calls PUT /api/v1/schedules/{tenant}/pause: Pause a schedule.
+//
+// Pauses sleep/wake for a tenant (optionally a single namespace or schedule) until it is resumed, e.g. during an incident, by setting spec.suspend on its SleepInfos. SleepInfos, delays, exclusions, annotations and restore data are kept intact. Manual actions still work while paused.
+func (c *Client) PauseSchedule(ctx context.Context, tenant string, params PauseScheduleParams) (*APIResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodPut, "/api/v1/schedules/"+url.PathEscape(tenant)+"/pause", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSchedulePreviewParams are the query parameters of GetSchedulePreview.
+type GetSchedulePreviewParams struct {
+	// Number of days previewed (1-31, default 7)
+	Days int
+	// Namespace suffix to preview
+	Namespace string
+}
+
+// GetSchedulePreview calls GET /api/v1/schedules/{tenant}/preview: Preview the next days of a tenant schedule.
+//
+// Returns every sleep and wake operation planned for the tenant, or one of its namespaces, in the next days in chronological order, including each wake stage and each staggered wake step, in both cluster (UTC) and user timezone, so complex schedules can be checked before they fire. Paused SleepInfos are skipped, suspended ones start after the suspension deadline and holiday policies are not applied.
+func (c *Client) GetSchedulePreview(ctx context.Context, tenant string, params GetSchedulePreviewParams) (*SchedulePreviewResponse, error) {
+	query := url.Values{}
+	if params.Days != 0 {
+		query.Set("days", strconv.Itoa(params.Days))
+	}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/preview", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &SchedulePreviewResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ResumeScheduleParams are the query parameters of ResumeSchedule.
+type ResumeScheduleParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Only SleepInfos belonging to this schedule name
+	ScheduleName string
+}
+
+// ResumeSchedule calls PUT /api/v1/schedules/{tenant}/resume: Resume a paused schedule.
+//
+// Resumes sleep/wake for a tenant previously paused with PUT /api/v1/schedules/{tenant}/pause, clearing spec.suspend.
+func (c *Client) ResumeSchedule(ctx context.Context, tenant string, params ResumeScheduleParams) (*APIResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodPut, "/api/v1/schedules/"+url.PathEscape(tenant)+"/resume", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSavingsParams are the query parameters of GetSavings.
+type GetSavingsParams struct {
+	// Price of one CPU core per hour (overrides the server default)
+	PricePerCoreHour float64
+	// Price of one GiB of memory per hour (overrides the server default)
+	PricePerGBHour float64
+	// Currency code shown in the response
+	Currency string
+}
+
+// GetSavings calls GET /api/v1/schedules/{tenant}/savings: Estimate schedule savings.
+//
+// Estimates the CPU core-hours and memory GiB-hours saved during the next week: requested CPU/memory of the Deployments and StatefulSets covered by each namespace SleepInfos (exclusions honored, workloads asleep counted with their replicas before sleep) multiplied by the weekly sleep hours of the schedule. Paused SleepInfos are ignored. The cost is returned when a price is configured on the server or given in the query.
+func (c *Client) GetSavings(ctx context.Context, tenant string, params GetSavingsParams) (*SavingsResponse, error) {
+	query := url.Values{}
+	if params.PricePerCoreHour != 0 {
+		query.Set("pricePerCoreHour", strconv.FormatFloat(params.PricePerCoreHour, 'f', -1, 64))
+	}
+	if params.PricePerGBHour != 0 {
+		query.Set("pricePerGBHour", strconv.FormatFloat(params.PricePerGBHour, 'f', -1, 64))
+	}
+	if params.Currency != "" {
+		query.Set("currency", params.Currency)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/savings", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &SavingsResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SleepNowParams are the query parameters of SleepNow.
+type SleepNowParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Only SleepInfos belonging to this schedule name
+	ScheduleName string
+}
+
+// SleepNow calls POST /api/v1/schedules/{tenant}/sleep-now: Force sleep now.
+//
+// Immediately triggers the sleep operation for a tenant (or a single namespace) through the controller, using the same patches the scheduled sleep applies. Wake objects of staged pairs are not touched, so the next scheduled wake restores the services normally. The operation is recorded in status.lastManualOperation of each SleepInfo.
+func (c *Client) SleepNow(ctx context.Context, tenant string, params SleepNowParams) (*ManualOperationResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/sleep-now", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &ManualOperationResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SnoozeSchedule calls POST /api/v1/schedules/{tenant}/snooze: Snooze the next sleep.
+//
+// Delays only the next scheduled sleep of a tenant (or a single namespace/schedule) by a duration such as "2h", without changing the recurring schedule. The controller skips the scheduled sleep, runs it once the snooze ends and clears the snooze. Snoozing again extends a pending snooze. The delayed sleep must happen before the next wake.
+func (c *Client) SnoozeSchedule(ctx context.Context, tenant string, request SnoozeRequest) (*SnoozeResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/snooze", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &SnoozeResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SuspendSchedule calls POST /api/v1/schedules/{tenant}/suspend: Suspend a schedule temporarily.
+//
+// Sets spec.suspendScheduleUntil on matching SleepInfos. Cron sleep/wake triggers are skipped until the deadline. Manual actions still work.
+func (c *Client) SuspendSchedule(ctx context.Context, tenant string, request SuspendScheduleRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/suspend", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnsuspendScheduleParams are the query parameters of UnsuspendSchedule.
+type UnsuspendScheduleParams struct {
+	// Namespace suffix (optional)
+	Namespace string
+	// Schedule name (optional)
+	ScheduleName string
+}
+
+// UnsuspendSchedule calls DELETE /api/v1/schedules/{tenant}/suspend: Remove schedule suspension.
+//
+// Clears spec.suspendScheduleUntil on matching SleepInfos, resuming normal cron execution immediately.
+func (c *Client) UnsuspendSchedule(ctx context.Context, tenant string, params UnsuspendScheduleParams) (*APIResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodDelete, "/api/v1/schedules/"+url.PathEscape(tenant)+"/suspend", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetSuspendedServices calls GET /api/v1/schedules/{tenant}/suspended: Get suspended services for tenant.
+//
+// Returns the services (Deployments, StatefulSets, CronJobs and managed CRDs) of a tenant that are actually asleep: resources with restore data in the SleepInfo secrets whose live state still differs from it. Each entry reports since when it is asleep, the reason (scheduled or manual sleep) and when it will wake according to the paired wake SleepInfo
+func (c *Client) GetSuspendedServices(ctx context.Context, tenant string) (*SuspendedServicesResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/suspended", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &SuspendedServicesResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WakeNowParams are the query parameters of WakeNow.
+type WakeNowParams struct {
+	// Namespace suffix filter (datastores, apps, rocket, intelligence, airflowsso)
+	Namespace string
+	// Only SleepInfos belonging to this schedule name
+	ScheduleName string
+}
+
+// WakeNow calls POST /api/v1/schedules/{tenant}/wake-now: Force wake now.
+//
+// Immediately wakes all suspended resources of a tenant (or a single namespace), e.g. for an emergency debugging session at night. The controller restores the resources from the saved restore patches; staged datastores wakes keep their relative delays (Postgres/HDFS first, then PgBouncer, then Deployments). The last operation is recorded as WAKE_UP, so the next scheduled sleep still works.
+func (c *Client) WakeNow(ctx context.Context, tenant string, params WakeNowParams) (*ManualOperationResponse, error) {
+	query := url.Values{}
+	if params.Namespace != "" {
+		query.Set("namespace", params.Namespace)
+	}
+	if params.ScheduleName != "" {
+		query.Set("scheduleName", params.ScheduleName)
+	}
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/wake-now", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &ManualOperationResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWindowSchedule calls POST /api/v1/schedules/{tenant}/windows: Create a one-time window.
+//
+// Puts the selected namespaces of a tenant to sleep once between two dates, e.g. from 2024-12-24T18:00 to 2025-01-02T08:00, next to the recurring schedules. Dates are RFC3339 or YYYY-MM-DDTHH:MM in the user timezone. Resources are detected like for recurring schedules but all wake up at the end of the window, without staggering. The controller deletes the window SleepInfos once they have woken up.
+func (c *Client) CreateWindowSchedule(ctx context.Context, tenant string, request WindowScheduleRequest) (*WindowScheduleResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/schedules/"+url.PathEscape(tenant)+"/windows", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &WindowScheduleResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetNamespaceStatus calls GET /api/v1/schedules/{tenant}/{namespace}/status: Get reconcile status of a namespace.
+//
+// Returns, for every SleepInfo of the namespace, what the controller did with it: lastScheduleTime and lastOperation from its status, the last manual operation, whether the sleepinfo-<name> secret and its restore data exist, when the controller is expected to act next, and errors (invalid schedule, operations not recorded on time, warning events). Use it to tell whether the created SleepInfos are actually being processed.
+func (c *Client) GetNamespaceStatus(ctx context.Context, tenant string, namespace string) (*NamespaceStatusResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/schedules/"+url.PathEscape(tenant)+"/"+url.PathEscape(namespace)+"/status", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &NamespaceStatusResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListTenantsParams are the query parameters of ListTenants.
+type ListTenantsParams struct {
+	// Include the schedule aggregates of every tenant
+	Details bool
+}
+
+// ListTenants calls GET /api/v1/tenants: List all tenants.
+//
+// Discovers all tenants by scanning namespaces that match the pattern {tenant}-{suffix}. With details=true every tenant also includes its schedule aggregates: number of SleepInfos, which namespaces have a schedule, next sleep/wake and current power state.
+func (c *Client) ListTenants(ctx context.Context, params ListTenantsParams) (*TenantListResponse, error) {
+	query := url.Values{}
+	if params.Details {
+		query.Set("details", strconv.FormatBool(params.Details))
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/tenants", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &TenantListResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListTimezonesParams are the query parameters of ListTimezones.
+type ListTimezonesParams struct {
+	// Group the timezones by region
+	GroupBy string
+}
+
+// ListTimezones calls GET /api/v1/timezones: List timezones.
+//
+// Returns the IANA timezone names available in the container with their current UTC offset, so the frontend can populate its timezone picker. Use groupBy=region to group them by top-level area (America, Europe, ...)
+func (c *Client) ListTimezones(ctx context.Context, params ListTimezonesParams) (*TimezoneListResponse, error) {
+	query := url.Values{}
+	if params.GroupBy != "" {
+		query.Set("groupBy", params.GroupBy)
+	}
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/timezones", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &TimezoneListResponse{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListUsers calls GET /api/v1/users: List all users.
+//
+// Lists all users in the system. Requires admin role.
+func (c *Client) ListUsers(ctx context.Context) ([]UserInfo, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/users", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []UserInfo
+	if err := decodeData(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateUser calls POST /api/v1/users: Create a new user.
+//
+// Creates a new user with a specified username, password, and role. Requires admin role.
+func (c *Client) CreateUser(ctx context.Context, request CreateUserRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/users", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteUser calls DELETE /api/v1/users/{username}: Delete user.
+//
+// Deletes a user from the system. Requires admin role.
+func (c *Client) DeleteUser(ctx context.Context, username string) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodDelete, "/api/v1/users/"+url.PathEscape(username), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateUserPassword calls PUT /api/v1/users/{username}/password: Update user password.
+//
+// Updates a user's password. Requires admin role.
+func (c *Client) UpdateUserPassword(ctx context.Context, username string, request UpdatePasswordRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPut, "/api/v1/users/"+url.PathEscape(username)+"/password", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateUserRole calls PUT /api/v1/users/{username}/role: Update user role.
+//
+// Updates a user's role. Requires admin role.
+func (c *Client) UpdateUserRole(ctx context.Context, username string, request UpdateRoleRequest) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodPut, "/api/v1/users/"+url.PathEscape(username)+"/role", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListWebhooks calls GET /api/v1/webhooks: List webhook subscriptions.
+//
+// Lists the callback URLs and email recipients notified of the schedule lifecycle events. Signing secrets are never returned.
+func (c *Client) ListWebhooks(ctx context.Context) ([]WebhookSubscriptionInfo, error) {
+	body, err := c.call(ctx, http.MethodGet, "/api/v1/webhooks", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []WebhookSubscriptionInfo
+	if err := decodeData(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateWebhook calls POST /api/v1/webhooks: Register a webhook subscription.
+//
+// Registers a callback URL notified with a JSON POST on schedule created/updated/deleted and sleep/wake executed: the event, a Microsoft Teams Adaptive Card with format teams, or the body rendered by the Go template of the subscription with format template. With emails instead of url, the events are emailed to the recipients, or with digest a weekly digest of the sleeps, wake ups and estimated savings of the tenant. Deliveries are retried with exponential backoff on network errors, 429 and 5xx responses. Global subscriptions (without tenant) require admin role.
+func (c *Client) CreateWebhook(ctx context.Context, request WebhookSubscriptionRequest) (*WebhookSubscriptionInfo, error) {
+	body, err := c.call(ctx, http.MethodPost, "/api/v1/webhooks", nil, request)
+	if err != nil {
+		return nil, err
+	}
+	result := &WebhookSubscriptionInfo{}
+	if err := decodeData(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteWebhook calls DELETE /api/v1/webhooks/{id}: Delete a webhook subscription.
+//
+// Removes a webhook subscription by ID
+func (c *Client) DeleteWebhook(ctx context.Context, id string) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodDelete, "/api/v1/webhooks/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Health calls GET /health: Health check endpoint.
+//
+// Returns the health status of the API server
+func (c *Client) Health(ctx context.Context) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/health", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Ready calls GET /ready: Readiness check endpoint.
+//
+// Returns the readiness status of the API server
+func (c *Client) Ready(ctx context.Context) (*APIResponse, error) {
+	body, err := c.call(ctx, http.MethodGet, "/ready", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &APIResponse{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUIConfig calls GET /ui-config: Get UI configuration.
+//
+// Returns environment-specific UI configuration (colors, labels) read from env vars ENV_NAME, ENV_COLOR, ENV_LABEL
+func (c *Client) GetUIConfig(ctx context.Context) (map[string]string, error) {
+	body, err := c.call(ctx, http.MethodGet, "/ui-config", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]string
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}